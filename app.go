@@ -2,6 +2,11 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"mergen/database"
 
@@ -16,27 +21,129 @@ type App struct {
 	db      *database.Manager
 	storage *database.Storage
 	updater *database.Updater
+	locker  *database.Locker
+
+	// Runs scheduled exports while the app is open
+	scheduler *database.ExportScheduler
+	sheets    *database.GoogleSheets
+
+	// Pending clear of a sensitive value placed on the clipboard
+	clipboardMu    sync.Mutex
+	clipboardTimer *time.Timer
+	clipboardText  string
+
+	// Outcome of the launch check of the local data files
+	healthMu    sync.Mutex
+	storeHealth []database.StoreHealth
+
+	// Set once in-flight operations have been dealt with and the window may close
+	quitting atomic.Bool
 }
 
+// shutdownTimeout bounds how long quitting waits for cancelled operations to
+// roll back and clean up
+const shutdownTimeout = 10 * time.Second
+
+// Choices offered when quitting with operations in flight
+const (
+	quitCancel = "Cancel and Quit"
+	quitWait   = "Wait"
+	quitStay   = "Don't Quit"
+)
+
 // NewApp creates a new App application struct
 func NewApp() *App {
 	storage, _ := database.NewStorage()
-	return &App{
+	a := &App{
 		db:      database.NewManager(),
 		storage: storage,
 		updater: database.NewUpdater(),
 	}
+	// Set corrupted data files aside before anything reads or rewrites them
+	if storage != nil {
+		a.storeHealth = storage.CheckStores()
+		if history, err := storage.OpenHistory(); err != nil {
+			a.storeHealth = database.HistoryFailed(a.storeHealth, err)
+		} else {
+			a.db.SetHistory(history)
+		}
+	}
+	a.locker = database.NewLocker(storage, a.onLock)
+	a.sheets = database.NewGoogleSheets(storage)
+	a.scheduler = database.NewExportScheduler(storage, a.managerFor, func(event string, data interface{}) {
+		runtime.EventsEmit(a.ctx, event, data)
+	})
+	return a
 }
 
 // startup is called when the app starts
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 	a.updater.SetContext(ctx)
+	a.db.SetContext(ctx)
+	a.locker.SetContext(ctx)
+	a.scheduler.Start()
+}
+
+// beforeClose is called when the window is about to close. With queries,
+// transfers or load tests in flight, it asks whether to cancel them or to wait
+// for them to finish before quitting.
+func (a *App) beforeClose(ctx context.Context) (prevent bool) {
+	if a.quitting.Load() {
+		return false
+	}
+	ops := a.db.ActiveOperations()
+	if len(ops) == 0 {
+		return false
+	}
+
+	lines := make([]string, len(ops))
+	for i, op := range ops {
+		desc := []rune(op.Description)
+		if len(desc) > 80 {
+			desc = append(desc[:77], []rune("...")...)
+		}
+		lines[i] = fmt.Sprintf("%s: %s", op.Kind, string(desc))
+	}
+	choice, err := runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
+		Type:          runtime.QuestionDialog,
+		Title:         "Operations in progress",
+		Message:       fmt.Sprintf("%d operation(s) are still running:\n\n%s\n\nCancelled imports are rolled back and partial exports are removed.", len(ops), strings.Join(lines, "\n")),
+		Buttons:       []string{quitCancel, quitWait, quitStay},
+		DefaultButton: quitWait,
+		CancelButton:  quitStay,
+	})
+	if err != nil {
+		// shutdown still cancels whatever is left
+		return false
+	}
+
+	switch choice {
+	case quitCancel, "Yes":
+		a.db.CancelOperations()
+		a.db.WaitOperations(shutdownTimeout)
+		return false
+	case quitWait:
+		go a.quitWhenIdle()
+		return true
+	default:
+		return true
+	}
+}
+
+// quitWhenIdle closes the app once every operation in flight has finished
+func (a *App) quitWhenIdle() {
+	runtime.EventsEmit(a.ctx, "app:quit-pending", a.db.ActiveOperations())
+	a.db.WaitOperations(0)
+	a.quitting.Store(true)
+	runtime.Quit(a.ctx)
 }
 
 // shutdown is called when the app quits
 func (a *App) shutdown(ctx context.Context) {
-	a.db.Disconnect()
+	a.clearClipboardNow()
+	a.scheduler.Stop()
+	a.db.Shutdown(shutdownTimeout)
 }
 
 // ====================
@@ -45,6 +152,9 @@ func (a *App) shutdown(ctx context.Context) {
 
 // Connect establishes a connection to MySQL
 func (a *App) Connect(config database.ConnectionConfig) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
 	return a.db.Connect(config)
 }
 
@@ -53,16 +163,30 @@ func (a *App) Disconnect() error {
 	return a.db.Disconnect()
 }
 
-// TestConnection tests if a connection can be established
-func (a *App) TestConnection(config database.ConnectionConfig) (bool, error) {
+// TestConnection tests if a connection can be established and reports diagnostics
+func (a *App) TestConnection(config database.ConnectionConfig) (*database.ConnectionDiagnostics, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
 	return a.db.TestConnection(config)
 }
 
+// GetConnectionStrings renders a profile as URI, psql/mysql CLI, JDBC and Go
+// connection strings with the password masked
+func (a *App) GetConnectionStrings(config database.ConnectionConfig) (*database.ConnectionStrings, error) {
+	return a.db.GetConnectionStrings(config)
+}
+
 // IsConnected returns whether we're connected to a database
 func (a *App) IsConnected() bool {
 	return a.db.IsConnected()
 }
 
+// DiscoverLocalServers scans local ports and Docker containers for database servers
+func (a *App) DiscoverLocalServers() ([]database.DiscoveredServer, error) {
+	return database.DiscoverLocalServers()
+}
+
 // ====================
 // Query Methods
 // ====================
@@ -77,6 +201,117 @@ func (a *App) ExecuteStatement(query string) (*database.ExecuteResult, error) {
 	return a.db.ExecuteStatement(query)
 }
 
+// ExecuteSQL runs the statements typed in the editor one after another,
+// returning every result set with column metadata, affected rows and timing
+func (a *App) ExecuteSQL(query string, opts database.SQLOptions) (*database.SQLResult, error) {
+	return a.db.ExecuteSQL(query, opts)
+}
+
+// FormatSQL pretty-prints SQL in the dialect of the current connection, or
+// the one given in opts
+func (a *App) FormatSQL(query string, opts database.FormatOptions) (string, error) {
+	return a.db.FormatSQL(query, opts)
+}
+
+// DetectParameters lists the placeholders of a script to prompt values for
+func (a *App) DetectParameters(query string) []database.Placeholder {
+	return a.db.DetectParameters(query)
+}
+
+// BeginTransaction opens a transaction for a query tab on a connection of its own
+func (a *App) BeginTransaction(tabID string) (*database.TransactionState, error) {
+	return a.db.BeginTransaction(tabID)
+}
+
+// CommitTransaction commits the open transaction of a query tab
+func (a *App) CommitTransaction(tabID string) (*database.TransactionState, error) {
+	return a.db.CommitTransaction(tabID)
+}
+
+// RollbackTransaction rolls back the open transaction of a query tab
+func (a *App) RollbackTransaction(tabID string) (*database.TransactionState, error) {
+	return a.db.RollbackTransaction(tabID)
+}
+
+// GetTransactionState returns the transaction status of a query tab
+func (a *App) GetTransactionState(tabID string) database.TransactionState {
+	return a.db.GetTransactionState(tabID)
+}
+
+// CloseQueryTab rolls back the transaction of a tab being closed
+func (a *App) CloseQueryTab(tabID string) error {
+	return a.db.CloseQueryTab(tabID)
+}
+
+// GetCompletionMetadata returns the tables, columns, functions and keywords the
+// SQL editor completes against; with since set, only what changed
+func (a *App) GetCompletionMetadata(dbName string, since int64) (*database.CompletionMetadata, error) {
+	return a.db.GetCompletionMetadata(dbName, since)
+}
+
+// ExplainQuery returns the plan of a statement as a tree with costs, row
+// estimates and, with ANALYZE, actual rows and timing
+func (a *App) ExplainQuery(query string, opts database.ExplainOptions) (*database.ExplainResult, error) {
+	return a.db.ExplainQuery(query, opts)
+}
+
+// AnalyzeStatement returns the planning and execution times of a query, or
+// nil for statements that change data
+func (a *App) AnalyzeStatement(query string, params map[string]interface{}) (*database.QueryStats, error) {
+	return a.db.AnalyzeStatement(query, params)
+}
+
+// OpenStream runs a query whose rows are then read in chunks with FetchStream
+func (a *App) OpenStream(query string, fetchSize int, params map[string]interface{}) (*database.StreamInfo, error) {
+	return a.db.OpenStream(query, fetchSize, params)
+}
+
+// FetchStream reads the next chunk of rows of a stream
+func (a *App) FetchStream(id int64) (*database.StreamChunk, error) {
+	return a.db.FetchStream(id)
+}
+
+// CloseStream closes a stream before it has been read to the end
+func (a *App) CloseStream(id int64) error {
+	return a.db.CloseStream(id)
+}
+
+// BenchmarkQuery runs a query repeatedly and reports latency statistics
+func (a *App) BenchmarkQuery(query string, opts database.BenchmarkOptions) (*database.BenchmarkResult, error) {
+	return a.db.BenchmarkQuery(query, opts)
+}
+
+// StartLoadTest runs a concurrent synthetic workload in the background; progress
+// is emitted as "loadtest:progress" and "loadtest:done" events
+func (a *App) StartLoadTest(opts database.LoadTestOptions) error {
+	return a.db.StartLoadTest(opts)
+}
+
+// StopLoadTest cancels the running load test
+func (a *App) StopLoadTest() error {
+	return a.db.StopLoadTest()
+}
+
+// GetLoadTestStatus returns the progress of the current or last load test
+func (a *App) GetLoadTestStatus() (*database.LoadTestStatus, error) {
+	return a.db.GetLoadTestStatus()
+}
+
+// GetActivityLog returns the statements issued on the connection after the entry with ID afterID
+func (a *App) GetActivityLog(afterID int64) []database.ActivityEntry {
+	return a.db.GetActivityLog(afterID)
+}
+
+// ClearActivityLog empties the connection's activity log
+func (a *App) ClearActivityLog() {
+	a.db.ClearActivityLog()
+}
+
+// GetInterceptors lists the query interceptors active on the connection, in execution order
+func (a *App) GetInterceptors() []string {
+	return a.db.Interceptors()
+}
+
 // ====================
 // Schema Methods
 // ====================
@@ -86,19 +321,124 @@ func (a *App) GetDatabases() ([]database.DatabaseInfo, error) {
 	return a.db.GetDatabases()
 }
 
+// GetSchemas returns the schemas of the current database (PostgreSQL only)
+func (a *App) GetSchemas() ([]string, error) {
+	return a.db.GetSchemas()
+}
+
+// GetSchema returns the schema the table tree and queries use (PostgreSQL only)
+func (a *App) GetSchema() (string, error) {
+	return a.db.GetSchema()
+}
+
 // GetTables returns list of tables in a database
-func (a *App) GetTables(dbName string) ([]database.TableInfo, error) {
-	return a.db.GetTables(dbName)
+func (a *App) GetTables(dbName, schema string) ([]database.TableInfo, error) {
+	return a.db.GetTables(dbName, schema)
+}
+
+// GetViews returns the views of a database with their definitions
+func (a *App) GetViews(dbName string) ([]database.ViewInfo, error) {
+	return a.db.GetViews(dbName)
+}
+
+// GetMaterializedViews returns the materialized views of a database
+func (a *App) GetMaterializedViews(dbName string) ([]database.MaterializedViewInfo, error) {
+	return a.db.GetMaterializedViews(dbName)
+}
+
+// GetForeignServers lists the foreign servers and their options (PostgreSQL only)
+func (a *App) GetForeignServers() ([]database.ForeignServerInfo, error) {
+	return a.db.GetForeignServers()
+}
+
+// GetForeignTables lists the foreign tables with their server and options (PostgreSQL only)
+func (a *App) GetForeignTables(dbName string) ([]database.ForeignTableInfo, error) {
+	return a.db.GetForeignTables(dbName)
+}
+
+// RefreshMaterializedView recomputes a materialized view, optionally without blocking readers
+func (a *App) RefreshMaterializedView(dbName, view string, concurrently bool) error {
+	return a.db.RefreshMaterializedView(dbName, view, concurrently)
+}
+
+// GetSequences returns the sequences (or AUTO_INCREMENT counters) of a database
+func (a *App) GetSequences(dbName string) ([]database.SequenceInfo, error) {
+	return a.db.GetSequences(dbName)
+}
+
+// RestartSequence sets the next value a sequence hands out
+func (a *App) RestartSequence(dbName, sequence, value string) error {
+	return a.db.RestartSequence(dbName, sequence, value)
+}
+
+// AlterSequence changes the increment, bounds or cycling of a sequence
+func (a *App) AlterSequence(dbName, sequence string, alteration database.SequenceAlteration) error {
+	return a.db.AlterSequence(dbName, sequence, alteration)
+}
+
+// GetTriggers returns the triggers defined on a table
+func (a *App) GetTriggers(dbName, schema, table string) ([]database.TriggerInfo, error) {
+	return a.db.GetTriggers(dbName, schema, table)
+}
+
+// SetTriggerEnabled enables or disables a trigger
+func (a *App) SetTriggerEnabled(dbName, schema, table, trigger string, enabled bool) error {
+	return a.db.SetTriggerEnabled(dbName, schema, table, trigger, enabled)
+}
+
+// DropTrigger removes a trigger from a table
+func (a *App) DropTrigger(dbName, schema, table, trigger string) error {
+	return a.db.DropTrigger(dbName, schema, table, trigger)
+}
+
+// GetRoutines returns the stored functions and procedures of a database
+func (a *App) GetRoutines(dbName string) ([]database.RoutineInfo, error) {
+	return a.db.GetRoutines(dbName)
+}
+
+// ExecuteRoutine calls a function or procedure and captures its result sets and OUT values
+func (a *App) ExecuteRoutine(dbName string, routine database.RoutineInfo, args []interface{}) (*database.RoutineResult, error) {
+	return a.db.ExecuteRoutine(dbName, routine, args)
+}
+
+// GetCustomTypes returns user-defined enum, composite, domain and range types (PostgreSQL only)
+func (a *App) GetCustomTypes() ([]database.CustomTypeInfo, error) {
+	return a.db.GetCustomTypes()
+}
+
+// AddEnumValue adds a label to an enum type, optionally before or after an existing one
+func (a *App) AddEnumValue(typeName, value, before, after string) error {
+	return a.db.AddEnumValue(typeName, value, before, after)
 }
 
 // GetColumns returns list of columns in a table
-func (a *App) GetColumns(dbName, table string) ([]database.ColumnInfo, error) {
-	return a.db.GetColumns(dbName, table)
+func (a *App) GetColumns(dbName, schema, table string) ([]database.ColumnInfo, error) {
+	return a.db.GetColumns(dbName, schema, table)
 }
 
 // GetTableInfo returns detailed information about a table
-func (a *App) GetTableInfo(dbName, table string) (*database.TableDetails, error) {
-	return a.db.GetTableInfo(dbName, table)
+func (a *App) GetTableInfo(dbName, schema, table string) (*database.TableDetails, error) {
+	return a.db.GetTableInfo(dbName, schema, table)
+}
+
+// GetForeignKeys returns the foreign keys declared on a table
+func (a *App) GetForeignKeys(dbName, schema, table string) ([]database.ForeignKeyInfo, error) {
+	return a.db.GetForeignKeys(dbName, schema, table)
+}
+
+// SearchSchema finds tables, views, columns and routines by name across all schemas
+func (a *App) SearchSchema(pattern string, limit int) ([]database.SchemaSearchResult, error) {
+	return a.db.SearchSchema(pattern, limit)
+}
+
+// GetTableDDL returns the CREATE statement of a table with its indexes and comments
+func (a *App) GetTableDDL(dbName, schema, table string) (string, error) {
+	return a.db.GetTableDDL(dbName, schema, table)
+}
+
+// GetConstraints returns the CHECK and UNIQUE constraints of a table
+func (a *App) GetConstraints(dbName, schema, table string) ([]database.ConstraintInfo, error) {
+	return a.db.GetConstraints(dbName, schema, table)
 }
 
 // UseDatabase switches to a specific database
@@ -106,82 +446,542 @@ func (a *App) UseDatabase(dbName string) error {
 	return a.db.UseDatabase(dbName)
 }
 
+// ====================
+// Saved Query Methods
+// ====================
+
+// LoadSavedQueries returns the saved queries offered on a saved connection,
+// or all of them when connection is empty
+func (a *App) LoadSavedQueries(connection string) ([]database.SavedQuery, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.storage.LoadSavedQueries(connection)
+}
+
+// SaveQuery creates or updates a saved query and returns it as stored
+func (a *App) SaveQuery(query database.SavedQuery) (*database.SavedQuery, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.storage.SaveQuery(query)
+}
+
+// DeleteSavedQuery removes a saved query
+func (a *App) DeleteSavedQuery(id string) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.storage.DeleteSavedQuery(id)
+}
+
+// MoveSavedQueries moves or renames a folder of saved queries
+func (a *App) MoveSavedQueries(from, to string) (int, error) {
+	if err := a.unlocked(); err != nil {
+		return 0, err
+	}
+	return a.storage.MoveSavedQueries(from, to)
+}
+
+// RememberQueryValues keeps the parameter values a saved query was last run with
+func (a *App) RememberQueryValues(id string, values map[string]interface{}) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.storage.RememberQueryValues(id, values)
+}
+
+// ====================
+// Query History Methods
+// ====================
+
+// SearchHistory returns the statements run from the app matching filter, newest first
+func (a *App) SearchHistory(filter database.HistoryFilter) ([]database.HistoryEntry, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.db.SearchHistory(filter)
+}
+
+// RerunHistory runs a statement from the history again on the current connection
+func (a *App) RerunHistory(id int64) (*database.SQLResult, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.db.RerunHistory(id)
+}
+
+// PruneHistory removes statements from the history and returns how many were removed
+func (a *App) PruneHistory(opts database.HistoryPrune) (int64, error) {
+	if err := a.unlocked(); err != nil {
+		return 0, err
+	}
+	return a.db.PruneHistory(opts)
+}
+
 // ====================
 // Storage Methods
 // ====================
 
 // SaveConnection saves a connection with a name
 func (a *App) SaveConnection(name string, config database.ConnectionConfig) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
 	return a.storage.SaveConnection(name, config)
 }
 
 // LoadConnections loads all saved connections
 func (a *App) LoadConnections() ([]database.SavedConnection, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
 	return a.storage.LoadConnections()
 }
 
 // DeleteConnection removes a saved connection
 func (a *App) DeleteConnection(name string) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
 	return a.storage.DeleteConnection(name)
 }
 
 // RenameConnection renames a saved connection
 func (a *App) RenameConnection(oldName, newName string) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
 	return a.storage.RenameConnection(oldName, newName)
 }
 
 // UpdateConnection updates an existing saved connection
 func (a *App) UpdateConnection(name string, config database.ConnectionConfig) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
 	return a.storage.SaveConnection(name, config)
 }
 
+// SaveColumnFormatters persists the display formatters of a table view
+func (a *App) SaveColumnFormatters(connection, dbName, table string, formatters []database.ColumnFormatter) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.storage.SaveColumnFormatters(connection, dbName, table, formatters)
+}
+
+// LoadColumnFormatters returns the display formatters of a table view
+func (a *App) LoadColumnFormatters(connection, dbName, table string) ([]database.ColumnFormatter, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.storage.LoadColumnFormatters(connection, dbName, table)
+}
+
+// LoadTranslationMaps returns the workspace's column value translation maps
+func (a *App) LoadTranslationMaps() ([]database.TranslationMap, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.storage.LoadTranslationMaps()
+}
+
+// SaveTranslationMap creates or replaces a translation map
+func (a *App) SaveTranslationMap(m database.TranslationMap) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.storage.SaveTranslationMap(m)
+}
+
+// DeleteTranslationMap removes a translation map
+func (a *App) DeleteTranslationMap(name string) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.storage.DeleteTranslationMap(name)
+}
+
+// GetColumnTranslations returns the translation labels matching a table's columns
+func (a *App) GetColumnTranslations(dbName, schema, table string) (map[string]map[string]string, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	columns, err := a.db.GetColumns(dbName, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	maps, err := a.storage.LoadTranslationMaps()
+	if err != nil {
+		return nil, err
+	}
+	return database.MatchTranslations(columns, maps), nil
+}
+
+// GetStoreHealth reports the launch check of the local data files; quarantined
+// ones can be restored with RecoverStore
+func (a *App) GetStoreHealth() []database.StoreHealth {
+	a.healthMu.Lock()
+	defer a.healthMu.Unlock()
+	return a.storeHealth
+}
+
+// RecoverStore restores the readable entries of a quarantined data file
+func (a *App) RecoverStore(name string) (*database.StoreHealth, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+
+	health, err := a.storage.RecoverStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	a.healthMu.Lock()
+	defer a.healthMu.Unlock()
+	for i, h := range a.storeHealth {
+		if h.Name == name {
+			a.storeHealth[i] = *health
+		}
+	}
+	return health, nil
+}
+
 // ====================
 // CRUD Methods
 // ====================
 
 // GetTableData returns paginated table data
 func (a *App) GetTableData(req database.TableDataRequest) (*database.TableDataResponse, error) {
-	return a.db.GetTableData(req)
+	resp, err := a.db.GetTableData(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Labels are best-effort; a broken translations file shouldn't hide the data
+	if maps, err := a.storage.LoadTranslationMaps(); err == nil {
+		resp.Translations = database.MatchTranslations(resp.Columns, maps)
+	}
+	return resp, nil
 }
 
 // InsertRow inserts a new row into a table
-func (a *App) InsertRow(dbName, table string, data map[string]interface{}) (*database.ExecuteResult, error) {
-	return a.db.InsertRow(dbName, table, data)
+func (a *App) InsertRow(dbName, schema, table string, data map[string]interface{}) (*database.ExecuteResult, error) {
+	return a.db.InsertRow(dbName, schema, table, data)
+}
+
+// PreviewInsertRow returns the INSERT that InsertRow would run
+func (a *App) PreviewInsertRow(dbName, schema, table string, data map[string]interface{}) (*database.SQLPreview, error) {
+	return a.db.PreviewInsertRow(dbName, schema, table, data)
 }
 
 // UpdateRow updates a row by primary key
-func (a *App) UpdateRow(dbName, table, primaryKey string, primaryValue interface{}, data map[string]interface{}) (*database.ExecuteResult, error) {
-	return a.db.UpdateRow(dbName, table, primaryKey, primaryValue, data)
+func (a *App) UpdateRow(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, data map[string]interface{}) (*database.ExecuteResult, error) {
+	return a.db.UpdateRow(dbName, schema, table, primaryKey, primaryValues, data)
+}
+
+// PreviewUpdateRow returns the UPDATE that UpdateRow would run
+func (a *App) PreviewUpdateRow(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, data map[string]interface{}) (*database.SQLPreview, error) {
+	return a.db.PreviewUpdateRow(dbName, schema, table, primaryKey, primaryValues, data)
+}
+
+// GetCellValue fetches the full, untruncated value of a cell
+func (a *App) GetCellValue(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, column string) (*database.CellValue, error) {
+	return a.db.GetCellValue(dbName, schema, table, primaryKey, primaryValues, column)
+}
+
+// PreviewCellUpdate returns a diff of a cell's content and the UPDATE saving it would run
+func (a *App) PreviewCellUpdate(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, column, newValue string) (*database.CellUpdatePreview, error) {
+	return a.db.PreviewCellUpdate(dbName, schema, table, primaryKey, primaryValues, column, newValue)
+}
+
+// SaveCellValue writes a cell, skipping JSON documents that are unchanged
+func (a *App) SaveCellValue(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, column, newValue string) (*database.ExecuteResult, error) {
+	return a.db.SaveCellValue(dbName, schema, table, primaryKey, primaryValues, column, newValue)
+}
+
+// DetectFormat reports embedded JSON, XML, images, JWTs or URLs inside a text value
+func (a *App) DetectFormat(value string) *database.FormatHint {
+	return database.DetectFormat(value)
+}
+
+// ValidateJSON checks a JSON document and returns it pretty-printed
+func (a *App) ValidateJSON(text string) *database.JSONValidation {
+	return database.ValidateJSON(text)
+}
+
+// GetBinaryCell fetches a binary cell as a hex dump with its detected type and preview
+func (a *App) GetBinaryCell(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, column string) (*database.BinaryValue, error) {
+	return a.db.GetBinaryCell(dbName, schema, table, primaryKey, primaryValues, column)
+}
+
+// SelectCellDownloadPath opens a save dialog for the content of a binary cell
+func (a *App) SelectCellDownloadPath(column string) (string, error) {
+	return runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Save Cell Content",
+		DefaultFilename: column + ".bin",
+	})
+}
+
+// DownloadCell writes the content of a binary cell to a file
+func (a *App) DownloadCell(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, column, outputPath string) error {
+	return a.db.DownloadCell(dbName, schema, table, primaryKey, primaryValues, column, outputPath)
+}
+
+// SelectCellUploadFile opens a file dialog to choose the new content of a binary cell
+func (a *App) SelectCellUploadFile() (string, error) {
+	return runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Upload File Into Cell",
+	})
+}
+
+// UploadCell stores the content of a file in a binary cell
+func (a *App) UploadCell(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, column, path string) (*database.ExecuteResult, error) {
+	return a.db.UploadCell(dbName, schema, table, primaryKey, primaryValues, column, path)
 }
 
 // DeleteRow deletes a row by primary key
-func (a *App) DeleteRow(dbName, table, primaryKey string, primaryValue interface{}) (*database.ExecuteResult, error) {
-	return a.db.DeleteRow(dbName, table, primaryKey, primaryValue)
+func (a *App) DeleteRow(dbName, schema, table string, primaryKey []string, primaryValues []interface{}) (*database.ExecuteResult, error) {
+	return a.db.DeleteRow(dbName, schema, table, primaryKey, primaryValues)
+}
+
+// PreviewDeleteRow returns the DELETE that DeleteRow would run
+func (a *App) PreviewDeleteRow(dbName, schema, table string, primaryKey []string, primaryValues []interface{}) (*database.SQLPreview, error) {
+	return a.db.PreviewDeleteRow(dbName, schema, table, primaryKey, primaryValues)
 }
 
 // DeleteRows deletes multiple rows by primary key values
-func (a *App) DeleteRows(dbName, table, primaryKey string, primaryValues []interface{}) (*database.ExecuteResult, error) {
-	return a.db.DeleteRows(dbName, table, primaryKey, primaryValues)
+func (a *App) DeleteRows(dbName, schema, table string, primaryKey []string, primaryValues [][]interface{}) (*database.ExecuteResult, error) {
+	return a.db.DeleteRows(dbName, schema, table, primaryKey, primaryValues)
+}
+
+// PreviewDeleteRows returns the DELETE that DeleteRows would run
+func (a *App) PreviewDeleteRows(dbName, schema, table string, primaryKey []string, primaryValues [][]interface{}) (*database.SQLPreview, error) {
+	return a.db.PreviewDeleteRows(dbName, schema, table, primaryKey, primaryValues)
+}
+
+// DuplicateRow inserts a copy of a row, with overrides for some of its columns
+func (a *App) DuplicateRow(dbName, schema, table string, primaryKey []string, primaryValues []interface{}, overrides map[string]interface{}) (*database.ExecuteResult, error) {
+	return a.db.DuplicateRow(dbName, schema, table, primaryKey, primaryValues, overrides)
+}
+
+// DuplicateRowValues returns the values DuplicateRow would copy from a row
+func (a *App) DuplicateRowValues(dbName, schema, table string, primaryKey []string, primaryValues []interface{}) (map[string]interface{}, error) {
+	return a.db.DuplicateRowValues(dbName, schema, table, primaryKey, primaryValues)
+}
+
+// BulkUpdateRows updates every row matching the filters with one UPDATE
+func (a *App) BulkUpdateRows(dbName, schema, table string, update database.BulkUpdate) (*database.ExecuteResult, error) {
+	return a.db.BulkUpdateRows(dbName, schema, table, update)
+}
+
+// PreviewBulkUpdate returns the UPDATE that BulkUpdateRows would run and the rows it matches
+func (a *App) PreviewBulkUpdate(dbName, schema, table string, update database.BulkUpdate) (*database.BulkPreview, error) {
+	return a.db.PreviewBulkUpdate(dbName, schema, table, update)
+}
+
+// BulkDeleteRows deletes every row matching the filters, confirmed by a PreviewBulkDelete token
+func (a *App) BulkDeleteRows(dbName, schema, table string, request database.BulkDelete) (*database.ExecuteResult, error) {
+	return a.db.BulkDeleteRows(dbName, schema, table, request)
+}
+
+// PreviewBulkDelete returns the DELETE that BulkDeleteRows would run, the rows it matches and its token
+func (a *App) PreviewBulkDelete(dbName, schema, table string, request database.BulkDelete) (*database.BulkPreview, error) {
+	return a.db.PreviewBulkDelete(dbName, schema, table, request)
+}
+
+// UpdateRowByLocator updates a row of a table without a primary key
+func (a *App) UpdateRowByLocator(dbName, schema, table string, locator database.RowLocator, data map[string]interface{}) (*database.ExecuteResult, error) {
+	return a.db.UpdateRowByLocator(dbName, schema, table, locator, data)
+}
+
+// PreviewUpdateRowByLocator returns the UPDATE that UpdateRowByLocator would run
+func (a *App) PreviewUpdateRowByLocator(dbName, schema, table string, locator database.RowLocator, data map[string]interface{}) (*database.SQLPreview, error) {
+	return a.db.PreviewUpdateRowByLocator(dbName, schema, table, locator, data)
+}
+
+// DeleteRowByLocator deletes a row of a table without a primary key
+func (a *App) DeleteRowByLocator(dbName, schema, table string, locator database.RowLocator) (*database.ExecuteResult, error) {
+	return a.db.DeleteRowByLocator(dbName, schema, table, locator)
+}
+
+// PreviewDeleteRowByLocator returns the DELETE that DeleteRowByLocator would run
+func (a *App) PreviewDeleteRowByLocator(dbName, schema, table string, locator database.RowLocator) (*database.SQLPreview, error) {
+	return a.db.PreviewDeleteRowByLocator(dbName, schema, table, locator)
+}
+
+// StageChange queues an insert, update or delete of a table to be applied with the others
+func (a *App) StageChange(dbName, schema, table string, change database.StagedChange) (*database.ChangeSet, error) {
+	return a.db.StageChange(dbName, schema, table, change)
+}
+
+// UnstageChange drops a staged change of a table
+func (a *App) UnstageChange(dbName, schema, table string, id int64) (*database.ChangeSet, error) {
+	return a.db.UnstageChange(dbName, schema, table, id)
+}
+
+// GetChangeSet returns the staged changes of a table
+func (a *App) GetChangeSet(dbName, schema, table string) *database.ChangeSet {
+	return a.db.GetChangeSet(dbName, schema, table)
+}
+
+// DiscardChangeSet drops every staged change of a table
+func (a *App) DiscardChangeSet(dbName, schema, table string) {
+	a.db.DiscardChangeSet(dbName, schema, table)
+}
+
+// PreviewChangeSet returns the statements ApplyChangeSet would run
+func (a *App) PreviewChangeSet(dbName, schema, table string) (*database.SQLPreview, error) {
+	return a.db.PreviewChangeSet(dbName, schema, table)
+}
+
+// ApplyChangeSet runs the staged changes of a table in a single transaction
+func (a *App) ApplyChangeSet(dbName, schema, table string) (*database.ChangeSetResult, error) {
+	return a.db.ApplyChangeSet(dbName, schema, table)
+}
+
+// GetUndoLog returns the grid changes that can be undone, newest first
+func (a *App) GetUndoLog() []database.UndoEntry {
+	return a.db.GetUndoLog()
+}
+
+// PreviewUndo returns the statements that would revert the last grid change
+func (a *App) PreviewUndo() (*database.SQLPreview, error) {
+	return a.db.PreviewUndo()
+}
+
+// UndoLastChange reverts the last grid change
+func (a *App) UndoLastChange() (*database.UndoEntry, error) {
+	return a.db.UndoLastChange()
 }
 
 // GetDistinctValues returns distinct values for a column to support frontend auto-completion
-func (a *App) GetDistinctValues(dbName, table, column string) ([]string, error) {
-	return a.db.GetDistinctValues(dbName, table, column)
+func (a *App) GetDistinctValues(dbName, schema, table, column string) ([]string, error) {
+	return a.db.GetDistinctValues(dbName, schema, table, column)
 }
 
 // AlterTable performs schema modifications on a table
-func (a *App) AlterTable(dbName, table string, alteration database.TableAlteration) error {
-	return a.db.AlterTable(dbName, table, alteration)
+func (a *App) AlterTable(dbName, schema, table string, alteration database.TableAlteration) error {
+	return a.db.AlterTable(dbName, schema, table, alteration)
+}
+
+// PreviewAlterTable returns the statements AlterTable would run
+func (a *App) PreviewAlterTable(dbName, schema, table string, alteration database.TableAlteration) (*database.SQLPreview, error) {
+	return a.db.PreviewAlterTable(dbName, schema, table, alteration)
 }
 
 // TruncateTable removes all rows from a table
-func (a *App) TruncateTable(dbName, table string) error {
-	return a.db.TruncateTable(dbName, table)
+func (a *App) TruncateTable(dbName, schema, table string) error {
+	return a.db.TruncateTable(dbName, schema, table)
+}
+
+// PreviewTruncateTable returns the statement TruncateTable would run
+func (a *App) PreviewTruncateTable(dbName, schema, table string) (*database.SQLPreview, error) {
+	return a.db.PreviewTruncateTable(dbName, schema, table)
 }
 
 // DropTable deletes a table
-func (a *App) DropTable(dbName, table string) error {
-	return a.db.DropTable(dbName, table)
+func (a *App) DropTable(dbName, schema, table string) error {
+	return a.db.DropTable(dbName, schema, table)
+}
+
+// PreviewDropTable returns the statement DropTable would run
+func (a *App) PreviewDropTable(dbName, schema, table string) (*database.SQLPreview, error) {
+	return a.db.PreviewDropTable(dbName, schema, table)
+}
+
+// SetTableComment sets the comment of a table; an empty comment removes it
+func (a *App) SetTableComment(dbName, schema, table, comment string) error {
+	return a.db.SetTableComment(dbName, schema, table, comment)
+}
+
+// SetColumnComment sets the comment of a column; an empty comment removes it
+func (a *App) SetColumnComment(dbName, schema, table, column, comment string) error {
+	return a.db.SetColumnComment(dbName, schema, table, column, comment)
+}
+
+// ====================
+// Clipboard Methods
+// ====================
+
+// CopyCells formats the selected cells by column type in the chosen format and
+// places them on the clipboard
+func (a *App) CopyCells(columns []database.ColumnInfo, rows [][]interface{}, opts database.CopyOptions) (string, error) {
+	text, err := a.db.FormatCopy(columns, rows, opts)
+	if err != nil {
+		return "", err
+	}
+	if err := runtime.ClipboardSetText(a.ctx, text); err != nil {
+		return "", err
+	}
+	a.scheduleClipboardClear(text, opts.ClearAfterSec)
+	return text, nil
+}
+
+// FormatCellsHTML renders the selected cells as an HTML table. The frontend puts
+// it on the clipboard as text/html, which the Wails clipboard can't carry.
+func (a *App) FormatCellsHTML(columns []database.ColumnInfo, rows [][]interface{}, opts database.CopyOptions) (string, error) {
+	return database.FormatCellsHTML(columns, rows, opts)
+}
+
+// GenerateStatements writes rows of a table as INSERT or UPDATE statements to paste elsewhere
+func (a *App) GenerateStatements(dbName, schema, table string, columns []string, rows [][]interface{}, opts database.StatementOptions) (string, error) {
+	return a.db.GenerateStatements(dbName, schema, table, columns, rows, opts)
+}
+
+// ReadClipboard returns the text on the clipboard, e.g. cells to paste into a table
+func (a *App) ReadClipboard() (string, error) {
+	return runtime.ClipboardGetText(a.ctx)
+}
+
+// CopySecret places a sensitive value such as a password on the clipboard and
+// clears it after clearAfterSec seconds; 0 leaves it in place
+func (a *App) CopySecret(text string, clearAfterSec int) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	if err := runtime.ClipboardSetText(a.ctx, text); err != nil {
+		return err
+	}
+	a.scheduleClipboardClear(text, clearAfterSec)
+	return nil
+}
+
+// scheduleClipboardClear replaces any pending clear with one for text. The
+// clipboard is only cleared if it still holds text, so later copies survive.
+func (a *App) scheduleClipboardClear(text string, afterSec int) {
+	a.clipboardMu.Lock()
+	defer a.clipboardMu.Unlock()
+
+	if a.clipboardTimer != nil {
+		a.clipboardTimer.Stop()
+		a.clipboardTimer = nil
+		a.clipboardText = ""
+	}
+	if afterSec <= 0 {
+		return
+	}
+
+	a.clipboardText = text
+	a.clipboardTimer = time.AfterFunc(time.Duration(afterSec)*time.Second, a.clearClipboardNow)
+}
+
+// clearClipboardNow empties the clipboard if it still holds the pending secret
+func (a *App) clearClipboardNow() {
+	a.clipboardMu.Lock()
+	defer a.clipboardMu.Unlock()
+
+	if a.clipboardTimer == nil {
+		return
+	}
+	a.clipboardTimer.Stop()
+	a.clipboardTimer = nil
+
+	if current, err := runtime.ClipboardGetText(a.ctx); err == nil && current == a.clipboardText {
+		runtime.ClipboardSetText(a.ctx, "")
+	}
+	a.clipboardText = ""
 }
 
 // ====================
@@ -250,6 +1050,15 @@ func (a *App) SelectExportPath(format string) (string, error) {
 	case "json":
 		filters = []runtime.FileFilter{{DisplayName: "JSON File (*.json)", Pattern: "*.json"}}
 		defaultExt = "*.json"
+	case "sql":
+		filters = []runtime.FileFilter{{DisplayName: "SQL Script (*.sql)", Pattern: "*.sql"}}
+		defaultExt = "*.sql"
+	case "markdown":
+		filters = []runtime.FileFilter{{DisplayName: "Markdown File (*.md)", Pattern: "*.md"}}
+		defaultExt = "*.md"
+	case "html":
+		filters = []runtime.FileFilter{{DisplayName: "HTML File (*.html)", Pattern: "*.html"}}
+		defaultExt = "*.html"
 	}
 
 	return runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
@@ -260,6 +1069,468 @@ func (a *App) SelectExportPath(format string) (string, error) {
 }
 
 // ExportTable exports the table data to a file
-func (a *App) ExportTable(dbName, tableName, format, outputPath string) error {
-	return a.db.ExportTable(dbName, tableName, format, outputPath)
+func (a *App) ExportTable(dbName, schema, tableName, format, outputPath string) error {
+	return a.db.ExportTable(dbName, schema, tableName, format, outputPath)
+}
+
+// ExportTableCSV streams the table data to a CSV file
+func (a *App) ExportTableCSV(dbName, schema, tableName, outputPath string, opts database.CSVExportOptions) error {
+	return a.db.ExportTableCSV(dbName, schema, tableName, outputPath, opts)
+}
+
+// ExportQueryCSV streams the result of a query to a CSV file
+func (a *App) ExportQueryCSV(query, outputPath string, opts database.CSVExportOptions) error {
+	return a.db.ExportQueryCSV(query, outputPath, opts)
+}
+
+// ExportResultsXLSX writes result sets to an Excel workbook, one sheet each
+func (a *App) ExportResultsXLSX(results []database.QueryResult, outputPath string) error {
+	return a.db.ExportResultsXLSX(results, outputPath)
+}
+
+// ExportResultsMarkup writes result sets to a Markdown or HTML file of tables
+func (a *App) ExportResultsMarkup(results []database.QueryResult, format, outputPath string) error {
+	return a.db.ExportResultsMarkup(results, format, outputPath)
+}
+
+// DumpTables writes tables to a SQL script of CREATE TABLE and INSERT statements
+func (a *App) DumpTables(dbName, outputPath string, opts database.DumpOptions) (*database.DumpResult, error) {
+	return a.db.DumpTables(dbName, outputPath, opts)
+}
+
+// ====================
+// Import Methods
+// ====================
+
+// SelectImportFile opens a file dialog for the user to choose a file to import
+func (a *App) SelectImportFile() (string, error) {
+	return runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import Data",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "JSON Files (*.json, *.ndjson, *.jsonl)", Pattern: "*.json;*.ndjson;*.jsonl"},
+			{DisplayName: "Parquet Files (*.parquet)", Pattern: "*.parquet"},
+			{DisplayName: "CSV Files (*.csv)", Pattern: "*.csv"},
+		},
+	})
+}
+
+// PreviewImport parses a file and returns the inferred schema and sample rows
+func (a *App) PreviewImport(path string, opts database.ImportOptions) (*database.ImportPreview, error) {
+	return a.db.PreviewImport(path, opts)
+}
+
+// ImportFile loads a file into a table
+func (a *App) ImportFile(dbName, schema, tableName, path string, opts database.ImportOptions) (*database.ImportResult, error) {
+	return a.db.ImportFile(dbName, schema, tableName, path, opts)
+}
+
+// PreviewPaste returns pasted tab or comma separated rows as they would be inserted into a table
+func (a *App) PreviewPaste(dbName, schema, tableName, text string, opts database.PasteOptions) (*database.PastePreview, error) {
+	return a.db.PreviewPaste(dbName, schema, tableName, text, opts)
+}
+
+// PasteRows inserts pasted rows into a table in a single transaction
+func (a *App) PasteRows(dbName, schema, tableName, text string, opts database.PasteOptions) (*database.ImportResult, error) {
+	return a.db.PasteRows(dbName, schema, tableName, text, opts)
+}
+
+// ====================
+// Backup Methods
+// ====================
+
+// DetectBackupTools finds the client programs backups of the connected server use
+func (a *App) DetectBackupTools() *database.BackupTools {
+	return a.db.DetectBackupTools()
+}
+
+// Backup backs up a database with pg_dump or mysqldump into the backups directory
+func (a *App) Backup(dbName string, opts database.BackupOptions) (*database.BackupResult, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	path, err := a.storage.NewBackupPath(dbName, a.db.BackupExtension(opts))
+	if err != nil {
+		return nil, err
+	}
+	return a.db.Backup(dbName, path, opts)
+}
+
+// SelectBackupFile opens a file dialog for the user to choose a backup to restore
+func (a *App) SelectBackupFile() (string, error) {
+	return runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Restore Backup",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Backups (*.dump, *.backup, *.tar, *.sql, *.sql.gz)", Pattern: "*.dump;*.backup;*.tar;*.sql;*.gz"},
+			{DisplayName: "All Files", Pattern: "*"},
+		},
+	})
+}
+
+// Restore loads a backup into a database with pg_restore, psql or mysql
+func (a *App) Restore(dbName, path string, opts database.RestoreOptions) error {
+	return a.db.Restore(dbName, path, opts)
+}
+
+// ListBackups returns the backups in the backups directory, newest first
+func (a *App) ListBackups() ([]database.BackupFile, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.storage.ListBackups()
+}
+
+// DeleteBackup removes a backup from the backups directory
+func (a *App) DeleteBackup(name string) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.storage.DeleteBackup(name)
+}
+
+// ====================
+// Compare Methods
+// ====================
+
+// CompareTables hashes two tables in primary key chunks and reports mismatching ranges
+func (a *App) CompareTables(req database.TableCompareRequest) (*database.TableCompareResult, error) {
+	source, closeSource, err := a.managerFor(req.Source.Connection)
+	if err != nil {
+		return nil, err
+	}
+	defer closeSource()
+
+	target, closeTarget, err := a.managerFor(req.Target.Connection)
+	if err != nil {
+		return nil, err
+	}
+	defer closeTarget()
+
+	return database.CompareTables(source, target, req)
+}
+
+// managerFor returns the active manager for an empty name, or a temporary one
+// connected to the named saved connection along with a func that closes it
+func (a *App) managerFor(connection string) (*database.Manager, func(), error) {
+	if connection == "" {
+		return a.db, func() {}, nil
+	}
+	if a.locker.IsLocked() {
+		return nil, nil, database.ErrLocked
+	}
+
+	saved, err := a.storage.GetConnection(connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := database.NewManager()
+	if err := m.Connect(saved.Config); err != nil {
+		return nil, nil, err
+	}
+	return m, func() { m.Disconnect() }, nil
+}
+
+// ====================
+// Table Copy Methods
+// ====================
+
+// StartTableCopy copies a table to another connection in the background
+func (a *App) StartTableCopy(req database.TableCopyRequest) (*database.TableCopy, error) {
+	return a.db.StartTableCopy(req, a.managerFor)
+}
+
+// ResumeTableCopy resumes a failed or cancelled table copy after its last committed row
+func (a *App) ResumeTableCopy(id int64) (*database.TableCopy, error) {
+	return a.db.ResumeTableCopy(id, a.managerFor)
+}
+
+// ListTableCopies returns the table copies of the session
+func (a *App) ListTableCopies() []database.TableCopy {
+	return a.db.ListTableCopies()
+}
+
+// CancelTableCopy stops a running table copy
+func (a *App) CancelTableCopy(id int64) error {
+	return a.db.CancelTableCopy(id)
+}
+
+// ClearTableCopies forgets the finished table copies
+func (a *App) ClearTableCopies() int {
+	return a.db.ClearTableCopies()
+}
+
+// ====================
+// Google Sheets Methods
+// ====================
+
+// GetGoogleAccount reports whether an OAuth client is set and which account is signed in
+func (a *App) GetGoogleAccount() (*database.GoogleAccount, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.sheets.Account()
+}
+
+// SetGoogleClient sets the OAuth client used to sign in to Google
+func (a *App) SetGoogleClient(clientID, clientSecret string) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.sheets.SetClient(clientID, clientSecret)
+}
+
+// SignInGoogle opens the Google consent page in the browser and waits for the sign-in
+func (a *App) SignInGoogle() (*database.GoogleAccount, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.sheets.SignIn(func(url string) {
+		runtime.BrowserOpenURL(a.ctx, url)
+	})
+}
+
+// SignOutGoogle revokes and forgets the Google token
+func (a *App) SignOutGoogle() error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.sheets.SignOut()
+}
+
+// ExportToGoogleSheet writes a result set to a new or existing Google Sheet
+func (a *App) ExportToGoogleSheet(result database.QueryResult, req database.GoogleSheetExport) (*database.GoogleSheetResult, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.sheets.Export(result, req)
+}
+
+// ====================
+// Scheduled Export Methods
+// ====================
+
+// ListExportSchedules returns the scheduled exports with their next and last runs
+func (a *App) ListExportSchedules() ([]database.ExportScheduleStatus, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.scheduler.List()
+}
+
+// SaveExportSchedule creates or updates a scheduled export
+func (a *App) SaveExportSchedule(schedule database.ExportSchedule) (*database.ExportSchedule, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.storage.SaveExportSchedule(schedule)
+}
+
+// DeleteExportSchedule removes a scheduled export and its run history
+func (a *App) DeleteExportSchedule(id string) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.storage.DeleteExportSchedule(id)
+}
+
+// RunExportSchedule runs a scheduled export now in the background
+func (a *App) RunExportSchedule(id string) error {
+	if err := a.unlocked(); err != nil {
+		return err
+	}
+	return a.scheduler.RunNow(id)
+}
+
+// GetExportRuns returns the recent runs of a scheduled export, newest first
+func (a *App) GetExportRuns(id string) ([]database.ExportRun, error) {
+	if err := a.unlocked(); err != nil {
+		return nil, err
+	}
+	return a.storage.ExportRuns(id)
+}
+
+// SelectExportDirectory opens a dialog for the user to choose where scheduled exports are written
+func (a *App) SelectExportDirectory() (string, error) {
+	return runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:                "Export Directory",
+		CanCreateDirectories: true,
+	})
+}
+
+// ====================
+// Server Status Methods
+// ====================
+
+// GetWALArchiveStatus reports WAL archiving and replication slot health (PostgreSQL only)
+func (a *App) GetWALArchiveStatus() (*database.WALArchiveStatus, error) {
+	return a.db.GetWALArchiveStatus()
+}
+
+// GetExtensions lists available and installed extensions (PostgreSQL only)
+func (a *App) GetExtensions() ([]database.ExtensionInfo, error) {
+	return a.db.GetExtensions()
+}
+
+// CreateExtension installs an extension, optionally into a schema and with its dependencies
+func (a *App) CreateExtension(name, schema string, cascade bool) error {
+	return a.db.CreateExtension(name, schema, cascade)
+}
+
+// DropExtension removes an extension
+func (a *App) DropExtension(name string, cascade bool) error {
+	return a.db.DropExtension(name, cascade)
+}
+
+// ====================
+// Privilege Methods
+// ====================
+
+// GetRoles lists the roles (PostgreSQL) or accounts (MySQL) of the server
+func (a *App) GetRoles() ([]database.RoleInfo, error) {
+	return a.db.GetRoles()
+}
+
+// GetTablePrivileges lists who holds which privileges on a table
+func (a *App) GetTablePrivileges(dbName, schema, table string) ([]database.TablePrivilege, error) {
+	return a.db.GetTablePrivileges(dbName, schema, table)
+}
+
+// PreviewPrivilegeChange returns the GRANT or REVOKE statement without running it
+func (a *App) PreviewPrivilegeChange(dbName string, change database.PrivilegeChange) (string, error) {
+	return a.db.PreviewPrivilegeChange(dbName, change)
+}
+
+// ApplyPrivilegeChange runs a GRANT or REVOKE
+func (a *App) ApplyPrivilegeChange(dbName string, change database.PrivilegeChange) error {
+	return a.db.ApplyPrivilegeChange(dbName, change)
+}
+
+// ====================
+// Operation Methods
+// ====================
+
+// GetActiveOperations lists the queries, transfers and load tests in flight
+func (a *App) GetActiveOperations() []database.Operation {
+	return a.db.ActiveOperations()
+}
+
+// CancelOperations aborts every operation in flight, rolling back open transactions
+func (a *App) CancelOperations() {
+	a.db.CancelOperations()
+}
+
+// CancelOperation stops a single operation, on the server as well
+func (a *App) CancelOperation(id int64) error {
+	return a.db.CancelOperation(id)
+}
+
+// ====================
+// Background Job Methods
+// ====================
+
+// StartJob runs a script in the background, independent of any query tab
+func (a *App) StartJob(query string, opts database.SQLOptions) (*database.Job, error) {
+	return a.db.StartJob(query, opts)
+}
+
+// ListJobs returns the background jobs of the session, newest first
+func (a *App) ListJobs() []database.Job {
+	return a.db.ListJobs()
+}
+
+// GetJobResult returns the results of a finished background job
+func (a *App) GetJobResult(id int64) (*database.SQLResult, error) {
+	return a.db.GetJobResult(id)
+}
+
+// CancelJob stops a running background job
+func (a *App) CancelJob(id int64) error {
+	return a.db.CancelJob(id)
+}
+
+// ClearJobs forgets the finished background jobs
+func (a *App) ClearJobs() int {
+	return a.db.ClearJobs()
+}
+
+// ====================
+// Notification Methods
+// ====================
+
+// Listen subscribes to a PostgreSQL notification channel; messages arrive as
+// "notify:message" events
+func (a *App) Listen(channel string) error {
+	return a.db.Listen(channel)
+}
+
+// Unlisten unsubscribes from a notification channel
+func (a *App) Unlisten(channel string) error {
+	return a.db.Unlisten(channel)
+}
+
+// ListeningChannels returns the notification channels subscribed to
+func (a *App) ListeningChannels() []string {
+	return a.db.ListeningChannels()
+}
+
+// Notify sends a notification on a channel
+func (a *App) Notify(channel, payload string) error {
+	return a.db.Notify(channel, payload)
+}
+
+// ====================
+// Lock Methods
+// ====================
+
+// GetLockStatus returns whether the app is locked and the lock settings
+func (a *App) GetLockStatus() (*database.LockStatus, error) {
+	return a.locker.Status()
+}
+
+// Lock disconnects, clears pending clipboard secrets and requires the lock
+// passphrase to resume
+func (a *App) Lock() error {
+	return a.locker.Lock()
+}
+
+// Unlock resumes a locked app
+func (a *App) Unlock(passphrase string) error {
+	return a.locker.Unlock(passphrase)
+}
+
+// ResetLock sets damaged lock settings aside and unlocks the app, returning
+// where the damaged file was moved
+func (a *App) ResetLock() (string, error) {
+	return a.locker.Reset()
+}
+
+// SetLockPassphrase changes the lock passphrase; an empty one disables locking
+func (a *App) SetLockPassphrase(current, passphrase string) error {
+	return a.locker.SetPassphrase(current, passphrase)
+}
+
+// SetIdleLockTimeout locks the app after minutes without activity; 0 disables it
+func (a *App) SetIdleLockTimeout(minutes int) error {
+	return a.locker.SetIdleTimeout(minutes)
+}
+
+// ReportActivity postpones the idle lock; the frontend calls it on user input
+func (a *App) ReportActivity() {
+	a.locker.Touch()
+}
+
+// unlocked returns ErrLocked while the app is locked, for bindings that read
+// or change the local data files
+func (a *App) unlocked() error {
+	if a.locker.IsLocked() {
+		return database.ErrLocked
+	}
+	return nil
+}
+
+// onLock drops the active connection, including the credentials its driver
+// holds, and any secret still waiting on the clipboard
+func (a *App) onLock() {
+	a.clearClipboardNow()
+	a.db.Disconnect()
 }
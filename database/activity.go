@@ -0,0 +1,93 @@
+package database
+
+import (
+	"sync"
+	"time"
+)
+
+// activityLogSize is the number of statements kept per connection
+const activityLogSize = 1000
+
+// ActivityEntry is a statement the app issued on the connection
+type ActivityEntry struct {
+	ID         int64   `json:"id"`
+	Time       string  `json:"time"` // RFC 3339 with milliseconds
+	Query      string  `json:"query"`
+	Args       int     `json:"args"` // Number of bound parameters
+	DurationMs float64 `json:"durationMs"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// ActivityLog is a bounded, in-memory log of executed statements
+type ActivityLog struct {
+	mu      sync.Mutex
+	entries []ActivityEntry
+	nextID  int64
+}
+
+// NewActivityLog creates an empty activity log
+func NewActivityLog() *ActivityLog {
+	return &ActivityLog{nextID: 1}
+}
+
+// record appends a statement, evicting the oldest entries once the log is full
+func (l *ActivityLog) record(query string, args int, start time.Time, err error) {
+	entry := ActivityEntry{
+		Time:       start.Format("2006-01-02T15:04:05.000Z07:00"),
+		Query:      redactSecrets(query),
+		Args:       args,
+		DurationMs: durationMs(time.Since(start)),
+	}
+	if err != nil {
+		entry.Error = redactSecrets(err.Error())
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry.ID = l.nextID
+	l.nextID++
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > activityLogSize {
+		l.entries = append([]ActivityEntry(nil), l.entries[len(l.entries)-activityLogSize:]...)
+	}
+}
+
+// Since returns the entries with an ID greater than afterID, oldest first
+func (l *ActivityLog) Since(afterID int64) []ActivityEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := []ActivityEntry{}
+	for _, e := range l.entries {
+		if e.ID > afterID {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Clear removes all entries
+func (l *ActivityLog) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = nil
+}
+
+// intercept records each statement with its duration and error
+func (l *ActivityLog) intercept(stmt *Statement, next func(*Statement) error) error {
+	start := time.Now()
+	err := next(stmt)
+	l.record(stmt.Query, len(stmt.Args), start, err)
+	return err
+}
+
+// GetActivityLog returns the statements issued after the entry with ID afterID
+func (m *Manager) GetActivityLog(afterID int64) []ActivityEntry {
+	return m.activity.Since(afterID)
+}
+
+// ClearActivityLog empties the activity log
+func (m *Manager) ClearActivityLog() {
+	m.activity.Clear()
+}
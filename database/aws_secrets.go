@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+const awsSecretTimeout = 15 * time.Second
+
+// awsSecret is the JSON layout used by RDS managed secrets and most hand-made ones
+type awsSecret struct {
+	Username string      `json:"username"`
+	Password string      `json:"password"`
+	Host     string      `json:"host"`
+	Port     json.Number `json:"port"`
+	DBName   string      `json:"dbname"`
+}
+
+// fetchAWSCredentials resolves username/password from Secrets Manager or SSM
+// Parameter Store using the default AWS credential chain
+func fetchAWSCredentials(config *ConnectionConfig) error {
+	if config.AWSSecretID == "" {
+		return fmt.Errorf("no AWS secret id provided")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), awsSecretTimeout)
+	defer cancel()
+
+	var opts []func(*awsconfig.LoadOptions) error
+	region := config.AWSRegion
+	if region == "" {
+		region = regionFromARN(config.AWSSecretID)
+	}
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	if config.AWSProfile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(config.AWSProfile))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	var value string
+	switch strings.ToLower(config.AWSSecretSource) {
+	case "ssm":
+		out, err := ssm.NewFromConfig(cfg).GetParameter(ctx, &ssm.GetParameterInput{
+			Name:           aws.String(config.AWSSecretID),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read SSM parameter: %w", err)
+		}
+		value = aws.ToString(out.Parameter.Value)
+	case "secretsmanager", "":
+		out, err := secretsmanager.NewFromConfig(cfg).GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(config.AWSSecretID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read secret: %w", err)
+		}
+		value = aws.ToString(out.SecretString)
+	default:
+		return fmt.Errorf("unsupported AWS secret source: %s", config.AWSSecretSource)
+	}
+
+	var secret awsSecret
+	if err := json.Unmarshal([]byte(value), &secret); err != nil {
+		// A plain string parameter holds just the password
+		config.Password = value
+		return nil
+	}
+
+	if secret.Username != "" {
+		config.User = secret.Username
+	}
+	config.Password = secret.Password
+
+	// RDS secrets also describe the endpoint; only fill what the profile leaves empty
+	if config.Host == "" {
+		config.Host = secret.Host
+	}
+	if config.Port == 0 {
+		if port, err := secret.Port.Int64(); err == nil {
+			config.Port = int(port)
+		}
+	}
+	if config.Database == "" {
+		config.Database = secret.DBName
+	}
+
+	return nil
+}
+
+// regionFromARN extracts the region from an ARN such as
+// arn:aws:secretsmanager:eu-west-1:123456789012:secret:name
+func regionFromARN(id string) string {
+	parts := strings.Split(id, ":")
+	if len(parts) > 3 && parts[0] == "arn" {
+		return parts[3]
+	}
+	return ""
+}
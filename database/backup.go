@@ -0,0 +1,563 @@
+package database
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BackupTools are the client programs backups and restores run: pg_dump,
+// pg_restore and psql for PostgreSQL, mysqldump and mysql for MySQL
+type BackupTools struct {
+	PgDump        string `json:"pgDump,omitempty"`
+	PgRestore     string `json:"pgRestore,omitempty"`
+	Psql          string `json:"psql,omitempty"`
+	Mysqldump     string `json:"mysqldump,omitempty"`
+	Mysql         string `json:"mysql,omitempty"`
+	Flavor        string `json:"flavor,omitempty"`        // MySQL or MariaDB, for the MySQL tools
+	Version       string `json:"version,omitempty"`       // e.g. 16.2
+	ServerVersion string `json:"serverVersion,omitempty"` // Of the connected server
+	Warning       string `json:"warning,omitempty"`       // Set when the tools may not suit the server
+}
+
+// BackupOptions controls how Backup runs pg_dump or mysqldump
+type BackupOptions struct {
+	Format      string   `json:"format,omitempty"`      // custom (default), plain, tar or directory; plain only on MySQL
+	Compression int      `json:"compression,omitempty"` // Level 1-9, 0 for the tool's default, -1 for none
+	Content     string   `json:"content,omitempty"`     // all (default), schema or data
+	Tables      []string `json:"tables,omitempty"`      // All tables when empty
+	NoOwner     bool     `json:"noOwner,omitempty"`     // Leave out ownership and privileges, PostgreSQL only
+	Jobs        int      `json:"jobs,omitempty"`        // Tables dumped in parallel, directory format only
+}
+
+// BackupResult describes a finished backup
+type BackupResult struct {
+	Path       string  `json:"path"`
+	Size       int64   `json:"size"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// RestoreOptions controls how Restore loads a backup. SQL script backups are
+// run whole by psql or mysql, so only ContinueOnError and, on PostgreSQL,
+// SingleTransaction apply.
+type RestoreOptions struct {
+	Clean             bool     `json:"clean,omitempty"`   // Drop objects before recreating them
+	Content           string   `json:"content,omitempty"` // all (default), schema or data
+	Tables            []string `json:"tables,omitempty"`  // All tables when empty
+	NoOwner           bool     `json:"noOwner,omitempty"`
+	Jobs              int      `json:"jobs,omitempty"` // Parallel jobs, not with SingleTransaction
+	SingleTransaction bool     `json:"singleTransaction,omitempty"`
+	ContinueOnError   bool     `json:"continueOnError,omitempty"` // Stops at the first error otherwise
+}
+
+// BackupProgress is a line reported by the running client program, or how
+// much of a SQL script has been read, emitted as "backup:progress" events
+type BackupProgress struct {
+	Operation  string `json:"operation"` // backup or restore
+	Line       string `json:"line,omitempty"`
+	Bytes      int64  `json:"bytes,omitempty"`
+	TotalBytes int64  `json:"totalBytes,omitempty"`
+	Done       bool   `json:"done"`
+}
+
+// backupToolDirs are where PostgreSQL client programs are usually installed,
+// as glob patterns by platform
+var backupToolDirs = map[string][]string{
+	"darwin": {
+		"/opt/homebrew/opt/libpq/bin",
+		"/opt/homebrew/opt/postgresql@*/bin",
+		"/usr/local/opt/libpq/bin",
+		"/usr/local/opt/postgresql@*/bin",
+		"/Applications/Postgres.app/Contents/Versions/*/bin",
+		"/Library/PostgreSQL/*/bin",
+	},
+	"linux": {
+		"/usr/lib/postgresql/*/bin",
+		"/usr/pgsql-*/bin",
+	},
+	"windows": {
+		`C:\Program Files\PostgreSQL\*\bin`,
+	},
+}
+
+var toolVersionPattern = regexp.MustCompile(`(\d+)(?:\.(\d+))?`)
+
+// DetectBackupTools finds the client programs for the connected server:
+// bundled with the app, on the PATH or in the usual install locations. The
+// newest pg_dump found is used, as it reads servers up to its own version.
+// Missing programs are left empty.
+func (m *Manager) DetectBackupTools() *BackupTools {
+	m.mu.RLock()
+	_, mysql := m.driver.(*MySQLDriver)
+	m.mu.RUnlock()
+	if mysql {
+		return m.detectMySQLTools()
+	}
+
+	tools := &BackupTools{}
+	best := -1
+	for _, dir := range toolDirs("pgtools", []string{"pg_dump"}, backupToolDirs) {
+		pgDump := findTool(dir, "pg_dump")
+		if pgDump == "" {
+			continue
+		}
+		version := toolVersionPattern.FindString(toolVersion(pgDump))
+		if major := majorVersion(version); major > best {
+			best = major
+			tools.PgDump, tools.Version = pgDump, version
+			tools.PgRestore, tools.Psql = findTool(dir, "pg_restore"), findTool(dir, "psql")
+		}
+	}
+
+	if db := m.getDB(); db != nil {
+		if err := db.QueryRow("SHOW server_version").Scan(&tools.ServerVersion); err == nil {
+			server := majorVersion(tools.ServerVersion)
+			if tools.PgDump != "" && best < server {
+				tools.Warning = fmt.Sprintf("pg_dump %s is older than the server (%s) and will refuse to back it up; install PostgreSQL %d client tools", tools.Version, tools.ServerVersion, server)
+			}
+		}
+	}
+	return tools
+}
+
+// toolDirs returns the directories to look for client programs in, in order
+// of preference: bundled next to the executable or in Resources of a macOS
+// app bundle, where the PATH finds one of names, then the install locations
+func toolDirs(bundled string, names []string, installed map[string][]string) []string {
+	var dirs []string
+	if self, err := os.Executable(); err == nil {
+		dir := filepath.Dir(self)
+		dirs = append(dirs, filepath.Join(dir, bundled), filepath.Join(dir, "..", "Resources", bundled))
+	}
+	for _, name := range names {
+		if path, err := exec.LookPath(toolName(name)); err == nil {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+	}
+	for _, pattern := range installed[runtime.GOOS] {
+		matches, _ := filepath.Glob(pattern)
+		dirs = append(dirs, matches...)
+	}
+	return dirs
+}
+
+// toolName adds the platform's executable extension
+func toolName(name string) string {
+	if runtime.GOOS == "windows" {
+		return name + ".exe"
+	}
+	return name
+}
+
+// findTool returns the path of a program in dir, or empty if it isn't there
+func findTool(dir, name string) string {
+	path := filepath.Join(dir, toolName(name))
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// toolVersion returns what a program prints for --version, e.g.
+// "pg_dump (PostgreSQL) 16.2"
+func toolVersion(path string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// majorVersion returns the major version of a version string, or -1
+func majorVersion(version string) int {
+	match := toolVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return -1
+	}
+	major, _ := strconv.Atoi(match[1])
+	return major
+}
+
+// backupConfig returns the configuration of the current connection and
+// whether it is to MySQL
+func (m *Manager) backupConfig() (*ConnectionConfig, bool, error) {
+	m.mu.RLock()
+	_, mysql := m.driver.(*MySQLDriver)
+	config := m.config
+	m.mu.RUnlock()
+	if config == nil {
+		return nil, false, fmt.Errorf("not connected to database")
+	}
+	return config, mysql, nil
+}
+
+// BackupExtension returns the file extension of a backup made with opts on
+// the current connection, empty for directory backups
+func (m *Manager) BackupExtension(opts BackupOptions) string {
+	m.mu.RLock()
+	_, mysql := m.driver.(*MySQLDriver)
+	m.mu.RUnlock()
+	switch {
+	case opts.Format == "directory":
+		return ""
+	case opts.Format == "tar":
+		return ".tar"
+	case opts.Format == "plain" || mysql:
+		if opts.Compression > 0 {
+			return ".sql.gz"
+		}
+		return ".sql"
+	}
+	return ".dump"
+}
+
+// Backup backs up a database to outputPath with pg_dump or mysqldump. The
+// path is a file or, for the directory format, a directory that must not
+// exist or be empty. Progress is emitted as "backup:progress" events and the
+// backup can be cancelled as an operation; a failed or cancelled backup
+// removes what it wrote.
+func (m *Manager) Backup(database, outputPath string, opts BackupOptions) (*BackupResult, error) {
+	config, mysql, err := m.backupConfig()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Compression > 9 {
+		return nil, fmt.Errorf("compression level must be between 1 and 9")
+	}
+	tools := m.DetectBackupTools()
+
+	run := func(ctx context.Context) error {
+		return m.backupMySQL(ctx, tools, *config, database, outputPath, opts)
+	}
+	if !mysql {
+		args, err := m.pgDumpArgs(tools, *config, database, outputPath, opts)
+		if err != nil {
+			return nil, err
+		}
+		run = func(ctx context.Context) error {
+			return m.runTool(ctx, "backup", tools.PgDump, args, pgToolEnv(*config), nil, nil)
+		}
+	}
+
+	ctx, done := m.track("backup", database)
+	defer done()
+	start := time.Now()
+	if err := run(ctx); err != nil {
+		os.RemoveAll(outputPath)
+		return nil, err
+	}
+	return &BackupResult{
+		Path:       outputPath,
+		Size:       pathSize(outputPath),
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}, nil
+}
+
+// pgDumpArgs returns the arguments of pg_dump for a backup
+func (m *Manager) pgDumpArgs(tools *BackupTools, config ConnectionConfig, database, outputPath string, opts BackupOptions) ([]string, error) {
+	if tools.PgDump == "" {
+		return nil, fmt.Errorf("pg_dump was not found; install the PostgreSQL client tools")
+	}
+
+	args := append(toolConnectionArgs(config, database), "--verbose", "--file="+outputPath)
+	format := opts.Format
+	switch format {
+	case "":
+		format = "custom"
+	case "custom", "plain", "tar", "directory":
+	default:
+		return nil, fmt.Errorf("unsupported backup format: %s", opts.Format)
+	}
+	args = append(args, "--format="+format)
+	switch {
+	case opts.Compression != 0 && format == "tar":
+		return nil, fmt.Errorf("tar backups cannot be compressed")
+	case opts.Compression != 0:
+		args = append(args, fmt.Sprintf("--compress=%d", max(opts.Compression, 0)))
+	}
+	contentArgs, err := toolContentArgs(opts.Content)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, contentArgs...)
+	if opts.NoOwner {
+		args = append(args, "--no-owner", "--no-privileges")
+	}
+	if opts.Jobs > 1 {
+		if format != "directory" {
+			return nil, fmt.Errorf("parallel backups need the directory format")
+		}
+		args = append(args, fmt.Sprintf("--jobs=%d", opts.Jobs))
+	}
+	for _, table := range opts.Tables {
+		args = append(args, "--table="+m.currentDriver().QualifiedName(database, table))
+	}
+
+	// Only what the backup creates is removed on failure
+	if format == "directory" {
+		if entries, err := os.ReadDir(outputPath); err == nil && len(entries) > 0 {
+			return nil, fmt.Errorf("backup directory %s is not empty", outputPath)
+		}
+	}
+	return args, nil
+}
+
+// Restore loads a backup into a database: custom, tar and directory backups
+// with pg_restore, SQL scripts, gzipped or not, with psql or mysql. Progress
+// is emitted as "backup:progress" events and the restore can be cancelled as
+// an operation.
+func (m *Manager) Restore(database, inputPath string, opts RestoreOptions) error {
+	config, mysql, err := m.backupConfig()
+	if err != nil {
+		return err
+	}
+	format, err := backupFormat(inputPath)
+	if err != nil {
+		return err
+	}
+	script := format == "plain" || format == "gzip"
+	if script && (opts.Clean || (opts.Content != "" && opts.Content != "all") || len(opts.Tables) > 0 || opts.NoOwner || opts.Jobs > 1) {
+		return fmt.Errorf("SQL script backups are restored whole; use a custom, tar or directory backup to choose what to restore")
+	}
+	if opts.Jobs > 1 && opts.SingleTransaction {
+		return fmt.Errorf("parallel restores cannot run in a single transaction")
+	}
+	tools := m.DetectBackupTools()
+
+	ctx, done := m.track("restore", database)
+	defer done()
+	if mysql {
+		return m.restoreMySQL(ctx, tools, *config, database, inputPath, format, opts)
+	}
+
+	args := toolConnectionArgs(*config, database)
+	if script {
+		if tools.Psql == "" {
+			return fmt.Errorf("psql was not found; install the PostgreSQL client tools")
+		}
+		if !opts.ContinueOnError {
+			args = append(args, "--set=ON_ERROR_STOP=1")
+		}
+		if opts.SingleTransaction {
+			args = append(args, "--single-transaction")
+		}
+		input, closeInput, err := m.openScript(inputPath, format)
+		if err != nil {
+			return err
+		}
+		defer closeInput()
+		return m.runTool(ctx, "restore", tools.Psql, args, pgToolEnv(*config), input, nil)
+	}
+
+	if tools.PgRestore == "" {
+		return fmt.Errorf("pg_restore was not found; install the PostgreSQL client tools")
+	}
+	args = append(args, "--verbose")
+	if opts.Clean {
+		args = append(args, "--clean", "--if-exists")
+	}
+	contentArgs, err := toolContentArgs(opts.Content)
+	if err != nil {
+		return err
+	}
+	args = append(args, contentArgs...)
+	if opts.NoOwner {
+		args = append(args, "--no-owner", "--no-privileges")
+	}
+	if opts.Jobs > 1 {
+		args = append(args, fmt.Sprintf("--jobs=%d", opts.Jobs))
+	}
+	if opts.SingleTransaction {
+		args = append(args, "--single-transaction")
+	}
+	if !opts.ContinueOnError {
+		args = append(args, "--exit-on-error")
+	}
+	for _, table := range opts.Tables {
+		args = append(args, "--table="+table)
+	}
+	args = append(args, inputPath)
+	return m.runTool(ctx, "restore", tools.PgRestore, args, pgToolEnv(*config), nil, nil)
+}
+
+// toolConnectionArgs returns the arguments that connect a PostgreSQL client
+// program to a database of the server. The password is passed in the
+// environment.
+func toolConnectionArgs(config ConnectionConfig, database string) []string {
+	if database == "" {
+		database = config.Database
+	}
+	return []string{
+		"--host=" + config.Host,
+		"--port=" + strconv.Itoa(config.Port),
+		"--username=" + config.User,
+		"--dbname=" + database,
+		"--no-password",
+	}
+}
+
+// pgToolEnv returns the environment PostgreSQL client programs connect with
+func pgToolEnv(config ConnectionConfig) []string {
+	// Connect doesn't negotiate TLS yet, so neither do the tools
+	return []string{"PGPASSWORD=" + config.Password, "PGSSLMODE=disable", "PGCONNECT_TIMEOUT=10"}
+}
+
+// toolContentArgs returns the arguments limiting a backup or restore to the
+// schema or the data
+func toolContentArgs(content string) ([]string, error) {
+	switch content {
+	case "", "all":
+		return nil, nil
+	case "schema":
+		return []string{"--schema-only"}, nil
+	case "data":
+		return []string{"--data-only"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported backup content: %s", content)
+	}
+}
+
+// runTool runs a client program, emitting each line it prints as a
+// "backup:progress" event. The program reads stdin and writes its output to
+// stdout when they are set. A failure carries the last lines it printed.
+func (m *Manager) runTool(ctx context.Context, operation, path string, args, env []string, stdin io.Reader, stdout io.Writer) error {
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Env = append(os.Environ(), env...)
+	pr, pw := io.Pipe()
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, pw, pw
+	if stdout != nil {
+		cmd.Stdout = stdout
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), ".exe")
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	waited := make(chan error, 1)
+	go func() {
+		waited <- cmd.Wait()
+		pw.Close()
+	}()
+
+	var tail []string
+	scanner := bufio.NewScanner(pr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := redactSecrets(scanner.Text())
+		if tail = append(tail, line); len(tail) > 5 {
+			tail = tail[1:]
+		}
+		m.emit("backup:progress", BackupProgress{Operation: operation, Line: line})
+	}
+	io.Copy(io.Discard, pr)
+	err := <-waited
+	m.emit("backup:progress", BackupProgress{Operation: operation, Done: true})
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("%s cancelled", operation)
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed: %w\n%s", name, err, strings.Join(tail, "\n"))
+	}
+	return nil
+}
+
+// openScript opens a SQL script backup for a client program to read,
+// decompressing it when gzipped and reporting how much of it has been read
+func (m *Manager) openScript(path, format string) (io.Reader, func(), error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	var total int64
+	if info, err := file.Stat(); err == nil {
+		total = info.Size()
+	}
+	var input io.Reader = &scriptProgress{r: file, m: m, total: total}
+	if format == "gzip" {
+		gz, err := gzip.NewReader(input)
+		if err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("failed to decompress backup: %w", err)
+		}
+		input = gz
+	}
+	return input, func() { file.Close() }, nil
+}
+
+// scriptProgress counts the bytes read of a SQL script being restored,
+// emitting "backup:progress" events at most every half second
+type scriptProgress struct {
+	r     io.Reader
+	m     *Manager
+	read  int64
+	total int64
+	last  time.Time
+}
+
+func (p *scriptProgress) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if time.Since(p.last) >= 500*time.Millisecond || err == io.EOF {
+		p.last = time.Now()
+		p.m.emit("backup:progress", BackupProgress{Operation: "restore", Bytes: p.read, TotalBytes: p.total})
+	}
+	return n, err
+}
+
+// backupFormat tells the format of a backup from its contents: custom, tar
+// or directory archives of pg_dump, or plain or gzip SQL scripts
+func backupFormat(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open backup: %w", err)
+	}
+	if info.IsDir() {
+		if _, err := os.Stat(filepath.Join(path, "toc.dat")); err != nil {
+			return "", fmt.Errorf("%s is not a directory backup", path)
+		}
+		return "directory", nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer file.Close()
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	head = head[:n]
+	switch {
+	case strings.HasPrefix(string(head), "PGDMP"):
+		return "custom", nil
+	case len(head) >= 262 && string(head[257:262]) == "ustar":
+		return "tar", nil
+	case len(head) >= 2 && head[0] == 0x1f && head[1] == 0x8b:
+		return "gzip", nil
+	}
+	return "plain", nil
+}
+
+// pathSize returns the size of a file, or of the files in a directory
+func pathSize(path string) int64 {
+	var size int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupsDir is the directory under the config directory backups are kept in
+const backupsDir = "backups"
+
+// BackupFile is a backup kept in the backups directory
+type BackupFile struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Format   string `json:"format"` // custom, plain, gzip, tar or directory
+	Size     int64  `json:"size"`
+	Modified string `json:"modified"` // RFC 3339
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// BackupsDir returns the directory backups are kept in, creating it if needed
+func (s *Storage) BackupsDir() (string, error) {
+	dir := s.dataPath(backupsDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create backups directory: %w", err)
+	}
+	return dir, nil
+}
+
+// NewBackupPath returns a path in the backups directory for a new backup of
+// a database, named after it and the time
+func (s *Storage) NewBackupPath(database, ext string) (string, error) {
+	dir, err := s.BackupsDir()
+	if err != nil {
+		return "", err
+	}
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(database, "_"), "_")
+	if name == "" {
+		name = "backup"
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s_%s%s", name, time.Now().Format("20060102-150405"), ext)), nil
+}
+
+// ListBackups returns the backups in the backups directory, newest first
+func (s *Storage) ListBackups() ([]BackupFile, error) {
+	dir, err := s.BackupsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backups directory: %w", err)
+	}
+
+	backups := []BackupFile{}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		format, err := backupFormat(path)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, BackupFile{
+			Name:     entry.Name(),
+			Path:     path,
+			Format:   format,
+			Size:     pathSize(path),
+			Modified: info.ModTime().Format(time.RFC3339),
+		})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Modified > backups[j].Modified })
+	return backups, nil
+}
+
+// DeleteBackup removes a backup from the backups directory
+func (s *Storage) DeleteBackup(name string) error {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return fmt.Errorf("invalid backup name: %s", name)
+	}
+	path := filepath.Join(s.dataPath(backupsDir), name)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("backup %s not found", name)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to delete backup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// mysqlToolDirs are where MySQL and MariaDB client programs are usually
+// installed, as glob patterns by platform
+var mysqlToolDirs = map[string][]string{
+	"darwin": {
+		"/opt/homebrew/opt/mysql-client/bin",
+		"/opt/homebrew/opt/mysql-client@*/bin",
+		"/opt/homebrew/opt/mysql/bin",
+		"/opt/homebrew/opt/mariadb/bin",
+		"/usr/local/opt/mysql-client/bin",
+		"/usr/local/mysql/bin",
+	},
+	"windows": {
+		`C:\Program Files\MySQL\MySQL Server *\bin`,
+		`C:\Program Files\MariaDB *\bin`,
+	},
+}
+
+// detectMySQLTools finds mysqldump and mysql, preferring the tools of the
+// server's own flavor: mysqldump of MySQL and of MariaDB each trip over the
+// other's system tables
+func (m *Manager) detectMySQLTools() *BackupTools {
+	tools := &BackupTools{}
+	if db := m.getDB(); db != nil {
+		db.QueryRow("SELECT VERSION()").Scan(&tools.ServerVersion)
+	}
+	serverMariaDB := strings.Contains(strings.ToLower(tools.ServerVersion), "mariadb")
+
+	best := -1
+	for _, dir := range toolDirs("mysqltools", []string{"mysqldump", "mariadb-dump"}, mysqlToolDirs) {
+		dump := findTool(dir, "mariadb-dump")
+		if dump == "" {
+			dump = findTool(dir, "mysqldump")
+		}
+		if dump == "" {
+			continue
+		}
+		version := toolVersion(dump)
+		mariadb := strings.Contains(strings.ToLower(version), "mariadb")
+		// Older tools report "Ver 10.13 Distrib 5.7.44" with the server version last
+		if i := strings.Index(version, "Distrib "); i >= 0 {
+			version = version[i:]
+		}
+		version = toolVersionPattern.FindString(version)
+		score := majorVersion(version)
+		if mariadb == serverMariaDB {
+			score += 1000
+		}
+		if score > best {
+			best = score
+			tools.Mysqldump, tools.Version, tools.Flavor = dump, version, "MySQL"
+			if mariadb {
+				tools.Flavor = "MariaDB"
+			}
+			if tools.Mysql = findTool(dir, "mariadb"); tools.Mysql == "" {
+				tools.Mysql = findTool(dir, "mysql")
+			}
+		}
+	}
+
+	if tools.Mysqldump != "" && tools.ServerVersion != "" && (tools.Flavor == "MariaDB") != serverMariaDB {
+		tools.Warning = fmt.Sprintf("mysqldump %s of %s may not back up this server (%s) completely; install its own client tools", tools.Version, tools.Flavor, tools.ServerVersion)
+	}
+	return tools
+}
+
+// backupMySQL runs mysqldump to back up a database to a SQL script, gzipped
+// when compression is set. Tables are read in one consistent snapshot
+// without locking them, and routines and triggers are included with the
+// schema.
+func (m *Manager) backupMySQL(ctx context.Context, tools *BackupTools, config ConnectionConfig, database, outputPath string, opts BackupOptions) error {
+	if tools.Mysqldump == "" {
+		return fmt.Errorf("mysqldump was not found; install the MySQL or MariaDB client tools")
+	}
+	if opts.Format != "" && opts.Format != "plain" {
+		return fmt.Errorf("mysqldump writes plain SQL backups only")
+	}
+	if opts.Jobs > 1 {
+		return fmt.Errorf("mysqldump cannot back up tables in parallel")
+	}
+	if database == "" {
+		database = config.Database
+	}
+
+	optionFile, err := mysqlOptionFile(config)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(optionFile)
+
+	args := append(mysqlConnectionArgs(optionFile, config),
+		"--verbose", "--single-transaction", "--hex-blob", "--no-tablespaces", "--default-character-set=utf8mb4")
+	if tools.Flavor == "MySQL" {
+		// GTID_PURGED would fail the restore on a server with its own history;
+		// column statistics are missing from MariaDB and older servers
+		args = append(args, "--set-gtid-purged=OFF")
+		if majorVersion(tools.Version) >= 8 {
+			args = append(args, "--column-statistics=0")
+		}
+	}
+	switch opts.Content {
+	case "", "all":
+		args = append(args, "--routines", "--triggers")
+	case "schema":
+		args = append(args, "--no-data", "--routines", "--triggers")
+	case "data":
+		args = append(args, "--no-create-info", "--skip-triggers")
+	default:
+		return fmt.Errorf("unsupported backup content: %s", opts.Content)
+	}
+
+	if opts.Compression <= 0 {
+		args = append(append(args, "--result-file="+outputPath, database), opts.Tables...)
+		return m.runTool(ctx, "backup", tools.Mysqldump, args, nil, nil, nil)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewWriterLevel(file, opts.Compression)
+	if err != nil {
+		return err
+	}
+	args = append(append(args, database), opts.Tables...)
+	if err := m.runTool(ctx, "backup", tools.Mysqldump, args, nil, nil, gz); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	return file.Close()
+}
+
+// restoreMySQL runs a SQL script backup into a database with the mysql client
+func (m *Manager) restoreMySQL(ctx context.Context, tools *BackupTools, config ConnectionConfig, database, inputPath, format string, opts RestoreOptions) error {
+	if format != "plain" && format != "gzip" {
+		return fmt.Errorf("%s backups are made by pg_dump and cannot be restored to MySQL", format)
+	}
+	if opts.SingleTransaction {
+		return fmt.Errorf("mysql cannot restore a backup in a single transaction")
+	}
+	if tools.Mysql == "" {
+		return fmt.Errorf("mysql was not found; install the MySQL or MariaDB client tools")
+	}
+	if database == "" {
+		database = config.Database
+	}
+
+	optionFile, err := mysqlOptionFile(config)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(optionFile)
+
+	args := append(mysqlConnectionArgs(optionFile, config), "--default-character-set=utf8mb4")
+	if opts.ContinueOnError {
+		args = append(args, "--force")
+	}
+	args = append(args, database)
+
+	input, closeInput, err := m.openScript(inputPath, format)
+	if err != nil {
+		return err
+	}
+	defer closeInput()
+	return m.runTool(ctx, "restore", tools.Mysql, args, nil, input, nil)
+}
+
+// mysqlConnectionArgs returns the arguments that connect a MySQL client
+// program to the server. The option file must come first.
+func mysqlConnectionArgs(optionFile string, config ConnectionConfig) []string {
+	return []string{
+		"--defaults-extra-file=" + optionFile,
+		"--host=" + config.Host,
+		"--port=" + strconv.Itoa(config.Port),
+		"--user=" + config.User,
+		// Like the driver, never the local socket
+		"--protocol=TCP",
+	}
+}
+
+// mysqlOptionFile writes the password to a temporary option file, keeping it
+// off the command line where other users of the machine could see it
+func mysqlOptionFile(config ConnectionConfig) (string, error) {
+	file, err := os.CreateTemp("", "rune-*.cnf")
+	if err != nil {
+		return "", fmt.Errorf("failed to create option file: %w", err)
+	}
+	password := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(config.Password)
+	_, err = fmt.Fprintf(file, "[client]\npassword=\"%s\"\n", password)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write option file: %w", err)
+	}
+	return file.Name(), nil
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// maxBenchmarkRuns caps a benchmark so a typo can't hammer the server
+const maxBenchmarkRuns = 1000
+
+// BenchmarkOptions controls a repeated query execution
+type BenchmarkOptions struct {
+	Runs           int  `json:"runs"`           // Measured executions, defaults to 10
+	Warmup         int  `json:"warmup"`         // Unmeasured executions before the first run
+	DiscardResults bool `json:"discardResults"` // Count rows without decoding them
+	ClearCache     bool `json:"clearCache"`     // Clear server-side caches before each run, where supported
+}
+
+// BenchmarkResult summarizes the latencies of a benchmark
+type BenchmarkResult struct {
+	Runs         int       `json:"runs"`
+	LatenciesMs  []float64 `json:"latenciesMs"` // In execution order
+	MinMs        float64   `json:"minMs"`
+	MaxMs        float64   `json:"maxMs"`
+	MeanMs       float64   `json:"meanMs"`
+	MedianMs     float64   `json:"medianMs"`
+	P95Ms        float64   `json:"p95Ms"`
+	RowsPerRun   int64     `json:"rowsPerRun"`
+	RowsPerSec   float64   `json:"rowsPerSec"`
+	CacheCleared bool      `json:"cacheCleared"`
+	Warnings     []string  `json:"warnings"`
+}
+
+// BenchmarkQuery executes a query repeatedly on a single session and reports
+// latency statistics. Each run includes fetching every row.
+func (m *Manager) BenchmarkQuery(query string, opts BenchmarkOptions) (*BenchmarkResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	if opts.Runs <= 0 {
+		opts.Runs = 10
+	}
+	if opts.Runs > maxBenchmarkRuns {
+		return nil, fmt.Errorf("at most %d runs are allowed", maxBenchmarkRuns)
+	}
+
+	// Session-level caches (prepared plans, DISCARD) only apply to one connection
+	ctx, done := m.track("benchmark", query)
+	defer done()
+
+	conn, err := m.session(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	result := &BenchmarkResult{
+		Runs:        opts.Runs,
+		LatenciesMs: make([]float64, 0, opts.Runs),
+		Warnings:    []string{},
+	}
+
+	clearCache := ""
+	if opts.ClearCache {
+		clearCache = m.currentDriver().BuildClearCacheQuery()
+		if clearCache == "" {
+			result.Warnings = append(result.Warnings, "clearing caches is not supported for this database")
+		}
+	}
+
+	for i := 0; i < opts.Warmup; i++ {
+		if _, err := runBenchmarkQuery(conn, query, opts.DiscardResults); err != nil {
+			return nil, fmt.Errorf("warmup failed: %w", err)
+		}
+	}
+
+	var total time.Duration
+	for i := 0; i < opts.Runs; i++ {
+		if clearCache != "" {
+			if _, err := conn.Exec(clearCache); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("failed to clear cache: %v", err))
+				clearCache = ""
+			} else {
+				result.CacheCleared = true
+			}
+		}
+
+		start := time.Now()
+		rows, err := runBenchmarkQuery(conn, query, opts.DiscardResults)
+		if err != nil {
+			return nil, fmt.Errorf("run %d failed: %w", i+1, err)
+		}
+		elapsed := time.Since(start)
+
+		total += elapsed
+		result.RowsPerRun = rows
+		result.LatenciesMs = append(result.LatenciesMs, durationMs(elapsed))
+	}
+
+	sorted := append([]float64(nil), result.LatenciesMs...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, l := range sorted {
+		sum += l
+	}
+	result.MinMs = sorted[0]
+	result.MaxMs = sorted[len(sorted)-1]
+	result.MeanMs = sum / float64(len(sorted))
+	result.MedianMs = percentile(sorted, 50)
+	result.P95Ms = percentile(sorted, 95)
+	if total > 0 {
+		result.RowsPerSec = float64(result.RowsPerRun*int64(opts.Runs)) / total.Seconds()
+	}
+
+	return result, nil
+}
+
+// runBenchmarkQuery executes a query once, fetching all rows, and returns the row count
+func runBenchmarkQuery(db Querier, query string, discard bool) (int64, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if !discard {
+		result, err := readQueryResult(rows)
+		if err != nil {
+			return 0, err
+		}
+		return int64(result.RowCount), nil
+	}
+
+	var count int64
+	for rows.Next() {
+		count++
+	}
+	return count, rows.Err()
+}
+
+// percentile returns the p-th percentile of sorted values using nearest rank
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
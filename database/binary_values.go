@@ -0,0 +1,152 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	// binaryDumpLimit is how much of a binary cell the hex dump shows
+	binaryDumpLimit = 4096
+	// binaryPreviewLimit is the largest image or PDF sent to the viewer inline
+	binaryPreviewLimit = 16 << 20
+	// binaryGridBytes is how many bytes the grid shows of a binary cell
+	binaryGridBytes = 16
+)
+
+// BinaryValue describes the content of a binary (bytea/blob) cell
+type BinaryValue struct {
+	IsNull    bool   `json:"isNull"`
+	Size      int    `json:"size"`              // Length in bytes
+	MimeType  string `json:"mimeType"`          // Sniffed from the content, application/octet-stream when unknown
+	Dump      string `json:"dump"`              // Hex dump of the first bytes, with offsets and printable characters
+	Truncated bool   `json:"truncated"`         // Dump covers only part of the value
+	Preview   string `json:"preview,omitempty"` // data: URI of an image or PDF small enough to show inline
+}
+
+// GetBinaryCell fetches a binary cell identified by its row's primary key
+func (m *Manager) GetBinaryCell(database, schema, table string, primaryKey []string, primaryValues []interface{}, column string) (*BinaryValue, error) {
+	data, valid, err := m.cellBytes(database, schema, table, primaryKey, primaryValues, column)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return &BinaryValue{IsNull: true}, nil
+	}
+
+	value := &BinaryValue{
+		Size:      len(data),
+		MimeType:  http.DetectContentType(data),
+		Truncated: len(data) > binaryDumpLimit,
+	}
+	if value.Truncated {
+		value.Dump = hex.Dump(data[:binaryDumpLimit])
+	} else {
+		value.Dump = hex.Dump(data)
+	}
+	if previewable(value.MimeType) && len(data) <= binaryPreviewLimit {
+		value.Preview = "data:" + strings.SplitN(value.MimeType, ";", 2)[0] + ";base64," + base64.StdEncoding.EncodeToString(data)
+	}
+	return value, nil
+}
+
+// DownloadCell writes the content of a binary cell to a file
+func (m *Manager) DownloadCell(database, schema, table string, primaryKey []string, primaryValues []interface{}, column, outputPath string) error {
+	data, valid, err := m.cellBytes(database, schema, table, primaryKey, primaryValues, column)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("cell is NULL")
+	}
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// UploadCell stores the content of a file in a binary cell
+func (m *Manager) UploadCell(database, schema, table string, primaryKey []string, primaryValues []interface{}, column, path string) (*ExecuteResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return m.UpdateRow(database, schema, table, primaryKey, primaryValues, RowData{column: data})
+}
+
+// cellBytes fetches the raw content of a cell and whether it is not NULL
+func (m *Manager) cellBytes(database, schema, table string, primaryKey []string, primaryValues []interface{}, column string) ([]byte, bool, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, false, fmt.Errorf("not connected to database")
+	}
+
+	keys, err := keyValues(primaryKey, primaryValues)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var data []byte
+	query := m.tableDriver(schema).BuildSelectCellQuery(database, table, primaryKey, column)
+	if err := db.QueryRow(query, keys...).Scan(&data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, fmt.Errorf("row not found")
+		}
+		return nil, false, fmt.Errorf("failed to fetch cell value: %w", err)
+	}
+	return data, data != nil, nil
+}
+
+// previewable reports whether the viewer can show content of a MIME type
+func previewable(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "image/") || mimeType == "application/pdf"
+}
+
+// binaryDisplay renders the binary columns of a page for the grid, as the
+// leading bytes in hex and the size of the value. Columns already rendered
+// by a formatter are left alone.
+func binaryDisplay(columns []ColumnInfo, rows [][]interface{}, display map[string][]string) map[string][]string {
+	for i, col := range columns {
+		if typeCategory(col.Type) != typeCategoryBinary {
+			continue
+		}
+		if _, ok := display[col.Name]; ok {
+			continue
+		}
+
+		values := make([]string, len(rows))
+		for r, row := range rows {
+			if i >= len(row) {
+				continue
+			}
+			var data []byte
+			switch v := row[i].(type) {
+			case []byte:
+				data = v
+			case string:
+				data = []byte(v)
+			default:
+				continue
+			}
+			head := data
+			if len(head) > binaryGridBytes {
+				head = head[:binaryGridBytes]
+			}
+			values[r] = fmt.Sprintf("0x%X", head)
+			if len(data) > binaryGridBytes {
+				values[r] += "…"
+			}
+			values[r] += " (" + humanSize(float64(len(data))) + ")"
+		}
+		if display == nil {
+			display = make(map[string][]string)
+		}
+		display[col.Name] = values
+	}
+	return display
+}
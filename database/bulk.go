@@ -0,0 +1,251 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// BulkUpdate sets columns of every row matching a filter set, such as the
+// filters and quick search of a table view. Without any filter every row of
+// the table is updated.
+type BulkUpdate struct {
+	Filters       []FilterCondition `json:"filters,omitempty"`
+	Search        string            `json:"search,omitempty"`
+	SearchColumns []string          `json:"searchColumns,omitempty"`
+	Values        RowData           `json:"values"` // New value per column
+}
+
+// BulkPreview is the statement a bulk change would run, with the number of
+// rows its filters match now. Rows changed in the meantime may make the
+// statement affect a different number.
+type BulkPreview struct {
+	Preview      SQLPreview `json:"preview"`
+	MatchingRows int64      `json:"matchingRows"`
+	Token        string     `json:"token,omitempty"` // Confirms a bulk delete, see BulkDelete
+}
+
+// BulkDelete deletes every row matching a filter set. Token must be the one
+// returned by PreviewBulkDelete for the same request; the delete is refused
+// when the statement or the number of matching rows has changed since.
+type BulkDelete struct {
+	Filters       []FilterCondition `json:"filters,omitempty"`
+	Search        string            `json:"search,omitempty"`
+	SearchColumns []string          `json:"searchColumns,omitempty"`
+	Token         string            `json:"token"`
+}
+
+// BulkUpdateRows runs a single UPDATE over every row matching the filters
+func (m *Manager) BulkUpdateRows(database, schema, table string, update BulkUpdate) (*ExecuteResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	filters, err := m.bulkFilters(database, schema, table, update.Filters, update.Search, update.SearchColumns)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := m.bulkUpdateStatement(database, schema, table, filters, update.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := m.track("update", table)
+	defer done()
+
+	res, err := db.withContext(ctx).Exec(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("update failed: %w", err)
+	}
+	m.forgetUndo(database, schema, table)
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return &ExecuteResult{
+		RowsAffected: rowsAffected,
+	}, nil
+}
+
+// PreviewBulkUpdate returns the UPDATE that BulkUpdateRows would run and how
+// many rows it would change
+func (m *Manager) PreviewBulkUpdate(database, schema, table string, update BulkUpdate) (*BulkPreview, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	filters, err := m.bulkFilters(database, schema, table, update.Filters, update.Search, update.SearchColumns)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := m.bulkUpdateStatement(database, schema, table, filters, update.Values)
+	if err != nil {
+		return nil, err
+	}
+	ctx, done := m.track("count", table)
+	defer done()
+
+	matching, err := countMatching(db.withContext(ctx), m.tableDriver(schema), database, table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkPreview{Preview: *m.previewOf(query, args), MatchingRows: matching}, nil
+}
+
+// BulkDeleteRows deletes every row matching the filters once the confirmation
+// token of a preview is given. Rows are counted again in the same transaction
+// as the DELETE, and the DELETE is rolled back when it removes a different
+// number of rows, as rows may change between the count and the delete.
+func (m *Manager) BulkDeleteRows(database, schema, table string, request BulkDelete) (*ExecuteResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if request.Token == "" {
+		return nil, fmt.Errorf("a confirmation token from PreviewBulkDelete is required")
+	}
+
+	filters, err := m.bulkFilters(database, schema, table, request.Filters, request.Search, request.SearchColumns)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := m.tableDriver(schema).BuildFilteredDeleteQuery(database, table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := m.track("delete", table)
+	defer done()
+
+	tx, err := db.withContext(ctx).Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	matching, err := countMatching(tx, m.tableDriver(schema), database, table, filters)
+	if err != nil {
+		return nil, err
+	}
+	if bulkToken(query, args, matching) != request.Token {
+		return nil, fmt.Errorf("the rows matching the filters changed since the preview (%d now); review the delete again", matching)
+	}
+
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("delete failed: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deleted rows: %w", err)
+	}
+	if rowsAffected != matching {
+		return nil, fmt.Errorf("the delete matched %d rows instead of %d as rows changed meanwhile; nothing was deleted, review the delete again", rowsAffected, matching)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit delete: %w", err)
+	}
+	m.forgetUndo(database, schema, table)
+
+	return &ExecuteResult{
+		RowsAffected: rowsAffected,
+	}, nil
+}
+
+// PreviewBulkDelete returns the DELETE that BulkDeleteRows would run, how
+// many rows it would remove and the token that confirms it
+func (m *Manager) PreviewBulkDelete(database, schema, table string, request BulkDelete) (*BulkPreview, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	filters, err := m.bulkFilters(database, schema, table, request.Filters, request.Search, request.SearchColumns)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := m.tableDriver(schema).BuildFilteredDeleteQuery(database, table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := m.track("count", table)
+	defer done()
+
+	matching, err := countMatching(db.withContext(ctx), m.tableDriver(schema), database, table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkPreview{
+		Preview:      *m.previewOf(query, args),
+		MatchingRows: matching,
+		Token:        bulkToken(query, args, matching),
+	}, nil
+}
+
+// bulkToken fingerprints a bulk statement together with the number of rows
+// it matched, so that a confirmation only holds while both are unchanged
+func bulkToken(query string, args []interface{}, matching int64) string {
+	h := sha256.New()
+	encoded, _ := json.Marshal(args)
+	fmt.Fprintf(h, "%s\x00%s\x00%d", query, encoded, matching)
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// bulkUpdateStatement builds the UPDATE setting values on the rows matching
+// filters and its arguments
+func (m *Manager) bulkUpdateStatement(database, schema, table string, filters []FilterCondition, values RowData) (string, []interface{}, error) {
+	data, err := m.writableData(database, schema, table, values)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(data) == 0 {
+		return "", nil, fmt.Errorf("no data provided")
+	}
+
+	columns, params := rowColumns(data)
+	query, args, err := m.tableDriver(schema).BuildBulkUpdateQuery(database, table, columns, filters)
+	if err != nil {
+		return "", nil, err
+	}
+	return query, append(params, args...), nil
+}
+
+// bulkFilters combines the filters and quick search of a bulk change as
+// GetTableData does, so that it covers the rows the table view shows
+func (m *Manager) bulkFilters(database, schema, table string, filters []FilterCondition, search string, searchColumns []string) ([]FilterCondition, error) {
+	if m.isView(database, schema, table) {
+		return nil, fmt.Errorf("%s is a view and cannot be changed", table)
+	}
+	if search == "" {
+		return filters, nil
+	}
+
+	columns, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	condition, err := quickSearchFilter(columns, searchColumns, search)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]FilterCondition{}, filters...), condition), nil
+}
+
+// countMatching counts the rows of a table matching filters
+func countMatching(db Querier, driver Driver, database, table string, filters []FilterCondition) (int64, error) {
+	query, args, err := driver.BuildCountQuery(database, table, filters, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count rows: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// CellValue is the untruncated content of a single cell
+type CellValue struct {
+	Value  string `json:"value"`
+	IsNull bool   `json:"isNull"`
+	Size   int    `json:"size"` // Length in bytes
+	Type   string `json:"type"` // Column type as reported by the schema
+
+	// Embedded format found in the value, if any
+	Format *FormatHint `json:"format,omitempty"`
+}
+
+// CellUpdatePreview describes a pending edit of a single cell
+type CellUpdatePreview struct {
+	Changed bool   `json:"changed"`
+	Diff    string `json:"diff"`  // Unified diff of the current and new content
+	Query   string `json:"query"` // Parameterized UPDATE that will be executed
+}
+
+// GetCellValue fetches the full value of a cell identified by its row's primary key
+func (m *Manager) GetCellValue(database, schema, table string, primaryKey []string, primaryValues []interface{}, column string) (*CellValue, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	keys, err := keyValues(primaryKey, primaryValues)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	cell := &CellValue{}
+	found := false
+	for _, col := range columns {
+		if col.Name == column {
+			cell.Type = col.Type
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("column not found: %s", column)
+	}
+
+	var val sql.NullString
+	query := m.tableDriver(schema).BuildSelectCellQuery(database, table, primaryKey, column)
+	if err := db.QueryRow(query, keys...).Scan(&val); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("row not found")
+		}
+		return nil, fmt.Errorf("failed to fetch cell value: %w", err)
+	}
+
+	cell.Value = val.String
+	cell.IsNull = !val.Valid
+	cell.Size = len(val.String)
+	switch typeCategory(cell.Type) {
+	case typeCategoryBinary:
+	case typeCategoryJSON:
+		// Scalars are documents too, which DetectFormat does not take for JSON
+		if check := ValidateJSON(cell.Value); check.Valid && !cell.IsNull {
+			cell.Format = &FormatHint{Format: embeddedJSON, Pretty: check.Pretty}
+		}
+	default:
+		cell.Format = DetectFormat(cell.Value)
+	}
+	return cell, nil
+}
+
+// PreviewCellUpdate diffs the stored value of a cell against newValue and
+// returns the UPDATE that saving it would run
+func (m *Manager) PreviewCellUpdate(database, schema, table string, primaryKey []string, primaryValues []interface{}, column, newValue string) (*CellUpdatePreview, error) {
+	cell, err := m.GetCellValue(database, schema, table, primaryKey, primaryValues, column)
+	if err != nil {
+		return nil, err
+	}
+
+	readOnly, err := m.readOnlyColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	if readOnly[column] {
+		return nil, fmt.Errorf("column %s is read-only", column)
+	}
+
+	oldText, newText := cell.Value, newValue
+	changed := cell.IsNull || cell.Value != newValue
+	// Compact JSON diffs as a single line; compare indented forms instead
+	if typeCategory(cell.Type) == typeCategoryJSON {
+		if _, err := jsonParam(column, newValue); err != nil {
+			return nil, err
+		}
+		oldText, newText = indentJSON(oldText), indentJSON(newText)
+		changed = cell.IsNull || !jsonEqual(cell.Value, newValue)
+	}
+
+	return &CellUpdatePreview{
+		Changed: changed,
+		Diff:    unifiedDiff(oldText, newText, "current", "new"),
+		Query:   m.tableDriver(schema).BuildUpdateQuery(database, table, primaryKey, []string{column}),
+	}, nil
+}
+
+// indentJSON pretty-prints s, returning it unchanged when it isn't valid JSON
+func indentJSON(s string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(s), "", "  "); err != nil {
+		return s
+	}
+	return buf.String()
+}
@@ -0,0 +1,283 @@
+package database
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Kinds of staged changes
+const (
+	changeInsert = "insert"
+	changeUpdate = "update"
+	changeDelete = "delete"
+)
+
+// StagedChange is an edit of a table's grid queued to be applied later
+type StagedChange struct {
+	ID            int64         `json:"id,omitempty"`
+	Kind          string        `json:"kind"` // insert, update or delete
+	PrimaryKey    []string      `json:"primaryKey,omitempty"`
+	PrimaryValues []interface{} `json:"primaryValues,omitempty"`
+	Values        RowData       `json:"values,omitempty"` // insert: the new row; update: the changed columns
+}
+
+// ChangeSet holds the staged changes of a table in the order they were made
+type ChangeSet struct {
+	Database string         `json:"database"`
+	Schema   string         `json:"schema,omitempty"` // PostgreSQL only
+	Table    string         `json:"table"`
+	Changes  []StagedChange `json:"changes"`
+}
+
+// ChangeSetResult is the outcome of applying a change set
+type ChangeSetResult struct {
+	Applied      int   `json:"applied"` // Changes applied
+	RowsAffected int64 `json:"rowsAffected"`
+}
+
+// changeSetRegistry holds the change sets of the connection by table
+type changeSetRegistry struct {
+	mu     sync.Mutex
+	sets   map[string]*ChangeSet
+	nextID int64
+}
+
+// changeSetKey identifies the change set of a table
+func (m *Manager) changeSetKey(database, schema, table string) string {
+	return database + "\x00" + m.resolveSchema(schema) + "\x00" + table
+}
+
+// stagedRowKey identifies the row a change is staged for
+func stagedRowKey(primaryKey []string, primaryValues []interface{}) string {
+	return fmt.Sprint(primaryKey, paramValues(primaryValues))
+}
+
+// StageChange queues a change of a table instead of running it. A row's
+// staged updates are merged into one; deleting a row replaces its staged
+// update, and a row staged for deletion can't be updated.
+func (m *Manager) StageChange(database, schema, table string, change StagedChange) (*ChangeSet, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	switch change.Kind {
+	case changeInsert:
+		if len(change.Values) == 0 {
+			return nil, fmt.Errorf("no data provided")
+		}
+		change.PrimaryKey, change.PrimaryValues = nil, nil
+	case changeUpdate, changeDelete:
+		if _, err := keyValues(change.PrimaryKey, change.PrimaryValues); err != nil {
+			return nil, err
+		}
+		if change.Kind == changeUpdate && len(change.Values) == 0 {
+			return nil, fmt.Errorf("no data provided")
+		}
+		if change.Kind == changeDelete {
+			change.Values = nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported change kind: %s", change.Kind)
+	}
+
+	r := m.changeSets
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := m.changeSetKey(database, schema, table)
+	set := r.sets[key]
+	if set == nil {
+		set = &ChangeSet{Database: database, Schema: m.resolveSchema(schema), Table: table}
+		r.sets[key] = set
+	}
+
+	if change.Kind != changeInsert {
+		row := stagedRowKey(change.PrimaryKey, change.PrimaryValues)
+		for i, staged := range set.Changes {
+			if staged.Kind == changeInsert || stagedRowKey(staged.PrimaryKey, staged.PrimaryValues) != row {
+				continue
+			}
+			switch {
+			case staged.Kind == changeDelete && change.Kind == changeUpdate:
+				return nil, fmt.Errorf("row is staged for deletion")
+			case staged.Kind == changeDelete:
+				return cloneChangeSet(set), nil
+			case change.Kind == changeDelete:
+				set.Changes = append(set.Changes[:i], set.Changes[i+1:]...)
+			default:
+				merged := make(RowData, len(staged.Values)+len(change.Values))
+				for col, v := range staged.Values {
+					merged[col] = v
+				}
+				for col, v := range change.Values {
+					merged[col] = v
+				}
+				set.Changes[i].Values = merged
+				return cloneChangeSet(set), nil
+			}
+			break
+		}
+	}
+
+	r.nextID++
+	change.ID = r.nextID
+	set.Changes = append(set.Changes, change)
+	return cloneChangeSet(set), nil
+}
+
+// UnstageChange drops a staged change of a table
+func (m *Manager) UnstageChange(database, schema, table string, id int64) (*ChangeSet, error) {
+	r := m.changeSets
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set := r.sets[m.changeSetKey(database, schema, table)]
+	if set != nil {
+		for i, change := range set.Changes {
+			if change.ID == id {
+				set.Changes = append(set.Changes[:i], set.Changes[i+1:]...)
+				if len(set.Changes) == 0 {
+					delete(r.sets, m.changeSetKey(database, schema, table))
+				}
+				return cloneChangeSet(set), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("staged change %d not found", id)
+}
+
+// GetChangeSet returns the staged changes of a table, empty when there are none
+func (m *Manager) GetChangeSet(database, schema, table string) *ChangeSet {
+	r := m.changeSets
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if set := r.sets[m.changeSetKey(database, schema, table)]; set != nil {
+		return cloneChangeSet(set)
+	}
+	return &ChangeSet{Database: database, Schema: m.resolveSchema(schema), Table: table, Changes: []StagedChange{}}
+}
+
+// DiscardChangeSet drops every staged change of a table
+func (m *Manager) DiscardChangeSet(database, schema, table string) {
+	r := m.changeSets
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sets, m.changeSetKey(database, schema, table))
+}
+
+// PreviewChangeSet returns the statements ApplyChangeSet would run, in order
+func (m *Manager) PreviewChangeSet(database, schema, table string) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	set := m.GetChangeSet(database, schema, table)
+	preview := &SQLPreview{}
+	for _, change := range set.Changes {
+		query, args, err := m.changeStatement(database, schema, table, change)
+		if err != nil {
+			return nil, err
+		}
+		preview.Statements = append(preview.Statements, m.previewStatement(query, args))
+	}
+	return preview, nil
+}
+
+// ApplyChangeSet runs the staged changes of a table in a single transaction.
+// Any failure, including an update or delete that finds no row, rolls every
+// change back and keeps the change set for another attempt.
+func (m *Manager) ApplyChangeSet(database, schema, table string) (*ChangeSetResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	set := m.GetChangeSet(database, schema, table)
+	if len(set.Changes) == 0 {
+		return nil, fmt.Errorf("no staged changes")
+	}
+
+	ctx, done := m.track("changes", table)
+	defer done()
+
+	tx, err := db.withContext(ctx).Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &ChangeSetResult{}
+	for i, change := range set.Changes {
+		query, args, err := m.changeStatement(database, schema, table, change)
+		if err != nil {
+			return nil, fmt.Errorf("change %d: %w", i+1, err)
+		}
+		res, err := tx.Exec(query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("change %d (%s) failed: %w", i+1, change.Kind, err)
+		}
+		affected, _ := res.RowsAffected()
+		if affected == 0 && change.Kind != changeInsert {
+			return nil, fmt.Errorf("change %d (%s) failed: row not found", i+1, change.Kind)
+		}
+		result.RowsAffected += affected
+		result.Applied++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit changes: %w", err)
+	}
+	m.forgetUndo(database, schema, table)
+
+	// Changes staged while applying stay for the next round
+	r := m.changeSets
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if current := r.sets[m.changeSetKey(database, schema, table)]; current != nil {
+		applied := make(map[int64]bool, len(set.Changes))
+		for _, change := range set.Changes {
+			applied[change.ID] = true
+		}
+		var rest []StagedChange
+		for _, change := range current.Changes {
+			if !applied[change.ID] {
+				rest = append(rest, change)
+			}
+		}
+		current.Changes = rest
+		if len(rest) == 0 {
+			delete(r.sets, m.changeSetKey(database, schema, table))
+		}
+	}
+	return result, nil
+}
+
+// changeStatement builds the statement of a staged change and its arguments
+func (m *Manager) changeStatement(database, schema, table string, change StagedChange) (string, []interface{}, error) {
+	switch change.Kind {
+	case changeInsert:
+		return m.insertRowStatement(database, schema, table, change.Values)
+	case changeUpdate:
+		return m.updateRowStatement(database, schema, table, change.PrimaryKey, change.PrimaryValues, change.Values)
+	default:
+		keys, err := keyValues(change.PrimaryKey, change.PrimaryValues)
+		if err != nil {
+			return "", nil, err
+		}
+		return m.tableDriver(schema).BuildDeleteQuery(database, table, change.PrimaryKey), keys, nil
+	}
+}
+
+// clear drops the change sets of a closed connection
+func (r *changeSetRegistry) clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sets = make(map[string]*ChangeSet)
+}
+
+// cloneChangeSet copies a change set for returning it outside the lock
+func cloneChangeSet(set *ChangeSet) *ChangeSet {
+	changes := make([]StagedChange, len(set.Changes))
+	copy(changes, set.Changes)
+	return &ChangeSet{Database: set.Database, Schema: set.Schema, Table: set.Table, Changes: changes}
+}
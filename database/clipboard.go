@@ -0,0 +1,165 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CopyOptions controls how copied cells are rendered as text
+type CopyOptions struct {
+	Delimiter       string `json:"delimiter"`       // Defaults to tab
+	IncludeHeaders  bool   `json:"includeHeaders"`  // Prepend a row of column names
+	NullMarker      string `json:"nullMarker"`      // Text used for NULL, defaults to "NULL"
+	TimestampFormat string `json:"timestampFormat"` // Go layout, defaults to RFC 3339 with fractional seconds
+	DateFormat      string `json:"dateFormat"`      // Go layout, defaults to 2006-01-02
+	BinaryFormat    string `json:"binaryFormat"`    // hex (default) or base64
+	ClearAfterSec   int    `json:"clearAfterSec"`   // Clear the clipboard after this many seconds, 0 keeps it
+	Styled          bool   `json:"styled"`          // HTML only: add inline borders, header shading and numeric alignment
+
+	// Format of FormatCopy: tsv (default), csv, markdown, json, html or insert
+	Format   string `json:"format,omitempty"`
+	Table    string `json:"table,omitempty"`    // insert: name written into the statements, e.g. schema.table
+	Dialect  string `json:"dialect,omitempty"`  // insert: postgres or mysql, defaults to that of the connection
+	MultiRow bool   `json:"multiRow,omitempty"` // insert: a single INSERT listing every row
+}
+
+// parseTimestampLayouts are tried in order when a timestamp arrives as text
+var parseTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// FormatCells renders rows as delimited text, formatting each value by its column type
+func FormatCells(columns []ColumnInfo, rows [][]interface{}, opts CopyOptions) (string, error) {
+	opts = opts.withDefaults()
+
+	var sb strings.Builder
+
+	if opts.IncludeHeaders {
+		headers := make([]string, len(columns))
+		for i, col := range columns {
+			headers[i] = quoteDelimited(col.Name, opts.Delimiter)
+		}
+		sb.WriteString(strings.Join(headers, opts.Delimiter))
+		sb.WriteString("\n")
+	}
+
+	for _, row := range rows {
+		if len(row) != len(columns) {
+			return "", fmt.Errorf("row has %d values but %d columns were given", len(row), len(columns))
+		}
+
+		cells := make([]string, len(row))
+		for i, val := range row {
+			if val == nil {
+				cells[i] = opts.NullMarker
+				continue
+			}
+			cells[i] = quoteDelimited(formatCell(val, typeCategory(columns[i].Type), opts), opts.Delimiter)
+		}
+		sb.WriteString(strings.Join(cells, opts.Delimiter))
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
+
+// withDefaults fills in the default delimiter, NULL marker and date layouts
+func (opts CopyOptions) withDefaults() CopyOptions {
+	if opts.Delimiter == "" {
+		opts.Delimiter = "\t"
+	}
+	if opts.NullMarker == "" {
+		opts.NullMarker = "NULL"
+	}
+	if opts.TimestampFormat == "" {
+		opts.TimestampFormat = time.RFC3339Nano
+	}
+	if opts.DateFormat == "" {
+		opts.DateFormat = "2006-01-02"
+	}
+	return opts
+}
+
+// formatCell renders a single non-NULL value according to its type category
+func formatCell(val interface{}, category string, opts CopyOptions) string {
+	switch category {
+	case typeCategoryTimestamp:
+		if t, ok := parseTimeValue(val); ok {
+			return t.Format(opts.TimestampFormat)
+		}
+	case typeCategoryDate:
+		if t, ok := parseTimeValue(val); ok {
+			return t.Format(opts.DateFormat)
+		}
+	case typeCategoryBinary:
+		var data []byte
+		switch v := val.(type) {
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		}
+		if data != nil {
+			if opts.BinaryFormat == "base64" {
+				return base64.StdEncoding.EncodeToString(data)
+			}
+			return fmt.Sprintf("\\x%x", data)
+		}
+	}
+
+	switch v := val.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float64:
+		// Never use exponent notation and keep every significant digit
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(opts.TimestampFormat)
+	case json.Number:
+		return v.String()
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		if err == nil {
+			return string(encoded)
+		}
+	}
+
+	return fmt.Sprintf("%v", val)
+}
+
+// parseTimeValue accepts a time.Time or the textual forms produced by the drivers
+func parseTimeValue(val interface{}) (time.Time, bool) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range parseTimestampLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// quoteDelimited quotes a cell when it contains the delimiter, quotes or line breaks
+func quoteDelimited(s, delimiter string) string {
+	if !strings.Contains(s, delimiter) && !strings.ContainsAny(s, "\"\r\n") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
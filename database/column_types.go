@@ -0,0 +1,68 @@
+package database
+
+import (
+	"strings"
+)
+
+// Type categories used to format and convert values independently of the dialect
+const (
+	typeCategoryInteger   = "integer"
+	typeCategoryDecimal   = "decimal"
+	typeCategoryFloat     = "float"
+	typeCategoryBoolean   = "boolean"
+	typeCategoryTimestamp = "timestamp"
+	typeCategoryDate      = "date"
+	typeCategoryTime      = "time"
+	typeCategoryBinary    = "binary"
+	typeCategoryJSON      = "json"
+	typeCategoryText      = "text"
+)
+
+// typeCategory classifies a MySQL or Postgres column type name
+func typeCategory(typeName string) string {
+	t := strings.ToLower(strings.TrimSpace(typeName))
+	if strings.HasPrefix(t, "tinyint(1)") {
+		return typeCategoryBoolean
+	}
+	if strings.HasSuffix(t, "[]") || t == "array" {
+		return typeCategoryText
+	}
+	// Strip length/precision arguments: "decimal(10,2) unsigned" -> "decimal"
+	if idx := strings.Index(t, "("); idx >= 0 {
+		t = t[:idx]
+	}
+
+	switch {
+	case t == "boolean" || t == "bool" || t == "bit":
+		return typeCategoryBoolean
+	case strings.Contains(t, "int") && !strings.Contains(t, "interval") && !strings.Contains(t, "point"):
+		return typeCategoryInteger
+	case t == "decimal" || t == "numeric" || t == "money":
+		return typeCategoryDecimal
+	case t == "float" || t == "real" || strings.HasPrefix(t, "double") || t == "float4" || t == "float8":
+		return typeCategoryFloat
+	case strings.HasPrefix(t, "timestamp") || t == "datetime":
+		return typeCategoryTimestamp
+	case t == "date":
+		return typeCategoryDate
+	case strings.HasPrefix(t, "time"):
+		return typeCategoryTime
+	case t == "bytea" || strings.HasSuffix(t, "blob") || t == "binary" || t == "varbinary":
+		return typeCategoryBinary
+	case t == "json" || t == "jsonb":
+		return typeCategoryJSON
+	default:
+		return typeCategoryText
+	}
+}
+
+// comparableColumn reports whether a column reads back exactly as written,
+// so that rows can be matched by its value. Floats round, JSON is normalized
+// or has no equality, blobs are large and generated columns are computed.
+func comparableColumn(col ColumnInfo) bool {
+	switch typeCategory(col.Type) {
+	case typeCategoryFloat, typeCategoryJSON, typeCategoryBinary:
+		return false
+	}
+	return col.Generated == ""
+}
@@ -0,0 +1,36 @@
+package database
+
+import "fmt"
+
+// SetTableComment sets the comment of a table; an empty comment removes it
+func (m *Manager) SetTableComment(database, schema, table, comment string) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	if _, err := db.Exec(m.tableDriver(schema).BuildTableCommentQuery(database, table, comment)); err != nil {
+		return fmt.Errorf("failed to set table comment: %w", err)
+	}
+
+	return nil
+}
+
+// SetColumnComment sets the comment of a column; an empty comment removes it
+func (m *Manager) SetColumnComment(database, schema, table, column, comment string) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	query, err := m.tableDriver(schema).BuildColumnCommentQuery(db, database, table, column, comment)
+	if err != nil {
+		return fmt.Errorf("failed to build column comment query: %w", err)
+	}
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to set column comment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CompletionColumn is a column offered by the SQL editor's autocomplete
+type CompletionColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// CompletionTable is a table or view offered by autocomplete
+type CompletionTable struct {
+	Schema  string             `json:"schema"` // Database on MySQL
+	Name    string             `json:"name"`
+	Type    string             `json:"type"` // TABLE, VIEW, MATERIALIZED VIEW or FOREIGN TABLE
+	Columns []CompletionColumn `json:"columns"`
+}
+
+// CompletionFunction is a stored function or procedure offered by autocomplete
+type CompletionFunction struct {
+	Schema     string `json:"schema"`
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`      // FUNCTION or PROCEDURE
+	Arguments  string `json:"arguments"` // e.g. id integer, name text
+	ReturnType string `json:"returnType,omitempty"`
+}
+
+// CompletionMetadata is what the SQL editor completes against. Passing its
+// Version back as since returns only the tables that changed in the meantime.
+type CompletionMetadata struct {
+	Database string `json:"database"`
+	Version  int64  `json:"version"`
+	// Full responses list every table; the others only those added or changed
+	// since the given version, with the dropped ones in Removed
+	Full      bool                 `json:"full"`
+	Schemas   []string             `json:"schemas"`
+	Tables    []CompletionTable    `json:"tables"`
+	Removed   []string             `json:"removed,omitempty"` // Qualified as schema.name
+	Functions []CompletionFunction `json:"functions"`
+	Keywords  []string             `json:"keywords,omitempty"` // Full responses only
+	Builtins  []string             `json:"builtins,omitempty"` // Built-in functions; full responses only
+}
+
+// completionCache remembers the tables last sent for each database so later
+// requests can be answered with what changed
+type completionCache struct {
+	mu        sync.Mutex
+	snapshots map[string]completionSnapshot
+	// version numbers snapshots; it is never reset, so that a version from
+	// before a reconnect cannot match a newer snapshot
+	version int64
+}
+
+type completionSnapshot struct {
+	version int64
+	tables  map[string]string // Qualified name -> fingerprint of type and columns
+}
+
+func newCompletionCache() *completionCache {
+	return &completionCache{snapshots: make(map[string]completionSnapshot)}
+}
+
+// reset forgets every snapshot, e.g. when connecting to another server
+func (c *completionCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshots = make(map[string]completionSnapshot)
+}
+
+// GetCompletionMetadata returns the schemas, tables with their columns and
+// functions of a database, along with the dialect's keywords. With since set
+// to the version of an earlier response, only the changes are returned.
+func (m *Manager) GetCompletionMetadata(database string, since int64) (*CompletionMetadata, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	tables, err := m.completionTables(db, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+	functions, err := m.completionFunctions(db, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get functions: %w", err)
+	}
+
+	var schemas []string
+	if driver, ok := m.currentDriver().(*PostgresDriver); ok {
+		schemas, err = driver.GetSchemas(db)
+	} else {
+		schemas, err = m.currentDriver().GetDatabases(db)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schemas: %w", err)
+	}
+
+	fingerprints := make(map[string]string, len(tables))
+	for _, t := range tables {
+		fingerprints[qualifiedKey(t.Schema, t.Name)] = t.fingerprint()
+	}
+
+	result := &CompletionMetadata{Database: database, Schemas: schemas, Functions: functions}
+
+	m.completion.mu.Lock()
+	defer m.completion.mu.Unlock()
+	previous, ok := m.completion.snapshots[database]
+	if !ok || since == 0 || since != previous.version {
+		result.Full = true
+		result.Tables = tables
+		_, mysql := m.currentDriver().(*MySQLDriver)
+		result.Keywords, result.Builtins = sqlKeywords(mysql)
+		m.completion.version++
+		result.Version = m.completion.version
+		m.completion.snapshots[database] = completionSnapshot{version: result.Version, tables: fingerprints}
+		return result, nil
+	}
+
+	result.Tables = []CompletionTable{}
+	for _, t := range tables {
+		key := qualifiedKey(t.Schema, t.Name)
+		if previous.tables[key] != fingerprints[key] {
+			result.Tables = append(result.Tables, t)
+		}
+	}
+	for key := range previous.tables {
+		if _, ok := fingerprints[key]; !ok {
+			result.Removed = append(result.Removed, key)
+		}
+	}
+	sort.Strings(result.Removed)
+
+	result.Version = previous.version
+	if len(result.Tables) > 0 || len(result.Removed) > 0 {
+		m.completion.version++
+		result.Version = m.completion.version
+		m.completion.snapshots[database] = completionSnapshot{version: result.Version, tables: fingerprints}
+	}
+	return result, nil
+}
+
+// completionTables reads every table and view of a database with its columns
+// in a single query
+func (m *Manager) completionTables(db Querier, database string) ([]CompletionTable, error) {
+	query, args := m.currentDriver().BuildCompletionColumnsQuery(database)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := []CompletionTable{}
+	for rows.Next() {
+		var schema, table, kind string
+		var col CompletionColumn
+		if err := rows.Scan(&schema, &table, &kind, &col.Name, &col.Type); err != nil {
+			return nil, err
+		}
+		// Rows come ordered by table
+		if n := len(tables); n == 0 || tables[n-1].Schema != schema || tables[n-1].Name != table {
+			tables = append(tables, CompletionTable{Schema: schema, Name: table, Type: kind})
+		}
+		last := &tables[len(tables)-1]
+		last.Columns = append(last.Columns, col)
+	}
+	return tables, rows.Err()
+}
+
+// completionFunctions reads the stored functions and procedures of a database
+func (m *Manager) completionFunctions(db Querier, database string) ([]CompletionFunction, error) {
+	query, args := m.currentDriver().BuildCompletionRoutinesQuery(database)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	functions := []CompletionFunction{}
+	for rows.Next() {
+		var f CompletionFunction
+		if err := rows.Scan(&f.Schema, &f.Name, &f.Kind, &f.Arguments, &f.ReturnType); err != nil {
+			return nil, err
+		}
+		functions = append(functions, f)
+	}
+	return functions, rows.Err()
+}
+
+func (t CompletionTable) fingerprint() string {
+	h := sha256.New()
+	h.Write([]byte(t.Type))
+	for _, c := range t.Columns {
+		fmt.Fprintf(h, "\x00%s\x00%s", c.Name, c.Type)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// commonKeywords are the keywords both dialects share
+var commonKeywords = []string{
+	"ADD", "ALL", "ALTER", "AND", "ANY", "AS", "ASC", "BEGIN", "BETWEEN", "BY",
+	"CASCADE", "CASE", "CHECK", "COLUMN", "COMMIT", "CONSTRAINT", "CREATE", "CROSS",
+	"DEFAULT", "DELETE", "DESC", "DISTINCT", "DROP", "ELSE", "END", "EXISTS",
+	"EXPLAIN", "FALSE", "FOREIGN", "FROM", "FULL", "GRANT", "GROUP BY", "HAVING",
+	"IN", "INDEX", "INNER JOIN", "INSERT INTO", "INTERSECT", "IS", "JOIN", "KEY",
+	"LEFT JOIN", "LIKE", "LIMIT", "NOT", "NULL", "OFFSET", "ON", "OR", "ORDER BY",
+	"OUTER", "PRIMARY KEY", "REFERENCES", "REVOKE", "RIGHT JOIN", "ROLLBACK",
+	"SAVEPOINT", "SELECT", "SET", "TABLE", "THEN", "TRUE", "TRUNCATE", "UNION",
+	"UNIQUE", "UPDATE", "USING", "VALUES", "VIEW", "WHEN", "WHERE", "WITH",
+}
+
+var mysqlKeywords = []string{
+	"AUTO_INCREMENT", "CHANGE", "CHARSET", "COLLATE", "DATABASE", "DELIMITER",
+	"DESCRIBE", "DUPLICATE", "ENGINE", "IGNORE", "KILL", "LOCK TABLES", "MODIFY",
+	"ON DUPLICATE KEY UPDATE", "PROCEDURE", "REGEXP", "RENAME", "REPLACE", "SHOW",
+	"STRAIGHT_JOIN", "UNLOCK TABLES", "UNSIGNED", "USE", "ZEROFILL",
+}
+
+var postgresKeywords = []string{
+	"ANALYZE", "ARRAY", "CONFLICT", "COPY", "DO NOTHING", "DO UPDATE", "FILTER",
+	"ILIKE", "LATERAL", "LISTEN", "MATERIALIZED", "NOTIFY", "NULLS FIRST",
+	"NULLS LAST", "ON CONFLICT", "OVER", "PARTITION BY", "RETURNING", "SCHEMA",
+	"SEQUENCE", "SIMILAR TO", "TABLESAMPLE", "VACUUM", "WINDOW",
+}
+
+var commonBuiltins = []string{
+	"ABS", "AVG", "CAST", "CEIL", "COALESCE", "CONCAT", "COUNT", "CURRENT_DATE",
+	"CURRENT_TIMESTAMP", "FLOOR", "GREATEST", "LEAST", "LENGTH", "LOWER", "MAX",
+	"MIN", "NOW", "NULLIF", "REPLACE", "ROUND", "ROW_NUMBER", "RANK", "DENSE_RANK",
+	"LAG", "LEAD", "SUBSTRING", "SUM", "TRIM", "UPPER",
+}
+
+var mysqlBuiltins = []string{
+	"DATE_ADD", "DATE_FORMAT", "DATE_SUB", "DATEDIFF", "FROM_UNIXTIME",
+	"GROUP_CONCAT", "IFNULL", "IF", "JSON_ARRAYAGG", "JSON_EXTRACT",
+	"JSON_OBJECT", "JSON_UNQUOTE", "LAST_INSERT_ID", "STR_TO_DATE",
+	"UNIX_TIMESTAMP", "UUID",
+}
+
+var postgresBuiltins = []string{
+	"AGE", "ARRAY_AGG", "ARRAY_LENGTH", "DATE_PART", "DATE_TRUNC",
+	"GENERATE_SERIES", "GEN_RANDOM_UUID", "JSONB_AGG", "JSONB_BUILD_OBJECT",
+	"JSONB_EXTRACT_PATH_TEXT", "REGEXP_REPLACE", "STRING_AGG", "TO_CHAR",
+	"TO_TIMESTAMP", "UNNEST",
+}
+
+// sqlKeywords returns the keywords and built-in functions of a dialect
+func sqlKeywords(mysql bool) (keywords, builtins []string) {
+	keywords = append([]string{}, commonKeywords...)
+	builtins = append([]string{}, commonBuiltins...)
+	if mysql {
+		keywords = append(keywords, mysqlKeywords...)
+		builtins = append(builtins, mysqlBuiltins...)
+	} else {
+		keywords = append(keywords, postgresKeywords...)
+		builtins = append(builtins, postgresBuiltins...)
+	}
+	sort.Strings(keywords)
+	sort.Strings(builtins)
+	return keywords, builtins
+}
+
+// qualifiedKey joins the schema and name of an object as in CompletionMetadata.Removed
+func qualifiedKey(schema, name string) string {
+	return schema + "." + name
+}
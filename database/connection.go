@@ -1,12 +1,16 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // Manager handles all database operations
@@ -15,12 +19,96 @@ type Manager struct {
 	config *ConnectionConfig
 	driver Driver
 	tunnel *SSHTunnel
+	lease  *vaultLease
 	mu     sync.RWMutex
+
+	// refreshed records when materialized views were refreshed from this session
+	refreshed map[string]time.Time
+
+	// activity records every statement issued on the connection
+	activity *ActivityLog
+
+	// interceptors wrap every statement issued on the connection
+	interceptors *interceptorChain
+
+	// ctx is the Wails application context used to emit events
+	ctx context.Context
+
+	// sink receives events when running without a window, e.g. in headless mode
+	sink func(event string, data interface{})
+
+	// loadTest is the running or last finished load test
+	loadTest *loadTest
+
+	// operations are the queries, transfers and load tests in flight
+	operations *operationTracker
+
+	// streams are the result sets open for reading in chunks
+	streams *streamRegistry
+
+	// history records the statements the user runs, when set
+	history *QueryHistory
+
+	// completion tracks the metadata sent to the editor's autocomplete
+	completion *completionCache
+
+	// tabs are the connections held by query tabs with an open transaction
+	tabs *tabRegistry
+
+	// jobs are the scripts detached to run in the background
+	jobs *jobRegistry
+
+	// copies are the table copies run in the background
+	copies *copyRegistry
+
+	// listener receives the notifications of the channels the session listens on
+	listener *channelListener
+
+	// changeSets are the grid edits staged to be applied together
+	changeSets *changeSetRegistry
+
+	// undo holds the recent grid changes that can be reverted
+	undo *undoLog
 }
 
 // NewManager creates a new database manager
 func NewManager() *Manager {
-	return &Manager{}
+	m := &Manager{
+		activity:     NewActivityLog(),
+		interceptors: &interceptorChain{},
+		operations:   newOperationTracker(),
+		streams:      &streamRegistry{streams: make(map[int64]*resultStream)},
+		completion:   newCompletionCache(),
+		tabs:         &tabRegistry{tabs: make(map[string]*tabSession)},
+		jobs:         &jobRegistry{jobs: make(map[int64]*backgroundJob)},
+		copies:       &copyRegistry{copies: make(map[int64]*tableCopy)},
+		listener:     &channelListener{},
+		changeSets:   &changeSetRegistry{sets: make(map[string]*ChangeSet)},
+		undo:         &undoLog{},
+	}
+	m.RegisterInterceptor("activity", m.activity.intercept)
+	return m
+}
+
+// SetContext sets the application context used to emit events to the frontend
+func (m *Manager) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// SetEventSink sets a function that receives every event the manager emits
+func (m *Manager) SetEventSink(sink func(event string, data interface{})) {
+	m.sink = sink
+}
+
+// emit sends an event to the frontend when running inside the app, and to the
+// event sink if one is set
+func (m *Manager) emit(event string, data interface{}) {
+	if m.ctx != nil {
+		runtime.EventsEmit(m.ctx, event, data)
+	}
+	if m.sink != nil {
+		m.sink(event, data)
+	}
 }
 
 // getDriver returns the appropriate driver for the config
@@ -29,17 +117,20 @@ func (m *Manager) getDriver(config ConnectionConfig) (Driver, error) {
 	case "mysql", "": // Default to mysql for backward compatibility
 		return &MySQLDriver{}, nil
 	case "postgres":
-		return &PostgresDriver{}, nil
+		return &PostgresDriver{Schema: config.Schema}, nil
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", config.Type)
 	}
 }
 
 // Connect establishes a connection to the database
-func (m *Manager) Connect(config ConnectionConfig) error {
+func (m *Manager) Connect(config ConnectionConfig) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// Driver errors may echo the connection string
+	defer func() { err = sanitizeError(err, &config) }()
+
 	// Close existing connection if any
 	if m.db != nil {
 		m.db.Close()
@@ -48,16 +139,33 @@ func (m *Manager) Connect(config ConnectionConfig) error {
 		m.tunnel.Close()
 		m.tunnel = nil
 	}
+	if m.lease != nil {
+		m.lease.Close()
+		m.lease = nil
+	}
+
+	// Fetch credentials from Vault or AWS if configured
+	lease, err := resolveCredentials(&config)
+	if err != nil {
+		return err
+	}
+	closeLease := func() {
+		if lease != nil {
+			lease.Close()
+		}
+	}
 
 	// Setup SSH tunnel if configured
 	if config.UseSSHTunnel {
 		tunnel, err := NewSSHTunnel(config)
 		if err != nil {
+			closeLease()
 			return fmt.Errorf("failed to create SSH tunnel: %w", err)
 		}
 
 		localAddr, err := tunnel.Start(config)
 		if err != nil {
+			closeLease()
 			return fmt.Errorf("failed to start SSH tunnel: %w", err)
 		}
 
@@ -67,6 +175,7 @@ func (m *Manager) Connect(config ConnectionConfig) error {
 		host, portStr, err := net.SplitHostPort(localAddr)
 		if err != nil {
 			tunnel.Close()
+			closeLease()
 			return fmt.Errorf("failed to parse tunnel address: %w", err)
 		}
 		port, _ := strconv.Atoi(portStr)
@@ -80,6 +189,7 @@ func (m *Manager) Connect(config ConnectionConfig) error {
 			m.tunnel.Close()
 			m.tunnel = nil
 		}
+		closeLease()
 		return err
 	}
 
@@ -89,17 +199,27 @@ func (m *Manager) Connect(config ConnectionConfig) error {
 			m.tunnel.Close()
 			m.tunnel = nil
 		}
+		closeLease()
 		return err
 	}
 
 	m.db = db
+	m.lease = lease
 	m.config = &config
 	m.driver = driver
+	m.completion.reset()
 	return nil
 }
 
 // Disconnect closes the database connection
 func (m *Manager) Disconnect() error {
+	// Open result sets and tab transactions would keep the pool from closing
+	m.closeStreams()
+	m.closeTabs()
+	m.closeListener()
+	m.changeSets.clear()
+	m.ClearUndoLog()
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -121,30 +241,64 @@ func (m *Manager) Disconnect() error {
 		m.tunnel = nil
 	}
 
+	if m.lease != nil {
+		if err := m.lease.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		m.lease = nil
+	}
+
 	if len(errs) > 0 {
 		return errs[0]
 	}
 	return nil
 }
 
-// TestConnection tests if a connection can be established
-func (m *Manager) TestConnection(config ConnectionConfig) (bool, error) {
+// TestConnection connects with the given config and reports latency, server
+// details and a permission summary
+func (m *Manager) TestConnection(config ConnectionConfig) (_ *ConnectionDiagnostics, err error) {
+	defer func() { err = sanitizeError(err, &config) }()
+
 	driver, err := m.getDriver(config)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
+	lease, err := resolveCredentials(&config)
+	if err != nil {
+		return nil, err
+	}
+	if lease != nil {
+		defer lease.Close()
+	}
+
+	start := time.Now()
 	db, err := driver.Connect(config)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	defer db.Close()
+	connectTime := time.Since(start)
 
+	start = time.Now()
 	if err := db.Ping(); err != nil {
-		return false, fmt.Errorf("failed to ping: %w", err)
+		return nil, fmt.Errorf("failed to ping: %w", err)
 	}
 
-	return true, nil
+	diag := &ConnectionDiagnostics{
+		Success:       true,
+		ConnectTimeMs: durationMs(connectTime),
+		LatencyMs:     durationMs(time.Since(start)),
+		Permissions:   []string{},
+	}
+	driver.GetDiagnostics(newInstrumentedDB(db, m.interceptors), diag)
+
+	return diag, nil
+}
+
+// durationMs converts a duration to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
 }
 
 // IsConnected returns whether we're connected to a database
@@ -161,9 +315,42 @@ func (m *Manager) GetCurrentConfig() *ConnectionConfig {
 	return m.config
 }
 
-// getDB returns the database connection for internal use
-func (m *Manager) getDB() *sql.DB {
+// getDB returns the database connection for internal use. Statements run
+// through it pass the interceptor chain.
+func (m *Manager) getDB() *instrumentedDB {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.db == nil {
+		return nil
+	}
+	return newInstrumentedDB(m.db, m.interceptors)
+}
+
+// tableDriver returns the driver that builds statements on tables of a schema,
+// the connection's default one when schema is empty. Only PostgreSQL tables
+// are schema-qualified; MySQL names them by database.
+func (m *Manager) tableDriver(schema string) Driver {
+	driver := m.currentDriver()
+	if _, ok := driver.(*PostgresDriver); ok && schema != "" {
+		return &PostgresDriver{Schema: schema}
+	}
+	return driver
+}
+
+// resolveSchema returns the PostgreSQL schema tables of schema are in, the
+// connection's when it is empty, so that both spellings name the same table.
+// It is empty on MySQL.
+func (m *Manager) resolveSchema(schema string) string {
+	if driver, ok := m.tableDriver(schema).(*PostgresDriver); ok {
+		return driver.schemaName()
+	}
+	return ""
+}
+
+// currentDriver returns the driver of the current connection. Connecting
+// replaces it while other calls may run, so it is read under the lock.
+func (m *Manager) currentDriver() Driver {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.db
+	return m.driver
 }
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ConnectionStrings renders a connection profile for use in other tools. The
+// password is always masked; with an SSH tunnel the strings point at the
+// database host as seen from the bastion.
+type ConnectionStrings struct {
+	URI  string `json:"uri"`
+	CLI  string `json:"cli"` // psql or mysql command line; the password is prompted for
+	JDBC string `json:"jdbc"`
+	Go   string `json:"go"` // database/sql snippet using the driver Mergen connects with
+}
+
+// GetConnectionStrings renders config as URI, CLI, JDBC and Go connection strings
+func (m *Manager) GetConnectionStrings(config ConnectionConfig) (*ConnectionStrings, error) {
+	driver, err := m.getDriver(config)
+	if err != nil {
+		return nil, err
+	}
+
+	strs := driver.ConnectionStrings(config)
+	return &strs, nil
+}
+
+// maskedConfig replaces the password of config with a placeholder
+func maskedConfig(config ConnectionConfig) ConnectionConfig {
+	if config.Password != "" || config.UseVault || config.UseAWSSecret {
+		config.Password = redacted
+	}
+	return config
+}
+
+// plainMask undoes the URL encoding of the password placeholder for readability
+func plainMask(s string) string {
+	return strings.ReplaceAll(s, url.QueryEscape(redacted), redacted)
+}
+
+// shellQuote quotes s for POSIX shells when it contains anything but safe characters
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.:/@%+=,") == "" {
+		return s
+	}
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", `'\''`))
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetConstraints returns the CHECK and UNIQUE constraints of a table
+func (m *Manager) GetConstraints(database, schema, table string) ([]ConstraintInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	constraints, err := m.tableDriver(schema).GetConstraints(db, database, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get constraints: %w", err)
+	}
+
+	return constraints, nil
+}
+
+// constraintClause renders the ADD clause of a constraint, leaving the name to
+// the server when none is given
+func constraintClause(d Driver, c ConstraintInfo) (string, error) {
+	var clause string
+	switch strings.ToUpper(c.Type) {
+	case "UNIQUE":
+		if len(c.Columns) == 0 {
+			return "", fmt.Errorf("unique constraint %s has no columns", c.Name)
+		}
+		quoted := make([]string, len(c.Columns))
+		for i, col := range c.Columns {
+			quoted[i] = d.QuoteIdentifier(col)
+		}
+		clause = fmt.Sprintf("UNIQUE (%s)", strings.Join(quoted, ", "))
+	case "CHECK":
+		if strings.TrimSpace(c.Expression) == "" {
+			return "", fmt.Errorf("check constraint %s has no expression", c.Name)
+		}
+		clause = fmt.Sprintf("CHECK (%s)", c.Expression)
+	default:
+		return "", fmt.Errorf("unsupported constraint type: %s", c.Type)
+	}
+
+	if c.Name != "" {
+		clause = fmt.Sprintf("CONSTRAINT %s %s", d.QuoteIdentifier(c.Name), clause)
+	}
+	return clause, nil
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+// copyRows loads rows into a table with COPY FROM STDIN, which streams them
+// in a single command instead of parsing and planning a statement per batch
+func (d *PostgresDriver) copyRows(tx *instrumentedTx, table string, columns []string, rows []map[string]interface{}, progress *progressReporter) (int64, error) {
+	stmt, err := tx.Prepare(pq.CopyInSchema(d.schemaName(), table, columns...))
+	if err != nil {
+		return 0, fmt.Errorf("failed to start copy: %w", err)
+	}
+	defer stmt.Close()
+
+	values := make([]interface{}, len(columns))
+	for i, row := range rows {
+		for j, name := range columns {
+			// pq only sends driver values, e.g. int64 rather than the int32 of a parquet column
+			if values[j], err = driver.DefaultParameterConverter.ConvertValue(row[name]); err != nil {
+				return 0, fmt.Errorf("failed to convert row %d column %s: %w", i+1, name, err)
+			}
+		}
+		if _, err := stmt.Exec(values...); err != nil {
+			return 0, fmt.Errorf("failed to copy row %d: %w", i+1, err)
+		}
+		progress.add()
+	}
+
+	// Rows are buffered by pq; the final Exec without arguments flushes them
+	// and reports errors raised by the server, e.g. constraint violations
+	if _, err := stmt.Exec(); err != nil {
+		return 0, fmt.Errorf("failed to copy rows: %w", err)
+	}
+	return int64(len(rows)), nil
+}
+
+// copyOutCSV writes the rows of query to a CSV file with COPY TO STDOUT, so the
+// server formats them rather than each value being scanned and encoded here.
+// lib/pq cannot read COPY output, so it runs on a connection of its own.
+func (m *Manager) copyOutCSV(ctx context.Context, db *instrumentedDB, driver *PostgresDriver, query, outputPath string, opts CSVExportOptions, progress *progressReporter) error {
+	m.mu.RLock()
+	config := m.config
+	m.mu.RUnlock()
+	if config == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	conn, err := pgconn.Connect(ctx, driver.buildDSN(*config))
+	if err != nil {
+		return fmt.Errorf("failed to open copy connection: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	out, err := m.createOutput(ctx, outputPath, "text/csv")
+	if err != nil {
+		return err
+	}
+	defer out.Abort()
+
+	buf := bufio.NewWriter(out)
+	copyQuery := fmt.Sprintf("COPY (%s) TO STDOUT WITH (%s)", query, opts.copyOptions())
+	err = db.chain.run(&Statement{Kind: StatementQuery, Query: copyQuery}, func(s *Statement) error {
+		_, err := conn.CopyTo(ctx, &copyRowCounter{w: buf, progress: progress, seenHeader: opts.NoHeader}, s.Query)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("copy failed: %w", err)
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// copyRowCounter counts the rows of COPY output as they are written. The
+// server sends one row per write, the first one being the header if any.
+type copyRowCounter struct {
+	w          io.Writer
+	progress   *progressReporter
+	seenHeader bool
+}
+
+func (c *copyRowCounter) Write(p []byte) (int, error) {
+	if c.seenHeader {
+		c.progress.add()
+	}
+	c.seenHeader = true
+	return c.w.Write(p)
+}
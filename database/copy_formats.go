@@ -0,0 +1,213 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FormatCopy renders copied rows in the format chosen by opts.Format: tab
+// separated text (default), csv, markdown, json, html or insert. INSERT
+// statements are written in the dialect of the connection unless one is given.
+func (m *Manager) FormatCopy(columns []ColumnInfo, rows [][]interface{}, opts CopyOptions) (string, error) {
+	switch opts.Format {
+	case "":
+		return FormatCells(columns, rows, opts)
+	case "tsv":
+		opts.Delimiter = "\t"
+		return FormatCells(columns, rows, opts)
+	case "csv":
+		if opts.Delimiter == "" {
+			opts.Delimiter = ","
+		}
+		return FormatCells(columns, rows, opts)
+	case "markdown":
+		return formatMarkdown(columns, rows, opts)
+	case "json":
+		return formatJSON(columns, rows, opts)
+	case "html":
+		return FormatCellsHTML(columns, rows, opts)
+	case "insert":
+		return m.formatInserts(columns, rows, opts)
+	default:
+		return "", fmt.Errorf("unsupported copy format: %s", opts.Format)
+	}
+}
+
+// formatMarkdown renders rows as a Markdown table, numbers aligned right.
+// Markdown tables need a header, so one is always written.
+func formatMarkdown(columns []ColumnInfo, rows [][]interface{}, opts CopyOptions) (string, error) {
+	opts = opts.withDefaults()
+	// Cells are single lines: line breaks become <br> and other markup is escaped
+	escape := strings.NewReplacer("|", `\|`, "<", "&lt;", "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+	var sb strings.Builder
+	headers := make([]string, len(columns))
+	rules := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = escape.Replace(col.Name)
+		rules[i] = "---"
+		if isNumericCategory(typeCategory(col.Type)) {
+			rules[i] = "---:"
+		}
+	}
+	sb.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	sb.WriteString("| " + strings.Join(rules, " | ") + " |\n")
+
+	for _, row := range rows {
+		if len(row) != len(columns) {
+			return "", fmt.Errorf("row has %d values but %d columns were given", len(row), len(columns))
+		}
+		cells := make([]string, len(row))
+		for i, val := range row {
+			if val == nil {
+				cells[i] = opts.NullMarker
+				continue
+			}
+			cells[i] = escape.Replace(formatCell(val, typeCategory(columns[i].Type), opts))
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return sb.String(), nil
+}
+
+// formatJSON renders rows as an array of objects with the keys in column
+// order. Numbers, booleans and JSON columns keep their JSON types, exact
+// numbers included; other values are formatted as text like FormatCells.
+func formatJSON(columns []ColumnInfo, rows [][]interface{}, opts CopyOptions) (string, error) {
+	opts = opts.withDefaults()
+
+	var sb strings.Builder
+	sb.WriteString("[")
+	for r, row := range rows {
+		if len(row) != len(columns) {
+			return "", fmt.Errorf("row has %d values but %d columns were given", len(row), len(columns))
+		}
+		if r > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n  {")
+		for i, val := range row {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			key, _ := json.Marshal(columns[i].Name)
+			value, err := json.Marshal(jsonCopyValue(val, typeCategory(columns[i].Type), opts))
+			if err != nil {
+				return "", fmt.Errorf("failed to encode column %s: %w", columns[i].Name, err)
+			}
+			sb.WriteString("\n    " + string(key) + ": " + string(value))
+		}
+		if len(row) > 0 {
+			sb.WriteString("\n  ")
+		}
+		sb.WriteString("}")
+	}
+	if len(rows) > 0 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("]\n")
+	return sb.String(), nil
+}
+
+// jsonCopyValue returns the value of a cell as it should be encoded in JSON
+func jsonCopyValue(val interface{}, category string, opts CopyOptions) interface{} {
+	if val == nil {
+		return nil
+	}
+	text := ""
+	switch v := val.(type) {
+	case string:
+		text = v
+	case []byte:
+		if category == typeCategoryBinary {
+			return formatCell(v, category, opts)
+		}
+		text = string(v)
+	case float64:
+		return jsonFloat(v)
+	case float32:
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			return jsonFloat(f)
+		}
+		return val
+	default:
+		if category == typeCategoryTimestamp || category == typeCategoryDate || category == typeCategoryBinary {
+			return formatCell(val, category, opts)
+		}
+		// Numbers, booleans, and lists and objects decoded from JSON columns
+		return val
+	}
+
+	switch {
+	case isNumericCategory(category):
+		// Exact numerics arrive as text and keep every digit. NaN, Infinity
+		// and the like parse as floats but are not JSON numbers.
+		if _, err := strconv.ParseFloat(text, 64); err == nil && json.Valid([]byte(text)) {
+			return json.Number(text)
+		}
+	case category == typeCategoryBoolean:
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	case category == typeCategoryJSON:
+		if json.Valid([]byte(text)) {
+			return json.RawMessage(text)
+		}
+	}
+	return formatCell(text, category, opts)
+}
+
+// jsonFloat returns a float, or NaN and the infinities, which JSON has no
+// numbers for, as strings spelled the way PostgreSQL does
+func jsonFloat(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return f
+}
+
+// isNumericCategory reports whether values of a type category are numbers
+func isNumericCategory(category string) bool {
+	return category == typeCategoryInteger || category == typeCategoryDecimal || category == typeCategoryFloat
+}
+
+// formatInserts renders rows as INSERT statements into opts.Table, leaving
+// out generated columns which the target computes itself
+func (m *Manager) formatInserts(columns []ColumnInfo, rows [][]interface{}, opts CopyOptions) (string, error) {
+	if opts.Table == "" {
+		return "", fmt.Errorf("a table name is required for INSERT statements")
+	}
+	if len(rows) == 0 {
+		return "", fmt.Errorf("no rows to generate statements for")
+	}
+	g, err := m.statementWriter(opts.Dialect)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(columns))
+	byName := make(map[string]ColumnInfo, len(columns))
+	var included []int
+	for i, col := range columns {
+		names[i] = col.Name
+		byName[col.Name] = col
+		if col.Generated == "" {
+			included = append(included, i)
+		}
+	}
+
+	var sb strings.Builder
+	target := qualifiedName(g.quote, strings.Split(opts.Table, ".")...)
+	if err := g.inserts(&sb, target, byName, names, included, rows, opts.MultiRow); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
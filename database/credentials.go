@@ -0,0 +1,26 @@
+package database
+
+import (
+	"fmt"
+)
+
+// resolveCredentials fills in the user and password from an external secret
+// store when the profile references one. A returned lease must be closed once
+// the connection is no longer needed.
+func resolveCredentials(config *ConnectionConfig) (*vaultLease, error) {
+	switch {
+	case config.UseVault:
+		user, password, lease, err := fetchVaultCredentials(*config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch Vault credentials: %w", err)
+		}
+		config.User = user
+		config.Password = password
+		return lease, nil
+	case config.UseAWSSecret:
+		if err := fetchAWSCredentials(config); err != nil {
+			return nil, fmt.Errorf("failed to fetch AWS credentials: %w", err)
+		}
+	}
+	return nil, nil
+}
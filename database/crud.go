@@ -1,31 +1,93 @@
 package database
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
 )
 
 // TableDataRequest represents a request for paginated table data
 type TableDataRequest struct {
-	Database string `json:"database"`
-	Table    string `json:"table"`
-	Page     int    `json:"page"`
-	PageSize int    `json:"pageSize"`
-	OrderBy  string `json:"orderBy"`
-	OrderDir string `json:"orderDir"`
-	Filters  string `json:"filters"`
+	Database string            `json:"database"`
+	Schema   string            `json:"schema,omitempty"` // PostgreSQL schema; the connection's default when empty
+	Table    string            `json:"table"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"pageSize"`
+	OrderBy  []SortColumn      `json:"orderBy,omitempty"` // Sort columns in priority order; primary key when empty
+	Filters  []FilterCondition `json:"filters,omitempty"`
+
+	// Quick search: rows where any of SearchColumns, by default every
+	// non-binary column, contains Search as text, ignoring case. The total of
+	// a search is counted up to quickSearchCountLimit.
+	Search        string   `json:"search,omitempty"`
+	SearchColumns []string `json:"searchColumns,omitempty"`
+
+	// Values nested in JSON columns shown as extra read-only columns
+	JSONPaths []JSONPathColumn `json:"jsonPaths,omitempty"`
+
+	// PaginationOffset (default) pages by Page; PaginationKeyset returns the
+	// rows after the cursor After, taken from NextCursor of the previous page
+	Pagination string        `json:"pagination,omitempty"`
+	After      []interface{} `json:"after,omitempty"`
+
+	// Display formatters evaluated on the returned page
+	Formatters []ColumnFormatter `json:"formatters,omitempty"`
+
+	// PostGIS columns to project as GeoJSON, set by GetTableData
+	geoColumns []string
+}
+
+// SortColumn is one column of a table data sort order
+type SortColumn struct {
+	Column    string `json:"column"`
+	Direction string `json:"direction"` // ASC (default) or DESC
+}
+
+// desc reports whether the column sorts in descending order
+func (s SortColumn) desc() bool {
+	return strings.EqualFold(s.Direction, "DESC")
 }
 
 // TableDataResponse represents paginated table data with metadata
 type TableDataResponse struct {
 	Columns    []ColumnInfo    `json:"columns"`
 	Rows       [][]interface{} `json:"rows"`
+	RowIDs     []string        `json:"rowIds"` // Stable key per row, see rowIDs
 	TotalRows  int64           `json:"totalRows"`
+	Capped     bool            `json:"capped,omitempty"` // TotalRows stopped at the quick search count limit
 	Page       int             `json:"page"`
 	PageSize   int             `json:"pageSize"`
 	TotalPages int             `json:"totalPages"`
-	PrimaryKey string          `json:"primaryKey"`
+	PrimaryKey []string        `json:"primaryKey"` // Key columns in table order; empty when rows can't be edited
+	ReadOnly   bool            `json:"readOnly"`   // Views can't be edited through the grid
+
+	// How rows are identified when the table has no primary key, see
+	// RowMatchCTID and RowMatchRow; empty when it has one or is read-only
+	RowMatch string `json:"rowMatch,omitempty"`
+	// ctid of each row, for RowMatchCTID
+	RowLocators []string `json:"rowLocators,omitempty"`
+
+	// Cursor of the next page in keyset mode; empty on the last page
+	NextCursor []interface{} `json:"nextCursor,omitempty"`
+
+	// Formatted values keyed by column name, one entry per row
+	Display map[string][]string `json:"display,omitempty"`
+
+	// Workspace translation labels keyed by column name, then raw value
+	Translations map[string]map[string]string `json:"translations,omitempty"`
 }
 
+// quickSearchCountLimit bounds the rows counted for a quick search, which
+// can't use indexes and would otherwise scan the whole table twice
+const quickSearchCountLimit = 10000
+
 // RowData represents a single row with column-value pairs
 type RowData map[string]interface{}
 
@@ -37,24 +99,61 @@ func (m *Manager) GetTableData(req TableDataRequest) (*TableDataResponse, error)
 	}
 
 	// Get columns info
-	columns, err := m.GetColumns(req.Database, req.Table)
+	columns, err := m.GetColumns(req.Database, req.Schema, req.Table)
 	if err != nil {
 		return nil, err
 	}
 
-	// Find primary key
-	var primaryKey string
-	for _, col := range columns {
-		if col.Key == "PRI" {
-			primaryKey = col.Name
-			break
+	// Views are shown read-only and have no primary key to edit by
+	readOnly := m.isView(req.Database, req.Schema, req.Table)
+
+	// Find primary key; empty rather than null for views and keyless tables
+	primaryKey := []string{}
+	var rowMatch string
+	if !readOnly {
+		primaryKey = append(primaryKey, primaryKeyColumns(columns)...)
+		if len(primaryKey) == 0 {
+			rowMatch = m.tableDriver(req.Schema).RowMatch()
+		}
+	}
+
+	switch req.Pagination {
+	case "", PaginationOffset:
+	case PaginationKeyset:
+		if len(primaryKey) == 0 {
+			return nil, fmt.Errorf("keyset pagination requires a primary key")
 		}
+	default:
+		return nil, fmt.Errorf("unsupported pagination mode: %s", req.Pagination)
+	}
+
+	countLimit := 0
+	if req.Search != "" {
+		search, err := quickSearchFilter(columns, req.SearchColumns, req.Search)
+		if err != nil {
+			return nil, err
+		}
+		req.Filters = append(append([]FilterCondition{}, req.Filters...), search)
+		countLimit = quickSearchCountLimit
 	}
 
 	// Get total row count
 	var totalRows int64
-	countQuery := m.driver.BuildCountQuery(req.Database, req.Table, req.Filters)
-	if err := db.QueryRow(countQuery).Scan(&totalRows); err != nil {
+	countQuery, countArgs, err := m.tableDriver(req.Schema).BuildCountQuery(req.Database, req.Table, req.Filters, countLimit)
+	if err != nil {
+		return nil, err
+	}
+	timeout := m.gridTimeout()
+	countCtx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		countCtx, cancel = context.WithTimeout(countCtx, timeout)
+		defer cancel()
+	}
+	if err := db.withContext(countCtx).QueryRow(countQuery, countArgs...).Scan(&totalRows); err != nil {
+		if countCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("failed to count rows: statement timed out after %s", timeout)
+		}
 		return nil, fmt.Errorf("failed to count rows: %w", err)
 	}
 
@@ -68,112 +167,387 @@ func (m *Manager) GetTableData(req TableDataRequest) (*TableDataResponse, error)
 		page = 1
 	}
 
-	query := m.driver.BuildTableDataQuery(req, primaryKey)
+	if _, ok := m.tableDriver(req.Schema).(*PostgresDriver); ok {
+		req.geoColumns = geoColumnNames(columns)
+	}
+
+	locate := rowMatch == RowMatchCTID
+	query, args, err := m.tableDriver(req.Schema).BuildTableDataQuery(req, primaryKey, locate)
+	if err != nil {
+		return nil, err
+	}
 
 	// Execute query
-	result, err := m.ExecuteQuery(query)
+	result, err := m.executeQuery(timeout, false, query, args...)
 	if err != nil {
 		return nil, err
 	}
 
+	// Split the locator column off the rows
+	var locators []string
+	if locate {
+		locators = make([]string, len(result.Rows))
+		for i, row := range result.Rows {
+			if n := len(row); n > 0 {
+				locators[i] = fmt.Sprint(row[n-1])
+				result.Rows[i] = row[:n-1]
+			}
+		}
+	}
+
+	// PostGIS values are returned as GeoJSON and arrays as lists
+	splitGeoJSON(columns, result.Rows, req.geoColumns)
+	structureArrays(columns, result.Rows)
+
+	// Projected JSON paths follow the table's columns
+	columns = append(columns, jsonPathColumns(req.JSONPaths)...)
+
+	var cursor []interface{}
+	if req.Pagination == PaginationKeyset {
+		cursor = nextCursor(columns, result.Rows, tableDataOrder(req, primaryKey), pageSize)
+	}
+
 	totalPages := int(totalRows) / pageSize
 	if int(totalRows)%pageSize != 0 {
 		totalPages++
 	}
 
 	return &TableDataResponse{
-		Columns:    columns,
-		Rows:       result.Rows,
-		TotalRows:  totalRows,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
-		PrimaryKey: primaryKey,
+		Columns:     columns,
+		Rows:        result.Rows,
+		RowIDs:      rowIDs(columns, result.Rows, primaryKey),
+		TotalRows:   totalRows,
+		Capped:      countLimit > 0 && totalRows >= int64(countLimit),
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  totalPages,
+		PrimaryKey:  primaryKey,
+		ReadOnly:    readOnly,
+		RowMatch:    rowMatch,
+		RowLocators: locators,
+		NextCursor:  cursor,
+		Display:     tableDisplay(columns, result.Rows, req.Formatters),
 	}, nil
 }
 
+// tableDisplay renders the formatted columns of a page, and the binary and
+// PostGIS columns that have no formatter
+func tableDisplay(columns []ColumnInfo, rows [][]interface{}, formatters []ColumnFormatter) map[string][]string {
+	display := ApplyColumnFormatters(columns, rows, formatters)
+	display = binaryDisplay(columns, rows, display)
+	return geoDisplay(columns, rows, display)
+}
+
+// primaryKeyColumns returns the names of the primary key columns
+func primaryKeyColumns(columns []ColumnInfo) []string {
+	var keys []string
+	for _, col := range columns {
+		if col.Key == "PRI" {
+			keys = append(keys, col.Name)
+		}
+	}
+	return keys
+}
+
+// keyValues checks that a value was given for every primary key column and
+// converts them to query arguments
+func keyValues(primaryKey []string, primaryValues []interface{}) ([]interface{}, error) {
+	if len(primaryKey) == 0 {
+		return nil, fmt.Errorf("no primary key provided")
+	}
+	if len(primaryValues) != len(primaryKey) {
+		return nil, fmt.Errorf("expected %d primary key values, got %d", len(primaryKey), len(primaryValues))
+	}
+	return paramValues(primaryValues), nil
+}
+
+// rowIDs derives a key for each row that survives refreshes and paging: a hash
+// of the primary key, or of the whole row for tables without one. Identical
+// rows of a keyless table are told apart by their occurrence on the page.
+func rowIDs(columns []ColumnInfo, rows [][]interface{}, primaryKey []string) []string {
+	var pkIdx []int
+	for _, key := range primaryKey {
+		for i, col := range columns {
+			if col.Name == key {
+				pkIdx = append(pkIdx, i)
+				break
+			}
+		}
+	}
+	if len(pkIdx) != len(primaryKey) {
+		pkIdx = nil
+	}
+
+	ids := make([]string, len(rows))
+	seen := make(map[string]int)
+	for r, row := range rows {
+		var key interface{} = row
+		prefix := "r"
+		if len(pkIdx) == 1 && pkIdx[0] < len(row) {
+			key, prefix = row[pkIdx[0]], "k"
+		} else if len(pkIdx) > 1 {
+			values := make([]interface{}, len(pkIdx))
+			for i, idx := range pkIdx {
+				if idx < len(row) {
+					values[i] = row[idx]
+				}
+			}
+			key, prefix = values, "k"
+		}
+
+		data, _ := json.Marshal(key)
+		sum := sha1.Sum(data)
+		id := prefix + hex.EncodeToString(sum[:8])
+		if n := seen[id]; n > 0 {
+			seen[id]++
+			id = fmt.Sprintf("%s-%d", id, n)
+		} else {
+			seen[id] = 1
+		}
+		ids[r] = id
+	}
+	return ids
+}
+
 // InsertRow inserts a new row into a table
-func (m *Manager) InsertRow(database, table string, data RowData) (*ExecuteResult, error) {
+func (m *Manager) InsertRow(database, schema, table string, data RowData) (*ExecuteResult, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
+	query, values, err := m.insertRowStatement(database, schema, table, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.execWrite(db, query, values)
+	if err != nil {
+		return nil, fmt.Errorf("insert failed: %w", err)
+	}
+	m.recordUndo(database, schema, table, m.insertedUndo(database, schema, table, data, result))
+
+	return result, nil
+}
+
+// PreviewInsertRow returns the INSERT that InsertRow would run
+func (m *Manager) PreviewInsertRow(database, schema, table string, data RowData) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	query, values, err := m.insertRowStatement(database, schema, table, data)
+	if err != nil {
+		return nil, err
+	}
+	return m.previewOf(query+m.tableDriver(schema).ReturningClause(), values), nil
+}
+
+// insertRowStatement builds the INSERT of a row and its arguments
+func (m *Manager) insertRowStatement(database, schema, table string, data RowData) (string, []interface{}, error) {
+	data, err := m.writableData(database, schema, table, data)
+	if err != nil {
+		return "", nil, err
+	}
 	if len(data) == 0 {
-		return nil, fmt.Errorf("no data provided")
+		return "", nil, fmt.Errorf("no data provided")
 	}
 
-	var columns []string
-	var values []interface{}
+	columns, values := rowColumns(data)
+	return m.tableDriver(schema).BuildInsertQuery(database, table, columns), values, nil
+}
 
-	for col, val := range data {
+// rowColumns lists the columns of data by name with their values. The order
+// is fixed so that a previewed statement is exactly the one that runs.
+func rowColumns(data RowData) ([]string, []interface{}) {
+	columns := make([]string, 0, len(data))
+	for col := range data {
 		columns = append(columns, col)
-		values = append(values, val)
 	}
+	sort.Strings(columns)
+	values := make([]interface{}, len(columns))
+	for i, col := range columns {
+		values[i] = paramValue(data[col])
+	}
+	return columns, values
+}
 
-	query := m.driver.BuildInsertQuery(database, table, columns)
+// execWrite runs an INSERT or UPDATE, returning the written rows when the
+// driver supports RETURNING. RETURNING * needs SELECT on every column, so a
+// role that may only write gets the statement run without it.
+func (m *Manager) execWrite(db *instrumentedDB, query string, args []interface{}) (*ExecuteResult, error) {
+	returning := m.currentDriver().ReturningClause()
+	if returning == "" {
+		return execPlainWrite(db, query, args)
+	}
 
-	res, err := db.Exec(query, values...)
+	rows, err := db.Query(query+returning, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pgErrInsufficientPrivilege {
+		return execPlainWrite(db, query, args)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("insert failed: %w", err)
+		return nil, err
 	}
+	defer rows.Close()
 
+	returned, err := readQueryResult(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &ExecuteResult{
+		RowsAffected: int64(returned.RowCount),
+		Returned:     returned,
+	}, nil
+}
+
+// execPlainWrite runs an INSERT or UPDATE without returning the written rows
+func execPlainWrite(db *instrumentedDB, query string, args []interface{}) (*ExecuteResult, error) {
+	res, err := db.Exec(query, args...)
+	if err != nil {
+		return nil, err
+	}
 	rowsAffected, _ := res.RowsAffected()
 	lastInsertId, _ := res.LastInsertId()
-
 	return &ExecuteResult{
 		RowsAffected: rowsAffected,
 		LastInsertId: lastInsertId,
 	}, nil
 }
 
-// UpdateRow updates a row by primary key
-func (m *Manager) UpdateRow(database, table, primaryKey string, primaryValue interface{}, data RowData) (*ExecuteResult, error) {
+// UpdateRow updates a row by primary key. primaryValues holds one value per
+// primary key column, in the same order.
+func (m *Manager) UpdateRow(database, schema, table string, primaryKey []string, primaryValues []interface{}, data RowData) (*ExecuteResult, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	if len(data) == 0 {
-		return nil, fmt.Errorf("no data provided")
+	query, values, err := m.updateRowStatement(database, schema, table, primaryKey, primaryValues, data)
+	if err != nil {
+		return nil, err
 	}
 
-	var columns []string
-	var values []interface{}
+	undo := m.captureUpdate(database, schema, table, primaryKey, primaryValues, data)
+	result, err := m.execWrite(db, query, values)
+	if err != nil {
+		return nil, fmt.Errorf("update failed: %w", err)
+	}
+	m.recordUndo(database, schema, table, undo)
 
-	for col, val := range data {
-		columns = append(columns, col)
-		values = append(values, val)
+	return result, nil
+}
+
+// PreviewUpdateRow returns the UPDATE that UpdateRow would run
+func (m *Manager) PreviewUpdateRow(database, schema, table string, primaryKey []string, primaryValues []interface{}, data RowData) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
 	}
-	values = append(values, primaryValue)
 
-	query := m.driver.BuildUpdateQuery(database, table, primaryKey, columns)
+	query, values, err := m.updateRowStatement(database, schema, table, primaryKey, primaryValues, data)
+	if err != nil {
+		return nil, err
+	}
+	return m.previewOf(query+m.tableDriver(schema).ReturningClause(), values), nil
+}
 
-	res, err := db.Exec(query, values...)
+// updateRowStatement builds the UPDATE of a row by primary key and its arguments
+func (m *Manager) updateRowStatement(database, schema, table string, primaryKey []string, primaryValues []interface{}, data RowData) (string, []interface{}, error) {
+	keys, err := keyValues(primaryKey, primaryValues)
 	if err != nil {
-		return nil, fmt.Errorf("update failed: %w", err)
+		return "", nil, err
 	}
 
-	rowsAffected, _ := res.RowsAffected()
+	data, err = m.writableData(database, schema, table, data)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(data) == 0 {
+		return "", nil, fmt.Errorf("no data provided")
+	}
 
-	return &ExecuteResult{
-		RowsAffected: rowsAffected,
-	}, nil
+	columns, values := rowColumns(data)
+	return m.tableDriver(schema).BuildUpdateQuery(database, table, primaryKey, columns), append(values, keys...), nil
 }
 
-// DeleteRow deletes a row by primary key
-func (m *Manager) DeleteRow(database, table, primaryKey string, primaryValue interface{}) (*ExecuteResult, error) {
+// writableData returns data without the read-only (generated and identity)
+// columns of the table, which the database would reject. Values of JSON
+// columns are validated, see jsonParam.
+func (m *Manager) writableData(database, schema, table string, data RowData) (RowData, error) {
+	columns, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	writable := make(RowData, len(data))
+	for col, val := range data {
+		writable[col] = val
+	}
+	for _, col := range columns {
+		val, ok := writable[col.Name]
+		switch {
+		case !ok:
+		case col.ReadOnly:
+			delete(writable, col.Name)
+		case typeCategory(col.Type) == typeCategoryJSON:
+			if writable[col.Name], err = jsonParam(col.Name, val); err != nil {
+				return nil, err
+			}
+		case isGeoColumn(col):
+			if writable[col.Name], err = geoParam(col.Name, val); err != nil {
+				return nil, err
+			}
+		case isArrayColumn(col):
+			// Lists from the array editor; text is taken as an array literal
+			if list, ok := val.([]interface{}); ok {
+				writable[col.Name] = pgArrayLiteral(list)
+			}
+		}
+	}
+	return writable, nil
+}
+
+// readOnlyColumns returns the set of columns of a table that can't be written
+func (m *Manager) readOnlyColumns(database, schema, table string) (map[string]bool, error) {
+	columns, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	readOnly := make(map[string]bool)
+	for _, col := range columns {
+		if col.ReadOnly {
+			readOnly[col.Name] = true
+		}
+	}
+	return readOnly, nil
+}
+
+// DeleteRow deletes a row by primary key. primaryValues holds one value per
+// primary key column, in the same order.
+func (m *Manager) DeleteRow(database, schema, table string, primaryKey []string, primaryValues []interface{}) (*ExecuteResult, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	query := m.driver.BuildDeleteQuery(database, table, primaryKey)
+	keys, err := keyValues(primaryKey, primaryValues)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := db.Exec(query, primaryValue)
+	query := m.tableDriver(schema).BuildDeleteQuery(database, table, primaryKey)
+
+	undo := m.captureDelete(database, schema, table, primaryKey, [][]interface{}{primaryValues})
+	res, err := db.Exec(query, keys...)
 	if err != nil {
 		return nil, fmt.Errorf("delete failed: %w", err)
 	}
+	m.recordUndo(database, schema, table, undo)
 
 	rowsAffected, _ := res.RowsAffected()
 
@@ -182,8 +556,22 @@ func (m *Manager) DeleteRow(database, table, primaryKey string, primaryValue int
 	}, nil
 }
 
-// DeleteRows deletes multiple rows by primary key values
-func (m *Manager) DeleteRows(database, table, primaryKey string, primaryValues []interface{}) (*ExecuteResult, error) {
+// PreviewDeleteRow returns the DELETE that DeleteRow would run
+func (m *Manager) PreviewDeleteRow(database, schema, table string, primaryKey []string, primaryValues []interface{}) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	keys, err := keyValues(primaryKey, primaryValues)
+	if err != nil {
+		return nil, err
+	}
+	return m.previewOf(m.tableDriver(schema).BuildDeleteQuery(database, table, primaryKey), keys), nil
+}
+
+// DeleteRows deletes multiple rows by primary key values, one list of key
+// values per row
+func (m *Manager) DeleteRows(database, schema, table string, primaryKey []string, primaryValues [][]interface{}) (*ExecuteResult, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
@@ -193,12 +581,17 @@ func (m *Manager) DeleteRows(database, table, primaryKey string, primaryValues [
 		return &ExecuteResult{}, nil
 	}
 
-	query := m.driver.BuildBatchDeleteQuery(database, table, primaryKey, len(primaryValues))
+	query, args, err := m.deleteRowsStatement(database, schema, table, primaryKey, primaryValues)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := db.Exec(query, primaryValues...)
+	undo := m.captureDelete(database, schema, table, primaryKey, primaryValues)
+	res, err := db.Exec(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("delete failed: %w", err)
 	}
+	m.recordUndo(database, schema, table, undo)
 
 	rowsAffected, _ := res.RowsAffected()
 
@@ -207,14 +600,43 @@ func (m *Manager) DeleteRows(database, table, primaryKey string, primaryValues [
 	}, nil
 }
 
+// PreviewDeleteRows returns the DELETE that DeleteRows would run
+func (m *Manager) PreviewDeleteRows(database, schema, table string, primaryKey []string, primaryValues [][]interface{}) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if len(primaryValues) == 0 {
+		return &SQLPreview{Statements: []PreviewStatement{}}, nil
+	}
+
+	query, args, err := m.deleteRowsStatement(database, schema, table, primaryKey, primaryValues)
+	if err != nil {
+		return nil, err
+	}
+	return m.previewOf(query, args), nil
+}
+
+// deleteRowsStatement builds the DELETE of rows by primary key and its arguments
+func (m *Manager) deleteRowsStatement(database, schema, table string, primaryKey []string, primaryValues [][]interface{}) (string, []interface{}, error) {
+	args := make([]interface{}, 0, len(primaryValues)*len(primaryKey))
+	for _, values := range primaryValues {
+		keys, err := keyValues(primaryKey, values)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, keys...)
+	}
+	return m.tableDriver(schema).BuildBatchDeleteQuery(database, table, primaryKey, len(primaryValues)), args, nil
+}
+
 // GetDistinctValues returns distinct values for a column
-func (m *Manager) GetDistinctValues(database, table, column string) ([]string, error) {
+func (m *Manager) GetDistinctValues(database, schema, table, column string) ([]string, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	query := m.driver.BuildDistinctValuesQuery(database, table, column)
+	query := m.tableDriver(schema).BuildDistinctValuesQuery(database, table, column)
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
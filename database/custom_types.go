@@ -0,0 +1,280 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// CustomTypeInfo describes a user-defined PostgreSQL type
+type CustomTypeInfo struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"`                 // enum, composite, domain or range
+	Values     []string `json:"values,omitempty"`     // Enum labels in sort order
+	Definition string   `json:"definition,omitempty"` // Domain base type or composite attributes
+
+	// Composite fields, in order
+	Attributes []CompositeAttribute `json:"attributes,omitempty"`
+
+	// Domain base type and constraints
+	BaseType string             `json:"baseType,omitempty"`
+	NotNull  bool               `json:"notNull,omitempty"`
+	Default  string             `json:"default,omitempty"`
+	Checks   []DomainConstraint `json:"checks,omitempty"`
+}
+
+// CompositeAttribute is a field of a composite type
+type CompositeAttribute struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// DomainConstraint is a CHECK constraint of a domain
+type DomainConstraint struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"` // Refers to the checked value as VALUE
+}
+
+// GetCustomTypes returns the enum, composite, domain and range types of the
+// current schema. Only PostgreSQL is supported.
+func (m *Manager) GetCustomTypes() ([]CustomTypeInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	driver, ok := m.currentDriver().(*PostgresDriver)
+	if !ok {
+		return nil, fmt.Errorf("custom types are only available for PostgreSQL")
+	}
+
+	// Composite types backing tables and views are skipped (relkind 'c' only)
+	rows, err := db.Query(`
+		SELECT
+			t.typname,
+			CASE t.typtype WHEN 'e' THEN 'enum' WHEN 'c' THEN 'composite' WHEN 'd' THEN 'domain' ELSE 'range' END,
+			CASE t.typtype
+				WHEN 'd' THEN format_type(t.typbasetype, t.typtypmod)
+				WHEN 'c' THEN (
+					SELECT string_agg(quote_ident(a.attname) || ' ' || format_type(a.atttypid, a.atttypmod), ', ' ORDER BY a.attnum)
+					FROM pg_attribute a
+					WHERE a.attrelid = t.typrelid AND a.attnum > 0 AND NOT a.attisdropped
+				)
+				ELSE ''
+			END
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		LEFT JOIN pg_class c ON c.oid = t.typrelid
+		WHERE n.nspname = $1
+			AND t.typtype IN ('e', 'c', 'd', 'r')
+			AND (t.typtype <> 'c' OR c.relkind = 'c')
+		ORDER BY t.typname
+	`, driver.schemaName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom types: %w", err)
+	}
+	defer rows.Close()
+
+	types := []CustomTypeInfo{}
+	for rows.Next() {
+		var t CustomTypeInfo
+		if err := rows.Scan(&t.Name, &t.Kind, &t.Definition); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	labels, err := pgEnumLabels(db, driver.schemaName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enum values: %w", err)
+	}
+	attributes, err := pgCompositeAttributes(db, driver.schemaName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get composite attributes: %w", err)
+	}
+	domains, err := pgDomains(db, driver.schemaName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain constraints: %w", err)
+	}
+	for i := range types {
+		switch types[i].Kind {
+		case "enum":
+			types[i].Values = labels[types[i].Name]
+		case "composite":
+			types[i].Attributes = attributes[types[i].Name]
+		case "domain":
+			if d, ok := domains[types[i].Name]; ok {
+				types[i].BaseType, types[i].NotNull, types[i].Default, types[i].Checks = d.BaseType, d.NotNull, d.Default, d.Checks
+			}
+		}
+	}
+
+	return types, nil
+}
+
+// AddEnumValue adds a label to a PostgreSQL enum type. The label is appended
+// unless before or after names an existing label to place it next to.
+func (m *Manager) AddEnumValue(typeName, value, before, after string) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	driver, ok := m.currentDriver().(*PostgresDriver)
+	if !ok {
+		return fmt.Errorf("enum types are only available for PostgreSQL")
+	}
+	if value == "" {
+		return fmt.Errorf("enum value is required")
+	}
+
+	query := fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s", driver.qualify(typeName), quoteLiteral(value))
+	switch {
+	case before != "":
+		query += " BEFORE " + quoteLiteral(before)
+	case after != "":
+		query += " AFTER " + quoteLiteral(after)
+	}
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to add enum value: %w", err)
+	}
+
+	return nil
+}
+
+// pgEnumLabels returns the labels of the enum types in a schema, in sort
+// order. Only the named types are read when names are given.
+func pgEnumLabels(db Querier, schema string, names ...string) (map[string][]string, error) {
+	rows, err := db.Query(`
+		SELECT t.typname, e.enumlabel
+		FROM pg_enum e
+		JOIN pg_type t ON t.oid = e.enumtypid
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE n.nspname = $1 AND (COALESCE(cardinality($2::text[]), 0) = 0 OR t.typname = ANY($2))
+		ORDER BY t.typname, e.enumsortorder
+	`, schema, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	labels := make(map[string][]string)
+	for rows.Next() {
+		var typeName, label string
+		if err := rows.Scan(&typeName, &label); err != nil {
+			return nil, err
+		}
+		labels[typeName] = append(labels[typeName], label)
+	}
+	return labels, rows.Err()
+}
+
+// pgCompositeAttributes returns the fields of the composite types in a
+// schema, including the row types of tables and views. As every table has
+// one, only the named types are read when names are given.
+func pgCompositeAttributes(db Querier, schema string, names ...string) (map[string][]CompositeAttribute, error) {
+	rows, err := db.Query(`
+		SELECT t.typname, a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		JOIN pg_attribute a ON a.attrelid = t.typrelid
+		WHERE n.nspname = $1 AND t.typtype = 'c' AND a.attnum > 0 AND NOT a.attisdropped
+			AND (COALESCE(cardinality($2::text[]), 0) = 0 OR t.typname = ANY($2))
+		ORDER BY t.typname, a.attnum
+	`, schema, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attributes := make(map[string][]CompositeAttribute)
+	for rows.Next() {
+		var typeName string
+		var a CompositeAttribute
+		if err := rows.Scan(&typeName, &a.Name, &a.Type); err != nil {
+			return nil, err
+		}
+		attributes[typeName] = append(attributes[typeName], a)
+	}
+	return attributes, rows.Err()
+}
+
+// pgDomains returns the domains of a schema with their base type and
+// constraints. Only the named domains are read when names are given.
+func pgDomains(db Querier, schema string, names ...string) (map[string]*CustomTypeInfo, error) {
+	rows, err := db.Query(`
+		SELECT
+			t.typname,
+			format_type(t.typbasetype, t.typtypmod),
+			t.typnotnull,
+			COALESCE(t.typdefault, ''),
+			COALESCE(c.conname, ''),
+			COALESCE(pg_get_expr(c.conbin, 0), '')
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		LEFT JOIN pg_constraint c ON c.contypid = t.oid AND c.contype = 'c'
+		WHERE n.nspname = $1 AND t.typtype = 'd' AND (COALESCE(cardinality($2::text[]), 0) = 0 OR t.typname = ANY($2))
+		ORDER BY t.typname, c.conname
+	`, schema, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	domains := make(map[string]*CustomTypeInfo)
+	for rows.Next() {
+		d := &CustomTypeInfo{Kind: "domain", Checks: []DomainConstraint{}}
+		var check DomainConstraint
+		if err := rows.Scan(&d.Name, &d.BaseType, &d.NotNull, &d.Default, &check.Name, &check.Expression); err != nil {
+			return nil, err
+		}
+		if existing, ok := domains[d.Name]; ok {
+			d = existing
+		} else {
+			d.Definition = d.BaseType
+			domains[d.Name] = d
+		}
+		if check.Name != "" {
+			d.Checks = append(d.Checks, check)
+		}
+	}
+	return domains, rows.Err()
+}
+
+// quoteLiteral quotes a string as a SQL literal
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// mysqlEnumValues parses the labels of an enum('a','b') or set('a','b') column type
+func mysqlEnumValues(columnType string) []string {
+	lower := strings.ToLower(columnType)
+	if !strings.HasPrefix(lower, "enum(") && !strings.HasPrefix(lower, "set(") {
+		return nil
+	}
+
+	body := columnType[strings.Index(columnType, "(")+1 : strings.LastIndex(columnType, ")")]
+	var values []string
+	var sb strings.Builder
+	inQuote := false
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\'' && inQuote && i+1 < len(body) && body[i+1] == '\'':
+			sb.WriteByte('\'')
+			i++
+		case c == '\'':
+			if inQuote {
+				values = append(values, sb.String())
+				sb.Reset()
+			}
+			inQuote = !inQuote
+		case inQuote:
+			sb.WriteByte(c)
+		}
+	}
+	return values
+}
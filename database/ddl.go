@@ -0,0 +1,19 @@
+package database
+
+import "fmt"
+
+// GetTableDDL returns the CREATE statement of a table, including its
+// constraints, indexes and comments
+func (m *Manager) GetTableDDL(database, schema, table string) (string, error) {
+	db := m.getDB()
+	if db == nil {
+		return "", fmt.Errorf("not connected to database")
+	}
+
+	ddl, err := m.tableDriver(schema).GetTableDDL(db, database, table)
+	if err != nil {
+		return "", fmt.Errorf("failed to get table DDL: %w", err)
+	}
+
+	return ddl, nil
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+)
+
+// defaultKeyword matches defaults that are expressions although they do not
+// look like one, e.g. CURRENT_TIMESTAMP(3) or NULL
+var defaultKeyword = regexp.MustCompile(`(?i)^(NULL|TRUE|FALSE|CURRENT_TIMESTAMP|CURRENT_DATE|CURRENT_TIME|LOCALTIME|LOCALTIMESTAMP|CURRENT_USER)(\s*\(\s*\d*\s*\))?$|^NOW\s*\(\s*\d*\s*\)$`)
+
+// defaultValue renders the default of a column. Expressions and keywords are
+// used as is; anything else is a literal and quoted by literal. MySQL needs
+// expressions other than keywords in parentheses, which paren adds.
+func defaultValue(col ColumnInfo, literal func(string) string, paren bool) string {
+	value := strings.TrimSpace(col.Default)
+	if defaultKeyword.MatchString(value) {
+		return value
+	}
+	if !col.DefaultExpression {
+		return literal(col.Default)
+	}
+	if paren && !(strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")")) {
+		return "(" + value + ")"
+	}
+	return value
+}
+
+// defaultClause renders the DEFAULT clause of a column definition, or nothing
+// when the column has no default
+func defaultClause(col ColumnInfo, literal func(string) string, paren bool) string {
+	if col.Default == "" {
+		return ""
+	}
+	return " DEFAULT " + defaultValue(col, literal, paren)
+}
+
+// mysqlExtra drops DEFAULT_GENERATED from the Extra of a column: MySQL reports
+// it for expression defaults but does not accept it in a column definition
+func mysqlExtra(extra string) string {
+	return strings.TrimSpace(strings.Replace(extra, "DEFAULT_GENERATED", "", 1))
+}
@@ -0,0 +1,197 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiscoveredServer represents a database server found on the local machine
+type DiscoveredServer struct {
+	Type      string           `json:"type"` // postgres, mysql, mssql, redis, mongodb
+	Host      string           `json:"host"`
+	Port      int              `json:"port"`
+	Source    string           `json:"source"` // "port" or "docker"
+	Container string           `json:"container,omitempty"`
+	Image     string           `json:"image,omitempty"`
+	Supported bool             `json:"supported"` // Whether Mergen can connect to this type
+	Config    ConnectionConfig `json:"config"`    // Prefilled config for one-click connection creation
+}
+
+// wellKnownPorts maps default server ports to their database type
+var wellKnownPorts = map[int]string{
+	5432:  "postgres",
+	3306:  "mysql",
+	1433:  "mssql",
+	6379:  "redis",
+	27017: "mongodb",
+}
+
+// imageTypes maps docker image name fragments to database types
+var imageTypes = []struct {
+	fragment string
+	dbType   string
+}{
+	{"postgres", "postgres"},
+	{"postgis", "postgres"},
+	{"timescale", "postgres"},
+	{"mariadb", "mysql"},
+	{"mysql", "mysql"},
+	{"mssql", "mssql"},
+	{"redis", "redis"},
+	{"mongo", "mongodb"},
+}
+
+// dockerPortPattern matches published ports such as "0.0.0.0:5433->5432/tcp"
+var dockerPortPattern = regexp.MustCompile(`:(\d+)->(\d+)/tcp`)
+
+const discoveryDialTimeout = 300 * time.Millisecond
+
+// DiscoverLocalServers probes well-known local ports and running Docker
+// containers for database servers
+func DiscoverLocalServers() ([]DiscoveredServer, error) {
+	servers := make(map[int]DiscoveredServer)
+
+	// Docker results carry more information, so they take precedence
+	for _, s := range discoverDockerServers() {
+		servers[s.Port] = s
+	}
+
+	for _, s := range discoverPortServers() {
+		if _, exists := servers[s.Port]; !exists {
+			servers[s.Port] = s
+		}
+	}
+
+	result := make([]DiscoveredServer, 0, len(servers))
+	for _, s := range servers {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Port < result[j].Port
+	})
+
+	return result, nil
+}
+
+// discoverPortServers dials the well-known ports on localhost concurrently
+func discoverPortServers() []DiscoveredServer {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		servers []DiscoveredServer
+	)
+
+	for port, dbType := range wellKnownPorts {
+		wg.Add(1)
+		go func(port int, dbType string) {
+			defer wg.Done()
+			if !isPortOpen("127.0.0.1", port) {
+				return
+			}
+			mu.Lock()
+			servers = append(servers, newDiscoveredServer(dbType, "127.0.0.1", port, "port"))
+			mu.Unlock()
+		}(port, dbType)
+	}
+
+	wg.Wait()
+	return servers
+}
+
+// discoverDockerServers lists running containers and their published database ports.
+// A missing or unreachable docker daemon is not an error, it just yields no results.
+func discoverDockerServers() []DiscoveredServer {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "docker", "ps", "--format", "{{.Names}}\t{{.Image}}\t{{.Ports}}").Output()
+	if err != nil {
+		return nil
+	}
+
+	var servers []DiscoveredServer
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		name, image, ports := parts[0], parts[1], parts[2]
+
+		seen := make(map[int]bool)
+		for _, match := range dockerPortPattern.FindAllStringSubmatch(ports, -1) {
+			hostPort, _ := strconv.Atoi(match[1])
+			containerPort, _ := strconv.Atoi(match[2])
+			if seen[hostPort] {
+				continue
+			}
+
+			dbType := dockerImageType(image)
+			if dbType == "" {
+				dbType = wellKnownPorts[containerPort]
+			}
+			if dbType == "" {
+				continue
+			}
+			seen[hostPort] = true
+
+			s := newDiscoveredServer(dbType, "127.0.0.1", hostPort, "docker")
+			s.Container = name
+			s.Image = image
+			servers = append(servers, s)
+		}
+	}
+
+	return servers
+}
+
+// dockerImageType guesses the database type from a docker image name
+func dockerImageType(image string) string {
+	image = strings.ToLower(image)
+	for _, it := range imageTypes {
+		if strings.Contains(image, it.fragment) {
+			return it.dbType
+		}
+	}
+	return ""
+}
+
+func isPortOpen(host string, port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), discoveryDialTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func newDiscoveredServer(dbType, host string, port int, source string) DiscoveredServer {
+	s := DiscoveredServer{
+		Type:   dbType,
+		Host:   host,
+		Port:   port,
+		Source: source,
+	}
+
+	switch dbType {
+	case "postgres":
+		s.Supported = true
+		s.Config = ConnectionConfig{Type: "postgres", Host: host, Port: port, User: "postgres", Database: "postgres"}
+	case "mysql":
+		s.Supported = true
+		s.Config = ConnectionConfig{Type: "mysql", Host: host, Port: port, User: "root"}
+	default:
+		s.Config = ConnectionConfig{Type: dbType, Host: host, Port: port}
+	}
+
+	return s
+}
@@ -2,35 +2,116 @@ package database
 
 import (
 	"database/sql"
+	"time"
 )
 
 // Driver defines the behavior for different database dialects
 type Driver interface {
 	// Connection
 	Connect(config ConnectionConfig) (*sql.DB, error)
+	ConnectionStrings(config ConnectionConfig) ConnectionStrings
+	GetDiagnostics(db Querier, diag *ConnectionDiagnostics)
 
 	// Schema Inspection
-	GetDatabases(db *sql.DB) ([]string, error)
-	GetTables(db *sql.DB, database string) ([]TableInfo, error)
-	GetColumns(db *sql.DB, database, table string) ([]ColumnInfo, error)
-	GetIndexes(db *sql.DB, database, table string) ([]IndexInfo, error)
+	GetDatabases(db Querier) ([]string, error)
+	GetTables(db Querier, database string) ([]TableInfo, error)
+	GetViews(db Querier, database string) ([]ViewInfo, error)
+	GetMaterializedViews(db Querier, database string) ([]MaterializedViewInfo, error)
+	GetColumns(db Querier, database, table string) ([]ColumnInfo, error)
+	GetIndexes(db Querier, database, table string) ([]IndexInfo, error)
+	GetForeignKeys(db Querier, database, table string) ([]ForeignKeyInfo, error)
+	GetConstraints(db Querier, database, table string) ([]ConstraintInfo, error)
+	GetSequences(db Querier, database string) ([]SequenceInfo, error)
+	GetTriggers(db Querier, database, table string) ([]TriggerInfo, error)
+	GetRoutines(db Querier, database string) ([]RoutineInfo, error)
+	SearchSchema(db Querier, pattern string, limit int) ([]SchemaSearchResult, error)
+	GetTableComment(db Querier, database, table string) (string, error)
+	GetTableDDL(db Querier, database, table string) (string, error)
+	GetRoles(db Querier) ([]RoleInfo, error)
+	GetTablePrivileges(db Querier, database, table string) ([]TablePrivilege, error)
 
 	// Query Building & Dialect Specifics
-	BuildTableDataQuery(req TableDataRequest, primaryKey string) string
-	BuildCountQuery(database, table, filters string) string
+	// BuildTableDataQuery selects a page of rows; with locate, the RowMatch
+	// locator of each row is appended as a last column when the driver has one
+	BuildTableDataQuery(req TableDataRequest, primaryKey []string, locate bool) (string, []interface{}, error)
+	// BuildCountQuery counts the rows matching filters, stopping at limit when positive
+	BuildCountQuery(database, table string, filters []FilterCondition, limit int) (string, []interface{}, error)
 	BuildDistinctValuesQuery(database, table, column string) string
+	BuildCallRoutine(database string, routine RoutineInfo) routineCall
+	BuildClearCacheQuery() string
+	// BackendIDQuery selects the server id of the current connection, which
+	// BuildCancelQuery takes to stop the statement running on it
+	BackendIDQuery() string
+	BuildCancelQuery(backendID int64) string
+	// StatementTimeoutQueries limit each statement of the session to timeout
+	// and restore the default; empty when the server has no such setting
+	StatementTimeoutQueries(timeout time.Duration) (set, reset string)
+	// CaptureNotices passes the notices the server sends on conn to collect
+	// until the returned func is called
+	CaptureNotices(conn *sql.Conn, collect func(ServerNotice)) func()
+	// WarningsQuery selects level, code and message of the warnings of the
+	// last statement; empty when the server sends them as notices
+	WarningsQuery() string
+	// BuildCompletionColumnsQuery selects schema, table, table type, column
+	// name and column type of every column, ordered by table
+	BuildCompletionColumnsQuery(database string) (string, []interface{})
+	// BuildCompletionRoutinesQuery selects schema, name, kind, arguments and
+	// return type of every stored routine
+	BuildCompletionRoutinesQuery(database string) (string, []interface{})
+	// BuildExplainQuery asks for the plan of a statement in a format ParsePlan reads
+	BuildExplainQuery(query string, analyze bool) string
+	ParsePlan(raw string, analyze bool) (*ExplainResult, error)
+	BuildSelectCellQuery(database, table string, primaryKey []string, column string) string
+	BuildKeyRangeQuery(database, table, primaryKey string, columns []string, hasLower, hasUpper bool) string
 
 	// Table Operations
+	BuildCreateTableQuery(database, table string, columns []ColumnInfo) string
 	BuildAlterTableQuery(database, table string, alteration TableAlteration) ([]string, error)
 	BuildTruncateTableQuery(database, table string) string
 	BuildDropTableQuery(database, table string) string
+	BuildRefreshMaterializedViewQuery(database, view string, concurrently bool) (string, error)
+	BuildRestartSequenceQuery(database, sequence string, value int64) string
+	BuildAlterSequenceQuery(database, sequence string, alteration SequenceAlteration) (string, error)
+	BuildSetTriggerEnabledQuery(database, table, trigger string, enabled bool) (string, error)
+	BuildDropTriggerQuery(database, table, trigger string) string
+	BuildTableCommentQuery(database, table, comment string) string
+	BuildColumnCommentQuery(db Querier, database, table, column, comment string) (string, error)
+	BuildPrivilegeQuery(database string, change PrivilegeChange) (string, error)
 
 	// CRUD Operations
 	BuildInsertQuery(database, table string, columns []string) string
-	BuildUpdateQuery(database, table, primaryKey string, columns []string) string
-	BuildDeleteQuery(database, table, primaryKey string) string
-	BuildBatchDeleteQuery(database, table, primaryKey string, count int) string
+	// BuildBatchInsertQuery inserts rows rows at once, binding their values row by row
+	BuildBatchInsertQuery(database, table string, columns []string, rows int) string
+	// ReturningClause makes an INSERT or UPDATE return the written rows; empty
+	// when unsupported
+	ReturningClause() string
+	// MaxPlaceholders is the most arguments a single statement can bind
+	MaxPlaceholders() int
+	BuildUpdateQuery(database, table string, primaryKey []string, columns []string) string
+	BuildDeleteQuery(database, table string, primaryKey []string) string
+	BuildBatchDeleteQuery(database, table string, primaryKey []string, count int) string
+	// BuildDuplicateRowQuery inserts a copy of the row matching primaryKey,
+	// copying the copied columns and binding values for the set columns
+	// first, followed by the key values
+	BuildDuplicateRowQuery(database, table string, primaryKey, copied, set []string) string
+	// BuildSelectRowsQuery selects columns of count rows matching primaryKey,
+	// with the key values bound row by row
+	BuildSelectRowsQuery(database, table string, primaryKey, columns []string, count int) string
+	// BuildBulkUpdateQuery sets columns of every row matching filters. The
+	// values of columns are bound first, followed by the returned arguments.
+	BuildBulkUpdateQuery(database, table string, columns []string, filters []FilterCondition) (string, []interface{}, error)
+	// BuildFilteredDeleteQuery deletes every row matching filters
+	BuildFilteredDeleteQuery(database, table string, filters []FilterCondition) (string, []interface{}, error)
+	BuildLocatedUpdateQuery(database, table string, columns []string, locator RowLocator) (string, []interface{}, error)
+	BuildLocatedDeleteQuery(database, table string, locator RowLocator) (string, []interface{}, error)
 
-	// Quote identifiers (backticks for MySQL, double quotes for Postgres)
+	// Quote identifiers (backticks for MySQL, double quotes for Postgres),
+	// escaping embedded quotes
 	QuoteIdentifier(name string) string
+	// ValidateIdentifier checks that a new name is accepted by the server as is
+	ValidateIdentifier(name string) error
+	// RowMatch tells how rows of tables without a primary key are identified
+	RowMatch() string
+	// QualifiedName quotes a table name qualified by its database (MySQL) or schema (Postgres)
+	QualifiedName(database, name string) string
 }
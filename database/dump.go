@@ -0,0 +1,593 @@
+package database
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DumpOptions controls what DumpTables writes
+type DumpOptions struct {
+	Tables        []string `json:"tables"`
+	Content       string   `json:"content,omitempty"`       // all (default), schema or data
+	DropTables    bool     `json:"dropTables,omitempty"`    // Drop each table before creating it
+	RowsPerInsert int      `json:"rowsPerInsert,omitempty"` // Rows listed by each INSERT, 100 by default
+	MaxFileSizeMB int      `json:"maxFileSizeMb,omitempty"` // Continue in a new file past this size; 0 writes a single file
+}
+
+// DumpResult reports the files a dump was written to
+type DumpResult struct {
+	Files  []string `json:"files"`
+	Tables int      `json:"tables"`
+	Rows   int64    `json:"rows"`
+}
+
+// DumpTables writes tables to a SQL script of CREATE TABLE and INSERT
+// statements that recreates them on another server of the same kind, without
+// needing pg_dump or mysqldump. Tables are written referenced ones first so
+// that foreign keys are satisfied; PostgreSQL tables are preceded by the
+// custom types and sequences their columns use. Rows are read as a stream with
+// "export:progress" events. With MaxFileSizeMB, statements continue in files
+// named after the first, e.g. dump.002.sql, each of which can be run on its
+// own. An s3:// or gs:// outputPath uploads the files to a bucket instead.
+// A cancelled or failed dump removes the files it wrote.
+func (m *Manager) DumpTables(database, outputPath string, opts DumpOptions) (*DumpResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if len(opts.Tables) == 0 {
+		return nil, fmt.Errorf("no tables selected")
+	}
+	schema, data := true, true
+	switch opts.Content {
+	case "", "all":
+	case "schema":
+		data = false
+	case "data":
+		schema = false
+	default:
+		return nil, fmt.Errorf("unsupported dump content: %s", opts.Content)
+	}
+	batch := opts.RowsPerInsert
+	if batch <= 0 {
+		batch = 100
+	}
+
+	ctx, done := m.track("dump", database)
+	defer done()
+	db = db.withContext(ctx)
+
+	tables, err := m.dumpOrder(database, opts.Tables)
+	if err != nil {
+		return nil, err
+	}
+
+	_, mysql := m.currentDriver().(*MySQLDriver)
+	g := &statementWriter{mysql: mysql, literal: quoteLiteral, quote: m.currentDriver().QuoteIdentifier}
+	header := "SET client_encoding = 'UTF8';\nSET standard_conforming_strings = on;\n\n"
+	footer := ""
+	if mysql {
+		g.literal = mysqlQuoteLiteral
+		header = "SET NAMES utf8mb4;\nSET FOREIGN_KEY_CHECKS = 0;\n\n"
+		footer = "SET FOREIGN_KEY_CHECKS = 1;\n"
+	}
+	header = fmt.Sprintf("-- Dump of %s, %s\n\n", database, time.Now().Format(time.RFC3339)) + header
+
+	create := func(path string) (exportOutput, error) {
+		return m.createOutput(ctx, path, "application/sql")
+	}
+	out := &dumpWriter{path: outputPath, create: create, maxBytes: int64(opts.MaxFileSizeMB) << 20, header: header, footer: footer}
+	result, err := m.writeDump(db, database, tables, g, out, schema, data, batch, opts.DropTables)
+	if err == nil {
+		err = out.close()
+	}
+	if err != nil {
+		out.abort()
+		return nil, err
+	}
+	for _, file := range out.files {
+		result.Files = append(result.Files, bucketLocation(file))
+	}
+	return result, nil
+}
+
+// writeDump writes the statements of a dump of tables in order
+func (m *Manager) writeDump(db *instrumentedDB, database string, tables []string, g *statementWriter, out *dumpWriter, schema, data bool, batch int, drop bool) (*DumpResult, error) {
+	result := &DumpResult{Tables: len(tables)}
+	// Dialect-specific names as in the DDL: schema-qualified for PostgreSQL,
+	// plain for MySQL so the dump loads into any database
+	target := func(table string) string {
+		if g.mysql {
+			return g.quote(table)
+		}
+		return m.currentDriver().QualifiedName(database, table)
+	}
+
+	// PostgreSQL tables need their custom types and serial sequences first
+	objects := &pgDumpObjects{}
+	if schema && !g.mysql {
+		var err error
+		if objects, err = m.pgDumpObjects(db, database, tables); err != nil {
+			return nil, err
+		}
+	}
+
+	if schema && drop {
+		for i := len(tables) - 1; i >= 0; i-- {
+			if err := out.write(fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", target(tables[i]))); err != nil {
+				return nil, err
+			}
+		}
+		for i := len(objects.types) - 1; i >= 0; i-- {
+			if err := out.write(objects.types[i].drop + "\n"); err != nil {
+				return nil, err
+			}
+		}
+		if err := out.write("\n"); err != nil {
+			return nil, err
+		}
+	}
+	if schema {
+		for _, t := range objects.types {
+			if err := out.write(fmt.Sprintf("-- Type %s\n%s\n\n", t.name, t.create)); err != nil {
+				return nil, err
+			}
+		}
+		for _, seq := range objects.sequences {
+			if err := out.write(seq + "\n"); err != nil {
+				return nil, err
+			}
+		}
+		if len(objects.sequences) > 0 {
+			if err := out.write("\n"); err != nil {
+				return nil, err
+			}
+		}
+		for _, table := range tables {
+			ddl, err := m.currentDriver().GetTableDDL(db, database, table)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get DDL of %s: %w", table, err)
+			}
+			ddl = strings.TrimSpace(ddl)
+			if !strings.HasSuffix(ddl, ";") {
+				ddl += ";"
+			}
+			if err := out.write(fmt.Sprintf("-- Table %s\n%s\n", table, ddl)); err != nil {
+				return nil, err
+			}
+			for _, owned := range objects.owners[table] {
+				if err := out.write(owned + "\n"); err != nil {
+					return nil, err
+				}
+			}
+			if err := out.write("\n"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if data {
+		for _, table := range tables {
+			rows, err := m.dumpRows(db, database, table, target(table), g, out, batch)
+			if err != nil {
+				return nil, err
+			}
+			result.Rows += rows
+		}
+	}
+	return result, nil
+}
+
+// pgDumpObjects lists what the tables of a PostgreSQL dump need before
+// they can be created
+type pgDumpObjects struct {
+	types     []pgDumpType        // Enums, then domains, then composites
+	sequences []string            // CREATE SEQUENCE statements of serial defaults
+	owners    map[string][]string // ALTER SEQUENCE ... OWNED BY statements by table
+}
+
+type pgDumpType struct {
+	name, create, drop string
+}
+
+// pgDumpObjects collects the enum, domain and composite types of the
+// tables' columns, and enums used by those types, along with the sequences
+// their nextval defaults draw from. Sequences are made owned by their
+// column so that pg_get_serial_sequence finds them when the rows are loaded.
+// Identity columns create their own sequence and are skipped.
+func (m *Manager) pgDumpObjects(db Querier, database string, tables []string) (*pgDumpObjects, error) {
+	objects := &pgDumpObjects{owners: make(map[string][]string)}
+	enums := make(map[string][]string)
+	var enumOrder, domainOrder, compositeOrder []string
+	domains := make(map[string]*CustomTypeInfo)
+	composites := make(map[string][]CompositeAttribute)
+	addEnum := func(name string, labels []string) {
+		if _, ok := enums[name]; !ok && len(labels) > 0 {
+			enums[name] = labels
+			enumOrder = append(enumOrder, name)
+		}
+	}
+
+	sequences, err := m.currentDriver().GetSequences(db, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sequences: %w", err)
+	}
+	bySequence := make(map[string]SequenceInfo, len(sequences))
+	for _, seq := range sequences {
+		bySequence[seq.Name] = seq
+	}
+	created := make(map[string]bool)
+
+	for _, table := range tables {
+		columns, err := m.currentDriver().GetColumns(db, database, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get columns of %s: %w", table, err)
+		}
+		for _, col := range columns {
+			name := strings.TrimSuffix(col.Type, "[]")
+			switch {
+			case col.CustomType != nil && col.CustomType.Kind == "domain":
+				if _, ok := domains[col.CustomType.Name]; !ok {
+					domains[col.CustomType.Name] = col.CustomType
+					domainOrder = append(domainOrder, col.CustomType.Name)
+				}
+			case col.CustomType != nil && col.CustomType.Kind == "composite":
+				if _, ok := composites[name]; !ok {
+					composites[name] = col.CustomType.Attributes
+					compositeOrder = append(compositeOrder, name)
+				}
+			default:
+				addEnum(name, col.EnumValues)
+			}
+
+			if col.Identity != "" || !strings.HasPrefix(col.Default, "nextval(") {
+				continue
+			}
+			seq, ok := bySequence[sequenceOfDefault(col.Default)]
+			if !ok {
+				continue
+			}
+			qualified := m.currentDriver().QualifiedName(database, seq.Name)
+			if !created[seq.Name] {
+				created[seq.Name] = true
+				stmt := fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s INCREMENT BY %s MINVALUE %s MAXVALUE %s START WITH %s",
+					qualified, seq.Increment, seq.MinValue, seq.MaxValue, seq.StartValue)
+				if seq.Cycle {
+					stmt += " CYCLE"
+				}
+				objects.sequences = append(objects.sequences, stmt+";")
+			}
+			objects.owners[table] = append(objects.owners[table], fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.%s;",
+				qualified, m.currentDriver().QualifiedName(database, table), m.currentDriver().QuoteIdentifier(col.Name)))
+		}
+	}
+
+	// Domains and composites may be built on enums no column uses directly
+	if len(domains) > 0 || len(composites) > 0 {
+		driver, _ := m.currentDriver().(*PostgresDriver)
+		labels, err := pgEnumLabels(db, driver.schemaName())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get enum values: %w", err)
+		}
+		for _, name := range domainOrder {
+			base := unqualifiedType(domains[name].BaseType)
+			addEnum(base, labels[base])
+		}
+		for _, name := range compositeOrder {
+			for _, attr := range composites[name] {
+				base := unqualifiedType(attr.Type)
+				addEnum(base, labels[base])
+			}
+		}
+	}
+
+	add := func(kind, name, create string) {
+		qualified := m.currentDriver().QualifiedName(database, name)
+		objects.types = append(objects.types, pgDumpType{
+			name:   name,
+			create: fmt.Sprintf("CREATE %s %s %s;", kind, qualified, create),
+			drop:   fmt.Sprintf("DROP %s IF EXISTS %s;", kind, qualified),
+		})
+	}
+	for _, name := range enumOrder {
+		labels := make([]string, len(enums[name]))
+		for i, label := range enums[name] {
+			labels[i] = quoteLiteral(label)
+		}
+		add("TYPE", name, "AS ENUM ("+strings.Join(labels, ", ")+")")
+	}
+	for _, name := range domainOrder {
+		d := domains[name]
+		def := "AS " + d.BaseType
+		if d.Default != "" {
+			def += " DEFAULT " + d.Default
+		}
+		if d.NotNull {
+			def += " NOT NULL"
+		}
+		for _, check := range d.Checks {
+			def += fmt.Sprintf(" CONSTRAINT %s CHECK (%s)", m.currentDriver().QuoteIdentifier(check.Name), check.Expression)
+		}
+		add("DOMAIN", name, def)
+	}
+	for _, name := range compositeOrder {
+		fields := make([]string, len(composites[name]))
+		for i, attr := range composites[name] {
+			fields[i] = m.currentDriver().QuoteIdentifier(attr.Name) + " " + attr.Type
+		}
+		add("TYPE", name, "AS ("+strings.Join(fields, ", ")+")")
+	}
+	return objects, nil
+}
+
+// sequenceOfDefault returns the unqualified sequence name of a nextval
+// default, e.g. orders_id_seq for nextval('public.orders_id_seq'::regclass)
+func sequenceOfDefault(def string) string {
+	start := strings.Index(def, "'")
+	end := strings.LastIndex(def, "'")
+	if start < 0 || end <= start {
+		return ""
+	}
+	return unqualifiedType(strings.ReplaceAll(def[start+1:end], "''", "'"))
+}
+
+// unqualifiedType strips the schema and quotes from a type or relation name
+// and an array suffix from a type, e.g. mood for public."mood"[]
+func unqualifiedType(name string) string {
+	name = strings.TrimSuffix(name, "[]")
+	var part strings.Builder
+	quoted := false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '"' && quoted && i+1 < len(name) && name[i+1] == '"':
+			part.WriteByte('"')
+			i++
+		case c == '"':
+			quoted = !quoted
+		case c == '.' && !quoted:
+			part.Reset()
+		default:
+			part.WriteByte(c)
+		}
+	}
+	return part.String()
+}
+
+// dumpRows writes the rows of a table as INSERT statements of up to batch
+// rows, followed for PostgreSQL by moving its sequences past the inserted ids
+func (m *Manager) dumpRows(db *instrumentedDB, database, table, target string, g *statementWriter, out *dumpWriter, batch int) (int64, error) {
+	infos, err := m.GetColumns(database, "", table)
+	if err != nil {
+		return 0, err
+	}
+	byName := make(map[string]ColumnInfo, len(infos))
+	var columns, selected []string
+	for _, col := range infos {
+		// Generated columns are computed again by the target
+		if col.Generated != "" {
+			continue
+		}
+		byName[col.Name] = col
+		columns = append(columns, col.Name)
+		expr := g.quote(col.Name)
+		if g.mysql && isGeoColumn(col) {
+			// Written back with ST_GeomFromText
+			expr = "ST_AsText(" + expr + ")"
+		}
+		selected = append(selected, expr)
+	}
+	if len(columns) == 0 {
+		return 0, nil
+	}
+	included := make([]int, len(columns))
+	for i := range included {
+		included[i] = i
+	}
+
+	rows, err := db.Query(fmt.Sprintf("SELECT %s FROM %s", strings.Join(selected, ", "), m.currentDriver().QualifiedName(database, table)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	progress := m.newProgress("export", table, 0)
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	var pending [][]interface{}
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		var sb strings.Builder
+		if err := g.inserts(&sb, target, byName, columns, included, pending, true); err != nil {
+			return fmt.Errorf("failed to write rows of %s: %w", table, err)
+		}
+		pending = pending[:0]
+		return out.write(sb.String())
+	}
+
+	if err := out.write(fmt.Sprintf("-- Data of %s\n", table)); err != nil {
+		return 0, err
+	}
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return 0, err
+		}
+		row := make([]interface{}, len(values))
+		for i, v := range values {
+			row[i] = dumpValue(byName[columns[i]], v, g.mysql)
+		}
+		pending = append(pending, row)
+		if len(pending) == batch {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+		count++
+		progress.add()
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+
+	if !g.mysql && count > 0 {
+		for _, col := range infos {
+			if col.Identity == "" && !strings.Contains(col.Default, "nextval(") {
+				continue
+			}
+			stmt := fmt.Sprintf("SELECT setval(pg_get_serial_sequence(%s, %s), COALESCE(MAX(%s), 0) + 1, false) FROM %s;\n",
+				g.literal(target), g.literal(col.Name), g.quote(col.Name), target)
+			if err := out.write(stmt); err != nil {
+				return 0, err
+			}
+		}
+	}
+	progress.finish()
+	return count, out.write("\n")
+}
+
+// dumpValue prepares a scanned value for statementWriter.value, which takes
+// values as the grid shows them
+func dumpValue(col ColumnInfo, v interface{}, mysql bool) interface{} {
+	switch val := v.(type) {
+	case []byte:
+		if typeCategory(col.Type) == typeCategoryBinary {
+			return val
+		}
+		return string(val)
+	case time.Time:
+		// MySQL rejects the zone of RFC 3339 times; its times carry none
+		if mysql {
+			return val.Format("2006-01-02 15:04:05.999999")
+		}
+	}
+	return v
+}
+
+// dumpOrder sorts tables so that each comes after the tables it references.
+// Tables in a reference cycle keep their given order.
+func (m *Manager) dumpOrder(database string, tables []string) ([]string, error) {
+	selected := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		selected[table] = true
+	}
+	refs := make(map[string][]string, len(tables))
+	for _, table := range tables {
+		fks, err := m.GetForeignKeys(database, "", table)
+		if err != nil {
+			return nil, err
+		}
+		for _, fk := range fks {
+			if selected[fk.ReferencedTable] && fk.ReferencedTable != table {
+				refs[table] = append(refs[table], fk.ReferencedTable)
+			}
+		}
+	}
+
+	ordered := make([]string, 0, len(tables))
+	state := make(map[string]int) // 1 while visiting, 2 once placed
+	var visit func(string)
+	visit = func(table string) {
+		if state[table] != 0 {
+			return
+		}
+		state[table] = 1
+		for _, ref := range refs[table] {
+			visit(ref)
+		}
+		state[table] = 2
+		ordered = append(ordered, table)
+	}
+	for _, table := range tables {
+		visit(table)
+	}
+	return ordered, nil
+}
+
+// dumpWriter writes a dump to one or more files, starting a new one at a
+// statement boundary once a file reaches maxBytes
+type dumpWriter struct {
+	path     string
+	create   func(path string) (exportOutput, error)
+	maxBytes int64
+	header   string
+	footer   string
+	files    []string // Paths of the files started, the current one last
+	file     exportOutput
+	buf      *bufio.Writer
+	written  int64
+}
+
+// write adds statements to the current file
+func (d *dumpWriter) write(s string) error {
+	if d.file != nil && d.maxBytes > 0 && d.written+int64(len(s)) > d.maxBytes && d.written > int64(len(d.header)) {
+		if err := d.close(); err != nil {
+			return err
+		}
+	}
+	if d.file == nil {
+		if err := d.open(); err != nil {
+			return err
+		}
+	}
+	n, err := d.buf.WriteString(s)
+	d.written += int64(n)
+	return err
+}
+
+// open starts the next file of the dump
+func (d *dumpWriter) open() error {
+	path := d.path
+	if len(d.files) > 0 {
+		path = numberedPath(d.path, len(d.files)+1)
+	}
+	file, err := d.create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create dump file: %w", err)
+	}
+	d.files = append(d.files, path)
+	d.file, d.buf = file, bufio.NewWriter(file)
+	n, err := d.buf.WriteString(d.header)
+	d.written = int64(n)
+	return err
+}
+
+// close ends the current file, if any
+func (d *dumpWriter) close() error {
+	if d.file == nil {
+		return nil
+	}
+	if _, err := d.buf.WriteString(d.footer); err != nil {
+		return err
+	}
+	if err := d.buf.Flush(); err != nil {
+		return err
+	}
+	if err := d.file.Close(); err != nil {
+		return err
+	}
+	d.file = nil
+	return nil
+}
+
+// abort discards the current file and removes the finished ones
+func (d *dumpWriter) abort() {
+	finished := d.files
+	if d.file != nil {
+		d.file.Abort()
+		d.file = nil
+		finished = finished[:len(finished)-1]
+	}
+	for _, path := range finished {
+		removeOutput(path)
+	}
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DuplicateRow inserts a copy of a row identified by its primary key. The
+// copy is made by the server with INSERT ... SELECT, so values of every type
+// are copied exactly. Identity, auto-increment, serial and generated columns
+// and primary key columns with a default, e.g. gen_random_uuid() or uuid(),
+// are left to the database; overrides replace the values of other columns.
+// A natural key, which has no default, must be given a new value.
+func (m *Manager) DuplicateRow(database, schema, table string, primaryKey []string, primaryValues []interface{}, overrides RowData) (*ExecuteResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	keys, err := keyValues(primaryKey, primaryValues)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err = m.writableData(database, schema, table, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	var copied []string
+	for _, col := range columns {
+		if _, ok := overrides[col.Name]; !ok && !regeneratedColumn(col) {
+			if col.Key == "PRI" {
+				return nil, fmt.Errorf("primary key column %s has no default; give the copy a new value for it", col.Name)
+			}
+			copied = append(copied, col.Name)
+		}
+	}
+	set, values := rowColumns(overrides)
+	if len(copied)+len(set) == 0 {
+		return nil, fmt.Errorf("no columns to copy")
+	}
+
+	query := m.tableDriver(schema).BuildDuplicateRowQuery(database, table, primaryKey, copied, set)
+	result, err := m.execWrite(db, query, append(values, keys...))
+	if err != nil {
+		return nil, fmt.Errorf("duplicate failed: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("row not found")
+	}
+	m.recordUndo(database, schema, table, m.insertedUndo(database, schema, table, overrides, result))
+	return result, nil
+}
+
+// DuplicateRowValues returns the values DuplicateRow would copy, as the grid
+// shows them, so that they can be reviewed and changed before inserting
+func (m *Manager) DuplicateRowValues(database, schema, table string, primaryKey []string, primaryValues []interface{}) (RowData, error) {
+	if len(primaryKey) == 0 || len(primaryValues) != len(primaryKey) {
+		return nil, fmt.Errorf("expected %d primary key values, got %d", len(primaryKey), len(primaryValues))
+	}
+
+	filters := make([]FilterCondition, len(primaryKey))
+	for i, key := range primaryKey {
+		filters[i] = FilterCondition{Column: key, Operator: "=", Value: primaryValues[i]}
+	}
+	page, err := m.GetTableData(TableDataRequest{Database: database, Table: table, Page: 1, PageSize: 1, Filters: filters})
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Rows) == 0 {
+		return nil, fmt.Errorf("row not found")
+	}
+
+	values := make(RowData)
+	for i, col := range page.Columns {
+		if !regeneratedColumn(col) && i < len(page.Rows[0]) {
+			values[col.Name] = page.Rows[0][i]
+		}
+	}
+	return values, nil
+}
+
+// regeneratedColumn reports whether the database fills a column of a new
+// row by itself, so that a duplicate must not copy it. Any default of a
+// primary key column is taken to make a new key.
+func regeneratedColumn(col ColumnInfo) bool {
+	return col.ReadOnly ||
+		col.Identity != "" ||
+		strings.Contains(strings.ToLower(col.Extra), "auto_increment") ||
+		strings.HasPrefix(col.Default, "nextval(") ||
+		(col.Key == "PRI" && col.Default != "")
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Embedded format names returned by DetectFormat
+const (
+	embeddedJSON  = "json"
+	embeddedXML   = "xml"
+	embeddedImage = "image"
+	embeddedJWT   = "jwt"
+	embeddedURL   = "url"
+)
+
+// FormatHint describes structured content found inside a text value
+type FormatHint struct {
+	Format   string                 `json:"format"`             // json, xml, image, jwt, url
+	Pretty   string                 `json:"pretty,omitempty"`   // Indented JSON/XML
+	MimeType string                 `json:"mimeType,omitempty"` // Image type, e.g. image/png
+	DataURI  string                 `json:"dataUri,omitempty"`  // Image as a data: URI ready for <img>
+	Header   map[string]interface{} `json:"header,omitempty"`   // Decoded JWT header
+	Claims   map[string]interface{} `json:"claims,omitempty"`   // Decoded JWT payload (signature is not verified)
+}
+
+// DetectFormat inspects a text value and returns a hint describing its
+// embedded format, or nil when the value is plain text
+func DetectFormat(value string) *FormatHint {
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return nil
+	}
+
+	switch {
+	case s[0] == '{' || s[0] == '[':
+		if json.Valid([]byte(s)) {
+			return &FormatHint{Format: embeddedJSON, Pretty: indentJSON(s)}
+		}
+	case s[0] == '<':
+		if pretty, ok := indentXML(s); ok {
+			return &FormatHint{Format: embeddedXML, Pretty: pretty}
+		}
+	}
+
+	if hint := detectJWT(s); hint != nil {
+		return hint
+	}
+	if hint := detectImage(s); hint != nil {
+		return hint
+	}
+	if u, err := url.Parse(s); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && !strings.ContainsAny(s, " \t\n") {
+		return &FormatHint{Format: embeddedURL}
+	}
+
+	return nil
+}
+
+// detectJWT decodes a compact JWS (header.payload.signature)
+func detectJWT(s string) *FormatHint {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "eyJ") {
+		return nil
+	}
+
+	var header, claims map[string]interface{}
+	if !decodeJWTSegment(parts[0], &header) || !decodeJWTSegment(parts[1], &claims) {
+		return nil
+	}
+	if _, ok := header["alg"]; !ok {
+		return nil
+	}
+
+	return &FormatHint{Format: embeddedJWT, Header: header, Claims: claims}
+}
+
+func decodeJWTSegment(segment string, v interface{}) bool {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// detectImage recognizes data: URIs and bare base64 strings holding an image
+func detectImage(s string) *FormatHint {
+	payload := s
+	if strings.HasPrefix(s, "data:image/") {
+		idx := strings.Index(s, ";base64,")
+		if idx < 0 {
+			return nil
+		}
+		payload = s[idx+len(";base64,"):]
+	} else if len(s) < 16 || strings.ContainsAny(s, " \t") {
+		return nil
+	}
+
+	payload = strings.NewReplacer("\n", "", "\r", "").Replace(payload)
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil
+	}
+
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return nil
+	}
+
+	return &FormatHint{
+		Format:   embeddedImage,
+		MimeType: mimeType,
+		DataURI:  "data:" + mimeType + ";base64," + payload,
+	}
+}
+
+// indentXML re-encodes an XML document with indentation, reporting whether it parsed
+func indentXML(s string) (string, bool) {
+	decoder := xml.NewDecoder(strings.NewReader(s))
+	var buf bytes.Buffer
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+
+	hasElement := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", false
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			hasElement = true
+		case xml.CharData:
+			// Whitespace between elements is replaced by the encoder's indentation
+			if len(bytes.TrimSpace(t)) == 0 {
+				continue
+			}
+		}
+		if err := encoder.EncodeToken(xml.CopyToken(token)); err != nil {
+			return "", false
+		}
+	}
+
+	if err := encoder.Flush(); err != nil || !hasElement {
+		return "", false
+	}
+	return buf.String(), true
+}
@@ -0,0 +1,360 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExplainOptions controls how ExplainQuery reports a plan
+type ExplainOptions struct {
+	// Analyze runs the statement to report actual rows and timing. It runs in
+	// a transaction that is rolled back, so data-modifying statements leave
+	// no changes behind.
+	Analyze bool `json:"analyze"`
+}
+
+// PlanNode is a step of a query plan. Costs are in the server's own units.
+type PlanNode struct {
+	Operation   string  `json:"operation"`          // e.g. Seq Scan, Hash Join, Full table scan
+	Relation    string  `json:"relation,omitempty"` // Table the step reads
+	Index       string  `json:"index,omitempty"`
+	Condition   string  `json:"condition,omitempty"` // Filter, index or join condition
+	StartupCost float64 `json:"startupCost,omitempty"`
+	TotalCost   float64 `json:"totalCost,omitempty"`
+	PlanRows    float64 `json:"planRows,omitempty"` // Estimated rows
+
+	// Measured by ANALYZE; time is per loop, in milliseconds
+	ActualRows   *float64 `json:"actualRows,omitempty"`
+	ActualTimeMs *float64 `json:"actualTimeMs,omitempty"`
+	Loops        *float64 `json:"loops,omitempty"`
+
+	Details  map[string]interface{} `json:"details,omitempty"` // Other properties as reported by the server
+	Children []PlanNode             `json:"children,omitempty"`
+}
+
+// ExplainResult is the plan of a query as a tree, along with the plan as the
+// server returned it
+type ExplainResult struct {
+	Plan            PlanNode `json:"plan"`
+	Raw             string   `json:"raw"`
+	Format          string   `json:"format"` // json, or tree for MySQL EXPLAIN ANALYZE
+	PlanningTimeMs  *float64 `json:"planningTimeMs,omitempty"`
+	ExecutionTimeMs *float64 `json:"executionTimeMs,omitempty"`
+}
+
+// ExplainQuery returns the plan the server chooses for a statement
+func (m *Manager) ExplainQuery(query string, opts ExplainOptions) (*ExplainResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	statement := strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+	if statement == "" {
+		return nil, fmt.Errorf("no statement to explain")
+	}
+
+	ctx, done := m.track("explain", query)
+	defer done()
+
+	tx, err := db.withContext(ctx).Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolled back even on success: ANALYZE really executes the statement
+	defer tx.Rollback()
+
+	var raw string
+	if err := tx.QueryRow(m.currentDriver().BuildExplainQuery(statement, opts.Analyze)).Scan(&raw); err != nil {
+		return nil, fmt.Errorf("explain failed: %w", err)
+	}
+
+	result, err := m.currentDriver().ParsePlan(raw, opts.Analyze)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	result.Raw = raw
+	return result, nil
+}
+
+// parsePostgresPlan reads the output of EXPLAIN (FORMAT JSON)
+func parsePostgresPlan(raw string) (*ExplainResult, error) {
+	var plans []map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("empty plan")
+	}
+
+	root, ok := plans[0]["Plan"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("plan has no root node")
+	}
+	result := &ExplainResult{Plan: postgresPlanNode(root), Format: "json"}
+	if v, ok := plans[0]["Planning Time"].(float64); ok {
+		result.PlanningTimeMs = &v
+	}
+	if v, ok := plans[0]["Execution Time"].(float64); ok {
+		result.ExecutionTimeMs = &v
+	}
+	return result, nil
+}
+
+// postgresConditions are the keys holding the conditions of a plan node
+var postgresConditions = []string{"Index Cond", "Hash Cond", "Merge Cond", "Join Filter", "Recheck Cond", "Filter"}
+
+func postgresPlanNode(obj map[string]interface{}) PlanNode {
+	node := PlanNode{Details: map[string]interface{}{}}
+	number := func(key string) *float64 {
+		if v, ok := obj[key].(float64); ok {
+			return &v
+		}
+		return nil
+	}
+
+	for key, v := range obj {
+		switch key {
+		case "Node Type":
+			node.Operation, _ = v.(string)
+		case "Relation Name":
+			node.Relation, _ = v.(string)
+		case "Index Name":
+			node.Index, _ = v.(string)
+		case "Startup Cost":
+			node.StartupCost, _ = v.(float64)
+		case "Total Cost":
+			node.TotalCost, _ = v.(float64)
+		case "Plan Rows":
+			node.PlanRows, _ = v.(float64)
+		case "Actual Rows":
+			node.ActualRows = number(key)
+		case "Actual Total Time":
+			node.ActualTimeMs = number(key)
+		case "Actual Loops":
+			node.Loops = number(key)
+		case "Plans":
+			children, _ := v.([]interface{})
+			for _, child := range children {
+				if c, ok := child.(map[string]interface{}); ok {
+					node.Children = append(node.Children, postgresPlanNode(c))
+				}
+			}
+		default:
+			node.Details[key] = v
+		}
+	}
+
+	var conditions []string
+	for _, key := range postgresConditions {
+		if c, ok := obj[key].(string); ok {
+			conditions = append(conditions, c)
+		}
+	}
+	node.Condition = strings.Join(conditions, " AND ")
+	if len(node.Details) == 0 {
+		node.Details = nil
+	}
+	return node
+}
+
+// mysqlPlanOperations names the keys of a MySQL JSON plan that nest further steps
+var mysqlPlanOperations = map[string]string{
+	"query_block":                "Query block",
+	"table":                      "Table",
+	"nested_loop":                "Nested loop",
+	"ordering_operation":         "Sort",
+	"grouping_operation":         "Group",
+	"duplicates_removal":         "Distinct",
+	"windowing":                  "Window",
+	"buffer_result":              "Buffer",
+	"union_result":               "Union",
+	"query_specifications":       "Union members",
+	"materialized_from_subquery": "Materialize",
+	"attached_subqueries":        "Subquery",
+	"optimized_away_subqueries":  "Subquery",
+	"having_subqueries":          "Subquery",
+	"select_list_subqueries":     "Subquery",
+	"order_by_subqueries":        "Subquery",
+	"group_by_subqueries":        "Subquery",
+}
+
+// mysqlAccessTypes describes the access_type of a table in a MySQL plan
+var mysqlAccessTypes = map[string]string{
+	"ALL":         "Full table scan",
+	"index":       "Full index scan",
+	"range":       "Index range scan",
+	"ref":         "Index lookup",
+	"ref_or_null": "Index lookup",
+	"eq_ref":      "Unique index lookup",
+	"const":       "Constant lookup",
+	"system":      "Constant lookup",
+	"fulltext":    "Fulltext index",
+	"index_merge": "Index merge",
+}
+
+// parseMySQLPlan reads the output of EXPLAIN FORMAT=JSON, or of EXPLAIN
+// ANALYZE, which MySQL only prints as a text tree
+func parseMySQLPlan(raw string, analyze bool) (*ExplainResult, error) {
+	if analyze {
+		return parseMySQLTree(raw)
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &obj); err != nil {
+		return nil, err
+	}
+	block, ok := obj["query_block"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("plan has no query block")
+	}
+	return &ExplainResult{Plan: mysqlPlanNode("query_block", block), Format: "json"}, nil
+}
+
+func mysqlPlanNode(key string, obj map[string]interface{}) PlanNode {
+	node := PlanNode{Operation: mysqlPlanOperations[key], Details: map[string]interface{}{}}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := obj[k]
+		if _, nested := mysqlPlanOperations[k]; nested {
+			node.Children = append(node.Children, mysqlPlanChildren(k, v)...)
+			continue
+		}
+		switch k {
+		case "table_name":
+			node.Relation, _ = v.(string)
+		case "key":
+			node.Index, _ = v.(string)
+		case "attached_condition":
+			node.Condition, _ = v.(string)
+		case "rows_examined_per_scan":
+			node.PlanRows, _ = v.(float64)
+		case "cost_info":
+			costs, _ := v.(map[string]interface{})
+			for _, c := range []string{"query_cost", "prefix_cost", "sort_cost"} {
+				if s, ok := costs[c].(string); ok {
+					node.TotalCost, _ = strconv.ParseFloat(s, 64)
+					break
+				}
+			}
+			node.Details[k] = v
+		default:
+			node.Details[k] = v
+		}
+	}
+
+	if access, ok := obj["access_type"].(string); ok {
+		node.Operation = mysqlAccessTypes[access]
+		if node.Operation == "" {
+			node.Operation = "Table access (" + access + ")"
+		}
+	}
+	if len(node.Details) == 0 {
+		node.Details = nil
+	}
+	return node
+}
+
+// mysqlPlanChildren converts a nested step, or a list of them such as the
+// tables of a nested loop, into plan nodes
+func mysqlPlanChildren(key string, v interface{}) []PlanNode {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return []PlanNode{mysqlPlanNode(key, v)}
+	case []interface{}:
+		group := PlanNode{Operation: mysqlPlanOperations[key]}
+		for _, item := range v {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			// List items wrap their step, e.g. {"table": {...}}
+			for k, child := range obj {
+				if _, nested := mysqlPlanOperations[k]; nested {
+					group.Children = append(group.Children, mysqlPlanChildren(k, child)...)
+				}
+			}
+		}
+		return []PlanNode{group}
+	}
+	return nil
+}
+
+// mysqlTreeLine matches a step of EXPLAIN ANALYZE output such as
+// -> Table scan on t  (cost=0.35 rows=1) (actual time=0.02..0.03 rows=1 loops=1)
+var mysqlTreeLine = regexp.MustCompile(`^(\s*)-> (.*?)(?:\s+\(cost=([\d.e+]+)(?:\.\.([\d.e+]+))? rows=([\d.e+]+)\))?(?:\s+\(actual time=([\d.e+]+)\.\.([\d.e+]+) rows=([\d.e+]+) loops=([\d.e+]+)\))?\s*$`)
+
+// parseMySQLTree reads the indented tree printed by MySQL EXPLAIN ANALYZE
+func parseMySQLTree(raw string) (*ExplainResult, error) {
+	type level struct {
+		indent int
+		node   *PlanNode
+	}
+	var root *PlanNode
+	var stack []level
+	float := func(s string) *float64 {
+		if s == "" {
+			return nil
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil
+		}
+		return &v
+	}
+
+	for _, line := range strings.Split(raw, "\n") {
+		match := mysqlTreeLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		node := &PlanNode{Operation: match[2]}
+		// A range of costs is startup..total
+		startup, total := float(match[3]), float(match[4])
+		if total == nil {
+			startup, total = nil, startup
+		}
+		if startup != nil {
+			node.StartupCost = *startup
+		}
+		if total != nil {
+			node.TotalCost = *total
+		}
+		if r := float(match[5]); r != nil {
+			node.PlanRows = *r
+		}
+		node.ActualTimeMs, node.ActualRows, node.Loops = float(match[7]), float(match[8]), float(match[9])
+
+		indent := len(match[1])
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			if root != nil {
+				return nil, fmt.Errorf("plan has more than one root")
+			}
+			root = node
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, *node)
+			node = &parent.Children[len(parent.Children)-1]
+		}
+		stack = append(stack, level{indent: indent, node: node})
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("empty plan")
+	}
+	return &ExplainResult{Plan: *root, Format: "tree"}, nil
+}
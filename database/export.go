@@ -1,25 +1,34 @@
 package database
 
 import (
+	"bufio"
+	"context"
 	"database/sql"
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"os"
 	"time"
-
-	"github.com/xuri/excelize/v2"
 )
 
-// ExportTable exports the entire table to the specified file format
-func (m *Manager) ExportTable(dbName, tableName, format, outputPath string) error {
+// ExportTable exports the entire table to the specified file format, or
+// uploads it when outputPath is an s3:// or gs:// URL. A cancelled or failed
+// export removes its partial output file or aborts its upload.
+func (m *Manager) ExportTable(dbName, schema, tableName, format, outputPath string) error {
 	db := m.getDB()
 	if db == nil {
 		return fmt.Errorf("not connected to database")
 	}
 
+	// CSV is streamed with the NULL marker exports have always used
+	if format == "csv" {
+		return m.ExportTableCSV(dbName, schema, tableName, outputPath, CSVExportOptions{Null: "NULL"})
+	}
+
+	ctx, done := m.track("export", tableName)
+	defer done()
+	db = db.withContext(ctx)
+
 	// 1. Get Columns to ensure order and headers
-	columns, err := m.GetColumns(dbName, tableName)
+	columns, err := m.GetColumns(dbName, schema, tableName)
 	if err != nil {
 		return fmt.Errorf("failed to get columns: %w", err)
 	}
@@ -30,24 +39,9 @@ func (m *Manager) ExportTable(dbName, tableName, format, outputPath string) erro
 	}
 
 	// 2. Query All Data (No Pagination)
-	// We construct a simple SELECT * query using the driver's quoting
-	quotedDb := m.driver.QuoteIdentifier(dbName)
-	quotedTable := m.driver.QuoteIdentifier(tableName)
-
-	// Postgres uses "db"."schema"."table" or just "schema"."table" but our abstraction
-	// often treats dbName/Schema loosely.
-	// For simpler logic, we rely on the fact that existing drivers use:
-	// MySQL: `db`.`table`
-	// Postgres: "schema"."table" (where dbName param is usually schema)
-
-	// We'll trust the driver to quote correctly if we use individual calls,
-	// but we need to join them.
-	// Actually, let's look at how BuildTableDataQuery does it.
-	// MySQL: fmt.Sprintf("SELECT * FROM `%s`.`%s` ...", req.Database, req.Table, ...)
-
-	// We will try to rely on a generic query.
-	query := fmt.Sprintf("SELECT * FROM %s.%s", quotedDb, quotedTable)
+	query := fmt.Sprintf("SELECT * FROM %s", m.tableDriver(schema).QualifiedName(dbName, tableName))
 
+	progress := m.newProgress("export", tableName, 0)
 	rows, err := db.Query(query)
 	if err != nil {
 		return fmt.Errorf("query failed: %w", err)
@@ -56,66 +50,31 @@ func (m *Manager) ExportTable(dbName, tableName, format, outputPath string) erro
 
 	// 3. Process Rows based on format
 	switch format {
-	case "csv":
-		return m.exportCSV(rows, colNames, outputPath)
 	case "json":
-		return m.exportJSON(rows, colNames, outputPath)
+		err = m.exportJSON(ctx, rows, colNames, outputPath, progress)
 	case "xlsx":
-		return m.exportXLSX(rows, colNames, outputPath)
+		err = m.exportXLSX(ctx, rows, tableName, columns, outputPath, progress)
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
-}
-
-func (m *Manager) exportCSV(rows *sql.Rows, columns []string, outputPath string) error {
-	file, err := os.Create(outputPath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write Header
-	if err := writer.Write(columns); err != nil {
-		return err
-	}
-
-	// Write Rows
-	values := make([]interface{}, len(columns))
-	valuePtrs := make([]interface{}, len(columns))
-	for i := range values {
-		valuePtrs[i] = &values[i]
-	}
-
-	for rows.Next() {
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return err
-		}
 
-		record := make([]string, len(columns))
-		for i, val := range values {
-			record[i] = formatValue(val)
-		}
-
-		if err := writer.Write(record); err != nil {
-			return err
-		}
-	}
-
-	return rows.Err()
+	progress.finish()
+	return nil
 }
 
-func (m *Manager) exportJSON(rows *sql.Rows, columns []string, outputPath string) error {
-	file, err := os.Create(outputPath)
+func (m *Manager) exportJSON(ctx context.Context, rows *sql.Rows, columns []string, outputPath string, progress *progressReporter) error {
+	out, err := m.createOutput(ctx, outputPath, "application/json")
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	defer out.Abort()
+	buf := bufio.NewWriter(out)
 
 	// We stream a JSON array: [ ...objects... ]
-	if _, err := file.WriteString("[\n"); err != nil {
+	if _, err := buf.WriteString("[\n"); err != nil {
 		return err
 	}
 
@@ -126,7 +85,7 @@ func (m *Manager) exportJSON(rows *sql.Rows, columns []string, outputPath string
 		valuePtrs[i] = &values[i]
 	}
 
-	enc := json.NewEncoder(file)
+	enc := json.NewEncoder(buf)
 	enc.SetIndent("  ", "  ") // Indent content inside the array items if desired, or we can just write objects
 
 	for rows.Next() {
@@ -135,7 +94,7 @@ func (m *Manager) exportJSON(rows *sql.Rows, columns []string, outputPath string
 		}
 
 		if !first {
-			if _, err := file.WriteString(",\n"); err != nil {
+			if _, err := buf.WriteString(",\n"); err != nil {
 				return err
 			}
 		}
@@ -155,67 +114,70 @@ func (m *Manager) exportJSON(rows *sql.Rows, columns []string, outputPath string
 		if err := enc.Encode(rowMap); err != nil {
 			return err
 		}
+		progress.add()
 	}
 
-	if _, err := file.WriteString("\n]"); err != nil {
+	if _, err := buf.WriteString("\n]"); err != nil {
 		return err
 	}
 
-	return rows.Err()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	return out.Close()
 }
 
-func (m *Manager) exportXLSX(rows *sql.Rows, columns []string, outputPath string) error {
-	f := excelize.NewFile()
-	defer func() {
-		if err := f.Close(); err != nil {
-			fmt.Println(err)
-		}
-	}()
+// exportXLSX writes rows to a workbook with cells typed by column
+func (m *Manager) exportXLSX(ctx context.Context, rows *sql.Rows, name string, columns []ColumnInfo, outputPath string, progress *progressReporter) error {
+	names := make([]string, len(columns))
+	types := make([]string, len(columns))
+	for i, col := range columns {
+		names[i], types[i] = col.Name, col.Type
+	}
 
-	sheetName := "Sheet1"
-	// Create a new sheet.
-	index, err := f.NewSheet(sheetName)
+	w, err := newXLSXWriter()
 	if err != nil {
 		return err
 	}
-	f.SetActiveSheet(index)
+	defer w.file.Close()
 
-	// Write Header
-	for i, col := range columns {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		f.SetCellValue(sheetName, cell, col)
+	sheet, err := w.addSheet(name, names, types)
+	if err != nil {
+		return err
 	}
-
-	// Write Rows
-	rowIdx := 2
 	values := make([]interface{}, len(columns))
 	valuePtrs := make([]interface{}, len(columns))
 	for i := range values {
 		valuePtrs[i] = &values[i]
 	}
-
 	for rows.Next() {
 		if err := rows.Scan(valuePtrs...); err != nil {
 			return err
 		}
-
-		for i, val := range values {
-			cell, _ := excelize.CoordinatesToCellName(i+1, rowIdx)
-			// Handle []byte
-			if v, ok := val.([]byte); ok {
-				f.SetCellValue(sheetName, cell, string(v))
-			} else {
-				f.SetCellValue(sheetName, cell, val)
-			}
+		if err := sheet.addRow(values); err != nil {
+			return err
 		}
-		rowIdx++
+		progress.add()
 	}
-
-	if err := f.SaveAs(outputPath); err != nil {
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := sheet.flush(); err != nil {
 		return err
 	}
 
-	return rows.Err()
+	out, err := m.createOutput(ctx, outputPath, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if err != nil {
+		return err
+	}
+	defer out.Abort()
+	if err := w.write(out); err != nil {
+		return err
+	}
+	return out.Close()
 }
 
 func formatValue(val interface{}) string {
@@ -0,0 +1,213 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// CSVExportOptions controls how rows are written to a CSV file
+type CSVExportOptions struct {
+	Delimiter string `json:"delimiter,omitempty"` // Single character, comma by default
+	Quote     string `json:"quote,omitempty"`     // minimal (default): only values that need it; all: every non-NULL value
+	Null      string `json:"null,omitempty"`      // Written for NULL, empty by default; values equal to it are quoted
+	NoHeader  bool   `json:"noHeader,omitempty"`  // Leave out the row of column names
+}
+
+// delimiter returns the delimiter of the options, checking it is one character
+func (o CSVExportOptions) delimiter() (rune, error) {
+	if o.Delimiter == "" {
+		return ',', nil
+	}
+	r, size := utf8.DecodeRuneInString(o.Delimiter)
+	if size != len(o.Delimiter) || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("delimiter must be a single character other than a quote or line break")
+	}
+	return r, nil
+}
+
+// ExportTableCSV streams the rows of a table to a CSV file, or to an object
+// when outputPath is an s3:// or gs:// URL. Rows are written as they are
+// read, with "export:progress" events every 1000 rows, so tables of any size
+// export in constant memory. A cancelled or failed export removes its
+// partial output file or aborts its upload.
+func (m *Manager) ExportTableCSV(dbName, schema, tableName, outputPath string, opts CSVExportOptions) error {
+	if m.getDB() == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	query := fmt.Sprintf("SELECT * FROM %s", m.tableDriver(schema).QualifiedName(dbName, tableName))
+	return m.exportCSV(tableName, query, outputPath, opts)
+}
+
+// ExportQueryCSV streams the result of a query to a CSV file like
+// ExportTableCSV. The query must be a single statement returning rows.
+func (m *Manager) ExportQueryCSV(query, outputPath string, opts CSVExportOptions) error {
+	if m.getDB() == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	_, mysql := m.currentDriver().(*MySQLDriver)
+	statements := splitStatements(query, mysql)
+	if len(statements) != 1 || !returnsRows(statements[0]) {
+		return fmt.Errorf("only a single query returning rows can be exported")
+	}
+	return m.exportCSV("query", statements[0], outputPath, opts)
+}
+
+// exportCSV writes the rows of query to a CSV file. PostgreSQL formats them
+// on the server with COPY; other drivers scan and encode each row here.
+func (m *Manager) exportCSV(name, query, outputPath string, opts CSVExportOptions) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	if _, err := opts.delimiter(); err != nil {
+		return err
+	}
+
+	ctx, done := m.track("export", name)
+	defer done()
+	db = db.withContext(ctx)
+
+	progress := m.newProgress("export", name, 0)
+	var err error
+	if driver, ok := m.currentDriver().(*PostgresDriver); ok {
+		err = m.copyOutCSV(ctx, db, driver, query, outputPath, opts, progress)
+	} else {
+		err = m.scanOutCSV(ctx, db, query, outputPath, opts, progress)
+	}
+	if err != nil {
+		return err
+	}
+	progress.finish()
+	return nil
+}
+
+// scanOutCSV runs query and writes each row to a CSV file as it is read
+func (m *Manager) scanOutCSV(ctx context.Context, db *instrumentedDB, query, outputPath string, opts CSVExportOptions, progress *progressReporter) error {
+	rows, err := db.Query(query)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	out, err := m.createOutput(ctx, outputPath, "text/csv")
+	if err != nil {
+		return err
+	}
+	defer out.Abort()
+
+	buf := bufio.NewWriter(out)
+	w := newCSVWriter(buf, opts)
+	if !opts.NoHeader {
+		if err := w.writeHeader(columns); err != nil {
+			return err
+		}
+	}
+	if err := writeCSVRows(w, rows, len(columns), progress); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// writeCSVRows scans rows and writes them as CSV records
+func writeCSVRows(w *csvWriter, rows *sql.Rows, count int, progress *progressReporter) error {
+	values := make([]interface{}, count)
+	ptrs := make([]interface{}, count)
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		if err := w.writeRow(values); err != nil {
+			return err
+		}
+		progress.add()
+	}
+	return rows.Err()
+}
+
+// csvWriter writes CSV records the way PostgreSQL's COPY does: NULL as the
+// null string, and values quoted when they contain the delimiter, a quote or
+// a line break, when they could be mistaken for NULL or, with Quote all,
+// always. encoding/csv can't tell NULL from an empty string, nor quote all.
+type csvWriter struct {
+	w         io.Writer
+	delimiter rune
+	quoteAll  bool
+	null      string
+	line      strings.Builder
+}
+
+// newCSVWriter returns a writer for options whose delimiter was checked
+func newCSVWriter(w io.Writer, opts CSVExportOptions) *csvWriter {
+	delimiter, _ := opts.delimiter()
+	return &csvWriter{w: w, delimiter: delimiter, quoteAll: opts.Quote == "all", null: opts.Null}
+}
+
+// writeHeader writes the column names
+func (c *csvWriter) writeHeader(columns []string) error {
+	values := make([]interface{}, len(columns))
+	for i, name := range columns {
+		values[i] = name
+	}
+	return c.writeRow(values)
+}
+
+// writeRow writes a record of scanned values
+func (c *csvWriter) writeRow(values []interface{}) error {
+	c.line.Reset()
+	for i, val := range values {
+		if i > 0 {
+			c.line.WriteRune(c.delimiter)
+		}
+		if val == nil {
+			c.line.WriteString(c.null)
+			continue
+		}
+		c.writeField(formatValue(val))
+	}
+	c.line.WriteByte('\n')
+	_, err := io.WriteString(c.w, c.line.String())
+	return err
+}
+
+// writeField adds a non-NULL value to the record, quoted when needed
+func (c *csvWriter) writeField(field string) {
+	if !c.quoteAll && field != c.null && !strings.ContainsRune(field, c.delimiter) && !strings.ContainsAny(field, "\"\r\n") {
+		c.line.WriteString(field)
+		return
+	}
+	c.line.WriteByte('"')
+	c.line.WriteString(strings.ReplaceAll(field, `"`, `""`))
+	c.line.WriteByte('"')
+}
+
+// copyOptions returns the COPY options matching CSV export options
+func (o CSVExportOptions) copyOptions() string {
+	options := []string{"FORMAT csv"}
+	if o.Delimiter != "" {
+		options = append(options, "DELIMITER "+quoteLiteral(o.Delimiter))
+	}
+	options = append(options, "NULL "+quoteLiteral(o.Null))
+	if !o.NoHeader {
+		options = append(options, "HEADER")
+	}
+	if o.Quote == "all" {
+		options = append(options, "FORCE_QUOTE *")
+	}
+	return strings.Join(options, ", ")
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+)
+
+// htmlDocumentHead opens a standalone HTML export
+const htmlDocumentHead = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Query results</title>
+</head>
+<body style="font-family: sans-serif">
+`
+
+// ExportResultsMarkup writes result sets to a GitHub-flavored Markdown
+// (markdown) or HTML (html) file of tables, for wikis, pull requests and
+// incident docs. Several result sets get a heading each.
+func (m *Manager) ExportResultsMarkup(results []QueryResult, format, outputPath string) error {
+	if len(results) == 0 {
+		return fmt.Errorf("no results to export")
+	}
+
+	var sb strings.Builder
+	if format == "html" {
+		sb.WriteString(htmlDocumentHead)
+	} else if format != "markdown" {
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	opts := CopyOptions{IncludeHeaders: true, Styled: true}
+	for i, result := range results {
+		columns := make([]ColumnInfo, len(result.Columns))
+		for j, name := range result.Columns {
+			columns[j] = ColumnInfo{Name: name}
+			if j < len(result.ColumnTypes) {
+				columns[j].Type = result.ColumnTypes[j]
+			}
+		}
+
+		var table string
+		var err error
+		title := fmt.Sprintf("Result %d", i+1)
+		if format == "html" {
+			if len(results) > 1 {
+				sb.WriteString("<h3>" + html.EscapeString(title) + "</h3>\n")
+			}
+			table, err = FormatCellsHTML(columns, result.Rows, opts)
+		} else {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			if len(results) > 1 {
+				sb.WriteString("### " + title + "\n\n")
+			}
+			table, err = formatMarkdown(columns, result.Rows, opts)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to format result %d: %w", i+1, err)
+		}
+		sb.WriteString(table)
+		if format == "html" {
+			sb.WriteString("\n")
+		}
+	}
+	if format == "html" {
+		sb.WriteString("</body>\n</html>\n")
+	}
+
+	if err := os.WriteFile(outputPath, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
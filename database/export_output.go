@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// UploadProgress reports how much of an export has been uploaded to a
+// bucket. It is emitted as "upload:progress" events after each part.
+type UploadProgress struct {
+	Location string `json:"location"` // s3:// or gs:// URL of the object
+	Bytes    int64  `json:"bytes"`
+	Parts    int    `json:"parts"`
+	Done     bool   `json:"done"`
+}
+
+// exportOutput is where an export is written: a local file, or an object in
+// a bucket uploaded while it is written
+type exportOutput interface {
+	io.Writer
+	Close() error // Finishes the file or completes the upload
+	Abort()       // Discards what was written, unless closed
+}
+
+// isBucketURL reports whether an export path is an s3:// or gs:// URL
+func isBucketURL(p string) bool {
+	return strings.HasPrefix(p, "s3://") || strings.HasPrefix(p, "gs://")
+}
+
+// parseBucketURL reads the bucket and key of an export path such as
+// s3://bucket/reports/orders.csv?region=eu-west-1&profile=prod. Besides
+// region and profile, endpoint names an S3-compatible service. gs:// URLs
+// use the XML API of Google Cloud Storage with HMAC keys from the profile.
+func parseBucketURL(p string) (S3Destination, string, error) {
+	u, err := url.Parse(p)
+	if err != nil {
+		return S3Destination{}, "", fmt.Errorf("invalid bucket URL: %w", err)
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" || strings.HasSuffix(key, "/") {
+		return S3Destination{}, "", fmt.Errorf("bucket URL must name a bucket and an object: %s", bucketLocation(p))
+	}
+	query := u.Query()
+	dest := S3Destination{
+		Bucket:   u.Host,
+		Region:   query.Get("region"),
+		Profile:  query.Get("profile"),
+		Endpoint: query.Get("endpoint"),
+	}
+	if u.Scheme == "gs" {
+		if dest.Endpoint == "" {
+			dest.Endpoint = gcsEndpoint
+		}
+		if dest.Region == "" {
+			dest.Region = "auto"
+		}
+	}
+	return dest, key, nil
+}
+
+// bucketLocation returns an export path without the options of a bucket
+// URL, for reporting where an export went
+func bucketLocation(p string) string {
+	if i := strings.IndexByte(p, '?'); i >= 0 && isBucketURL(p) {
+		return p[:i]
+	}
+	return p
+}
+
+// numberedPath returns the path of the nth file of a split export, e.g.
+// dump.002.sql, keeping the options of a bucket URL
+func numberedPath(p string, n int) string {
+	query := ""
+	if i := strings.IndexByte(p, '?'); i >= 0 && isBucketURL(p) {
+		p, query = p[:i], p[i:]
+	}
+	ext := path.Ext(p)
+	if !isBucketURL(p) {
+		ext = filepath.Ext(p)
+	}
+	return fmt.Sprintf("%s.%03d%s", strings.TrimSuffix(p, ext), n, ext) + query
+}
+
+// createOutput opens the file or starts the upload an export writes to.
+// Uploads are cancelled with ctx and report "upload:progress" events.
+func (m *Manager) createOutput(ctx context.Context, p, contentType string) (exportOutput, error) {
+	if !isBucketURL(p) {
+		file, err := os.Create(p)
+		if err != nil {
+			return nil, err
+		}
+		return &fileOutput{File: file, path: p}, nil
+	}
+
+	dest, key, err := parseBucketURL(p)
+	if err != nil {
+		return nil, err
+	}
+	return m.createBucketOutput(ctx, dest, key, bucketLocation(p), contentType)
+}
+
+// createBucketOutput starts the upload of an object, reported under location
+func (m *Manager) createBucketOutput(ctx context.Context, dest S3Destination, key, location, contentType string) (exportOutput, error) {
+	client, err := newS3Client(ctx, dest)
+	if err != nil {
+		return nil, err
+	}
+	return newS3Writer(ctx, client, dest, key, contentType, func(bytes int64, parts int, done bool) {
+		m.emit("upload:progress", UploadProgress{Location: location, Bytes: bytes, Parts: parts, Done: done})
+	}), nil
+}
+
+// removeOutput deletes a finished export file or object
+func removeOutput(p string) error {
+	if !isBucketURL(p) {
+		return os.Remove(p)
+	}
+	dest, key, err := parseBucketURL(p)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client, err := newS3Client(ctx, dest)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(dest.Bucket), Key: aws.String(key)})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", bucketLocation(p), err)
+	}
+	return nil
+}
+
+// fileOutput is an export written to a local file
+type fileOutput struct {
+	*os.File
+	path   string
+	closed bool
+}
+
+func (f *fileOutput) Close() error {
+	if err := f.File.Close(); err != nil {
+		return err
+	}
+	f.closed = true
+	return nil
+}
+
+// Abort removes the partial file
+func (f *fileOutput) Abort() {
+	if f.closed {
+		return
+	}
+	f.closed = true
+	f.File.Close()
+	os.Remove(f.path)
+}
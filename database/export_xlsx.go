@@ -0,0 +1,325 @@
+package database
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ExportResultsXLSX writes result sets to an Excel workbook, one sheet per
+// result set. Cells are typed by their column: numbers, booleans, dates and
+// times stay sortable and summable rather than becoming text. The header row
+// is bold and frozen.
+func (m *Manager) ExportResultsXLSX(results []QueryResult, outputPath string) error {
+	if len(results) == 0 {
+		return fmt.Errorf("no results to export")
+	}
+
+	w, err := newXLSXWriter()
+	if err != nil {
+		return err
+	}
+	defer w.file.Close()
+
+	for i, result := range results {
+		name := "Result"
+		if len(results) > 1 {
+			name = fmt.Sprintf("Result %d", i+1)
+		}
+		sheet, err := w.addSheet(name, result.Columns, result.ColumnTypes)
+		if err != nil {
+			return err
+		}
+		for _, row := range result.Rows {
+			if err := sheet.addRow(row); err != nil {
+				return err
+			}
+		}
+		if err := sheet.flush(); err != nil {
+			return err
+		}
+	}
+	return w.save(outputPath)
+}
+
+// xlsxWriter builds a workbook sheet by sheet, streaming the rows of each
+type xlsxWriter struct {
+	file   *excelize.File
+	sheets int
+	styles map[string]int // Style of the header and of each date or time category
+}
+
+// xlsxSheet is a sheet being written; rows beyond Excel's limit continue on
+// a sheet of their own
+type xlsxSheet struct {
+	w          *xlsxWriter
+	name       string
+	columns    []string
+	categories []string
+	stream     *excelize.StreamWriter
+	row        int
+	part       int
+}
+
+func newXLSXWriter() (*xlsxWriter, error) {
+	f := excelize.NewFile()
+	w := &xlsxWriter{file: f, styles: make(map[string]int)}
+
+	formats := map[string]string{
+		typeCategoryTimestamp: "yyyy-mm-dd hh:mm:ss",
+		typeCategoryDate:      "yyyy-mm-dd",
+		typeCategoryTime:      "hh:mm:ss",
+	}
+	for category, format := range formats {
+		style, err := f.NewStyle(&excelize.Style{CustomNumFmt: &format})
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to create cell style: %w", err)
+		}
+		w.styles[category] = style
+	}
+	header, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"E7E6E6"}},
+	})
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to create cell style: %w", err)
+	}
+	w.styles["header"] = header
+	return w, nil
+}
+
+// addSheet starts a sheet with a frozen header row of the column names
+func (w *xlsxWriter) addSheet(name string, columns, types []string) (*xlsxSheet, error) {
+	s := &xlsxSheet{w: w, name: xlsxSheetName(name), columns: columns, categories: make([]string, len(columns))}
+	for i := range columns {
+		if i < len(types) {
+			s.categories[i] = typeCategory(types[i])
+		}
+	}
+	if err := s.start(s.name); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// start opens the stream of a new sheet and writes the header
+func (s *xlsxSheet) start(name string) error {
+	w := s.w
+	// A new workbook comes with an empty Sheet1, which is taken for the first sheet
+	if w.sheets == 0 {
+		if err := w.file.SetSheetName("Sheet1", name); err != nil {
+			return fmt.Errorf("failed to name sheet: %w", err)
+		}
+	} else if _, err := w.file.NewSheet(name); err != nil {
+		return fmt.Errorf("failed to add sheet: %w", err)
+	}
+	w.sheets++
+
+	stream, err := w.file.NewStreamWriter(name)
+	if err != nil {
+		return fmt.Errorf("failed to write sheet: %w", err)
+	}
+	if err := stream.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+	for i, category := range s.categories {
+		width := 14.0
+		switch category {
+		case typeCategoryTimestamp:
+			width = 20
+		case typeCategoryText, typeCategoryJSON:
+			width = 24
+		}
+		if err := stream.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
+	}
+
+	header := make([]interface{}, len(s.columns))
+	for i, name := range s.columns {
+		header[i] = excelize.Cell{StyleID: w.styles["header"], Value: name}
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		return err
+	}
+	s.stream, s.row = stream, 1
+	return nil
+}
+
+// addRow writes a row of values as typed cells
+func (s *xlsxSheet) addRow(values []interface{}) error {
+	if s.row == excelize.TotalRows {
+		if err := s.flush(); err != nil {
+			return err
+		}
+		s.part++
+		suffix := fmt.Sprintf(" (%d)", s.part+1)
+		base := []rune(s.name)
+		base = base[:min(len(base), excelize.MaxSheetNameLength-len(suffix))]
+		if err := s.start(string(base) + suffix); err != nil {
+			return err
+		}
+	}
+
+	cells := make([]interface{}, len(values))
+	for i, v := range values {
+		category := ""
+		if i < len(s.categories) {
+			category = s.categories[i]
+		}
+		cells[i] = s.w.cell(category, v)
+	}
+	s.row++
+	cell, _ := excelize.CoordinatesToCellName(1, s.row)
+	return s.stream.SetRow(cell, cells)
+}
+
+// flush ends the stream of the sheet
+func (s *xlsxSheet) flush() error {
+	if err := s.stream.Flush(); err != nil {
+		return fmt.Errorf("failed to write sheet: %w", err)
+	}
+	return nil
+}
+
+// save writes the workbook, opening it on the first sheet
+func (w *xlsxWriter) save(outputPath string) error {
+	w.file.SetActiveSheet(0)
+	if err := w.file.SaveAs(outputPath); err != nil {
+		return fmt.Errorf("failed to save workbook: %w", err)
+	}
+	return nil
+}
+
+// write writes the workbook to an export output
+func (w *xlsxWriter) write(out io.Writer) error {
+	w.file.SetActiveSheet(0)
+	if _, err := w.file.WriteTo(out); err != nil {
+		return fmt.Errorf("failed to save workbook: %w", err)
+	}
+	return nil
+}
+
+// timeLayouts are the forms dates and times arrive in: RFC 3339 from
+// scanned time.Time values, and the server's own text otherwise
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// clockLayouts are the forms of times of day
+var clockLayouts = []string{
+	"15:04:05.999999999",
+	"15:04:05.999999999Z07:00",
+	"15:04:05.999999999Z07",
+}
+
+// cell converts a value to a cell of its column's type. Values that don't
+// parse as their type are written as text rather than failing the export.
+func (w *xlsxWriter) cell(category string, v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if category == typeCategoryBinary || !utf8.Valid(val) {
+			return `\x` + hex.EncodeToString(val)
+		}
+		v = string(val)
+	case time.Time:
+		return w.timeCell(category, val)
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(val)
+		v = string(data)
+	}
+
+	text, ok := v.(string)
+	if !ok {
+		return v // Numbers and booleans
+	}
+	switch category {
+	case typeCategoryInteger:
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return n
+		}
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	case typeCategoryFloat, typeCategoryDecimal:
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	case typeCategoryBoolean:
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	case typeCategoryTimestamp, typeCategoryDate:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				return w.timeCell(category, t)
+			}
+		}
+	case typeCategoryTime:
+		for _, layout := range clockLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				return w.timeCell(category, t)
+			}
+		}
+	}
+
+	// Longer text is cut to what a cell can hold
+	if utf8.RuneCountInString(text) > excelize.TotalCellChars {
+		text = string([]rune(text)[:excelize.TotalCellChars])
+	}
+	return text
+}
+
+// timeCell returns a date or time cell showing the time as the database
+// reported it; Excel has no time zones
+func (w *xlsxWriter) timeCell(category string, t time.Time) interface{} {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	style, ok := w.styles[category]
+	if !ok {
+		style = w.styles[typeCategoryTimestamp]
+	}
+	if category == typeCategoryTime {
+		// A time of day is a fraction of a day
+		day := wall.Sub(time.Date(wall.Year(), wall.Month(), wall.Day(), 0, 0, 0, 0, time.UTC))
+		return excelize.Cell{StyleID: style, Value: day.Hours() / 24}
+	}
+	return excelize.Cell{StyleID: style, Value: wall}
+}
+
+// xlsxSheetName makes a valid sheet name: at most 31 characters, none of
+// which are : \ / ? * [ or ]
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if utf8.RuneCountInString(name) > excelize.MaxSheetNameLength {
+		name = string([]rune(name)[:excelize.MaxSheetNameLength])
+	}
+	if name == "" {
+		name = "Sheet"
+	}
+	return name
+}
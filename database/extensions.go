@@ -0,0 +1,106 @@
+package database
+
+import (
+	"fmt"
+)
+
+// ExtensionInfo describes a PostgreSQL extension available on the server
+type ExtensionInfo struct {
+	Name             string `json:"name"`
+	DefaultVersion   string `json:"defaultVersion"`
+	InstalledVersion string `json:"installedVersion"` // Empty when not installed in this database
+	Schema           string `json:"schema"`           // Schema holding the extension's objects, when installed
+	Comment          string `json:"comment"`
+}
+
+// GetExtensions lists the extensions available on the server and whether they
+// are installed in the current database. Only PostgreSQL is supported.
+func (m *Manager) GetExtensions() ([]ExtensionInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if _, ok := m.currentDriver().(*PostgresDriver); !ok {
+		return nil, fmt.Errorf("extensions are only available for PostgreSQL")
+	}
+
+	rows, err := db.Query(`
+		SELECT
+			a.name,
+			COALESCE(a.default_version, ''),
+			COALESCE(e.extversion, ''),
+			COALESCE(n.nspname, ''),
+			COALESCE(a.comment, '')
+		FROM pg_available_extensions a
+		LEFT JOIN pg_extension e ON e.extname = a.name
+		LEFT JOIN pg_namespace n ON n.oid = e.extnamespace
+		ORDER BY e.extname IS NULL, a.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extensions: %w", err)
+	}
+	defer rows.Close()
+
+	extensions := []ExtensionInfo{}
+	for rows.Next() {
+		var e ExtensionInfo
+		if err := rows.Scan(&e.Name, &e.DefaultVersion, &e.InstalledVersion, &e.Schema, &e.Comment); err != nil {
+			return nil, fmt.Errorf("failed to get extensions: %w", err)
+		}
+		extensions = append(extensions, e)
+	}
+	return extensions, rows.Err()
+}
+
+// CreateExtension installs an extension, optionally into schema and with the
+// extensions it requires
+func (m *Manager) CreateExtension(name, schema string, cascade bool) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	driver, ok := m.currentDriver().(*PostgresDriver)
+	if !ok {
+		return fmt.Errorf("extensions are only available for PostgreSQL")
+	}
+	if name == "" {
+		return fmt.Errorf("extension name is required")
+	}
+
+	query := "CREATE EXTENSION IF NOT EXISTS " + driver.QuoteIdentifier(name)
+	if schema != "" {
+		query += " SCHEMA " + driver.QuoteIdentifier(schema)
+	}
+	if cascade {
+		query += " CASCADE"
+	}
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create extension: %w", err)
+	}
+
+	return nil
+}
+
+// DropExtension removes an extension; cascade also drops the objects that depend on it
+func (m *Manager) DropExtension(name string, cascade bool) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	driver, ok := m.currentDriver().(*PostgresDriver)
+	if !ok {
+		return fmt.Errorf("extensions are only available for PostgreSQL")
+	}
+
+	query := "DROP EXTENSION " + driver.QuoteIdentifier(name)
+	if cascade {
+		query += " CASCADE"
+	}
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to drop extension: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ForeignServerInfo describes a PostgreSQL foreign server
+type ForeignServerInfo struct {
+	Name    string            `json:"name"`
+	Wrapper string            `json:"wrapper"` // Foreign data wrapper, e.g. postgres_fdw
+	Type    string            `json:"type,omitempty"`
+	Version string            `json:"version,omitempty"`
+	Owner   string            `json:"owner"`
+	Options map[string]string `json:"options"` // e.g. host, port, dbname
+	Comment string            `json:"comment"`
+}
+
+// ForeignTableInfo describes a PostgreSQL foreign table. Foreign tables are
+// also listed by GetTables with engine "foreign" and are queried like any
+// other table.
+type ForeignTableInfo struct {
+	Name    string            `json:"name"`
+	Server  string            `json:"server"`
+	Options map[string]string `json:"options"` // e.g. schema_name, table_name
+	Comment string            `json:"comment"`
+}
+
+// GetForeignServers lists the foreign servers of the current database. Only
+// PostgreSQL is supported.
+func (m *Manager) GetForeignServers() ([]ForeignServerInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if _, ok := m.currentDriver().(*PostgresDriver); !ok {
+		return nil, fmt.Errorf("foreign servers are only available for PostgreSQL")
+	}
+
+	rows, err := db.Query(`
+		SELECT
+			s.srvname,
+			w.fdwname,
+			COALESCE(s.srvtype, ''),
+			COALESCE(s.srvversion, ''),
+			pg_get_userbyid(s.srvowner),
+			COALESCE(obj_description(s.oid, 'pg_foreign_server'), ''),
+			o.option_name,
+			o.option_value
+		FROM pg_foreign_server s
+		JOIN pg_foreign_data_wrapper w ON w.oid = s.srvfdw
+		LEFT JOIN LATERAL pg_options_to_table(s.srvoptions) o ON true
+		ORDER BY s.srvname
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get foreign servers: %w", err)
+	}
+	defer rows.Close()
+
+	servers := []ForeignServerInfo{}
+	for rows.Next() {
+		var s ForeignServerInfo
+		var option, value sql.NullString
+		if err := rows.Scan(&s.Name, &s.Wrapper, &s.Type, &s.Version, &s.Owner, &s.Comment, &option, &value); err != nil {
+			return nil, fmt.Errorf("failed to get foreign servers: %w", err)
+		}
+
+		if n := len(servers); n == 0 || servers[n-1].Name != s.Name {
+			s.Options = map[string]string{}
+			servers = append(servers, s)
+		}
+		if option.Valid {
+			servers[len(servers)-1].Options[option.String] = value.String
+		}
+	}
+	return servers, rows.Err()
+}
+
+// GetForeignTables lists the foreign tables of the current schema with the
+// server they read from. Only PostgreSQL is supported.
+func (m *Manager) GetForeignTables(database string) ([]ForeignTableInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	driver, ok := m.currentDriver().(*PostgresDriver)
+	if !ok {
+		return nil, fmt.Errorf("foreign tables are only available for PostgreSQL")
+	}
+
+	rows, err := db.Query(`
+		SELECT
+			c.relname,
+			s.srvname,
+			COALESCE(obj_description(c.oid, 'pg_class'), ''),
+			o.option_name,
+			o.option_value
+		FROM pg_foreign_table ft
+		JOIN pg_class c ON c.oid = ft.ftrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_foreign_server s ON s.oid = ft.ftserver
+		LEFT JOIN LATERAL pg_options_to_table(ft.ftoptions) o ON true
+		WHERE n.nspname = $1
+		ORDER BY c.relname
+	`, driver.schemaName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get foreign tables: %w", err)
+	}
+	defer rows.Close()
+
+	tables := []ForeignTableInfo{}
+	for rows.Next() {
+		var t ForeignTableInfo
+		var option, value sql.NullString
+		if err := rows.Scan(&t.Name, &t.Server, &t.Comment, &option, &value); err != nil {
+			return nil, fmt.Errorf("failed to get foreign tables: %w", err)
+		}
+
+		if n := len(tables); n == 0 || tables[n-1].Name != t.Name {
+			t.Options = map[string]string{}
+			tables = append(tables, t)
+		}
+		if option.Valid {
+			tables[len(tables)-1].Options[option.String] = value.String
+		}
+	}
+	return tables, rows.Err()
+}
+
+// foreignTableClause renders the SERVER and OPTIONS clauses of a foreign table definition
+func (d *PostgresDriver) foreignTableClause(db Querier, name string) (string, error) {
+	var server, options string
+	err := db.QueryRow(`
+		SELECT
+			quote_ident(s.srvname),
+			COALESCE((
+				SELECT string_agg(quote_ident(o.option_name) || ' ' || quote_literal(o.option_value), ', ')
+				FROM pg_options_to_table(ft.ftoptions) o
+			), '')
+		FROM pg_foreign_table ft
+		JOIN pg_foreign_server s ON s.oid = ft.ftserver
+		WHERE ft.ftrelid = $1::regclass
+	`, name).Scan(&server, &options)
+	if err != nil {
+		return "", err
+	}
+
+	clause := " SERVER " + server
+	if options != "" {
+		clause += " OPTIONS (" + options + ")"
+	}
+	return clause, nil
+}
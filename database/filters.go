@@ -0,0 +1,263 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterCondition is one condition of a table data filter. Values are bound
+// as query arguments and never become part of the SQL text.
+type FilterCondition struct {
+	Column   string      `json:"column,omitempty"`
+	Operator string      `json:"operator,omitempty"` // See compileFilter for the supported operators
+	Value    interface{} `json:"value,omitempty"`    // A list for IN and NOT IN, [low, high] for BETWEEN, unused for IS [NOT] NULL
+
+	// Keys of a value nested in a JSON column to compare instead of the
+	// column itself; the value is compared as text, or as a number when Value
+	// is one, in which case values that aren't numbers don't match
+	Path []string `json:"path,omitempty"`
+
+	// AND (default) or OR, joining the condition to the one before it
+	Conjunction string `json:"conjunction,omitempty"`
+
+	// Parenthesized sub-conditions, used instead of Column and Operator
+	Group []FilterCondition `json:"group,omitempty"`
+}
+
+// likeEscaper makes LIKE wildcards match literally, using the default
+// backslash escape of both dialects
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// filterDialect holds what compiling filters needs to know about a database
+type filterDialect struct {
+	quote       func(name string) string
+	placeholder func(n int) string         // Placeholder of the n-th argument, from 1
+	textColumn  func(column string) string // Column expression LIKE compares as text
+
+	// Case-insensitive LIKE and regular expression match of a text column
+	// expression against a placeholder
+	ilike func(column, pattern string) string
+	regex func(column, pattern string) string
+
+	// Text of the value at path inside a JSON column; bind renders each
+	// argument of the path
+	jsonPath func(column string, path []string, bind func(interface{}) string) string
+	// Number at path inside a JSON column, NULL when the value there is not
+	// a number
+	numeric func(column string, path []string, bind func(interface{}) string) string
+	// literal quotes a string constant
+	literal func(s string) string
+}
+
+// compileFilters renders conditions as a WHERE clause and its arguments. It
+// returns an empty clause when there are no conditions.
+func compileFilters(dialect filterDialect, filters []FilterCondition) (string, []interface{}, error) {
+	return compileFiltersAfter(dialect, filters, 0)
+}
+
+// compileFiltersAfter is compileFilters for a statement whose first taken
+// placeholders are bound to other values, such as the SET values of an
+// UPDATE. Only the arguments of the filters are returned.
+func compileFiltersAfter(dialect filterDialect, filters []FilterCondition, taken int) (string, []interface{}, error) {
+	args := make([]interface{}, taken)
+	expr, err := compileFilterGroup(dialect, filters, &args)
+	if err != nil {
+		return "", nil, err
+	}
+	if expr == "" {
+		return "", nil, nil
+	}
+	return " WHERE " + expr, args[taken:], nil
+}
+
+func compileFilterGroup(dialect filterDialect, filters []FilterCondition, args *[]interface{}) (string, error) {
+	var sb strings.Builder
+	for i, f := range filters {
+		var cond string
+		var err error
+		if len(f.Group) > 0 {
+			cond, err = compileFilterGroup(dialect, f.Group, args)
+			cond = "(" + cond + ")"
+		} else {
+			cond, err = compileFilter(dialect, f, args)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if i > 0 {
+			switch conj := strings.ToUpper(strings.TrimSpace(f.Conjunction)); conj {
+			case "", "AND":
+				sb.WriteString(" AND ")
+			case "OR":
+				sb.WriteString(" OR ")
+			default:
+				return "", fmt.Errorf("unsupported filter conjunction: %s", f.Conjunction)
+			}
+		}
+		sb.WriteString(cond)
+	}
+	return sb.String(), nil
+}
+
+// compileFilter renders a single condition. Operators:
+//
+//	=, !=, <>, <, <=, >, >=    comparison with Value
+//	LIKE, NOT LIKE             pattern match, case sensitivity as the collation decides
+//	ILIKE, NOT ILIKE           case-insensitive pattern match
+//	CONTAINS, NOT CONTAINS     case-insensitive substring match; % and _ match literally
+//	REGEXP, NOT REGEXP         regular expression match
+//	IN, NOT IN                 membership in the list Value
+//	BETWEEN, NOT BETWEEN       inclusive range, Value being [low, high]
+//	IS NULL, IS NOT NULL
+func compileFilter(dialect filterDialect, f FilterCondition, args *[]interface{}) (string, error) {
+	if f.Column == "" {
+		return "", fmt.Errorf("filter column is required")
+	}
+	bind := func(v interface{}) string {
+		*args = append(*args, v)
+		return dialect.placeholder(len(*args))
+	}
+	op := strings.ToUpper(strings.Join(strings.Fields(f.Operator), " "))
+	col := dialect.quote(f.Column)
+	if len(f.Path) > 0 {
+		// Extracted JSON is text; compare it numerically against numbers
+		if numericComparison(op, f.Value) {
+			col = dialect.numeric(col, f.Path, bind)
+		} else {
+			col = dialect.jsonPath(col, f.Path, bind)
+		}
+	}
+
+	switch op {
+	case "=", "!=", "<>", "<", "<=", ">", ">=":
+		if f.Value == nil {
+			return "", fmt.Errorf("filter on %s needs a value; use IS NULL to match NULL", f.Column)
+		}
+		return fmt.Sprintf("%s %s %s", col, op, bind(f.Value)), nil
+	case "LIKE", "NOT LIKE":
+		if f.Value == nil {
+			return "", fmt.Errorf("filter on %s needs a pattern", f.Column)
+		}
+		return fmt.Sprintf("%s %s %s", dialect.textColumn(col), op, bind(fmt.Sprint(f.Value))), nil
+	case "ILIKE", "NOT ILIKE", "CONTAINS", "NOT CONTAINS", "REGEXP", "NOT REGEXP":
+		if f.Value == nil {
+			return "", fmt.Errorf("filter on %s needs a pattern", f.Column)
+		}
+		pattern := fmt.Sprint(f.Value)
+		match := dialect.ilike
+		switch strings.TrimPrefix(op, "NOT ") {
+		case "CONTAINS":
+			pattern = "%" + likeEscaper.Replace(pattern) + "%"
+		case "REGEXP":
+			match = dialect.regex
+		}
+		cond := match(dialect.textColumn(col), bind(pattern))
+		if strings.HasPrefix(op, "NOT ") {
+			cond = "NOT (" + cond + ")"
+		}
+		return cond, nil
+	case "BETWEEN", "NOT BETWEEN":
+		bounds, ok := f.Value.([]interface{})
+		if !ok || len(bounds) != 2 || bounds[0] == nil || bounds[1] == nil {
+			return "", fmt.Errorf("%s filter on %s needs a low and a high value", op, f.Column)
+		}
+		low := bind(bounds[0])
+		return fmt.Sprintf("%s %s %s AND %s", col, op, low, bind(bounds[1])), nil
+	case "IN", "NOT IN":
+		values, ok := f.Value.([]interface{})
+		if !ok || len(values) == 0 {
+			return "", fmt.Errorf("%s filter on %s needs a list of values", op, f.Column)
+		}
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			placeholders[i] = bind(v)
+		}
+		return fmt.Sprintf("%s %s (%s)", col, op, strings.Join(placeholders, ", ")), nil
+	case "IS NULL", "IS NOT NULL":
+		return col + " " + op, nil
+	default:
+		return "", fmt.Errorf("unsupported filter operator: %s", f.Operator)
+	}
+}
+
+// numericComparison reports whether a comparison is against a number, the
+// low bound deciding for BETWEEN
+func numericComparison(op string, value interface{}) bool {
+	switch op {
+	case "=", "!=", "<>", "<", "<=", ">", ">=":
+	case "BETWEEN", "NOT BETWEEN":
+		bounds, ok := value.([]interface{})
+		if !ok || len(bounds) == 0 {
+			return false
+		}
+		value = bounds[0]
+	default:
+		return false
+	}
+	_, ok := value.(float64)
+	return ok
+}
+
+// quickSearchFilter builds the OR group of a quick search: a case-insensitive
+// substring match against the text of each searched column
+func quickSearchFilter(columns []ColumnInfo, searchColumns []string, search string) (FilterCondition, error) {
+	searched := make(map[string]bool, len(searchColumns))
+	for _, name := range searchColumns {
+		searched[name] = true
+	}
+
+	var group []FilterCondition
+	for _, col := range columns {
+		if len(searched) > 0 && !searched[col.Name] {
+			continue
+		}
+		if len(searched) == 0 && typeCategory(col.Type) == typeCategoryBinary {
+			continue
+		}
+		group = append(group, FilterCondition{Column: col.Name, Operator: "CONTAINS", Value: search, Conjunction: "OR"})
+	}
+	if len(group) == 0 {
+		return FilterCondition{}, fmt.Errorf("no columns to search")
+	}
+	return FilterCondition{Group: group}, nil
+}
+
+// keyCondition matches one row by its key columns. Placeholders are numbered
+// from start, for statements that bind other arguments first.
+func keyCondition(dialect filterDialect, keys []string, start int) string {
+	conditions := make([]string, len(keys))
+	for i, key := range keys {
+		conditions[i] = fmt.Sprintf("%s = %s", dialect.quote(key), dialect.placeholder(start+i))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// keyListCondition matches count rows by their key columns, with a tuple IN
+// for composite keys. Arguments are bound row by row.
+func keyListCondition(dialect filterDialect, keys []string, count int) string {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = dialect.quote(key)
+	}
+
+	n := 0
+	tuples := make([]string, count)
+	for r := range tuples {
+		placeholders := make([]string, len(keys))
+		for i := range placeholders {
+			n++
+			placeholders[i] = dialect.placeholder(n)
+		}
+		tuples[r] = strings.Join(placeholders, ", ")
+		if len(keys) > 1 {
+			tuples[r] = "(" + tuples[r] + ")"
+		}
+	}
+
+	column := quoted[0]
+	if len(keys) > 1 {
+		column = "(" + strings.Join(quoted, ", ") + ")"
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(tuples, ", "))
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnFormatter describes how a column's values are rendered in the grid
+type ColumnFormatter struct {
+	Column string `json:"column"`
+	Kind   string `json:"kind"` // epoch, currency, bytes, map
+
+	// epoch
+	Unit     string `json:"unit,omitempty"`     // s (default), ms, us, ns
+	Layout   string `json:"layout,omitempty"`   // Go time layout, defaults to 2006-01-02 15:04:05
+	Timezone string `json:"timezone,omitempty"` // IANA name, defaults to UTC
+
+	// currency
+	Symbol   string `json:"symbol,omitempty"`   // e.g. "$"
+	Divisor  int64  `json:"divisor,omitempty"`  // e.g. 100 for cents, defaults to 1
+	Decimals int    `json:"decimals,omitempty"` // Defaults to 2
+
+	// map
+	Labels map[string]string `json:"labels,omitempty"` // Raw value -> label
+}
+
+// formattersFile stores formatters per table view, keyed by connection/database/table
+const formattersFile = "formatters.json"
+
+// SaveColumnFormatters persists the formatters of a table view
+func (s *Storage) SaveColumnFormatters(connection, database, table string, formatters []ColumnFormatter) error {
+	all := make(map[string][]ColumnFormatter)
+	if err := s.readJSON(formattersFile, &all); err != nil {
+		return err
+	}
+
+	key := viewKey(connection, database, table)
+	if len(formatters) == 0 {
+		delete(all, key)
+	} else {
+		all[key] = formatters
+	}
+
+	return s.writeJSON(formattersFile, all)
+}
+
+// LoadColumnFormatters returns the formatters saved for a table view
+func (s *Storage) LoadColumnFormatters(connection, database, table string) ([]ColumnFormatter, error) {
+	all := make(map[string][]ColumnFormatter)
+	if err := s.readJSON(formattersFile, &all); err != nil {
+		return nil, err
+	}
+
+	formatters := all[viewKey(connection, database, table)]
+	if formatters == nil {
+		formatters = []ColumnFormatter{}
+	}
+	return formatters, nil
+}
+
+func viewKey(connection, database, table string) string {
+	return connection + "/" + database + "/" + table
+}
+
+// ApplyColumnFormatters renders formatted columns of rows. The result is keyed
+// by column name with one entry per row; NULL values stay empty.
+func ApplyColumnFormatters(columns []ColumnInfo, rows [][]interface{}, formatters []ColumnFormatter) map[string][]string {
+	if len(formatters) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(columns))
+	for i, col := range columns {
+		index[col.Name] = i
+	}
+
+	display := make(map[string][]string)
+	for _, f := range formatters {
+		colIdx, ok := index[f.Column]
+		if !ok {
+			continue
+		}
+
+		values := make([]string, len(rows))
+		for r, row := range rows {
+			if colIdx >= len(row) || row[colIdx] == nil {
+				continue
+			}
+			values[r] = f.Format(row[colIdx])
+		}
+		display[f.Column] = values
+	}
+
+	return display
+}
+
+// Format renders a single value, falling back to its raw form when it doesn't fit the formatter
+func (f ColumnFormatter) Format(val interface{}) string {
+	raw := formatCell(val, typeCategoryText, CopyOptions{TimestampFormat: time.RFC3339Nano})
+
+	switch f.Kind {
+	case "epoch":
+		if s, ok := f.formatEpoch(raw); ok {
+			return s
+		}
+	case "currency":
+		if s, ok := f.formatCurrency(raw); ok {
+			return s
+		}
+	case "bytes":
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return humanSize(n)
+		}
+	case "map":
+		if label, ok := f.Labels[raw]; ok {
+			return label
+		}
+	}
+
+	return raw
+}
+
+func (f ColumnFormatter) formatEpoch(raw string) (string, bool) {
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		fl, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "", false
+		}
+		n = int64(fl)
+	}
+
+	var t time.Time
+	switch f.Unit {
+	case "ms":
+		t = time.UnixMilli(n)
+	case "us":
+		t = time.UnixMicro(n)
+	case "ns":
+		t = time.Unix(0, n)
+	default:
+		t = time.Unix(n, 0)
+	}
+
+	loc := time.UTC
+	if f.Timezone != "" {
+		if l, err := time.LoadLocation(f.Timezone); err == nil {
+			loc = l
+		}
+	}
+
+	layout := f.Layout
+	if layout == "" {
+		layout = "2006-01-02 15:04:05"
+	}
+	return t.In(loc).Format(layout), true
+}
+
+func (f ColumnFormatter) formatCurrency(raw string) (string, bool) {
+	amount, ok := new(big.Rat).SetString(raw)
+	if !ok {
+		return "", false
+	}
+	if f.Divisor > 1 {
+		amount.Quo(amount, new(big.Rat).SetInt64(f.Divisor))
+	}
+
+	decimals := f.Decimals
+	if decimals <= 0 {
+		decimals = 2
+	}
+
+	text := amount.FloatString(decimals)
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+
+	intPart, fracPart := text, ""
+	if idx := strings.Index(text, "."); idx >= 0 {
+		intPart, fracPart = text[:idx], text[idx:]
+	}
+
+	return sign + f.Symbol + groupThousands(intPart) + fracPart, true
+}
+
+// groupThousands inserts commas into a string of digits
+func groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var sb strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		sb.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// humanSize renders a byte count using binary units
+func humanSize(n float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := 0
+	for (n >= 1024 || n <= -1024) && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", n, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
@@ -0,0 +1,276 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// isGeoColumn reports whether a column holds PostGIS geometry or geography
+func isGeoColumn(col ColumnInfo) bool {
+	t := strings.ToLower(col.Type)
+	return t == "geometry" || t == "geography"
+}
+
+// geoColumnNames returns the PostGIS columns of a table
+func geoColumnNames(columns []ColumnInfo) []string {
+	var names []string
+	for _, col := range columns {
+		if isGeoColumn(col) {
+			names = append(names, col.Name)
+		}
+	}
+	return names
+}
+
+// geoJSONSelection projects each PostGIS column a second time as GeoJSON,
+// including the SRID as a short CRS (EPSG:n) so that the document can be
+// written back to a column constrained to that SRID
+func geoJSONSelection(quote func(string) string, names []string) string {
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, ", ST_AsGeoJSON(%s, 9, 2)::text", quote(name))
+	}
+	return sb.String()
+}
+
+// splitGeoJSON takes the GeoJSON projections of geoJSONSelection off the end
+// of the rows and puts them in place of the binary values of their columns
+func splitGeoJSON(columns []ColumnInfo, rows [][]interface{}, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	index := make(map[string]int, len(columns))
+	for i, col := range columns {
+		index[col.Name] = i
+	}
+	for r, row := range rows {
+		n := len(row) - len(names)
+		if n < 0 {
+			continue
+		}
+		for j, name := range names {
+			if i, ok := index[name]; ok && i < n {
+				row[i] = row[n+j]
+			}
+		}
+		rows[r] = row[:n]
+	}
+}
+
+// geoDisplay summarizes the GeoJSON of the PostGIS columns of a page for the
+// grid, e.g. Point (13.4 52.5) or Polygon, 5 points
+func geoDisplay(columns []ColumnInfo, rows [][]interface{}, display map[string][]string) map[string][]string {
+	for i, col := range columns {
+		if !isGeoColumn(col) {
+			continue
+		}
+		if _, ok := display[col.Name]; ok {
+			continue
+		}
+
+		values := make([]string, len(rows))
+		for r, row := range rows {
+			if i >= len(row) {
+				continue
+			}
+			if text, ok := row[i].(string); ok {
+				values[r] = geoSummary(text)
+			}
+		}
+		if display == nil {
+			display = make(map[string][]string)
+		}
+		display[col.Name] = values
+	}
+	return display
+}
+
+// geoJSONObject is the part of a GeoJSON object needed to convert it to WKT
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometries  []geoJSONObject `json:"geometries"`
+	Geometry    *geoJSONObject  `json:"geometry"` // Feature
+	CRS         *struct {
+		Properties struct {
+			Name string `json:"name"`
+		} `json:"properties"`
+	} `json:"crs"`
+}
+
+// geoSummary describes a GeoJSON geometry in a few words, falling back to
+// the text itself when it doesn't parse
+func geoSummary(text string) string {
+	var g geoJSONObject
+	if err := json.Unmarshal([]byte(text), &g); err != nil || g.Type == "" {
+		return text
+	}
+	if g.Type == "Point" {
+		var point []float64
+		if json.Unmarshal(g.Coordinates, &point) == nil && len(point) >= 2 {
+			return fmt.Sprintf("Point (%s)", joinCoordinates(point))
+		}
+	}
+	if g.Type == "GeometryCollection" {
+		return fmt.Sprintf("GeometryCollection, %d geometries", len(g.Geometries))
+	}
+	var coordinates interface{}
+	if json.Unmarshal(g.Coordinates, &coordinates) != nil {
+		return g.Type
+	}
+	return fmt.Sprintf("%s, %d points", g.Type, countPositions(coordinates))
+}
+
+// countPositions counts the positions of nested GeoJSON coordinates
+func countPositions(v interface{}) int {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return 0
+	}
+	if _, ok := list[0].(float64); ok {
+		return 1
+	}
+	n := 0
+	for _, item := range list {
+		n += countPositions(item)
+	}
+	return n
+}
+
+// epsgPattern takes the code out of CRS names such as EPSG:4326 or
+// urn:ogc:def:crs:EPSG::4326
+var epsgPattern = regexp.MustCompile(`(?i)EPSG:+(\d+)$`)
+
+// geoParam converts a value written to a PostGIS column. GeoJSON, as shown
+// by the grid, becomes EWKT carrying its CRS as the SRID; WKT, EWKT and hex
+// EWKB are understood by PostGIS itself and passed through.
+func geoParam(column string, v interface{}) (interface{}, error) {
+	text, ok := v.(string)
+	if !ok {
+		if v == nil {
+			return nil, nil
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode GeoJSON for column %s: %w", column, err)
+		}
+		text = string(encoded)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(text), "{") {
+		return text, nil
+	}
+
+	var g geoJSONObject
+	if err := json.Unmarshal([]byte(text), &g); err != nil {
+		return nil, fmt.Errorf("column %s holds invalid GeoJSON: %w", column, err)
+	}
+	wkt, err := geoJSONToWKT(g)
+	if err != nil {
+		return nil, fmt.Errorf("column %s holds invalid GeoJSON: %w", column, err)
+	}
+	if g.CRS != nil {
+		if m := epsgPattern.FindStringSubmatch(g.CRS.Properties.Name); m != nil {
+			wkt = "SRID=" + m[1] + ";" + wkt
+		}
+	}
+	return wkt, nil
+}
+
+// geoJSONToWKT converts a GeoJSON geometry, or the geometry of a Feature, to WKT
+func geoJSONToWKT(g geoJSONObject) (string, error) {
+	if g.Type == "Feature" {
+		if g.Geometry == nil {
+			return "", fmt.Errorf("feature has no geometry")
+		}
+		return geoJSONToWKT(*g.Geometry)
+	}
+	if g.Type == "GeometryCollection" {
+		if len(g.Geometries) == 0 {
+			return "GEOMETRYCOLLECTION EMPTY", nil
+		}
+		parts := make([]string, len(g.Geometries))
+		for i, member := range g.Geometries {
+			wkt, err := geoJSONToWKT(member)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = wkt
+		}
+		return "GEOMETRYCOLLECTION(" + strings.Join(parts, ",") + ")", nil
+	}
+
+	// Depth of the coordinate nesting of each type: a position is depth 0
+	depths := map[string]int{
+		"Point": 0, "MultiPoint": 1, "LineString": 1,
+		"MultiLineString": 2, "Polygon": 2, "MultiPolygon": 3,
+	}
+	depth, ok := depths[g.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported geometry type %q", g.Type)
+	}
+	name := strings.ToUpper(g.Type)
+
+	var coordinates interface{}
+	if len(g.Coordinates) > 0 {
+		if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+			return "", err
+		}
+	}
+	if list, ok := coordinates.([]interface{}); !ok || len(list) == 0 {
+		return name + " EMPTY", nil
+	}
+	body, err := wktCoordinates(coordinates, depth)
+	if err != nil {
+		return "", err
+	}
+	return name + body, nil
+}
+
+// wktCoordinates writes nested GeoJSON coordinates as parenthesized WKT
+// coordinate lists
+func wktCoordinates(v interface{}, depth int) (string, error) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return "", fmt.Errorf("coordinates must be arrays")
+	}
+	if depth == 0 {
+		position := make([]float64, len(list))
+		for i, c := range list {
+			f, ok := c.(float64)
+			if !ok {
+				return "", fmt.Errorf("position values must be numbers")
+			}
+			position[i] = f
+		}
+		if len(position) < 2 {
+			return "", fmt.Errorf("a position needs at least two values")
+		}
+		return "(" + joinCoordinates(position) + ")", nil
+	}
+
+	parts := make([]string, len(list))
+	for i, item := range list {
+		part, err := wktCoordinates(item, depth-1)
+		if err != nil {
+			return "", err
+		}
+		// Positions inside a list are written without their own parentheses
+		if depth == 1 {
+			part = strings.TrimSuffix(strings.TrimPrefix(part, "("), ")")
+		}
+		parts[i] = part
+	}
+	return "(" + strings.Join(parts, ",") + ")", nil
+}
+
+// joinCoordinates writes a position as space-separated numbers
+func joinCoordinates(position []float64) string {
+	parts := make([]string, len(position))
+	for i, f := range position {
+		parts[i] = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return strings.Join(parts, " ")
+}
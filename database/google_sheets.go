@@ -0,0 +1,509 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+// googleSheetsFile holds the OAuth client and the token of the signed in
+// Google account
+const googleSheetsFile = "google_sheets.json"
+
+const (
+	googleSheetsAPI  = "https://sheets.googleapis.com/v4/spreadsheets"
+	googleUserInfo   = "https://openidconnect.googleapis.com/v1/userinfo"
+	googleSignInWait = 5 * time.Minute
+)
+
+// sheetsBatchRows is the number of rows appended per request, keeping
+// requests well below the API's size limit
+const sheetsBatchRows = 5000
+
+// googleScopes lets the app create and edit spreadsheets and see which
+// account it is signed in to
+var googleScopes = []string{"https://www.googleapis.com/auth/spreadsheets", "openid", "email"}
+
+// spreadsheetURLPattern extracts the ID from a spreadsheet URL
+var spreadsheetURLPattern = regexp.MustCompile(`/spreadsheets/d/([a-zA-Z0-9_-]+)`)
+
+// googleSheetsSettings is the stored Google configuration. The client is a
+// "Desktop app" OAuth client created by the user in Google Cloud.
+type googleSheetsSettings struct {
+	ClientID     string        `json:"clientId"`
+	ClientSecret string        `json:"clientSecret"`
+	Email        string        `json:"email,omitempty"`
+	Token        *oauth2.Token `json:"token,omitempty"`
+}
+
+// GoogleAccount reports whether exports to Google Sheets are set up
+type GoogleAccount struct {
+	Configured bool   `json:"configured"` // An OAuth client is set
+	ClientID   string `json:"clientId"`
+	SignedIn   bool   `json:"signedIn"`
+	Email      string `json:"email,omitempty"`
+}
+
+// GoogleSheetExport says where a result set goes. Without a spreadsheet a new
+// one is created.
+type GoogleSheetExport struct {
+	Spreadsheet string `json:"spreadsheet,omitempty"` // ID or URL of an existing spreadsheet
+	Title       string `json:"title,omitempty"`       // Title of a new spreadsheet
+	Sheet       string `json:"sheet,omitempty"`       // Tab, created when missing; the first one when empty
+	Mode        string `json:"mode"`                  // replace or append
+}
+
+// GoogleSheetResult is where an export was written
+type GoogleSheetResult struct {
+	SpreadsheetID string `json:"spreadsheetId"`
+	URL           string `json:"url"`
+	Sheet         string `json:"sheet"`
+	Rows          int    `json:"rows"`
+}
+
+// GoogleSheets signs in to Google and exports result sets to spreadsheets
+type GoogleSheets struct {
+	storage *Storage
+
+	mu     sync.Mutex
+	cancel context.CancelFunc // Sign-in waiting for the browser
+}
+
+// NewGoogleSheets returns the Google Sheets integration keeping its settings in storage
+func NewGoogleSheets(storage *Storage) *GoogleSheets {
+	return &GoogleSheets{storage: storage}
+}
+
+func (g *GoogleSheets) load() (*googleSheetsSettings, error) {
+	settings := &googleSheetsSettings{}
+	if err := g.storage.readJSON(googleSheetsFile, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// save writes the settings readable by the user only, as they hold a token
+func (g *GoogleSheets) save(settings *googleSheetsSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", googleSheetsFile, err)
+	}
+	if err := writeFileAtomic(g.storage.dataPath(googleSheetsFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", googleSheetsFile, err)
+	}
+	return nil
+}
+
+func (s *googleSheetsSettings) config(redirectURL string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.ClientID,
+		ClientSecret: s.ClientSecret,
+		Endpoint:     endpoints.Google,
+		Scopes:       googleScopes,
+		RedirectURL:  redirectURL,
+	}
+}
+
+// Account returns the OAuth client and the signed in account
+func (g *GoogleSheets) Account() (*GoogleAccount, error) {
+	settings, err := g.load()
+	if err != nil {
+		return nil, err
+	}
+	return &GoogleAccount{
+		Configured: settings.ClientID != "",
+		ClientID:   settings.ClientID,
+		SignedIn:   settings.Token != nil,
+		Email:      settings.Email,
+	}, nil
+}
+
+// SetClient sets the OAuth client used to sign in. Changing it signs out.
+func (g *GoogleSheets) SetClient(clientID, clientSecret string) error {
+	clientID, clientSecret = strings.TrimSpace(clientID), strings.TrimSpace(clientSecret)
+	if clientID == "" {
+		return fmt.Errorf("a client ID is required")
+	}
+	settings, err := g.load()
+	if err != nil {
+		return err
+	}
+	if settings.ClientID != clientID || settings.ClientSecret != clientSecret {
+		settings.Token, settings.Email = nil, ""
+	}
+	settings.ClientID, settings.ClientSecret = clientID, clientSecret
+	return g.save(settings)
+}
+
+// SignIn opens the Google consent page with openURL and waits for the
+// browser to come back to a loopback address with the authorization code.
+// A new sign-in replaces one still waiting.
+func (g *GoogleSheets) SignIn(openURL func(url string)) (*GoogleAccount, error) {
+	settings, err := g.load()
+	if err != nil {
+		return nil, err
+	}
+	if settings.ClientID == "" {
+		return nil, fmt.Errorf("no Google OAuth client configured")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), googleSignInWait)
+	defer cancel()
+	g.mu.Lock()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.cancel = cancel
+	g.mu.Unlock()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the sign-in redirect: %w", err)
+	}
+	defer listener.Close()
+
+	state, err := newQueryID()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+	config := settings.config(fmt.Sprintf("http://%s/callback", listener.Addr()))
+
+	type callback struct {
+		code string
+		err  error
+	}
+	done := make(chan callback, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/callback" {
+			http.NotFound(w, r)
+			return
+		}
+		query := r.URL.Query()
+		result := callback{code: query.Get("code")}
+		switch {
+		case query.Get("state") != state:
+			result.err = fmt.Errorf("sign-in response does not match the request")
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("sign-in was declined: %s", query.Get("error"))
+		case result.code == "":
+			result.err = fmt.Errorf("sign-in response has no authorization code")
+		}
+		message := "Signed in. You can close this window and return to the app."
+		if result.err != nil {
+			message = result.err.Error()
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<!DOCTYPE html><html><body style=\"font-family: sans-serif\"><p>%s</p></body></html>", html.EscapeString(message))
+		select {
+		case done <- result:
+		default:
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	openURL(config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce, oauth2.S256ChallengeOption(verifier)))
+
+	var result callback
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("sign-in was not completed")
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	token, err := config.Exchange(ctx, result.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Google token: %w", err)
+	}
+	settings.Token = token
+	settings.Email = ""
+	var info struct {
+		Email string `json:"email"`
+	}
+	if err := newSheetsClient(ctx, config.Client(ctx, token)).do(http.MethodGet, googleUserInfo, nil, &info); err == nil {
+		settings.Email = info.Email
+	}
+	if err := g.save(settings); err != nil {
+		return nil, err
+	}
+	return g.Account()
+}
+
+// SignOut forgets the token of the signed in account, revoking it with Google
+func (g *GoogleSheets) SignOut() error {
+	settings, err := g.load()
+	if err != nil {
+		return err
+	}
+	if settings.Token != nil {
+		token := settings.Token.RefreshToken
+		if token == "" {
+			token = settings.Token.AccessToken
+		}
+		// Best effort; the token is forgotten either way
+		if resp, err := http.PostForm("https://oauth2.googleapis.com/revoke", url.Values{"token": {token}}); err == nil {
+			resp.Body.Close()
+		}
+	}
+	settings.Token, settings.Email = nil, ""
+	return g.save(settings)
+}
+
+// sheetsClient is a minimal client for the Google Sheets API
+type sheetsClient struct {
+	ctx  context.Context
+	http *http.Client
+}
+
+func newSheetsClient(ctx context.Context, client *http.Client) *sheetsClient {
+	client.Timeout = 2 * time.Minute
+	return &sheetsClient{ctx: ctx, http: client}
+}
+
+func (c *sheetsClient) do(method, target string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(c.ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("google request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var failure struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&failure)
+		if failure.Error.Message != "" {
+			return fmt.Errorf("google returned %d: %s", resp.StatusCode, failure.Error.Message)
+		}
+		return fmt.Errorf("google returned %d", resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("invalid google response: %w", err)
+		}
+	}
+	return nil
+}
+
+// spreadsheet is the part of a spreadsheet resource the export needs
+type spreadsheet struct {
+	SpreadsheetID  string `json:"spreadsheetId"`
+	SpreadsheetURL string `json:"spreadsheetUrl"`
+	Sheets         []struct {
+		Properties struct {
+			Title string `json:"title"`
+		} `json:"properties"`
+	} `json:"sheets"`
+}
+
+// Export writes a result set with a header row to a Google Sheet. Replace
+// clears the tab first; append adds the rows after the existing ones, the
+// header only when the tab was empty. Numbers and booleans keep their types
+// and text is never interpreted as formulas.
+func (g *GoogleSheets) Export(result QueryResult, req GoogleSheetExport) (*GoogleSheetResult, error) {
+	if len(result.Columns) == 0 {
+		return nil, fmt.Errorf("no results to export")
+	}
+	if req.Mode != "replace" && req.Mode != "append" {
+		return nil, fmt.Errorf("unsupported export mode: %s", req.Mode)
+	}
+	settings, err := g.load()
+	if err != nil {
+		return nil, err
+	}
+	if settings.Token == nil {
+		return nil, fmt.Errorf("not signed in to Google")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+	source := settings.config("").TokenSource(ctx, settings.Token)
+	client := newSheetsClient(ctx, oauth2.NewClient(ctx, source))
+	// Keep a refreshed token so the next export need not refresh it again
+	defer func() {
+		if token, err := source.Token(); err == nil && token.AccessToken != settings.Token.AccessToken {
+			settings.Token = token
+			g.save(settings)
+		}
+	}()
+
+	sheet := strings.TrimSpace(req.Sheet)
+	var doc spreadsheet
+	hasHeader := false
+	if id := spreadsheetID(req.Spreadsheet); id != "" {
+		if err := client.do(http.MethodGet, googleSheetsAPI+"/"+url.PathEscape(id)+"?fields=spreadsheetId,spreadsheetUrl,sheets.properties.title", nil, &doc); err != nil {
+			return nil, fmt.Errorf("failed to open spreadsheet: %w", err)
+		}
+		exists := false
+		for _, s := range doc.Sheets {
+			exists = exists || s.Properties.Title == sheet
+		}
+		switch {
+		case sheet == "" && len(doc.Sheets) > 0:
+			sheet, exists = doc.Sheets[0].Properties.Title, true
+		case sheet == "":
+			sheet = "Sheet1"
+		}
+		if !exists {
+			addSheet := map[string]interface{}{"requests": []interface{}{
+				map[string]interface{}{"addSheet": map[string]interface{}{"properties": map[string]string{"title": sheet}}},
+			}}
+			if err := client.do(http.MethodPost, googleSheetsAPI+"/"+url.PathEscape(id)+":batchUpdate", addSheet, nil); err != nil {
+				return nil, fmt.Errorf("failed to add sheet %s: %w", sheet, err)
+			}
+		} else if req.Mode == "append" {
+			// Tabs with data already have their header
+			var values struct {
+				Values [][]interface{} `json:"values"`
+			}
+			if err := client.do(http.MethodGet, sheetsRangeURL(id, sheet, "1:1"), nil, &values); err != nil {
+				return nil, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+			}
+			hasHeader = len(values.Values) > 0
+		}
+	} else {
+		title := strings.TrimSpace(req.Title)
+		if title == "" {
+			title = "Query results " + time.Now().Format("2006-01-02 15:04")
+		}
+		if sheet == "" {
+			sheet = "Results"
+		}
+		create := map[string]interface{}{
+			"properties": map[string]string{"title": title},
+			"sheets":     []interface{}{map[string]interface{}{"properties": map[string]string{"title": sheet}}},
+		}
+		if err := client.do(http.MethodPost, googleSheetsAPI, create, &doc); err != nil {
+			return nil, fmt.Errorf("failed to create spreadsheet: %w", err)
+		}
+	}
+
+	if req.Mode == "replace" {
+		if err := client.do(http.MethodPost, sheetsRangeURL(doc.SpreadsheetID, sheet, "")+":clear", map[string]string{}, nil); err != nil {
+			return nil, fmt.Errorf("failed to clear sheet %s: %w", sheet, err)
+		}
+	}
+
+	var batch [][]interface{}
+	if !hasHeader {
+		header := make([]interface{}, len(result.Columns))
+		for i, name := range result.Columns {
+			header[i] = name
+		}
+		batch = append(batch, header)
+	}
+	categories := make([]string, len(result.Columns))
+	for i := range categories {
+		if i < len(result.ColumnTypes) {
+			categories[i] = typeCategory(result.ColumnTypes[i])
+		}
+	}
+	opts := CopyOptions{}.withDefaults()
+	appendURL := sheetsRangeURL(doc.SpreadsheetID, sheet, "A1") + ":append?valueInputOption=RAW&insertDataOption=INSERT_ROWS"
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := client.do(http.MethodPost, appendURL, map[string]interface{}{"values": batch}, nil); err != nil {
+			return fmt.Errorf("failed to write rows: %w", err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for _, row := range result.Rows {
+		cells := make([]interface{}, len(row))
+		for i, val := range row {
+			cells[i] = sheetCellValue(val, categories[i], opts)
+		}
+		batch = append(batch, cells)
+		if len(batch) >= sheetsBatchRows {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return &GoogleSheetResult{
+		SpreadsheetID: doc.SpreadsheetID,
+		URL:           doc.SpreadsheetURL,
+		Sheet:         sheet,
+		Rows:          len(result.Rows),
+	}, nil
+}
+
+// spreadsheetID accepts a spreadsheet ID or URL
+func spreadsheetID(value string) string {
+	value = strings.TrimSpace(value)
+	if m := spreadsheetURLPattern.FindStringSubmatch(value); m != nil {
+		return m[1]
+	}
+	return value
+}
+
+// sheetsRangeURL returns the values endpoint of a range of a tab, the whole
+// tab when cells is empty
+func sheetsRangeURL(id, sheet, cells string) string {
+	a1 := "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+	if cells != "" {
+		a1 += "!" + cells
+	}
+	return googleSheetsAPI + "/" + url.PathEscape(id) + "/values/" + url.PathEscape(a1)
+}
+
+// sheetCellValue returns the value of a cell as the Sheets API takes it:
+// numbers and booleans as such, everything else as text, NULL as empty
+func sheetCellValue(val interface{}, category string, opts CopyOptions) interface{} {
+	switch v := jsonCopyValue(val, category, opts).(type) {
+	case nil:
+		return ""
+	case json.RawMessage:
+		return string(v)
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return v
+	}
+}
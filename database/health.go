@@ -0,0 +1,354 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// quarantineDir holds corrupted data files, next to the data files themselves
+const quarantineDir = "quarantine"
+
+// Store health states
+const (
+	StoreOK          = "ok"
+	StoreMissing     = "missing"     // Not created yet; defaults apply
+	StoreUnreadable  = "unreadable"  // Could not be read, e.g. permissions; left in place
+	StoreQuarantined = "quarantined" // Corrupted; moved aside so defaults apply
+	StoreRecovered   = "recovered"   // Readable entries restored from the quarantined copy
+)
+
+// StoreHealth reports the state of one local data file
+type StoreHealth struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Backup   string `json:"backup,omitempty"` // Quarantined copy of a corrupted file
+	Readable int    `json:"readable"`         // Entries that can be recovered, or were recovered
+	Error    string `json:"error,omitempty"`
+}
+
+// storeSpec describes the layout of a data file so damaged ones can be salvaged
+type storeSpec struct {
+	name string
+
+	// array stores hold a list of entries identified by their "name" field,
+	// the others an object whose members are the entries
+	array bool
+
+	// validate decodes the whole file, or its first header bytes
+	validate func(data []byte) error
+
+	// header limits the check to the start of files too large to read on
+	// every launch; zero reads the whole file
+	header int
+
+	// entry validates a single entry; nil when entries are meaningless on
+	// their own and a damaged file cannot be salvaged
+	entry func(raw json.RawMessage) error
+
+	// guarded files are left in place when damaged, since starting with
+	// defaults would lift the protection they hold
+	guarded bool
+}
+
+// stores lists the data files checked on launch
+var stores = []storeSpec{
+	{
+		name:     connectionsFile,
+		array:    true,
+		validate: func(data []byte) error { return json.Unmarshal(data, &[]SavedConnection{}) },
+		entry:    func(raw json.RawMessage) error { return json.Unmarshal(raw, &SavedConnection{}) },
+	},
+	{
+		name:     formattersFile,
+		validate: func(data []byte) error { return json.Unmarshal(data, &map[string][]ColumnFormatter{}) },
+		entry:    func(raw json.RawMessage) error { return json.Unmarshal(raw, &[]ColumnFormatter{}) },
+	},
+	{
+		name:     translationsFile,
+		array:    true,
+		validate: func(data []byte) error { return json.Unmarshal(data, &[]TranslationMap{}) },
+		entry:    func(raw json.RawMessage) error { return json.Unmarshal(raw, &TranslationMap{}) },
+	},
+	{
+		name:     savedQueriesFile,
+		validate: func(data []byte) error { return json.Unmarshal(data, &map[string]SavedQuery{}) },
+		entry:    func(raw json.RawMessage) error { return json.Unmarshal(raw, &SavedQuery{}) },
+	},
+	{
+		name:     exportSchedulesFile,
+		validate: func(data []byte) error { return json.Unmarshal(data, &map[string]ExportSchedule{}) },
+		entry:    func(raw json.RawMessage) error { return json.Unmarshal(raw, &ExportSchedule{}) },
+	},
+	{
+		name:     exportRunsFile,
+		validate: func(data []byte) error { return json.Unmarshal(data, &map[string][]ExportRun{}) },
+		entry:    func(raw json.RawMessage) error { return json.Unmarshal(raw, &[]ExportRun{}) },
+	},
+	{
+		// The OAuth client and its token only make sense together
+		name:     googleSheetsFile,
+		validate: func(data []byte) error { return json.Unmarshal(data, &googleSheetsSettings{}) },
+	},
+	{
+		// A salt without its hash, or the reverse, would lock the user out.
+		// The Locker stays locked until the user resets a damaged file.
+		name:     lockFile,
+		validate: func(data []byte) error { return json.Unmarshal(data, &lockSettings{}) },
+		guarded:  true,
+	},
+	{
+		name:     historyFile,
+		validate: validateSQLite,
+		header:   len(sqliteHeader),
+	},
+}
+
+// sqliteHeader starts every SQLite database file
+const sqliteHeader = "SQLite format 3\x00"
+
+// validateSQLite checks the header of a SQLite database. An empty file is a
+// database SQLite has not written to yet.
+func validateSQLite(data []byte) error {
+	if len(data) > 0 && !bytes.HasPrefix(data, []byte(sqliteHeader)) {
+		return fmt.Errorf("not a SQLite database")
+	}
+	return nil
+}
+
+// storeEntry is a top-level entry of a data file
+type storeEntry struct {
+	key string // Object member name, or the "name" field of an array item
+	raw json.RawMessage
+}
+
+// CheckStores validates the local data files. Corrupted files are moved to
+// the quarantine directory so the app starts with defaults instead of failing
+// or overwriting them; RecoverStore restores what is still readable.
+func (s *Storage) CheckStores() []StoreHealth {
+	report := make([]StoreHealth, 0, len(stores))
+	for _, spec := range stores {
+		report = append(report, s.checkStore(spec))
+	}
+	return report
+}
+
+func (s *Storage) checkStore(spec storeSpec) StoreHealth {
+	health := StoreHealth{Name: spec.name}
+
+	data, err := readStore(s.dataPath(spec.name), spec.header)
+	if err != nil {
+		if os.IsNotExist(err) {
+			health.Status = StoreMissing
+		} else {
+			health.Status = StoreUnreadable
+			health.Error = err.Error()
+		}
+		return health
+	}
+
+	verr := spec.validate(data)
+	if verr == nil {
+		health.Status = StoreOK
+		return health
+	}
+	if spec.guarded {
+		health.Status = StoreUnreadable
+		health.Error = verr.Error()
+		return health
+	}
+
+	backup, err := s.quarantine(spec.name)
+	if err != nil {
+		health.Status = StoreUnreadable
+		health.Error = err.Error()
+		return health
+	}
+
+	health.Status = StoreQuarantined
+	health.Backup = backup
+	health.Readable = len(salvageEntries(data, spec))
+	health.Error = verr.Error()
+	return health
+}
+
+// readStore reads a data file, or only its first n bytes when n is positive
+func readStore(path string, n int) ([]byte, error) {
+	if n <= 0 {
+		return os.ReadFile(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data := make([]byte, n)
+	read, err := io.ReadFull(f, data)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return data[:read], nil
+}
+
+// quarantine moves a data file to the quarantine directory under a
+// timestamped name and returns the new path
+func (s *Storage) quarantine(name string) (string, error) {
+	dir := s.dataPath(quarantineDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+
+	ext := filepath.Ext(name)
+	backup := filepath.Join(dir, fmt.Sprintf("%s.%s%s", strings.TrimSuffix(name, ext), time.Now().Format("20060102-150405"), ext))
+	if err := os.Rename(s.dataPath(name), backup); err != nil {
+		return "", fmt.Errorf("failed to quarantine %s: %w", name, err)
+	}
+	// Connection files hold credentials
+	os.Chmod(backup, 0600)
+	// A SQLite journal belongs to its database and must not be replayed into
+	// the fresh one
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Rename(s.dataPath(name)+suffix, backup+suffix); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to quarantine %s: %w", name+suffix, err)
+		}
+	}
+
+	return backup, nil
+}
+
+// latestBackup returns the most recent quarantined copy of a data file
+func (s *Storage) latestBackup(name string) (string, error) {
+	ext := filepath.Ext(name)
+	matches, err := filepath.Glob(filepath.Join(s.dataPath(quarantineDir), strings.TrimSuffix(name, ext)+".*"+ext))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no quarantined copy of %s found", name)
+	}
+	sort.Strings(matches)
+	return matches[len(matches)-1], nil
+}
+
+// RecoverStore rebuilds a data file from its latest quarantined copy. The
+// readable entries are merged into the current file, entries created since
+// the file was quarantined taking precedence. The quarantined copy is kept.
+func (s *Storage) RecoverStore(name string) (*StoreHealth, error) {
+	var spec *storeSpec
+	for i := range stores {
+		if stores[i].name == name {
+			spec = &stores[i]
+		}
+	}
+	if spec == nil {
+		return nil, fmt.Errorf("unknown data file: %s", name)
+	}
+	if spec.entry == nil {
+		return nil, fmt.Errorf("%s cannot be recovered; it has been reset", name)
+	}
+
+	backup, err := s.latestBackup(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(backup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", backup, err)
+	}
+	salvaged := salvageEntries(data, *spec)
+
+	var current []storeEntry
+	if data, err := os.ReadFile(s.dataPath(name)); err == nil {
+		if err := spec.validate(data); err != nil {
+			return nil, fmt.Errorf("%s is corrupted again: %w", name, err)
+		}
+		current = salvageEntries(data, *spec)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	seen := make(map[string]bool, len(current))
+	for _, e := range current {
+		seen[e.key] = true
+	}
+	merged := current
+	restored := 0
+	for _, e := range salvaged {
+		if e.key != "" && seen[e.key] {
+			continue
+		}
+		seen[e.key] = true
+		merged = append(merged, e)
+		restored++
+	}
+
+	var v interface{}
+	if spec.array {
+		items := make([]json.RawMessage, len(merged))
+		for i, e := range merged {
+			items[i] = e.raw
+		}
+		v = items
+	} else {
+		members := make(map[string]json.RawMessage, len(merged))
+		for _, e := range merged {
+			members[e.key] = e.raw
+		}
+		v = members
+	}
+	if err := s.writeJSON(name, v); err != nil {
+		return nil, err
+	}
+
+	return &StoreHealth{Name: name, Status: StoreRecovered, Backup: backup, Readable: restored}, nil
+}
+
+// salvageEntries reads the entries of a possibly damaged data file up to the
+// first syntax error, keeping those that decode on their own
+func salvageEntries(data []byte, spec storeSpec) []storeEntry {
+	if spec.entry == nil {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	open := json.Delim('{')
+	if spec.array {
+		open = json.Delim('[')
+	}
+	if tok, err := dec.Token(); err != nil || tok != open {
+		return nil
+	}
+
+	var entries []storeEntry
+	for dec.More() {
+		var e storeEntry
+		if !spec.array {
+			tok, err := dec.Token()
+			if err != nil {
+				break
+			}
+			e.key, _ = tok.(string)
+		}
+		if err := dec.Decode(&e.raw); err != nil {
+			break
+		}
+		if spec.entry(e.raw) != nil {
+			continue
+		}
+		if spec.array {
+			var named struct {
+				Name string `json:"name"`
+			}
+			json.Unmarshal(e.raw, &named)
+			e.key = named.Name
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
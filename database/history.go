@@ -0,0 +1,294 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// historyFile is the SQLite database holding the query history
+const historyFile = "history.db"
+
+// defaultHistoryLimit is the number of entries SearchHistory returns by default
+const defaultHistoryLimit = 100
+
+// historySchema creates the history table and a full-text index over its
+// statements, kept in sync by triggers
+const historySchema = `
+CREATE TABLE IF NOT EXISTS history (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	executed_at TEXT NOT NULL,
+	connection  TEXT NOT NULL,
+	database    TEXT NOT NULL,
+	statement   TEXT NOT NULL,
+	duration_ms REAL NOT NULL,
+	row_count   INTEGER NOT NULL,
+	error       TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS history_executed_at ON history (executed_at);
+CREATE VIRTUAL TABLE IF NOT EXISTS history_fts USING fts5 (statement, content='history', content_rowid='id');
+CREATE TRIGGER IF NOT EXISTS history_ai AFTER INSERT ON history BEGIN
+	INSERT INTO history_fts (rowid, statement) VALUES (new.id, new.statement);
+END;
+CREATE TRIGGER IF NOT EXISTS history_ad AFTER DELETE ON history BEGIN
+	INSERT INTO history_fts (history_fts, rowid, statement) VALUES ('delete', old.id, old.statement);
+END;
+`
+
+// HistoryEntry is a statement run from the app, as recorded in the query history
+type HistoryEntry struct {
+	ID         int64   `json:"id"`
+	ExecutedAt string  `json:"executedAt"` // RFC 3339 with milliseconds
+	Connection string  `json:"connection"` // e.g. postgres://app@db.internal:5432
+	Database   string  `json:"database"`
+	Statement  string  `json:"statement"`
+	DurationMs float64 `json:"durationMs"`
+	RowCount   int64   `json:"rowCount"` // Rows returned or affected
+	Error      string  `json:"error,omitempty"`
+}
+
+// HistoryFilter narrows a history search. Empty fields match everything.
+type HistoryFilter struct {
+	Text       string `json:"text"` // Words the statement contains, matched as prefixes
+	Connection string `json:"connection"`
+	Database   string `json:"database"`
+	ErrorsOnly bool   `json:"errorsOnly"`
+	Limit      int    `json:"limit"` // Defaults to 100
+	Offset     int    `json:"offset"`
+}
+
+// HistoryPrune selects the entries PruneHistory removes
+type HistoryPrune struct {
+	OlderThanDays int  `json:"olderThanDays"` // Entries older than this many days
+	KeepLast      int  `json:"keepLast"`      // Everything but the newest entries
+	All           bool `json:"all"`
+}
+
+// QueryHistory is the persistent log of statements run from the app
+type QueryHistory struct {
+	db *sql.DB
+}
+
+// OpenHistory opens the query history next to the connections file, creating
+// it on first use
+func (s *Storage) OpenHistory() (*QueryHistory, error) {
+	dsn := "file:" + s.dataPath(historyFile) + "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := db.Exec(historySchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create history: %w", err)
+	}
+	return &QueryHistory{db: db}, nil
+}
+
+// HistoryFailed records in a launch check report that the history database
+// could not be opened, replacing its entry
+func HistoryFailed(report []StoreHealth, err error) []StoreHealth {
+	failed := StoreHealth{Name: historyFile, Status: StoreUnreadable, Error: err.Error()}
+	for i := range report {
+		if report[i].Name == historyFile {
+			report[i] = failed
+			return report
+		}
+	}
+	return append(report, failed)
+}
+
+// Close closes the history database
+func (h *QueryHistory) Close() error {
+	return h.db.Close()
+}
+
+// Record adds an entry to the history
+func (h *QueryHistory) Record(entry HistoryEntry) error {
+	_, err := h.db.Exec(`INSERT INTO history (executed_at, connection, database, statement, duration_ms, row_count, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		entry.ExecutedAt, entry.Connection, entry.Database, entry.Statement, entry.DurationMs, entry.RowCount, entry.Error)
+	if err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
+	return nil
+}
+
+// Search returns the entries matching filter, newest first
+func (h *QueryHistory) Search(filter HistoryFilter) ([]HistoryEntry, error) {
+	var conditions []string
+	var args []interface{}
+	if match := ftsQuery(filter.Text); match != "" {
+		conditions = append(conditions, "id IN (SELECT rowid FROM history_fts WHERE history_fts MATCH ?)")
+		args = append(args, match)
+	}
+	if filter.Connection != "" {
+		conditions = append(conditions, "connection = ?")
+		args = append(args, filter.Connection)
+	}
+	if filter.Database != "" {
+		conditions = append(conditions, "database = ?")
+		args = append(args, filter.Database)
+	}
+	if filter.ErrorsOnly {
+		conditions = append(conditions, "error <> ''")
+	}
+
+	query := "SELECT id, executed_at, connection, database, statement, duration_ms, row_count, error FROM history"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	query += " ORDER BY id DESC LIMIT ? OFFSET ?"
+	args = append(args, limit, filter.Offset)
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []HistoryEntry{}
+	for rows.Next() {
+		var e HistoryEntry
+		if err := rows.Scan(&e.ID, &e.ExecutedAt, &e.Connection, &e.Database, &e.Statement, &e.DurationMs, &e.RowCount, &e.Error); err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Get returns a single history entry
+func (h *QueryHistory) Get(id int64) (*HistoryEntry, error) {
+	var e HistoryEntry
+	err := h.db.QueryRow(`SELECT id, executed_at, connection, database, statement, duration_ms, row_count, error
+		FROM history WHERE id = ?`, id).
+		Scan(&e.ID, &e.ExecutedAt, &e.Connection, &e.Database, &e.Statement, &e.DurationMs, &e.RowCount, &e.Error)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("history entry not found: %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return &e, nil
+}
+
+// Prune removes the selected entries and returns how many were removed
+func (h *QueryHistory) Prune(opts HistoryPrune) (int64, error) {
+	var query string
+	var args []interface{}
+	switch {
+	case opts.All:
+		query = "DELETE FROM history"
+	case opts.OlderThanDays > 0:
+		cutoff := time.Now().AddDate(0, 0, -opts.OlderThanDays).UTC().Format(historyTimeLayout)
+		query = "DELETE FROM history WHERE executed_at < ?"
+		args = append(args, cutoff)
+	case opts.KeepLast > 0:
+		query = "DELETE FROM history WHERE id NOT IN (SELECT id FROM history ORDER BY id DESC LIMIT ?)"
+		args = append(args, opts.KeepLast)
+	default:
+		return 0, fmt.Errorf("nothing selected to prune")
+	}
+
+	res, err := h.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune history: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// historyTimeLayout formats execution times so that they sort as text
+const historyTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// ftsQuery turns search text into an FTS5 query matching statements that
+// contain every word, each as a prefix, with FTS5 syntax taken literally
+func ftsQuery(text string) string {
+	words := strings.Fields(text)
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"*`
+	}
+	return strings.Join(words, " ")
+}
+
+// SetHistory sets where the statements run from the app are recorded
+func (m *Manager) SetHistory(h *QueryHistory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.history = h
+}
+
+// recordHistory logs a statement run by the user. Secrets are redacted, as
+// the history is kept on disk.
+func (m *Manager) recordHistory(statement string, start time.Time, elapsed time.Duration, rows int64, err error) {
+	m.mu.RLock()
+	h, config := m.history, m.config
+	m.mu.RUnlock()
+	if h == nil || config == nil {
+		return
+	}
+
+	entry := HistoryEntry{
+		ExecutedAt: start.UTC().Format(historyTimeLayout),
+		Connection: connectionLabel(config),
+		Database:   config.Database,
+		Statement:  redactSecrets(statement),
+		DurationMs: durationMs(elapsed),
+		RowCount:   rows,
+	}
+	if err != nil {
+		entry.Error = redactSecrets(err.Error())
+	}
+	h.Record(entry)
+}
+
+// connectionLabel identifies a server in the history without its credentials
+func connectionLabel(config *ConnectionConfig) string {
+	return fmt.Sprintf("%s://%s@%s:%d", config.Type, config.User, config.Host, config.Port)
+}
+
+// SearchHistory returns the recorded statements matching filter, newest first
+func (m *Manager) SearchHistory(filter HistoryFilter) ([]HistoryEntry, error) {
+	h, err := m.queryHistory()
+	if err != nil {
+		return nil, err
+	}
+	return h.Search(filter)
+}
+
+// RerunHistory runs a recorded statement again on the current connection
+func (m *Manager) RerunHistory(id int64) (*SQLResult, error) {
+	h, err := m.queryHistory()
+	if err != nil {
+		return nil, err
+	}
+	entry, err := h.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return m.ExecuteSQL(entry.Statement, SQLOptions{})
+}
+
+// PruneHistory removes recorded statements and returns how many were removed
+func (m *Manager) PruneHistory(opts HistoryPrune) (int64, error) {
+	h, err := m.queryHistory()
+	if err != nil {
+		return 0, err
+	}
+	return h.Prune(opts)
+}
+
+func (m *Manager) queryHistory() (*QueryHistory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.history == nil {
+		return nil, fmt.Errorf("query history is not available")
+	}
+	return m.history, nil
+}
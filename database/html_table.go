@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Inline styles of a styled HTML table; inline because pasting into Sheets,
+// mail clients and wikis drops stylesheets
+const (
+	htmlTableStyle  = `border-collapse:collapse;font-family:Arial,sans-serif;font-size:10pt`
+	htmlHeaderStyle = `border:1px solid #ccc;padding:4px 8px;background:#f3f4f6;font-weight:bold;text-align:left`
+	htmlCellStyle   = `border:1px solid #ccc;padding:4px 8px;vertical-align:top`
+	htmlNullStyle   = `;color:#9ca3af;font-style:italic`
+)
+
+// FormatCellsHTML renders rows as an HTML table for rich paste into
+// spreadsheets, email and wikis. Values are formatted like FormatCells and
+// HTML-escaped; line breaks inside values become <br>.
+func FormatCellsHTML(columns []ColumnInfo, rows [][]interface{}, opts CopyOptions) (string, error) {
+	opts = opts.withDefaults()
+
+	var sb strings.Builder
+	sb.WriteString("<table")
+	if opts.Styled {
+		fmt.Fprintf(&sb, ` style="%s"`, htmlTableStyle)
+	}
+	sb.WriteString(">\n")
+
+	if opts.IncludeHeaders {
+		sb.WriteString("<thead><tr>")
+		for _, col := range columns {
+			sb.WriteString("<th")
+			if opts.Styled {
+				fmt.Fprintf(&sb, ` style="%s"`, htmlHeaderStyle)
+			}
+			sb.WriteString(">" + html.EscapeString(col.Name) + "</th>")
+		}
+		sb.WriteString("</tr></thead>\n")
+	}
+
+	sb.WriteString("<tbody>\n")
+	for _, row := range rows {
+		if len(row) != len(columns) {
+			return "", fmt.Errorf("row has %d values but %d columns were given", len(row), len(columns))
+		}
+
+		sb.WriteString("<tr>")
+		for i, val := range row {
+			category := typeCategory(columns[i].Type)
+
+			text := opts.NullMarker
+			if val != nil {
+				text = formatCell(val, category, opts)
+			}
+
+			sb.WriteString("<td")
+			if opts.Styled {
+				style := htmlCellStyle
+				switch {
+				case val == nil:
+					style += htmlNullStyle
+				case category == typeCategoryInteger || category == typeCategoryDecimal || category == typeCategoryFloat:
+					style += ";text-align:right"
+				}
+				fmt.Fprintf(&sb, ` style="%s"`, style)
+			}
+			sb.WriteString(">" + htmlCellText(text) + "</td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>")
+
+	return sb.String(), nil
+}
+
+// htmlCellText escapes a value and keeps its line breaks
+func htmlCellText(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Longest identifiers the servers accept. MySQL counts characters and rejects
+// longer names; PostgreSQL counts bytes and silently truncates them.
+const (
+	mysqlMaxIdentifier    = 64
+	postgresMaxIdentifier = 63
+)
+
+// quoteIdentifier wraps a name in quote, doubling every quote inside it so
+// that any name, however odd, stays a single identifier
+func quoteIdentifier(name, quote string) string {
+	return quote + strings.ReplaceAll(name, quote, quote+quote) + quote
+}
+
+// qualifiedName quotes the non-empty parts of a name and joins them with dots,
+// e.g. "schema"."table"
+func qualifiedName(quote func(string) string, parts ...string) string {
+	quoted := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			quoted = append(quoted, quote(part))
+		}
+	}
+	return strings.Join(quoted, ".")
+}
+
+// validateIdentifier checks that a name can be used as an identifier of at
+// most max characters, or max bytes when bytes is set
+func validateIdentifier(name string, max int, bytes bool) error {
+	if name == "" {
+		return fmt.Errorf("identifier must not be empty")
+	}
+	if strings.ContainsRune(name, 0) {
+		return fmt.Errorf("identifier %q must not contain NUL characters", name)
+	}
+	if !utf8.ValidString(name) {
+		return fmt.Errorf("identifier %q is not valid UTF-8", name)
+	}
+
+	length, unit := utf8.RuneCountInString(name), "characters"
+	if bytes {
+		length, unit = len(name), "bytes"
+	}
+	if length > max {
+		return fmt.Errorf("identifier %q is %d %s long; the limit is %d", name, length, unit, max)
+	}
+	return nil
+}
+
+// validateNames checks new names of tables, columns and constraints before
+// they are used in DDL, skipping empty ones
+func (m *Manager) validateNames(names ...string) error {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if err := m.currentDriver().ValidateIdentifier(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,592 @@
+package database
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Logical column types inferred from imported data
+const (
+	importTypeInteger   = "integer"
+	importTypeFloat     = "float"
+	importTypeBoolean   = "boolean"
+	importTypeText      = "text"
+	importTypeJSON      = "json"
+	importTypeDecimal   = "decimal"
+	importTypeDate      = "date"
+	importTypeTimestamp = "timestamp"
+	importTypeBinary    = "binary"
+)
+
+// ImportOptions controls how a file is parsed and loaded
+type ImportOptions struct {
+	Format      string `json:"format"`      // json, ndjson, parquet, csv or empty for auto-detect
+	Flatten     bool   `json:"flatten"`     // Flatten nested objects into a.b.c columns
+	Separator   string `json:"separator"`   // Separator for flattened column names, defaults to "."
+	CreateTable bool   `json:"createTable"` // Create the target table from the inferred schema
+	PreviewRows int    `json:"previewRows"` // Number of rows returned by PreviewImport
+}
+
+// ImportColumn represents a column inferred from the imported data
+type ImportColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`    // Logical type: integer, float, boolean, text, json, decimal, date, timestamp, binary
+	SQLType  string `json:"sqlType"` // Type used for the current driver
+	Nullable bool   `json:"nullable"`
+}
+
+// ImportPreview holds the inferred schema and a sample of rows
+type ImportPreview struct {
+	Columns   []ImportColumn  `json:"columns"`
+	Rows      [][]interface{} `json:"rows"`
+	TotalRows int             `json:"totalRows"`
+}
+
+// ImportResult holds the outcome of an import
+type ImportResult struct {
+	RowsImported int64 `json:"rowsImported"`
+	TableCreated bool  `json:"tableCreated"`
+}
+
+// importData is a parsed file ready to be previewed or inserted
+type importData struct {
+	columns []ImportColumn
+	rows    []map[string]interface{}
+}
+
+// PreviewImport parses a file and returns the inferred schema with the first rows
+func (m *Manager) PreviewImport(path string, opts ImportOptions) (*ImportPreview, error) {
+	data, err := m.readImportFile(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := opts.PreviewRows
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > len(data.rows) {
+		limit = len(data.rows)
+	}
+
+	preview := &ImportPreview{
+		Columns:   data.columns,
+		Rows:      make([][]interface{}, 0, limit),
+		TotalRows: len(data.rows),
+	}
+	for _, row := range data.rows[:limit] {
+		values := make([]interface{}, len(data.columns))
+		for i, col := range data.columns {
+			values[i] = row[col.Name]
+		}
+		preview.Rows = append(preview.Rows, values)
+	}
+
+	return preview, nil
+}
+
+// ImportFile loads a file into a table, optionally creating it from the inferred schema
+func (m *Manager) ImportFile(database, schema, table, path string, opts ImportOptions) (*ImportResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	data, err := m.readImportFile(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.columns) == 0 {
+		return nil, fmt.Errorf("no columns found in import file")
+	}
+	if opts.CreateTable {
+		names := []string{table}
+		for _, col := range data.columns {
+			names = append(names, col.Name)
+		}
+		if err := m.validateNames(names...); err != nil {
+			return nil, err
+		}
+	}
+
+	// Cancelling the operation rolls the transaction back
+	ctx, done := m.track("import", table)
+	defer done()
+
+	tx, err := db.withContext(ctx).Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &ImportResult{}
+
+	if opts.CreateTable {
+		columns := make([]ColumnInfo, len(data.columns))
+		for i, col := range data.columns {
+			columns[i] = ColumnInfo{Name: col.Name, Type: col.SQLType, Nullable: col.Nullable}
+		}
+		if _, err := tx.Exec(m.tableDriver(schema).BuildCreateTableQuery(database, table, columns)); err != nil {
+			return nil, fmt.Errorf("failed to create table: %w", err)
+		}
+		result.TableCreated = true
+	}
+
+	// Generated and identity columns of an existing table are filled by the database
+	readOnly := map[string]bool{}
+	if !opts.CreateTable {
+		if readOnly, err = m.readOnlyColumns(database, schema, table); err != nil {
+			return nil, err
+		}
+	}
+
+	var colNames []string
+	for _, col := range data.columns {
+		if !readOnly[col.Name] {
+			colNames = append(colNames, col.Name)
+		}
+	}
+	if len(colNames) == 0 {
+		return nil, fmt.Errorf("no writable columns found in import file")
+	}
+
+	progress := m.newProgress("import", table, int64(len(data.rows)))
+	if driver, ok := m.tableDriver(schema).(*PostgresDriver); ok {
+		result.RowsImported, err = driver.copyRows(tx, table, colNames, data.rows, progress)
+	} else {
+		result.RowsImported, err = m.insertRows(tx, database, schema, table, colNames, data.rows, progress)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit import: %w", err)
+	}
+	m.forgetUndo(database, schema, table)
+	progress.finish()
+
+	return result, nil
+}
+
+// maxInsertBatch bounds the rows of a multi-row INSERT, keeping statements
+// well below packet size limits for wide values
+const maxInsertBatch = 1000
+
+// insertBatchSize returns how many rows of columns columns a multi-row INSERT
+// can hold within the driver's placeholder limit
+func insertBatchSize(driver Driver, columns int) int {
+	return max(1, min(maxInsertBatch, driver.MaxPlaceholders()/columns))
+}
+
+// insertRows loads rows with multi-row INSERTs and returns how many were inserted
+func (m *Manager) insertRows(tx *instrumentedTx, database, schema, table string, colNames []string, rows []map[string]interface{}, progress *progressReporter) (int64, error) {
+	// Rows are inserted in multi-row batches; the prepared statement covers
+	// every full batch and the remainder gets its own
+	batch := max(1, min(insertBatchSize(m.tableDriver(schema), len(colNames)), len(rows)))
+	stmt, err := tx.Prepare(m.tableDriver(schema).BuildBatchInsertQuery(database, table, colNames, batch))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	var imported int64
+	values := make([]interface{}, 0, batch*len(colNames))
+	for start := 0; start < len(rows); start += batch {
+		chunk := rows[start:min(start+batch, len(rows))]
+		values = values[:0]
+		for _, row := range chunk {
+			for _, name := range colNames {
+				values = append(values, row[name])
+			}
+		}
+
+		if len(chunk) == batch {
+			_, err = stmt.Exec(values...)
+		} else {
+			_, err = tx.Exec(m.tableDriver(schema).BuildBatchInsertQuery(database, table, colNames, len(chunk)), values...)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert rows %d-%d: %w", start+1, start+len(chunk), err)
+		}
+
+		imported += int64(len(chunk))
+		for range chunk {
+			progress.add()
+		}
+	}
+	return imported, nil
+}
+
+// readImportFile dispatches to the parser for the requested format
+func (m *Manager) readImportFile(path string, opts ImportOptions) (*importData, error) {
+	if opts.Separator == "" {
+		opts.Separator = "."
+	}
+
+	format := strings.ToLower(opts.Format)
+	if format == "" {
+		format = formatFromPath(path)
+	}
+
+	var (
+		data *importData
+		err  error
+	)
+	switch format {
+	case "json", "ndjson", "jsonl":
+		data, err = readJSONImport(path, opts)
+	case "parquet":
+		data, err = readParquetImport(path, opts)
+	case "csv":
+		data, err = readCSVImport(path)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range data.columns {
+		if data.columns[i].SQLType == "" {
+			data.columns[i].SQLType = importSQLType(m.currentDriver(), data.columns[i].Type)
+		}
+	}
+
+	return data, nil
+}
+
+// readJSONImport parses a JSON array or newline delimited JSON objects
+func readJSONImport(path string, opts ImportOptions) (*importData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	dec := json.NewDecoder(reader)
+	dec.UseNumber()
+
+	// A leading '[' means a JSON array, anything else is treated as NDJSON
+	isArray, err := startsWithArray(reader)
+	if err != nil {
+		return nil, err
+	}
+	if isArray {
+		if _, err := dec.Token(); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+
+	inf := newSchemaInference()
+	data := &importData{}
+
+	for dec.More() {
+		obj, err := decodeOrderedObject(dec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON at record %d: %w", len(data.rows)+1, err)
+		}
+
+		row := make(map[string]interface{})
+		obj.collect("", opts, row, inf)
+		inf.rows++
+		data.rows = append(data.rows, row)
+	}
+
+	data.columns = inf.columns()
+	return data, nil
+}
+
+// startsWithArray peeks the first non-whitespace byte of the reader
+func startsWithArray(r *bufio.Reader) (bool, error) {
+	for i := 1; ; i++ {
+		b, err := r.Peek(i)
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read file: %w", err)
+		}
+		switch b[i-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b[i-1] == '[', nil
+	}
+}
+
+// orderedObject is a JSON object that keeps its keys in document order
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// decodeOrderedObject reads the next JSON object from the decoder
+func decodeOrderedObject(dec *json.Decoder) (*orderedObject, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected object, got %v", tok)
+	}
+	return decodeObjectBody(dec)
+}
+
+func decodeObjectBody(dec *json.Decoder) (*orderedObject, error) {
+	obj := &orderedObject{values: make(map[string]interface{})}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected object key, got %v", tok)
+		}
+
+		val, err := decodeOrderedValue(dec)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := obj.values[key]; !exists {
+			obj.keys = append(obj.keys, key)
+		}
+		obj.values[key] = val
+	}
+	// Consume the closing '}'
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func decodeOrderedValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		return decodeObjectBody(dec)
+	case '[':
+		var arr []interface{}
+		for dec.More() {
+			val, err := decodeOrderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		if arr == nil {
+			arr = []interface{}{}
+		}
+		return arr, nil
+	default:
+		return nil, fmt.Errorf("unexpected delimiter %v", delim)
+	}
+}
+
+// MarshalJSON keeps the document key order when nested values are stored as JSON
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		sb.Write(k)
+		sb.WriteByte(':')
+		sb.Write(v)
+	}
+	sb.WriteByte('}')
+	return []byte(sb.String()), nil
+}
+
+// collect converts the object into column values, flattening nested objects if requested
+func (o *orderedObject) collect(prefix string, opts ImportOptions, row map[string]interface{}, inf *schemaInference) {
+	for _, key := range o.keys {
+		name := key
+		if prefix != "" {
+			name = prefix + opts.Separator + key
+		}
+
+		val := o.values[key]
+		if nested, ok := val.(*orderedObject); ok && opts.Flatten {
+			nested.collect(name, opts, row, inf)
+			continue
+		}
+
+		value, logicalType := importValue(val)
+		row[name] = value
+		inf.observe(name, logicalType)
+	}
+}
+
+// importValue converts a decoded JSON value into a driver parameter and its logical type
+func importValue(val interface{}) (interface{}, string) {
+	switch v := val.(type) {
+	case nil:
+		return nil, ""
+	case bool:
+		return v, importTypeBoolean
+	case string:
+		return v, importTypeText
+	case json.Number:
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return i, importTypeInteger
+		}
+		if f, err := strconv.ParseFloat(v.String(), 64); err == nil && strconv.FormatFloat(f, 'g', -1, 64) == v.String() {
+			return f, importTypeFloat
+		}
+		// Keep the original text so large or precise numbers are not rounded
+		return v.String(), importTypeFloat
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v), importTypeText
+		}
+		return string(encoded), importTypeJSON
+	}
+}
+
+// schemaInference accumulates column types across all imported rows
+type schemaInference struct {
+	order []string
+	types map[string]string
+	nulls map[string]bool
+	seen  map[string]int
+	rows  int
+}
+
+func newSchemaInference() *schemaInference {
+	return &schemaInference{
+		types: make(map[string]string),
+		nulls: make(map[string]bool),
+		seen:  make(map[string]int),
+	}
+}
+
+func (s *schemaInference) observe(name, logicalType string) {
+	if _, exists := s.seen[name]; !exists {
+		s.order = append(s.order, name)
+	}
+	s.seen[name]++
+
+	if logicalType == "" {
+		s.nulls[name] = true
+		return
+	}
+	s.types[name] = mergeImportTypes(s.types[name], logicalType)
+}
+
+func (s *schemaInference) columns() []ImportColumn {
+	columns := make([]ImportColumn, len(s.order))
+	for i, name := range s.order {
+		t := s.types[name]
+		if t == "" {
+			t = importTypeText
+		}
+		columns[i] = ImportColumn{
+			Name: name,
+			Type: t,
+			// Columns missing from some records are nullable too
+			Nullable: s.nulls[name] || s.seen[name] < s.rows,
+		}
+	}
+	return columns
+}
+
+// mergeImportTypes widens a column type to fit a newly observed value. A
+// column mixing JSON with other values becomes text: plain strings are not
+// valid JSON, and the JSON values are already encoded as text.
+func mergeImportTypes(current, observed string) string {
+	switch {
+	case current == "" || current == observed:
+		return observed
+	case (current == importTypeInteger && observed == importTypeFloat) ||
+		(current == importTypeFloat && observed == importTypeInteger):
+		return importTypeFloat
+	default:
+		return importTypeText
+	}
+}
+
+// importSQLType maps a logical import type to a column type for the driver
+func importSQLType(driver Driver, logicalType string) string {
+	if _, ok := driver.(*PostgresDriver); ok {
+		switch logicalType {
+		case importTypeInteger:
+			return "BIGINT"
+		case importTypeFloat:
+			return "DOUBLE PRECISION"
+		case importTypeBoolean:
+			return "BOOLEAN"
+		case importTypeJSON:
+			return "JSONB"
+		case importTypeDecimal:
+			return "NUMERIC"
+		case importTypeDate:
+			return "DATE"
+		case importTypeTimestamp:
+			return "TIMESTAMP"
+		case importTypeBinary:
+			return "BYTEA"
+		default:
+			return "TEXT"
+		}
+	}
+
+	switch logicalType {
+	case importTypeInteger:
+		return "BIGINT"
+	case importTypeFloat:
+		return "DOUBLE"
+	case importTypeBoolean:
+		return "TINYINT(1)"
+	case importTypeJSON:
+		return "JSON"
+	case importTypeDecimal:
+		return "DECIMAL(65,30)"
+	case importTypeDate:
+		return "DATE"
+	case importTypeTimestamp:
+		return "DATETIME(6)"
+	case importTypeBinary:
+		return "LONGBLOB"
+	default:
+		return "TEXT"
+	}
+}
+
+// formatFromPath guesses the import format from the file extension
+func formatFromPath(path string) string {
+	lower := strings.ToLower(path)
+	if idx := strings.LastIndex(lower, "."); idx >= 0 {
+		return lower[idx+1:]
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readCSVImport reads a CSV file whose first row names the columns. Empty
+// fields and the NULL written by exportCSV are imported as NULL.
+func readCSVImport(path string) (*importData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	names := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		// Files saved by spreadsheet tools may start with a byte order mark
+		names[i] = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if names[i] == "" {
+			return nil, fmt.Errorf("CSV column %d has no name", i+1)
+		}
+		if seen[names[i]] {
+			return nil, fmt.Errorf("duplicate CSV column: %s", names[i])
+		}
+		seen[names[i]] = true
+	}
+
+	data := &importData{}
+	inf := newSchemaInference()
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		row := make(map[string]interface{}, len(names))
+		for i, name := range names {
+			value, logicalType := csvValue(record[i])
+			row[name] = value
+			inf.observe(name, logicalType)
+		}
+		data.rows = append(data.rows, row)
+		inf.rows++
+	}
+
+	// A file without rows still yields the columns of its header
+	if len(data.rows) == 0 {
+		for _, name := range names {
+			inf.observe(name, "")
+		}
+	}
+	data.columns = inf.columns()
+	return data, nil
+}
+
+// csvValue converts a CSV field into a driver parameter and its logical type
+func csvValue(field string) (interface{}, string) {
+	if field == "" || field == "NULL" {
+		return nil, ""
+	}
+	// Only values that format back to the same text are numbers, so codes
+	// such as 007 keep their leading zeros
+	if i, err := strconv.ParseInt(field, 10, 64); err == nil && strconv.FormatInt(i, 10) == field {
+		return i, importTypeInteger
+	}
+	if f, err := strconv.ParseFloat(field, 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == field {
+		return f, importTypeFloat
+	}
+	switch strings.ToLower(field) {
+	case "true", "false":
+		return strings.EqualFold(field, "true"), importTypeBoolean
+	}
+	return field, importTypeText
+}
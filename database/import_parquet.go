@@ -0,0 +1,241 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// julianUnixEpoch is the Julian day number of 1970-01-01, used by INT96 timestamps
+const julianUnixEpoch = 2440588
+
+// parquetColumn describes how a leaf or nested parquet field is converted
+type parquetColumn struct {
+	path   []string
+	field  parquet.Field
+	column ImportColumn
+}
+
+// readParquetImport reads a parquet file, mapping column types from the file schema
+func readParquetImport(path string, opts ImportOptions) (*importData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	pf, err := parquet.OpenFile(file, stat.Size())
+	if err != nil {
+		return nil, fmt.Errorf("invalid parquet file: %w", err)
+	}
+
+	var columns []parquetColumn
+	for _, field := range pf.Schema().Fields() {
+		columns = appendParquetColumns(columns, nil, field, opts)
+	}
+
+	data := &importData{
+		columns: make([]ImportColumn, len(columns)),
+		rows:    make([]map[string]interface{}, 0, pf.NumRows()),
+	}
+	for i, col := range columns {
+		data.columns[i] = col.column
+	}
+
+	reader := parquet.NewReader(pf)
+	defer reader.Close()
+
+	for {
+		record := make(map[string]interface{})
+		if err := reader.Read(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to read row %d: %w", len(data.rows)+1, err)
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for _, col := range columns {
+			row[col.column.Name] = parquetValue(col, lookupParquetPath(record, col.path))
+		}
+		data.rows = append(data.rows, row)
+	}
+
+	return data, nil
+}
+
+// appendParquetColumns maps a schema field to import columns, flattening plain groups if requested
+func appendParquetColumns(columns []parquetColumn, parent []string, field parquet.Field, opts ImportOptions) []parquetColumn {
+	path := append(append([]string{}, parent...), field.Name())
+
+	isList := field.Type().LogicalType() != nil && (field.Type().LogicalType().List != nil || field.Type().LogicalType().Map != nil)
+	if !field.Leaf() && opts.Flatten && !isList && !field.Repeated() {
+		for _, child := range field.Fields() {
+			columns = appendParquetColumns(columns, path, child, opts)
+		}
+		return columns
+	}
+
+	col := ImportColumn{
+		Name:     strings.Join(path, opts.Separator),
+		Type:     parquetImportType(field),
+		Nullable: field.Optional() || len(parent) > 0,
+	}
+	if dec := parquetDecimal(field); dec != nil {
+		col.SQLType = fmt.Sprintf("DECIMAL(%d,%d)", dec.Precision, dec.Scale)
+	}
+
+	return append(columns, parquetColumn{path: path, field: field, column: col})
+}
+
+// parquetImportType maps a parquet field to a logical import type
+func parquetImportType(field parquet.Field) string {
+	if !field.Leaf() || field.Repeated() {
+		return importTypeJSON
+	}
+
+	if lt := field.Type().LogicalType(); lt != nil {
+		switch {
+		case lt.UTF8 != nil, lt.Enum != nil, lt.UUID != nil:
+			return importTypeText
+		case lt.Json != nil:
+			return importTypeJSON
+		case lt.Decimal != nil:
+			return importTypeDecimal
+		case lt.Date != nil:
+			return importTypeDate
+		case lt.Timestamp != nil:
+			return importTypeTimestamp
+		}
+	}
+
+	switch field.Type().Kind() {
+	case parquet.Boolean:
+		return importTypeBoolean
+	case parquet.Int32, parquet.Int64:
+		return importTypeInteger
+	case parquet.Float, parquet.Double:
+		return importTypeFloat
+	case parquet.Int96:
+		return importTypeTimestamp
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		return importTypeBinary
+	default:
+		return importTypeText
+	}
+}
+
+func parquetDecimal(field parquet.Field) *format.DecimalType {
+	if !field.Leaf() {
+		return nil
+	}
+	if lt := field.Type().LogicalType(); lt != nil {
+		return lt.Decimal
+	}
+	return nil
+}
+
+// lookupParquetPath walks a reconstructed record to the value at path
+func lookupParquetPath(record map[string]interface{}, path []string) interface{} {
+	var current interface{} = record
+	for _, name := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[name]
+	}
+	return current
+}
+
+// parquetValue converts a reconstructed parquet value into a driver parameter
+func parquetValue(col parquetColumn, val interface{}) interface{} {
+	if val == nil {
+		return nil
+	}
+
+	switch col.column.Type {
+	case importTypeJSON:
+		if s, ok := val.(string); ok {
+			return s
+		}
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(encoded)
+	case importTypeBinary:
+		if s, ok := val.(string); ok {
+			return []byte(s)
+		}
+	case importTypeDecimal:
+		return parquetDecimalString(val, int(parquetDecimal(col.field).Scale))
+	case importTypeDate:
+		if days, ok := val.(int32); ok {
+			return time.Unix(int64(days)*86400, 0).UTC()
+		}
+	case importTypeTimestamp:
+		return parquetTimestamp(col.field, val)
+	}
+
+	return val
+}
+
+// parquetDecimalString formats an unscaled decimal value with the given scale
+func parquetDecimalString(val interface{}, scale int) interface{} {
+	unscaled := new(big.Int)
+	switch v := val.(type) {
+	case int32:
+		unscaled.SetInt64(int64(v))
+	case int64:
+		unscaled.SetInt64(v)
+	case string:
+		// Big-endian two's complement
+		unscaled.SetBytes([]byte(v))
+		if len(v) > 0 && v[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(v)*8)))
+		}
+	default:
+		return val
+	}
+
+	return new(big.Rat).SetFrac(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)).FloatString(scale)
+}
+
+// parquetTimestamp converts INT64 timestamps and legacy INT96 values to time.Time
+func parquetTimestamp(field parquet.Field, val interface{}) interface{} {
+	switch v := val.(type) {
+	case deprecated.Int96:
+		nanos := int64(uint64(v[1])<<32 | uint64(v[0]))
+		days := int64(v[2]) - julianUnixEpoch
+		return time.Unix(days*86400, nanos).UTC()
+	case int64:
+		lt := field.Type().LogicalType()
+		if lt == nil || lt.Timestamp == nil {
+			return v
+		}
+		switch {
+		case lt.Timestamp.Unit.Millis != nil:
+			return time.UnixMilli(v).UTC()
+		case lt.Timestamp.Unit.Micros != nil:
+			return time.UnixMicro(v).UTC()
+		default:
+			return time.Unix(0, v).UTC()
+		}
+	}
+	return val
+}
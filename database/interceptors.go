@@ -0,0 +1,293 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// Statement kinds passed to interceptors
+const (
+	StatementQuery    = "query"
+	StatementExec     = "exec"
+	StatementPrepare  = "prepare"
+	StatementBegin    = "begin"
+	StatementCommit   = "commit"
+	StatementRollback = "rollback"
+)
+
+// Querier runs statements; it is implemented by the instrumented connection,
+// transaction and session handed out by the Manager
+type Querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) RowScanner
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// RowScanner is the result of QueryRow; *sql.Row satisfies it
+type RowScanner interface {
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+// Statement is a SQL statement on its way to the database. Interceptors may
+// rewrite Query and Args before passing it on.
+type Statement struct {
+	Kind  string
+	Query string
+	Args  []interface{}
+}
+
+// Interceptor wraps the execution of a statement. It must call next to run the
+// statement, or return an error to reject it. Interceptors run in registration
+// order, the first registered being the outermost.
+type Interceptor func(stmt *Statement, next func(*Statement) error) error
+
+type namedInterceptor struct {
+	name        string
+	interceptor Interceptor
+}
+
+// interceptorChain is the ordered set of interceptors of a Manager
+type interceptorChain struct {
+	mu      sync.RWMutex
+	entries []namedInterceptor
+}
+
+// add registers an interceptor, replacing one registered under the same name in place
+func (c *interceptorChain) add(name string, i Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for idx, e := range c.entries {
+		if e.name == name {
+			c.entries[idx].interceptor = i
+			return
+		}
+	}
+	c.entries = append(c.entries, namedInterceptor{name: name, interceptor: i})
+}
+
+// remove unregisters an interceptor by name
+func (c *interceptorChain) remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for idx, e := range c.entries {
+		if e.name == name {
+			c.entries = append(c.entries[:idx], c.entries[idx+1:]...)
+			return
+		}
+	}
+}
+
+// names lists the registered interceptors in order
+func (c *interceptorChain) names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, len(c.entries))
+	for i, e := range c.entries {
+		names[i] = e.name
+	}
+	return names
+}
+
+// run passes stmt through the chain and finally to exec
+func (c *interceptorChain) run(stmt *Statement, exec func(*Statement) error) error {
+	c.mu.RLock()
+	entries := append([]namedInterceptor(nil), c.entries...)
+	c.mu.RUnlock()
+
+	next := exec
+	for i := len(entries) - 1; i >= 0; i-- {
+		interceptor, inner := entries[i].interceptor, next
+		next = func(s *Statement) error { return interceptor(s, inner) }
+	}
+	return next(stmt)
+}
+
+// RegisterInterceptor adds an interceptor to the connection's execution chain.
+// Registering a name again replaces the previous interceptor.
+func (m *Manager) RegisterInterceptor(name string, i Interceptor) {
+	m.interceptors.add(name, i)
+}
+
+// UnregisterInterceptor removes an interceptor from the execution chain
+func (m *Manager) UnregisterInterceptor(name string) {
+	m.interceptors.remove(name)
+}
+
+// Interceptors lists the registered interceptors in execution order
+func (m *Manager) Interceptors() []string {
+	return m.interceptors.names()
+}
+
+// sqlRunner is the context-aware API shared by *sql.DB, *sql.Tx and *sql.Conn
+type sqlRunner interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// statementRunner implements Querier by sending each statement through the chain
+type statementRunner struct {
+	runner sqlRunner
+	ctx    context.Context
+	chain  *interceptorChain
+}
+
+func (r statementRunner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := r.chain.run(&Statement{Kind: StatementQuery, Query: query, Args: args}, func(s *Statement) error {
+		var err error
+		rows, err = r.runner.QueryContext(r.ctx, s.Query, s.Args...)
+		return err
+	})
+	if err != nil {
+		if rows != nil {
+			rows.Close()
+		}
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r statementRunner) QueryRow(query string, args ...interface{}) RowScanner {
+	var row *sql.Row
+	err := r.chain.run(&Statement{Kind: StatementQuery, Query: query, Args: args}, func(s *Statement) error {
+		row = r.runner.QueryRowContext(r.ctx, s.Query, s.Args...)
+		return row.Err()
+	})
+	if row == nil || (err != nil && row.Err() == nil) {
+		return errRow{err: err}
+	}
+	return row
+}
+
+func (r statementRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	var res sql.Result
+	err := r.chain.run(&Statement{Kind: StatementExec, Query: query, Args: args}, func(s *Statement) error {
+		var err error
+		res, err = r.runner.ExecContext(r.ctx, s.Query, s.Args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// errRow is returned by QueryRow when an interceptor rejects the statement
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...interface{}) error { return r.err }
+func (r errRow) Err() error                     { return r.err }
+
+// instrumentedDB is the connection pool as seen by the rest of the package
+type instrumentedDB struct {
+	statementRunner
+	pool *sql.DB
+}
+
+func newInstrumentedDB(pool *sql.DB, chain *interceptorChain) *instrumentedDB {
+	return &instrumentedDB{
+		statementRunner: statementRunner{runner: pool, ctx: context.Background(), chain: chain},
+		pool:            pool,
+	}
+}
+
+// withContext returns a copy of the pool whose statements and transactions
+// are bound to ctx
+func (d *instrumentedDB) withContext(ctx context.Context) *instrumentedDB {
+	c := *d
+	c.ctx = ctx
+	return &c
+}
+
+// Begin starts a transaction whose statements also go through the chain
+func (d *instrumentedDB) Begin() (*instrumentedTx, error) {
+	var tx *sql.Tx
+	err := d.chain.run(&Statement{Kind: StatementBegin, Query: "BEGIN"}, func(*Statement) error {
+		var err error
+		tx, err = d.pool.BeginTx(d.ctx, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{
+		statementRunner: statementRunner{runner: tx, ctx: d.ctx, chain: d.chain},
+		tx:              tx,
+	}, nil
+}
+
+// session reserves a single connection from the pool, for statements that
+// depend on session state such as user variables
+func (d *instrumentedDB) session(ctx context.Context) (*instrumentedConn, error) {
+	conn, err := d.pool.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{
+		statementRunner: statementRunner{runner: conn, ctx: ctx, chain: d.chain},
+		conn:            conn,
+	}, nil
+}
+
+// instrumentedConn is a reserved connection; Close returns it to the pool
+type instrumentedConn struct {
+	statementRunner
+	conn *sql.Conn
+
+	// release runs before the connection goes back to the pool
+	release func()
+}
+
+func (c *instrumentedConn) Close() error {
+	if c.release != nil {
+		c.release()
+	}
+	return c.conn.Close()
+}
+
+// instrumentedTx is a transaction whose statements go through the chain
+type instrumentedTx struct {
+	statementRunner
+	tx   *sql.Tx
+	done bool
+}
+
+// Prepare sends the statement through the chain once; its executions are not intercepted individually
+func (t *instrumentedTx) Prepare(query string) (*sql.Stmt, error) {
+	var stmt *sql.Stmt
+	err := t.chain.run(&Statement{Kind: StatementPrepare, Query: query}, func(s *Statement) error {
+		var err error
+		stmt, err = t.tx.PrepareContext(t.ctx, s.Query)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+func (t *instrumentedTx) Commit() error {
+	t.done = true
+	return t.chain.run(&Statement{Kind: StatementCommit, Query: "COMMIT"}, func(*Statement) error {
+		return t.tx.Commit()
+	})
+}
+
+// Rollback is a no-op once the transaction has been committed or rolled back
+func (t *instrumentedTx) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	return t.chain.run(&Statement{Kind: StatementRollback, Query: "ROLLBACK"}, func(*Statement) error {
+		return t.tx.Rollback()
+	})
+}
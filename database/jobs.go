@@ -0,0 +1,200 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxJobRows bounds the rows a job keeps per result set while it reads them,
+// as nobody is looking at its results while it runs
+const maxJobRows = 1000
+
+// Job is a script detached to run in the background. Its status changes are
+// emitted as "job:progress" after each statement and "job:done" at the end.
+type Job struct {
+	ID           int64   `json:"id"`
+	Query        string  `json:"query"`
+	Database     string  `json:"database"`
+	Status       string  `json:"status"` // running, done, failed or cancelled
+	Statements   int     `json:"statements"`
+	Completed    int     `json:"completed"` // Statements run so far
+	RowsAffected int64   `json:"rowsAffected"`
+	Started      string  `json:"started"` // RFC 3339
+	Finished     string  `json:"finished,omitempty"`
+	DurationMs   float64 `json:"durationMs"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// jobRegistry holds the background jobs of the session, finished ones
+// included until they are cleared
+type jobRegistry struct {
+	mu     sync.Mutex
+	nextID int64
+	jobs   map[int64]*backgroundJob
+}
+
+type backgroundJob struct {
+	info      Job
+	operation int64 // Tracked operation running the script
+	cancelled bool
+	result    *SQLResult
+}
+
+// StartJob runs a script in the background as ExecuteSQL would and returns at
+// once. The job runs on a connection of its own, outside of any tab, so that
+// closing the tab it was started from leaves it running. Result sets keep
+// their first maxJobRows rows, whatever opts.MaxRows asks for.
+func (m *Manager) StartJob(query string, opts SQLOptions) (*Job, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	_, mysql := m.currentDriver().(*MySQLDriver)
+	statements := splitStatements(query, mysql)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no SQL to execute")
+	}
+	opts.TabID = ""
+	opts.MaxRows = maxJobRows
+	var database string
+	m.mu.RLock()
+	if m.config != nil {
+		database = m.config.Database
+	}
+	m.mu.RUnlock()
+
+	ctx, done := m.track("job", query)
+	operation, _ := ctx.Value(operationKey{}).(int64)
+
+	m.jobs.mu.Lock()
+	m.jobs.nextID++
+	job := &backgroundJob{
+		info: Job{
+			ID:         m.jobs.nextID,
+			Query:      query,
+			Database:   database,
+			Status:     "running",
+			Statements: len(statements),
+			Started:    time.Now().Format(time.RFC3339),
+		},
+		operation: operation,
+	}
+	m.jobs.jobs[job.info.ID] = job
+	info := job.info
+	m.jobs.mu.Unlock()
+
+	go func() {
+		defer done()
+		start := time.Now()
+		result, err := m.runScript(ctx, db, statements, opts, func(stmt StatementResult) {
+			m.jobs.mu.Lock()
+			job.info.Completed++
+			job.info.RowsAffected += stmt.RowsAffected
+			job.info.DurationMs = durationMs(time.Since(start))
+			info := job.info
+			m.jobs.mu.Unlock()
+			m.emit("job:progress", info)
+		})
+		m.finishJob(job, result, err, time.Since(start))
+	}()
+
+	return &info, nil
+}
+
+// finishJob records the outcome of a job and emits "job:done"
+func (m *Manager) finishJob(job *backgroundJob, result *SQLResult, err error, elapsed time.Duration) {
+	m.jobs.mu.Lock()
+	job.info.DurationMs = durationMs(elapsed)
+	job.info.Finished = time.Now().Format(time.RFC3339)
+	switch {
+	case job.cancelled:
+		job.info.Status = "cancelled"
+	case err != nil:
+		job.info.Status, job.info.Error = "failed", err.Error()
+	default:
+		job.info.Status = "done"
+	}
+	if result != nil {
+		for i := range result.Statements {
+			stmt := &result.Statements[i]
+			if stmt.Error != "" && job.info.Status == "done" {
+				job.info.Status, job.info.Error = "failed", stmt.Error
+			}
+		}
+		job.result = result
+	}
+	info := job.info
+	m.jobs.mu.Unlock()
+
+	m.emit("job:done", info)
+}
+
+// ListJobs returns the background jobs of the session, newest first
+func (m *Manager) ListJobs() []Job {
+	m.jobs.mu.Lock()
+	defer m.jobs.mu.Unlock()
+
+	jobs := make([]Job, 0, len(m.jobs.jobs))
+	for _, job := range m.jobs.jobs {
+		jobs = append(jobs, job.info)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID > jobs[j].ID })
+	return jobs
+}
+
+// GetJobResult returns the results of a finished job. Result sets hold only
+// their first maxJobRows rows; RowCount still tells how many there were.
+func (m *Manager) GetJobResult(id int64) (*SQLResult, error) {
+	m.jobs.mu.Lock()
+	defer m.jobs.mu.Unlock()
+
+	job, ok := m.jobs.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("job %d not found", id)
+	}
+	if job.info.Status == "running" {
+		return nil, fmt.Errorf("job %d is still running", id)
+	}
+	if job.result == nil {
+		return nil, fmt.Errorf("job %d has no results", id)
+	}
+	return job.result, nil
+}
+
+// CancelJob stops a running job. The statement in progress is stopped on the
+// server and the rest of the script is skipped.
+func (m *Manager) CancelJob(id int64) error {
+	m.jobs.mu.Lock()
+	job, ok := m.jobs.jobs[id]
+	running := ok && job.info.Status == "running"
+	if running {
+		job.cancelled = true
+	}
+	m.jobs.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("job %d not found", id)
+	}
+	if !running {
+		return fmt.Errorf("job %d is not running", id)
+	}
+	return m.CancelOperation(job.operation)
+}
+
+// ClearJobs forgets the finished jobs and returns how many were removed
+func (m *Manager) ClearJobs() int {
+	m.jobs.mu.Lock()
+	defer m.jobs.mu.Unlock()
+
+	removed := 0
+	for id, job := range m.jobs.jobs {
+		if job.info.Status != "running" {
+			delete(m.jobs.jobs, id)
+			removed++
+		}
+	}
+	return removed
+}
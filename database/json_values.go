@@ -0,0 +1,116 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSONValidation is the result of checking the text of a JSON document
+type JSONValidation struct {
+	Valid  bool   `json:"valid"`
+	Error  string `json:"error,omitempty"`
+	Line   int    `json:"line,omitempty"`   // 1-based position of the error
+	Column int    `json:"column,omitempty"` // 1-based, in characters
+	Pretty string `json:"pretty,omitempty"` // Indented document when valid
+}
+
+// ValidateJSON checks that text is a single JSON document and pretty-prints it
+func ValidateJSON(text string) *JSONValidation {
+	if _, err := decodeJSON(text); err != nil {
+		result := &JSONValidation{Error: err.Error()}
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			result.Line, result.Column = textPosition(text, int(syntax.Offset))
+		}
+		return result
+	}
+	return &JSONValidation{Valid: true, Pretty: indentJSON(strings.TrimSpace(text))}
+}
+
+// SaveCellValue writes a cell identified by its row's primary key. A JSON
+// document that only differs from the stored one in formatting or key order
+// is not written back, and RowsAffected is 0.
+func (m *Manager) SaveCellValue(database, schema, table string, primaryKey []string, primaryValues []interface{}, column, newValue string) (*ExecuteResult, error) {
+	cell, err := m.GetCellValue(database, schema, table, primaryKey, primaryValues, column)
+	if err != nil {
+		return nil, err
+	}
+	if typeCategory(cell.Type) == typeCategoryJSON && !cell.IsNull && jsonEqual(cell.Value, newValue) {
+		return &ExecuteResult{}, nil
+	}
+	return m.UpdateRow(database, schema, table, primaryKey, primaryValues, RowData{column: newValue})
+}
+
+// decodeJSON parses text, which must hold exactly one JSON value. Numbers
+// are kept as written so that large integers compare exactly.
+func decodeJSON(text string) (interface{}, error) {
+	if !json.Valid([]byte(text)) {
+		// Unmarshal reports where the document is malformed
+		var v interface{}
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid JSON")
+	}
+
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// jsonEqual reports whether two JSON texts hold the same document
+func jsonEqual(a, b string) bool {
+	va, err := decodeJSON(a)
+	if err != nil {
+		return false
+	}
+	vb, err := decodeJSON(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// jsonParam checks a value written to a JSON column. Text must be valid
+// JSON; objects and arrays received from the frontend are encoded.
+func jsonParam(column string, v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if check := ValidateJSON(val); !check.Valid {
+			if check.Line > 0 {
+				return nil, fmt.Errorf("column %s holds invalid JSON at line %d, column %d: %s", column, check.Line, check.Column, check.Error)
+			}
+			return nil, fmt.Errorf("column %s holds invalid JSON: %s", column, check.Error)
+		}
+		return val, nil
+	default:
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode JSON for column %s: %w", column, err)
+		}
+		return string(encoded), nil
+	}
+}
+
+// textPosition converts a byte offset into a 1-based line and column
+func textPosition(text string, offset int) (int, int) {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	before := text[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := len([]rune(before[strings.LastIndex(before, "\n")+1:]))
+	if column == 0 {
+		column = 1
+	}
+	return line, column
+}
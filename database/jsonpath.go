@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPathColumn projects a value nested in a JSON column as an extra,
+// read-only column of the table data, e.g. payload -> status
+type JSONPathColumn struct {
+	Column string   `json:"column"`
+	Path   []string `json:"path"`            // Object keys; array indexes as numbers in text
+	Alias  string   `json:"alias,omitempty"` // Defaults to the column and keys joined by dots
+}
+
+// name returns the column name the projected value is shown under
+func (p JSONPathColumn) name() string {
+	if p.Alias != "" {
+		return p.Alias
+	}
+	return strings.Join(append([]string{p.Column}, p.Path...), ".")
+}
+
+// jsonPathSelection renders projected paths as select list items, to follow
+// SELECT *. Path keys are inlined as literals so that the select list binds
+// no arguments ahead of the WHERE clause.
+func jsonPathSelection(dialect filterDialect, paths []JSONPathColumn) (string, error) {
+	literal := func(v interface{}) string { return dialect.literal(fmt.Sprint(v)) }
+
+	var sb strings.Builder
+	for _, p := range paths {
+		if p.Column == "" || len(p.Path) == 0 {
+			return "", fmt.Errorf("JSON path column needs a column and a path")
+		}
+		fmt.Fprintf(&sb, ", %s AS %s", dialect.jsonPath(dialect.quote(p.Column), p.Path, literal), dialect.quote(p.name()))
+	}
+	return sb.String(), nil
+}
+
+// jsonPathColumns describes projected paths as columns of the result
+func jsonPathColumns(paths []JSONPathColumn) []ColumnInfo {
+	columns := make([]ColumnInfo, len(paths))
+	for i, p := range paths {
+		columns[i] = ColumnInfo{
+			Name:       p.name(),
+			Type:       "text",
+			Nullable:   true,
+			Generated:  "VIRTUAL",
+			Expression: strings.Join(append([]string{p.Column}, p.Path...), " -> "),
+			ReadOnly:   true,
+		}
+	}
+	return columns
+}
+
+// mysqlJSONPath renders path keys as a MySQL JSON path such as $."a"[0]
+func mysqlJSONPath(path []string) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, key := range path {
+		if n, err := strconv.Atoi(key); err == nil && n >= 0 {
+			fmt.Fprintf(&sb, "[%d]", n)
+			continue
+		}
+		sb.WriteString(`."` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key) + `"`)
+	}
+	return sb.String()
+}
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"fmt"
+)
+
+// Ways of identifying rows of tables without a primary key
+const (
+	RowMatchCTID = "ctid" // PostgreSQL physical row location; changes when the row is updated or the table vacuumed full
+	RowMatchRow  = "row"  // Every column matches the loaded values; only the first of identical rows is changed
+)
+
+// RowLocator identifies a row of a table without a primary key
+type RowLocator struct {
+	CTID   string  `json:"ctid,omitempty"`   // PostgreSQL
+	Values RowData `json:"values,omitempty"` // MySQL: the row as it was loaded
+}
+
+// UpdateRowByLocator updates a row of a table without a primary key. A ctid
+// no longer matches once the row has been changed by someone else; a row
+// matched by content is updated only if no column has changed since it was
+// loaded, and only one of several identical rows is updated. A row whose
+// compared columns match other rows too is refused, see uniqueLocatedRow.
+func (m *Manager) UpdateRowByLocator(database, schema, table string, locator RowLocator, data RowData) (*ExecuteResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	query, args, err := m.locatedUpdateStatement(database, schema, table, locator, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.uniqueLocatedRow(db, database, schema, table, locator); err != nil {
+		return nil, err
+	}
+
+	result, err := m.execWrite(db, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("update failed: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("row not found; it may have been changed since it was loaded")
+	}
+	m.forgetUndo(database, schema, table)
+
+	return result, nil
+}
+
+// PreviewUpdateRowByLocator returns the UPDATE that UpdateRowByLocator would run
+func (m *Manager) PreviewUpdateRowByLocator(database, schema, table string, locator RowLocator, data RowData) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	query, args, err := m.locatedUpdateStatement(database, schema, table, locator, data)
+	if err != nil {
+		return nil, err
+	}
+	return m.previewOf(query+m.tableDriver(schema).ReturningClause(), args), nil
+}
+
+// locatedUpdateStatement builds the UPDATE of a located row and its arguments
+func (m *Manager) locatedUpdateStatement(database, schema, table string, locator RowLocator, data RowData) (string, []interface{}, error) {
+	data, err := m.writableData(database, schema, table, data)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(data) == 0 {
+		return "", nil, fmt.Errorf("no data provided")
+	}
+
+	locator, _, err = m.stableLocator(database, schema, table, locator)
+	if err != nil {
+		return "", nil, err
+	}
+	columns, values := rowColumns(data)
+	query, args, err := m.tableDriver(schema).BuildLocatedUpdateQuery(database, table, columns, locator)
+	if err != nil {
+		return "", nil, err
+	}
+	return query, append(values, args...), nil
+}
+
+// DeleteRowByLocator deletes a row of a table without a primary key, with
+// the same caveats as UpdateRowByLocator
+func (m *Manager) DeleteRowByLocator(database, schema, table string, locator RowLocator) (*ExecuteResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	if err := m.uniqueLocatedRow(db, database, schema, table, locator); err != nil {
+		return nil, err
+	}
+	locator, _, err := m.stableLocator(database, schema, table, locator)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := m.tableDriver(schema).BuildLocatedDeleteQuery(database, table, locator)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := db.Exec(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("delete failed: %w", err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("row not found; it may have been changed since it was loaded")
+	}
+	m.forgetUndo(database, schema, table)
+
+	return &ExecuteResult{
+		RowsAffected: rowsAffected,
+	}, nil
+}
+
+// PreviewDeleteRowByLocator returns the DELETE that DeleteRowByLocator would run
+func (m *Manager) PreviewDeleteRowByLocator(database, schema, table string, locator RowLocator) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	locator, _, err := m.stableLocator(database, schema, table, locator)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := m.tableDriver(schema).BuildLocatedDeleteQuery(database, table, locator)
+	if err != nil {
+		return nil, err
+	}
+	return m.previewOf(query, args), nil
+}
+
+// stableLocator drops the values of columns that do not read back exactly as
+// written, such as floats, JSON, blobs and generated columns, from a row
+// matched by content; comparing them would miss the row. It also reports
+// whether every column of the table is still compared.
+func (m *Manager) stableLocator(database, schema, table string, locator RowLocator) (RowLocator, bool, error) {
+	if len(locator.Values) == 0 {
+		return locator, false, nil
+	}
+	columns, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return locator, false, err
+	}
+	values := make(RowData, len(locator.Values))
+	for _, col := range columns {
+		if v, ok := locator.Values[col.Name]; ok && comparableColumn(col) {
+			values[col.Name] = v
+		}
+	}
+	if len(values) == 0 {
+		return locator, false, fmt.Errorf("table %s has no columns that can identify a row", table)
+	}
+	locator.Values = values
+	return locator, len(values) == len(columns), nil
+}
+
+// uniqueLocatedRow refuses to write a row matched by content when the
+// columns left to compare also match other rows. LIMIT 1 would then change
+// whichever of them comes first, which may not be the row that was loaded.
+// Rows equal in every column are interchangeable, so they are let through.
+func (m *Manager) uniqueLocatedRow(db *instrumentedDB, database, schema, table string, locator RowLocator) error {
+	locator, all, err := m.stableLocator(database, schema, table, locator)
+	if err != nil || all || len(locator.Values) == 0 {
+		return err
+	}
+
+	filters := make([]FilterCondition, 0, len(locator.Values))
+	for col, v := range locator.Values {
+		if v == nil {
+			filters = append(filters, FilterCondition{Column: col, Operator: "IS NULL"})
+		} else {
+			filters = append(filters, FilterCondition{Column: col, Operator: "=", Value: paramValue(v)})
+		}
+	}
+	query, args, err := m.tableDriver(schema).BuildCountQuery(database, table, filters, 2)
+	if err != nil {
+		return err
+	}
+	var matching int64
+	if err := db.QueryRow(query, args...).Scan(&matching); err != nil {
+		return fmt.Errorf("failed to count matching rows: %w", err)
+	}
+	if matching > 1 {
+		return fmt.Errorf("the row can't be told apart from other rows by the columns that can be compared; add a primary key to edit it")
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pagination modes of TableDataRequest
+const (
+	PaginationOffset = "offset" // LIMIT/OFFSET; the default
+	PaginationKeyset = "keyset" // Rows after a cursor; stays fast deep into huge tables
+)
+
+// tableDataOrder returns the sort order of a page of table data: the
+// requested columns, or the primary key. In keyset mode the primary key is
+// appended so that the order is total and the cursor of a row identifies it;
+// it sorts in the direction of the last requested column.
+func tableDataOrder(req TableDataRequest, primaryKey []string) []SortColumn {
+	order := make([]SortColumn, 0, len(req.OrderBy)+len(primaryKey))
+	seen := make(map[string]bool, len(req.OrderBy))
+	for _, s := range req.OrderBy {
+		if s.Column == "" || seen[s.Column] {
+			continue
+		}
+		seen[s.Column] = true
+		order = append(order, s)
+	}
+	if len(order) > 0 && req.Pagination != PaginationKeyset {
+		return order
+	}
+
+	direction := "ASC"
+	if len(order) > 0 && order[len(order)-1].desc() {
+		direction = "DESC"
+	}
+	for _, key := range primaryKey {
+		if !seen[key] {
+			order = append(order, SortColumn{Column: key, Direction: direction})
+		}
+	}
+	return order
+}
+
+// orderClause renders a sort order as an ORDER BY clause
+func orderClause(quote func(string) string, order []SortColumn) string {
+	if len(order) == 0 {
+		return ""
+	}
+	terms := make([]string, len(order))
+	for i, s := range order {
+		dir := "ASC"
+		if s.desc() {
+			dir = "DESC"
+		}
+		terms[i] = quote(s.Column) + " " + dir
+	}
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
+
+// keysetCondition selects the rows that come after the cursor in the given
+// order. A uniform direction compiles to a row value comparison; mixed
+// directions expand to (a > ?) OR (a = ? AND b < ?) and so on. Rows whose
+// sort columns are NULL are never after a cursor, so keyset pagination suits
+// non-nullable sort columns.
+func keysetCondition(dialect filterDialect, order []SortColumn, after []interface{}, args *[]interface{}) string {
+	bind := func(v interface{}) string {
+		*args = append(*args, v)
+		return dialect.placeholder(len(*args))
+	}
+	op := func(s SortColumn) string {
+		if s.desc() {
+			return "<"
+		}
+		return ">"
+	}
+
+	uniform := true
+	for _, s := range order[1:] {
+		uniform = uniform && s.desc() == order[0].desc()
+	}
+	if uniform {
+		quoted := make([]string, len(order))
+		placeholders := make([]string, len(order))
+		for i, s := range order {
+			quoted[i] = dialect.quote(s.Column)
+			placeholders[i] = bind(after[i])
+		}
+		if len(order) == 1 {
+			return fmt.Sprintf("%s %s %s", quoted[0], op(order[0]), placeholders[0])
+		}
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(quoted, ", "), op(order[0]), strings.Join(placeholders, ", "))
+	}
+
+	branches := make([]string, len(order))
+	for i, s := range order {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("%s = %s", dialect.quote(order[j].Column), bind(after[j])))
+		}
+		terms = append(terms, fmt.Sprintf("%s %s %s", dialect.quote(s.Column), op(s), bind(after[i])))
+		branches[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return "(" + strings.Join(branches, " OR ") + ")"
+}
+
+// addKeysetCondition narrows a WHERE clause built by compileFilters to the
+// rows after req.After
+func addKeysetCondition(dialect filterDialect, where string, args []interface{}, req TableDataRequest, order []SortColumn) (string, []interface{}, error) {
+	if req.Pagination != PaginationKeyset || len(req.After) == 0 {
+		return where, args, nil
+	}
+	if len(req.After) != len(order) {
+		return "", nil, fmt.Errorf("cursor has %d values, expected %d", len(req.After), len(order))
+	}
+
+	cond := keysetCondition(dialect, order, paramValues(req.After), &args)
+	if where == "" {
+		where = " WHERE " + cond
+	} else {
+		where = fmt.Sprintf(" WHERE (%s) AND %s", strings.TrimPrefix(where, " WHERE "), cond)
+	}
+	return where, args, nil
+}
+
+// nextCursor returns the cursor of the last row of a page, or nil when the
+// page is the last one
+func nextCursor(columns []ColumnInfo, rows [][]interface{}, order []SortColumn, pageSize int) []interface{} {
+	if len(rows) < pageSize || len(rows) == 0 {
+		return nil
+	}
+
+	last := rows[len(rows)-1]
+	cursor := make([]interface{}, len(order))
+	for i, s := range order {
+		idx := -1
+		for j, col := range columns {
+			if col.Name == s.Column {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 || idx >= len(last) {
+			return nil
+		}
+		cursor[i] = last[idx]
+	}
+	return cursor
+}
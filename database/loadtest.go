@@ -0,0 +1,390 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Load test limits and sampling
+const (
+	maxLoadTestSessions = 100
+	maxLoadTestDuration = time.Hour
+	loadTestSampleSize  = 10000 // Latencies kept per query for percentiles
+)
+
+// LoadTestQuery is a statement of the workload and its relative frequency
+type LoadTestQuery struct {
+	SQL    string `json:"sql"`
+	Weight int    `json:"weight"` // Defaults to 1
+}
+
+// LoadTestOptions describes a synthetic workload
+type LoadTestOptions struct {
+	Queries     []LoadTestQuery `json:"queries"`
+	Sessions    int             `json:"sessions"`    // Concurrent connections
+	DurationSec int             `json:"durationSec"` // How long to run
+	ThinkTimeMs int             `json:"thinkTimeMs"` // Pause between statements of a session
+}
+
+// LoadTestSnapshot is emitted every second while a load test runs. Rates and
+// latencies cover the last interval; counters are cumulative.
+type LoadTestSnapshot struct {
+	ElapsedSec float64 `json:"elapsedSec"`
+	Queries    int64   `json:"queries"`
+	Errors     int64   `json:"errors"`
+	QPS        float64 `json:"qps"`
+	P50Ms      float64 `json:"p50Ms"`
+	P95Ms      float64 `json:"p95Ms"`
+	MaxMs      float64 `json:"maxMs"`
+	LastError  string  `json:"lastError,omitempty"`
+}
+
+// LoadTestQueryStats summarizes one statement of the workload
+type LoadTestQueryStats struct {
+	SQL    string  `json:"sql"`
+	Count  int64   `json:"count"`
+	Errors int64   `json:"errors"`
+	MeanMs float64 `json:"meanMs"`
+	P50Ms  float64 `json:"p50Ms"`
+	P95Ms  float64 `json:"p95Ms"`
+	MaxMs  float64 `json:"maxMs"`
+}
+
+// LoadTestStatus reports the state of the current or last load test
+type LoadTestStatus struct {
+	Running    bool                 `json:"running"`
+	ElapsedSec float64              `json:"elapsedSec"`
+	Queries    int64                `json:"queries"`
+	Errors     int64                `json:"errors"`
+	QPS        float64              `json:"qps"` // Average over the whole run
+	PerQuery   []LoadTestQueryStats `json:"perQuery"`
+	History    []LoadTestSnapshot   `json:"history"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// loadTestQueryState accumulates the measurements of one statement
+type loadTestQueryState struct {
+	count, errors int64
+	sum, max      float64
+	seen          int64
+	samples       []float64 // Reservoir sample of latencies
+}
+
+// loadTest is a running or finished workload
+type loadTest struct {
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	done     chan struct{}
+	start    time.Time
+	end      time.Time
+	running  bool
+	err      string
+	queries  []LoadTestQuery
+	stats    []loadTestQueryState
+	interval []float64 // Latencies since the last snapshot
+	errors   int64
+	lastErr  string
+	history  []LoadTestSnapshot
+	rnd      *rand.Rand
+}
+
+// StartLoadTest runs a workload in the background on a dedicated connection
+// pool. Progress is emitted as "loadtest:progress" events and the final status
+// as "loadtest:done".
+func (m *Manager) StartLoadTest(opts LoadTestOptions) error {
+	m.mu.RLock()
+	config := m.config
+	driver := m.driver
+	m.mu.RUnlock()
+	if config == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	if len(opts.Queries) == 0 {
+		return fmt.Errorf("no queries provided")
+	}
+	if opts.Sessions <= 0 {
+		opts.Sessions = 1
+	}
+	if opts.Sessions > maxLoadTestSessions {
+		return fmt.Errorf("at most %d sessions are allowed", maxLoadTestSessions)
+	}
+	duration := time.Duration(opts.DurationSec) * time.Second
+	if duration <= 0 {
+		duration = 10 * time.Second
+	}
+	if duration > maxLoadTestDuration {
+		return fmt.Errorf("load tests can run for at most %s", maxLoadTestDuration)
+	}
+
+	if lt := m.currentLoadTest(); lt != nil && lt.isRunning() {
+		return fmt.Errorf("a load test is already running")
+	}
+
+	// A separate pool keeps the workload from starving the UI's connections
+	pool, err := driver.Connect(*config)
+	if err != nil {
+		return sanitizeError(err, config)
+	}
+	pool.SetMaxOpenConns(opts.Sessions)
+	pool.SetMaxIdleConns(opts.Sessions)
+	db := newInstrumentedDB(pool, m.interceptors)
+
+	opCtx, done := m.track("loadtest", fmt.Sprintf("%d sessions, %s", opts.Sessions, duration))
+	ctx, cancel := context.WithTimeout(opCtx, duration)
+	lt := &loadTest{
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		start:   time.Now(),
+		running: true,
+		queries: opts.Queries,
+		stats:   make([]loadTestQueryState, len(opts.Queries)),
+		history: []LoadTestSnapshot{},
+		rnd:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	m.mu.Lock()
+	m.loadTest = lt
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Sessions; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			lt.runSession(ctx, db, opts, rand.New(rand.NewSource(seed)))
+		}(time.Now().UnixNano() + int64(i))
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.emit("loadtest:progress", lt.snapshot())
+			case <-ctx.Done():
+				wg.Wait()
+				pool.Close()
+				lt.finish()
+				m.emit("loadtest:done", lt.status())
+				close(lt.done)
+				done()
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// StopLoadTest cancels the running load test and waits for its sessions to finish
+func (m *Manager) StopLoadTest() error {
+	lt := m.currentLoadTest()
+	if lt == nil || !lt.isRunning() {
+		return fmt.Errorf("no load test is running")
+	}
+	lt.cancel()
+	<-lt.done
+	return nil
+}
+
+// GetLoadTestStatus returns the progress of the current or last load test
+func (m *Manager) GetLoadTestStatus() (*LoadTestStatus, error) {
+	lt := m.currentLoadTest()
+	if lt == nil {
+		return nil, fmt.Errorf("no load test has been run")
+	}
+	return lt.status(), nil
+}
+
+func (m *Manager) currentLoadTest() *loadTest {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.loadTest
+}
+
+// runSession executes randomly picked statements on one connection until ctx ends
+func (lt *loadTest) runSession(ctx context.Context, db *instrumentedDB, opts LoadTestOptions, rnd *rand.Rand) {
+	conn, err := db.session(ctx)
+	if err != nil {
+		lt.recordError(-1, err)
+		return
+	}
+	defer conn.Close()
+
+	totalWeight := 0
+	for _, q := range opts.Queries {
+		totalWeight += max(q.Weight, 1)
+	}
+
+	for ctx.Err() == nil {
+		pick := rnd.Intn(totalWeight)
+		idx := 0
+		for i, q := range opts.Queries {
+			pick -= max(q.Weight, 1)
+			if pick < 0 {
+				idx = i
+				break
+			}
+		}
+
+		start := time.Now()
+		rows, err := conn.Query(opts.Queries[idx].SQL)
+		if err == nil {
+			for rows.Next() {
+			}
+			err = rows.Err()
+			rows.Close()
+		}
+		// Statements interrupted by the end of the run are not failures
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			lt.recordError(idx, err)
+		} else {
+			lt.record(idx, durationMs(time.Since(start)))
+		}
+
+		if opts.ThinkTimeMs > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Duration(opts.ThinkTimeMs) * time.Millisecond):
+			}
+		}
+	}
+}
+
+func (lt *loadTest) record(idx int, latency float64) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	s := &lt.stats[idx]
+	s.count++
+	s.sum += latency
+	s.max = max(s.max, latency)
+	s.seen++
+	if len(s.samples) < loadTestSampleSize {
+		s.samples = append(s.samples, latency)
+	} else if j := lt.rnd.Int63n(s.seen); j < loadTestSampleSize {
+		s.samples[j] = latency
+	}
+	lt.interval = append(lt.interval, latency)
+}
+
+// recordError counts a failed statement; idx is -1 when no statement was run
+func (lt *loadTest) recordError(idx int, err error) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	if idx >= 0 {
+		lt.stats[idx].errors++
+	}
+	lt.errors++
+	lt.lastErr = err.Error()
+}
+
+// snapshot closes the current interval and appends it to the history
+func (lt *loadTest) snapshot() LoadTestSnapshot {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	var elapsed float64
+	if n := len(lt.history); n > 0 {
+		elapsed = lt.history[n-1].ElapsedSec
+	}
+	now := time.Since(lt.start).Seconds()
+
+	sorted := append([]float64(nil), lt.interval...)
+	sort.Float64s(sorted)
+	lt.interval = lt.interval[:0]
+
+	snap := LoadTestSnapshot{
+		ElapsedSec: now,
+		Queries:    lt.totalQueries(),
+		Errors:     lt.errors,
+		P50Ms:      percentile(sorted, 50),
+		P95Ms:      percentile(sorted, 95),
+		LastError:  lt.lastErr,
+	}
+	if len(sorted) > 0 {
+		snap.MaxMs = sorted[len(sorted)-1]
+	}
+	if now > elapsed {
+		snap.QPS = float64(len(sorted)) / (now - elapsed)
+	}
+	lt.history = append(lt.history, snap)
+	return snap
+}
+
+// totalQueries returns the number of successful statements; callers hold mu
+func (lt *loadTest) totalQueries() int64 {
+	var total int64
+	for _, s := range lt.stats {
+		total += s.count
+	}
+	return total
+}
+
+func (lt *loadTest) isRunning() bool {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	return lt.running
+}
+
+func (lt *loadTest) finish() {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	lt.running = false
+	lt.end = time.Now()
+	if lt.errors > 0 && lt.totalQueries() == 0 {
+		lt.err = lt.lastErr
+	}
+}
+
+func (lt *loadTest) status() *LoadTestStatus {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	end := lt.end
+	if lt.running {
+		end = time.Now()
+	}
+	elapsed := end.Sub(lt.start).Seconds()
+
+	status := &LoadTestStatus{
+		Running:    lt.running,
+		ElapsedSec: elapsed,
+		Queries:    lt.totalQueries(),
+		Errors:     lt.errors,
+		PerQuery:   make([]LoadTestQueryStats, len(lt.stats)),
+		History:    append([]LoadTestSnapshot(nil), lt.history...),
+		Error:      lt.err,
+	}
+	if elapsed > 0 {
+		status.QPS = float64(status.Queries) / elapsed
+	}
+
+	for i, s := range lt.stats {
+		sorted := append([]float64(nil), s.samples...)
+		sort.Float64s(sorted)
+		qs := LoadTestQueryStats{
+			SQL:    lt.queries[i].SQL,
+			Count:  s.count,
+			Errors: s.errors,
+			P50Ms:  percentile(sorted, 50),
+			P95Ms:  percentile(sorted, 95),
+			MaxMs:  s.max,
+		}
+		if s.count > 0 {
+			qs.MeanMs = s.sum / float64(s.count)
+		}
+		status.PerQuery[i] = qs
+	}
+
+	return status
+}
@@ -0,0 +1,300 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrLocked is returned by operations refused while the app is locked
+var ErrLocked = errors.New("the application is locked")
+
+// errNoLockStorage is returned when there is no data directory to keep the
+// lock settings in. Locking is disabled then, as no passphrase can be stored.
+var errNoLockStorage = errors.New("lock settings cannot be stored: the application data directory is unavailable")
+
+// lockFile stores the lock passphrase hash and idle timeout
+const lockFile = "lock.json"
+
+// idleCheckInterval is how often the idle timeout is evaluated
+const idleCheckInterval = 15 * time.Second
+
+// LockStatus reports the lock state and its settings
+type LockStatus struct {
+	Locked         bool `json:"locked"`
+	HasPassphrase  bool `json:"hasPassphrase"`  // Locking requires a passphrase to be set
+	IdleTimeoutMin int  `json:"idleTimeoutMin"` // 0 disables locking when idle
+
+	// Damaged reports why the lock settings could not be read. The app stays
+	// locked until they are reset.
+	Damaged string `json:"damaged,omitempty"`
+}
+
+type lockSettings struct {
+	Salt           string `json:"salt"` // Base64
+	Hash           string `json:"hash"` // Base64 scrypt key of the passphrase
+	IdleTimeoutMin int    `json:"idleTimeoutMin"`
+}
+
+// Locker guards the app on shared workstations. Locking runs onLock, which is
+// expected to drop connections and any credentials they hold, and unlocking
+// requires the lock passphrase.
+type Locker struct {
+	mu           sync.Mutex
+	ctx          context.Context
+	storage      *Storage
+	onLock       func()
+	locked       bool
+	lastActivity time.Time
+}
+
+// NewLocker creates a locker, locked when a passphrase is set so restarting
+// the app doesn't bypass the lock. Unreadable settings lock it as well, as
+// they may hold a passphrase. Without storage it starts unlocked with locking
+// disabled.
+func NewLocker(storage *Storage, onLock func()) *Locker {
+	l := &Locker{storage: storage, onLock: onLock, lastActivity: time.Now()}
+	settings, err := l.settings()
+	switch {
+	case errors.Is(err, errNoLockStorage):
+		// No passphrase can be set, so there is nothing to unlock with
+	case err != nil || settings.Hash != "":
+		l.locked = true
+	}
+	return l
+}
+
+// SetContext sets the application context and starts watching for idleness
+func (l *Locker) SetContext(ctx context.Context) {
+	l.ctx = ctx
+	go l.watchIdle(ctx)
+}
+
+// Status returns the lock state and settings
+func (l *Locker) Status() (*LockStatus, error) {
+	settings, err := l.settings()
+	if errors.Is(err, errNoLockStorage) {
+		return &LockStatus{Locked: l.IsLocked()}, nil
+	}
+	if err != nil {
+		return &LockStatus{Locked: l.IsLocked(), HasPassphrase: true, Damaged: err.Error()}, nil
+	}
+	return &LockStatus{
+		Locked:         l.IsLocked(),
+		HasPassphrase:  settings.Hash != "",
+		IdleTimeoutMin: settings.IdleTimeoutMin,
+	}, nil
+}
+
+// IsLocked reports whether the app is locked
+func (l *Locker) IsLocked() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.locked
+}
+
+// Touch records user activity, postponing the idle lock
+func (l *Locker) Touch() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastActivity = time.Now()
+}
+
+// Lock locks the app. A passphrase must be set so it can be unlocked again.
+func (l *Locker) Lock() error {
+	settings, err := l.settings()
+	if err != nil {
+		return err
+	}
+	if settings.Hash == "" {
+		return fmt.Errorf("set a lock passphrase before locking")
+	}
+
+	l.mu.Lock()
+	if l.locked {
+		l.mu.Unlock()
+		return nil
+	}
+	l.locked = true
+	l.mu.Unlock()
+
+	if l.onLock != nil {
+		l.onLock()
+	}
+	l.emit("app:locked")
+	return nil
+}
+
+// Unlock unlocks the app if passphrase matches the lock passphrase
+func (l *Locker) Unlock(passphrase string) error {
+	settings, err := l.settings()
+	if err != nil {
+		return err
+	}
+	if err := settings.verify(passphrase); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.locked = false
+	l.lastActivity = time.Now()
+	l.mu.Unlock()
+
+	l.emit("app:unlocked")
+	return nil
+}
+
+// Reset sets damaged lock settings aside and unlocks the app, returning where
+// the damaged file was moved. It is refused while the settings are readable,
+// so it cannot lift a working lock.
+func (l *Locker) Reset() (string, error) {
+	if _, err := l.settings(); err == nil {
+		return "", fmt.Errorf("the lock settings are not damaged")
+	} else if errors.Is(err, errNoLockStorage) {
+		return "", err
+	}
+	backup, err := l.storage.quarantine(lockFile)
+	if err != nil {
+		return "", err
+	}
+
+	l.mu.Lock()
+	l.locked = false
+	l.lastActivity = time.Now()
+	l.mu.Unlock()
+
+	l.emit("app:unlocked")
+	return backup, nil
+}
+
+// SetPassphrase changes the lock passphrase. current must match the existing
+// passphrase, if any; an empty passphrase disables locking.
+func (l *Locker) SetPassphrase(current, passphrase string) error {
+	if l.IsLocked() {
+		return ErrLocked
+	}
+
+	settings, err := l.settings()
+	if err != nil {
+		return err
+	}
+	if settings.Hash != "" {
+		if err := settings.verify(current); err != nil {
+			return err
+		}
+	}
+
+	if passphrase == "" {
+		settings.Salt, settings.Hash = "", ""
+		return l.storage.writeJSON(lockFile, settings)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key, err := lockKey(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	settings.Salt = base64.StdEncoding.EncodeToString(salt)
+	settings.Hash = base64.StdEncoding.EncodeToString(key)
+	return l.storage.writeJSON(lockFile, settings)
+}
+
+// SetIdleTimeout locks the app after minutes without activity; 0 disables it
+func (l *Locker) SetIdleTimeout(minutes int) error {
+	if l.IsLocked() {
+		return ErrLocked
+	}
+	if minutes < 0 {
+		return fmt.Errorf("invalid idle timeout: %d", minutes)
+	}
+
+	settings, err := l.settings()
+	if err != nil {
+		return err
+	}
+	settings.IdleTimeoutMin = minutes
+	return l.storage.writeJSON(lockFile, settings)
+}
+
+func (l *Locker) settings() (*lockSettings, error) {
+	if l.storage == nil {
+		return nil, errNoLockStorage
+	}
+	settings := &lockSettings{}
+	if err := l.storage.readJSON(lockFile, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (l *Locker) watchIdle(ctx context.Context) {
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		settings, err := l.settings()
+		if err != nil || settings.Hash == "" || settings.IdleTimeoutMin <= 0 {
+			continue
+		}
+
+		l.mu.Lock()
+		idle := !l.locked && time.Since(l.lastActivity) >= time.Duration(settings.IdleTimeoutMin)*time.Minute
+		l.mu.Unlock()
+		if idle {
+			l.Lock()
+		}
+	}
+}
+
+func (l *Locker) emit(event string) {
+	if l.ctx != nil {
+		runtime.EventsEmit(l.ctx, event)
+	}
+}
+
+// verify checks passphrase against the stored hash in constant time
+func (s *lockSettings) verify(passphrase string) error {
+	salt, err := base64.StdEncoding.DecodeString(s.Salt)
+	if err != nil {
+		return fmt.Errorf("failed to decode lock salt: %w", err)
+	}
+	want, err := base64.StdEncoding.DecodeString(s.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to decode lock hash: %w", err)
+	}
+
+	key, err := lockKey(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(key, want) != 1 {
+		return fmt.Errorf("incorrect passphrase")
+	}
+	return nil
+}
+
+// lockKey derives the stored key of a passphrase
+func lockKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return key, nil
+}
@@ -10,16 +10,20 @@ type TableAlteration struct {
 	ModifyColumns []ColumnInfo `json:"modifyColumns"`
 	DropColumns   []string     `json:"dropColumns"`
 	RenameTo      string       `json:"renameTo"`
+
+	// CHECK and UNIQUE constraints; dropped ones need their name and type
+	AddConstraints  []ConstraintInfo `json:"addConstraints,omitempty"`
+	DropConstraints []ConstraintInfo `json:"dropConstraints,omitempty"`
 }
 
 // AlterTable performs schema modifications on a table
-func (m *Manager) AlterTable(database, table string, alteration TableAlteration) error {
+func (m *Manager) AlterTable(database, schema, table string, alteration TableAlteration) error {
 	db := m.getDB()
 	if db == nil {
 		return fmt.Errorf("not connected to database")
 	}
 
-	queries, err := m.driver.BuildAlterTableQuery(database, table, alteration)
+	queries, err := m.alterTableStatements(database, schema, table, alteration)
 	if err != nil {
 		return err
 	}
@@ -30,6 +34,44 @@ func (m *Manager) AlterTable(database, table string, alteration TableAlteration)
 			return fmt.Errorf("failed to execute alter query [%s]: %w", query, err)
 		}
 	}
+	m.forgetUndo(database, schema, table)
 
 	return nil
 }
+
+// PreviewAlterTable returns the statements AlterTable would run, in order
+func (m *Manager) PreviewAlterTable(database, schema, table string, alteration TableAlteration) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	queries, err := m.alterTableStatements(database, schema, table, alteration)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &SQLPreview{Statements: make([]PreviewStatement, len(queries))}
+	for i, query := range queries {
+		preview.Statements[i] = m.previewStatement(query, nil)
+	}
+	return preview, nil
+}
+
+// alterTableStatements validates an alteration and builds its statements
+func (m *Manager) alterTableStatements(database, schema, table string, alteration TableAlteration) ([]string, error) {
+	names := []string{alteration.RenameTo}
+	for _, col := range alteration.AddColumns {
+		names = append(names, col.Name)
+	}
+	for _, col := range alteration.ModifyColumns {
+		names = append(names, col.Name)
+	}
+	for _, c := range alteration.AddConstraints {
+		names = append(names, c.Name)
+	}
+	if err := m.validateNames(names...); err != nil {
+		return nil, err
+	}
+
+	return m.tableDriver(schema).BuildAlterTableQuery(database, table, alteration)
+}
@@ -2,13 +2,22 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
+// mysqlErrUnknownTable is ER_UNKNOWN_TABLE, returned for missing information_schema views
+const mysqlErrUnknownTable = 1109
+
 type MySQLDriver struct{}
 
 func (d *MySQLDriver) Connect(config ConnectionConfig) (*sql.DB, error) {
@@ -34,7 +43,9 @@ func (d *MySQLDriver) Connect(config ConnectionConfig) (*sql.DB, error) {
 
 // buildDSN constructs the MySQL DSN with SSL support
 func (d *MySQLDriver) buildDSN(config ConnectionConfig) string {
-	params := []string{"parseTime=true"}
+	// clientFoundRows reports rows matched rather than changed, so an update
+	// writing the current values isn't mistaken for a missing row
+	params := []string{"parseTime=true", "clientFoundRows=true"}
 
 	// SSL/TLS configuration
 	if config.UseSSL {
@@ -55,7 +66,67 @@ func (d *MySQLDriver) buildDSN(config ConnectionConfig) string {
 	return dsn
 }
 
-func (d *MySQLDriver) GetDatabases(db *sql.DB) ([]string, error) {
+func (d *MySQLDriver) ConnectionStrings(config ConnectionConfig) ConnectionStrings {
+	config = maskedConfig(config)
+	host := net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+
+	cli := fmt.Sprintf("mysql -h %s -P %d -u %s -p", shellQuote(config.Host), config.Port, shellQuote(config.User))
+	jdbcParams := url.Values{"user": {config.User}, "password": {config.Password}}
+	uri := &url.URL{Scheme: "mysql", User: url.UserPassword(config.User, config.Password), Host: host, Path: "/" + config.Database}
+	if config.UseSSL && config.SSLMode != "" && config.SSLMode != "disable" {
+		mode := map[string]string{"require": "REQUIRED", "verify-ca": "VERIFY_CA", "verify-full": "VERIFY_IDENTITY"}[config.SSLMode]
+		cli += " --ssl-mode=" + mode
+		jdbcParams.Set("sslMode", mode)
+		uri.RawQuery = "ssl-mode=" + mode
+	}
+	if config.Database != "" {
+		cli += " " + shellQuote(config.Database)
+	}
+
+	return ConnectionStrings{
+		URI:  plainMask(uri.String()),
+		CLI:  cli,
+		JDBC: plainMask(fmt.Sprintf("jdbc:mysql://%s/%s?%s", host, url.PathEscape(config.Database), jdbcParams.Encode())),
+		Go:   fmt.Sprintf("db, err := sql.Open(\"mysql\", %q) // github.com/go-sql-driver/mysql", d.buildDSN(config)),
+	}
+}
+
+func (d *MySQLDriver) GetDiagnostics(db Querier, diag *ConnectionDiagnostics) {
+	var schema sql.NullString
+	if err := db.QueryRow("SELECT VERSION(), CURRENT_USER(), DATABASE()").Scan(&diag.ServerVersion, &diag.CurrentUser, &schema); err != nil {
+		diag.Warnings = append(diag.Warnings, fmt.Sprintf("server info: %v", err))
+	}
+	diag.DefaultSchema = schema.String
+
+	var name, cipher, version string
+	if err := db.QueryRow("SHOW SESSION STATUS LIKE 'Ssl_cipher'").Scan(&name, &cipher); err != nil {
+		diag.Warnings = append(diag.Warnings, fmt.Sprintf("TLS status: %v", err))
+	}
+	diag.TLS = cipher != ""
+	if diag.TLS {
+		if err := db.QueryRow("SHOW SESSION STATUS LIKE 'Ssl_version'").Scan(&name, &version); err == nil {
+			diag.TLSVersion = version
+		}
+	}
+
+	rows, err := db.Query("SHOW GRANTS")
+	if err != nil {
+		diag.Warnings = append(diag.Warnings, fmt.Sprintf("permissions: %v", err))
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var grant string
+		if err := rows.Scan(&grant); err != nil {
+			diag.Warnings = append(diag.Warnings, fmt.Sprintf("permissions: %v", err))
+			return
+		}
+		diag.Permissions = append(diag.Permissions, grant)
+	}
+}
+
+func (d *MySQLDriver) GetDatabases(db Querier) ([]string, error) {
 	rows, err := db.Query("SHOW DATABASES")
 	if err != nil {
 		return nil, err
@@ -73,8 +144,8 @@ func (d *MySQLDriver) GetDatabases(db *sql.DB) ([]string, error) {
 	return databases, nil
 }
 
-func (d *MySQLDriver) GetTables(db *sql.DB, database string) ([]TableInfo, error) {
-	query := fmt.Sprintf("SHOW TABLE STATUS FROM `%s`", database)
+func (d *MySQLDriver) GetTables(db Querier, database string) ([]TableInfo, error) {
+	query := fmt.Sprintf("SHOW TABLE STATUS FROM %s", d.QuoteIdentifier(database))
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
@@ -121,6 +192,8 @@ func (d *MySQLDriver) GetTables(db *sql.DB, database string) ([]TableInfo, error
 				if t, ok := val.(time.Time); ok {
 					table.CreateTime = t.Format("2006-01-02 15:04:05")
 				}
+			case "Comment":
+				table.Comment = string(val.([]uint8))
 			}
 		}
 		tables = append(tables, table)
@@ -128,8 +201,39 @@ func (d *MySQLDriver) GetTables(db *sql.DB, database string) ([]TableInfo, error
 	return tables, nil
 }
 
-func (d *MySQLDriver) GetColumns(db *sql.DB, database, table string) ([]ColumnInfo, error) {
-	query := fmt.Sprintf("SHOW FULL COLUMNS FROM `%s`.`%s`", database, table)
+func (d *MySQLDriver) GetViews(db Querier, database string) ([]ViewInfo, error) {
+	query := `
+		SELECT TABLE_NAME, VIEW_DEFINITION
+		FROM information_schema.VIEWS
+		WHERE TABLE_SCHEMA = ?
+		ORDER BY TABLE_NAME
+	`
+	rows, err := db.Query(query, database)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []ViewInfo{}
+	for rows.Next() {
+		var v ViewInfo
+		var definition sql.NullString
+		if err := rows.Scan(&v.Name, &definition); err != nil {
+			return nil, err
+		}
+		v.Definition = definition.String
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
+// MySQL has no materialized views
+func (d *MySQLDriver) GetMaterializedViews(db Querier, database string) ([]MaterializedViewInfo, error) {
+	return []MaterializedViewInfo{}, nil
+}
+
+func (d *MySQLDriver) GetColumns(db Querier, database, table string) ([]ColumnInfo, error) {
+	query := fmt.Sprintf("SHOW FULL COLUMNS FROM %s", d.QualifiedName(database, table))
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
@@ -143,6 +247,16 @@ func (d *MySQLDriver) GetColumns(db *sql.DB, database, table string) ([]ColumnIn
 			return nil, err
 		}
 
+		// Extra reads VIRTUAL GENERATED or STORED GENERATED for computed columns;
+		// DEFAULT_GENERATED only marks an expression default
+		var generated string
+		extraUpper := strings.ToUpper(extra.String)
+		if strings.Contains(extraUpper, "VIRTUAL GENERATED") {
+			generated = "VIRTUAL"
+		} else if strings.Contains(extraUpper, "STORED GENERATED") {
+			generated = "STORED"
+		}
+
 		columns = append(columns, ColumnInfo{
 			Name:     field.String,
 			Type:     typeStr.String,
@@ -150,13 +264,133 @@ func (d *MySQLDriver) GetColumns(db *sql.DB, database, table string) ([]ColumnIn
 			Key:      key.String,
 			Default:  defaultVal.String,
 			Extra:    extra.String,
+			Comment:  comment.String,
+
+			DefaultExpression: strings.Contains(extraUpper, "DEFAULT_GENERATED"),
+
+			Charset:   mysqlCollationCharset(collation.String),
+			Collation: collation.String,
+
+			EnumValues: mysqlEnumValues(typeStr.String),
+			Generated:  generated,
+			ReadOnly:   generated != "",
 		})
 	}
 	return columns, nil
 }
 
-func (d *MySQLDriver) GetIndexes(db *sql.DB, database, table string) ([]IndexInfo, error) {
-	query := fmt.Sprintf("SHOW INDEX FROM `%s`.`%s`", database, table)
+// mysqlCollationCharset derives the character set from a collation name, e.g.
+// utf8mb4 from utf8mb4_0900_ai_ci
+func mysqlCollationCharset(collation string) string {
+	if idx := strings.Index(collation, "_"); idx > 0 {
+		return collation[:idx]
+	}
+	return collation
+}
+
+// mysqlCharsetName matches character set and collation names
+var mysqlCharsetName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// mysqlCharsetClause renders the CHARACTER SET and COLLATE attributes of a
+// column definition. They are left out for non-string types, e.g. when a
+// column is converted to a number.
+func mysqlCharsetClause(col ColumnInfo) (string, error) {
+	if typeCategory(col.Type) != typeCategoryText {
+		return "", nil
+	}
+
+	var clause string
+	if col.Charset != "" {
+		if !mysqlCharsetName.MatchString(col.Charset) {
+			return "", fmt.Errorf("invalid character set: %s", col.Charset)
+		}
+		clause += " CHARACTER SET " + col.Charset
+	}
+	// A collation left over from before a character set change would be
+	// rejected; the new character set's default applies instead
+	if col.Collation != "" && (col.Charset == "" || strings.EqualFold(mysqlCollationCharset(col.Collation), col.Charset)) {
+		if !mysqlCharsetName.MatchString(col.Collation) {
+			return "", fmt.Errorf("invalid collation: %s", col.Collation)
+		}
+		clause += " COLLATE " + col.Collation
+	}
+	return clause, nil
+}
+
+func (d *MySQLDriver) GetTableComment(db Querier, database, table string) (string, error) {
+	var comment sql.NullString
+	err := db.QueryRow("SELECT TABLE_COMMENT FROM information_schema.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?", database, table).Scan(&comment)
+	if err != nil {
+		return "", err
+	}
+	return comment.String, nil
+}
+
+// SHOW CREATE TABLE also answers for views, with extra charset columns
+func (d *MySQLDriver) GetTableDDL(db Querier, database, table string) (string, error) {
+	rows, err := db.Query(fmt.Sprintf("SHOW CREATE TABLE %s", d.QualifiedName(database, table)))
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("table %s not found", table)
+	}
+
+	values := make([]sql.NullString, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return "", err
+	}
+	return values[1].String + ";", nil
+}
+
+// SearchSchema matches pattern, which is lower case, against every non-system database
+func (d *MySQLDriver) SearchSchema(db Querier, pattern string, limit int) ([]SchemaSearchResult, error) {
+	query := `
+		SELECT kind, db, tbl, name, detail FROM (
+			SELECT
+				CASE TABLE_TYPE WHEN 'VIEW' THEN 'view' ELSE 'table' END AS kind,
+				TABLE_SCHEMA AS db, '' AS tbl, TABLE_NAME AS name, '' AS detail
+			FROM information_schema.TABLES
+			WHERE LOWER(TABLE_NAME) LIKE ?
+			UNION ALL
+			SELECT 'column', TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME, COLUMN_TYPE
+			FROM information_schema.COLUMNS
+			WHERE LOWER(COLUMN_NAME) LIKE ?
+			UNION ALL
+			SELECT LOWER(ROUTINE_TYPE), ROUTINE_SCHEMA, '', ROUTINE_NAME, ''
+			FROM information_schema.ROUTINES
+			WHERE LOWER(ROUTINE_NAME) LIKE ?
+		) r
+		WHERE db NOT IN ('mysql', 'information_schema', 'performance_schema', 'sys')
+		ORDER BY
+			CASE WHEN LOWER(name) = ? THEN 0 WHEN LOWER(name) LIKE ? THEN 1 ELSE 2 END,
+			CHAR_LENGTH(name), name, db, tbl
+		LIMIT ?
+	`
+	escaped := escapeLike(pattern)
+	contains := "%" + escaped + "%"
+	rows, err := db.Query(query, contains, contains, contains, pattern, escaped+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanSearchResults(rows)
+}
+
+func (d *MySQLDriver) GetIndexes(db Querier, database, table string) ([]IndexInfo, error) {
+	query := fmt.Sprintf("SHOW INDEX FROM %s", d.QualifiedName(database, table))
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
@@ -177,6 +411,7 @@ func (d *MySQLDriver) GetIndexes(db *sql.DB, database, table string) ([]IndexInf
 				Columns:   []string{},
 				IsUnique:  nonUnique.String == "0",
 				IsPrimary: keyName.String == "PRIMARY",
+				Method:    strings.ToLower(indexType.String),
 			}
 			indexMap[keyName.String] = idx
 		}
@@ -190,42 +425,427 @@ func (d *MySQLDriver) GetIndexes(db *sql.DB, database, table string) ([]IndexInf
 	return indexes, nil
 }
 
-func (d *MySQLDriver) BuildTableDataQuery(req TableDataRequest, primaryKey string) string {
-	where := ""
-	if req.Filters != "" {
-		where = fmt.Sprintf(" WHERE %s", req.Filters)
+func (d *MySQLDriver) GetForeignKeys(db Querier, database, table string) ([]ForeignKeyInfo, error) {
+	query := `
+		SELECT
+			k.CONSTRAINT_NAME,
+			k.REFERENCED_TABLE_NAME,
+			r.UPDATE_RULE,
+			r.DELETE_RULE,
+			k.COLUMN_NAME,
+			k.REFERENCED_COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE k
+		JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+			ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+		WHERE k.TABLE_SCHEMA = ? AND k.TABLE_NAME = ? AND k.REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION
+	`
+	rows, err := db.Query(query, database, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	foreignKeys := []ForeignKeyInfo{}
+	for rows.Next() {
+		var fk ForeignKeyInfo
+		var column, refColumn string
+		if err := rows.Scan(&fk.Name, &fk.ReferencedTable, &fk.OnUpdate, &fk.OnDelete, &column, &refColumn); err != nil {
+			return nil, err
+		}
+
+		if n := len(foreignKeys); n > 0 && foreignKeys[n-1].Name == fk.Name {
+			foreignKeys[n-1].Columns = append(foreignKeys[n-1].Columns, column)
+			foreignKeys[n-1].ReferencedColumns = append(foreignKeys[n-1].ReferencedColumns, refColumn)
+			continue
+		}
+		fk.Columns = []string{column}
+		fk.ReferencedColumns = []string{refColumn}
+		foreignKeys = append(foreignKeys, fk)
+	}
+	return foreignKeys, rows.Err()
+}
+
+// GetConstraints lists the unique and check constraints of a table
+func (d *MySQLDriver) GetConstraints(db Querier, database, table string) ([]ConstraintInfo, error) {
+	query := `
+		SELECT tc.CONSTRAINT_NAME, k.COLUMN_NAME
+		FROM information_schema.TABLE_CONSTRAINTS tc
+		JOIN information_schema.KEY_COLUMN_USAGE k
+			ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND k.TABLE_NAME = tc.TABLE_NAME AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = ? AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE = 'UNIQUE'
+		ORDER BY tc.CONSTRAINT_NAME, k.ORDINAL_POSITION
+	`
+	rows, err := db.Query(query, database, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	constraints := []ConstraintInfo{}
+	for rows.Next() {
+		var name, column string
+		if err := rows.Scan(&name, &column); err != nil {
+			return nil, err
+		}
+
+		if n := len(constraints); n > 0 && constraints[n-1].Name == name {
+			constraints[n-1].Columns = append(constraints[n-1].Columns, column)
+			continue
+		}
+		constraints = append(constraints, ConstraintInfo{Name: name, Type: "UNIQUE", Columns: []string{column}})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	checks, err := d.getCheckConstraints(db, database, table)
+	if err != nil {
+		return nil, err
+	}
+	return append(constraints, checks...), nil
+}
+
+// getCheckConstraints lists CHECK constraints, which servers before MySQL
+// 8.0.16 neither enforce nor expose
+func (d *MySQLDriver) getCheckConstraints(db Querier, database, table string) ([]ConstraintInfo, error) {
+	query := `
+		SELECT tc.CONSTRAINT_NAME, cc.CHECK_CLAUSE
+		FROM information_schema.TABLE_CONSTRAINTS tc
+		JOIN information_schema.CHECK_CONSTRAINTS cc
+			ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = ? AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE = 'CHECK'
+		ORDER BY tc.CONSTRAINT_NAME
+	`
+	rows, err := db.Query(query, database, table)
+	if err != nil {
+		var myErr *mysql.MySQLError
+		if errors.As(err, &myErr) && myErr.Number == mysqlErrUnknownTable {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checks []ConstraintInfo
+	for rows.Next() {
+		c := ConstraintInfo{Type: "CHECK", Columns: []string{}}
+		if err := rows.Scan(&c.Name, &c.Expression); err != nil {
+			return nil, err
+		}
+		checks = append(checks, c)
+	}
+	return checks, rows.Err()
+}
+
+// MySQL has no sequences; each table's AUTO_INCREMENT counter is reported as one
+// named after the table
+func (d *MySQLDriver) GetSequences(db Querier, database string) ([]SequenceInfo, error) {
+	query := `
+		SELECT t.TABLE_NAME, t.AUTO_INCREMENT, c.COLUMN_NAME, c.COLUMN_TYPE
+		FROM information_schema.TABLES t
+		JOIN information_schema.COLUMNS c
+			ON c.TABLE_SCHEMA = t.TABLE_SCHEMA AND c.TABLE_NAME = t.TABLE_NAME AND c.EXTRA LIKE '%auto_increment%'
+		WHERE t.TABLE_SCHEMA = ?
+		ORDER BY t.TABLE_NAME
+	`
+	rows, err := db.Query(query, database)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sequences := []SequenceInfo{}
+	for rows.Next() {
+		var table, column, columnType string
+		var next sql.NullString
+		if err := rows.Scan(&table, &next, &column, &columnType); err != nil {
+			return nil, err
+		}
+
+		s := SequenceInfo{
+			Name:       table,
+			Increment:  "1",
+			MinValue:   "1",
+			MaxValue:   mysqlIntegerMax(columnType),
+			StartValue: "1",
+			OwnedBy:    table + "." + column,
+		}
+		// AUTO_INCREMENT holds the next value to be handed out
+		if n, err := strconv.ParseInt(next.String, 10, 64); err == nil && n > 1 {
+			s.CurrentValue = strconv.FormatInt(n-1, 10)
+		}
+		sequences = append(sequences, s)
+	}
+	return sequences, rows.Err()
+}
+
+func (d *MySQLDriver) GetTriggers(db Querier, database, table string) ([]TriggerInfo, error) {
+	query := `
+		SELECT TRIGGER_NAME, ACTION_TIMING, EVENT_MANIPULATION, ACTION_ORIENTATION, ACTION_STATEMENT
+		FROM information_schema.TRIGGERS
+		WHERE EVENT_OBJECT_SCHEMA = ? AND EVENT_OBJECT_TABLE = ?
+		ORDER BY ACTION_TIMING, EVENT_MANIPULATION, ACTION_ORDER
+	`
+	rows, err := db.Query(query, database, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// MySQL triggers fire on a single event and can't be disabled
+	triggers := []TriggerInfo{}
+	for rows.Next() {
+		var tr TriggerInfo
+		var event string
+		if err := rows.Scan(&tr.Name, &tr.Timing, &event, &tr.Level, &tr.Body); err != nil {
+			return nil, err
+		}
+		tr.Events = []string{event}
+		tr.Enabled = true
+		tr.Definition = fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH %s %s",
+			d.QuoteIdentifier(tr.Name), tr.Timing, event, d.QuoteIdentifier(table), tr.Level, tr.Body)
+		triggers = append(triggers, tr)
+	}
+	return triggers, rows.Err()
+}
+
+func (d *MySQLDriver) GetRoutines(db Querier, database string) ([]RoutineInfo, error) {
+	rows, err := db.Query(`
+		SELECT ROUTINE_NAME, ROUTINE_TYPE, DTD_IDENTIFIER, ROUTINE_BODY, ROUTINE_DEFINITION
+		FROM information_schema.ROUTINES
+		WHERE ROUTINE_SCHEMA = ?
+		ORDER BY ROUTINE_NAME, ROUTINE_TYPE
+	`, database)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	routines := []RoutineInfo{}
+	byName := make(map[string]int)
+	for rows.Next() {
+		r := RoutineInfo{Arguments: []RoutineArgument{}}
+		var returnType, definition sql.NullString
+		if err := rows.Scan(&r.Name, &r.Kind, &returnType, &r.Language, &definition); err != nil {
+			return nil, err
+		}
+		r.ReturnType = returnType.String
+		r.Source = definition.String
+		byName[r.Kind+"/"+r.Name] = len(routines)
+		routines = append(routines, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Position 0 is a function's return value, already covered by DTD_IDENTIFIER
+	args, err := db.Query(`
+		SELECT SPECIFIC_NAME, ROUTINE_TYPE, COALESCE(PARAMETER_MODE, 'IN'), COALESCE(PARAMETER_NAME, ''), DTD_IDENTIFIER
+		FROM information_schema.PARAMETERS
+		WHERE SPECIFIC_SCHEMA = ? AND ORDINAL_POSITION > 0
+		ORDER BY SPECIFIC_NAME, ROUTINE_TYPE, ORDINAL_POSITION
+	`, database)
+	if err != nil {
+		return nil, err
+	}
+	defer args.Close()
+
+	for args.Next() {
+		var name, kind string
+		var arg RoutineArgument
+		if err := args.Scan(&name, &kind, &arg.Mode, &arg.Name, &arg.Type); err != nil {
+			return nil, err
+		}
+		if idx, ok := byName[kind+"/"+name]; ok {
+			routines[idx].Arguments = append(routines[idx].Arguments, arg)
+		}
+	}
+	return routines, args.Err()
+}
+
+// Roles of MySQL 8 are accounts too; they show up as locked accounts
+func (d *MySQLDriver) GetRoles(db Querier) ([]RoleInfo, error) {
+	rows, err := db.Query(`
+		SELECT User, Host, account_locked = 'N', Super_priv = 'Y', Create_user_priv = 'Y', Create_priv = 'Y'
+		FROM mysql.user
+		ORDER BY User, Host
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []RoleInfo{}
+	byAccount := make(map[string]int)
+	for rows.Next() {
+		r := RoleInfo{MemberOf: []string{}}
+		if err := rows.Scan(&r.Name, &r.Host, &r.CanLogin, &r.Superuser, &r.CreateRole, &r.CreateDB); err != nil {
+			return nil, err
+		}
+		byAccount[r.Name+"@"+r.Host] = len(roles)
+		roles = append(roles, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Role grants only exist from MySQL 8.0 on
+	edges, err := db.Query("SELECT FROM_USER, TO_USER, TO_HOST FROM mysql.role_edges ORDER BY FROM_USER")
+	if err != nil {
+		return roles, nil
+	}
+	defer edges.Close()
+
+	for edges.Next() {
+		var role, user, host string
+		if err := edges.Scan(&role, &user, &host); err != nil {
+			return nil, err
+		}
+		if idx, ok := byAccount[user+"@"+host]; ok {
+			roles[idx].MemberOf = append(roles[idx].MemberOf, role)
+		}
+	}
+	return roles, edges.Err()
+}
+
+// mysqlTablePrivileges are the privileges that can be granted on a table
+var mysqlTablePrivileges = []string{
+	"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "REFERENCES",
+	"INDEX", "ALTER", "CREATE VIEW", "SHOW VIEW", "TRIGGER",
+}
+
+// Database and global grants apply to the table as well, so they are listed
+// alongside the table's own grants
+func (d *MySQLDriver) GetTablePrivileges(db Querier, database, table string) ([]TablePrivilege, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(mysqlTablePrivileges)), ", ")
+	query := fmt.Sprintf(`
+		SELECT GRANTEE, PRIVILEGE_TYPE, IS_GRANTABLE = 'YES', 'table'
+		FROM information_schema.TABLE_PRIVILEGES
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		UNION ALL
+		SELECT GRANTEE, PRIVILEGE_TYPE, IS_GRANTABLE = 'YES', 'database'
+		FROM information_schema.SCHEMA_PRIVILEGES
+		WHERE TABLE_SCHEMA = ? AND PRIVILEGE_TYPE IN (%[1]s)
+		UNION ALL
+		SELECT GRANTEE, PRIVILEGE_TYPE, IS_GRANTABLE = 'YES', 'global'
+		FROM information_schema.USER_PRIVILEGES
+		WHERE PRIVILEGE_TYPE IN (%[1]s)
+		ORDER BY 1, 2, 4
+	`, placeholders)
+
+	args := []interface{}{database, table, database}
+	for i := 0; i < 2; i++ {
+		for _, p := range mysqlTablePrivileges {
+			args = append(args, p)
+		}
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	privileges := []TablePrivilege{}
+	for rows.Next() {
+		var p TablePrivilege
+		if err := rows.Scan(&p.Grantee, &p.Privilege, &p.Grantable, &p.Level); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, p)
+	}
+	return privileges, rows.Err()
+}
+
+// mysqlIntegerMax returns the largest value an integer column type can hold
+func mysqlIntegerMax(columnType string) string {
+	unsigned := strings.Contains(columnType, "unsigned")
+	limits := map[string][2]string{
+		"tinyint":   {"127", "255"},
+		"smallint":  {"32767", "65535"},
+		"mediumint": {"8388607", "16777215"},
+		"int":       {"2147483647", "4294967295"},
+		"bigint":    {"9223372036854775807", "18446744073709551615"},
 	}
 
-	orderBy := req.OrderBy
-	if orderBy == "" && primaryKey != "" {
-		orderBy = primaryKey
+	base := columnType
+	if idx := strings.IndexAny(base, "( "); idx >= 0 {
+		base = base[:idx]
 	}
-	orderDir := strings.ToUpper(req.OrderDir)
-	if orderDir != "DESC" {
-		orderDir = "ASC"
+	if limit, ok := limits[base]; ok {
+		if unsigned {
+			return limit[1]
+		}
+		return limit[0]
+	}
+	return ""
+}
+
+// filterDialect compiles filters with ? placeholders
+func (d *MySQLDriver) filterDialect() filterDialect {
+	return filterDialect{
+		quote:       d.QuoteIdentifier,
+		placeholder: func(int) string { return "?" },
+		textColumn:  func(column string) string { return column },
+		ilike:       func(column, pattern string) string { return fmt.Sprintf("LOWER(%s) LIKE LOWER(%s)", column, pattern) },
+		regex:       func(column, pattern string) string { return fmt.Sprintf("%s REGEXP %s", column, pattern) },
+		jsonPath: func(column string, path []string, bind func(interface{}) string) string {
+			return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", column, bind(mysqlJSONPath(path)))
+		},
+		// Strings would otherwise be converted to numbers, "abc" matching 0
+		numeric: func(column string, path []string, bind func(interface{}) string) string {
+			// Positional placeholders can't be reused, so the path is bound twice
+			value := func() string { return fmt.Sprintf("JSON_EXTRACT(%s, %s)", column, bind(mysqlJSONPath(path))) }
+			return fmt.Sprintf("CASE WHEN JSON_TYPE(%s) IN ('INTEGER', 'UNSIGNED INTEGER', 'DECIMAL', 'DOUBLE') THEN %s END", value(), value())
+		},
+		literal: mysqlQuoteLiteral,
 	}
+}
 
-	query := fmt.Sprintf("SELECT * FROM `%s`.`%s` %s", req.Database, req.Table, where)
-	if orderBy != "" {
-		query += fmt.Sprintf(" ORDER BY `%s` %s", orderBy, orderDir)
+func (d *MySQLDriver) BuildTableDataQuery(req TableDataRequest, primaryKey []string, locate bool) (string, []interface{}, error) {
+	where, args, err := compileFilters(d.filterDialect(), req.Filters)
+	if err != nil {
+		return "", nil, err
 	}
 
+	order := tableDataOrder(req, primaryKey)
+	where, args, err = addKeysetCondition(d.filterDialect(), where, args, req, order)
+	if err != nil {
+		return "", nil, err
+	}
+
+	paths, err := jsonPathSelection(d.filterDialect(), req.JSONPaths)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := fmt.Sprintf("SELECT *%s FROM %s%s", paths, d.QualifiedName(req.Database, req.Table), where)
+	query += orderClause(d.QuoteIdentifier, order)
+
 	pageSize := req.PageSize
 	if pageSize <= 0 {
 		pageSize = 50
 	}
-	offset := (req.Page - 1) * pageSize
-	query += fmt.Sprintf(" LIMIT %d OFFSET %d", pageSize, offset)
+	if req.Pagination == PaginationKeyset {
+		query += fmt.Sprintf(" LIMIT %d", pageSize)
+	} else {
+		offset := (req.Page - 1) * pageSize
+		query += fmt.Sprintf(" LIMIT %d OFFSET %d", pageSize, offset)
+	}
 
-	return query
+	return query, args, nil
 }
 
-func (d *MySQLDriver) BuildCountQuery(database, table, filters string) string {
-	where := ""
-	if filters != "" {
-		where = fmt.Sprintf(" WHERE %s", filters)
+func (d *MySQLDriver) BuildCountQuery(database, table string, filters []FilterCondition, limit int) (string, []interface{}, error) {
+	where, args, err := compileFilters(d.filterDialect(), filters)
+	if err != nil {
+		return "", nil, err
 	}
-	return fmt.Sprintf("SELECT COUNT(*) FROM `%s`.`%s` %s", database, table, where)
+	if limit > 0 {
+		return fmt.Sprintf("SELECT COUNT(*) FROM (SELECT 1 FROM %s%s LIMIT %d) AS bounded", d.QualifiedName(database, table), where, limit), args, nil
+	}
+	return fmt.Sprintf("SELECT COUNT(*) FROM %s%s", d.QualifiedName(database, table), where), args, nil
 }
 
 func (d *MySQLDriver) BuildAlterTableQuery(database, table string, alteration TableAlteration) ([]string, error) {
@@ -233,13 +853,23 @@ func (d *MySQLDriver) BuildAlterTableQuery(database, table string, alteration Ta
 
 	// Rename table if requested
 	if alteration.RenameTo != "" && alteration.RenameTo != table {
-		statements = append(statements, fmt.Sprintf("ALTER TABLE `%s`.`%s` RENAME TO `%s`", database, table, alteration.RenameTo))
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", d.QualifiedName(database, table), d.QuoteIdentifier(alteration.RenameTo)))
 		table = alteration.RenameTo
 	}
 
+	// Drop constraints before the columns they may refer to. UNIQUE constraints
+	// are indexes in MySQL.
+	for _, c := range alteration.DropConstraints {
+		if strings.EqualFold(c.Type, "UNIQUE") {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP INDEX %s", d.QualifiedName(database, table), d.QuoteIdentifier(c.Name)))
+		} else {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", d.QualifiedName(database, table), d.QuoteIdentifier(c.Name)))
+		}
+	}
+
 	// Drop columns
 	for _, col := range alteration.DropColumns {
-		statements = append(statements, fmt.Sprintf("ALTER TABLE `%s`.`%s` DROP COLUMN `%s`", database, table, col))
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", d.QualifiedName(database, table), d.QuoteIdentifier(col)))
 	}
 
 	// Add columns
@@ -248,44 +878,160 @@ func (d *MySQLDriver) BuildAlterTableQuery(database, table string, alteration Ta
 		if col.Nullable {
 			nullStr = "NULL"
 		}
-		defaultStr := ""
-		if col.Default != "" {
-			defaultStr = fmt.Sprintf(" DEFAULT '%s'", col.Default)
+		defaultStr := defaultClause(col, mysqlQuoteLiteral, true)
+		charsetStr, err := mysqlCharsetClause(col)
+		if err != nil {
+			return nil, err
 		}
-		statements = append(statements, fmt.Sprintf("ALTER TABLE `%s`.`%s` ADD COLUMN `%s` %s %s%s %s",
-			database, table, col.Name, col.Type, nullStr, defaultStr, col.Extra))
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s%s %s%s %s",
+			d.QualifiedName(database, table), d.QuoteIdentifier(col.Name), col.Type, charsetStr, nullStr, defaultStr, mysqlExtra(col.Extra)))
 	}
 
-	// Modify columns
+	// Modify columns. Restating the column without a collation would reset it
+	// to the table default, so the collation read by GetColumns is kept.
 	for _, col := range alteration.ModifyColumns {
 		nullStr := "NOT NULL"
 		if col.Nullable {
 			nullStr = "NULL"
 		}
-		defaultStr := ""
-		if col.Default != "" {
-			defaultStr = fmt.Sprintf(" DEFAULT '%s'", col.Default)
+		defaultStr := defaultClause(col, mysqlQuoteLiteral, true)
+		charsetStr, err := mysqlCharsetClause(col)
+		if err != nil {
+			return nil, err
 		}
 
 		// Use CHANGE COLUMN if renaming, otherwise MODIFY COLUMN
 		if col.OldName != "" && col.OldName != col.Name {
-			statements = append(statements, fmt.Sprintf("ALTER TABLE `%s`.`%s` CHANGE COLUMN `%s` `%s` %s %s%s %s",
-				database, table, col.OldName, col.Name, col.Type, nullStr, defaultStr, col.Extra))
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s CHANGE COLUMN %s %s %s%s %s%s %s",
+				d.QualifiedName(database, table), d.QuoteIdentifier(col.OldName), d.QuoteIdentifier(col.Name), col.Type, charsetStr, nullStr, defaultStr, mysqlExtra(col.Extra)))
 		} else {
-			statements = append(statements, fmt.Sprintf("ALTER TABLE `%s`.`%s` MODIFY COLUMN `%s` %s %s%s %s",
-				database, table, col.Name, col.Type, nullStr, defaultStr, col.Extra))
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s%s %s%s %s",
+				d.QualifiedName(database, table), d.QuoteIdentifier(col.Name), col.Type, charsetStr, nullStr, defaultStr, mysqlExtra(col.Extra)))
 		}
 	}
 
+	// Add constraints once the columns they refer to exist
+	for _, c := range alteration.AddConstraints {
+		clause, err := constraintClause(d, c)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD %s", d.QualifiedName(database, table), clause))
+	}
+
 	return statements, nil
 }
 
+func (d *MySQLDriver) BuildCreateTableQuery(database, table string, columns []ColumnInfo) string {
+	var defs []string
+	var primaryKeys []string
+	for _, col := range columns {
+		nullStr := "NOT NULL"
+		if col.Nullable {
+			nullStr = "NULL"
+		}
+		defs = append(defs, fmt.Sprintf("%s %s %s", d.QuoteIdentifier(col.Name), col.Type, nullStr))
+		if col.Key == "PRI" {
+			primaryKeys = append(primaryKeys, d.QuoteIdentifier(col.Name))
+		}
+	}
+	if len(primaryKeys) > 0 {
+		defs = append(defs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+	return fmt.Sprintf("CREATE TABLE %s (%s)", d.QualifiedName(database, table), strings.Join(defs, ", "))
+}
+
 func (d *MySQLDriver) BuildTruncateTableQuery(database, table string) string {
-	return fmt.Sprintf("TRUNCATE TABLE `%s`.`%s`", database, table)
+	return fmt.Sprintf("TRUNCATE TABLE %s", d.QualifiedName(database, table))
 }
 
 func (d *MySQLDriver) BuildDropTableQuery(database, table string) string {
-	return fmt.Sprintf("DROP TABLE `%s`.`%s`", database, table)
+	return fmt.Sprintf("DROP TABLE %s", d.QualifiedName(database, table))
+}
+
+func (d *MySQLDriver) BuildRefreshMaterializedViewQuery(database, view string, concurrently bool) (string, error) {
+	return "", fmt.Errorf("materialized views are not supported by MySQL")
+}
+
+func (d *MySQLDriver) BuildRestartSequenceQuery(database, sequence string, value int64) string {
+	return fmt.Sprintf("ALTER TABLE %s AUTO_INCREMENT = %d", d.QualifiedName(database, sequence), value)
+}
+
+func (d *MySQLDriver) BuildAlterSequenceQuery(database, sequence string, alteration SequenceAlteration) (string, error) {
+	return "", fmt.Errorf("MySQL AUTO_INCREMENT counters only support restarting")
+}
+
+func (d *MySQLDriver) BuildSetTriggerEnabledQuery(database, table, trigger string, enabled bool) (string, error) {
+	return "", fmt.Errorf("MySQL does not support enabling or disabling triggers")
+}
+
+func (d *MySQLDriver) BuildDropTriggerQuery(database, table, trigger string) string {
+	return fmt.Sprintf("DROP TRIGGER %s", d.QualifiedName(database, trigger))
+}
+
+func (d *MySQLDriver) BuildTableCommentQuery(database, table, comment string) string {
+	return fmt.Sprintf("ALTER TABLE %s COMMENT = %s", d.QualifiedName(database, table), mysqlQuoteLiteral(comment))
+}
+
+// MySQL can only change a column comment by restating the whole column, so the
+// definition is taken from SHOW CREATE TABLE to keep everything else intact
+func (d *MySQLDriver) BuildColumnCommentQuery(db Querier, database, table, column, comment string) (string, error) {
+	var name, createStmt string
+	if err := db.QueryRow(fmt.Sprintf("SHOW CREATE TABLE %s", d.QualifiedName(database, table))).Scan(&name, &createStmt); err != nil {
+		return "", err
+	}
+
+	prefix := d.QuoteIdentifier(column) + " "
+	for _, line := range strings.Split(createStmt, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		definition := strings.TrimSuffix(line, ",")
+		definition = mysqlColumnComment.ReplaceAllString(definition, "")
+		if comment != "" {
+			definition += " COMMENT " + mysqlQuoteLiteral(comment)
+		}
+		return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s", d.QualifiedName(database, table), definition), nil
+	}
+
+	return "", fmt.Errorf("column %s not found in table %s", column, table)
+}
+
+// mysqlColumnComment matches the COMMENT clause of a column definition
+var mysqlColumnComment = regexp.MustCompile(` COMMENT '(?:[^'\\]|\\.|'')*'`)
+
+// mysqlQuoteLiteral quotes a string literal, escaping backslashes as MySQL requires
+func mysqlQuoteLiteral(s string) string {
+	return quoteLiteral(strings.ReplaceAll(s, `\`, `\\`))
+}
+
+func (d *MySQLDriver) BuildPrivilegeQuery(database string, change PrivilegeChange) (string, error) {
+	privileges, err := privilegeList(change, mysqlTablePrivileges)
+	if err != nil {
+		return "", err
+	}
+
+	host := change.Host
+	if host == "" {
+		host = "%"
+	}
+	target := d.QualifiedName(database, change.Table)
+	account := mysqlQuoteLiteral(change.Grantee) + "@" + mysqlQuoteLiteral(host)
+
+	if strings.EqualFold(change.Action, "REVOKE") {
+		// The grant option is not tied to single privileges in MySQL
+		if change.WithGrantOption {
+			return fmt.Sprintf("REVOKE GRANT OPTION ON %s FROM %s", target, account), nil
+		}
+		return fmt.Sprintf("REVOKE %s ON %s FROM %s", privileges, target, account), nil
+	}
+
+	query := fmt.Sprintf("GRANT %s ON %s TO %s", privileges, target, account)
+	if change.WithGrantOption {
+		query += " WITH GRANT OPTION"
+	}
+	return query, nil
 }
 
 func (d *MySQLDriver) BuildInsertQuery(database, table string, columns []string) string {
@@ -295,38 +1041,299 @@ func (d *MySQLDriver) BuildInsertQuery(database, table string, columns []string)
 		quotedCols[i] = d.QuoteIdentifier(col)
 		placeholders[i] = "?"
 	}
-	return fmt.Sprintf("INSERT INTO `%s`.`%s` (%s) VALUES (%s)",
-		database, table, strings.Join(quotedCols, ", "), strings.Join(placeholders, ", "))
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		d.QualifiedName(database, table), strings.Join(quotedCols, ", "), strings.Join(placeholders, ", "))
+}
+
+func (d *MySQLDriver) BuildBatchInsertQuery(database, table string, columns []string, rows int) string {
+	quotedCols := make([]string, len(columns))
+	for i, col := range columns {
+		quotedCols[i] = d.QuoteIdentifier(col)
+	}
+	tuple := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	tuples := make([]string, rows)
+	for i := range tuples {
+		tuples[i] = tuple
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		d.QualifiedName(database, table), strings.Join(quotedCols, ", "), strings.Join(tuples, ", "))
+}
+
+// MySQL has no RETURNING; LastInsertId reports generated ids
+func (d *MySQLDriver) ReturningClause() string {
+	return ""
 }
 
-func (d *MySQLDriver) BuildUpdateQuery(database, table, primaryKey string, columns []string) string {
+// Prepared statements count placeholders in 16 bits
+func (d *MySQLDriver) MaxPlaceholders() int {
+	return 65535
+}
+
+func (d *MySQLDriver) BuildUpdateQuery(database, table string, primaryKey []string, columns []string) string {
 	setClauses := make([]string, len(columns))
 	for i, col := range columns {
 		setClauses[i] = fmt.Sprintf("%s = ?", d.QuoteIdentifier(col))
 	}
-	return fmt.Sprintf("UPDATE `%s`.`%s` SET %s WHERE %s = ?",
-		database, table, strings.Join(setClauses, ", "), d.QuoteIdentifier(primaryKey))
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s",
+		d.QualifiedName(database, table), strings.Join(setClauses, ", "), keyCondition(d.filterDialect(), primaryKey, len(columns)+1))
 }
 
-func (d *MySQLDriver) BuildDeleteQuery(database, table, primaryKey string) string {
-	return fmt.Sprintf("DELETE FROM `%s`.`%s` WHERE %s = ?",
-		database, table, d.QuoteIdentifier(primaryKey))
+func (d *MySQLDriver) BuildDeleteQuery(database, table string, primaryKey []string) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s",
+		d.QualifiedName(database, table), keyCondition(d.filterDialect(), primaryKey, 1))
 }
 
-func (d *MySQLDriver) BuildBatchDeleteQuery(database, table, primaryKey string, count int) string {
-	placeholders := make([]string, count)
-	for i := range placeholders {
-		placeholders[i] = "?"
+func (d *MySQLDriver) BuildBatchDeleteQuery(database, table string, primaryKey []string, count int) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s",
+		d.QualifiedName(database, table), keyListCondition(d.filterDialect(), primaryKey, count))
+}
+
+func (d *MySQLDriver) BuildDuplicateRowQuery(database, table string, primaryKey, copied, set []string) string {
+	dialect := d.filterDialect()
+	columns := make([]string, 0, len(copied)+len(set))
+	selected := make([]string, 0, len(copied)+len(set))
+	for _, col := range copied {
+		columns = append(columns, d.QuoteIdentifier(col))
+		selected = append(selected, d.QuoteIdentifier(col))
+	}
+	for i, col := range set {
+		columns = append(columns, d.QuoteIdentifier(col))
+		selected = append(selected, dialect.placeholder(i+1))
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s WHERE %s",
+		d.QualifiedName(database, table), strings.Join(columns, ", "), strings.Join(selected, ", "), d.QualifiedName(database, table), keyCondition(dialect, primaryKey, len(set)+1))
+}
+
+func (d *MySQLDriver) BuildSelectRowsQuery(database, table string, primaryKey, columns []string, count int) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = d.QuoteIdentifier(col)
+	}
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		strings.Join(quoted, ", "), d.QualifiedName(database, table), keyListCondition(d.filterDialect(), primaryKey, count))
+}
+
+func (d *MySQLDriver) BuildBulkUpdateQuery(database, table string, columns []string, filters []FilterCondition) (string, []interface{}, error) {
+	where, args, err := compileFiltersAfter(d.filterDialect(), filters, len(columns))
+	if err != nil {
+		return "", nil, err
+	}
+	setClauses := make([]string, len(columns))
+	for i, col := range columns {
+		setClauses[i] = fmt.Sprintf("%s = ?", d.QuoteIdentifier(col))
 	}
-	return fmt.Sprintf("DELETE FROM `%s`.`%s` WHERE %s IN (%s)",
-		database, table, d.QuoteIdentifier(primaryKey), strings.Join(placeholders, ", "))
+	return fmt.Sprintf("UPDATE %s SET %s%s", d.QualifiedName(database, table), strings.Join(setClauses, ", "), where), args, nil
+}
+
+func (d *MySQLDriver) BuildFilteredDeleteQuery(database, table string, filters []FilterCondition) (string, []interface{}, error) {
+	where, args, err := compileFilters(d.filterDialect(), filters)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("DELETE FROM %s%s", d.QualifiedName(database, table), where), args, nil
+}
+
+// BuildLocatedUpdateQuery matches the row by its loaded values, as MySQL has
+// no stable row address. LIMIT 1 keeps identical rows from all being changed.
+func (d *MySQLDriver) BuildLocatedUpdateQuery(database, table string, columns []string, locator RowLocator) (string, []interface{}, error) {
+	where, args, err := d.rowMatchCondition(locator)
+	if err != nil {
+		return "", nil, err
+	}
+	setClauses := make([]string, len(columns))
+	for i, col := range columns {
+		setClauses[i] = fmt.Sprintf("%s = ?", d.QuoteIdentifier(col))
+	}
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s LIMIT 1",
+		d.QualifiedName(database, table), strings.Join(setClauses, ", "), where), args, nil
+}
+
+func (d *MySQLDriver) BuildLocatedDeleteQuery(database, table string, locator RowLocator) (string, []interface{}, error) {
+	where, args, err := d.rowMatchCondition(locator)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("DELETE FROM %s WHERE %s LIMIT 1", d.QualifiedName(database, table), where), args, nil
+}
+
+// rowMatchCondition matches every given column of a row with null-safe
+// equality, binding the values in column name order. The Manager leaves out
+// columns that do not compare reliably (see stableLocator).
+func (d *MySQLDriver) rowMatchCondition(locator RowLocator) (string, []interface{}, error) {
+	if len(locator.Values) == 0 {
+		return "", nil, fmt.Errorf("row values are required to identify a row without a primary key")
+	}
+
+	columns := make([]string, 0, len(locator.Values))
+	for col := range locator.Values {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+
+	conditions := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, col := range columns {
+		conditions[i] = d.QuoteIdentifier(col) + " <=> ?"
+		args[i] = paramValue(locator.Values[col])
+	}
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+func (d *MySQLDriver) QualifiedName(database, name string) string {
+	return qualifiedName(d.QuoteIdentifier, database, name)
+}
+
+// MySQL has no row address to select; rows are matched by content
+func (d *MySQLDriver) RowMatch() string {
+	return RowMatchRow
 }
 
 func (d *MySQLDriver) QuoteIdentifier(name string) string {
-	return fmt.Sprintf("`%s`", name)
+	return quoteIdentifier(name, "`")
+}
+
+// MySQL also rejects names that end with a space
+func (d *MySQLDriver) ValidateIdentifier(name string) error {
+	if err := validateIdentifier(name, mysqlMaxIdentifier, false); err != nil {
+		return err
+	}
+	if strings.HasSuffix(name, " ") {
+		return fmt.Errorf("identifier %q must not end with a space", name)
+	}
+	return nil
 }
 
 func (d *MySQLDriver) BuildDistinctValuesQuery(database, table, column string) string {
-	return fmt.Sprintf("SELECT DISTINCT `%s` FROM `%s`.`%s` ORDER BY `%s` LIMIT 100",
-		column, database, table, column)
+	return fmt.Sprintf("SELECT DISTINCT %s FROM %s ORDER BY %s LIMIT 100",
+		d.QuoteIdentifier(column), d.QualifiedName(database, table), d.QuoteIdentifier(column))
+}
+
+func (d *MySQLDriver) BuildKeyRangeQuery(database, table, primaryKey string, columns []string, hasLower, hasUpper bool) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = d.QuoteIdentifier(col)
+	}
+	pk := d.QuoteIdentifier(primaryKey)
+
+	var conditions []string
+	if hasLower {
+		conditions = append(conditions, pk+" > ?")
+	}
+	if hasUpper {
+		conditions = append(conditions, pk+" <= ?")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(quoted, ", "), d.QualifiedName(database, table))
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return query + " ORDER BY " + pk
+}
+
+func (d *MySQLDriver) BuildCallRoutine(database string, routine RoutineInfo) routineCall {
+	var plan routineCall
+	var params, outputs []string
+	input := 0
+	for i, arg := range routine.Arguments {
+		// OUT and INOUT arguments are passed through session variables
+		variable := fmt.Sprintf("@_rune_arg%d", i+1)
+		switch arg.Mode {
+		case argModeInOut:
+			plan.prepare = append(plan.prepare, routineStatement{query: "SET " + variable + " = ?", args: []int{input}})
+			input++
+			params = append(params, variable)
+			outputs = append(outputs, fmt.Sprintf("%s AS %s", variable, d.QuoteIdentifier(arg.Name)))
+		case argModeOut:
+			params = append(params, variable)
+			outputs = append(outputs, fmt.Sprintf("%s AS %s", variable, d.QuoteIdentifier(arg.Name)))
+		default:
+			plan.call.args = append(plan.call.args, input)
+			input++
+			params = append(params, "?")
+		}
+	}
+
+	name := d.QualifiedName(database, routine.Name)
+	if routine.Kind == "PROCEDURE" {
+		plan.call.query = fmt.Sprintf("CALL %s(%s)", name, strings.Join(params, ", "))
+	} else {
+		plan.call.query = fmt.Sprintf("SELECT %s(%s) AS %s", name, strings.Join(params, ", "), d.QuoteIdentifier(routine.Name))
+	}
+	if len(outputs) > 0 {
+		plan.fetch = "SELECT " + strings.Join(outputs, ", ")
+	}
+	return plan
+}
+
+// The query cache only exists before MySQL 8.0; newer servers report an error
+func (d *MySQLDriver) BuildClearCacheQuery() string {
+	return "RESET QUERY CACHE"
+}
+
+func (d *MySQLDriver) BackendIDQuery() string {
+	return "SELECT CONNECTION_ID()"
+}
+
+// KILL QUERY stops the statement but keeps the connection open
+func (d *MySQLDriver) BuildCancelQuery(backendID int64) string {
+	return fmt.Sprintf("KILL QUERY %d", backendID)
+}
+
+// max_execution_time only covers SELECT, so timeouts are left to the client
+func (d *MySQLDriver) StatementTimeoutQueries(timeout time.Duration) (string, string) {
+	return "", ""
+}
+
+func (d *MySQLDriver) BuildCompletionColumnsQuery(database string) (string, []interface{}) {
+	return `
+		SELECT c.TABLE_SCHEMA, c.TABLE_NAME,
+			CASE t.TABLE_TYPE WHEN 'VIEW' THEN 'VIEW' ELSE 'TABLE' END,
+			c.COLUMN_NAME, c.COLUMN_TYPE
+		FROM information_schema.COLUMNS c
+		JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+		WHERE c.TABLE_SCHEMA = ?
+		ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION
+	`, []interface{}{database}
+}
+
+func (d *MySQLDriver) BuildCompletionRoutinesQuery(database string) (string, []interface{}) {
+	// The return value of a function is its parameter 0
+	return `
+		SELECT r.ROUTINE_SCHEMA, r.ROUTINE_NAME, r.ROUTINE_TYPE,
+			COALESCE((
+				SELECT GROUP_CONCAT(CONCAT(p.PARAMETER_NAME, ' ', p.DTD_IDENTIFIER) ORDER BY p.ORDINAL_POSITION SEPARATOR ', ')
+				FROM information_schema.PARAMETERS p
+				WHERE p.SPECIFIC_SCHEMA = r.ROUTINE_SCHEMA AND p.SPECIFIC_NAME = r.SPECIFIC_NAME AND p.ORDINAL_POSITION > 0
+			), ''),
+			COALESCE(r.DTD_IDENTIFIER, '')
+		FROM information_schema.ROUTINES r
+		WHERE r.ROUTINE_SCHEMA = ?
+		ORDER BY r.ROUTINE_NAME
+	`, []interface{}{database}
+}
+
+// MySQL sends no notices; warnings are read with WarningsQuery
+func (d *MySQLDriver) CaptureNotices(conn *sql.Conn, collect func(ServerNotice)) func() {
+	return func() {}
+}
+
+func (d *MySQLDriver) WarningsQuery() string {
+	return "SHOW WARNINGS"
+}
+
+// EXPLAIN ANALYZE (MySQL 8.0.18+) only prints a text tree
+func (d *MySQLDriver) BuildExplainQuery(query string, analyze bool) string {
+	if analyze {
+		return "EXPLAIN ANALYZE " + query
+	}
+	return "EXPLAIN FORMAT=JSON " + query
+}
+
+func (d *MySQLDriver) ParsePlan(raw string, analyze bool) (*ExplainResult, error) {
+	return parseMySQLPlan(raw, analyze)
+}
+
+func (d *MySQLDriver) BuildSelectCellQuery(database, table string, primaryKey []string, column string) string {
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		d.QuoteIdentifier(column), d.QualifiedName(database, table), keyCondition(d.filterDialect(), primaryKey, 1))
 }
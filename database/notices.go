@@ -0,0 +1,83 @@
+package database
+
+import (
+	"strconv"
+	"sync"
+)
+
+// maxNotices bounds the notices kept per statement, as a loop in a DO block
+// can raise one per row
+const maxNotices = 1000
+
+// ServerNotice is a notice or warning the server sent while running a statement
+type ServerNotice struct {
+	Severity string `json:"severity"` // NOTICE, WARNING, INFO, ... (PostgreSQL); Note, Warning, Error (MySQL)
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message"`
+	Detail   string `json:"detail,omitempty"`
+	Hint     string `json:"hint,omitempty"`
+}
+
+// noticeCollector gathers the notices sent on a connection. The driver calls
+// add from its own goroutine.
+type noticeCollector struct {
+	mu      sync.Mutex
+	notices []ServerNotice
+	dropped int
+}
+
+func (c *noticeCollector) add(n ServerNotice) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.notices) >= maxNotices {
+		c.dropped++
+		return
+	}
+	c.notices = append(c.notices, n)
+}
+
+// drain returns the notices gathered so far and starts over
+func (c *noticeCollector) drain() []ServerNotice {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	notices := c.notices
+	if c.dropped > 0 {
+		notices = append(notices, ServerNotice{
+			Severity: "INFO",
+			Message:  strconv.Itoa(c.dropped) + " more notices were dropped",
+		})
+	}
+	c.notices, c.dropped = nil, 0
+	return notices
+}
+
+// serverWarnings asks the server for the warnings of the statement just run
+// on conn, for servers that don't send them as notices. Nothing is asked
+// after a failed statement, whose error already says what went wrong.
+func (m *Manager) serverWarnings(conn *instrumentedConn, statement string, failed bool) []ServerNotice {
+	if !m.asksWarnings(statement, failed) {
+		return nil
+	}
+
+	// Bookkeeping stays out of the activity log and interceptors
+	rows, err := conn.conn.QueryContext(conn.ctx, m.currentDriver().WarningsQuery())
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var warnings []ServerNotice
+	for rows.Next() {
+		var n ServerNotice
+		if err := rows.Scan(&n.Severity, &n.Code, &n.Message); err != nil {
+			return warnings
+		}
+		warnings = append(warnings, n)
+	}
+	return warnings
+}
+
+// asksWarnings tells whether serverWarnings queries the server after a statement
+func (m *Manager) asksWarnings(statement string, failed bool) bool {
+	return m.currentDriver().WarningsQuery() != "" && !failed && leadingKeyword(statement) != "SHOW"
+}
@@ -0,0 +1,194 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// listenerPingInterval is how often an idle listener checks its connection,
+// as a dropped connection otherwise goes unnoticed until the next notification
+const listenerPingInterval = 90 * time.Second
+
+// Notification is a message sent with NOTIFY on a channel the session listens
+// on. It is emitted as a "notify:message" event.
+type Notification struct {
+	Channel    string `json:"channel"`
+	Payload    string `json:"payload"`
+	PID        int    `json:"pid"`        // Server process that sent it
+	ReceivedAt string `json:"receivedAt"` // RFC 3339 with milliseconds
+}
+
+// ListenerStatus reports a change of the listener's connection. It is
+// emitted as a "notify:status" event.
+type ListenerStatus struct {
+	State string `json:"state"` // connected, disconnected, reconnected or failed
+	Error string `json:"error,omitempty"`
+}
+
+// channelListener holds the connection LISTEN runs on. It is kept apart from
+// the pool, as notifications only arrive on the session that asked for them.
+type channelListener struct {
+	mu       sync.Mutex
+	listener *pq.Listener
+	channels map[string]bool
+	stop     chan struct{}
+}
+
+// Listen subscribes the session to a PostgreSQL notification channel.
+// Notifications arrive as "notify:message" events until Unlisten is called or
+// the session disconnects; the subscription survives reconnects.
+func (m *Manager) Listen(channel string) error {
+	if channel == "" {
+		return fmt.Errorf("channel name is required")
+	}
+
+	m.mu.RLock()
+	driver, ok := m.driver.(*PostgresDriver)
+	config := m.config
+	m.mu.RUnlock()
+	if config == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	if !ok {
+		return fmt.Errorf("LISTEN/NOTIFY is only supported on PostgreSQL")
+	}
+
+	m.listener.mu.Lock()
+	defer m.listener.mu.Unlock()
+	if m.listener.listener == nil {
+		l := pq.NewListener(driver.buildDSN(*config), time.Second, time.Minute, m.listenerEvent)
+		m.listener.listener, m.listener.stop = l, make(chan struct{})
+		m.listener.channels = make(map[string]bool)
+		go m.receiveNotifications(l, m.listener.stop)
+	}
+
+	if err := m.listener.listener.Listen(channel); err != nil && err != pq.ErrChannelAlreadyOpen {
+		if len(m.listener.channels) == 0 {
+			m.stopListener()
+		}
+		return fmt.Errorf("failed to listen on %s: %w", channel, err)
+	}
+	m.listener.channels[channel] = true
+	return nil
+}
+
+// Unlisten unsubscribes the session from a notification channel. The
+// listener's connection is closed once no channel is left.
+func (m *Manager) Unlisten(channel string) error {
+	m.listener.mu.Lock()
+	defer m.listener.mu.Unlock()
+	l := m.listener.listener
+	if l == nil {
+		return fmt.Errorf("not listening on %s", channel)
+	}
+
+	if err := l.Unlisten(channel); err != nil {
+		if err == pq.ErrChannelNotOpen {
+			return fmt.Errorf("not listening on %s", channel)
+		}
+		return fmt.Errorf("failed to unlisten %s: %w", channel, err)
+	}
+	delete(m.listener.channels, channel)
+	if len(m.listener.channels) == 0 {
+		m.stopListener()
+	}
+	return nil
+}
+
+// ListeningChannels returns the channels the session listens on, sorted
+func (m *Manager) ListeningChannels() []string {
+	m.listener.mu.Lock()
+	defer m.listener.mu.Unlock()
+	return m.listeningChannels()
+}
+
+// Notify sends a notification on a channel, e.g. to try out a listener
+func (m *Manager) Notify(channel, payload string) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	if _, ok := m.currentDriver().(*PostgresDriver); !ok {
+		return fmt.Errorf("LISTEN/NOTIFY is only supported on PostgreSQL")
+	}
+	if _, err := db.Exec("SELECT pg_notify($1, $2)", channel, payload); err != nil {
+		return fmt.Errorf("failed to notify %s: %w", channel, err)
+	}
+	return nil
+}
+
+// closeListener stops listening on every channel, e.g. before disconnecting
+func (m *Manager) closeListener() {
+	m.listener.mu.Lock()
+	defer m.listener.mu.Unlock()
+	m.stopListener()
+}
+
+// stopListener closes the listener's connection. The caller holds m.listener.mu.
+func (m *Manager) stopListener() {
+	if m.listener.listener == nil {
+		return
+	}
+	close(m.listener.stop)
+	m.listener.listener.Close()
+	m.listener.listener, m.listener.channels, m.listener.stop = nil, nil, nil
+}
+
+// listeningChannels lists the channels of the listener. The caller holds m.listener.mu.
+func (m *Manager) listeningChannels() []string {
+	channels := []string{}
+	for channel := range m.listener.channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// receiveNotifications emits the notifications of a listener until stop is closed
+func (m *Manager) receiveNotifications(l *pq.Listener, stop chan struct{}) {
+	ping := time.NewTicker(listenerPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case n := <-l.Notify:
+			// A nil notification follows a reconnect; messages sent meanwhile are lost
+			if n == nil {
+				continue
+			}
+			m.emit("notify:message", Notification{
+				Channel:    n.Channel,
+				Payload:    n.Extra,
+				PID:        n.BePid,
+				ReceivedAt: time.Now().Format("2006-01-02T15:04:05.000Z07:00"),
+			})
+		case <-ping.C:
+			go l.Ping()
+		}
+	}
+}
+
+// listenerEvent reports the listener's connection changes to the frontend
+func (m *Manager) listenerEvent(event pq.ListenerEventType, err error) {
+	status := ListenerStatus{}
+	switch event {
+	case pq.ListenerEventConnected:
+		status.State = "connected"
+	case pq.ListenerEventDisconnected:
+		status.State = "disconnected"
+	case pq.ListenerEventReconnected:
+		status.State = "reconnected"
+	case pq.ListenerEventConnectionAttemptFailed:
+		status.State = "failed"
+	}
+	if err != nil {
+		status.Error = redactSecrets(strings.TrimSpace(err.Error()))
+	}
+	m.emit("notify:status", status)
+}
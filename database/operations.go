@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// serverCancelTimeout bounds how long cancelling a statement on the server may take
+const serverCancelTimeout = 5 * time.Second
+
+// Operation is a long-running piece of work in flight on the connection, such
+// as a query, an export or a load test
+type Operation struct {
+	ID          int64  `json:"id"`
+	Kind        string `json:"kind"`        // query, statement, sql, stream, job, export, import, dump, backup, restore, copy, benchmark, loadtest
+	Description string `json:"description"` // Statement text or table name
+	Started     string `json:"started"`     // RFC 3339
+}
+
+// operationTracker records the operations in flight so they can be cancelled
+// when the app quits
+type operationTracker struct {
+	mu     sync.Mutex
+	nextID int64
+	ops    map[int64]*trackedOperation
+	idle   *sync.Cond
+}
+
+type trackedOperation struct {
+	info   Operation
+	cancel context.CancelFunc
+
+	// backend is the server id of the connection running the operation's
+	// statements, or 0 when it has none reserved. mu keeps the connection
+	// from going back to the pool while its statement is being cancelled.
+	mu      sync.Mutex
+	backend int64
+}
+
+// operationKey is the context key holding the id of a tracked operation
+type operationKey struct{}
+
+func newOperationTracker() *operationTracker {
+	t := &operationTracker{ops: make(map[int64]*trackedOperation)}
+	t.idle = sync.NewCond(&t.mu)
+	return t
+}
+
+// track registers an operation. Statements run with the returned context are
+// aborted by CancelOperations; done must be called when the operation ends.
+func (m *Manager) track(kind, description string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	t := m.operations
+	t.mu.Lock()
+	t.nextID++
+	id := t.nextID
+	t.ops[id] = &trackedOperation{
+		info: Operation{
+			ID:          id,
+			Kind:        kind,
+			Description: description,
+			Started:     time.Now().Format(time.RFC3339),
+		},
+		cancel: cancel,
+	}
+	t.mu.Unlock()
+
+	var once sync.Once
+	return context.WithValue(ctx, operationKey{}, id), func() {
+		once.Do(func() {
+			cancel()
+			t.mu.Lock()
+			delete(t.ops, id)
+			if len(t.ops) == 0 {
+				t.idle.Broadcast()
+			}
+			t.mu.Unlock()
+		})
+	}
+}
+
+// ActiveOperations lists the operations in flight, oldest first
+func (m *Manager) ActiveOperations() []Operation {
+	t := m.operations
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ops := make([]Operation, 0, len(t.ops))
+	for _, op := range t.ops {
+		ops = append(ops, op.info)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].ID < ops[j].ID })
+	return ops
+}
+
+// CancelOperations aborts every operation in flight. Their statements are
+// interrupted and open transactions are rolled back.
+func (m *Manager) CancelOperations() {
+	t := m.operations
+	t.mu.Lock()
+	ops := make([]*trackedOperation, 0, len(t.ops))
+	for _, op := range t.ops {
+		ops = append(ops, op)
+	}
+	t.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, op := range ops {
+		wg.Add(1)
+		go func(op *trackedOperation) {
+			defer wg.Done()
+			m.cancelOperation(op)
+		}(op)
+	}
+	wg.Wait()
+}
+
+// CancelOperation aborts a single operation, e.g. when the user stops a query
+func (m *Manager) CancelOperation(id int64) error {
+	t := m.operations
+	t.mu.Lock()
+	op, ok := t.ops[id]
+	t.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("operation %d is not running", id)
+	}
+
+	m.cancelOperation(op)
+	return nil
+}
+
+// cancelOperation stops the statement the operation runs on the server, then
+// cancels its context. Cancelling the context alone leaves a MySQL statement
+// running on the server after the client has given up on it.
+func (m *Manager) cancelOperation(op *trackedOperation) {
+	m.cancelBackend(op)
+	op.cancel()
+}
+
+// cancelBackend stops the statement running on the operation's connection,
+// leaving the connection usable, and reports whether it could
+func (m *Manager) cancelBackend(op *trackedOperation) bool {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	db := m.getDB()
+	if op.backend == 0 || db == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), serverCancelTimeout)
+	defer cancel()
+	_, err := db.withContext(ctx).Exec(m.currentDriver().BuildCancelQuery(op.backend))
+	return err == nil
+}
+
+// session reserves a connection for the operation tracked by ctx and records
+// its server id, so that cancelling the operation also stops its statement
+// on the server. Looking the id up costs a roundtrip, so it is only used for
+// statements the user may stop: those of the editor, streams, benchmarks and
+// analyses.
+func (m *Manager) session(ctx context.Context, db *instrumentedDB) (*instrumentedConn, error) {
+	conn, err := db.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bookkeeping stays out of the activity log and interceptors
+	var backend int64
+	if err := conn.conn.QueryRowContext(ctx, m.currentDriver().BackendIDQuery()).Scan(&backend); err != nil {
+		return conn, nil
+	}
+	conn.release = m.attachBackend(ctx, backend)
+	return conn, nil
+}
+
+// attachBackend records the server id of the connection running the
+// operation tracked by ctx; the returned func detaches it before the
+// connection is used for anything else
+func (m *Manager) attachBackend(ctx context.Context, backend int64) func() {
+	id, _ := ctx.Value(operationKey{}).(int64)
+	t := m.operations
+	t.mu.Lock()
+	op := t.ops[id]
+	t.mu.Unlock()
+	if op == nil {
+		return func() {}
+	}
+
+	op.mu.Lock()
+	op.backend = backend
+	op.mu.Unlock()
+	return func() {
+		op.mu.Lock()
+		op.backend = 0
+		op.mu.Unlock()
+	}
+}
+
+// WaitOperations blocks until no operation is in flight or the timeout
+// elapses, and reports whether everything finished. A timeout of zero waits
+// indefinitely.
+func (m *Manager) WaitOperations(timeout time.Duration) bool {
+	t := m.operations
+	finished := make(chan struct{})
+	go func() {
+		t.mu.Lock()
+		for len(t.ops) > 0 {
+			t.idle.Wait()
+		}
+		t.mu.Unlock()
+		close(finished)
+	}()
+
+	if timeout <= 0 {
+		<-finished
+		return true
+	}
+	select {
+	case <-finished:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Shutdown cancels the operations in flight, gives them up to timeout to roll
+// back and clean up, then closes the connection pool, tunnel and lease
+func (m *Manager) Shutdown(timeout time.Duration) error {
+	m.CancelOperations()
+	m.WaitOperations(timeout)
+	return m.Disconnect()
+}
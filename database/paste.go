@@ -0,0 +1,337 @@
+package database
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PasteOptions controls how pasted text is read into rows of a table
+type PasteOptions struct {
+	Delimiter   string   `json:"delimiter,omitempty"`   // Tab or comma; detected from the first line when empty
+	Header      string   `json:"header,omitempty"`      // auto (default), yes or no: whether the first row names the columns
+	Mapping     []string `json:"mapping,omitempty"`     // Target column of each pasted column, "" skips it; defaults to header names or positions
+	PreviewRows int      `json:"previewRows,omitempty"` // Number of rows returned by PreviewPaste
+}
+
+// PastePreview holds pasted rows as they would be inserted
+type PastePreview struct {
+	Delimiter string          `json:"delimiter"`
+	Header    bool            `json:"header"`  // The first row named the columns and is not inserted
+	Source    []string        `json:"source"`  // Header names of the pasted columns, or Column n
+	Mapping   []string        `json:"mapping"` // Target column of each pasted column, "" when skipped
+	Columns   []string        `json:"columns"` // Target columns of Rows
+	Rows      [][]interface{} `json:"rows"`    // Values converted to the types of their columns
+	TotalRows int             `json:"totalRows"`
+	Errors    []PasteError    `json:"errors"` // Values that don't fit their column; pasting is refused while there are any
+}
+
+// PasteError is a pasted value that can't be converted to its column's type
+type PasteError struct {
+	Row     int    `json:"row"` // From 1, not counting the header
+	Column  string `json:"column"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
+// maxPasteErrors bounds the errors reported by a paste
+const maxPasteErrors = 100
+
+// pasteData is pasted text converted to rows of a table
+type pasteData struct {
+	preview PastePreview
+	rows    []map[string]interface{}
+}
+
+// PreviewPaste parses tab or comma separated text, such as cells copied from
+// a spreadsheet, and returns its rows as they would be inserted into a table
+func (m *Manager) PreviewPaste(database, schema, table, text string, opts PasteOptions) (*PastePreview, error) {
+	data, err := m.readPaste(database, schema, table, text, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := opts.PreviewRows
+	if limit <= 0 {
+		limit = 50
+	}
+	limit = min(limit, len(data.rows))
+
+	preview := data.preview
+	preview.Rows = make([][]interface{}, 0, limit)
+	for _, row := range data.rows[:limit] {
+		values := make([]interface{}, len(preview.Columns))
+		for i, name := range preview.Columns {
+			values[i] = row[name]
+		}
+		preview.Rows = append(preview.Rows, values)
+	}
+	return &preview, nil
+}
+
+// PasteRows inserts pasted text into a table in a single transaction. Nothing
+// is inserted while any value doesn't fit its column.
+func (m *Manager) PasteRows(database, schema, table, text string, opts PasteOptions) (*ImportResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	data, err := m.readPaste(database, schema, table, text, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.preview.Errors) > 0 {
+		e := data.preview.Errors[0]
+		return nil, fmt.Errorf("row %d column %s: %s", e.Row, e.Column, e.Message)
+	}
+	if len(data.rows) == 0 {
+		return nil, fmt.Errorf("no rows to paste")
+	}
+
+	ctx, done := m.track("paste", table)
+	defer done()
+
+	tx, err := db.withContext(ctx).Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &ImportResult{}
+	columns := data.preview.Columns
+	progress := m.newProgress("paste", table, int64(len(data.rows)))
+	if driver, ok := m.tableDriver(schema).(*PostgresDriver); ok {
+		result.RowsImported, err = driver.copyRows(tx, table, columns, data.rows, progress)
+	} else {
+		result.RowsImported, err = m.insertRows(tx, database, schema, table, columns, data.rows, progress)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit paste: %w", err)
+	}
+	m.forgetUndo(database, schema, table)
+	progress.finish()
+	return result, nil
+}
+
+// readPaste parses pasted text, maps its columns to those of the table and
+// converts the values by column type
+func (m *Manager) readPaste(database, schema, table, text string, opts PasteOptions) (*pasteData, error) {
+	delimiter := opts.Delimiter
+	if delimiter == "" {
+		delimiter = ","
+		firstLine, _, _ := strings.Cut(text, "\n")
+		if strings.Contains(firstLine, "\t") {
+			delimiter = "\t"
+		}
+	}
+	if len([]rune(delimiter)) != 1 {
+		return nil, fmt.Errorf("delimiter must be a single character")
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = []rune(delimiter)[0]
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid pasted data: %w", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("nothing to paste")
+	}
+
+	columns, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]ColumnInfo, len(columns))
+	lower := make(map[string]string, len(columns))
+	var writable []string
+	for _, col := range columns {
+		byName[col.Name] = col
+		lower[strings.ToLower(col.Name)] = col.Name
+		if !col.ReadOnly {
+			writable = append(writable, col.Name)
+		}
+	}
+
+	width := 0
+	for _, record := range records {
+		width = max(width, len(record))
+	}
+
+	// The first row is a header when every field of it names a column
+	header := opts.Header == "yes"
+	if opts.Header == "" || opts.Header == "auto" {
+		header = true
+		for _, field := range records[0] {
+			if _, ok := lower[strings.ToLower(strings.TrimSpace(field))]; !ok {
+				header = false
+				break
+			}
+		}
+	}
+
+	preview := PastePreview{Delimiter: delimiter, Header: header, Source: make([]string, width), Mapping: make([]string, width)}
+	for i := range preview.Source {
+		preview.Source[i] = fmt.Sprintf("Column %d", i+1)
+		if header && i < len(records[0]) {
+			preview.Source[i] = strings.TrimSpace(records[0][i])
+		}
+	}
+	if header {
+		records = records[1:]
+	}
+
+	switch {
+	case len(opts.Mapping) > 0:
+		copy(preview.Mapping, opts.Mapping)
+	case header:
+		for i, name := range preview.Source {
+			preview.Mapping[i] = lower[strings.ToLower(name)]
+		}
+	default:
+		copy(preview.Mapping, writable)
+	}
+
+	seen := make(map[string]bool)
+	for i, name := range preview.Mapping {
+		if name == "" {
+			continue
+		}
+		col, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in table %s", name, table)
+		}
+		if col.ReadOnly {
+			// Generated and identity columns are filled by the database
+			if len(opts.Mapping) > 0 {
+				return nil, fmt.Errorf("column %s is read-only", name)
+			}
+			preview.Mapping[i] = ""
+			continue
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("column %s is mapped more than once", name)
+		}
+		seen[name] = true
+		preview.Columns = append(preview.Columns, name)
+	}
+	if len(preview.Columns) == 0 {
+		return nil, fmt.Errorf("no pasted column is mapped to a column of the table")
+	}
+
+	data := &pasteData{rows: make([]map[string]interface{}, 0, len(records))}
+	for r, record := range records {
+		// Spreadsheets end the copied range with an empty line
+		if len(record) == 1 && record[0] == "" {
+			continue
+		}
+		row := make(map[string]interface{}, len(preview.Columns))
+		for i, name := range preview.Mapping {
+			if name == "" {
+				continue
+			}
+			field := ""
+			if i < len(record) {
+				field = record[i]
+			}
+			value, err := pasteValue(byName[name], field)
+			if err != nil && len(preview.Errors) < maxPasteErrors {
+				preview.Errors = append(preview.Errors, PasteError{Row: r + 1, Column: name, Value: field, Message: err.Error()})
+			}
+			row[name] = value
+		}
+		data.rows = append(data.rows, row)
+	}
+	preview.TotalRows = len(data.rows)
+	data.preview = preview
+	return data, nil
+}
+
+// pasteValue converts a pasted field to a parameter for its column. Empty
+// fields and NULL are NULL; dates and times are left for the server to parse.
+func pasteValue(col ColumnInfo, field string) (interface{}, error) {
+	if field == "" || field == "NULL" {
+		return nil, nil
+	}
+	text := strings.TrimSpace(field)
+
+	if isGeoColumn(col) {
+		return geoParam(col.Name, text)
+	}
+	if isArrayColumn(col) {
+		// Lists as copied from the grid, e.g. ["a","b"]
+		if strings.HasPrefix(text, "[") {
+			var list []interface{}
+			if err := json.Unmarshal([]byte(text), &list); err != nil {
+				return nil, fmt.Errorf("invalid array: %w", err)
+			}
+			return pgArrayLiteral(list), nil
+		}
+		return field, nil
+	}
+
+	switch typeCategory(col.Type) {
+	case typeCategoryInteger:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("not an integer")
+		}
+		return n, nil
+	case typeCategoryFloat:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("not a number")
+		}
+		return f, nil
+	case typeCategoryDecimal:
+		// Passed as text to keep every digit
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return nil, fmt.Errorf("not a number")
+		}
+		return text, nil
+	case typeCategoryBoolean:
+		switch strings.ToLower(text) {
+		case "true", "t", "yes", "y", "1":
+			return true, nil
+		case "false", "f", "no", "n", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("not a boolean")
+	case typeCategoryJSON:
+		if !json.Valid([]byte(text)) {
+			return nil, fmt.Errorf("invalid JSON")
+		}
+		return text, nil
+	case typeCategoryBinary:
+		// Hex as copied from the grid, e.g. \xdeadbeef or 0xDEADBEEF
+		for _, prefix := range []string{`\x`, "0x", "0X"} {
+			if strings.HasPrefix(text, prefix) {
+				data, err := hex.DecodeString(text[len(prefix):])
+				if err != nil {
+					return nil, fmt.Errorf("invalid hex: %w", err)
+				}
+				return data, nil
+			}
+		}
+		return []byte(field), nil
+	}
+	return field, nil
+}
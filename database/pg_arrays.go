@@ -0,0 +1,217 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// isArrayColumn reports whether a column is a PostgreSQL array
+func isArrayColumn(col ColumnInfo) bool {
+	return col.ElementType != ""
+}
+
+// structureArrays replaces the array literals of a page, such as {1,2} or
+// {"a b",NULL}, by lists of their elements so that the grid can show them
+// element by element. Values that don't parse are left as text.
+func structureArrays(columns []ColumnInfo, rows [][]interface{}) {
+	for i, col := range columns {
+		if !isArrayColumn(col) {
+			continue
+		}
+		category := typeCategory(col.ElementType)
+		for _, row := range rows {
+			if i >= len(row) {
+				continue
+			}
+			text, ok := row[i].(string)
+			if !ok {
+				continue
+			}
+			if list, err := parsePGArray(text, category); err == nil {
+				row[i] = list
+			}
+		}
+	}
+}
+
+// parsePGArray parses the text form of an array. Elements of numeric and
+// boolean arrays are converted to numbers and booleans; others stay strings.
+// Nested arrays become nested lists.
+func parsePGArray(text string, category string) ([]interface{}, error) {
+	// Arrays with custom bounds are prefixed by them, e.g. [0:1]={a,b}
+	if strings.HasPrefix(text, "[") {
+		if idx := strings.Index(text, "="); idx >= 0 {
+			text = text[idx+1:]
+		}
+	}
+	p := &pgArrayParser{text: text, category: category}
+	list, err := p.parseArray()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.text) {
+		return nil, fmt.Errorf("unexpected %q after array", p.text[p.pos:])
+	}
+	return list, nil
+}
+
+type pgArrayParser struct {
+	text     string
+	pos      int
+	category string
+}
+
+func (p *pgArrayParser) parseArray() ([]interface{}, error) {
+	if p.pos >= len(p.text) || p.text[p.pos] != '{' {
+		return nil, fmt.Errorf("array literal must start with {")
+	}
+	p.pos++
+
+	list := []interface{}{}
+	if p.pos < len(p.text) && p.text[p.pos] == '}' {
+		p.pos++
+		return list, nil
+	}
+	for {
+		if p.pos >= len(p.text) {
+			return nil, fmt.Errorf("unterminated array literal")
+		}
+
+		var elem interface{}
+		var err error
+		switch p.text[p.pos] {
+		case '{':
+			elem, err = p.parseArray()
+		case '"':
+			elem, err = p.parseQuoted()
+		default:
+			elem, err = p.parseBare()
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, elem)
+
+		if p.pos >= len(p.text) {
+			return nil, fmt.Errorf("unterminated array literal")
+		}
+		switch p.text[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return list, nil
+		default:
+			return nil, fmt.Errorf("unexpected %q in array literal", p.text[p.pos])
+		}
+	}
+}
+
+// parseQuoted reads a double-quoted element, in which backslash escapes the
+// next character. A quoted NULL is the string "NULL".
+func (p *pgArrayParser) parseQuoted() (interface{}, error) {
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		switch c {
+		case '\\':
+			p.pos++
+			if p.pos < len(p.text) {
+				b.WriteByte(p.text[p.pos])
+			}
+		case '"':
+			p.pos++
+			return p.element(b.String()), nil
+		default:
+			b.WriteByte(c)
+		}
+		p.pos++
+	}
+	return nil, fmt.Errorf("unterminated quoted element")
+}
+
+// parseBare reads an unquoted element up to the next delimiter
+func (p *pgArrayParser) parseBare() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.text) && p.text[p.pos] != ',' && p.text[p.pos] != '}' {
+		p.pos++
+	}
+	s := strings.TrimSpace(p.text[start:p.pos])
+	if strings.EqualFold(s, "NULL") {
+		return nil, nil
+	}
+	return p.element(s), nil
+}
+
+// element converts the text of an element by the category of the array's
+// element type. Decimals stay strings to keep them exact, as do integers
+// beyond the precision of JavaScript numbers.
+func (p *pgArrayParser) element(s string) interface{} {
+	switch p.category {
+	case typeCategoryInteger:
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return scanValue(n)
+		}
+	case typeCategoryFloat:
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case typeCategoryBoolean:
+		switch s {
+		case "t", "true":
+			return true
+		case "f", "false":
+			return false
+		}
+	}
+	return s
+}
+
+// pgArrayLiteral encodes a list received from the frontend as the text form
+// of an array. Every element is quoted, which PostgreSQL accepts for any
+// element type.
+func pgArrayLiteral(list []interface{}) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, elem := range list {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		switch v := elem.(type) {
+		case nil:
+			b.WriteString("NULL")
+		case []interface{}:
+			b.WriteString(pgArrayLiteral(v))
+		default:
+			b.WriteByte('"')
+			b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arrayElementText(v)))
+			b.WriteByte('"')
+		}
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// arrayElementText renders a scalar element of a list
+func arrayElementText(v interface{}) string {
+	switch val := paramValue(v).(type) {
+	case string:
+		return val
+	case bool:
+		if val {
+			return "t"
+		}
+		return "f"
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case map[string]interface{}:
+		// Elements of json/jsonb arrays
+		encoded, _ := json.Marshal(val)
+		return string(encoded)
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -2,20 +2,85 @@ package database
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
-type PostgresDriver struct{}
+// pgErrInsufficientPrivilege is the SQLSTATE of a statement the role lacks a
+// privilege for
+const pgErrInsufficientPrivilege = "42501"
 
-func (d *PostgresDriver) Connect(config ConnectionConfig) (*sql.DB, error) {
-	sslmode := "disable"
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		config.Host, config.Port, config.User, config.Password, config.Database, sslmode)
+// PostgresDriver implements Driver for PostgreSQL. Tables are resolved in
+// Schema, which defaults to public.
+type PostgresDriver struct {
+	Schema string
+}
+
+// schemaName returns the schema tables are looked up in
+func (d *PostgresDriver) schemaName() string {
+	if d.Schema == "" {
+		return "public"
+	}
+	return d.Schema
+}
+
+// pgServerVersion returns the server version as in server_version_num, e.g.
+// 120005 for 12.5, or 0 when it cannot be read
+func pgServerVersion(db Querier) int {
+	var version int
+	db.QueryRow("SHOW server_version_num").Scan(&version)
+	return version
+}
 
-	db, err := sql.Open("postgres", connStr)
+// pgLeafPartitions returns a subquery of the leaf partitions of the relation
+// c. pg_partition_tree is only available from PostgreSQL 12; older servers
+// walk pg_inherits instead.
+func pgLeafPartitions(version int) string {
+	if version >= 120000 {
+		return "(SELECT relid FROM pg_partition_tree(c.oid) WHERE isleaf)"
+	}
+	return `(
+					WITH RECURSIVE tree(relid) AS (
+						SELECT c.oid
+						UNION ALL
+						SELECT inh.inhrelid FROM pg_inherits inh JOIN tree ON inh.inhparent = tree.relid
+					)
+					SELECT tree.relid FROM tree JOIN pg_class leaf ON leaf.oid = tree.relid WHERE leaf.relkind <> 'p'
+				)`
+}
+
+// pgPartitionColumns returns the partition key, parent and bound columns of
+// the relation c, which are empty before PostgreSQL 10 introduced
+// declarative partitioning
+func pgPartitionColumns(version int, parent string) string {
+	if version > 0 && version < 100000 {
+		return "'', '', ''"
+	}
+	return fmt.Sprintf("COALESCE(pg_get_partkeydef(c.oid), ''), COALESCE(%s, ''), COALESCE(pg_get_expr(c.relpartbound, c.oid), '')", parent)
+}
+
+// pgIsPartition returns the condition that the relation c is a partition
+func pgIsPartition(version int) string {
+	if version > 0 && version < 100000 {
+		return "false"
+	}
+	return "c.relispartition"
+}
+
+// qualify returns the schema-qualified, quoted name of a relation
+func (d *PostgresDriver) qualify(name string) string {
+	return qualifiedName(d.QuoteIdentifier, d.schemaName(), name)
+}
+
+func (d *PostgresDriver) Connect(config ConnectionConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", d.buildDSN(config))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
 	}
@@ -28,7 +93,100 @@ func (d *PostgresDriver) Connect(config ConnectionConfig) (*sql.DB, error) {
 	return db, nil
 }
 
-func (d *PostgresDriver) GetDatabases(db *sql.DB) ([]string, error) {
+// buildDSN constructs the key/value connection string, quoting values so
+// passwords with spaces or quotes survive and are caught by redactSecrets
+func (d *PostgresDriver) buildDSN(config ConnectionConfig) string {
+	sslmode := "disable"
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		pqValue(config.Host), config.Port, pqValue(config.User), pqValue(config.Password), pqValue(config.Database), sslmode)
+}
+
+// pqValue quotes a connection string value when it is empty or contains
+// whitespace, quotes or backslashes
+func pqValue(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\\") {
+		return s
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
+
+func (d *PostgresDriver) ConnectionStrings(config ConnectionConfig) ConnectionStrings {
+	config = maskedConfig(config)
+	host := net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+
+	// Connect doesn't negotiate TLS yet, so neither do the generated strings
+	sslmode := "disable"
+	uri := &url.URL{
+		Scheme:   "postgresql",
+		User:     url.UserPassword(config.User, config.Password),
+		Host:     host,
+		Path:     "/" + config.Database,
+		RawQuery: "sslmode=" + sslmode,
+	}
+	jdbcParams := url.Values{"user": {config.User}, "password": {config.Password}, "sslmode": {sslmode}}
+	if d.Schema != "" {
+		jdbcParams.Set("currentSchema", d.Schema)
+	}
+
+	return ConnectionStrings{
+		URI: plainMask(uri.String()),
+		CLI: fmt.Sprintf("psql %s", shellQuote(fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=%s",
+			pqValue(config.Host), config.Port, pqValue(config.User), pqValue(config.Database), sslmode))),
+		JDBC: plainMask(fmt.Sprintf("jdbc:postgresql://%s/%s?%s", host, url.PathEscape(config.Database), jdbcParams.Encode())),
+		Go:   fmt.Sprintf("db, err := sql.Open(\"postgres\", %q) // github.com/lib/pq", d.buildDSN(config)),
+	}
+}
+
+func (d *PostgresDriver) GetDiagnostics(db Querier, diag *ConnectionDiagnostics) {
+	if err := db.QueryRow("SHOW server_version").Scan(&diag.ServerVersion); err != nil {
+		diag.Warnings = append(diag.Warnings, fmt.Sprintf("server version: %v", err))
+	}
+
+	var schema sql.NullString
+	if err := db.QueryRow("SELECT current_user, current_schema()").Scan(&diag.CurrentUser, &schema); err != nil {
+		diag.Warnings = append(diag.Warnings, fmt.Sprintf("current user: %v", err))
+	}
+	diag.DefaultSchema = schema.String
+
+	var tlsVersion sql.NullString
+	err := db.QueryRow("SELECT ssl, version FROM pg_stat_ssl WHERE pid = pg_backend_pid()").Scan(&diag.TLS, &tlsVersion)
+	if err != nil && err != sql.ErrNoRows {
+		diag.Warnings = append(diag.Warnings, fmt.Sprintf("TLS status: %v", err))
+	}
+	diag.TLSVersion = tlsVersion.String
+
+	var super, createDB, createRole, replication, canCreate, canConnect bool
+	err = db.QueryRow(`
+		SELECT rolsuper, rolcreatedb, rolcreaterole, rolreplication,
+			has_database_privilege(current_database(), 'CREATE'),
+			has_database_privilege(current_database(), 'CONNECT')
+		FROM pg_roles WHERE rolname = current_user
+	`).Scan(&super, &createDB, &createRole, &replication, &canCreate, &canConnect)
+	if err != nil {
+		diag.Warnings = append(diag.Warnings, fmt.Sprintf("permissions: %v", err))
+		return
+	}
+
+	for _, p := range []struct {
+		granted bool
+		name    string
+	}{
+		{super, "SUPERUSER"},
+		{createDB, "CREATEDB"},
+		{createRole, "CREATEROLE"},
+		{replication, "REPLICATION"},
+		{canConnect, "CONNECT"},
+		{canCreate, "CREATE"},
+	} {
+		if p.granted {
+			diag.Permissions = append(diag.Permissions, p.name)
+		}
+	}
+}
+
+func (d *PostgresDriver) GetDatabases(db Querier) ([]string, error) {
 	rows, err := db.Query("SELECT datname FROM pg_database WHERE datistemplate = false")
 	if err != nil {
 		return nil, err
@@ -46,19 +204,63 @@ func (d *PostgresDriver) GetDatabases(db *sql.DB) ([]string, error) {
 	return databases, nil
 }
 
-func (d *PostgresDriver) GetTables(db *sql.DB, database string) ([]TableInfo, error) {
-	// Simple table list for Postgres
-	query := `
-		SELECT 
-			table_name, 
-			'heap' as engine,
-			0 as row_count,
-			0 as data_size,
-			'' as create_time
-		FROM information_schema.tables 
-		WHERE table_schema = 'public'
-	`
-	rows, err := db.Query(query)
+func (d *PostgresDriver) GetSchemas(db Querier) ([]string, error) {
+	rows, err := db.Query(`
+		SELECT nspname
+		FROM pg_namespace
+		WHERE nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+			AND nspname NOT LIKE 'pg_temp_%' AND nspname NOT LIKE 'pg_toast_temp_%'
+		ORDER BY nspname = 'public' DESC, nspname
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schemas := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, name)
+	}
+	return schemas, rows.Err()
+}
+
+func (d *PostgresDriver) GetTables(db Querier, database string) ([]TableInfo, error) {
+	// Partitions are listed alongside their parent, which reports the totals
+	// of its leaf partitions. Row counts are planner estimates; reltuples is -1
+	// until a table is first vacuumed or analyzed.
+	version := pgServerVersion(db)
+	query := fmt.Sprintf(`
+		SELECT
+			c.relname,
+			CASE c.relkind WHEN 'p' THEN 'partitioned' WHEN 'v' THEN 'view' WHEN 'f' THEN 'foreign' ELSE 'heap' END as engine,
+			CASE WHEN c.relkind = 'p' THEN COALESCE((
+				SELECT SUM(GREATEST(l.reltuples, 0))::bigint
+				FROM pg_class l
+				WHERE l.oid IN %[1]s
+			), 0) ELSE GREATEST(c.reltuples, 0)::bigint END as row_count,
+			CASE WHEN c.relkind = 'p' THEN COALESCE((
+				SELECT SUM(pg_total_relation_size(l.oid))::bigint
+				FROM pg_class l
+				WHERE l.oid IN %[1]s
+			), 0) ELSE pg_total_relation_size(c.oid) END as data_size,
+			'' as create_time,
+			COALESCE(to_char(GREATEST(s.last_vacuum, s.last_autovacuum), 'YYYY-MM-DD HH24:MI:SS'), '') as last_vacuum,
+			COALESCE(obj_description(c.oid, 'pg_class'), '') as comment,
+			c.relkind = 'p',
+			%[2]s
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_inherits i ON i.inhrelid = c.oid AND %[3]s
+		LEFT JOIN pg_class parent ON parent.oid = i.inhparent
+		LEFT JOIN pg_stat_all_tables s ON s.relid = c.oid
+		WHERE n.nspname = $1 AND c.relkind IN ('r', 'p', 'v', 'f')
+		ORDER BY c.relname
+	`, pgLeafPartitions(version), pgPartitionColumns(version, "parent.relname"), pgIsPartition(version))
+	rows, err := db.Query(query, d.schemaName())
 	if err != nil {
 		return nil, err
 	}
@@ -67,94 +269,921 @@ func (d *PostgresDriver) GetTables(db *sql.DB, database string) ([]TableInfo, er
 	var tables []TableInfo
 	for rows.Next() {
 		var t TableInfo
-		if err := rows.Scan(&t.Name, &t.Engine, &t.RowCount, &t.DataSize, &t.CreateTime); err != nil {
+		if err := rows.Scan(&t.Name, &t.Engine, &t.RowCount, &t.DataSize, &t.CreateTime, &t.LastVacuum, &t.Comment,
+			&t.Partitioned, &t.PartitionKey, &t.Parent, &t.PartitionBound); err != nil {
 			return nil, err
 		}
+		t.Schema = d.schemaName()
 		tables = append(tables, t)
 	}
 	return tables, nil
 }
 
-func (d *PostgresDriver) GetColumns(db *sql.DB, database, table string) ([]ColumnInfo, error) {
+func (d *PostgresDriver) GetViews(db Querier, database string) ([]ViewInfo, error) {
+	query := `
+		SELECT c.relname, pg_get_viewdef(c.oid, true)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'v' AND n.nspname = $1
+		ORDER BY c.relname
+	`
+	rows, err := db.Query(query, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []ViewInfo{}
+	for rows.Next() {
+		var v ViewInfo
+		if err := rows.Scan(&v.Name, &v.Definition); err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
+func (d *PostgresDriver) GetMaterializedViews(db Querier, database string) ([]MaterializedViewInfo, error) {
+	query := `
+		SELECT
+			c.relname,
+			pg_get_viewdef(c.oid, true),
+			c.relispopulated,
+			pg_total_relation_size(c.oid),
+			EXISTS (
+				SELECT 1 FROM pg_index ix
+				WHERE ix.indrelid = c.oid AND ix.indisunique AND ix.indpred IS NULL
+			)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'm' AND n.nspname = $1
+		ORDER BY c.relname
+	`
+	rows, err := db.Query(query, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []MaterializedViewInfo{}
+	for rows.Next() {
+		var v MaterializedViewInfo
+		if err := rows.Scan(&v.Name, &v.Definition, &v.Populated, &v.Size, &v.HasUniqueIndex); err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
+func (d *PostgresDriver) GetColumns(db Querier, database, table string) ([]ColumnInfo, error) {
 	query := `
 		SELECT 
 			column_name, 
 			data_type, 
+			udt_schema,
+			udt_name,
 			is_nullable, 
-			'', 
+			CASE WHEN EXISTS (
+				SELECT 1
+				FROM pg_index i
+				JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+				WHERE i.indrelid = format('%I.%I', table_schema, table_name)::regclass
+					AND i.indisprimary AND a.attname = column_name
+			) THEN 'PRI' ELSE '' END, 
 			column_default, 
-			'' 
+			'',
+			COALESCE(col_description(format('%I.%I', table_schema, table_name)::regclass, ordinal_position), ''),
+			COALESCE(identity_generation, ''),
+			COALESCE((
+				SELECT CASE a.attgenerated WHEN 's' THEN 'STORED' WHEN 'v' THEN 'VIRTUAL' ELSE '' END
+				FROM pg_attribute a
+				WHERE a.attrelid = format('%I.%I', table_schema, table_name)::regclass AND a.attnum = ordinal_position
+			), ''),
+			COALESCE(generation_expression, ''),
+			COALESCE(collation_name, ''),
+			COALESCE(domain_schema, ''),
+			COALESCE(domain_name, '')
 		FROM information_schema.columns 
-		WHERE table_name = $1 AND table_schema = 'public'
+		WHERE table_name = $1 AND table_schema = $2
 		ORDER BY ordinal_position
 	`
-	rows, err := db.Query(query, table)
+	rows, err := db.Query(query, table, d.schemaName())
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	var columns []ColumnInfo
+	typeSchemas := make(map[string][]int)   // Schema of a custom type -> columns using it
+	domainSchemas := make(map[string][]int) // Schema of a domain -> columns using it
+	domainNames := make(map[int]string)
 	for rows.Next() {
 		var c ColumnInfo
-		var nullable string
+		var nullable, udtSchema, udtName, domainSchema, domainName string
 		var defaultVal sql.NullString
-		if err := rows.Scan(&c.Name, &c.Type, &nullable, &c.Key, &defaultVal, &c.Extra); err != nil {
+		if err := rows.Scan(&c.Name, &c.Type, &udtSchema, &udtName, &nullable, &c.Key, &defaultVal, &c.Extra, &c.Comment,
+			&c.Identity, &c.Generated, &c.Expression, &c.Collation, &domainSchema, &domainName); err != nil {
 			return nil, err
 		}
+		// Arrays are reported by the name of their element type, e.g. _int4
+		if c.Type == "ARRAY" {
+			c.ElementType = strings.TrimPrefix(udtName, "_")
+			c.Type = c.ElementType + "[]"
+		}
+		// Report enums and other custom types by name instead of USER-DEFINED
+		if c.Type == "USER-DEFINED" {
+			c.Type = udtName
+			typeSchemas[udtSchema] = append(typeSchemas[udtSchema], len(columns))
+		}
+		// Domain columns keep their base type in Type
+		if domainName != "" {
+			domainSchemas[domainSchema] = append(domainSchemas[domainSchema], len(columns))
+			domainNames[len(columns)] = domainName
+		}
 		c.Nullable = nullable == "YES"
+		// column_default is always an expression, e.g. 'a'::text or now()
 		c.Default = defaultVal.String
+		c.DefaultExpression = defaultVal.Valid
+		c.ReadOnly = c.Generated != "" || c.Identity == "ALWAYS"
 		columns = append(columns, c)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Only the types the columns use are read
+	for schema, indexes := range typeSchemas {
+		names := make([]string, len(indexes))
+		for n, i := range indexes {
+			names[n] = columns[i].Type
+		}
+		labels, err := pgEnumLabels(db, schema, names...)
+		if err != nil {
+			return nil, err
+		}
+		attributes, err := pgCompositeAttributes(db, schema, names...)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range indexes {
+			columns[i].EnumValues = labels[columns[i].Type]
+			if attrs, ok := attributes[columns[i].Type]; ok {
+				columns[i].CustomType = &CustomTypeInfo{Name: columns[i].Type, Kind: "composite", Attributes: attrs}
+			}
+		}
+	}
+
+	for schema, indexes := range domainSchemas {
+		names := make([]string, len(indexes))
+		for n, i := range indexes {
+			names[n] = domainNames[i]
+		}
+		domains, err := pgDomains(db, schema, names...)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range indexes {
+			columns[i].CustomType = domains[domainNames[i]]
+		}
+	}
 	return columns, nil
 }
 
-func (d *PostgresDriver) GetIndexes(db *sql.DB, database, table string) ([]IndexInfo, error) {
-	// Simplified indexes for PG PoC
-	return []IndexInfo{}, nil
+func (d *PostgresDriver) GetTableComment(db Querier, database, table string) (string, error) {
+	query := `
+		SELECT COALESCE(obj_description(c.oid, 'pg_class'), '')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relname = $1 AND n.nspname = $2
+	`
+	var comment string
+	if err := db.QueryRow(query, table, d.schemaName()).Scan(&comment); err != nil {
+		return "", err
+	}
+	return comment, nil
+}
+
+// GetTableDDL reconstructs the CREATE statement of a table or view, followed by
+// its standalone indexes and comments
+func (d *PostgresDriver) GetTableDDL(db Querier, database, table string) (string, error) {
+	name := d.qualify(table)
+
+	var relkind, persistence, partKey, parent, bound, comment string
+	version := pgServerVersion(db)
+	err := db.QueryRow(fmt.Sprintf(`
+		SELECT
+			c.relkind,
+			c.relpersistence,
+			%s,
+			COALESCE(obj_description(c.oid, 'pg_class'), '')
+		FROM pg_class c
+		LEFT JOIN pg_inherits i ON i.inhrelid = c.oid AND %s
+		WHERE c.oid = $1::regclass
+	`, pgPartitionColumns(version, "i.inhparent::regclass::text"), pgIsPartition(version)), name).Scan(&relkind, &persistence, &partKey, &parent, &bound, &comment)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	switch relkind {
+	case "v", "m":
+		var definition string
+		if err := db.QueryRow("SELECT pg_get_viewdef($1::regclass, true)", name).Scan(&definition); err != nil {
+			return "", err
+		}
+		kind := "VIEW"
+		if relkind == "m" {
+			kind = "MATERIALIZED VIEW"
+		}
+		fmt.Fprintf(&sb, "CREATE %s %s AS\n%s\n", kind, name, strings.TrimSpace(definition))
+	case "f":
+		ddl, err := d.createTableDDL(db, name, persistence, parent, bound, partKey)
+		if err != nil {
+			return "", err
+		}
+		server, err := d.foreignTableClause(db, name)
+		if err != nil {
+			return "", err
+		}
+		ddl = strings.TrimSuffix(strings.TrimPrefix(ddl, "CREATE TABLE"), ";\n")
+		sb.WriteString("CREATE FOREIGN TABLE" + ddl + server + ";\n")
+	default:
+		ddl, err := d.createTableDDL(db, name, persistence, parent, bound, partKey)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(ddl)
+	}
+
+	// Indexes backing constraints are part of the constraint definitions
+	rows, err := db.Query(`
+		SELECT pg_get_indexdef(i.indexrelid)
+		FROM pg_index i
+		WHERE i.indrelid = $1::regclass
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = i.indexrelid AND c.conrelid = i.indrelid)
+		ORDER BY i.indexrelid::regclass::text
+	`, name)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var def string
+		if err := rows.Scan(&def); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "\n%s;\n", def)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	if comment != "" {
+		fmt.Fprintf(&sb, "\nCOMMENT ON TABLE %s IS %s;\n", name, quoteLiteral(comment))
+	}
+	columns, err := d.GetColumns(db, database, table)
+	if err != nil {
+		return "", err
+	}
+	for _, col := range columns {
+		if col.Comment != "" {
+			fmt.Fprintf(&sb, "COMMENT ON COLUMN %s.%s IS %s;\n", name, d.QuoteIdentifier(col.Name), quoteLiteral(col.Comment))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// createTableDDL renders the CREATE TABLE statement with columns and constraints
+func (d *PostgresDriver) createTableDDL(db Querier, name, persistence, parent, bound, partKey string) (string, error) {
+	var defs []string
+
+	// Partitions inherit their columns and constraints from the parent
+	if parent == "" {
+		rows, err := db.Query(`
+			SELECT
+				a.attname,
+				format_type(a.atttypid, a.atttypmod),
+				a.attnotnull,
+				COALESCE(pg_get_expr(ad.adbin, ad.adrelid), ''),
+				a.attidentity,
+				a.attgenerated,
+				COALESCE(quote_ident(co.collname), '')
+			FROM pg_attribute a
+			JOIN pg_type t ON t.oid = a.atttypid
+			LEFT JOIN pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+			LEFT JOIN pg_collation co ON co.oid = a.attcollation AND a.attcollation <> t.typcollation
+			WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+			ORDER BY a.attnum
+		`, name)
+		if err != nil {
+			return "", err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var col, typ, def, identity, generated, collation string
+			var notNull bool
+			if err := rows.Scan(&col, &typ, &notNull, &def, &identity, &generated, &collation); err != nil {
+				return "", err
+			}
+
+			line := d.QuoteIdentifier(col) + " " + typ
+			if collation != "" {
+				line += " COLLATE " + collation
+			}
+			switch {
+			case identity == "a":
+				line += " GENERATED ALWAYS AS IDENTITY"
+			case identity == "d":
+				line += " GENERATED BY DEFAULT AS IDENTITY"
+			case generated == "s":
+				line += fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", def)
+			case generated == "v":
+				line += fmt.Sprintf(" GENERATED ALWAYS AS (%s) VIRTUAL", def)
+			case def != "":
+				line += " DEFAULT " + def
+			}
+			if notNull {
+				line += " NOT NULL"
+			}
+			defs = append(defs, line)
+		}
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+	}
+
+	rows, err := db.Query(`
+		SELECT conname, pg_get_constraintdef(oid, true)
+		FROM pg_constraint
+		WHERE conrelid = $1::regclass AND conislocal AND contype IN ('p', 'u', 'c', 'f', 'x')
+		ORDER BY CASE contype WHEN 'p' THEN 0 WHEN 'u' THEN 1 WHEN 'c' THEN 2 WHEN 'f' THEN 3 ELSE 4 END, conname
+	`, name)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var conName, def string
+		if err := rows.Scan(&conName, &def); err != nil {
+			return "", err
+		}
+		defs = append(defs, fmt.Sprintf("CONSTRAINT %s %s", d.QuoteIdentifier(conName), def))
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE ")
+	if persistence == "u" {
+		sb.WriteString("UNLOGGED ")
+	}
+	sb.WriteString("TABLE " + name)
+	if parent != "" {
+		fmt.Fprintf(&sb, " PARTITION OF %s", parent)
+	}
+	if len(defs) > 0 {
+		sb.WriteString(" (\n    " + strings.Join(defs, ",\n    ") + "\n)")
+	} else if parent == "" {
+		sb.WriteString(" ()")
+	}
+	if parent != "" {
+		sb.WriteString(" " + bound)
+	}
+	if partKey != "" {
+		sb.WriteString(" PARTITION BY " + partKey)
+	}
+	sb.WriteString(";\n")
+	return sb.String(), nil
+}
+
+// SearchSchema matches pattern, which is lower case, against every non-system schema
+func (d *PostgresDriver) SearchSchema(db Querier, pattern string, limit int) ([]SchemaSearchResult, error) {
+	query := `
+		SELECT kind, schema, tbl, name, detail FROM (
+			SELECT
+				CASE c.relkind WHEN 'v' THEN 'view' WHEN 'm' THEN 'materialized_view' ELSE 'table' END AS kind,
+				n.nspname AS schema, '' AS tbl, c.relname AS name, '' AS detail
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f') AND lower(c.relname) LIKE $1
+			UNION ALL
+			SELECT 'column', n.nspname, c.relname, a.attname, format_type(a.atttypid, a.atttypmod)
+			FROM pg_attribute a
+			JOIN pg_class c ON c.oid = a.attrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f') AND a.attnum > 0 AND NOT a.attisdropped
+				AND lower(a.attname) LIKE $1
+			UNION ALL
+			SELECT
+				CASE p.prokind WHEN 'p' THEN 'procedure' ELSE 'function' END,
+				n.nspname, '', p.proname, pg_get_function_identity_arguments(p.oid)
+			FROM pg_proc p
+			JOIN pg_namespace n ON n.oid = p.pronamespace
+			WHERE p.prokind IN ('f', 'p') AND lower(p.proname) LIKE $1
+		) r
+		WHERE schema <> 'information_schema' AND schema NOT LIKE 'pg\_%'
+		ORDER BY
+			CASE WHEN lower(name) = $2 THEN 0 WHEN lower(name) LIKE $3 THEN 1 ELSE 2 END,
+			length(name), name, schema, tbl
+		LIMIT $4
+	`
+	escaped := escapeLike(pattern)
+	rows, err := db.Query(query, "%"+escaped+"%", pattern, escaped+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanSearchResults(rows)
+}
+
+func (d *PostgresDriver) GetIndexes(db Querier, database, table string) ([]IndexInfo, error) {
+	// Expression columns (attnum 0) are rendered through pg_get_indexdef
+	query := `
+		SELECT
+			ic.relname,
+			ix.indisunique,
+			ix.indisprimary,
+			am.amname,
+			COALESCE(pg_get_expr(ix.indpred, ix.indrelid), ''),
+			pg_relation_size(ix.indexrelid),
+			k.ord,
+			COALESCE(a.attname, pg_get_indexdef(ix.indexrelid, k.ord::int, true))
+		FROM pg_index ix
+		JOIN pg_class t ON t.oid = ix.indrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_class ic ON ic.oid = ix.indexrelid
+		JOIN pg_am am ON am.oid = ic.relam
+		CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord)
+		LEFT JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum AND k.attnum > 0
+		WHERE t.relname = $1 AND n.nspname = $2
+		ORDER BY ix.indisprimary DESC, ic.relname, k.ord
+	`
+	rows, err := db.Query(query, table, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := []IndexInfo{}
+	for rows.Next() {
+		var idx IndexInfo
+		var ord int
+		var column string
+		if err := rows.Scan(&idx.Name, &idx.IsUnique, &idx.IsPrimary, &idx.Method, &idx.Predicate, &idx.Size, &ord, &column); err != nil {
+			return nil, err
+		}
+
+		if n := len(indexes); n > 0 && indexes[n-1].Name == idx.Name {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, column)
+			continue
+		}
+		idx.Columns = []string{column}
+		indexes = append(indexes, idx)
+	}
+	return indexes, rows.Err()
+}
+
+func (d *PostgresDriver) GetForeignKeys(db Querier, database, table string) ([]ForeignKeyInfo, error) {
+	// confupdtype/confdeltype codes: a = no action, r = restrict, c = cascade, n = set null, d = set default
+	query := `
+		SELECT
+			c.conname,
+			rt.relname,
+			c.confupdtype,
+			c.confdeltype,
+			a.attname,
+			ra.attname
+		FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_class rt ON rt.oid = c.confrelid
+		CROSS JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, refattnum, ord)
+		JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.refattnum
+		WHERE c.contype = 'f' AND t.relname = $1 AND n.nspname = $2
+		ORDER BY c.conname, k.ord
+	`
+	rows, err := db.Query(query, table, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	foreignKeys := []ForeignKeyInfo{}
+	for rows.Next() {
+		var fk ForeignKeyInfo
+		var onUpdate, onDelete, column, refColumn string
+		if err := rows.Scan(&fk.Name, &fk.ReferencedTable, &onUpdate, &onDelete, &column, &refColumn); err != nil {
+			return nil, err
+		}
+
+		if n := len(foreignKeys); n > 0 && foreignKeys[n-1].Name == fk.Name {
+			foreignKeys[n-1].Columns = append(foreignKeys[n-1].Columns, column)
+			foreignKeys[n-1].ReferencedColumns = append(foreignKeys[n-1].ReferencedColumns, refColumn)
+			continue
+		}
+		fk.Columns = []string{column}
+		fk.ReferencedColumns = []string{refColumn}
+		fk.OnUpdate = pgReferentialAction(onUpdate)
+		fk.OnDelete = pgReferentialAction(onDelete)
+		foreignKeys = append(foreignKeys, fk)
+	}
+	return foreignKeys, rows.Err()
+}
+
+func (d *PostgresDriver) GetConstraints(db Querier, database, table string) ([]ConstraintInfo, error) {
+	// A CHECK may not reference any column, hence the outer join on conkey
+	query := `
+		SELECT
+			c.conname,
+			CASE c.contype WHEN 'c' THEN 'CHECK' ELSE 'UNIQUE' END,
+			CASE WHEN c.contype = 'c' THEN pg_get_expr(c.conbin, c.conrelid) ELSE '' END,
+			a.attname
+		FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		LEFT JOIN LATERAL unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		LEFT JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		WHERE c.contype IN ('c', 'u') AND t.relname = $1 AND n.nspname = $2
+		ORDER BY c.conname, k.ord
+	`
+	rows, err := db.Query(query, table, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	constraints := []ConstraintInfo{}
+	for rows.Next() {
+		var c ConstraintInfo
+		var column sql.NullString
+		if err := rows.Scan(&c.Name, &c.Type, &c.Expression, &column); err != nil {
+			return nil, err
+		}
+
+		if n := len(constraints); n > 0 && constraints[n-1].Name == c.Name {
+			constraints[n-1].Columns = append(constraints[n-1].Columns, column.String)
+			continue
+		}
+		c.Columns = []string{}
+		if column.Valid {
+			c.Columns = append(c.Columns, column.String)
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, rows.Err()
+}
+
+func (d *PostgresDriver) GetSequences(db Querier, database string) ([]SequenceInfo, error) {
+	query := `
+		SELECT
+			s.sequencename,
+			COALESCE(s.last_value::text, ''),
+			s.increment_by::text,
+			s.min_value::text,
+			s.max_value::text,
+			s.start_value::text,
+			s.cycle,
+			COALESCE(t.relname || '.' || a.attname, '')
+		FROM pg_sequences s
+		JOIN pg_class c ON c.relname = s.sequencename
+		JOIN pg_namespace n ON n.oid = c.relnamespace AND n.nspname = s.schemaname
+		LEFT JOIN pg_depend dep ON dep.objid = c.oid AND dep.classid = 'pg_class'::regclass AND dep.deptype IN ('a', 'i')
+		LEFT JOIN pg_class t ON t.oid = dep.refobjid
+		LEFT JOIN pg_attribute a ON a.attrelid = dep.refobjid AND a.attnum = dep.refobjsubid
+		WHERE s.schemaname = $1
+		ORDER BY s.sequencename
+	`
+	rows, err := db.Query(query, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sequences := []SequenceInfo{}
+	for rows.Next() {
+		var s SequenceInfo
+		if err := rows.Scan(&s.Name, &s.CurrentValue, &s.Increment, &s.MinValue, &s.MaxValue, &s.StartValue, &s.Cycle, &s.OwnedBy); err != nil {
+			return nil, err
+		}
+		sequences = append(sequences, s)
+	}
+	return sequences, rows.Err()
+}
+
+func (d *PostgresDriver) GetTriggers(db Querier, database, table string) ([]TriggerInfo, error) {
+	query := `
+		SELECT
+			tg.tgname,
+			tg.tgtype,
+			tg.tgenabled <> 'D',
+			tg.tgfoid::regproc::text,
+			pg_get_triggerdef(tg.oid, true),
+			COALESCE(pg_get_functiondef(tg.tgfoid), '')
+		FROM pg_trigger tg
+		JOIN pg_class t ON t.oid = tg.tgrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE NOT tg.tgisinternal AND t.relname = $1 AND n.nspname = $2
+		ORDER BY tg.tgname
+	`
+	rows, err := db.Query(query, table, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	triggers := []TriggerInfo{}
+	for rows.Next() {
+		var tr TriggerInfo
+		var tgtype int
+		if err := rows.Scan(&tr.Name, &tgtype, &tr.Enabled, &tr.Function, &tr.Definition, &tr.Body); err != nil {
+			return nil, err
+		}
+		tr.Timing, tr.Level, tr.Events = pgTriggerType(tgtype)
+		triggers = append(triggers, tr)
+	}
+	return triggers, rows.Err()
+}
+
+func (d *PostgresDriver) GetRoutines(db Querier, database string) ([]RoutineInfo, error) {
+	rows, err := db.Query(`
+		SELECT
+			p.oid,
+			p.proname,
+			CASE p.prokind WHEN 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END,
+			COALESCE(pg_get_function_result(p.oid), ''),
+			l.lanname,
+			pg_get_functiondef(p.oid)
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		JOIN pg_language l ON l.oid = p.prolang
+		WHERE n.nspname = $1 AND p.prokind IN ('f', 'p')
+		ORDER BY p.proname, p.oid
+	`, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	routines := []RoutineInfo{}
+	byOID := make(map[int64]int)
+	for rows.Next() {
+		var oid int64
+		r := RoutineInfo{Arguments: []RoutineArgument{}}
+		if err := rows.Scan(&oid, &r.Name, &r.Kind, &r.ReturnType, &r.Language, &r.Source); err != nil {
+			return nil, err
+		}
+		byOID[oid] = len(routines)
+		routines = append(routines, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// proallargtypes is only set when there are OUT arguments; proargmodes is NULL when all are IN
+	args, err := db.Query(`
+		SELECT
+			p.oid,
+			COALESCE(p.proargnames[a.ord], ''),
+			COALESCE(p.proargmodes[a.ord]::text, 'i'),
+			format_type(a.typ, NULL)
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		CROSS JOIN LATERAL unnest(COALESCE(p.proallargtypes, p.proargtypes::oid[])) WITH ORDINALITY AS a(typ, ord)
+		WHERE n.nspname = $1 AND p.prokind IN ('f', 'p')
+		ORDER BY p.oid, a.ord
+	`, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer args.Close()
+
+	modes := map[string]string{"i": argModeIn, "o": argModeOut, "b": argModeInOut, "v": argModeVariadic, "t": argModeTable}
+	for args.Next() {
+		var oid int64
+		var arg RoutineArgument
+		var mode string
+		if err := args.Scan(&oid, &arg.Name, &mode, &arg.Type); err != nil {
+			return nil, err
+		}
+		idx, ok := byOID[oid]
+		if !ok {
+			continue
+		}
+		arg.Mode = modes[mode]
+		routines[idx].Arguments = append(routines[idx].Arguments, arg)
+	}
+	return routines, args.Err()
 }
 
-func (d *PostgresDriver) BuildTableDataQuery(req TableDataRequest, primaryKey string) string {
-	orderBy := req.OrderBy
-	if orderBy == "" && primaryKey != "" {
-		orderBy = primaryKey
+// Built-in pg_* roles are left out
+func (d *PostgresDriver) GetRoles(db Querier) ([]RoleInfo, error) {
+	rows, err := db.Query(`
+		SELECT r.rolname, r.rolcanlogin, r.rolsuper, r.rolcreaterole, r.rolcreatedb, COALESCE(g.rolname, '')
+		FROM pg_roles r
+		LEFT JOIN pg_auth_members m ON m.member = r.oid
+		LEFT JOIN pg_roles g ON g.oid = m.roleid
+		WHERE r.rolname !~ '^pg_'
+		ORDER BY r.rolname, g.rolname
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []RoleInfo{}
+	for rows.Next() {
+		r := RoleInfo{MemberOf: []string{}}
+		var memberOf string
+		if err := rows.Scan(&r.Name, &r.CanLogin, &r.Superuser, &r.CreateRole, &r.CreateDB, &memberOf); err != nil {
+			return nil, err
+		}
+
+		if n := len(roles); n > 0 && roles[n-1].Name == r.Name {
+			roles[n-1].MemberOf = append(roles[n-1].MemberOf, memberOf)
+			continue
+		}
+		if memberOf != "" {
+			r.MemberOf = append(r.MemberOf, memberOf)
+		}
+		roles = append(roles, r)
 	}
-	orderDir := strings.ToUpper(req.OrderDir)
-	if orderDir != "DESC" {
-		orderDir = "ASC"
+	return roles, rows.Err()
+}
+
+// pgTablePrivileges are the privileges that can be granted on a table
+var pgTablePrivileges = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"}
+
+// The ACL is read from pg_class rather than information_schema, which only
+// shows grants involving the current user's roles. A NULL ACL means the
+// owner's default privileges.
+func (d *PostgresDriver) GetTablePrivileges(db Querier, database, table string) ([]TablePrivilege, error) {
+	rows, err := db.Query(`
+		SELECT COALESCE(g.rolname, 'PUBLIC'), a.privilege_type, a.is_grantable, COALESCE(gr.rolname, '')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		CROSS JOIN LATERAL aclexplode(COALESCE(c.relacl, acldefault('r', c.relowner))) a
+		LEFT JOIN pg_roles g ON g.oid = a.grantee
+		LEFT JOIN pg_roles gr ON gr.oid = a.grantor
+		WHERE c.relname = $1 AND n.nspname = $2
+		ORDER BY 1, 2
+	`, table, d.schemaName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	privileges := []TablePrivilege{}
+	for rows.Next() {
+		p := TablePrivilege{Level: "table"}
+		if err := rows.Scan(&p.Grantee, &p.Privilege, &p.Grantable, &p.Grantor); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, p)
+	}
+	return privileges, rows.Err()
+}
+
+// pgTriggerType decodes the pg_trigger.tgtype bit mask
+func pgTriggerType(tgtype int) (timing, level string, events []string) {
+	level = "STATEMENT"
+	if tgtype&1 != 0 {
+		level = "ROW"
 	}
 
-	query := fmt.Sprintf("SELECT * FROM %s", d.QuoteIdentifier(req.Table))
-	if orderBy != "" {
-		query += fmt.Sprintf(" ORDER BY %s %s", d.QuoteIdentifier(orderBy), orderDir)
+	switch {
+	case tgtype&2 != 0:
+		timing = "BEFORE"
+	case tgtype&64 != 0:
+		timing = "INSTEAD OF"
+	default:
+		timing = "AFTER"
 	}
 
+	events = []string{}
+	for _, e := range []struct {
+		bit  int
+		name string
+	}{{4, "INSERT"}, {16, "UPDATE"}, {8, "DELETE"}, {32, "TRUNCATE"}} {
+		if tgtype&e.bit != 0 {
+			events = append(events, e.name)
+		}
+	}
+	return timing, level, events
+}
+
+// pgReferentialAction maps a pg_constraint action code to its SQL name
+func pgReferentialAction(code string) string {
+	switch code {
+	case "r":
+		return "RESTRICT"
+	case "c":
+		return "CASCADE"
+	case "n":
+		return "SET NULL"
+	case "d":
+		return "SET DEFAULT"
+	default:
+		return "NO ACTION"
+	}
+}
+
+// filterDialect compiles filters with numbered placeholders. LIKE compares
+// the text form of a column so it also applies to numbers and dates.
+func (d *PostgresDriver) filterDialect() filterDialect {
+	return filterDialect{
+		quote:       d.QuoteIdentifier,
+		placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+		textColumn:  func(column string) string { return column + "::text" },
+		ilike:       func(column, pattern string) string { return fmt.Sprintf("%s ILIKE %s", column, pattern) },
+		regex:       func(column, pattern string) string { return fmt.Sprintf("%s ~ %s", column, pattern) },
+		// The cast accepts json, jsonb and text columns holding JSON
+		jsonPath: func(column string, path []string, bind func(interface{}) string) string {
+			return fmt.Sprintf("jsonb_extract_path_text(%s::jsonb, %s)", column, pgJSONPathKeys(path, bind))
+		},
+		// Values that are not numbers compare as NULL rather than failing the cast
+		numeric: func(column string, path []string, bind func(interface{}) string) string {
+			value := fmt.Sprintf("jsonb_extract_path(%s::jsonb, %s)", column, pgJSONPathKeys(path, bind))
+			return fmt.Sprintf("CASE WHEN jsonb_typeof(%s) = 'number' THEN (%s)::text::numeric END", value, value)
+		},
+		literal: quoteLiteral,
+	}
+}
+
+// pgJSONPathKeys renders the keys of a JSON path as text arguments
+func pgJSONPathKeys(path []string, bind func(interface{}) string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = bind(key) + "::text"
+	}
+	return strings.Join(keys, ", ")
+}
+
+func (d *PostgresDriver) BuildTableDataQuery(req TableDataRequest, primaryKey []string, locate bool) (string, []interface{}, error) {
+	where, args, err := compileFilters(d.filterDialect(), req.Filters)
+	if err != nil {
+		return "", nil, err
+	}
+
+	order := tableDataOrder(req, primaryKey)
+	where, args, err = addKeysetCondition(d.filterDialect(), where, args, req, order)
+	if err != nil {
+		return "", nil, err
+	}
+
+	paths, err := jsonPathSelection(d.filterDialect(), req.JSONPaths)
+	if err != nil {
+		return "", nil, err
+	}
+	selection := "*" + paths + geoJSONSelection(d.QuoteIdentifier, req.geoColumns)
+	if locate {
+		selection += ", ctid::text"
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s%s", selection, d.qualify(req.Table), where)
+	query += orderClause(d.QuoteIdentifier, order)
+
 	pageSize := req.PageSize
 	if pageSize <= 0 {
 		pageSize = 50
 	}
-	offset := (req.Page - 1) * pageSize
-	query += fmt.Sprintf(" LIMIT %d OFFSET %d", pageSize, offset)
+	if req.Pagination == PaginationKeyset {
+		query += fmt.Sprintf(" LIMIT %d", pageSize)
+	} else {
+		offset := (req.Page - 1) * pageSize
+		query += fmt.Sprintf(" LIMIT %d OFFSET %d", pageSize, offset)
+	}
 
-	return query
+	return query, args, nil
 }
 
-func (d *PostgresDriver) BuildCountQuery(database, table, filters string) string {
-	where := ""
-	if filters != "" {
-		where = fmt.Sprintf(" WHERE %s", filters)
+func (d *PostgresDriver) BuildCountQuery(database, table string, filters []FilterCondition, limit int) (string, []interface{}, error) {
+	where, args, err := compileFilters(d.filterDialect(), filters)
+	if err != nil {
+		return "", nil, err
+	}
+	if limit > 0 {
+		return fmt.Sprintf("SELECT COUNT(*) FROM (SELECT 1 FROM %s%s LIMIT %d) AS bounded", d.qualify(table), where, limit), args, nil
 	}
-	return fmt.Sprintf("SELECT COUNT(*) FROM %s%s", d.QuoteIdentifier(table), where)
+	return fmt.Sprintf("SELECT COUNT(*) FROM %s%s", d.qualify(table), where), args, nil
 }
 
 func (d *PostgresDriver) BuildAlterTableQuery(database, table string, alteration TableAlteration) ([]string, error) {
 	var statements []string
-	quotedTable := d.QuoteIdentifier(table)
+	quotedTable := d.qualify(table)
 
 	// Rename table if requested
 	if alteration.RenameTo != "" && alteration.RenameTo != table {
 		statements = append(statements, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", quotedTable, d.QuoteIdentifier(alteration.RenameTo)))
-		quotedTable = d.QuoteIdentifier(alteration.RenameTo)
+		quotedTable = d.qualify(alteration.RenameTo)
+	}
+
+	// Drop constraints before the columns they may refer to
+	for _, c := range alteration.DropConstraints {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", quotedTable, d.QuoteIdentifier(c.Name)))
 	}
 
 	// Drop columns
@@ -168,12 +1197,9 @@ func (d *PostgresDriver) BuildAlterTableQuery(database, table string, alteration
 		if col.Nullable {
 			nullStr = "NULL"
 		}
-		defaultStr := ""
-		if col.Default != "" {
-			defaultStr = fmt.Sprintf(" DEFAULT '%s'", col.Default)
-		}
-		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s %s%s",
-			quotedTable, d.QuoteIdentifier(col.Name), col.Type, nullStr, defaultStr))
+		defaultStr := defaultClause(col, quoteLiteral, false)
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s%s %s%s",
+			quotedTable, d.QuoteIdentifier(col.Name), col.Type, d.collateClause(col), nullStr, defaultStr))
 	}
 
 	// Modify columns
@@ -186,9 +1212,9 @@ func (d *PostgresDriver) BuildAlterTableQuery(database, table string, alteration
 				quotedTable, d.QuoteIdentifier(col.OldName), quotedCol))
 		}
 
-		// Type change
-		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s",
-			quotedTable, quotedCol, col.Type, quotedCol, col.Type))
+		// Type change; without COLLATE the type's default collation applies
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s%s USING %s::%s",
+			quotedTable, quotedCol, col.Type, d.collateClause(col), quotedCol, col.Type))
 
 		// Nullable change
 		if col.Nullable {
@@ -199,21 +1225,147 @@ func (d *PostgresDriver) BuildAlterTableQuery(database, table string, alteration
 
 		// Default change
 		if col.Default != "" {
-			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT '%s'", quotedTable, quotedCol, col.Default))
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", quotedTable, quotedCol, defaultValue(col, quoteLiteral, false)))
 		} else {
 			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", quotedTable, quotedCol))
 		}
 	}
 
+	// Add constraints once the columns they refer to exist
+	for _, c := range alteration.AddConstraints {
+		clause, err := constraintClause(d, c)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD %s", quotedTable, clause))
+	}
+
 	return statements, nil
 }
 
+// collateClause renders the COLLATE attribute of a string column definition.
+// The character set is fixed per database in PostgreSQL.
+func (d *PostgresDriver) collateClause(col ColumnInfo) string {
+	if col.Collation == "" || typeCategory(col.Type) != typeCategoryText {
+		return ""
+	}
+	return " COLLATE " + d.QuoteIdentifier(col.Collation)
+}
+
+func (d *PostgresDriver) BuildCreateTableQuery(database, table string, columns []ColumnInfo) string {
+	var defs []string
+	var primaryKeys []string
+	for _, col := range columns {
+		nullStr := "NOT NULL"
+		if col.Nullable {
+			nullStr = "NULL"
+		}
+		defs = append(defs, fmt.Sprintf("%s %s %s", d.QuoteIdentifier(col.Name), col.Type, nullStr))
+		if col.Key == "PRI" {
+			primaryKeys = append(primaryKeys, d.QuoteIdentifier(col.Name))
+		}
+	}
+	if len(primaryKeys) > 0 {
+		defs = append(defs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+	return fmt.Sprintf("CREATE TABLE %s (%s)", d.qualify(table), strings.Join(defs, ", "))
+}
+
 func (d *PostgresDriver) BuildTruncateTableQuery(database, table string) string {
-	return fmt.Sprintf("TRUNCATE TABLE %s", d.QuoteIdentifier(table))
+	return fmt.Sprintf("TRUNCATE TABLE %s", d.qualify(table))
 }
 
 func (d *PostgresDriver) BuildDropTableQuery(database, table string) string {
-	return fmt.Sprintf("DROP TABLE %s", d.QuoteIdentifier(table))
+	return fmt.Sprintf("DROP TABLE %s", d.qualify(table))
+}
+
+func (d *PostgresDriver) BuildRefreshMaterializedViewQuery(database, view string, concurrently bool) (string, error) {
+	if concurrently {
+		return fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", d.qualify(view)), nil
+	}
+	return fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", d.qualify(view)), nil
+}
+
+func (d *PostgresDriver) BuildRestartSequenceQuery(database, sequence string, value int64) string {
+	return fmt.Sprintf("ALTER SEQUENCE %s RESTART WITH %d", d.qualify(sequence), value)
+}
+
+func (d *PostgresDriver) BuildAlterSequenceQuery(database, sequence string, alteration SequenceAlteration) (string, error) {
+	var options []string
+	if alteration.Increment != "" {
+		options = append(options, "INCREMENT BY "+alteration.Increment)
+	}
+	if alteration.MinValue != "" {
+		options = append(options, "MINVALUE "+alteration.MinValue)
+	}
+	if alteration.MaxValue != "" {
+		options = append(options, "MAXVALUE "+alteration.MaxValue)
+	}
+	if alteration.Cycle != nil {
+		if *alteration.Cycle {
+			options = append(options, "CYCLE")
+		} else {
+			options = append(options, "NO CYCLE")
+		}
+	}
+	if len(options) == 0 {
+		return "", fmt.Errorf("no sequence changes provided")
+	}
+	return fmt.Sprintf("ALTER SEQUENCE %s %s", d.qualify(sequence), strings.Join(options, " ")), nil
+}
+
+func (d *PostgresDriver) BuildSetTriggerEnabledQuery(database, table, trigger string, enabled bool) (string, error) {
+	action := "DISABLE"
+	if enabled {
+		action = "ENABLE"
+	}
+	return fmt.Sprintf("ALTER TABLE %s %s TRIGGER %s", d.qualify(table), action, d.QuoteIdentifier(trigger)), nil
+}
+
+func (d *PostgresDriver) BuildDropTriggerQuery(database, table, trigger string) string {
+	return fmt.Sprintf("DROP TRIGGER %s ON %s", d.QuoteIdentifier(trigger), d.qualify(table))
+}
+
+func (d *PostgresDriver) BuildTableCommentQuery(database, table, comment string) string {
+	return fmt.Sprintf("COMMENT ON TABLE %s IS %s", d.qualify(table), pgCommentLiteral(comment))
+}
+
+func (d *PostgresDriver) BuildColumnCommentQuery(db Querier, database, table, column, comment string) (string, error) {
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", d.qualify(table), d.QuoteIdentifier(column), pgCommentLiteral(comment)), nil
+}
+
+// pgCommentLiteral quotes a comment, mapping an empty one to NULL which removes it
+func pgCommentLiteral(comment string) string {
+	if comment == "" {
+		return "NULL"
+	}
+	return quoteLiteral(comment)
+}
+
+func (d *PostgresDriver) BuildPrivilegeQuery(database string, change PrivilegeChange) (string, error) {
+	privileges, err := privilegeList(change, pgTablePrivileges)
+	if err != nil {
+		return "", err
+	}
+
+	grantee := d.QuoteIdentifier(change.Grantee)
+	if strings.EqualFold(change.Grantee, "PUBLIC") {
+		grantee = "PUBLIC"
+	}
+
+	if strings.EqualFold(change.Action, "REVOKE") {
+		grantOption := ""
+		if change.WithGrantOption {
+			grantOption = "GRANT OPTION FOR "
+		}
+		return fmt.Sprintf("REVOKE %s%s ON %s FROM %s", grantOption, privileges, d.qualify(change.Table), grantee), nil
+	}
+
+	query := fmt.Sprintf("GRANT %s ON %s TO %s", privileges, d.qualify(change.Table), grantee)
+	if change.WithGrantOption {
+		query += " WITH GRANT OPTION"
+	}
+	return query, nil
 }
 
 func (d *PostgresDriver) BuildInsertQuery(database, table string, columns []string) string {
@@ -224,37 +1376,272 @@ func (d *PostgresDriver) BuildInsertQuery(database, table string, columns []stri
 		placeholders[i] = fmt.Sprintf("$%d", i+1)
 	}
 	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
-		d.QuoteIdentifier(table), strings.Join(quotedCols, ", "), strings.Join(placeholders, ", "))
+		d.qualify(table), strings.Join(quotedCols, ", "), strings.Join(placeholders, ", "))
 }
 
-func (d *PostgresDriver) BuildUpdateQuery(database, table, primaryKey string, columns []string) string {
+func (d *PostgresDriver) BuildBatchInsertQuery(database, table string, columns []string, rows int) string {
+	quotedCols := make([]string, len(columns))
+	for i, col := range columns {
+		quotedCols[i] = d.QuoteIdentifier(col)
+	}
+	tuples := make([]string, rows)
+	placeholders := make([]string, len(columns))
+	for r := range tuples {
+		for i := range placeholders {
+			placeholders[i] = fmt.Sprintf("$%d", r*len(columns)+i+1)
+		}
+		tuples[r] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		d.qualify(table), strings.Join(quotedCols, ", "), strings.Join(tuples, ", "))
+}
+
+func (d *PostgresDriver) ReturningClause() string {
+	return " RETURNING *"
+}
+
+// The wire protocol counts bind parameters in 16 bits
+func (d *PostgresDriver) MaxPlaceholders() int {
+	return 65535
+}
+
+func (d *PostgresDriver) BuildUpdateQuery(database, table string, primaryKey []string, columns []string) string {
 	setClauses := make([]string, len(columns))
 	for i, col := range columns {
 		setClauses[i] = fmt.Sprintf("%s = $%d", d.QuoteIdentifier(col), i+1)
 	}
-	return fmt.Sprintf("UPDATE %s SET %s WHERE %s = $%d",
-		d.QuoteIdentifier(table), strings.Join(setClauses, ", "), d.QuoteIdentifier(primaryKey), len(columns)+1)
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s",
+		d.qualify(table), strings.Join(setClauses, ", "), keyCondition(d.filterDialect(), primaryKey, len(columns)+1))
 }
 
-func (d *PostgresDriver) BuildDeleteQuery(database, table, primaryKey string) string {
-	return fmt.Sprintf("DELETE FROM %s WHERE %s = $1",
-		d.QuoteIdentifier(table), d.QuoteIdentifier(primaryKey))
+func (d *PostgresDriver) BuildDeleteQuery(database, table string, primaryKey []string) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s",
+		d.qualify(table), keyCondition(d.filterDialect(), primaryKey, 1))
 }
 
-func (d *PostgresDriver) BuildBatchDeleteQuery(database, table, primaryKey string, count int) string {
-	placeholders := make([]string, count)
-	for i := range placeholders {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
+func (d *PostgresDriver) BuildBatchDeleteQuery(database, table string, primaryKey []string, count int) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s",
+		d.qualify(table), keyListCondition(d.filterDialect(), primaryKey, count))
+}
+
+func (d *PostgresDriver) BuildDuplicateRowQuery(database, table string, primaryKey, copied, set []string) string {
+	dialect := d.filterDialect()
+	columns := make([]string, 0, len(copied)+len(set))
+	selected := make([]string, 0, len(copied)+len(set))
+	for _, col := range copied {
+		columns = append(columns, d.QuoteIdentifier(col))
+		selected = append(selected, d.QuoteIdentifier(col))
+	}
+	for i, col := range set {
+		columns = append(columns, d.QuoteIdentifier(col))
+		selected = append(selected, dialect.placeholder(i+1))
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s WHERE %s",
+		d.qualify(table), strings.Join(columns, ", "), strings.Join(selected, ", "), d.qualify(table), keyCondition(dialect, primaryKey, len(set)+1))
+}
+
+func (d *PostgresDriver) BuildSelectRowsQuery(database, table string, primaryKey, columns []string, count int) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = d.QuoteIdentifier(col)
+	}
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		strings.Join(quoted, ", "), d.qualify(table), keyListCondition(d.filterDialect(), primaryKey, count))
+}
+
+func (d *PostgresDriver) BuildBulkUpdateQuery(database, table string, columns []string, filters []FilterCondition) (string, []interface{}, error) {
+	where, args, err := compileFiltersAfter(d.filterDialect(), filters, len(columns))
+	if err != nil {
+		return "", nil, err
+	}
+	setClauses := make([]string, len(columns))
+	for i, col := range columns {
+		setClauses[i] = fmt.Sprintf("%s = $%d", d.QuoteIdentifier(col), i+1)
+	}
+	return fmt.Sprintf("UPDATE %s SET %s%s", d.qualify(table), strings.Join(setClauses, ", "), where), args, nil
+}
+
+func (d *PostgresDriver) BuildFilteredDeleteQuery(database, table string, filters []FilterCondition) (string, []interface{}, error) {
+	where, args, err := compileFilters(d.filterDialect(), filters)
+	if err != nil {
+		return "", nil, err
 	}
-	return fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)",
-		d.QuoteIdentifier(table), d.QuoteIdentifier(primaryKey), strings.Join(placeholders, ", "))
+	return fmt.Sprintf("DELETE FROM %s%s", d.qualify(table), where), args, nil
+}
+
+// BuildLocatedUpdateQuery addresses the row by its ctid, numbering the
+// locator placeholder after the SET values
+func (d *PostgresDriver) BuildLocatedUpdateQuery(database, table string, columns []string, locator RowLocator) (string, []interface{}, error) {
+	if locator.CTID == "" {
+		return "", nil, fmt.Errorf("ctid is required to identify a row without a primary key")
+	}
+	setClauses := make([]string, len(columns))
+	for i, col := range columns {
+		setClauses[i] = fmt.Sprintf("%s = $%d", d.QuoteIdentifier(col), i+1)
+	}
+	return fmt.Sprintf("UPDATE %s SET %s WHERE ctid = $%d::tid",
+		d.qualify(table), strings.Join(setClauses, ", "), len(columns)+1), []interface{}{locator.CTID}, nil
+}
+
+func (d *PostgresDriver) BuildLocatedDeleteQuery(database, table string, locator RowLocator) (string, []interface{}, error) {
+	if locator.CTID == "" {
+		return "", nil, fmt.Errorf("ctid is required to identify a row without a primary key")
+	}
+	return fmt.Sprintf("DELETE FROM %s WHERE ctid = $1::tid", d.qualify(table)), []interface{}{locator.CTID}, nil
+}
+
+func (d *PostgresDriver) QualifiedName(database, name string) string {
+	return d.qualify(name)
+}
+
+func (d *PostgresDriver) RowMatch() string {
+	return RowMatchCTID
 }
 
 func (d *PostgresDriver) QuoteIdentifier(name string) string {
-	return fmt.Sprintf("\"%s\"", name)
+	return quoteIdentifier(name, `"`)
+}
+
+// Longer names would be truncated by the server rather than rejected
+func (d *PostgresDriver) ValidateIdentifier(name string) error {
+	return validateIdentifier(name, postgresMaxIdentifier, true)
 }
 
 func (d *PostgresDriver) BuildDistinctValuesQuery(database, table, column string) string {
 	return fmt.Sprintf("SELECT DISTINCT %s FROM %s ORDER BY %s LIMIT 100",
-		d.QuoteIdentifier(column), d.QuoteIdentifier(table), d.QuoteIdentifier(column))
+		d.QuoteIdentifier(column), d.qualify(table), d.QuoteIdentifier(column))
+}
+
+func (d *PostgresDriver) BuildKeyRangeQuery(database, table, primaryKey string, columns []string, hasLower, hasUpper bool) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = d.QuoteIdentifier(col)
+	}
+	pk := d.QuoteIdentifier(primaryKey)
+
+	var conditions []string
+	if hasLower {
+		conditions = append(conditions, fmt.Sprintf("%s > $%d", pk, len(conditions)+1))
+	}
+	if hasUpper {
+		conditions = append(conditions, fmt.Sprintf("%s <= $%d", pk, len(conditions)+1))
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(quoted, ", "), d.qualify(table))
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return query + " ORDER BY " + pk
+}
+
+func (d *PostgresDriver) BuildCallRoutine(database string, routine RoutineInfo) routineCall {
+	var params []string
+	var call routineStatement
+	input := 0
+	for _, arg := range routine.Arguments {
+		switch {
+		case arg.isInput():
+			call.args = append(call.args, input)
+			param := fmt.Sprintf("$%d", len(call.args))
+			if arg.Mode == argModeVariadic {
+				param = "VARIADIC " + param
+			}
+			params = append(params, param)
+			input++
+		case arg.Mode == argModeOut && routine.Kind == "PROCEDURE":
+			// Procedures take a placeholder for OUT arguments and return them as a row
+			params = append(params, "NULL")
+		}
+	}
+
+	name := d.qualify(routine.Name)
+	if routine.Kind == "PROCEDURE" {
+		call.query = fmt.Sprintf("CALL %s(%s)", name, strings.Join(params, ", "))
+		return routineCall{call: call, outRow: true}
+	}
+	call.query = fmt.Sprintf("SELECT * FROM %s(%s)", name, strings.Join(params, ", "))
+	return routineCall{call: call}
+}
+
+// DISCARD ALL drops cached plans and session state; the shared buffer cache can't be cleared from SQL
+func (d *PostgresDriver) BuildClearCacheQuery() string {
+	return "DISCARD ALL"
+}
+
+func (d *PostgresDriver) BackendIDQuery() string {
+	return "SELECT pg_backend_pid()"
+}
+
+func (d *PostgresDriver) BuildCancelQuery(backendID int64) string {
+	return fmt.Sprintf("SELECT pg_cancel_backend(%d)", backendID)
+}
+
+func (d *PostgresDriver) StatementTimeoutQueries(timeout time.Duration) (string, string) {
+	return fmt.Sprintf("SET statement_timeout = %d", timeout.Milliseconds()), "RESET statement_timeout"
+}
+
+// Completion covers every schema of the database so that qualified names complete too
+func (d *PostgresDriver) BuildCompletionColumnsQuery(database string) (string, []interface{}) {
+	return `
+		SELECT n.nspname, c.relname,
+			CASE c.relkind WHEN 'v' THEN 'VIEW' WHEN 'm' THEN 'MATERIALIZED VIEW' WHEN 'f' THEN 'FOREIGN TABLE' ELSE 'TABLE' END,
+			a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+		WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f')
+			AND n.nspname <> 'information_schema' AND n.nspname NOT LIKE 'pg\_%'
+		ORDER BY n.nspname, c.relname, a.attnum
+	`, nil
+}
+
+func (d *PostgresDriver) BuildCompletionRoutinesQuery(database string) (string, []interface{}) {
+	return `
+		SELECT n.nspname, p.proname,
+			CASE p.prokind WHEN 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END,
+			pg_get_function_arguments(p.oid), COALESCE(pg_get_function_result(p.oid), '')
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE p.prokind IN ('f', 'p')
+			AND n.nspname <> 'information_schema' AND n.nspname NOT LIKE 'pg\_%'
+		ORDER BY n.nspname, p.proname
+	`, nil
+}
+
+// Notices go to a handler set on the connection, removed again before the
+// connection goes back to the pool
+func (d *PostgresDriver) CaptureNotices(conn *sql.Conn, collect func(ServerNotice)) func() {
+	set := func(handler func(*pq.Error)) {
+		conn.Raw(func(driverConn interface{}) error {
+			if c, ok := driverConn.(driver.Conn); ok {
+				pq.SetNoticeHandler(c, handler)
+			}
+			return nil
+		})
+	}
+	set(func(e *pq.Error) {
+		collect(ServerNotice{Severity: e.Severity, Code: string(e.Code), Message: e.Message, Detail: e.Detail, Hint: e.Hint})
+	})
+	return func() { set(nil) }
+}
+
+// Warnings arrive as notices
+func (d *PostgresDriver) WarningsQuery() string {
+	return ""
+}
+
+func (d *PostgresDriver) BuildExplainQuery(query string, analyze bool) string {
+	if analyze {
+		return "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) " + query
+	}
+	return "EXPLAIN (FORMAT JSON) " + query
+}
+
+func (d *PostgresDriver) ParsePlan(raw string, analyze bool) (*ExplainResult, error) {
+	return parsePostgresPlan(raw)
+}
+
+func (d *PostgresDriver) BuildSelectCellQuery(database, table string, primaryKey []string, column string) string {
+	return fmt.Sprintf("SELECT %s::text FROM %s WHERE %s",
+		d.QuoteIdentifier(column), d.qualify(table), keyCondition(d.filterDialect(), primaryKey, 1))
 }
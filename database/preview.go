@@ -0,0 +1,88 @@
+package database
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SQLPreview is the SQL a change would run, returned by the Preview methods
+// instead of running it so that it can be reviewed first
+type SQLPreview struct {
+	Statements []PreviewStatement `json:"statements"`
+}
+
+// PreviewStatement is a statement of a preview along with its bind arguments
+type PreviewStatement struct {
+	Query string        `json:"query"` // As sent to the server
+	Args  []interface{} `json:"args,omitempty"`
+	SQL   string        `json:"sql"` // Query with the arguments written in as literals, for reading
+}
+
+// previewOf builds the preview of a single statement
+func (m *Manager) previewOf(query string, args []interface{}) *SQLPreview {
+	return &SQLPreview{Statements: []PreviewStatement{m.previewStatement(query, args)}}
+}
+
+// previewStatement pairs a statement with a readable form of it, in which
+// the placeholders are replaced by the values they are bound to
+func (m *Manager) previewStatement(query string, args []interface{}) PreviewStatement {
+	_, mysql := m.currentDriver().(*MySQLDriver)
+	literal := quoteLiteral
+	if mysql {
+		literal = mysqlQuoteLiteral
+	}
+
+	var sql []byte
+	last, anonymous := 0, 0
+	for _, p := range findPlaceholders(query, mysql, &anonymous) {
+		// ?n for MySQL, $n for PostgreSQL; both count from 1
+		n, err := strconv.Atoi(p.Name[1:])
+		if err != nil || n < 1 || n > len(args) {
+			continue
+		}
+		sql = append(sql, query[last:p.start]...)
+		sql = append(sql, sqlLiteral(args[n-1], mysql, literal)...)
+		last = p.end
+	}
+	sql = append(sql, query[last:]...)
+
+	return PreviewStatement{Query: query, Args: args, SQL: string(sql)}
+}
+
+// sqlLiteral writes a bind argument as a SQL constant
+func sqlLiteral(v interface{}, mysql bool, literal func(string) string) string {
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if val {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(val)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case json.Number:
+		return val.String()
+	case []byte:
+		if mysql {
+			return "X'" + hex.EncodeToString(val) + "'"
+		}
+		return `'\x` + hex.EncodeToString(val) + `'::bytea`
+	case time.Time:
+		return literal(val.Format("2006-01-02 15:04:05.999999Z07:00"))
+	case string:
+		return literal(val)
+	default:
+		if encoded, err := json.Marshal(val); err == nil {
+			return literal(string(encoded))
+		}
+		return literal(fmt.Sprint(val))
+	}
+}
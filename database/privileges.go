@@ -0,0 +1,135 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RoleInfo describes a role (PostgreSQL) or account (MySQL)
+type RoleInfo struct {
+	Name       string   `json:"name"`
+	Host       string   `json:"host,omitempty"` // MySQL account host
+	CanLogin   bool     `json:"canLogin"`
+	Superuser  bool     `json:"superuser"`
+	CreateRole bool     `json:"createRole"`
+	CreateDB   bool     `json:"createDb"`
+	MemberOf   []string `json:"memberOf"` // Roles granted to this one
+}
+
+// TablePrivilege is a privilege some grantee holds on a table
+type TablePrivilege struct {
+	Grantee   string `json:"grantee"` // Role, 'user'@'host' (MySQL) or PUBLIC
+	Privilege string `json:"privilege"`
+	Grantable bool   `json:"grantable"`         // The grantee may grant it to others
+	Grantor   string `json:"grantor,omitempty"` // PostgreSQL only
+	Level     string `json:"level"`             // table, or database/global for MySQL grants covering the table
+}
+
+// PrivilegeChange is a GRANT or REVOKE on a table
+type PrivilegeChange struct {
+	Action     string   `json:"action"` // GRANT or REVOKE
+	Table      string   `json:"table"`
+	Privileges []string `json:"privileges"` // e.g. SELECT, INSERT; ALL for every privilege
+	Grantee    string   `json:"grantee"`    // Role or user; PUBLIC for everyone (PostgreSQL)
+	Host       string   `json:"host,omitempty"`
+
+	// On GRANT, lets the grantee grant the privileges on. On REVOKE, only the
+	// right to grant them on is revoked.
+	WithGrantOption bool `json:"withGrantOption"`
+}
+
+// GetRoles lists the roles or accounts of the server
+func (m *Manager) GetRoles() ([]RoleInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	roles, err := m.currentDriver().GetRoles(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// GetTablePrivileges lists who holds which privileges on a table
+func (m *Manager) GetTablePrivileges(database, schema, table string) ([]TablePrivilege, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	privileges, err := m.tableDriver(schema).GetTablePrivileges(db, database, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table privileges: %w", err)
+	}
+
+	return privileges, nil
+}
+
+// PreviewPrivilegeChange returns the GRANT or REVOKE statement for a change without running it
+func (m *Manager) PreviewPrivilegeChange(database string, change PrivilegeChange) (string, error) {
+	if m.getDB() == nil {
+		return "", fmt.Errorf("not connected to database")
+	}
+	return m.currentDriver().BuildPrivilegeQuery(database, change)
+}
+
+// ApplyPrivilegeChange runs a GRANT or REVOKE
+func (m *Manager) ApplyPrivilegeChange(database string, change PrivilegeChange) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	query, err := m.currentDriver().BuildPrivilegeQuery(database, change)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to %s privileges: %w", strings.ToLower(change.Action), err)
+	}
+
+	return nil
+}
+
+// privilegeList validates a change against the privileges a dialect supports
+// on tables and renders its privilege list
+func privilegeList(change PrivilegeChange, allowed []string) (string, error) {
+	switch strings.ToUpper(change.Action) {
+	case "GRANT", "REVOKE":
+	default:
+		return "", fmt.Errorf("unsupported privilege action: %s", change.Action)
+	}
+	if change.Table == "" {
+		return "", fmt.Errorf("table is required")
+	}
+	if change.Grantee == "" {
+		return "", fmt.Errorf("grantee is required")
+	}
+	if len(change.Privileges) == 0 {
+		return "", fmt.Errorf("no privileges selected")
+	}
+
+	names := make([]string, 0, len(change.Privileges))
+	for _, p := range change.Privileges {
+		p = strings.ToUpper(strings.Join(strings.Fields(p), " "))
+		if p == "ALL" || p == "ALL PRIVILEGES" {
+			return "ALL PRIVILEGES", nil
+		}
+		known := false
+		for _, a := range allowed {
+			if p == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return "", fmt.Errorf("unsupported table privilege: %s", p)
+		}
+		names = append(names, p)
+	}
+	return strings.Join(names, ", "), nil
+}
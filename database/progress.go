@@ -0,0 +1,38 @@
+package database
+
+// progressInterval is the number of rows between progress events
+const progressInterval = 1000
+
+// TransferProgress reports how far an export, import or table copy has come.
+// It is emitted as "export:progress", "import:progress" or "copy:progress" events.
+type TransferProgress struct {
+	Operation string `json:"operation"` // export, import or copy
+	Table     string `json:"table"`
+	Rows      int64  `json:"rows"`
+	TotalRows int64  `json:"totalRows,omitempty"` // Known for imports, estimated for copies
+	Done      bool   `json:"done"`
+}
+
+// progressReporter emits throttled progress events for a row-by-row transfer
+type progressReporter struct {
+	m        *Manager
+	progress TransferProgress
+}
+
+func (m *Manager) newProgress(operation, table string, total int64) *progressReporter {
+	return &progressReporter{m: m, progress: TransferProgress{Operation: operation, Table: table, TotalRows: total}}
+}
+
+// add counts a row, emitting an event every progressInterval rows
+func (r *progressReporter) add() {
+	r.progress.Rows++
+	if r.progress.Rows%progressInterval == 0 {
+		r.m.emit(r.progress.Operation+":progress", r.progress)
+	}
+}
+
+// finish emits the final event
+func (r *progressReporter) finish() {
+	r.progress.Done = true
+	r.m.emit(r.progress.Operation+":progress", r.progress)
+}
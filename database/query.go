@@ -1,22 +1,67 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
+	"time"
 )
 
 // ExecuteQuery runs a SELECT query and returns results
 func (m *Manager) ExecuteQuery(query string) (*QueryResult, error) {
+	start := time.Now()
+	result, err := m.executeQuery(m.queryTimeout(), true, query)
+	var rows int64
+	if result != nil {
+		rows = int64(result.RowCount)
+	}
+	m.recordHistory(query, start, time.Since(start), rows, err)
+	return result, err
+}
+
+// executeQuery runs a SELECT query with bound arguments, limited to timeout.
+// With stoppable, the server id of the connection is looked up first so that
+// stopping the query also stops it on the server; grid pages skip that extra
+// roundtrip and rely on the timeout and the driver's own cancellation.
+func (m *Manager) executeQuery(timeout time.Duration, stoppable bool, query string, args ...interface{}) (*QueryResult, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	rows, err := db.Query(query)
+	ctx, done := m.track("query", query)
+	defer done()
+
+	var conn *instrumentedConn
+	var err error
+	if stoppable {
+		conn, err = m.session(ctx, db)
+	} else {
+		conn, err = db.session(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	limit, err := m.limitStatements(ctx, conn, timeout)
 	if err != nil {
-		return nil, fmt.Errorf("query failed: %w", err)
+		return nil, err
+	}
+	defer limit.close()
+
+	limit.start()
+	rows, err := conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", limit.stop(err))
 	}
 	defer rows.Close()
 
+	result, err := readQueryResult(rows)
+	return result, limit.stop(err)
+}
+
+// readQueryResult reads the current result set of rows into a QueryResult
+func readQueryResult(rows *sql.Rows) (*QueryResult, error) {
 	// Get column names
 	columns, err := rows.Columns()
 	if err != nil {
@@ -24,40 +69,58 @@ func (m *Manager) ExecuteQuery(query string) (*QueryResult, error) {
 	}
 
 	result := &QueryResult{
-		Columns: columns,
-		Rows:    make([][]interface{}, 0),
+		Columns:     columns,
+		ColumnTypes: make([]string, len(columns)),
+		Rows:        make([][]interface{}, 0),
 	}
 
+	// Type tags let the frontend tell numeric strings apart from text
+	if colTypes, err := rows.ColumnTypes(); err == nil {
+		for i, ct := range colTypes {
+			result.ColumnTypes[i] = ct.DatabaseTypeName()
+		}
+	}
+
+	// Fetch all rows
+	result.Rows, _, err = scanRows(rows, len(columns), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result.RowCount = len(result.Rows)
+	return result, nil
+}
+
+// scanRows reads up to max rows of the current result set, or all of them when
+// max is 0, and reports whether more rows may follow
+func scanRows(rows *sql.Rows, columns, max int) ([][]interface{}, bool, error) {
 	// Create a slice of interface{} to hold row values
-	values := make([]interface{}, len(columns))
-	valuePtrs := make([]interface{}, len(columns))
+	values := make([]interface{}, columns)
+	valuePtrs := make([]interface{}, columns)
 	for i := range values {
 		valuePtrs[i] = &values[i]
 	}
 
-	// Fetch all rows
-	for rows.Next() {
+	result := make([][]interface{}, 0)
+	for max == 0 || len(result) < max {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return nil, false, fmt.Errorf("failed to read rows: %w", err)
+			}
+			return result, false, nil
+		}
 		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+			return nil, false, fmt.Errorf("failed to scan row: %w", err)
 		}
 
 		// Convert values to JSON-serializable types
-		row := make([]interface{}, len(columns))
+		row := make([]interface{}, columns)
 		for i, v := range values {
-			switch val := v.(type) {
-			case []byte:
-				row[i] = string(val)
-			case nil:
-				row[i] = nil
-			default:
-				row[i] = val
-			}
+			row[i] = scanValue(v)
 		}
-		result.Rows = append(result.Rows, row)
+		result = append(result, row)
 	}
-
-	result.RowCount = len(result.Rows)
-	return result, nil
+	return result, true, nil
 }
 
 // ExecuteStatement runs an INSERT/UPDATE/DELETE statement
@@ -67,13 +130,34 @@ func (m *Manager) ExecuteStatement(query string) (*ExecuteResult, error) {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	res, err := db.Exec(query)
+	ctx, done := m.track("statement", query)
+	defer done()
+
+	conn, err := m.session(ctx, db)
 	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	limit, err := m.limitStatements(ctx, conn, m.queryTimeout())
+	if err != nil {
+		return nil, err
+	}
+	defer limit.close()
+
+	start := time.Now()
+	limit.start()
+	res, err := conn.Exec(query)
+	if err = limit.stop(err); err != nil {
+		m.recordHistory(query, start, time.Since(start), 0, err)
 		return nil, fmt.Errorf("statement failed: %w", err)
 	}
 
 	rowsAffected, _ := res.RowsAffected()
 	lastInsertId, _ := res.LastInsertId()
+	m.recordHistory(query, start, time.Since(start), rowsAffected, nil)
+	// Any table may have changed
+	m.ClearUndoLog()
 
 	return &ExecuteResult{
 		RowsAffected: rowsAffected,
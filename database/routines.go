@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Routine argument modes
+const (
+	argModeIn       = "IN"
+	argModeOut      = "OUT"
+	argModeInOut    = "INOUT"
+	argModeVariadic = "VARIADIC"
+	argModeTable    = "TABLE" // Column of a RETURNS TABLE result
+)
+
+// RoutineArgument is a parameter of a stored function or procedure
+type RoutineArgument struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Mode string `json:"mode"` // IN, OUT, INOUT, VARIADIC, TABLE
+}
+
+// RoutineInfo describes a stored function or procedure
+type RoutineInfo struct {
+	Name       string            `json:"name"`
+	Kind       string            `json:"kind"` // FUNCTION or PROCEDURE
+	Arguments  []RoutineArgument `json:"arguments"`
+	ReturnType string            `json:"returnType"`
+	Language   string            `json:"language"`
+	Source     string            `json:"source"`
+}
+
+// RoutineResult holds what a routine call produced
+type RoutineResult struct {
+	ResultSets []QueryResult          `json:"resultSets"`
+	OutValues  map[string]interface{} `json:"outValues"`
+	DurationMs float64                `json:"durationMs"`
+}
+
+// routineCall is the statement sequence a driver needs to invoke a routine
+type routineCall struct {
+	prepare []routineStatement // Run before the call, e.g. to seed INOUT variables
+	call    routineStatement
+	outRow  bool   // The call's single result row holds the OUT values
+	fetch   string // Query reading OUT values after the call
+}
+
+// routineStatement is a statement bound to a subset of the input values
+type routineStatement struct {
+	query string
+	args  []int // Indexes into the input values
+}
+
+// isInput reports whether the caller supplies a value for the argument
+func (a RoutineArgument) isInput() bool {
+	return a.Mode == argModeIn || a.Mode == argModeInOut || a.Mode == argModeVariadic
+}
+
+// GetRoutines returns the stored functions and procedures of a database
+func (m *Manager) GetRoutines(database string) ([]RoutineInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	routines, err := m.currentDriver().GetRoutines(db, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routines: %w", err)
+	}
+
+	return routines, nil
+}
+
+// ExecuteRoutine calls a function or procedure. args holds one value per IN,
+// INOUT or VARIADIC argument, in declaration order.
+func (m *Manager) ExecuteRoutine(database string, routine RoutineInfo, args []interface{}) (*RoutineResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	inputs := 0
+	for _, arg := range routine.Arguments {
+		if arg.isInput() {
+			inputs++
+		}
+	}
+	if len(args) != inputs {
+		return nil, fmt.Errorf("%s expects %d arguments, got %d", routine.Name, inputs, len(args))
+	}
+
+	plan := m.currentDriver().BuildCallRoutine(database, routine)
+	bind := func(stmt routineStatement) []interface{} {
+		values := make([]interface{}, len(stmt.args))
+		for i, idx := range stmt.args {
+			values[i] = paramValue(args[idx])
+		}
+		return values
+	}
+
+	// OUT values may live in session variables, so everything runs on one connection
+	conn, err := db.session(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	result := &RoutineResult{
+		ResultSets: []QueryResult{},
+		OutValues:  map[string]interface{}{},
+	}
+
+	for _, stmt := range plan.prepare {
+		if _, err := conn.Exec(stmt.query, bind(stmt)...); err != nil {
+			return nil, fmt.Errorf("failed to prepare routine arguments: %w", err)
+		}
+	}
+
+	// The routine may change any table
+	m.ClearUndoLog()
+	rows, err := conn.Query(plan.call.query, bind(plan.call)...)
+	if err != nil {
+		return nil, fmt.Errorf("routine failed: %w", err)
+	}
+	for {
+		set, err := readQueryResult(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if len(set.Columns) > 0 {
+			result.ResultSets = append(result.ResultSets, *set)
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("routine failed: %w", err)
+	}
+	rows.Close()
+
+	if plan.outRow && len(result.ResultSets) > 0 {
+		collectOutValues(result.OutValues, result.ResultSets[0])
+		result.ResultSets = result.ResultSets[1:]
+	}
+
+	if plan.fetch != "" {
+		out, err := conn.Query(plan.fetch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OUT arguments: %w", err)
+		}
+		set, err := readQueryResult(out)
+		out.Close()
+		if err != nil {
+			return nil, err
+		}
+		collectOutValues(result.OutValues, *set)
+	}
+
+	result.DurationMs = durationMs(time.Since(start))
+	return result, nil
+}
+
+// collectOutValues copies the first row of a result set into values by column name
+func collectOutValues(values map[string]interface{}, set QueryResult) {
+	if len(set.Rows) == 0 {
+		return
+	}
+	for i, col := range set.Columns {
+		values[col] = set.Rows[0][i]
+	}
+}
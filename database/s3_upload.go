@@ -0,0 +1,194 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3UploadTimeout bounds a scheduled export uploaded to a bucket
+const s3UploadTimeout = 30 * time.Minute
+
+// s3PartSize is the size of the parts of a multipart upload. The size of an
+// export is not known up front, so with the 10,000 parts S3 allows it caps
+// an object at about 156 GiB.
+const s3PartSize = 16 << 20
+
+// s3UploadConcurrency is how many parts are sent at once; each holds a part
+// in memory
+const s3UploadConcurrency = 2
+
+// gcsEndpoint is the S3-compatible XML API of Google Cloud Storage, used
+// with HMAC keys
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// errUploadAborted stops an upload whose export failed or was cancelled
+var errUploadAborted = errors.New("upload aborted")
+
+// S3Destination is a bucket exports are uploaded to
+type S3Destination struct {
+	Bucket   string `json:"bucket"`
+	Prefix   string `json:"prefix,omitempty"`   // Key prefix, e.g. reports/daily/
+	Region   string `json:"region,omitempty"`   // Defaults to that of the AWS configuration
+	Profile  string `json:"profile,omitempty"`  // AWS shared config profile
+	Endpoint string `json:"endpoint,omitempty"` // S3-compatible service such as MinIO, addressed path-style
+}
+
+// newS3Client creates a client for a bucket with the default AWS credential
+// chain, like connection secrets are read. Custom endpoints and bucket names
+// with dots, which don't match the TLS certificate of virtual hosts, are
+// addressed path-style.
+func newS3Client(ctx context.Context, dest S3Destination) (*s3.Client, error) {
+	if dest.Bucket == "" {
+		return nil, fmt.Errorf("an S3 bucket is required")
+	}
+	var opts []func(*awsconfig.LoadOptions) error
+	if dest.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(dest.Region))
+	}
+	if dest.Profile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(dest.Profile))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = strings.Contains(dest.Bucket, ".")
+		if dest.Endpoint != "" {
+			o.BaseEndpoint = aws.String(dest.Endpoint)
+			o.UsePathStyle = true
+			// S3-compatible services may reject the checksums AWS accepts
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}
+	}), nil
+}
+
+// s3Writer uploads an object as it is written, so exports of any size go to
+// a bucket without a local copy. The uploader reads what is written from a
+// pipe, sending it in parts or, for objects smaller than a part, in a single
+// request.
+type s3Writer struct {
+	pipe     *io.PipeWriter
+	done     chan error
+	progress func(bytes int64, parts int, done bool)
+
+	mu       sync.Mutex
+	uploaded int64
+	closed   bool
+}
+
+// newS3Writer starts uploading key to the bucket of client in the background
+func newS3Writer(ctx context.Context, client *s3.Client, dest S3Destination, key, contentType string, progress func(bytes int64, parts int, done bool)) *s3Writer {
+	reader, pipe := io.Pipe()
+	w := &s3Writer{pipe: pipe, done: make(chan error, 1), progress: progress}
+
+	uploader := manager.NewUploader(client, func(u *manager.Uploader) {
+		u.PartSize = s3PartSize
+		u.Concurrency = s3UploadConcurrency
+	})
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(dest.Bucket),
+		Key:    aws.String(key),
+		Body:   &s3ProgressReader{reader: reader, writer: w},
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	go func() {
+		_, err := uploader.Upload(ctx, input)
+		// Unblock writes when the upload stops early
+		reader.CloseWithError(err)
+		w.done <- err
+	}()
+	return w
+}
+
+func (w *s3Writer) Write(p []byte) (int, error) {
+	n, err := w.pipe.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("upload failed: %w", err)
+	}
+	return n, nil
+}
+
+// Close ends the object and waits for the upload to complete
+func (w *s3Writer) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	w.pipe.Close()
+	if err := <-w.done; err != nil {
+		return fmt.Errorf("failed to upload: %w", err)
+	}
+	uploaded := w.uploadedBytes()
+	w.progress(uploaded, s3Parts(uploaded), true)
+	return nil
+}
+
+// Abort stops the upload; the uploader discards the parts sent so far
+func (w *s3Writer) Abort() {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	w.pipe.CloseWithError(errUploadAborted)
+	<-w.done
+}
+
+func (w *s3Writer) uploadedBytes() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.uploaded
+}
+
+// s3ProgressReader counts what the uploader reads, reporting progress each
+// time a part is filled
+type s3ProgressReader struct {
+	reader io.Reader
+	writer *s3Writer
+}
+
+func (r *s3ProgressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		w := r.writer
+		w.mu.Lock()
+		before := w.uploaded
+		w.uploaded += int64(n)
+		uploaded := w.uploaded
+		w.mu.Unlock()
+		if uploaded/s3PartSize > before/s3PartSize {
+			w.progress(uploaded, s3Parts(uploaded), false)
+		}
+	}
+	return n, err
+}
+
+// s3Parts returns how many parts an object of size bytes is uploaded in
+func s3Parts(size int64) int {
+	return int((size + s3PartSize - 1) / s3PartSize)
+}
@@ -0,0 +1,230 @@
+package database
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SavedQuery is a named query of the snippet library
+type SavedQuery struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Folder      string           `json:"folder,omitempty"` // Slash-separated path, e.g. reports/monthly
+	SQL         string           `json:"sql"`
+	Description string           `json:"description,omitempty"`
+	Parameters  []QueryParameter `json:"parameters,omitempty"`
+	Connections []string         `json:"connections,omitempty"` // Saved connections it is offered on; all when empty
+	CreatedAt   string           `json:"createdAt"`             // RFC 3339
+	UpdatedAt   string           `json:"updatedAt"`
+	// Parameter values it was last run with, keyed by placeholder name
+	LastValues map[string]interface{} `json:"lastValues,omitempty"`
+}
+
+// QueryParameter is a value a saved query asks for when it is run
+type QueryParameter struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"` // text (default), number, date or boolean
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// savedQueriesFile stores the snippet library, keyed by query ID
+const savedQueriesFile = "saved_queries.json"
+
+// LoadSavedQueries returns the saved queries offered on a connection, or all
+// of them when connection is empty, sorted by folder and name
+func (s *Storage) LoadSavedQueries(connection string) ([]SavedQuery, error) {
+	all := make(map[string]SavedQuery)
+	if err := s.readJSON(savedQueriesFile, &all); err != nil {
+		return nil, err
+	}
+
+	queries := []SavedQuery{}
+	for _, q := range all {
+		if connection == "" || q.availableOn(connection) {
+			queries = append(queries, q)
+		}
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].Folder != queries[j].Folder {
+			return queries[i].Folder < queries[j].Folder
+		}
+		return strings.ToLower(queries[i].Name) < strings.ToLower(queries[j].Name)
+	})
+	return queries, nil
+}
+
+// SaveQuery creates a saved query, or replaces the one with the same ID, and
+// returns it as stored
+func (s *Storage) SaveQuery(q SavedQuery) (*SavedQuery, error) {
+	q.Name = strings.TrimSpace(q.Name)
+	if q.Name == "" {
+		return nil, fmt.Errorf("query name is required")
+	}
+	q.Folder = cleanFolder(q.Folder)
+	seen := make(map[string]bool, len(q.Parameters))
+	for _, p := range q.Parameters {
+		if p.Name == "" {
+			return nil, fmt.Errorf("parameter name is required")
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("duplicate parameter: %s", p.Name)
+		}
+		seen[p.Name] = true
+		switch p.Type {
+		case "", "text", "number", "date", "boolean":
+		default:
+			return nil, fmt.Errorf("unsupported parameter type: %s", p.Type)
+		}
+	}
+
+	all := make(map[string]SavedQuery)
+	if err := s.readJSON(savedQueriesFile, &all); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if existing, ok := all[q.ID]; ok && q.ID != "" {
+		q.CreatedAt = existing.CreatedAt
+		if q.LastValues == nil {
+			q.LastValues = existing.LastValues
+		}
+	} else {
+		id, err := newQueryID()
+		if err != nil {
+			return nil, err
+		}
+		q.ID, q.CreatedAt = id, now
+	}
+	q.UpdatedAt = now
+
+	for _, other := range all {
+		if other.ID != q.ID && other.Folder == q.Folder && strings.EqualFold(other.Name, q.Name) {
+			return nil, fmt.Errorf("a query named %s already exists in this folder", q.Name)
+		}
+	}
+
+	all[q.ID] = q
+	if err := s.writeJSON(savedQueriesFile, all); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// DeleteSavedQuery removes a saved query by ID
+func (s *Storage) DeleteSavedQuery(id string) error {
+	all := make(map[string]SavedQuery)
+	if err := s.readJSON(savedQueriesFile, &all); err != nil {
+		return err
+	}
+	if _, ok := all[id]; !ok {
+		return fmt.Errorf("saved query not found: %s", id)
+	}
+	delete(all, id)
+	return s.writeJSON(savedQueriesFile, all)
+}
+
+// RememberQueryValues stores the parameter values a saved query was last run
+// with, to be offered the next time it is run
+func (s *Storage) RememberQueryValues(id string, values map[string]interface{}) error {
+	all := make(map[string]SavedQuery)
+	if err := s.readJSON(savedQueriesFile, &all); err != nil {
+		return err
+	}
+	q, ok := all[id]
+	if !ok {
+		return fmt.Errorf("saved query not found: %s", id)
+	}
+	q.LastValues = values
+	all[id] = q
+	return s.writeJSON(savedQueriesFile, all)
+}
+
+// MoveSavedQueries moves every query of a folder and its subfolders under a
+// new folder, e.g. to rename it, and returns how many were moved
+func (s *Storage) MoveSavedQueries(from, to string) (int, error) {
+	from, to = cleanFolder(from), cleanFolder(to)
+	all := make(map[string]SavedQuery)
+	if err := s.readJSON(savedQueriesFile, &all); err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	now := time.Now().UTC().Format(time.RFC3339)
+	for id, q := range all {
+		switch {
+		case q.Folder == from:
+			q.Folder = to
+		case from == "" || strings.HasPrefix(q.Folder, from+"/"):
+			q.Folder = cleanFolder(to + "/" + strings.TrimPrefix(q.Folder, from))
+		default:
+			continue
+		}
+		q.UpdatedAt = now
+		all[id] = q
+		moved++
+	}
+	if moved == 0 {
+		return 0, nil
+	}
+	return moved, s.writeJSON(savedQueriesFile, all)
+}
+
+// renameQueryConnection keeps saved queries attached to a renamed connection
+func (s *Storage) renameQueryConnection(oldName, newName string) error {
+	all := make(map[string]SavedQuery)
+	if err := s.readJSON(savedQueriesFile, &all); err != nil {
+		return err
+	}
+
+	changed := false
+	for id, q := range all {
+		for i, c := range q.Connections {
+			if c == oldName {
+				q.Connections[i] = newName
+				all[id] = q
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.writeJSON(savedQueriesFile, all)
+}
+
+func (q SavedQuery) availableOn(connection string) bool {
+	if len(q.Connections) == 0 {
+		return true
+	}
+	for _, c := range q.Connections {
+		if c == connection {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFolder normalizes a folder path: no empty segments and no leading or
+// trailing slashes
+func cleanFolder(folder string) string {
+	var parts []string
+	for _, p := range strings.Split(folder, "/") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func newQueryID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate query id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
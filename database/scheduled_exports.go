@@ -0,0 +1,566 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Data files of the scheduled exports and their run history
+const (
+	exportSchedulesFile = "export_schedules.json"
+	exportRunsFile      = "export_runs.json"
+)
+
+// maxExportRuns is the number of runs kept in the history of a schedule
+const maxExportRuns = 50
+
+// schedulerTick is how often the scheduler looks for schedules that are due
+const schedulerTick = 20 * time.Second
+
+// ExportSchedule runs a saved query on a saved connection on a cron-like
+// schedule and writes its rows to a new CSV or JSON file in a directory, or
+// uploads it to S3
+type ExportSchedule struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	QueryID    string                 `json:"queryId"`    // Saved query run
+	Connection string                 `json:"connection"` // Saved connection it runs on
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Cron       string                 `json:"cron"`   // minute hour day-of-month month day-of-week, or @hourly, @daily, @weekly, @monthly
+	Format     string                 `json:"format"` // csv or json
+	Directory  string                 `json:"directory,omitempty"`
+	S3         *S3Destination         `json:"s3,omitempty"` // Uploaded there instead of written to Directory
+	Enabled    bool                   `json:"enabled"`
+	CreatedAt  string                 `json:"createdAt"` // RFC 3339
+	UpdatedAt  string                 `json:"updatedAt"`
+}
+
+// ExportRun is a run of a scheduled export. Runs are emitted as
+// "schedule:run" events, failed ones also as "schedule:failed".
+type ExportRun struct {
+	ScheduleID string  `json:"scheduleId"`
+	Schedule   string  `json:"schedule"` // Name of the schedule
+	Started    string  `json:"started"`  // RFC 3339
+	DurationMs float64 `json:"durationMs"`
+	Status     string  `json:"status"` // done or failed
+	Rows       int64   `json:"rows"`
+	Location   string  `json:"location,omitempty"` // File written or s3:// URL
+	Error      string  `json:"error,omitempty"`
+	Manual     bool    `json:"manual,omitempty"` // Started by hand rather than by the schedule
+}
+
+// ExportScheduleStatus is a schedule with its next and last runs
+type ExportScheduleStatus struct {
+	ExportSchedule
+	NextRun string     `json:"nextRun,omitempty"` // RFC 3339; empty when disabled
+	Running bool       `json:"running"`
+	LastRun *ExportRun `json:"lastRun,omitempty"`
+}
+
+// LoadExportSchedules returns the scheduled exports sorted by name
+func (s *Storage) LoadExportSchedules() ([]ExportSchedule, error) {
+	all := make(map[string]ExportSchedule)
+	if err := s.readJSON(exportSchedulesFile, &all); err != nil {
+		return nil, err
+	}
+	schedules := make([]ExportSchedule, 0, len(all))
+	for _, schedule := range all {
+		schedules = append(schedules, schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return strings.ToLower(schedules[i].Name) < strings.ToLower(schedules[j].Name)
+	})
+	return schedules, nil
+}
+
+// SaveExportSchedule creates a scheduled export, or replaces the one with the
+// same ID, and returns it as stored
+func (s *Storage) SaveExportSchedule(schedule ExportSchedule) (*ExportSchedule, error) {
+	schedule.Name = strings.TrimSpace(schedule.Name)
+	switch {
+	case schedule.Name == "":
+		return nil, fmt.Errorf("schedule name is required")
+	case schedule.QueryID == "":
+		return nil, fmt.Errorf("a saved query is required")
+	case schedule.Connection == "":
+		return nil, fmt.Errorf("a saved connection is required")
+	case schedule.Format != "csv" && schedule.Format != "json":
+		return nil, fmt.Errorf("unsupported export format: %s", schedule.Format)
+	}
+	if _, err := parseCron(schedule.Cron); err != nil {
+		return nil, err
+	}
+	if schedule.S3 != nil {
+		if schedule.S3.Bucket == "" {
+			return nil, fmt.Errorf("an S3 bucket is required")
+		}
+		schedule.Directory = ""
+	} else if !filepath.IsAbs(schedule.Directory) {
+		return nil, fmt.Errorf("an absolute output directory is required")
+	}
+
+	s.schedulesMu.Lock()
+	defer s.schedulesMu.Unlock()
+
+	all := make(map[string]ExportSchedule)
+	if err := s.readJSON(exportSchedulesFile, &all); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	if existing, ok := all[schedule.ID]; ok && schedule.ID != "" {
+		schedule.CreatedAt = existing.CreatedAt
+	} else {
+		id, err := newQueryID()
+		if err != nil {
+			return nil, err
+		}
+		schedule.ID, schedule.CreatedAt = id, now
+	}
+	schedule.UpdatedAt = now
+
+	all[schedule.ID] = schedule
+	if err := s.writeJSON(exportSchedulesFile, all); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// DeleteExportSchedule removes a scheduled export and its run history
+func (s *Storage) DeleteExportSchedule(id string) error {
+	s.schedulesMu.Lock()
+	defer s.schedulesMu.Unlock()
+
+	all := make(map[string]ExportSchedule)
+	if err := s.readJSON(exportSchedulesFile, &all); err != nil {
+		return err
+	}
+	if _, ok := all[id]; !ok {
+		return fmt.Errorf("schedule not found: %s", id)
+	}
+	delete(all, id)
+	if err := s.writeJSON(exportSchedulesFile, all); err != nil {
+		return err
+	}
+
+	runs := make(map[string][]ExportRun)
+	if err := s.readJSON(exportRunsFile, &runs); err != nil {
+		return err
+	}
+	delete(runs, id)
+	return s.writeJSON(exportRunsFile, runs)
+}
+
+// ExportRuns returns the recent runs of a scheduled export, newest first
+func (s *Storage) ExportRuns(id string) ([]ExportRun, error) {
+	runs := make(map[string][]ExportRun)
+	if err := s.readJSON(exportRunsFile, &runs); err != nil {
+		return nil, err
+	}
+	if runs[id] == nil {
+		return []ExportRun{}, nil
+	}
+	return runs[id], nil
+}
+
+// recordExportRun adds a run to the history of its schedule
+func (s *Storage) recordExportRun(run ExportRun) error {
+	s.schedulesMu.Lock()
+	defer s.schedulesMu.Unlock()
+
+	runs := make(map[string][]ExportRun)
+	if err := s.readJSON(exportRunsFile, &runs); err != nil {
+		return err
+	}
+	history := append([]ExportRun{run}, runs[run.ScheduleID]...)
+	if len(history) > maxExportRuns {
+		history = history[:maxExportRuns]
+	}
+	runs[run.ScheduleID] = history
+	return s.writeJSON(exportRunsFile, runs)
+}
+
+// ExportScheduler runs the enabled scheduled exports while the app is open.
+// Runs missed while it was closed are not made up for.
+type ExportScheduler struct {
+	storage *Storage
+	open    connectionOpener
+	emit    func(event string, data interface{})
+
+	mu      sync.Mutex
+	next    map[string]scheduledRun
+	running map[string]bool
+	active  map[string]*Manager // Connections of the runs in progress
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// scheduledRun is when a schedule runs next, for the expression it was
+// computed from
+type scheduledRun struct {
+	cron string
+	at   time.Time
+}
+
+// NewExportScheduler returns a scheduler that opens connections with open
+// and reports runs through emit
+func NewExportScheduler(storage *Storage, open func(connection string) (*Manager, func(), error), emit func(event string, data interface{})) *ExportScheduler {
+	return &ExportScheduler{
+		storage: storage,
+		open:    open,
+		emit:    emit,
+		next:    make(map[string]scheduledRun),
+		running: make(map[string]bool),
+		active:  make(map[string]*Manager),
+	}
+}
+
+// Start begins checking for due schedules in the background. Without storage
+// there are no schedules to run.
+func (s *ExportScheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil || s.storage == nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	go s.loop(s.stop)
+}
+
+// Stop stops scheduling, cancels the runs in progress and waits for them to
+// clean up
+func (s *ExportScheduler) Stop() {
+	s.mu.Lock()
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	for _, m := range s.active {
+		m.CancelOperations()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *ExportScheduler) loop(stop chan struct{}) {
+	ticker := time.NewTicker(schedulerTick)
+	defer ticker.Stop()
+	s.tick(time.Now())
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.tick(now)
+		}
+	}
+}
+
+// tick starts the schedules that are due and works out when each runs next
+func (s *ExportScheduler) tick(now time.Time) {
+	schedules, err := s.storage.LoadExportSchedules()
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, schedule := range schedules {
+		next, ok := s.nextRun(schedule, now)
+		if !ok || now.Before(next) {
+			continue
+		}
+		// A run still in progress makes the schedule skip this time
+		if !s.running[schedule.ID] {
+			s.startRun(schedule, false)
+		}
+		delete(s.next, schedule.ID)
+		s.nextRun(schedule, now)
+	}
+}
+
+// nextRun returns when an enabled schedule runs next, computing it from now
+// when the schedule is new or its expression changed. Called with mu held.
+func (s *ExportScheduler) nextRun(schedule ExportSchedule, now time.Time) (time.Time, bool) {
+	if !schedule.Enabled {
+		delete(s.next, schedule.ID)
+		return time.Time{}, false
+	}
+	if run, ok := s.next[schedule.ID]; ok && run.cron == schedule.Cron {
+		return run.at, !run.at.IsZero()
+	}
+	cron, err := parseCron(schedule.Cron)
+	if err != nil {
+		return time.Time{}, false
+	}
+	at := cron.next(now)
+	s.next[schedule.ID] = scheduledRun{cron: schedule.Cron, at: at}
+	return at, !at.IsZero()
+}
+
+// RunNow starts a scheduled export at once, whether enabled or not
+func (s *ExportScheduler) RunNow(id string) error {
+	schedules, err := s.storage.LoadExportSchedules()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, schedule := range schedules {
+		if schedule.ID != id {
+			continue
+		}
+		if s.running[id] {
+			return fmt.Errorf("%s is already running", schedule.Name)
+		}
+		s.startRun(schedule, true)
+		return nil
+	}
+	return fmt.Errorf("schedule not found: %s", id)
+}
+
+// List returns the scheduled exports with their next and last runs
+func (s *ExportScheduler) List() ([]ExportScheduleStatus, error) {
+	schedules, err := s.storage.LoadExportSchedules()
+	if err != nil {
+		return nil, err
+	}
+	runs := make(map[string][]ExportRun)
+	if err := s.storage.readJSON(exportRunsFile, &runs); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	list := make([]ExportScheduleStatus, len(schedules))
+	for i, schedule := range schedules {
+		list[i] = ExportScheduleStatus{ExportSchedule: schedule, Running: s.running[schedule.ID]}
+		if next, ok := s.nextRun(schedule, now); ok {
+			list[i].NextRun = next.Format(time.RFC3339)
+		}
+		if history := runs[schedule.ID]; len(history) > 0 {
+			list[i].LastRun = &history[0]
+		}
+	}
+	return list, nil
+}
+
+// startRun runs a schedule in the background. Called with mu held.
+func (s *ExportScheduler) startRun(schedule ExportSchedule, manual bool) {
+	s.running[schedule.ID] = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		run := s.run(schedule, manual)
+		s.storage.recordExportRun(run)
+
+		s.mu.Lock()
+		delete(s.running, schedule.ID)
+		s.mu.Unlock()
+
+		s.emit("schedule:run", run)
+		if run.Status == "failed" {
+			s.emit("schedule:failed", run)
+		}
+	}()
+}
+
+// run exports the rows of a schedule's query and reports how it went
+func (s *ExportScheduler) run(schedule ExportSchedule, manual bool) ExportRun {
+	start := time.Now()
+	run := ExportRun{
+		ScheduleID: schedule.ID,
+		Schedule:   schedule.Name,
+		Started:    start.Format(time.RFC3339),
+		Manual:     manual,
+	}
+	rows, location, err := s.export(schedule, start)
+	run.DurationMs = durationMs(time.Since(start))
+	run.Rows, run.Location = rows, location
+	if err != nil {
+		run.Status, run.Error = "failed", err.Error()
+	} else {
+		run.Status = "done"
+	}
+	return run
+}
+
+// export writes the rows of a schedule's query to its file and returns how
+// many there were and where they went
+func (s *ExportScheduler) export(schedule ExportSchedule, start time.Time) (int64, string, error) {
+	queries, err := s.storage.LoadSavedQueries("")
+	if err != nil {
+		return 0, "", err
+	}
+	var query *SavedQuery
+	for i := range queries {
+		if queries[i].ID == schedule.QueryID {
+			query = &queries[i]
+		}
+	}
+	if query == nil {
+		return 0, "", fmt.Errorf("saved query not found: %s", schedule.QueryID)
+	}
+	// Parameters take their defaults unless the schedule sets them
+	values := make(map[string]interface{}, len(query.Parameters))
+	for _, p := range query.Parameters {
+		if p.Default != "" {
+			values[p.Name] = p.Default
+		}
+	}
+	for name, value := range schedule.Parameters {
+		values[name] = value
+	}
+
+	m, release, err := s.open(schedule.Connection)
+	if err != nil {
+		return 0, "", err
+	}
+	defer release()
+	s.mu.Lock()
+	s.active[schedule.ID] = m
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.active, schedule.ID)
+		s.mu.Unlock()
+	}()
+
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(schedule.Name, "_"), "_")
+	if name == "" {
+		name = "export"
+	}
+	name = fmt.Sprintf("%s_%s.%s", name, start.Format("20060102-150405"), schedule.Format)
+
+	contentType := "text/csv"
+	if schedule.Format == "json" {
+		contentType = "application/json"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s3UploadTimeout)
+	defer cancel()
+	var out exportOutput
+	location := filepath.Join(schedule.Directory, name)
+	if schedule.S3 != nil {
+		key := schedule.S3.Prefix + name
+		location = fmt.Sprintf("s3://%s/%s", schedule.S3.Bucket, key)
+		out, err = m.createBucketOutput(ctx, *schedule.S3, key, location, contentType)
+	} else {
+		out, err = m.createOutput(ctx, location, contentType)
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer out.Abort()
+
+	rows, err := m.exportQuery(query.SQL, values, schedule.Format, out)
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		return rows, "", err
+	}
+	return rows, location, nil
+}
+
+// exportQuery runs a single query returning rows with bind parameters and
+// writes the rows to w as CSV with a header or as a JSON array of objects,
+// returning how many there were
+func (m *Manager) exportQuery(query string, params map[string]interface{}, format string, w io.Writer) (int64, error) {
+	db := m.getDB()
+	if db == nil {
+		return 0, fmt.Errorf("not connected to database")
+	}
+	_, mysql := m.currentDriver().(*MySQLDriver)
+	statements := splitStatements(query, mysql)
+	if len(statements) != 1 || !returnsRows(statements[0]) {
+		return 0, fmt.Errorf("only a single query returning rows can be exported")
+	}
+	bound, args, err := (&parameterBinder{values: params, mysql: mysql}).bind(statements[0])
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, done := m.track("export", query)
+	defer done()
+	rows, err := db.withContext(ctx).Query(bound, args...)
+	if err != nil {
+		return 0, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get columns: %w", err)
+	}
+	categories := make([]string, len(columns))
+	if types, err := rows.ColumnTypes(); err == nil {
+		for i, t := range types {
+			categories[i] = typeCategory(t.DatabaseTypeName())
+		}
+	}
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	buf := bufio.NewWriter(w)
+	var csv *csvWriter
+	if format == "csv" {
+		csv = newCSVWriter(buf, CSVExportOptions{})
+		if err := csv.writeHeader(columns); err != nil {
+			return 0, err
+		}
+	} else {
+		buf.WriteString("[")
+	}
+
+	var count int64
+	opts := CopyOptions{}.withDefaults()
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return count, err
+		}
+		if csv != nil {
+			if err := csv.writeRow(values); err != nil {
+				return count, err
+			}
+			count++
+			continue
+		}
+
+		// One object per line, its keys in column order
+		if count > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n{")
+		for i, val := range values {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			key, _ := json.Marshal(columns[i])
+			value, err := json.Marshal(jsonCopyValue(val, categories[i], opts))
+			if err != nil {
+				return count, fmt.Errorf("failed to encode column %s: %w", columns[i], err)
+			}
+			buf.WriteString(string(key) + ":" + string(value))
+		}
+		buf.WriteString("}")
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+	if csv == nil {
+		buf.WriteString("\n]\n")
+	}
+	return count, buf.Flush()
+}
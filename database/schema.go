@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"time"
 )
 
 // GetDatabases returns list of all databases
@@ -11,7 +12,7 @@ func (m *Manager) GetDatabases() ([]DatabaseInfo, error) {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	names, err := m.driver.GetDatabases(db)
+	names, err := m.currentDriver().GetDatabases(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get databases: %w", err)
 	}
@@ -24,14 +25,47 @@ func (m *Manager) GetDatabases() ([]DatabaseInfo, error) {
 	return databases, nil
 }
 
-// GetTables returns list of tables in a database
-func (m *Manager) GetTables(database string) ([]TableInfo, error) {
+// GetSchemas returns the schemas of the current PostgreSQL database
+func (m *Manager) GetSchemas() ([]string, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	driver, ok := m.currentDriver().(*PostgresDriver)
+	if !ok {
+		return nil, fmt.Errorf("schemas are only available for PostgreSQL")
+	}
+
+	schemas, err := driver.GetSchemas(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schemas: %w", err)
+	}
+
+	return schemas, nil
+}
+
+// GetSchema returns the PostgreSQL schema of the connection, which tables
+// without a schema of their own are browsed and queried in
+func (m *Manager) GetSchema() (string, error) {
+	if m.getDB() == nil {
+		return "", fmt.Errorf("not connected to database")
+	}
+	driver, ok := m.currentDriver().(*PostgresDriver)
+	if !ok {
+		return "", fmt.Errorf("schemas are only available for PostgreSQL")
+	}
+	return driver.schemaName(), nil
+}
+
+// GetTables returns list of tables in a database. PostgreSQL lists them from
+// schema, or from the schema of the connection when it is empty.
+func (m *Manager) GetTables(database, schema string) ([]TableInfo, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	tables, err := m.driver.GetTables(db, database)
+	tables, err := m.tableDriver(schema).GetTables(db, database)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tables: %w", err)
 	}
@@ -39,14 +73,53 @@ func (m *Manager) GetTables(database string) ([]TableInfo, error) {
 	return tables, nil
 }
 
+// GetViews returns the views of a database with their definitions
+func (m *Manager) GetViews(database string) ([]ViewInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	views, err := m.currentDriver().GetViews(db, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get views: %w", err)
+	}
+
+	return views, nil
+}
+
+// isView reports whether a relation is a view or materialized view
+func (m *Manager) isView(database, schema, name string) bool {
+	db := m.getDB()
+	if db == nil {
+		return false
+	}
+	driver := m.tableDriver(schema)
+	if views, err := driver.GetViews(db, database); err == nil {
+		for _, v := range views {
+			if v.Name == name {
+				return true
+			}
+		}
+	}
+	if views, err := driver.GetMaterializedViews(db, database); err == nil {
+		for _, v := range views {
+			if v.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetColumns returns list of columns in a table
-func (m *Manager) GetColumns(database, table string) ([]ColumnInfo, error) {
+func (m *Manager) GetColumns(database, schema, table string) ([]ColumnInfo, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	columns, err := m.driver.GetColumns(db, database, table)
+	columns, err := m.tableDriver(schema).GetColumns(db, database, table)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get columns: %w", err)
 	}
@@ -55,32 +128,50 @@ func (m *Manager) GetColumns(database, table string) ([]ColumnInfo, error) {
 }
 
 // GetTableInfo returns detailed information about a table
-func (m *Manager) GetTableInfo(database, table string) (*TableDetails, error) {
-	columns, err := m.GetColumns(database, table)
+func (m *Manager) GetTableInfo(database, schema, table string) (*TableDetails, error) {
+	columns, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := m.GetIndexes(database, schema, table)
 	if err != nil {
 		return nil, err
 	}
 
-	indexes, err := m.GetIndexes(database, table)
+	foreignKeys, err := m.GetForeignKeys(database, schema, table)
 	if err != nil {
 		return nil, err
 	}
 
+	constraints, err := m.GetConstraints(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := m.tableDriver(schema).GetTableComment(m.getDB(), database, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table comment: %w", err)
+	}
+
 	return &TableDetails{
-		Name:    table,
-		Columns: columns,
-		Indexes: indexes,
+		Name:        table,
+		Comment:     comment,
+		Columns:     columns,
+		Indexes:     indexes,
+		ForeignKeys: foreignKeys,
+		Constraints: constraints,
 	}, nil
 }
 
 // GetIndexes returns list of indexes on a table
-func (m *Manager) GetIndexes(database, table string) ([]IndexInfo, error) {
+func (m *Manager) GetIndexes(database, schema, table string) ([]IndexInfo, error) {
 	db := m.getDB()
 	if db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
 
-	indexes, err := m.driver.GetIndexes(db, database, table)
+	indexes, err := m.tableDriver(schema).GetIndexes(db, database, table)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get indexes: %w", err)
 	}
@@ -88,6 +179,21 @@ func (m *Manager) GetIndexes(database, table string) ([]IndexInfo, error) {
 	return indexes, nil
 }
 
+// GetForeignKeys returns the foreign keys declared on a table
+func (m *Manager) GetForeignKeys(database, schema, table string) ([]ForeignKeyInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	foreignKeys, err := m.tableDriver(schema).GetForeignKeys(db, database, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get foreign keys: %w", err)
+	}
+
+	return foreignKeys, nil
+}
+
 // UseDatabase switches to a specific database
 func (m *Manager) UseDatabase(database string) error {
 	db := m.getDB()
@@ -98,7 +204,7 @@ func (m *Manager) UseDatabase(database string) error {
 	// Dialect specific switch might be needed, but USE is fairly common.
 	// For now, let's just use a raw statement, but PostgreSQL uses a different connection.
 	// We might need Driver.SwitchDatabase in the future.
-	_, err := db.Exec("USE " + m.driver.QuoteIdentifier(database))
+	_, err := db.Exec("USE " + m.currentDriver().QuoteIdentifier(database))
 	if err != nil {
 		return fmt.Errorf("failed to switch database: %w", err)
 	}
@@ -111,33 +217,101 @@ func (m *Manager) UseDatabase(database string) error {
 }
 
 // TruncateTable removes all rows from a table
-func (m *Manager) TruncateTable(database, table string) error {
+func (m *Manager) TruncateTable(database, schema, table string) error {
 	db := m.getDB()
 	if db == nil {
 		return fmt.Errorf("not connected to database")
 	}
 
-	query := m.driver.BuildTruncateTableQuery(database, table)
+	query := m.tableDriver(schema).BuildTruncateTableQuery(database, table)
 	_, err := db.Exec(query)
 	if err != nil {
 		return fmt.Errorf("failed to truncate table: %w", err)
 	}
+	m.forgetUndo(database, schema, table)
 
 	return nil
 }
 
+// PreviewTruncateTable returns the statement TruncateTable would run
+func (m *Manager) PreviewTruncateTable(database, schema, table string) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	return m.previewOf(m.tableDriver(schema).BuildTruncateTableQuery(database, table), nil), nil
+}
+
 // DropTable deletes a table
-func (m *Manager) DropTable(database, table string) error {
+func (m *Manager) DropTable(database, schema, table string) error {
 	db := m.getDB()
 	if db == nil {
 		return fmt.Errorf("not connected to database")
 	}
 
-	query := m.driver.BuildDropTableQuery(database, table)
+	query := m.tableDriver(schema).BuildDropTableQuery(database, table)
 	_, err := db.Exec(query)
 	if err != nil {
 		return fmt.Errorf("failed to drop table: %w", err)
 	}
+	m.forgetUndo(database, schema, table)
+
+	return nil
+}
+
+// PreviewDropTable returns the statement DropTable would run
+func (m *Manager) PreviewDropTable(database, schema, table string) (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	return m.previewOf(m.tableDriver(schema).BuildDropTableQuery(database, table), nil), nil
+}
+
+// GetMaterializedViews returns the materialized views of a database
+func (m *Manager) GetMaterializedViews(database string) ([]MaterializedViewInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	views, err := m.currentDriver().GetMaterializedViews(db, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get materialized views: %w", err)
+	}
+
+	m.mu.RLock()
+	for i, v := range views {
+		if t, ok := m.refreshed[database+"/"+v.Name]; ok {
+			views[i].LastRefresh = t.Format(time.RFC3339)
+		}
+	}
+	m.mu.RUnlock()
+
+	return views, nil
+}
+
+// RefreshMaterializedView recomputes a materialized view. A concurrent refresh
+// doesn't block readers but requires a unique index on the view.
+func (m *Manager) RefreshMaterializedView(database, view string, concurrently bool) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	query, err := m.currentDriver().BuildRefreshMaterializedViewQuery(database, view, concurrently)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to refresh materialized view: %w", err)
+	}
+
+	m.mu.Lock()
+	if m.refreshed == nil {
+		m.refreshed = make(map[string]time.Time)
+	}
+	m.refreshed[database+"/"+view] = time.Now()
+	m.mu.Unlock()
 
 	return nil
 }
@@ -31,7 +31,7 @@ func (m *Manager) GetDatabaseSchema(database string) (map[string][]string, error
 	// Given the user wants DataGrip like storage, let's try the efficient way but valid for MySQL.
 
 	// Check if MySQL
-	if _, ok := m.driver.(*MySQLDriver); ok {
+	if _, ok := m.currentDriver().(*MySQLDriver); ok {
 		rows, err := db.Query(query, database)
 		if err != nil {
 			return nil, err
@@ -50,7 +50,7 @@ func (m *Manager) GetDatabaseSchema(database string) (map[string][]string, error
 	}
 
 	// Fallback for others (Postgres) or if we want to be safe
-	tables, err := m.GetTables(database)
+	tables, err := m.GetTables(database, "")
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +58,7 @@ func (m *Manager) GetDatabaseSchema(database string) (map[string][]string, error
 	schema := make(map[string][]string)
 	// This might be slow for many tables, but reliable
 	for _, table := range tables {
-		cols, err := m.GetColumns(database, table.Name)
+		cols, err := m.GetColumns(database, "", table.Name)
 		if err != nil {
 			continue
 		}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// defaultSearchLimit caps schema search results when no limit is given
+const defaultSearchLimit = 100
+
+// SchemaSearchResult is an object whose name matched a schema search
+type SchemaSearchResult struct {
+	Kind   string `json:"kind"`   // table, view, materialized_view, column, function, procedure
+	Schema string `json:"schema"` // PostgreSQL schema or MySQL database
+	Table  string `json:"table,omitempty"`
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"` // Column type or function arguments
+}
+
+// SearchSchema finds tables, views, columns and routines whose name contains
+// pattern, in every schema of the connection. Exact and prefix matches rank first.
+func (m *Manager) SearchSchema(pattern string, limit int) ([]SchemaSearchResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return nil, fmt.Errorf("search pattern is empty")
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	results, err := m.currentDriver().SearchSchema(db, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search schema: %w", err)
+	}
+
+	return results, nil
+}
+
+// escapeLike escapes LIKE wildcards so s matches literally, using backslash
+// as the escape character
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// scanSearchResults reads rows of kind, schema, table, name, detail
+func scanSearchResults(rows *sql.Rows) ([]SchemaSearchResult, error) {
+	defer rows.Close()
+
+	results := []SchemaSearchResult{}
+	for rows.Next() {
+		var r SchemaSearchResult
+		if err := rows.Scan(&r.Kind, &r.Schema, &r.Table, &r.Name, &r.Detail); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
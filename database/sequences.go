@@ -0,0 +1,90 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SequenceInfo describes a sequence. Numeric values are decimal strings since
+// bigint bounds don't survive the trip through JavaScript numbers.
+type SequenceInfo struct {
+	Name         string `json:"name"`
+	CurrentValue string `json:"currentValue"` // Empty until the sequence is first used
+	Increment    string `json:"increment"`
+	MinValue     string `json:"minValue"`
+	MaxValue     string `json:"maxValue"`
+	StartValue   string `json:"startValue"`
+	Cycle        bool   `json:"cycle"`
+	OwnedBy      string `json:"ownedBy"` // table.column the sequence feeds, if any
+}
+
+// SequenceAlteration lists sequence options to change; empty fields are left as is
+type SequenceAlteration struct {
+	Increment string `json:"increment,omitempty"`
+	MinValue  string `json:"minValue,omitempty"`
+	MaxValue  string `json:"maxValue,omitempty"`
+	Cycle     *bool  `json:"cycle,omitempty"`
+}
+
+// GetSequences returns the sequences of a database. For MySQL these are the
+// AUTO_INCREMENT counters of its tables.
+func (m *Manager) GetSequences(database string) ([]SequenceInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	sequences, err := m.currentDriver().GetSequences(db, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sequences: %w", err)
+	}
+
+	return sequences, nil
+}
+
+// RestartSequence makes the next value of a sequence equal to value
+func (m *Manager) RestartSequence(database, sequence, value string) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	next, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid sequence value: %s", value)
+	}
+
+	if _, err := db.Exec(m.currentDriver().BuildRestartSequenceQuery(database, sequence, next)); err != nil {
+		return fmt.Errorf("failed to restart sequence: %w", err)
+	}
+
+	return nil
+}
+
+// AlterSequence changes the increment, bounds or cycling of a sequence
+func (m *Manager) AlterSequence(database, sequence string, alteration SequenceAlteration) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	for _, v := range []string{alteration.Increment, alteration.MinValue, alteration.MaxValue} {
+		if v == "" {
+			continue
+		}
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			return fmt.Errorf("invalid sequence value: %s", v)
+		}
+	}
+
+	query, err := m.currentDriver().BuildAlterSequenceQuery(database, sequence, alteration)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to alter sequence: %w", err)
+	}
+
+	return nil
+}
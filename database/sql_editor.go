@@ -0,0 +1,343 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ResultColumn describes a column of a result set as reported by the driver
+type ResultColumn struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"` // Database type name, e.g. VARCHAR, NUMERIC
+	Nullable  *bool  `json:"nullable,omitempty"`
+	Length    int64  `json:"length,omitempty"`    // Variable-length text and binary types
+	Precision int64  `json:"precision,omitempty"` // Decimal types
+	Scale     int64  `json:"scale,omitempty"`
+}
+
+// ResultSet is one set of rows returned by a statement
+type ResultSet struct {
+	Columns  []ResultColumn  `json:"columns"`
+	Rows     [][]interface{} `json:"rows"`
+	RowCount int             `json:"rowCount"`
+}
+
+// StatementResult is the outcome of one statement typed in the SQL editor.
+// Statements that return rows report them as result sets; others report the
+// rows they affected.
+type StatementResult struct {
+	Statement    string         `json:"statement"`
+	ResultSets   []ResultSet    `json:"resultSets"`
+	RowsAffected int64          `json:"rowsAffected"`
+	LastInsertId int64          `json:"lastInsertId"`
+	DurationMs   float64        `json:"durationMs"`
+	Error        string         `json:"error,omitempty"`
+	Notices      []ServerNotice `json:"notices,omitempty"` // RAISE NOTICE output and warnings
+	Stats        QueryStats     `json:"stats"`
+}
+
+// SQLResult holds the outcome of an ExecuteSQL call
+type SQLResult struct {
+	Statements []StatementResult `json:"statements"`
+	Skipped    int               `json:"skipped,omitempty"` // Statements not run after a failure
+	DurationMs float64           `json:"durationMs"`
+}
+
+// SQLOptions controls how ExecuteSQL runs a script
+type SQLOptions struct {
+	ContinueOnError bool   `json:"continueOnError"`   // Run the remaining statements after one fails
+	Timeout         int    `json:"timeout,omitempty"` // Seconds per statement; defaults to the connection's QueryTimeout
+	TabID           string `json:"tabId,omitempty"`   // Query tab whose transaction the statements run in
+	// Values of the placeholders found by DetectParameters, keyed by name. When
+	// set, the statements run with bind parameters.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// Analyze runs each query a second time under EXPLAIN ANALYZE to report
+	// its planning and execution times. Statements that change data, take
+	// locks or call functions with side effects are not analyzed.
+	Analyze bool `json:"analyze,omitempty"`
+	// MaxRows bounds the rows kept per result set; the rest are read and
+	// counted in RowCount only. 0 keeps every row.
+	MaxRows int `json:"maxRows,omitempty"`
+}
+
+// rowKeywords are the leading keywords of statements that return rows
+var rowKeywords = map[string]bool{
+	"SELECT": true, "WITH": true, "VALUES": true, "TABLE": true,
+	"SHOW": true, "EXPLAIN": true, "DESCRIBE": true, "DESC": true,
+	"CALL": true, "FETCH": true,
+}
+
+// readOnlyKeywords lead statements that can't change rows of a table, which
+// leave the undo log alone
+var readOnlyKeywords = map[string]bool{
+	"SELECT": true, "VALUES": true, "TABLE": true, "SHOW": true,
+	"DESCRIBE": true, "DESC": true, "FETCH": true,
+}
+
+// returningClause matches the RETURNING clause of a data-modifying statement
+var returningClause = regexp.MustCompile(`(?i)\bRETURNING\b`)
+
+// leadingComment matches comments and whitespace at the start of a statement
+var leadingComment = regexp.MustCompile(`^(\s+|--[^\n]*(\n|$)|#[^\n]*(\n|$)|/\*(?s:.*?)\*/)+`)
+
+// leadingKeyword returns the first word of a statement in upper case
+func leadingKeyword(statement string) string {
+	s := strings.TrimLeft(leadingComment.ReplaceAllString(statement, ""), "(")
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if end < 0 {
+		end = len(s)
+	}
+	return strings.ToUpper(s[:end])
+}
+
+// returnsRows tells whether a statement is expected to produce result sets
+func returnsRows(statement string) bool {
+	return rowKeywords[leadingKeyword(statement)] || returningClause.MatchString(statement)
+}
+
+// ExecuteSQL runs SQL typed by the user. Unlike ExecuteQuery it accepts any
+// statement, keeps every result set and reports column metadata and timing.
+// The script is split into statements that run one after another on a single
+// connection, so that session settings and temporary tables carry over. A
+// failing statement is reported in its result rather than as an error, so that
+// the results before it stay visible; the rest of the script is skipped
+// unless opts.ContinueOnError is set. With opts.TabID, the statements run in
+// the tab's open transaction, or open one there when the script begins one.
+// With opts.Parameters, placeholders such as $1 or :name are bound rather
+// than sent as typed.
+func (m *Manager) ExecuteSQL(query string, opts SQLOptions) (*SQLResult, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	_, mysql := m.currentDriver().(*MySQLDriver)
+	statements := splitStatements(query, mysql)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no SQL to execute")
+	}
+
+	ctx, done := m.track("sql", query)
+	defer done()
+	return m.runScript(ctx, db, statements, opts, nil)
+}
+
+// runScript runs the statements of a script for the operation tracked by ctx
+// as ExecuteSQL does, calling progress, if set, after each statement
+func (m *Manager) runScript(ctx context.Context, db *instrumentedDB, statements []string, opts SQLOptions, progress func(StatementResult)) (*SQLResult, error) {
+	_, mysql := m.currentDriver().(*MySQLDriver)
+	var err error
+	tab := m.tab(opts.TabID)
+	if tab == nil && opts.TabID != "" && startsTransaction(statements) {
+		if tab, err = m.openTab(db, opts.TabID); err != nil {
+			return nil, err
+		}
+	}
+
+	var conn *instrumentedConn
+	if tab != nil {
+		if !tab.mu.TryLock() {
+			return nil, fmt.Errorf("a statement is still running in this tab")
+		}
+		defer tab.mu.Unlock()
+		// The tab keeps its connection while the transaction stays open
+		defer m.settleTab(tab)
+		conn = tabConn(ctx, db, tab)
+		defer m.attachBackend(ctx, tab.backend)()
+	} else {
+		conn, err = m.session(ctx, db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire connection: %w", err)
+		}
+		defer conn.Close()
+	}
+
+	timeout := m.queryTimeout()
+	if opts.Timeout > 0 {
+		timeout = time.Duration(opts.Timeout) * time.Second
+	}
+	notices := &noticeCollector{}
+	defer m.currentDriver().CaptureNotices(conn.conn, notices.add)()
+
+	var limit *statementLimit
+	if tab != nil && tab.state.Aborted {
+		// Even SET fails in an aborted transaction
+		limit = m.clientLimit(ctx, conn, timeout)
+	} else if limit, err = m.limitStatements(ctx, conn, timeout); err != nil {
+		return nil, err
+	}
+	defer limit.close()
+
+	binder := &parameterBinder{values: opts.Parameters, mysql: mysql}
+	start := time.Now()
+	result := &SQLResult{Statements: make([]StatementResult, 0, len(statements))}
+	for i, statement := range statements {
+		began := time.Now()
+		limit.start()
+		var stmt StatementResult
+		var err error
+		bound, args, bindErr := statement, []interface{}(nil), error(nil)
+		if len(opts.Parameters) > 0 {
+			bound, args, bindErr = binder.bind(statement)
+		}
+		if bindErr != nil {
+			stmt = StatementResult{Statement: statement, ResultSets: []ResultSet{}, Error: bindErr.Error()}
+		} else {
+			stmt = runStatement(conn, opts.MaxRows, bound, args...)
+			stmt.Statement = statement
+			if !readOnlyKeywords[leadingKeyword(statement)] {
+				// Any table may have changed
+				m.ClearUndoLog()
+			}
+		}
+		if stmt.Error != "" {
+			err = errors.New(stmt.Error)
+		}
+		if err = limit.stop(err); err != nil {
+			stmt.Error = err.Error()
+		}
+		stmt.Notices = append(notices.drain(), m.serverWarnings(conn, statement, err != nil)...)
+		stmt.Stats = QueryStats{
+			WallTimeMs:   durationMs(time.Since(began)),
+			RowsAffected: stmt.RowsAffected,
+			RowsFetched:  stmt.rowCount() - stmt.RowsAffected,
+		}
+		if bindErr == nil {
+			stmt.Stats.EstimatedRoundtrips = m.statementRoundtrips(statement, args, err != nil)
+		}
+		if opts.Analyze && err == nil && analyzable(statement) {
+			limit.start()
+			m.analyzeStatement(conn, bound, args, &stmt.Stats)
+			limit.stop(nil)
+		}
+		m.recordHistory(statement, began, time.Since(began), stmt.rowCount(), err)
+		if tab != nil {
+			tab.observe(statement, err != nil, mysql)
+		}
+		result.Statements = append(result.Statements, stmt)
+		if progress != nil {
+			progress(stmt)
+		}
+		if stmt.Error != "" && !opts.ContinueOnError {
+			result.Skipped = len(statements) - i - 1
+			break
+		}
+	}
+	result.DurationMs = durationMs(time.Since(start))
+	return result, nil
+}
+
+// rowCount is the number of rows a statement returned or affected
+func (r StatementResult) rowCount() int64 {
+	count := r.RowsAffected
+	for _, set := range r.ResultSets {
+		count += int64(set.RowCount)
+	}
+	return count
+}
+
+// runStatement runs a single statement of the SQL editor with its bind
+// arguments, keeping up to maxRows rows of each result set when positive
+func runStatement(db Querier, maxRows int, statement string, args ...interface{}) StatementResult {
+	result := StatementResult{Statement: statement, ResultSets: []ResultSet{}}
+	start := time.Now()
+	defer func() { result.DurationMs = durationMs(time.Since(start)) }()
+
+	if !returnsRows(statement) {
+		res, err := db.Exec(statement, args...)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.RowsAffected, _ = res.RowsAffected()
+		result.LastInsertId, _ = res.LastInsertId()
+		return result
+	}
+
+	rows, err := db.Query(statement, args...)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer rows.Close()
+
+	for {
+		set, err := readResultSet(rows, maxRows)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if len(set.Columns) > 0 {
+			result.ResultSets = append(result.ResultSets, *set)
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// readResultSet reads the current result set of rows with its column
+// metadata. Beyond maxRows, when positive, rows are counted but not kept.
+func readResultSet(rows *sql.Rows, maxRows int) (*ResultSet, error) {
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	columns := resultColumns(rows)
+	if columns == nil {
+		columns = make([]ResultColumn, len(names))
+		for i, name := range names {
+			columns[i] = ResultColumn{Name: name}
+		}
+	}
+
+	kept, more, err := scanRows(rows, len(names), maxRows)
+	if err != nil {
+		return nil, err
+	}
+	set := &ResultSet{Columns: columns, Rows: kept, RowCount: len(kept)}
+	for more && rows.Next() {
+		set.RowCount++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+	return set, nil
+}
+
+// resultColumns describes the columns of the current result set, or returns
+// nil when the driver cannot
+func resultColumns(rows *sql.Rows) []ResultColumn {
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil
+	}
+
+	columns := make([]ResultColumn, len(colTypes))
+	for i, ct := range colTypes {
+		columns[i] = ResultColumn{Name: ct.Name(), Type: ct.DatabaseTypeName()}
+		if nullable, ok := ct.Nullable(); ok {
+			columns[i].Nullable = &nullable
+		}
+		// Unbounded types report the largest length
+		if length, ok := ct.Length(); ok && length != math.MaxInt64 {
+			columns[i].Length = length
+		}
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			columns[i].Precision, columns[i].Scale = precision, scale
+		}
+	}
+	return columns
+}
@@ -0,0 +1,486 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FormatOptions controls how FormatSQL lays out a script
+type FormatOptions struct {
+	Dialect     string `json:"dialect,omitempty"`     // mysql or postgres; the connected server's when empty
+	Indent      int    `json:"indent,omitempty"`      // Spaces per level; defaults to 2
+	KeywordCase string `json:"keywordCase,omitempty"` // upper (default), lower or preserve
+}
+
+type sqlTokenKind int
+
+const (
+	tokenWord sqlTokenKind = iota
+	tokenQuoted
+	tokenComment
+	tokenNumber
+	tokenOperator
+	tokenPunct
+	tokenPlaceholder
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+}
+
+// sqlOperators are the operators of more than one character, longest first
+var sqlOperators = []string{
+	"->>", "#>>", "!~*", "<=>",
+	"::", "<=", ">=", "<>", "!=", "||", "->", "#>", "@>", "<@", ":=", "=>", "<<", ">>",
+	"~~", "!~", "~*", "&&",
+}
+
+// delimiterLine matches the DELIMITER lines of mysql client scripts
+var delimiterLine = regexp.MustCompile(`(?im)^\s*DELIMITER\s`)
+
+// FormatSQL pretty-prints a script the way the SQL editor lays out queries:
+// one clause per line, select lists and conditions one item per line and
+// subqueries indented. Strings, quoted identifiers, comments and dollar-quoted
+// bodies are kept as typed, following the quoting rules of the dialect.
+func (m *Manager) FormatSQL(script string, opts FormatOptions) (string, error) {
+	var mysql bool
+	switch opts.Dialect {
+	case "":
+		_, mysql = m.currentDriver().(*MySQLDriver)
+	case "mysql":
+		mysql = true
+	case "postgres":
+	default:
+		return "", fmt.Errorf("unsupported dialect: %s", opts.Dialect)
+	}
+	if opts.Indent <= 0 {
+		opts.Indent = 2
+	}
+	switch opts.KeywordCase {
+	case "":
+		opts.KeywordCase = "upper"
+	case "upper", "lower", "preserve":
+	default:
+		return "", fmt.Errorf("unsupported keyword case: %s", opts.KeywordCase)
+	}
+	// Statement bodies between DELIMITER lines hold semicolons of their own
+	if mysql && delimiterLine.MatchString(script) {
+		return "", fmt.Errorf("scripts with DELIMITER lines cannot be formatted")
+	}
+
+	f := &sqlFormatter{opts: opts, keywords: formatKeywords(mysql)}
+	f.format(tokenizeSQL(script, mysql))
+	return f.String(), nil
+}
+
+// tokenizeSQL splits a script into tokens, dropping whitespace
+func tokenizeSQL(script string, mysql bool) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(script); {
+		c := script[i]
+		if next := skipLiteral(script, i, mysql); next > i {
+			text := strings.TrimRight(script[i:next], " \t\r\n")
+			kind := tokenQuoted
+			if strings.HasPrefix(text, "--") || strings.HasPrefix(text, "/*") || c == '#' {
+				kind = tokenComment
+			}
+			tokens = append(tokens, sqlToken{kind, text})
+			i = next
+			continue
+		}
+
+		start := i
+		kind := tokenPunct
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+			continue
+
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(script) && script[i+1] >= '0' && script[i+1] <= '9':
+			kind = tokenNumber
+			for i < len(script) && (script[i] >= '0' && script[i] <= '9' || script[i] == '.') {
+				i++
+			}
+			if i < len(script) && (script[i] == 'e' || script[i] == 'E') {
+				i++
+				if i < len(script) && (script[i] == '+' || script[i] == '-') {
+					i++
+				}
+				for i < len(script) && script[i] >= '0' && script[i] <= '9' {
+					i++
+				}
+			}
+
+		case isIdentChar(c) && c != '$' || mysql && c == '@':
+			kind = tokenWord
+			i++
+			for i < len(script) && (isIdentChar(script[i]) || mysql && script[i] == '@') {
+				i++
+			}
+			// Prefixed strings such as E'\n', N'text' or X'ff'
+			if i < len(script) && script[i] == '\'' && i-start == 1 && strings.ContainsRune("EeNnXxBbUu", rune(c)) {
+				i = skipLiteral(script, i, mysql)
+				kind = tokenQuoted
+			}
+
+		case !mysql && c == '$' && i+1 < len(script) && script[i+1] >= '0' && script[i+1] <= '9',
+			c == ':' && i+1 < len(script) && isIdentChar(script[i+1]) && script[i+1] != '$' &&
+				(script[i+1] < '0' || script[i+1] > '9') && (i == 0 || script[i-1] != ':'):
+			kind = tokenPlaceholder
+			i++
+			for i < len(script) && isIdentChar(script[i]) && script[i] != '$' {
+				i++
+			}
+
+		case mysql && c == '?':
+			kind = tokenPlaceholder
+			i++
+
+		case strings.ContainsRune("(),;.[]", rune(c)):
+			i++
+
+		default:
+			kind = tokenOperator
+			i++
+			for _, op := range sqlOperators {
+				if strings.HasPrefix(script[start:], op) {
+					i = start + len(op)
+					break
+				}
+			}
+		}
+		tokens = append(tokens, sqlToken{kind, script[start:i]})
+	}
+	return tokens
+}
+
+// formatBlock is a statement or a parenthesized subquery or definition list
+// being formatted
+type formatBlock struct {
+	base   int  // Indent level of its clauses
+	open   int  // Indent level of the line holding its opening parenthesis
+	parens int  // Inline parentheses open within it, e.g. function calls
+	cases  int  // CASE expressions open within it, kept on one line
+	list   bool // A column list of CREATE TABLE: one item per line, no clauses
+}
+
+type sqlFormatter struct {
+	opts     FormatOptions
+	keywords map[string]bool
+
+	lines  []string
+	line   strings.Builder
+	indent int // Indent level of the current line
+	blocks []formatBlock
+
+	start   int       // Index of the first token of the statement
+	prev    *sqlToken // Last token written in the statement
+	before  *sqlToken // Token written before prev
+	unary   bool      // The last token was a sign, written without a space after it
+	between bool      // The next AND belongs to BETWEEN
+}
+
+func (f *sqlFormatter) String() string {
+	f.newline(0)
+	for len(f.lines) > 0 && f.lines[len(f.lines)-1] == "" {
+		f.lines = f.lines[:len(f.lines)-1]
+	}
+	return strings.Join(f.lines, "\n")
+}
+
+// newline ends the current line, unless it is still empty, and starts one at
+// the given indent level
+func (f *sqlFormatter) newline(indent int) {
+	if f.line.Len() > 0 {
+		f.lines = append(f.lines, strings.Repeat(" ", f.indent*f.opts.Indent)+f.line.String())
+		f.line.Reset()
+	}
+	f.indent = indent
+}
+
+func (f *sqlFormatter) block() *formatBlock {
+	return &f.blocks[len(f.blocks)-1]
+}
+
+func (f *sqlFormatter) format(tokens []sqlToken) {
+	f.blocks = []formatBlock{{}}
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		b := f.block()
+		upper := strings.ToUpper(t.text)
+
+		switch {
+		case t.kind == tokenComment:
+			f.write(t)
+			if !strings.HasPrefix(t.text, "/*") {
+				f.newline(f.indent)
+			}
+			continue
+
+		case t.text == ";":
+			f.write(t)
+			f.newline(0)
+			f.lines = append(f.lines, "")
+			f.blocks = []formatBlock{{}}
+			f.start = i + 1
+			f.prev, f.before, f.unary, f.between = nil, nil, false, false
+			continue
+
+		case t.text == "(":
+			f.write(t)
+			next := f.nextWord(tokens, i)
+			switch {
+			case next == "SELECT" || next == "WITH":
+				f.blocks = append(f.blocks, formatBlock{base: f.indent + 1, open: f.indent})
+			case b.parens == 0 && len(f.blocks) == 1 && f.createsTable(tokens[f.start:i]):
+				f.blocks = append(f.blocks, formatBlock{base: f.indent + 1, open: f.indent, list: true})
+				f.newline(f.indent + 1)
+			default:
+				b.parens++
+			}
+			continue
+
+		case t.text == ")":
+			if b.parens == 0 && len(f.blocks) > 1 {
+				f.blocks = f.blocks[:len(f.blocks)-1]
+				f.newline(b.open)
+			} else if b.parens > 0 {
+				b.parens--
+			}
+			f.write(t)
+			continue
+
+		case t.text == "," && b.parens == 0:
+			f.write(t)
+			if b.list {
+				f.newline(b.base)
+			} else {
+				f.newline(b.base + 1)
+			}
+			continue
+		}
+
+		if t.kind == tokenWord && upper == "CASE" {
+			b.cases++
+		} else if t.kind == tokenWord && upper == "END" && b.cases > 0 {
+			b.cases--
+		} else if t.kind == tokenWord && b.parens == 0 && b.cases == 0 && !b.list {
+			if n := f.clause(tokens, i); n > 0 {
+				i += n - 1
+				continue
+			}
+			switch {
+			case upper == "BETWEEN":
+				f.between = true
+			case upper == "AND" && f.between:
+				f.between = false
+			case upper == "AND" || upper == "OR":
+				f.newline(b.base + 1)
+			}
+		}
+		f.write(t)
+	}
+}
+
+// clause lays out the clause keyword starting at tokens[i], if one does, and
+// returns how many tokens it took
+func (f *sqlFormatter) clause(tokens []sqlToken, i int) int {
+	b := f.block()
+	words := f.words(tokens, i, 4)
+	prev := ""
+	if f.prev != nil {
+		prev = strings.ToUpper(f.prev.text)
+	}
+	joined := strings.Join(words, " ")
+	has := func(prefix string) bool {
+		return joined == prefix || strings.HasPrefix(joined, prefix+" ")
+	}
+
+	n := 0
+	kind := "" // content: the items go on lines of their own; inline: on the keyword's line; alone: on a line of its own
+	switch {
+	case has("GROUP BY"), has("ORDER BY"), has("PARTITION BY"):
+		n, kind = 2, "content"
+	case words[0] == "SELECT":
+		n, kind = 1, "content"
+		if len(words) > 1 && (words[1] == "DISTINCT" || words[1] == "ALL") {
+			n = 2
+		}
+	case words[0] == "FROM" && prev != "DISTINCT",
+		words[0] == "WHERE", words[0] == "HAVING", words[0] == "RETURNING", words[0] == "WINDOW",
+		words[0] == "VALUES" && (f.prev == nil || f.prev.kind != tokenOperator),
+		words[0] == "SET" && prev != "CHARACTER" && prev != "" && prev != ";":
+		n, kind = 1, "content"
+	case words[0] == "WITH" && (f.prev == nil || prev == "("):
+		n, kind = 1, "content"
+		if has("WITH RECURSIVE") {
+			n = 2
+		}
+	case has("UNION ALL"), has("UNION DISTINCT"), has("EXCEPT ALL"), has("INTERSECT ALL"):
+		n, kind = 2, "alone"
+	case words[0] == "UNION", words[0] == "EXCEPT", words[0] == "INTERSECT":
+		n, kind = 1, "alone"
+	case has("ON DUPLICATE KEY UPDATE"):
+		n, kind = 4, "inline"
+	case has("ON CONFLICT"), has("INSERT INTO"), has("DELETE FROM"), has("REPLACE INTO"),
+		has("FOR UPDATE"), has("FOR SHARE"), has("FOR NO KEY UPDATE"), has("FOR KEY SHARE"):
+		n, kind = 2, "inline"
+		if has("FOR NO KEY UPDATE") {
+			n = 4
+		} else if has("FOR KEY SHARE") {
+			n = 3
+		}
+	case words[0] == "LIMIT", words[0] == "OFFSET", words[0] == "FETCH",
+		words[0] == "UPDATE" && prev != "FOR" && prev != "DO" && prev != "KEY" && prev != "ON",
+		words[0] == "INSERT", words[0] == "DELETE" && prev != "ON":
+		n, kind = 1, "inline"
+	default:
+		// LEFT OUTER JOIN, CROSS JOIN, JOIN, ...
+		for j, w := range words {
+			if w == "JOIN" || w == "STRAIGHT_JOIN" {
+				n, kind = j+1, "join"
+				break
+			}
+			if w != "LEFT" && w != "RIGHT" && w != "FULL" && w != "INNER" && w != "OUTER" && w != "CROSS" && w != "NATURAL" {
+				break
+			}
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+
+	f.between = false
+	if kind == "join" {
+		f.newline(b.base + 1)
+	} else {
+		f.newline(b.base)
+	}
+	for j := 0; j < n; j++ {
+		f.write(tokens[i+j])
+	}
+	switch kind {
+	case "content":
+		f.newline(b.base + 1)
+	case "alone":
+		f.newline(b.base)
+	}
+	return n
+}
+
+// words returns up to n words from tokens[i] on in upper case, stopping at
+// the first token that is not a word
+func (f *sqlFormatter) words(tokens []sqlToken, i, n int) []string {
+	var words []string
+	for ; i < len(tokens) && len(words) < n && tokens[i].kind == tokenWord; i++ {
+		words = append(words, strings.ToUpper(tokens[i].text))
+	}
+	return words
+}
+
+// nextWord returns the next token after tokens[i] in upper case, skipping comments
+func (f *sqlFormatter) nextWord(tokens []sqlToken, i int) string {
+	return strings.ToUpper(f.nextText(tokens, i))
+}
+
+func (f *sqlFormatter) nextText(tokens []sqlToken, i int) string {
+	for i++; i < len(tokens); i++ {
+		if tokens[i].kind != tokenComment {
+			return tokens[i].text
+		}
+	}
+	return ""
+}
+
+// createsTable tells whether a statement so far is a CREATE TABLE about to
+// open its column list
+func (f *sqlFormatter) createsTable(statement []sqlToken) bool {
+	if len(statement) == 0 || !strings.EqualFold(statement[0].text, "CREATE") {
+		return false
+	}
+	table := false
+	for _, t := range statement {
+		switch {
+		case t.text == "(", strings.EqualFold(t.text, "AS"):
+			return false
+		case strings.EqualFold(t.text, "TABLE"):
+			table = true
+		}
+	}
+	return table
+}
+
+// write appends a token to the current line, spaced from the previous one
+func (f *sqlFormatter) write(t sqlToken) {
+	text := t.text
+	if t.kind == tokenWord && f.opts.KeywordCase != "preserve" && f.keywords[strings.ToUpper(text)] &&
+		(f.prev == nil || f.prev.text != ".") {
+		if f.opts.KeywordCase == "upper" {
+			text = strings.ToUpper(text)
+		} else {
+			text = strings.ToLower(text)
+		}
+	}
+
+	if f.line.Len() > 0 && f.spaced(t) {
+		f.line.WriteByte(' ')
+	}
+	f.line.WriteString(text)
+
+	f.unary = (t.text == "-" || t.text == "+") && (f.prev == nil || f.prev.kind == tokenOperator ||
+		f.prev.text == "(" || f.prev.text == "," || f.prev.kind == tokenWord && f.keywords[strings.ToUpper(f.prev.text)])
+	tok := t
+	f.before, f.prev = f.prev, &tok
+}
+
+// spaced tells whether a space goes between the previous token and t
+func (f *sqlFormatter) spaced(t sqlToken) bool {
+	p := f.prev
+	if p == nil {
+		return true
+	}
+	switch {
+	case f.unary, p.text == "(", p.text == ".", p.text == "::", p.text == "[":
+		return false
+	case t.text == ",", t.text == ")", t.text == ";", t.text == ".", t.text == "::", t.text == "]":
+		return false
+	case t.text == "[":
+		return p.kind == tokenOperator
+	case t.text == "(":
+		// Function calls take no space; keywords such as IN and VALUES do, as
+		// do the column lists of INSERT INTO t (a, b)
+		if f.before != nil && (strings.EqualFold(f.before.text, "INTO") || strings.EqualFold(f.before.text, "TABLE")) {
+			return true
+		}
+		return p.kind == tokenOperator || p.kind == tokenPunct && p.text != ")" && p.text != "]" ||
+			p.kind == tokenWord && f.keywords[strings.ToUpper(p.text)] && !formatBuiltins[strings.ToUpper(p.text)]
+	}
+	return true
+}
+
+// formatBuiltins are keywords that are called like functions
+var formatBuiltins = map[string]bool{
+	"CAST": true, "COALESCE": true, "NULLIF": true, "IF": true, "REPLACE": true,
+	"LEFT": true, "RIGHT": true, "ROW": true,
+}
+
+// formatKeywords returns the words FormatSQL changes the case of
+func formatKeywords(mysql bool) map[string]bool {
+	keywords, _ := sqlKeywords(mysql)
+	set := make(map[string]bool)
+	for _, k := range keywords {
+		for _, w := range strings.Fields(k) {
+			set[w] = true
+		}
+	}
+	for _, w := range []string{
+		"ASC", "BY", "DISTINCT", "FIRST", "FOR", "INTO", "INTERVAL", "LAST", "NATURAL",
+		"NEXT", "NO", "NOTHING", "ONLY", "OVER", "PARTITION", "RECURSIVE", "ROW", "ROWS",
+		"SHARE", "TEMPORARY", "IF", "DO", "EXCEPT", "RETURNING",
+	} {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Placeholder is a bind parameter found in SQL typed by the user. Its Name is
+// written as in the SQL, e.g. $1 or :customer_id; the anonymous ? placeholders
+// of MySQL are numbered through the script as ?1, ?2, ...
+type Placeholder struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"` // positional ($1), named (:name) or anonymous (?)
+}
+
+// placeholder is where a parameter appears in a statement
+type placeholder struct {
+	Placeholder
+	start, end int
+}
+
+// DetectParameters lists the bind parameters of a script in order of first
+// appearance, each once. Besides :name, PostgreSQL scripts may use $1 and
+// MySQL scripts ?; the other styles would clash with the dialect's operators.
+func (m *Manager) DetectParameters(script string) []Placeholder {
+	_, mysql := m.currentDriver().(*MySQLDriver)
+	seen := make(map[string]bool)
+	params := []Placeholder{}
+	anonymous := 0
+	for _, statement := range splitStatements(script, mysql) {
+		for _, p := range findPlaceholders(statement, mysql, &anonymous) {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				params = append(params, p.Placeholder)
+			}
+		}
+	}
+	return params
+}
+
+// findPlaceholders locates the bind parameters of a statement outside of
+// strings, quoted identifiers and comments. anonymous counts the ? seen so
+// far in the script.
+func findPlaceholders(statement string, mysql bool, anonymous *int) []placeholder {
+	var found []placeholder
+	for i := 0; i < len(statement); {
+		if next := skipLiteral(statement, i, mysql); next > i {
+			i = next
+			continue
+		}
+
+		c := statement[i]
+		end := i + 1
+		switch {
+		case mysql && c == '?':
+			*anonymous++
+			found = append(found, placeholder{Placeholder{"?" + strconv.Itoa(*anonymous), "anonymous"}, i, end})
+
+		case !mysql && c == '$' && (i == 0 || !isIdentChar(statement[i-1])):
+			for end < len(statement) && statement[end] >= '0' && statement[end] <= '9' {
+				end++
+			}
+			if end > i+1 {
+				found = append(found, placeholder{Placeholder{statement[i:end], "positional"}, i, end})
+			}
+
+		// :: is a PostgreSQL cast and := a MySQL assignment
+		case c == ':' && (i == 0 || statement[i-1] != ':' && !isIdentChar(statement[i-1])) &&
+			end < len(statement) && isIdentChar(statement[end]) && statement[end] != '$' &&
+			(statement[end] < '0' || statement[end] > '9'):
+			for end < len(statement) && isIdentChar(statement[end]) && statement[end] != '$' {
+				end++
+			}
+			found = append(found, placeholder{Placeholder{statement[i:end], "named"}, i, end})
+		}
+		i = end
+	}
+	return found
+}
+
+// parameterBinder rewrites the placeholders of the statements of a script
+// into the driver's own, taking their values from a map keyed by
+// Placeholder.Name
+type parameterBinder struct {
+	values    map[string]interface{}
+	mysql     bool
+	anonymous int
+}
+
+// bind returns a statement with its placeholders rewritten as $n or ? and the
+// arguments to run it with. Statements must be bound in script order.
+func (b *parameterBinder) bind(statement string) (string, []interface{}, error) {
+	found := findPlaceholders(statement, b.mysql, &b.anonymous)
+	if len(found) == 0 {
+		return statement, nil, nil
+	}
+
+	var query []byte
+	var args []interface{}
+	index := make(map[string]int)
+	last := 0
+	for _, p := range found {
+		value, ok := b.values[p.Name]
+		if !ok {
+			return "", nil, fmt.Errorf("missing value for parameter %s", p.Name)
+		}
+		query = append(query, statement[last:p.start]...)
+		last = p.end
+		if b.mysql {
+			// MySQL binds every ? separately, even for a repeated name
+			query = append(query, '?')
+			args = append(args, value)
+			continue
+		}
+		n, ok := index[p.Name]
+		if !ok {
+			args = append(args, value)
+			n = len(args)
+			index[p.Name] = n
+		}
+		query = append(query, '$')
+		query = strconv.AppendInt(query, int64(n), 10)
+	}
+	query = append(query, statement[last:]...)
+	return string(query), args, nil
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+)
+
+// dollarTag matches the opening tag of a PostgreSQL dollar-quoted string, e.g. $body$
+var dollarTag = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// isIdentChar tells whether c can be part of an unquoted identifier
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// splitStatements splits a script into statements at delimiters outside of
+// strings, quoted identifiers and comments. MySQL scripts may use # comments,
+// backslash escapes and DELIMITER lines as the mysql client does; PostgreSQL
+// scripts may use dollar quoting, escape strings (E'\n') and nested block comments.
+// Statements holding nothing but comments are dropped.
+func splitStatements(script string, mysql bool) []string {
+	var statements []string
+	delimiter := ";"
+	start := 0
+
+	emit := func(end int) {
+		s := strings.TrimSpace(script[start:end])
+		if strings.TrimSpace(leadingComment.ReplaceAllString(s, "")) != "" {
+			statements = append(statements, s)
+		}
+	}
+	for i := 0; i < len(script); {
+		rest := script[i:]
+		next := skipLiteral(script, i, mysql)
+		switch {
+		case mysql && strings.TrimSpace(script[start:i]) == "" && len(rest) > 10 &&
+			strings.EqualFold(rest[:9], "DELIMITER") && (rest[9] == ' ' || rest[9] == '\t'):
+			end := skipPast(script, i, "\n")
+			if d := strings.TrimSpace(script[i+10 : end]); d != "" {
+				delimiter = d
+			}
+			i, start = end, end
+
+		case next > i:
+			i = next
+
+		case strings.HasPrefix(rest, delimiter):
+			emit(i)
+			i += len(delimiter)
+			start = i
+
+		default:
+			i++
+		}
+	}
+	if start < len(script) {
+		emit(len(script))
+	}
+	return statements
+}
+
+// skipLiteral returns the index just past the comment, string, quoted
+// identifier or dollar-quoted string starting at i, or i when none does
+func skipLiteral(script string, i int, mysql bool) int {
+	c := script[i]
+	rest := script[i:]
+	switch {
+	case strings.HasPrefix(rest, "--"), mysql && c == '#':
+		i = skipPast(script, i, "\n")
+
+	case strings.HasPrefix(rest, "/*"):
+		if mysql {
+			i = skipPast(script, i+2, "*/")
+			return i
+		}
+		depth := 0
+		for i < len(script) {
+			if strings.HasPrefix(script[i:], "/*") {
+				depth++
+				i += 2
+			} else if strings.HasPrefix(script[i:], "*/") {
+				depth--
+				i += 2
+				if depth == 0 {
+					return i
+				}
+			} else {
+				i++
+			}
+		}
+
+	case c == '\'' || c == '"' || mysql && c == '`':
+		escapes := mysql && c != '`' ||
+			!mysql && c == '\'' && i > 0 && (script[i-1] == 'E' || script[i-1] == 'e') && (i < 2 || !isIdentChar(script[i-2]))
+		i++
+		for i < len(script) {
+			if escapes && script[i] == '\\' {
+				i += 2
+				continue
+			}
+			if script[i] == c {
+				i++
+				if i < len(script) && script[i] == c {
+					i++
+					continue
+				}
+				return i
+			}
+			i++
+		}
+
+	case !mysql && c == '$' && (i == 0 || !isIdentChar(script[i-1])) && dollarTag.MatchString(rest):
+		tag := dollarTag.FindString(rest)
+		i = skipPast(script, i+len(tag), tag)
+	}
+	return i
+}
+
+// skipPast returns the index just past the first sep at or after i, or the
+// end of the script
+func skipPast(script string, i int, sep string) int {
+	if n := strings.Index(script[i:], sep); n >= 0 {
+		return i + n + len(sep)
+	}
+	return len(script)
+}
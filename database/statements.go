@@ -0,0 +1,218 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatementOptions controls the SQL GenerateStatements writes
+type StatementOptions struct {
+	Kind     string `json:"kind,omitempty"`     // insert (default) or update
+	Dialect  string `json:"dialect,omitempty"`  // postgres or mysql, defaults to that of the connection
+	Table    string `json:"table,omitempty"`    // Name written into the statements, e.g. schema.table; defaults to the table's name
+	MultiRow bool   `json:"multiRow,omitempty"` // insert: a single INSERT listing every row
+}
+
+// GenerateStatements writes rows of a table, as shown by the grid, as
+// INSERT statements or as UPDATE statements matching them by primary key,
+// with every value written in as a literal of the target dialect
+func (m *Manager) GenerateStatements(database, schema, table string, columns []string, rows [][]interface{}, opts StatementOptions) (string, error) {
+	if len(rows) == 0 {
+		return "", fmt.Errorf("no rows to generate statements for")
+	}
+
+	g, err := m.statementWriter(opts.Dialect)
+	if err != nil {
+		return "", err
+	}
+
+	infos, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return "", err
+	}
+	byName := make(map[string]ColumnInfo, len(infos))
+	for _, col := range infos {
+		byName[col.Name] = col
+	}
+	// Stored and virtual generated columns are computed by the target as well
+	var included []int
+	for i, name := range columns {
+		col, ok := byName[name]
+		if !ok {
+			return "", fmt.Errorf("column %s not found in table %s", name, table)
+		}
+		if col.Generated == "" {
+			included = append(included, i)
+		}
+	}
+
+	name := opts.Table
+	if name == "" {
+		name = table
+	}
+	target := qualifiedName(g.quote, strings.Split(name, ".")...)
+
+	var sb strings.Builder
+	switch opts.Kind {
+	case "", "insert":
+		err = g.inserts(&sb, target, byName, columns, included, rows, opts.MultiRow)
+	case "update":
+		err = g.updates(&sb, target, byName, columns, included, rows)
+	default:
+		err = fmt.Errorf("unsupported statement kind: %s", opts.Kind)
+	}
+	if err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// statementWriter writes statements in the quoting of a dialect
+type statementWriter struct {
+	mysql   bool
+	literal func(string) string
+	quote   func(string) string
+}
+
+// statementWriter returns a writer for postgres or mysql, or for the dialect
+// of the connection when empty
+func (m *Manager) statementWriter(dialect string) (*statementWriter, error) {
+	mysql := false
+	switch dialect {
+	case "":
+		_, mysql = m.currentDriver().(*MySQLDriver)
+	case "mysql":
+		mysql = true
+	case "postgres":
+	default:
+		return nil, fmt.Errorf("unsupported dialect: %s", dialect)
+	}
+	g := &statementWriter{mysql: mysql, literal: quoteLiteral, quote: func(name string) string { return quoteIdentifier(name, `"`) }}
+	if mysql {
+		g.literal = mysqlQuoteLiteral
+		g.quote = func(name string) string { return quoteIdentifier(name, "`") }
+	}
+	return g, nil
+}
+
+// inserts writes an INSERT per row, or one for all rows when multiRow is set
+func (g *statementWriter) inserts(sb *strings.Builder, target string, byName map[string]ColumnInfo, columns []string, included []int, rows [][]interface{}, multiRow bool) error {
+	names := make([]string, len(included))
+	// PostgreSQL only takes values for GENERATED ALWAYS identity columns when told to
+	overriding := ""
+	for i, idx := range included {
+		names[i] = g.quote(columns[idx])
+		if !g.mysql && byName[columns[idx]].Identity == "ALWAYS" {
+			overriding = " OVERRIDING SYSTEM VALUE"
+		}
+	}
+	head := fmt.Sprintf("INSERT INTO %s (%s)%s VALUES", target, strings.Join(names, ", "), overriding)
+
+	tuples := make([]string, len(rows))
+	for r, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("row has %d values but %d columns were given", len(row), len(columns))
+		}
+		values := make([]string, len(included))
+		for i, idx := range included {
+			value, err := g.value(byName[columns[idx]], row[idx])
+			if err != nil {
+				return err
+			}
+			values[i] = value
+		}
+		tuples[r] = "(" + strings.Join(values, ", ") + ")"
+	}
+
+	if multiRow {
+		sb.WriteString(head)
+		sb.WriteString("\n  ")
+		sb.WriteString(strings.Join(tuples, ",\n  "))
+		sb.WriteString(";\n")
+		return nil
+	}
+	for _, tuple := range tuples {
+		sb.WriteString(head)
+		sb.WriteString(" ")
+		sb.WriteString(tuple)
+		sb.WriteString(";\n")
+	}
+	return nil
+}
+
+// updates writes an UPDATE per row setting its values by its primary key
+func (g *statementWriter) updates(sb *strings.Builder, target string, byName map[string]ColumnInfo, columns []string, included []int, rows [][]interface{}) error {
+	var keys, set []int
+	for _, idx := range included {
+		col := byName[columns[idx]]
+		switch {
+		case col.Key == "PRI":
+			keys = append(keys, idx)
+		case !col.ReadOnly:
+			set = append(set, idx)
+		}
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("UPDATE statements need the primary key columns of the table")
+	}
+	if len(set) == 0 {
+		return fmt.Errorf("no columns to update")
+	}
+
+	assign := func(row []interface{}, idx []int, sep string) (string, error) {
+		parts := make([]string, len(idx))
+		for i, c := range idx {
+			value, err := g.value(byName[columns[c]], row[c])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = g.quote(columns[c]) + " = " + value
+		}
+		return strings.Join(parts, sep), nil
+	}
+
+	for _, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("row has %d values but %d columns were given", len(row), len(columns))
+		}
+		values, err := assign(row, set, ", ")
+		if err != nil {
+			return err
+		}
+		where, err := assign(row, keys, " AND ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(sb, "UPDATE %s SET %s WHERE %s;\n", target, values, where)
+	}
+	return nil
+}
+
+// value writes a grid value of a column as a literal. Binary values are
+// written as hex, array lists as array literals (JSON for MySQL) and the
+// GeoJSON of PostGIS columns as EWKT.
+func (g *statementWriter) value(col ColumnInfo, v interface{}) (string, error) {
+	if v == nil {
+		return "NULL", nil
+	}
+	if list, ok := v.([]interface{}); ok && isArrayColumn(col) && !g.mysql {
+		return g.literal(pgArrayLiteral(list)), nil
+	}
+	if isGeoColumn(col) {
+		if g.mysql {
+			if text, ok := v.(string); ok && !strings.HasPrefix(strings.TrimSpace(text), "{") {
+				return "ST_GeomFromText(" + g.literal(text) + ")", nil
+			}
+			return "ST_GeomFromGeoJSON(" + sqlLiteral(v, true, g.literal) + ")", nil
+		}
+		wkt, err := geoParam(col.Name, v)
+		if err != nil {
+			return "", err
+		}
+		return sqlLiteral(wkt, false, g.literal), nil
+	}
+	if text, ok := v.(string); ok && typeCategory(col.Type) == typeCategoryBinary {
+		return sqlLiteral([]byte(text), g.mysql, g.literal), nil
+	}
+	return sqlLiteral(paramValue(v), g.mysql, g.literal), nil
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// QueryStats tells where the time of a statement went, to help diagnose slow
+// queries
+type QueryStats struct {
+	WallTimeMs   float64 `json:"wallTimeMs"` // From sending the statement until its results and warnings were read
+	RowsAffected int64   `json:"rowsAffected"`
+	RowsFetched  int64   `json:"rowsFetched"`
+	// Requests expected to wait for the server, counted from the shape of the
+	// statement rather than measured: the statement, its prepare step when it
+	// has bind parameters and the warnings asked for afterwards
+	EstimatedRoundtrips int `json:"estimatedRoundtrips"`
+
+	// Reported by EXPLAIN ANALYZE when SQLOptions.Analyze is set. MySQL has
+	// no planning time; its execution time is that of the plan's root step.
+	PlanningTimeMs  *float64 `json:"planningTimeMs,omitempty"`
+	ExecutionTimeMs *float64 `json:"executionTimeMs,omitempty"`
+	AnalyzeError    string   `json:"analyzeError,omitempty"`
+}
+
+// sideEffectKeyword matches clauses that make a query change data or take
+// locks, e.g. a data-modifying WITH, SELECT ... INTO or SELECT ... FOR UPDATE
+var sideEffectKeyword = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|MERGE|REPLACE|INTO|FOR\s+(NO\s+KEY\s+UPDATE|KEY\s+SHARE|SHARE)|LOCK\s+IN\s+SHARE\s+MODE)\b`)
+
+// sideEffectFunction matches calls of built-in functions that have effects
+// beyond the query's result, such as advancing a sequence, signalling other
+// sessions, taking advisory locks or sleeping
+var sideEffectFunction = regexp.MustCompile(`(?i)\b(nextval|setval|pg_terminate_backend|pg_cancel_backend|pg_advisory_\w*|pg_try_advisory_\w*|pg_notify|pg_sleep\w*|set_config|pg_reload_conf|pg_rotate_logfile|pg_switch_wal|pg_create_\w+|pg_drop_\w+|lo_\w+|dblink\w*|txid_current|get_lock|release_lock|release_all_locks|sleep|benchmark|uuid_short|last_insert_id)\s*\(`)
+
+// analyzable reports whether a statement can run again under EXPLAIN ANALYZE
+// without changing data. Only plain queries qualify, as the statement may be
+// part of a transaction that cannot be rolled back on its own, and those that
+// call built-in functions with side effects are left out. Functions of the
+// database itself may still have effects, which is why analyzing is only done
+// when SQLOptions.Analyze opts in.
+func analyzable(statement string) bool {
+	switch leadingKeyword(statement) {
+	case "SELECT", "TABLE", "VALUES", "WITH":
+		return !sideEffectKeyword.MatchString(statement) && !sideEffectFunction.MatchString(statement)
+	}
+	return false
+}
+
+// statementRoundtrips estimates the requests a statement waits on the server for
+func (m *Manager) statementRoundtrips(statement string, args []interface{}, failed bool) int {
+	roundtrips := 1
+	if len(args) > 0 {
+		roundtrips++
+	}
+	if m.asksWarnings(statement, failed) {
+		roundtrips++
+	}
+	return roundtrips
+}
+
+// analyzeStatement runs a statement again under EXPLAIN ANALYZE and records
+// its planning and execution times in stats
+func (m *Manager) analyzeStatement(conn *instrumentedConn, statement string, args []interface{}, stats *QueryStats) {
+	var raw string
+	if err := conn.QueryRow(m.currentDriver().BuildExplainQuery(statement, true), args...).Scan(&raw); err != nil {
+		stats.AnalyzeError = err.Error()
+		return
+	}
+	plan, err := m.currentDriver().ParsePlan(raw, true)
+	if err != nil {
+		stats.AnalyzeError = err.Error()
+		return
+	}
+	stats.PlanningTimeMs, stats.ExecutionTimeMs = plan.PlanningTimeMs, plan.ExecutionTimeMs
+	if stats.ExecutionTimeMs == nil {
+		stats.ExecutionTimeMs = plan.Plan.ActualTimeMs
+	}
+}
+
+// AnalyzeStatement runs a query under EXPLAIN ANALYZE and returns its planning
+// and execution times, for statements run through OpenStream. Statements that
+// change data are not analyzed and return nil. With params, the placeholders
+// found by DetectParameters are bound to their values.
+func (m *Manager) AnalyzeStatement(query string, params map[string]interface{}) (*QueryStats, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if !analyzable(query) {
+		return nil, nil
+	}
+
+	bound, args := query, []interface{}(nil)
+	if len(params) > 0 {
+		_, mysql := m.currentDriver().(*MySQLDriver)
+		var err error
+		if bound, args, err = (&parameterBinder{values: params, mysql: mysql}).bind(query); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, done := m.track("explain", query)
+	defer done()
+	conn, err := m.session(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	limit := m.clientLimit(ctx, conn, m.queryTimeout())
+	limit.start()
+	stats := &QueryStats{}
+	m.analyzeStatement(conn, bound, args, stats)
+	limit.stop(nil)
+	return stats, nil
+}
@@ -5,11 +5,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
+// connectionsFile stores the saved connections
+const connectionsFile = "connections.json"
+
 // Storage handles saving and loading connections
 type Storage struct {
 	configPath string
+
+	// Serializes the read-modify-writes of the export schedules and their
+	// runs, which the scheduler records from background goroutines
+	schedulesMu sync.Mutex
 }
 
 // NewStorage creates a new storage instance
@@ -25,7 +33,7 @@ func NewStorage() (*Storage, error) {
 	}
 
 	return &Storage{
-		configPath: filepath.Join(configDir, "connections.json"),
+		configPath: filepath.Join(configDir, connectionsFile),
 	}, nil
 }
 
@@ -127,7 +135,10 @@ func (s *Storage) RenameConnection(oldName, newName string) error {
 		return fmt.Errorf("connection not found: %s", oldName)
 	}
 
-	return s.saveConnections(connections)
+	if err := s.saveConnections(connections); err != nil {
+		return err
+	}
+	return s.renameQueryConnection(oldName, newName)
 }
 
 func (s *Storage) saveConnections(connections []SavedConnection) error {
@@ -136,9 +147,69 @@ func (s *Storage) saveConnections(connections []SavedConnection) error {
 		return fmt.Errorf("failed to marshal connections: %w", err)
 	}
 
-	if err := os.WriteFile(s.configPath, data, 0644); err != nil {
+	if err := writeFileAtomic(s.configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write connections: %w", err)
 	}
 
 	return nil
 }
+
+// dataPath returns the path of a file stored next to the connections file
+func (s *Storage) dataPath(name string) string {
+	return filepath.Join(filepath.Dir(s.configPath), name)
+}
+
+// readJSON decodes a data file into v, leaving v untouched if the file doesn't exist
+func (s *Storage) readJSON(name string, v interface{}) error {
+	data, err := os.ReadFile(s.dataPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeJSON encodes v into a data file
+func (s *Storage) writeJSON(name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+
+	if err := writeFileAtomic(s.dataPath(name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeFileAtomic replaces a file through a temporary file in the same
+// directory, so a crash mid-write cannot leave it truncated
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,254 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFetchSize = 1000
+	maxFetchSize     = 50000
+
+	// streamIdleTimeout closes streams the frontend stopped fetching from, so
+	// that their connections go back to the pool
+	streamIdleTimeout = 5 * time.Minute
+)
+
+// StreamInfo describes a result stream opened by OpenStream
+type StreamInfo struct {
+	ID      int64          `json:"id"`
+	Columns []ResultColumn `json:"columns"`
+}
+
+// StreamChunk is the next batch of rows of a result stream
+type StreamChunk struct {
+	Rows   [][]interface{} `json:"rows"`
+	Offset int64           `json:"offset"` // Position of the first row in the result
+	Done   bool            `json:"done"`   // No rows are left and the stream is closed
+
+	Notices []ServerNotice `json:"notices,omitempty"` // Sent by the server since the previous chunk
+	Stats   *QueryStats    `json:"stats"`             // Of the stream so far
+}
+
+// resultStream is an open result set read a chunk at a time. Only the rows of
+// the current chunk are held in memory; the rest stay with the server and the
+// driver until they are fetched.
+type resultStream struct {
+	mu        sync.Mutex
+	query     string
+	started   time.Time
+	elapsed   time.Duration // Until the first rows arrived
+	fetching  time.Duration // Spent reading chunks since
+	requests  int           // Estimated roundtrips to the server so far
+	conn      *instrumentedConn
+	rows      *sql.Rows
+	columns   int
+	fetchSize int
+	offset    int64
+	notices   *noticeCollector
+	release   func() // Stops collecting notices
+	idle      *time.Timer
+	stop      func() bool
+	done      func()
+	closed    bool
+}
+
+// streamRegistry holds the open result streams by operation id
+type streamRegistry struct {
+	mu      sync.Mutex
+	streams map[int64]*resultStream
+}
+
+// OpenStream runs a query and keeps its result open to be read in chunks of
+// fetchSize rows with FetchStream, so that huge results never have to fit in
+// memory. The stream holds a connection until it is read to the end, closed,
+// cancelled as an operation or left idle for five minutes. The query timeout
+// only applies until the first rows arrive, as the rest are fetched at the
+// user's pace. With params, the placeholders found by DetectParameters are
+// bound to their values.
+func (m *Manager) OpenStream(query string, fetchSize int, params map[string]interface{}) (*StreamInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if fetchSize <= 0 {
+		fetchSize = defaultFetchSize
+	}
+	if fetchSize > maxFetchSize {
+		return nil, fmt.Errorf("fetch size must be at most %d", maxFetchSize)
+	}
+
+	bound, args := query, []interface{}(nil)
+	if len(params) > 0 {
+		_, mysql := m.currentDriver().(*MySQLDriver)
+		var err error
+		if bound, args, err = (&parameterBinder{values: params, mysql: mysql}).bind(query); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, done := m.track("stream", query)
+	conn, err := m.session(ctx, db)
+	if err != nil {
+		done()
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	notices := &noticeCollector{}
+	release := m.currentDriver().CaptureNotices(conn.conn, notices.add)
+
+	start := time.Now()
+	limit := m.clientLimit(ctx, conn, m.queryTimeout())
+	limit.start()
+	rows, err := conn.Query(bound, args...)
+	if err = limit.stop(err); err != nil {
+		release()
+		conn.Close()
+		done()
+		m.recordHistory(query, start, time.Since(start), 0, err)
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	elapsed := time.Since(start)
+
+	names, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		release()
+		conn.Close()
+		done()
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	columns := resultColumns(rows)
+	if columns == nil {
+		columns = make([]ResultColumn, len(names))
+		for i, name := range names {
+			columns[i] = ResultColumn{Name: name}
+		}
+	}
+
+	id, _ := ctx.Value(operationKey{}).(int64)
+	s := &resultStream{
+		query:     query,
+		started:   start,
+		elapsed:   elapsed,
+		requests:  m.statementRoundtrips(query, args, true), // Warnings are counted once asked for
+		conn:      conn,
+		rows:      rows,
+		notices:   notices,
+		release:   release,
+		columns:   len(names),
+		fetchSize: fetchSize,
+		done:      done,
+	}
+	s.mu.Lock()
+	m.streams.mu.Lock()
+	m.streams.streams[id] = s
+	m.streams.mu.Unlock()
+	s.idle = time.AfterFunc(streamIdleTimeout, func() { m.closeStream(id) })
+	// Cancelling the operation, e.g. with the Stop button, closes the stream
+	s.stop = context.AfterFunc(ctx, func() { m.closeStream(id) })
+	s.mu.Unlock()
+
+	return &StreamInfo{ID: id, Columns: columns}, nil
+}
+
+// FetchStream reads the next chunk of rows of a stream. The stream is closed
+// once the last chunk has been read or an error occurs.
+func (m *Manager) FetchStream(id int64) (*StreamChunk, error) {
+	m.streams.mu.Lock()
+	s := m.streams.streams[id]
+	m.streams.mu.Unlock()
+	if s == nil {
+		return nil, fmt.Errorf("stream %d is not open", id)
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("stream %d is not open", id)
+	}
+	s.idle.Reset(streamIdleTimeout)
+	start := time.Now()
+	rows, more, err := scanRows(s.rows, s.columns, s.fetchSize)
+	chunk := &StreamChunk{Rows: rows, Offset: s.offset, Done: !more}
+	if !more && err == nil {
+		// Warnings can only be asked for once the result has been read
+		s.rows.Close()
+		chunk.Notices = append(s.notices.drain(), m.serverWarnings(s.conn, s.query, false)...)
+		if m.asksWarnings(s.query, false) {
+			s.requests++
+		}
+	} else {
+		chunk.Notices = s.notices.drain()
+	}
+	s.offset += int64(len(rows))
+	s.fetching += time.Since(start)
+	// The wall time leaves out the time the stream waited to be fetched from
+	chunk.Stats = &QueryStats{
+		WallTimeMs:          durationMs(s.elapsed + s.fetching),
+		RowsFetched:         s.offset,
+		EstimatedRoundtrips: s.requests,
+	}
+	s.mu.Unlock()
+
+	if err != nil || !more {
+		m.closeStream(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+// CloseStream closes a stream before it has been read to the end
+func (m *Manager) CloseStream(id int64) error {
+	if !m.closeStream(id) {
+		return fmt.Errorf("stream %d is not open", id)
+	}
+	return nil
+}
+
+// closeStream releases a stream's result set, connection and operation, and
+// reports whether it was open
+func (m *Manager) closeStream(id int64) bool {
+	m.streams.mu.Lock()
+	s := m.streams.streams[id]
+	delete(m.streams.streams, id)
+	m.streams.mu.Unlock()
+	if s == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.closed = true
+	s.idle.Stop()
+	s.stop()
+	s.rows.Close()
+	s.release()
+	s.conn.Close()
+	s.done()
+	// Recorded once closed, with the rows that were read
+	m.recordHistory(s.query, s.started, s.elapsed, s.offset, nil)
+	return true
+}
+
+// closeStreams closes every open stream, e.g. before disconnecting
+func (m *Manager) closeStreams() {
+	m.streams.mu.Lock()
+	ids := make([]int64, 0, len(m.streams.streams))
+	for id := range m.streams.streams {
+		ids = append(ids, id)
+	}
+	m.streams.mu.Unlock()
+
+	for _, id := range ids {
+		m.closeStream(id)
+	}
+}
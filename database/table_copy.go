@@ -0,0 +1,629 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCopyBatchSize is the number of rows copied per target transaction
+const defaultCopyBatchSize = 1000
+
+// TableCopyRequest describes a copy of a table's rows to another table,
+// possibly on another connection and engine
+type TableCopyRequest struct {
+	Source TableRef `json:"source"`
+	Target TableRef `json:"target"` // Table defaults to the source table
+
+	BatchSize   int  `json:"batchSize,omitempty"`
+	CreateTable bool `json:"createTable,omitempty"` // Create the target table when missing
+	Truncate    bool `json:"truncate,omitempty"`    // Empty the target table first; not when resuming
+
+	// Primary key of the last row already copied, to resume a copy after it
+	After []interface{} `json:"after,omitempty"`
+}
+
+// TableCopy is a table copy running in the background. Rows are copied in
+// batches, each committed on its own, so that a failed or cancelled copy of
+// a table with a primary key can be resumed after LastKey. Row progress is
+// emitted as "copy:progress" and the copy as "copy:done" when it ends.
+type TableCopy struct {
+	ID           int64            `json:"id"`
+	Request      TableCopyRequest `json:"request"`
+	Status       string           `json:"status"` // running, done, failed or cancelled
+	Rows         int64            `json:"rows"`   // Rows copied so far, over every run
+	TotalRows    int64            `json:"totalRows"`
+	LastKey      []interface{}    `json:"lastKey,omitempty"`
+	Resumable    bool             `json:"resumable"`
+	TableCreated bool             `json:"tableCreated"`
+	Started      string           `json:"started"` // RFC 3339
+	Finished     string           `json:"finished,omitempty"`
+	DurationMs   float64          `json:"durationMs"`
+	Error        string           `json:"error,omitempty"`
+}
+
+// copyRegistry holds the table copies of the session
+type copyRegistry struct {
+	mu     sync.Mutex
+	nextID int64
+	copies map[int64]*tableCopy
+}
+
+type tableCopy struct {
+	info      TableCopy
+	operation int64 // Tracked operation running the copy
+	cancelled bool
+}
+
+// connectionOpener returns the manager of a saved connection, the active one
+// when empty, and a func releasing it
+type connectionOpener func(connection string) (*Manager, func(), error)
+
+// StartTableCopy copies a table in the background and returns at once. Both
+// connections are opened by open and released when the copy ends.
+func (m *Manager) StartTableCopy(req TableCopyRequest, open connectionOpener) (*TableCopy, error) {
+	if req.Source.Table == "" {
+		return nil, fmt.Errorf("source table is required")
+	}
+	if req.Target.Table == "" {
+		req.Target.Table = req.Source.Table
+	}
+	if req.Source == req.Target {
+		return nil, fmt.Errorf("source and target are the same table")
+	}
+
+	tc := &tableCopy{info: TableCopy{Request: req, Status: "running"}}
+	info, err := m.runTableCopy(tc, req, open)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// ResumeTableCopy runs a failed or cancelled copy again from the row after
+// the last one it committed
+func (m *Manager) ResumeTableCopy(id int64, open connectionOpener) (*TableCopy, error) {
+	m.copies.mu.Lock()
+	tc, ok := m.copies.copies[id]
+	if !ok {
+		m.copies.mu.Unlock()
+		return nil, fmt.Errorf("copy %d not found", id)
+	}
+	status := tc.info.Status
+	if status != "failed" && status != "cancelled" {
+		m.copies.mu.Unlock()
+		return nil, fmt.Errorf("copy %d is %s", id, status)
+	}
+	if !tc.info.Resumable {
+		m.copies.mu.Unlock()
+		return nil, fmt.Errorf("copy %d cannot be resumed as the table has no primary key", id)
+	}
+	req := tc.info.Request
+	req.After, req.Truncate = tc.info.LastKey, false
+	// The target table exists by now if it was created
+	req.CreateTable = req.CreateTable && !tc.info.TableCreated
+	tc.info.Status = "running"
+	m.copies.mu.Unlock()
+
+	info, err := m.runTableCopy(tc, req, open)
+	if err != nil {
+		m.copies.mu.Lock()
+		tc.info.Status = status
+		m.copies.mu.Unlock()
+		return nil, err
+	}
+	return info, nil
+}
+
+// runTableCopy opens both connections and starts copying in the background
+func (m *Manager) runTableCopy(tc *tableCopy, req TableCopyRequest, open connectionOpener) (*TableCopy, error) {
+	source, closeSource, err := open(req.Source.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open source connection: %w", err)
+	}
+	target, closeTarget, err := open(req.Target.Connection)
+	if err != nil {
+		closeSource()
+		return nil, fmt.Errorf("failed to open target connection: %w", err)
+	}
+
+	ctx, done := m.track("copy", fmt.Sprintf("%s → %s", req.Source.Table, req.Target.Table))
+	operation, _ := ctx.Value(operationKey{}).(int64)
+
+	m.copies.mu.Lock()
+	if tc.info.ID == 0 {
+		m.copies.nextID++
+		tc.info.ID = m.copies.nextID
+		m.copies.copies[tc.info.ID] = tc
+	}
+	tc.info.Status, tc.info.Error, tc.info.Finished = "running", "", ""
+	tc.info.Started = time.Now().Format(time.RFC3339)
+	tc.operation, tc.cancelled = operation, false
+	info, elapsed := tc.info, tc.info.DurationMs
+	m.copies.mu.Unlock()
+
+	go func() {
+		defer done()
+		defer closeSource()
+		defer closeTarget()
+		start := time.Now()
+		err := m.copyTable(ctx, source, target, req, func(update func(*TableCopy)) {
+			m.copies.mu.Lock()
+			update(&tc.info)
+			tc.info.DurationMs = elapsed + durationMs(time.Since(start))
+			m.copies.mu.Unlock()
+		})
+		m.finishTableCopy(tc, err, elapsed+durationMs(time.Since(start)))
+	}()
+
+	return &info, nil
+}
+
+// finishTableCopy records the outcome of a copy and emits "copy:done"
+func (m *Manager) finishTableCopy(tc *tableCopy, err error, duration float64) {
+	m.copies.mu.Lock()
+	tc.info.DurationMs = duration
+	tc.info.Finished = time.Now().Format(time.RFC3339)
+	switch {
+	case tc.cancelled:
+		tc.info.Status = "cancelled"
+	case err != nil:
+		tc.info.Status, tc.info.Error = "failed", err.Error()
+	default:
+		tc.info.Status = "done"
+	}
+	info := tc.info
+	m.copies.mu.Unlock()
+
+	m.emit("copy:done", info)
+}
+
+// copyTable copies the rows of the source table to the target, creating the
+// target table first when asked to. update records progress on the copy.
+func (m *Manager) copyTable(ctx context.Context, source, target *Manager, req TableCopyRequest, update func(func(*TableCopy))) error {
+	sourceDB, targetDB := source.getDB(), target.getDB()
+	if sourceDB == nil || targetDB == nil {
+		return fmt.Errorf("not connected to database")
+	}
+	sourceDB, targetDB = sourceDB.withContext(ctx), targetDB.withContext(ctx)
+
+	columns, err := source.GetColumns(req.Source.Database, req.Source.Schema, req.Source.Table)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("table %s not found", req.Source.Table)
+	}
+	var primaryKey []string
+	for _, col := range columns {
+		if col.Key == "PRI" {
+			primaryKey = append(primaryKey, col.Name)
+		}
+	}
+	if len(req.After) > 0 && len(req.After) != len(primaryKey) {
+		return fmt.Errorf("resume key has %d values, expected %d", len(req.After), len(primaryKey))
+	}
+
+	var total int64
+	if tables, err := source.GetTables(req.Source.Database, req.Source.Schema); err == nil {
+		for _, t := range tables {
+			if t.Name == req.Source.Table {
+				total = t.RowCount
+			}
+		}
+	}
+	update(func(info *TableCopy) {
+		info.Resumable = len(primaryKey) > 0
+		info.TotalRows = total
+	})
+
+	// Target table
+	targetColumns, err := target.GetColumns(req.Target.Database, req.Target.Schema, req.Target.Table)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(targetColumns) == 0 && !req.CreateTable:
+		return fmt.Errorf("target table %s does not exist", req.Target.Table)
+	case len(targetColumns) == 0:
+		targetColumns = copyTableColumns(columns, source.tableDriver(req.Source.Schema), target.tableDriver(req.Target.Schema))
+		names := []string{req.Target.Table}
+		for _, col := range targetColumns {
+			names = append(names, col.Name)
+		}
+		if err := target.validateNames(names...); err != nil {
+			return err
+		}
+		if _, err := targetDB.Exec(target.tableDriver(req.Target.Schema).BuildCreateTableQuery(req.Target.Database, req.Target.Table, targetColumns)); err != nil {
+			return fmt.Errorf("failed to create target table: %w", err)
+		}
+		update(func(info *TableCopy) { info.TableCreated = true })
+	case req.Truncate && len(req.After) == 0:
+		if _, err := targetDB.Exec(target.tableDriver(req.Target.Schema).BuildTruncateTableQuery(req.Target.Database, req.Target.Table)); err != nil {
+			return fmt.Errorf("failed to truncate target table: %w", err)
+		}
+	}
+
+	// Columns are matched by name, ignoring case across engines; computed
+	// source columns and read-only target columns are left out. GENERATED
+	// ALWAYS identity columns are kept so that ids survive the copy: COPY
+	// always takes the supplied values, as INSERT does with OVERRIDING
+	// SYSTEM VALUE.
+	writable := make(map[string]string, len(targetColumns))
+	for _, col := range targetColumns {
+		if !col.ReadOnly || (col.Generated == "" && col.Identity == "ALWAYS") {
+			writable[strings.ToLower(col.Name)] = col.Name
+		}
+	}
+	c := &tableCopier{
+		source:   source,
+		target:   target,
+		sourceDB: sourceDB,
+		targetDB: targetDB,
+		req:      req,
+		update:   update,
+	}
+	for _, col := range columns {
+		if name, ok := writable[strings.ToLower(col.Name)]; ok && col.Generated == "" {
+			c.columns = append(c.columns, col)
+			c.names = append(c.names, name)
+		}
+	}
+	if len(c.columns) == 0 {
+		return fmt.Errorf("no columns in common between %s and %s", req.Source.Table, req.Target.Table)
+	}
+	c.primaryKey = primaryKey
+	c.batchSize = req.BatchSize
+	if c.batchSize <= 0 {
+		c.batchSize = defaultCopyBatchSize
+	}
+
+	var copied int64
+	update(func(info *TableCopy) { copied = info.Rows })
+	c.progress = m.newProgress("copy", req.Source.Table, total)
+	c.progress.progress.Rows = copied
+	if err := c.run(ctx); err != nil {
+		return err
+	}
+	if err := c.advanceSequences(targetColumns); err != nil {
+		return err
+	}
+	c.progress.finish()
+	return nil
+}
+
+// tableCopier writes the rows read from a source table to the target
+type tableCopier struct {
+	source, target     *Manager
+	sourceDB, targetDB *instrumentedDB
+	req                TableCopyRequest
+	columns            []ColumnInfo // Source columns copied
+	names              []string     // Their names on the target
+	primaryKey         []string
+	batchSize          int
+	progress           *progressReporter
+	update             func(func(*TableCopy))
+}
+
+// run reads the source a page at a time in primary key order, or in one scan
+// when there is no primary key
+func (c *tableCopier) run(ctx context.Context) error {
+	if len(c.primaryKey) == 0 {
+		query := "SELECT * FROM " + c.source.tableDriver(c.req.Source.Schema).QualifiedName(c.req.Source.Database, c.req.Source.Table)
+		_, _, err := c.copyQuery(query, nil)
+		return err
+	}
+
+	after := c.req.After
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		query, args, err := c.source.tableDriver(c.req.Source.Schema).BuildTableDataQuery(TableDataRequest{
+			Database:   c.req.Source.Database,
+			Schema:     c.req.Source.Schema,
+			Table:      c.req.Source.Table,
+			PageSize:   c.batchSize,
+			Pagination: PaginationKeyset,
+			After:      after,
+		}, c.primaryKey, false)
+		if err != nil {
+			return err
+		}
+		n, last, err := c.copyQuery(query, args)
+		if err != nil {
+			return err
+		}
+		if n < c.batchSize {
+			return nil
+		}
+		after = last
+	}
+}
+
+// copyQuery copies the rows of a source query in batches and returns how
+// many there were and the primary key of the last one
+func (c *tableCopier) copyQuery(query string, args []interface{}) (int, []interface{}, error) {
+	rows, err := c.sourceDB.Query(query, args...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read source rows: %w", err)
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return 0, nil, err
+	}
+	position := make(map[string]int, len(names))
+	for i, name := range names {
+		position[name] = i
+	}
+	values := make([]interface{}, len(names))
+	ptrs := make([]interface{}, len(names))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	var batch []map[string]interface{}
+	var last []interface{}
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return n, nil, fmt.Errorf("failed to read source row: %w", err)
+		}
+		row := make(map[string]interface{}, len(c.columns))
+		for i, col := range c.columns {
+			row[c.names[i]] = copyValue(col, values[position[col.Name]])
+		}
+		batch = append(batch, row)
+		last = make([]interface{}, len(c.primaryKey))
+		for i, name := range c.primaryKey {
+			last[i] = copyValue(ColumnInfo{}, values[position[name]])
+		}
+		n++
+		if len(batch) == c.batchSize {
+			if err := c.write(batch, last); err != nil {
+				return n, nil, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return n, nil, fmt.Errorf("failed to read source rows: %w", err)
+	}
+	if len(batch) > 0 {
+		if err := c.write(batch, last); err != nil {
+			return n, nil, err
+		}
+	}
+	return n, last, nil
+}
+
+// write inserts a batch of rows into the target table in a transaction of
+// its own and records the key of its last row
+func (c *tableCopier) write(batch []map[string]interface{}, last []interface{}) error {
+	tx, err := c.targetDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if driver, ok := c.target.tableDriver(c.req.Target.Schema).(*PostgresDriver); ok {
+		_, err = driver.copyRows(tx, c.req.Target.Table, c.names, batch, c.progress)
+	} else {
+		_, err = c.target.insertRows(tx, c.req.Target.Database, c.req.Target.Schema, c.req.Target.Table, c.names, batch, c.progress)
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	c.update(func(info *TableCopy) {
+		info.Rows += int64(len(batch))
+		if len(c.primaryKey) > 0 {
+			info.LastKey = last
+		}
+	})
+	return nil
+}
+
+// advanceSequences moves the sequences of copied PostgreSQL identity and
+// serial columns past the copied ids, so that later inserts do not collide
+func (c *tableCopier) advanceSequences(targetColumns []ColumnInfo) error {
+	if _, ok := c.target.tableDriver(c.req.Target.Schema).(*PostgresDriver); !ok {
+		return nil
+	}
+	copied := make(map[string]bool, len(c.names))
+	for _, name := range c.names {
+		copied[name] = true
+	}
+	target := c.target.tableDriver(c.req.Target.Schema).QualifiedName(c.req.Target.Database, c.req.Target.Table)
+	for _, col := range targetColumns {
+		if !copied[col.Name] || (col.Identity == "" && !strings.Contains(col.Default, "nextval(")) {
+			continue
+		}
+		query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX(%s), 0) + 1, false) FROM %s",
+			c.target.tableDriver(c.req.Target.Schema).QuoteIdentifier(col.Name), target)
+		if _, err := c.targetDB.Exec(query, target, col.Name); err != nil {
+			return fmt.Errorf("failed to advance sequence of %s: %w", col.Name, err)
+		}
+	}
+	return nil
+}
+
+// copyValue converts a value read from the source for the target. Drivers
+// return text as bytes, which must not be written as binary.
+func copyValue(col ColumnInfo, v interface{}) interface{} {
+	if b, ok := v.([]byte); ok && typeCategory(col.Type) != typeCategoryBinary {
+		return string(b)
+	}
+	return v
+}
+
+// copyTableColumns returns the columns of a target table created for a copy.
+// Defaults, identity and computed columns are not carried over.
+func copyTableColumns(columns []ColumnInfo, source, target Driver) []ColumnInfo {
+	var out []ColumnInfo
+	for _, col := range columns {
+		if col.Generated != "" {
+			continue
+		}
+		out = append(out, ColumnInfo{
+			Name:     col.Name,
+			Type:     copyColumnType(col, source, target),
+			Nullable: col.Nullable,
+			Key:      col.Key,
+		})
+	}
+	return out
+}
+
+// copyColumnType maps the type of a source column to the target engine,
+// keeping it when both are the same
+func copyColumnType(col ColumnInfo, source, target Driver) string {
+	_, fromPostgres := source.(*PostgresDriver)
+	_, toPostgres := target.(*PostgresDriver)
+	if fromPostgres == toPostgres {
+		// Enums and composite types may not exist in the target database;
+		// MySQL enums are declared inline by the column type
+		if col.CustomType != nil && col.CustomType.Kind != "domain" {
+			return "TEXT"
+		}
+		if fromPostgres && len(col.EnumValues) > 0 {
+			return "TEXT"
+		}
+		return col.Type
+	}
+
+	t := strings.ToLower(strings.TrimSpace(col.Type))
+	args := ""
+	if i := strings.Index(t, "("); i >= 0 {
+		if j := strings.Index(t[i:], ")"); j >= 0 {
+			args = t[i : i+j+1]
+		}
+	}
+	pick := func(postgres, mysql string) string {
+		if toPostgres {
+			return postgres
+		}
+		return mysql
+	}
+
+	switch typeCategory(col.Type) {
+	case typeCategoryInteger:
+		unsigned := strings.Contains(t, "unsigned")
+		switch {
+		case strings.HasPrefix(t, "bigint") && unsigned:
+			return "NUMERIC(20)"
+		case strings.HasPrefix(t, "bigint"):
+			return "BIGINT"
+		case strings.HasPrefix(t, "tinyint") || strings.HasPrefix(t, "smallint"):
+			return "SMALLINT"
+		case unsigned:
+			return "BIGINT"
+		default:
+			return pick("INTEGER", "INT")
+		}
+	case typeCategoryDecimal:
+		if args != "" {
+			return pick("NUMERIC", "DECIMAL") + args
+		}
+		if t == "money" {
+			return "DECIMAL(19,2)"
+		}
+		return pick("NUMERIC", "DECIMAL(65,30)")
+	case typeCategoryFloat:
+		if t == "real" || t == "float" || t == "float4" {
+			return pick("REAL", "FLOAT")
+		}
+		return pick("DOUBLE PRECISION", "DOUBLE")
+	case typeCategoryBoolean:
+		return pick("BOOLEAN", "TINYINT(1)")
+	case typeCategoryTimestamp:
+		// MySQL TIMESTAMP values are instants, DATETIME ones are not
+		if !toPostgres {
+			return "DATETIME(6)"
+		}
+		if strings.HasPrefix(t, "timestamp") {
+			return "TIMESTAMPTZ"
+		}
+		return "TIMESTAMP"
+	case typeCategoryDate:
+		return "DATE"
+	case typeCategoryTime:
+		return pick("TIME", "TIME(6)")
+	case typeCategoryBinary:
+		return pick("BYTEA", "LONGBLOB")
+	case typeCategoryJSON:
+		return pick("JSONB", "JSON")
+	}
+
+	switch {
+	case (strings.HasPrefix(t, "varchar") || strings.HasPrefix(t, "character varying")) && args != "":
+		return "VARCHAR" + args
+	case (strings.HasPrefix(t, "char") || strings.HasPrefix(t, "character")) && args != "":
+		return "CHAR" + args
+	case t == "uuid":
+		return "CHAR(36)"
+	case toPostgres:
+		return "TEXT"
+	case col.Key == "PRI":
+		// MySQL cannot index TEXT without a prefix length
+		return "VARCHAR(255)"
+	default:
+		return "LONGTEXT"
+	}
+}
+
+// ListTableCopies returns the table copies of the session, newest first
+func (m *Manager) ListTableCopies() []TableCopy {
+	m.copies.mu.Lock()
+	defer m.copies.mu.Unlock()
+
+	copies := make([]TableCopy, 0, len(m.copies.copies))
+	for _, tc := range m.copies.copies {
+		copies = append(copies, tc.info)
+	}
+	sort.Slice(copies, func(i, j int) bool { return copies[i].ID > copies[j].ID })
+	return copies
+}
+
+// CancelTableCopy stops a running copy. Batches already committed stay in
+// the target table.
+func (m *Manager) CancelTableCopy(id int64) error {
+	m.copies.mu.Lock()
+	tc, ok := m.copies.copies[id]
+	running := ok && tc.info.Status == "running"
+	if running {
+		tc.cancelled = true
+	}
+	m.copies.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("copy %d not found", id)
+	}
+	if !running {
+		return fmt.Errorf("copy %d is not running", id)
+	}
+	return m.CancelOperation(tc.operation)
+}
+
+// ClearTableCopies forgets the finished copies and returns how many were removed
+func (m *Manager) ClearTableCopies() int {
+	m.copies.mu.Lock()
+	defer m.copies.mu.Unlock()
+
+	removed := 0
+	for id, tc := range m.copies.copies {
+		if tc.info.Status != "running" {
+			delete(m.copies.copies, id)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// timeoutGrace is how long the client waits past a timeout enforced by the
+// server before cancelling the statement itself
+const timeoutGrace = time.Second
+
+// gridTimeout returns the statement timeout of table data queries
+func (m *Manager) gridTimeout() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.config == nil {
+		return 0
+	}
+	return time.Duration(m.config.GridTimeout) * time.Second
+}
+
+// queryTimeout returns the statement timeout of queries run from the editor
+func (m *Manager) queryTimeout() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.config == nil {
+		return 0
+	}
+	return time.Duration(m.config.QueryTimeout) * time.Second
+}
+
+// statementLimit bounds how long each statement on a reserved connection may
+// run. The server enforces it where it can (statement_timeout on PostgreSQL);
+// otherwise a timer stops the statement as the Stop button would.
+type statementLimit struct {
+	m       *Manager
+	op      *trackedOperation
+	conn    *instrumentedConn
+	timeout time.Duration
+	reset   string
+
+	timer   *time.Timer
+	expired atomic.Bool
+}
+
+// limitStatements applies timeout to the statements run on conn for the
+// operation tracked by ctx. A nil limit, for a timeout of zero, does nothing.
+func (m *Manager) limitStatements(ctx context.Context, conn *instrumentedConn, timeout time.Duration) (*statementLimit, error) {
+	if timeout <= 0 {
+		return nil, nil
+	}
+
+	l := m.clientLimit(ctx, conn, timeout)
+	set, reset := m.currentDriver().StatementTimeoutQueries(timeout)
+	if set != "" {
+		if _, err := conn.conn.ExecContext(ctx, set); err != nil {
+			return nil, fmt.Errorf("failed to set statement timeout: %w", err)
+		}
+		l.reset = reset
+	}
+	return l, nil
+}
+
+// clientLimit limits statements with a timer only, leaving server settings
+// alone. A nil limit, for a timeout of zero, does nothing.
+func (m *Manager) clientLimit(ctx context.Context, conn *instrumentedConn, timeout time.Duration) *statementLimit {
+	if timeout <= 0 {
+		return nil
+	}
+
+	id, _ := ctx.Value(operationKey{}).(int64)
+	t := m.operations
+	t.mu.Lock()
+	op := t.ops[id]
+	t.mu.Unlock()
+
+	return &statementLimit{m: m, op: op, conn: conn, timeout: timeout}
+}
+
+// start arms the limit for the next statement
+func (l *statementLimit) start() {
+	if l == nil || l.op == nil {
+		return
+	}
+	wait := l.timeout
+	if l.reset != "" {
+		wait += timeoutGrace
+	}
+	l.expired.Store(false)
+	l.timer = time.AfterFunc(wait, func() {
+		l.expired.Store(true)
+		if !l.m.cancelBackend(l.op) {
+			l.op.cancel()
+		}
+	})
+}
+
+// stop disarms the limit and turns the error of a statement it stopped into
+// a timeout error
+func (l *statementLimit) stop(err error) error {
+	if l == nil || l.timer == nil {
+		return err
+	}
+	l.timer.Stop()
+	if err != nil && l.expired.Load() {
+		return fmt.Errorf("statement timed out after %s", l.timeout)
+	}
+	return err
+}
+
+// close restores the server setting before the connection goes back to the pool
+func (l *statementLimit) close() {
+	if l == nil || l.reset == "" {
+		return
+	}
+	l.conn.conn.ExecContext(context.Background(), l.reset)
+}
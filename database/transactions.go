@@ -0,0 +1,300 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TransactionState is the transaction status of a query tab, also emitted as
+// "transaction:state" whenever it changes
+type TransactionState struct {
+	TabID         string `json:"tabId"`
+	InTransaction bool   `json:"inTransaction"`
+	Dirty         bool   `json:"dirty"`   // Statements that may have changed data ran since BEGIN
+	Aborted       bool   `json:"aborted"` // PostgreSQL rejects every statement until ROLLBACK
+	StartedAt     string `json:"startedAt,omitempty"`
+	Statements    int    `json:"statements"`      // Run since BEGIN
+	Error         string `json:"error,omitempty"` // Why the transaction ended on its own
+}
+
+// tabSession is the connection a query tab holds while a transaction is open
+type tabSession struct {
+	mu      sync.Mutex // Held while statements run on the connection
+	conn    *sql.Conn
+	backend int64
+	state   TransactionState
+}
+
+// tabRegistry holds the sessions of query tabs by tab id
+type tabRegistry struct {
+	mu   sync.Mutex
+	tabs map[string]*tabSession
+}
+
+// readKeywords lead statements that leave data unchanged
+var readKeywords = map[string]bool{
+	"SELECT": true, "SHOW": true, "EXPLAIN": true, "DESCRIBE": true, "DESC": true,
+	"VALUES": true, "TABLE": true, "FETCH": true, "SET": true, "SAVEPOINT": true,
+	"RELEASE": true, "USE": true,
+}
+
+// mysqlImplicitCommit lead statements after which MySQL has committed the
+// open transaction
+var mysqlImplicitCommit = map[string]bool{
+	"CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true, "RENAME": true,
+	"GRANT": true, "REVOKE": true, "LOCK": true, "UNLOCK": true,
+}
+
+// BeginTransaction opens a transaction for a query tab on a connection of its
+// own. Statements run with ExecuteSQL for the tab use that connection until
+// the transaction is committed or rolled back.
+func (m *Manager) BeginTransaction(tabID string) (*TransactionState, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	s, err := m.openTab(db, tabID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := tabConn(context.Background(), db, s).Exec("BEGIN"); err != nil {
+		m.releaseTab(s)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	s.begin()
+	return m.reportTab(s), nil
+}
+
+// CommitTransaction commits the open transaction of a query tab
+func (m *Manager) CommitTransaction(tabID string) (*TransactionState, error) {
+	return m.endTransaction(tabID, "COMMIT")
+}
+
+// RollbackTransaction rolls back the open transaction of a query tab
+func (m *Manager) RollbackTransaction(tabID string) (*TransactionState, error) {
+	return m.endTransaction(tabID, "ROLLBACK")
+}
+
+func (m *Manager) endTransaction(tabID, statement string) (*TransactionState, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	s := m.tab(tabID)
+	if s == nil {
+		return nil, fmt.Errorf("no open transaction in this tab")
+	}
+	if !s.mu.TryLock() {
+		return nil, fmt.Errorf("a statement is still running in this tab")
+	}
+	defer s.mu.Unlock()
+
+	if _, err := tabConn(context.Background(), db, s).Exec(statement); err != nil {
+		// The transaction is still open unless the connection is gone
+		if m.checkTab(s) {
+			return nil, fmt.Errorf("failed to %s: %w", strings.ToLower(statement), err)
+		}
+		return m.reportTab(s), fmt.Errorf("failed to %s: %w", strings.ToLower(statement), err)
+	}
+	s.state = TransactionState{TabID: tabID}
+	m.releaseTab(s)
+	return m.reportTab(s), nil
+}
+
+// GetTransactionState returns the transaction status of a query tab
+func (m *Manager) GetTransactionState(tabID string) TransactionState {
+	s := m.tab(tabID)
+	if s == nil {
+		return TransactionState{TabID: tabID}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// CloseQueryTab rolls back the open transaction of a tab being closed and
+// returns its connection to the pool. A statement still running is stopped.
+func (m *Manager) CloseQueryTab(tabID string) error {
+	s := m.tab(tabID)
+	if s == nil {
+		return nil
+	}
+	m.closeTab(s)
+	return nil
+}
+
+// closeTab stops the statement running in a tab, if any, then rolls back
+// its transaction and releases its connection
+func (m *Manager) closeTab(s *tabSession) {
+	db := m.getDB()
+	if !s.mu.TryLock() {
+		if db != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), serverCancelTimeout)
+			db.withContext(ctx).Exec(m.currentDriver().BuildCancelQuery(s.backend))
+			cancel()
+		}
+		s.mu.Lock()
+	}
+	defer s.mu.Unlock()
+
+	if s.state.InTransaction {
+		ctx, cancel := context.WithTimeout(context.Background(), serverCancelTimeout)
+		s.conn.ExecContext(ctx, "ROLLBACK")
+		cancel()
+	}
+	s.state = TransactionState{TabID: s.state.TabID}
+	m.releaseTab(s)
+}
+
+// closeTabs rolls back every tab transaction, e.g. before disconnecting
+func (m *Manager) closeTabs() {
+	m.tabs.mu.Lock()
+	sessions := make([]*tabSession, 0, len(m.tabs.tabs))
+	for _, s := range m.tabs.tabs {
+		sessions = append(sessions, s)
+	}
+	m.tabs.mu.Unlock()
+
+	for _, s := range sessions {
+		m.closeTab(s)
+	}
+}
+
+// openTab reserves a connection for a tab and records its server id, so that
+// its statements can be stopped on the server
+func (m *Manager) openTab(db *instrumentedDB, tabID string) (*tabSession, error) {
+	if tabID == "" {
+		return nil, fmt.Errorf("tab id is required")
+	}
+
+	m.tabs.mu.Lock()
+	defer m.tabs.mu.Unlock()
+	if _, ok := m.tabs.tabs[tabID]; ok {
+		return nil, fmt.Errorf("this tab already has an open transaction")
+	}
+
+	conn, err := db.pool.Conn(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	s := &tabSession{conn: conn, state: TransactionState{TabID: tabID}}
+	conn.QueryRowContext(context.Background(), m.currentDriver().BackendIDQuery()).Scan(&s.backend)
+	m.tabs.tabs[tabID] = s
+	return s, nil
+}
+
+func (m *Manager) tab(tabID string) *tabSession {
+	m.tabs.mu.Lock()
+	defer m.tabs.mu.Unlock()
+	return m.tabs.tabs[tabID]
+}
+
+// releaseTab forgets a tab's session and returns its connection to the pool.
+// The caller holds s.mu.
+func (m *Manager) releaseTab(s *tabSession) {
+	m.tabs.mu.Lock()
+	if m.tabs.tabs[s.state.TabID] == s {
+		delete(m.tabs.tabs, s.state.TabID)
+	}
+	m.tabs.mu.Unlock()
+	s.conn.Close()
+}
+
+// checkTab reports whether a tab's connection still works, releasing it when
+// it does not. The caller holds s.mu.
+func (m *Manager) checkTab(s *tabSession) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), serverCancelTimeout)
+	defer cancel()
+	if err := s.conn.PingContext(ctx); err == nil {
+		return true
+	}
+
+	lost := s.state.InTransaction
+	s.state = TransactionState{TabID: s.state.TabID}
+	if lost {
+		s.state.Error = "connection lost; the transaction was rolled back"
+	}
+	m.releaseTab(s)
+	return false
+}
+
+// reportTab emits and returns a copy of a tab's state. The caller holds s.mu.
+func (m *Manager) reportTab(s *tabSession) *TransactionState {
+	state := s.state
+	m.emit("transaction:state", state)
+	return &state
+}
+
+// tabConn runs statements on a tab's connection for the operation tracked by
+// ctx. It must not be closed; the connection stays with the tab.
+func tabConn(ctx context.Context, db *instrumentedDB, s *tabSession) *instrumentedConn {
+	return &instrumentedConn{
+		statementRunner: statementRunner{runner: s.conn, ctx: ctx, chain: db.chain},
+		conn:            s.conn,
+	}
+}
+
+func (s *tabSession) begin() {
+	s.state = TransactionState{
+		TabID:         s.state.TabID,
+		InTransaction: true,
+		StartedAt:     time.Now().Format(time.RFC3339),
+	}
+}
+
+// observe follows the transaction through a statement run in the tab, so
+// that BEGIN, COMMIT and ROLLBACK typed in the editor are tracked too
+func (s *tabSession) observe(statement string, failed, mysql bool) {
+	keyword := leadingKeyword(statement)
+	words := strings.Fields(strings.ToUpper(leadingComment.ReplaceAllString(statement, "")))
+
+	if s.state.InTransaction && keyword != "BEGIN" && keyword != "START" {
+		s.state.Statements++
+	}
+	switch {
+	case failed:
+		// A failed statement aborts the whole transaction on PostgreSQL
+		if s.state.InTransaction && !mysql {
+			s.state.Aborted = true
+		}
+	case keyword == "BEGIN" || keyword == "START":
+		s.begin()
+	case keyword == "ROLLBACK" && len(words) > 1 && words[1] == "TO":
+		// Rolling back to a savepoint keeps the transaction open
+		s.state.Aborted = false
+	case keyword == "COMMIT" || keyword == "END" || keyword == "ABORT" || keyword == "ROLLBACK",
+		mysql && mysqlImplicitCommit[keyword]:
+		s.state = TransactionState{TabID: s.state.TabID}
+	case s.state.InTransaction && (!readKeywords[keyword] || returningClause.MatchString(statement)):
+		s.state.Dirty = true
+	}
+}
+
+// settleTab releases a tab's connection once no transaction is open on it
+// any more, or it broke, and reports the tab's state. The caller holds s.mu.
+func (m *Manager) settleTab(s *tabSession) {
+	if !s.state.InTransaction {
+		m.releaseTab(s)
+	} else {
+		m.checkTab(s)
+	}
+	m.reportTab(s)
+}
+
+// startsTransaction tells whether a script opens a transaction
+func startsTransaction(statements []string) bool {
+	for _, statement := range statements {
+		if k := leadingKeyword(statement); k == "BEGIN" || k == "START" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TranslationMap is a shared lookup (e.g. 1=active, 2=banned) applied to every
+// column whose name matches one of Columns
+type TranslationMap struct {
+	Name    string            `json:"name"`
+	Columns []string          `json:"columns"` // Column names, matched case-insensitively
+	Values  map[string]string `json:"values"`  // Raw value -> label
+}
+
+// translationsFile stores the workspace's translation maps
+const translationsFile = "translations.json"
+
+// LoadTranslationMaps returns all translation maps of the workspace
+func (s *Storage) LoadTranslationMaps() ([]TranslationMap, error) {
+	maps := []TranslationMap{}
+	if err := s.readJSON(translationsFile, &maps); err != nil {
+		return nil, err
+	}
+	return maps, nil
+}
+
+// SaveTranslationMap creates or replaces a translation map by name
+func (s *Storage) SaveTranslationMap(m TranslationMap) error {
+	if m.Name == "" {
+		return fmt.Errorf("translation map name is required")
+	}
+
+	maps, err := s.LoadTranslationMaps()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existing := range maps {
+		if existing.Name == m.Name {
+			maps[i] = m
+			found = true
+			break
+		}
+	}
+	if !found {
+		maps = append(maps, m)
+	}
+
+	return s.writeJSON(translationsFile, maps)
+}
+
+// DeleteTranslationMap removes a translation map by name
+func (s *Storage) DeleteTranslationMap(name string) error {
+	maps, err := s.LoadTranslationMaps()
+	if err != nil {
+		return err
+	}
+
+	filtered := []TranslationMap{}
+	for _, m := range maps {
+		if m.Name != name {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return s.writeJSON(translationsFile, filtered)
+}
+
+// MatchTranslations returns the value labels that apply to each of the given
+// columns, keyed by column name. When several maps match a column, earlier
+// maps take precedence.
+func MatchTranslations(columns []ColumnInfo, maps []TranslationMap) map[string]map[string]string {
+	if len(maps) == 0 {
+		return nil
+	}
+
+	matched := make(map[string]map[string]string)
+	for _, col := range columns {
+		for _, m := range maps {
+			if !m.appliesTo(col.Name) {
+				continue
+			}
+			labels := matched[col.Name]
+			if labels == nil {
+				labels = make(map[string]string, len(m.Values))
+				matched[col.Name] = labels
+			}
+			for raw, label := range m.Values {
+				if _, exists := labels[raw]; !exists {
+					labels[raw] = label
+				}
+			}
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil
+	}
+	return matched
+}
+
+func (m TranslationMap) appliesTo(column string) bool {
+	for _, c := range m.Columns {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"fmt"
+)
+
+// TriggerInfo describes a trigger on a table
+type TriggerInfo struct {
+	Name       string   `json:"name"`
+	Timing     string   `json:"timing"` // BEFORE, AFTER or INSTEAD OF
+	Events     []string `json:"events"` // INSERT, UPDATE, DELETE, TRUNCATE
+	Level      string   `json:"level"`  // ROW or STATEMENT
+	Function   string   `json:"function,omitempty"`
+	Definition string   `json:"definition"` // CREATE TRIGGER statement or trigger body
+	Body       string   `json:"body"`       // Source of the executed function or statement
+	Enabled    bool     `json:"enabled"`
+}
+
+// GetTriggers returns the triggers defined on a table
+func (m *Manager) GetTriggers(database, schema, table string) ([]TriggerInfo, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	triggers, err := m.tableDriver(schema).GetTriggers(db, database, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get triggers: %w", err)
+	}
+
+	return triggers, nil
+}
+
+// SetTriggerEnabled enables or disables a trigger
+func (m *Manager) SetTriggerEnabled(database, schema, table, trigger string, enabled bool) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	query, err := m.tableDriver(schema).BuildSetTriggerEnabledQuery(database, table, trigger, enabled)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to update trigger: %w", err)
+	}
+
+	return nil
+}
+
+// DropTrigger removes a trigger from a table
+func (m *Manager) DropTrigger(database, schema, table, trigger string) error {
+	db := m.getDB()
+	if db == nil {
+		return fmt.Errorf("not connected to database")
+	}
+
+	if _, err := db.Exec(m.tableDriver(schema).BuildDropTriggerQuery(database, table, trigger)); err != nil {
+		return fmt.Errorf("failed to drop trigger: %w", err)
+	}
+
+	return nil
+}
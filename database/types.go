@@ -8,6 +8,7 @@ type ConnectionConfig struct {
 	User     string `json:"user"`
 	Password string `json:"password"`
 	Database string `json:"database"`
+	Schema   string `json:"schema,omitempty"` // PostgreSQL schema to browse, defaults to public
 
 	// Connection Color Coding (for environment identification)
 	Color string `json:"color"` // hex color e.g. "#ef4444" for prod
@@ -27,6 +28,30 @@ type ConnectionConfig struct {
 	SSHPassword   string `json:"sshPassword"`   // Optional, for password auth
 	SSHPrivateKey string `json:"sshPrivateKey"` // PEM content or file path
 	SSHPassphrase string `json:"sshPassphrase"` // Key passphrase if encrypted
+
+	// HashiCorp Vault Credentials
+	UseVault       bool   `json:"useVault"`
+	VaultAddr      string `json:"vaultAddr"`      // Defaults to VAULT_ADDR
+	VaultToken     string `json:"vaultToken"`     // Defaults to VAULT_TOKEN or ~/.vault-token
+	VaultNamespace string `json:"vaultNamespace"` // Enterprise namespace, optional
+	VaultPath      string `json:"vaultPath"`      // e.g. secret/data/db/prod or database/creds/readonly
+
+	// AWS Secrets Manager / SSM Parameter Store Credentials
+	UseAWSSecret    bool   `json:"useAWSSecret"`
+	AWSSecretSource string `json:"awsSecretSource"` // secretsmanager (default) or ssm
+	AWSSecretID     string `json:"awsSecretId"`     // Secret ARN/name or parameter name
+	AWSRegion       string `json:"awsRegion"`       // Defaults to the ARN region or AWS config
+	AWSProfile      string `json:"awsProfile"`      // Named profile, optional
+
+	// Statement timeouts in seconds, 0 for none. Table data pages and
+	// queries from the editor are limited separately.
+	GridTimeout  int `json:"gridTimeout,omitempty"`
+	QueryTimeout int `json:"queryTimeout,omitempty"`
+
+	// Limits of the undo log of grid changes: how many changes it keeps
+	// (default 50) and how many rows a change may touch (default 1000)
+	UndoDepth   int `json:"undoDepth,omitempty"`
+	UndoMaxRows int `json:"undoMaxRows,omitempty"`
 }
 
 // SavedConnection represents a saved connection with a name
@@ -35,17 +60,36 @@ type SavedConnection struct {
 	Config ConnectionConfig `json:"config"`
 }
 
+// ConnectionDiagnostics holds the outcome of a connection test
+type ConnectionDiagnostics struct {
+	Success       bool     `json:"success"`
+	ConnectTimeMs float64  `json:"connectTimeMs"` // Time to open the connection, including the initial handshake
+	LatencyMs     float64  `json:"latencyMs"`     // Round trip of a ping on the open connection
+	ServerVersion string   `json:"serverVersion"`
+	TLS           bool     `json:"tls"`
+	TLSVersion    string   `json:"tlsVersion,omitempty"`
+	CurrentUser   string   `json:"currentUser"`
+	DefaultSchema string   `json:"defaultSchema"`
+	Permissions   []string `json:"permissions"` // Summary of what the user is allowed to do
+	Warnings      []string `json:"warnings,omitempty"`
+}
+
 // QueryResult holds the result of a SELECT query
 type QueryResult struct {
-	Columns  []string        `json:"columns"`
-	Rows     [][]interface{} `json:"rows"`
-	RowCount int             `json:"rowCount"`
+	Columns     []string        `json:"columns"`
+	ColumnTypes []string        `json:"columnTypes"` // Database type names, e.g. BIGINT, NUMERIC
+	Rows        [][]interface{} `json:"rows"`
+	RowCount    int             `json:"rowCount"`
 }
 
 // ExecuteResult holds the result of an INSERT/UPDATE/DELETE query
 type ExecuteResult struct {
 	RowsAffected int64 `json:"rowsAffected"`
 	LastInsertId int64 `json:"lastInsertId"`
+
+	// Rows as stored, including generated ids, defaults and trigger changes,
+	// for row edits on databases supporting RETURNING
+	Returned *QueryResult `json:"returned,omitempty"`
 }
 
 // DatabaseInfo represents a database
@@ -56,10 +100,36 @@ type DatabaseInfo struct {
 // TableInfo represents a table
 type TableInfo struct {
 	Name       string `json:"name"`
+	Schema     string `json:"schema,omitempty"` // PostgreSQL schema the table belongs to
 	Engine     string `json:"engine"`
 	RowCount   int64  `json:"rowCount"`
 	DataSize   int64  `json:"dataSize"`
 	CreateTime string `json:"createTime"`
+	LastVacuum string `json:"lastVacuum,omitempty"` // PostgreSQL: latest manual or auto vacuum
+	Comment    string `json:"comment"`
+
+	// PostgreSQL declarative partitioning. Stats of a partitioned table are
+	// the totals of its leaf partitions.
+	Partitioned    bool   `json:"partitioned,omitempty"`
+	PartitionKey   string `json:"partitionKey,omitempty"`   // e.g. RANGE (created_at)
+	Parent         string `json:"parent,omitempty"`         // Partitioned table this is a partition of
+	PartitionBound string `json:"partitionBound,omitempty"` // e.g. FOR VALUES FROM ('2024-01-01') TO ('2024-02-01')
+}
+
+// ViewInfo represents a view and its definition
+type ViewInfo struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition"`
+}
+
+// MaterializedViewInfo represents a materialized view
+type MaterializedViewInfo struct {
+	Name           string `json:"name"`
+	Definition     string `json:"definition"`
+	Populated      bool   `json:"populated"`
+	Size           int64  `json:"size"`           // On-disk size in bytes
+	HasUniqueIndex bool   `json:"hasUniqueIndex"` // Required for a concurrent refresh
+	LastRefresh    string `json:"lastRefresh"`    // RFC 3339, only known for refreshes run from this session
 }
 
 // ColumnInfo represents a column
@@ -71,6 +141,37 @@ type ColumnInfo struct {
 	Default  string `json:"default"`
 	Extra    string `json:"extra"`
 	OldName  string `json:"oldName,omitempty"` // For renaming columns
+	Comment  string `json:"comment"`
+
+	// DefaultExpression marks Default as an SQL expression such as now() or
+	// nextval('seq'); otherwise it is a literal value and quoted when altering
+	// the column. Keywords like CURRENT_TIMESTAMP and NULL are never quoted.
+	DefaultExpression bool `json:"defaultExpression,omitempty"`
+
+	// Character set and collation of text columns. Changing either through a
+	// TableAlteration only applies to string types; PostgreSQL has no
+	// per-column character set and reports a collation only when it differs
+	// from the type's default.
+	Charset   string `json:"charset,omitempty"`
+	Collation string `json:"collation,omitempty"`
+
+	// Allowed labels of enum (and MySQL set) columns
+	EnumValues []string `json:"enumValues,omitempty"`
+
+	// Element type of a PostgreSQL array column, whose Type is then the
+	// element type followed by [], e.g. int4[]
+	ElementType string `json:"elementType,omitempty"`
+
+	// PostgreSQL composite type with its fields, or domain with its
+	// constraints, of the column
+	CustomType *CustomTypeInfo `json:"customType,omitempty"`
+
+	// Computed and identity columns. ReadOnly columns are left out of inserts
+	// and updates.
+	Generated  string `json:"generated,omitempty"`  // STORED or VIRTUAL
+	Expression string `json:"expression,omitempty"` // Expression of a generated column, when known
+	Identity   string `json:"identity,omitempty"`   // ALWAYS or BY DEFAULT (PostgreSQL)
+	ReadOnly   bool   `json:"readOnly,omitempty"`
 }
 
 // IndexInfo represents an index
@@ -79,11 +180,35 @@ type IndexInfo struct {
 	Columns   []string `json:"columns"`
 	IsUnique  bool     `json:"isUnique"`
 	IsPrimary bool     `json:"isPrimary"`
+	Method    string   `json:"method,omitempty"`    // btree, hash, gin, gist, ...
+	Predicate string   `json:"predicate,omitempty"` // WHERE clause of a partial index
+	Size      int64    `json:"size,omitempty"`      // On-disk size in bytes, when known
+}
+
+// ForeignKeyInfo represents a foreign key constraint
+type ForeignKeyInfo struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referencedTable"`
+	ReferencedColumns []string `json:"referencedColumns"`
+	OnDelete          string   `json:"onDelete"` // CASCADE, SET NULL, SET DEFAULT, RESTRICT, NO ACTION
+	OnUpdate          string   `json:"onUpdate"`
+}
+
+// ConstraintInfo represents a CHECK or UNIQUE constraint
+type ConstraintInfo struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`                 // CHECK or UNIQUE
+	Columns    []string `json:"columns"`              // Columns of a UNIQUE constraint, or those a CHECK refers to
+	Expression string   `json:"expression,omitempty"` // Boolean expression of a CHECK constraint
 }
 
 // TableDetails contains full table information
 type TableDetails struct {
-	Name    string       `json:"name"`
-	Columns []ColumnInfo `json:"columns"`
-	Indexes []IndexInfo  `json:"indexes"`
+	Name        string           `json:"name"`
+	Comment     string           `json:"comment"`
+	Columns     []ColumnInfo     `json:"columns"`
+	Indexes     []IndexInfo      `json:"indexes"`
+	ForeignKeys []ForeignKeyInfo `json:"foreignKeys"`
+	Constraints []ConstraintInfo `json:"constraints"`
 }
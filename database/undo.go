@@ -0,0 +1,444 @@
+package database
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Default limits of the undo log, see ConnectionConfig.UndoDepth
+const (
+	defaultUndoDepth   = 50
+	defaultUndoMaxRows = 1000
+)
+
+// UndoEntry describes a change to rows of a table that can be undone
+type UndoEntry struct {
+	ID       int64     `json:"id"`
+	Database string    `json:"database"`
+	Schema   string    `json:"schema,omitempty"` // PostgreSQL only
+	Table    string    `json:"table"`
+	Kind     string    `json:"kind"` // insert, update or delete
+	Rows     int       `json:"rows"`
+	Time     time.Time `json:"time"`
+}
+
+// undoRecord is an entry of the undo log with what is needed to compensate it
+type undoRecord struct {
+	UndoEntry
+	primaryKey []string
+	keys       [][]interface{}     // insert, update: key values of each row after the change
+	columns    []string            // update: the changed columns; delete: every stored column
+	values     [][]interface{}     // update, delete: values of columns of each row before the change
+	written    [][]FilterCondition // update: conditions matching each row only while it holds what the change wrote
+}
+
+// undoLog holds the most recent row changes of the session, newest last
+type undoLog struct {
+	mu      sync.Mutex
+	records []*undoRecord
+	nextID  int64
+}
+
+// undoLimits returns how many changes the undo log keeps and how many rows
+// a change may touch to be kept
+func (m *Manager) undoLimits() (depth, maxRows int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	depth, maxRows = defaultUndoDepth, defaultUndoMaxRows
+	if m.config != nil && m.config.UndoDepth > 0 {
+		depth = m.config.UndoDepth
+	}
+	if m.config != nil && m.config.UndoMaxRows > 0 {
+		maxRows = m.config.UndoMaxRows
+	}
+	return depth, maxRows
+}
+
+// GetUndoLog returns the changes that can be undone, newest first
+func (m *Manager) GetUndoLog() []UndoEntry {
+	l := m.undo
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]UndoEntry, len(l.records))
+	for i, rec := range l.records {
+		entries[len(l.records)-1-i] = rec.UndoEntry
+	}
+	return entries
+}
+
+// PreviewUndo returns the statements UndoLastChange would run
+func (m *Manager) PreviewUndo() (*SQLPreview, error) {
+	if m.getDB() == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	rec := m.lastUndo()
+	if rec == nil {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+
+	stmts, err := m.undoStatements(rec)
+	if err != nil {
+		return nil, err
+	}
+	preview := &SQLPreview{}
+	for _, stmt := range stmts {
+		preview.Statements = append(preview.Statements, m.previewStatement(stmt.query, stmt.args))
+	}
+	return preview, nil
+}
+
+// UndoLastChange reverts the most recent recorded change in a transaction:
+// inserted rows are deleted, updated columns get their old values back and
+// deleted rows are inserted again. Updated rows are only reverted while they
+// still hold the values the change wrote. Rows changed since or no longer
+// found make it fail without reverting anything, and drop the change from
+// the log, as it can't be undone anymore.
+func (m *Manager) UndoLastChange() (*UndoEntry, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	rec := m.lastUndo()
+	if rec == nil {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+
+	stmts, err := m.undoStatements(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := m.track("undo", rec.Table)
+	defer done()
+
+	tx, err := db.withContext(ctx).Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range stmts {
+		res, err := tx.Exec(stmt.query, stmt.args...)
+		if err != nil {
+			return nil, fmt.Errorf("undo failed: %w", err)
+		}
+		if affected, _ := res.RowsAffected(); affected == 0 {
+			m.dropUndo(rec)
+			return nil, fmt.Errorf("undo failed: the row was changed since or no longer exists; the change was dropped from the undo log")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit undo: %w", err)
+	}
+
+	m.dropUndo(rec)
+	return &rec.UndoEntry, nil
+}
+
+// dropUndo removes a record from the undo log
+func (m *Manager) dropUndo(rec *undoRecord) {
+	l := m.undo
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, r := range l.records {
+		if r == rec {
+			l.records = append(l.records[:i], l.records[i+1:]...)
+			break
+		}
+	}
+}
+
+// ClearUndoLog forgets every recorded change
+func (m *Manager) ClearUndoLog() {
+	l := m.undo
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = nil
+}
+
+// lastUndo returns the newest record of the undo log
+func (m *Manager) lastUndo() *undoRecord {
+	l := m.undo
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.records) == 0 {
+		return nil
+	}
+	return l.records[len(l.records)-1]
+}
+
+// undoStatement is a statement compensating a row of a change
+type undoStatement struct {
+	query string
+	args  []interface{}
+}
+
+// undoStatements builds the statements reverting a change, one per row
+func (m *Manager) undoStatements(rec *undoRecord) ([]undoStatement, error) {
+	driver := m.tableDriver(rec.Schema)
+	var stmts []undoStatement
+	switch rec.Kind {
+	case changeInsert:
+		query := driver.BuildDeleteQuery(rec.Database, rec.Table, rec.primaryKey)
+		for _, keys := range rec.keys {
+			stmts = append(stmts, undoStatement{query, keys})
+		}
+	case changeUpdate:
+		for i, keys := range rec.keys {
+			filters := make([]FilterCondition, 0, len(keys)+len(rec.written[i]))
+			for j, key := range rec.primaryKey {
+				filters = append(filters, FilterCondition{Column: key, Operator: "=", Value: keys[j]})
+			}
+			query, args, err := driver.BuildBulkUpdateQuery(rec.Database, rec.Table, rec.columns, append(filters, rec.written[i]...))
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, undoStatement{query, append(append([]interface{}{}, rec.values[i]...), args...)})
+		}
+	default:
+		query := driver.BuildInsertQuery(rec.Database, rec.Table, rec.columns)
+		for _, values := range rec.values {
+			stmts = append(stmts, undoStatement{query, values})
+		}
+	}
+	return stmts, nil
+}
+
+// recordUndo adds a change to the undo log, dropping the oldest beyond the
+// configured depth. A nil record, for a change that could not be captured,
+// forgets the earlier changes of its table instead, as reverting them could
+// now undo more than intended.
+func (m *Manager) recordUndo(database, schema, table string, rec *undoRecord) {
+	if rec == nil {
+		m.forgetUndo(database, schema, table)
+		return
+	}
+	depth, _ := m.undoLimits()
+	schema = m.resolveSchema(schema)
+
+	l := m.undo
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nextID++
+	rec.ID = l.nextID
+	rec.Database, rec.Schema, rec.Table, rec.Time = database, schema, table, time.Now()
+	rec.Rows = max(len(rec.keys), len(rec.values))
+	l.records = append(l.records, rec)
+	if len(l.records) > depth {
+		l.records = l.records[len(l.records)-depth:]
+	}
+}
+
+// forgetUndo drops the recorded changes of a table, after it was changed in
+// a way the undo log can't revert
+func (m *Manager) forgetUndo(database, schema, table string) {
+	schema = m.resolveSchema(schema)
+
+	l := m.undo
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	kept := l.records[:0]
+	for _, rec := range l.records {
+		if rec.Database != database || rec.Schema != schema || rec.Table != table {
+			kept = append(kept, rec)
+		}
+	}
+	l.records = kept
+}
+
+// captureRows reads columns of the rows with the given key values as they
+// are before a change, or returns nil when there are more rows than the undo
+// log may keep or the rows can't be read
+func (m *Manager) captureRows(database, schema, table string, primaryKey, columns []string, primaryValues [][]interface{}) [][]interface{} {
+	db := m.getDB()
+	if db == nil || len(primaryKey) == 0 || len(primaryValues) == 0 {
+		return nil
+	}
+	if _, maxRows := m.undoLimits(); len(primaryValues) > maxRows {
+		return nil
+	}
+	infos, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil
+	}
+	binary := make(map[string]bool, len(infos))
+	for _, col := range infos {
+		binary[col.Name] = typeCategory(col.Type) == typeCategoryBinary
+	}
+
+	// Key columns are selected too, to put the rows in the order of the keys
+	selected := append(append([]string{}, primaryKey...), columns...)
+	byKey := make(map[string][]interface{}, len(primaryValues))
+	batch := insertBatchSize(m.tableDriver(schema), len(primaryKey))
+	for start := 0; start < len(primaryValues); start += batch {
+		chunk := primaryValues[start:min(start+batch, len(primaryValues))]
+		var args []interface{}
+		for _, values := range chunk {
+			keys, err := keyValues(primaryKey, values)
+			if err != nil {
+				return nil
+			}
+			args = append(args, keys...)
+		}
+
+		rows, err := db.Query(m.tableDriver(schema).BuildSelectRowsQuery(database, table, primaryKey, selected, len(chunk)), args...)
+		if err != nil {
+			return nil
+		}
+		for rows.Next() {
+			row := make([]interface{}, len(selected))
+			ptrs := make([]interface{}, len(selected))
+			for i := range row {
+				ptrs[i] = &row[i]
+			}
+			if err := rows.Scan(ptrs...); err != nil {
+				rows.Close()
+				return nil
+			}
+			// Drivers return most values as text bytes, which would be bound
+			// as binary when written back
+			for i, name := range selected {
+				if b, ok := row[i].([]byte); ok && !binary[name] {
+					row[i] = string(b)
+				}
+			}
+			byKey[stagedRowKey(primaryKey, row[:len(primaryKey)])] = row[len(primaryKey):]
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil
+		}
+	}
+
+	captured := make([][]interface{}, 0, len(primaryValues))
+	for _, values := range primaryValues {
+		if row, ok := byKey[stagedRowKey(primaryKey, values)]; ok {
+			captured = append(captured, row)
+		}
+	}
+	return captured
+}
+
+// captureUpdate reads the values an update of a row is about to replace
+func (m *Manager) captureUpdate(database, schema, table string, primaryKey []string, primaryValues []interface{}, data RowData) *undoRecord {
+	data, err := m.writableData(database, schema, table, data)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	columns, _ := rowColumns(data)
+	old := m.captureRows(database, schema, table, primaryKey, columns, [][]interface{}{primaryValues})
+	if len(old) != 1 {
+		return nil
+	}
+
+	// The row is found by its new key when the update changes it
+	keys := make([]interface{}, len(primaryKey))
+	for i, key := range primaryKey {
+		keys[i] = paramValue(primaryValues[i])
+		if v, ok := data[key]; ok {
+			keys[i] = paramValue(v)
+		}
+	}
+	written, err := m.writtenConditions(database, schema, table, data)
+	if err != nil {
+		return nil
+	}
+	return &undoRecord{
+		UndoEntry:  UndoEntry{Kind: changeUpdate},
+		primaryKey: primaryKey,
+		keys:       [][]interface{}{keys},
+		columns:    columns,
+		values:     old,
+		written:    [][]FilterCondition{written},
+	}
+}
+
+// writtenConditions matches a row holding the values an update wrote, so that
+// undoing it leaves later changes alone. Columns that don't read back as
+// written are not compared.
+func (m *Manager) writtenConditions(database, schema, table string, data RowData) ([]FilterCondition, error) {
+	infos, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	var conditions []FilterCondition
+	for _, col := range infos {
+		value, ok := data[col.Name]
+		if !ok || !comparableColumn(col) {
+			continue
+		}
+		if value == nil {
+			conditions = append(conditions, FilterCondition{Column: col.Name, Operator: "IS NULL"})
+		} else {
+			conditions = append(conditions, FilterCondition{Column: col.Name, Operator: "=", Value: paramValue(value)})
+		}
+	}
+	return conditions, nil
+}
+
+// captureDelete reads the rows a delete is about to remove. Generated and
+// GENERATED ALWAYS identity columns are left out, so the database assigns
+// them again when the rows are restored.
+func (m *Manager) captureDelete(database, schema, table string, primaryKey []string, primaryValues [][]interface{}) *undoRecord {
+	infos, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil
+	}
+	var columns []string
+	for _, col := range infos {
+		if !col.ReadOnly {
+			columns = append(columns, col.Name)
+		}
+	}
+	old := m.captureRows(database, schema, table, primaryKey, columns, primaryValues)
+	if len(old) == 0 {
+		return nil
+	}
+
+	return &undoRecord{
+		UndoEntry:  UndoEntry{Kind: changeDelete},
+		primaryKey: primaryKey,
+		columns:    columns,
+		values:     old,
+	}
+}
+
+// insertedUndo records the key of a row just inserted, taken from the
+// returned row, the inserted data or the generated id
+func (m *Manager) insertedUndo(database, schema, table string, data RowData, result *ExecuteResult) *undoRecord {
+	infos, err := m.GetColumns(database, schema, table)
+	if err != nil {
+		return nil
+	}
+	primaryKey := primaryKeyColumns(infos)
+	if len(primaryKey) == 0 || result == nil || result.RowsAffected != 1 {
+		return nil
+	}
+
+	keys := make([]interface{}, len(primaryKey))
+	for i, key := range primaryKey {
+		switch {
+		case result.Returned != nil && len(result.Returned.Rows) == 1:
+			for j, name := range result.Returned.Columns {
+				if name == key {
+					keys[i] = result.Returned.Rows[0][j]
+				}
+			}
+		case data[key] != nil:
+			keys[i] = paramValue(data[key])
+		case len(primaryKey) == 1 && result.LastInsertId > 0:
+			keys[i] = result.LastInsertId
+		}
+		if keys[i] == nil {
+			return nil
+		}
+	}
+	return &undoRecord{
+		UndoEntry:  UndoEntry{Kind: changeInsert},
+		primaryKey: primaryKey,
+		keys:       [][]interface{}{keys},
+	}
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// maxSafeInteger is the largest integer a JavaScript number represents exactly (2^53 - 1)
+const maxSafeInteger = 1<<53 - 1
+
+// scanValue converts a scanned driver value into a JSON-serializable value that
+// survives the trip to the frontend. Integers outside the JavaScript safe range
+// are sent as strings; DECIMAL/NUMERIC values already arrive as text.
+func scanValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []byte:
+		return string(val)
+	case int64:
+		if val > maxSafeInteger || val < -maxSafeInteger {
+			return strconv.FormatInt(val, 10)
+		}
+		return val
+	case uint64:
+		if val > maxSafeInteger {
+			return strconv.FormatUint(val, 10)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// paramValue converts a value received from the frontend into a query parameter.
+// Numbers decoded by the bridge are float64; integral ones are passed as int64 so
+// drivers never render them in exponent notation. Strings are passed through
+// untouched, which keeps big integers and decimals exact.
+func paramValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) <= maxSafeInteger {
+			return int64(val)
+		}
+		return val
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		return val.String()
+	default:
+		return val
+	}
+}
+
+// paramValues applies paramValue to each element
+func paramValues(values []interface{}) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = paramValue(v)
+	}
+	return out
+}
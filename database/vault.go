@@ -0,0 +1,212 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultVaultAddr  = "http://127.0.0.1:8200"
+	vaultRetryBackoff = 10 * time.Second
+)
+
+// vaultClient is a minimal client for the Vault HTTP API
+type vaultClient struct {
+	addr      string
+	token     string
+	namespace string
+	http      *http.Client
+}
+
+// vaultResponse is the common envelope returned by Vault secret endpoints
+type vaultResponse struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Errors        []string               `json:"errors"`
+}
+
+// newVaultClient builds a client from the config, falling back to the
+// standard VAULT_ADDR / VAULT_TOKEN environment and ~/.vault-token
+func newVaultClient(config ConnectionConfig) (*vaultClient, error) {
+	addr := config.VaultAddr
+	if addr == "" {
+		addr = os.Getenv("VAULT_ADDR")
+	}
+	if addr == "" {
+		addr = defaultVaultAddr
+	}
+
+	token := config.VaultToken
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if token == "" {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			if data, err := os.ReadFile(filepath.Join(homeDir, ".vault-token")); err == nil {
+				token = strings.TrimSpace(string(data))
+			}
+		}
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no Vault token provided")
+	}
+
+	namespace := config.VaultNamespace
+	if namespace == "" {
+		namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+
+	return &vaultClient{
+		addr:      strings.TrimRight(addr, "/"),
+		token:     token,
+		namespace: namespace,
+		http:      &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+func (c *vaultClient) do(method, path string, body interface{}) (*vaultResponse, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.addr+"/v1/"+strings.TrimLeft(path, "/"), reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result := &vaultResponse{}
+	if resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("invalid vault response: %w", err)
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		if len(result.Errors) > 0 {
+			return nil, fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.Join(result.Errors, "; "))
+		}
+		return nil, fmt.Errorf("vault returned %d", resp.StatusCode)
+	}
+
+	return result, nil
+}
+
+// fetchVaultCredentials reads username/password from a KV (v1 or v2) path or
+// a database secrets engine role. Dynamic secrets come back with a lease that
+// must be renewed while connected.
+func fetchVaultCredentials(config ConnectionConfig) (username, password string, lease *vaultLease, err error) {
+	if config.VaultPath == "" {
+		return "", "", nil, fmt.Errorf("no Vault path provided")
+	}
+
+	client, err := newVaultClient(config)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	resp, err := client.do(http.MethodGet, config.VaultPath, nil)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to read %s: %w", config.VaultPath, err)
+	}
+
+	data := resp.Data
+	// KV v2 nests the secret under data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	username, _ = data["username"].(string)
+	password, _ = data["password"].(string)
+	if username == "" {
+		return "", "", nil, fmt.Errorf("vault secret at %s has no username", config.VaultPath)
+	}
+
+	if resp.LeaseID != "" {
+		lease = &vaultLease{
+			client:   client,
+			leaseID:  resp.LeaseID,
+			duration: time.Duration(resp.LeaseDuration) * time.Second,
+			done:     make(chan struct{}),
+		}
+		if resp.Renewable && resp.LeaseDuration > 0 {
+			lease.wg.Add(1)
+			go lease.renewLoop()
+		}
+	}
+
+	return username, password, lease, nil
+}
+
+// vaultLease keeps dynamic credentials alive while a connection is open
+type vaultLease struct {
+	client   *vaultClient
+	leaseID  string
+	duration time.Duration
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// renewLoop renews the lease at two thirds of its duration until closed
+func (l *vaultLease) renewLoop() {
+	defer l.wg.Done()
+
+	wait := l.duration * 2 / 3
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-time.After(wait):
+		}
+
+		resp, err := l.client.do(http.MethodPut, "sys/leases/renew", map[string]interface{}{
+			"lease_id":  l.leaseID,
+			"increment": int(l.duration.Seconds()),
+		})
+		if err != nil || resp.LeaseDuration <= 0 {
+			wait = vaultRetryBackoff
+			continue
+		}
+
+		l.duration = time.Duration(resp.LeaseDuration) * time.Second
+		wait = l.duration * 2 / 3
+	}
+}
+
+// Close stops renewing and revokes the lease so the credentials are dropped
+func (l *vaultLease) Close() error {
+	close(l.done)
+	l.wg.Wait()
+
+	_, err := l.client.do(http.MethodPut, "sys/leases/revoke", map[string]interface{}{
+		"lease_id": l.leaseID,
+	})
+	return err
+}
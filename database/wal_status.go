@@ -0,0 +1,143 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// slotRetentionWarnBytes is the WAL retained by a slot above which a warning is raised
+const slotRetentionWarnBytes = 1 << 30
+
+// ReplicationSlotInfo describes a replication slot and the WAL it holds back
+type ReplicationSlotInfo struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"` // physical or logical
+	Database      string `json:"database"`
+	Active        bool   `json:"active"`
+	WALStatus     string `json:"walStatus"`     // reserved, extended, unreserved, lost (PostgreSQL 13+)
+	RetainedBytes int64  `json:"retainedBytes"` // WAL kept on disk because of this slot
+}
+
+// WALArchiveStatus summarizes the health of WAL archiving for point-in-time recovery
+type WALArchiveStatus struct {
+	WALLevel         string                `json:"walLevel"`
+	ArchiveMode      string                `json:"archiveMode"`
+	ArchiveCommand   string                `json:"archiveCommand"`
+	InRecovery       bool                  `json:"inRecovery"`
+	CurrentWAL       string                `json:"currentWal"`
+	ArchivedCount    int64                 `json:"archivedCount"`
+	LastArchivedWAL  string                `json:"lastArchivedWal"`
+	LastArchivedTime string                `json:"lastArchivedTime"`
+	FailedCount      int64                 `json:"failedCount"`
+	LastFailedWAL    string                `json:"lastFailedWal"`
+	LastFailedTime   string                `json:"lastFailedTime"`
+	Slots            []ReplicationSlotInfo `json:"slots"`
+	Warnings         []string              `json:"warnings"`
+}
+
+// GetWALArchiveStatus reports archive settings, archiver progress and replication
+// slots retaining WAL. Only PostgreSQL is supported.
+func (m *Manager) GetWALArchiveStatus() (*WALArchiveStatus, error) {
+	db := m.getDB()
+	if db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if _, ok := m.currentDriver().(*PostgresDriver); !ok {
+		return nil, fmt.Errorf("WAL archive status is only available for PostgreSQL")
+	}
+
+	status := &WALArchiveStatus{
+		Slots:    []ReplicationSlotInfo{},
+		Warnings: []string{},
+	}
+
+	err := db.QueryRow(`
+		SELECT
+			current_setting('wal_level'),
+			current_setting('archive_mode'),
+			current_setting('archive_command'),
+			pg_is_in_recovery(),
+			COALESCE(CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END::text, '')
+	`).Scan(&status.WALLevel, &status.ArchiveMode, &status.ArchiveCommand, &status.InRecovery, &status.CurrentWAL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WAL settings: %w", err)
+	}
+
+	var lastArchivedWAL, lastArchivedTime, lastFailedWAL, lastFailedTime sql.NullString
+	err = db.QueryRow(`
+		SELECT archived_count, last_archived_wal, last_archived_time::text,
+			failed_count, last_failed_wal, last_failed_time::text
+		FROM pg_stat_archiver
+	`).Scan(&status.ArchivedCount, &lastArchivedWAL, &lastArchivedTime, &status.FailedCount, &lastFailedWAL, &lastFailedTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archiver statistics: %w", err)
+	}
+	status.LastArchivedWAL = lastArchivedWAL.String
+	status.LastArchivedTime = lastArchivedTime.String
+	status.LastFailedWAL = lastFailedWAL.String
+	status.LastFailedTime = lastFailedTime.String
+
+	// wal_status only exists from PostgreSQL 13; reading it through jsonb keeps older servers working
+	rows, err := db.Query(`
+		SELECT
+			s.slot_name,
+			s.slot_type,
+			COALESCE(s.database, ''),
+			s.active,
+			COALESCE(to_jsonb(s) ->> 'wal_status', ''),
+			COALESCE(pg_wal_lsn_diff(
+				CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END,
+				s.restart_lsn
+			), 0)::bigint
+		FROM pg_replication_slots s
+		ORDER BY s.slot_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replication slots: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slot ReplicationSlotInfo
+		if err := rows.Scan(&slot.Name, &slot.Type, &slot.Database, &slot.Active, &slot.WALStatus, &slot.RetainedBytes); err != nil {
+			return nil, err
+		}
+		status.Slots = append(status.Slots, slot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	status.Warnings = walWarnings(status)
+	return status, nil
+}
+
+// walWarnings flags conditions that put point-in-time recovery at risk
+func walWarnings(status *WALArchiveStatus) []string {
+	warnings := []string{}
+
+	if status.ArchiveMode == "off" {
+		warnings = append(warnings, "archive_mode is off; WAL is not archived and point-in-time recovery is not possible")
+	} else if status.WALLevel == "minimal" {
+		warnings = append(warnings, "wal_level is minimal; archived WAL cannot be used for point-in-time recovery")
+	}
+
+	if status.LastFailedTime != "" && status.LastFailedTime > status.LastArchivedTime {
+		warnings = append(warnings, fmt.Sprintf("archiving is failing: last attempt for %s failed at %s", status.LastFailedWAL, status.LastFailedTime))
+	}
+
+	for _, slot := range status.Slots {
+		switch {
+		case slot.WALStatus == "lost":
+			warnings = append(warnings, fmt.Sprintf("replication slot %s has lost required WAL and can no longer be used", slot.Name))
+		case slot.RetainedBytes >= slotRetentionWarnBytes && !slot.Active:
+			warnings = append(warnings, fmt.Sprintf("inactive replication slot %s is retaining %s of WAL", slot.Name, humanSize(float64(slot.RetainedBytes))))
+		case slot.RetainedBytes >= slotRetentionWarnBytes:
+			warnings = append(warnings, fmt.Sprintf("replication slot %s is retaining %s of WAL", slot.Name, humanSize(float64(slot.RetainedBytes))))
+		case slot.WALStatus == "unreserved":
+			warnings = append(warnings, fmt.Sprintf("replication slot %s is about to lose required WAL", slot.Name))
+		}
+	}
+
+	return warnings
+}
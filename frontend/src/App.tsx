@@ -1,4 +1,4 @@
-import { useState, useEffect, useCallback } from 'react';
+import { useState, useEffect, useCallback, useRef } from 'react';
 import { useTranslation } from 'react-i18next';
 import './style.css';
 import { useDatabase } from './hooks/useDatabase';
@@ -11,11 +11,17 @@ import { DataEditor } from './components/DataEditor';
 import { ConnectionModal } from './components/ConnectionModal';
 import { CommandPalette, useCommandPalette } from './components/CommandPalette';
 import { ThemeToggle } from './components/ThemeToggle';
-import { ConnectionConfig, UpdateInfo } from './types';
-import { ToggleFullscreen, CheckForUpdate, GetAppVersion } from '../wailsjs/go/main/App';
-import { WindowToggleMaximise, WindowIsMaximised } from '../wailsjs/runtime/runtime';
+import { ConnectionConfig, UpdateInfo, Placeholder, ExportRun, TransactionState, SavedQuery } from './types';
+import { ToggleFullscreen, CheckForUpdate, GetAppVersion, CloseQueryTab, DetectParameters, BeginTransaction, CommitTransaction, RollbackTransaction, RememberQueryValues, LoadSavedQueries, GetStoreHealth } from '../wailsjs/go/main/App';
+import { WindowToggleMaximise, WindowIsMaximised, EventsOn } from '../wailsjs/runtime/runtime';
+import { toast } from "sonner";
 import { UpdateModal } from './components/UpdateModal';
+import { ParameterPrompt } from './components/ParameterPrompt';
+import { SavedQueriesModal } from './components/SavedQueriesModal';
+import { JobsPopover } from './components/JobsPopover';
+import { NotificationsPopover } from './components/NotificationsPopover';
 import { WindowControls } from './components/WindowControls';
+import { AppLock } from './components/AppLock';
 import {
     ResizableHandle,
     ResizablePanel,
@@ -38,6 +44,19 @@ import {
 import { Separator } from '@/components/ui/separator';
 import { cn } from '@/lib/utils';
 
+// Values a saved query's parameters are offered with: their defaults,
+// overridden by the values of its last run
+function savedQueryPrefill(saved: SavedQuery): Record<string, string | null> {
+    const values: Record<string, string | null> = {};
+    for (const p of saved.parameters || []) {
+        if (p.default !== undefined) values[p.name] = p.default;
+    }
+    for (const [name, value] of Object.entries(saved.lastValues || {})) {
+        values[name] = value === null ? null : String(value);
+    }
+    return values;
+}
+
 function App() {
     const {
         connected,
@@ -54,8 +73,12 @@ function App() {
         getTables,
         getColumns,
         useDb,
-        executeQuery,
-        executeQueries,
+        executeScript,
+        cancelQueries,
+        runInBackground,
+        showJobResult,
+        analyzeQueries,
+        setAnalyzeQueries,
         loadSavedConnections,
         saveConnection,
         updateConnection,
@@ -91,6 +114,21 @@ function App() {
     const [activeConnectionName, setActiveConnectionName] = useState<string | undefined>(undefined);
     const [isFullscreen, setIsFullscreen] = useState(false);
     const [updateInfo, setUpdateInfo] = useState<UpdateInfo | null>(null);
+    // Statements waiting for their bind parameter values
+    const [parameterRun, setParameterRun] = useState<{
+        sql: string;
+        tabId?: string;
+        savedQueryId?: string;
+        parameters: Placeholder[];
+        initialValues: Record<string, string | null>;
+    } | null>(null);
+    const [showSavedQueries, setShowSavedQueries] = useState(false);
+    // Transactions held open by each query tab's session
+    const [transactions, setTransactions] = useState<Record<string, TransactionState>>({});
+    // Values last bound, offered again by name
+    const lastParameterValues = useRef<Record<string, string | null>>({});
+    // Defaults and last values of the saved queries opened in tabs, by query id
+    const savedQueryValues = useRef<Record<string, Record<string, string | null>>>({});
     const [appVersion, setAppVersion] = useState("V0.1.0-ALPHA");
 
     // Command Palette
@@ -102,6 +140,16 @@ function App() {
                 const ver = await GetAppVersion();
                 setAppVersion(ver);
 
+                // Damaged data files were set aside at launch; say so
+                const health = await GetStoreHealth();
+                for (const store of health || []) {
+                    if (store.status === 'quarantined') {
+                        toast.warning(t('app.storeQuarantined', { name: store.name, backup: store.backup }));
+                    } else if (store.status === 'unreadable') {
+                        toast.error(t('app.storeUnreadable', { name: store.name, error: store.error }));
+                    }
+                }
+
                 const info = await CheckForUpdate();
                 if (info && info.hasUpdate) {
                     setUpdateInfo(info);
@@ -113,6 +161,13 @@ function App() {
         init();
     }, []);
 
+    // Scheduled exports run in the background whatever is open
+    useEffect(() => {
+        return EventsOn('schedule:failed', (run: ExportRun) => {
+            toast.error(t('schedules.runFailed', { name: run.schedule, error: run.error }));
+        });
+    }, [t]);
+
     // Update schema when database changes
     // Update schema when database changes
     useEffect(() => {
@@ -163,8 +218,8 @@ function App() {
         useDb(database);
     }, [useDb]);
 
-    const handleSelectTable = useCallback((database: string, table: string) => {
-        const tabId = `table-${database}-${table}`;
+    const handleSelectTable = useCallback((database: string, table: string, schema?: string) => {
+        const tabId = schema ? `table-${database}-${schema}-${table}` : `table-${database}-${table}`;
         const existingTab = tabs.find(t => t.id === tabId);
 
         if (existingTab) {
@@ -174,7 +229,7 @@ function App() {
                 id: tabId,
                 type: 'table',
                 title: table,
-                data: { db: database, table }
+                data: { db: database, schema, table }
             };
             setTabs(prev => [...prev, newTab]);
             setActiveTabId(tabId);
@@ -183,6 +238,14 @@ function App() {
 
     const handleTabClose = (id: string, e: React.MouseEvent) => {
         e.stopPropagation();
+        // Roll back whatever transaction the tab left open
+        if (tabs.find(t => t.id === id)?.type === 'query') {
+            CloseQueryTab(id).catch(err => console.error('Failed to close tab session:', err));
+        }
+        setTransactions(prev => {
+            const { [id]: _, ...rest } = prev;
+            return rest;
+        });
         const newTabs = tabs.filter(t => t.id !== id);
         setTabs(newTabs);
 
@@ -197,26 +260,79 @@ function App() {
     };
 
 
-    const handleExecute = useCallback((sqlOverride?: string) => {
+    const handleExecute = useCallback(async (sqlOverride?: string) => {
         const sqlToRun = typeof sqlOverride === 'string' ? sqlOverride : query;
-        if (sqlToRun.trim()) {
-            // Split by semicolon for basic multi-statement support
-            // This is a naive split; it doesn't handle semicolons in quotes, but covers basic usage
-            const statements = sqlToRun.split(';').map(s => s.trim()).filter(s => s.length > 0);
-
-            if (statements.length > 0) {
-                executeQueries(statements);
+        if (!sqlToRun.trim()) return;
+
+        // Ensure we are in query mode/tab
+        let queryTab = activeTab;
+        if (activeTab?.type !== 'query') {
+            // Find or create query tab
+            queryTab = tabs.find(t => t.type === 'query');
+            if (queryTab) setActiveTabId(queryTab.id);
+        }
+        const tabId = queryTab?.id;
+        const savedQueryId = queryTab?.savedQueryId;
+
+        // Placeholders are prompted for once by name through the whole script;
+        // a saved query offers the values it was last run with
+        const parameters = await DetectParameters(sqlToRun).catch(() => [] as Placeholder[]);
+        if (parameters.length > 0) {
+            // Tabs restored from the last session have not loaded their query yet
+            if (savedQueryId && !savedQueryValues.current[savedQueryId]) {
+                const saved = (await LoadSavedQueries('').catch(() => [] as SavedQuery[])).find(q => q.id === savedQueryId);
+                if (saved) savedQueryValues.current[savedQueryId] = savedQueryPrefill(saved);
             }
+            const initialValues = {
+                ...lastParameterValues.current,
+                ...(savedQueryId ? savedQueryValues.current[savedQueryId] : {}),
+            };
+            setParameterRun({ sql: sqlToRun, tabId, savedQueryId, parameters, initialValues });
+        } else {
+            executeScript(sqlToRun, { tabId });
+        }
+    }, [query, executeScript, tabs, activeTab]);
 
-            // Ensure we are in query mode/tab
-            if (activeTab?.type !== 'query') {
-                // Find or create query tab
-                const queryTab = tabs.find(t => t.type === 'query');
-                if (queryTab) setActiveTabId(queryTab.id);
+    // Disconnecting rolls back every tab transaction
+    useEffect(() => {
+        if (!connected) setTransactions({});
+    }, [connected]);
+
+    // The backend reports every change, including a transaction the server ended
+    useEffect(() => {
+        return EventsOn('transaction:state', (state: TransactionState) => {
+            setTransactions(prev => ({ ...prev, [state.tabId]: state }));
+            if (!state.inTransaction && state.error) {
+                toast.error(t('queryEditor.transactionEnded', { error: state.error }));
             }
+        });
+    }, [t]);
+
+    const handleTransaction = useCallback(async (action: (tabId: string) => Promise<TransactionState>) => {
+        if (activeTab?.type !== 'query') return;
+        try {
+            const state = await action(activeTab.id);
+            setTransactions(prev => ({ ...prev, [state.tabId]: state }));
+        } catch (err: any) {
+            toast.error(typeof err === 'string' ? err : err.message);
         }
-    }, [query, executeQueries, tabs, activeTab]);
+    }, [activeTab]);
 
+    // Opens a saved query in a new query tab, remembering the values its
+    // parameters were last run with
+    const openSavedQuery = useCallback((saved: SavedQuery) => {
+        savedQueryValues.current[saved.id] = savedQueryPrefill(saved);
+
+        const newTab: Tab = {
+            id: `query-${Date.now()}`,
+            type: 'query',
+            title: saved.name,
+            savedQueryId: saved.id,
+        };
+        setTabs(prev => [...prev, newTab]);
+        setActiveTabId(newTab.id);
+        setQuery(saved.sql);
+    }, []);
 
     const handleNewQueryTab = useCallback(() => {
         const newTab: Tab = {
@@ -426,8 +542,15 @@ function App() {
                                             value={query}
                                             onChange={setQuery}
                                             onExecute={handleExecute}
+                                            onCancel={cancelQueries}
+                                            onRunInBackground={runInBackground}
                                             loading={loading}
                                             schema={dbSchema}
+                                            transaction={transactions[activeTab.id]}
+                                            onBegin={() => handleTransaction(BeginTransaction)}
+                                            onCommit={() => handleTransaction(CommitTransaction)}
+                                            onRollback={() => handleTransaction(RollbackTransaction)}
+                                            onOpenSavedQueries={() => setShowSavedQueries(true)}
                                         />
                                     </ResizablePanel>
                                     <ResizableHandle withHandle className="bg-border/10 h-[1px]" />
@@ -435,12 +558,15 @@ function App() {
                                         <ResultsTable
                                             results={queryResults}
                                             error={error}
+                                            analyze={analyzeQueries}
+                                            onAnalyzeChange={setAnalyzeQueries}
                                         />
                                     </ResizablePanel>
                                 </ResizablePanelGroup>
                             ) : activeTab?.type === 'table' && activeTab.data ? (
                                 <DataEditor
                                     database={activeTab.data.db}
+                                    schema={activeTab.data.schema}
                                     table={activeTab.data.table}
                                     onClose={() => {
                                         // Close this tab
@@ -505,6 +631,40 @@ function App() {
                 )
             }
 
+            {/* Bind Parameters */}
+            {
+                parameterRun && (
+                    <ParameterPrompt
+                        parameters={parameterRun.parameters}
+                        initialValues={parameterRun.initialValues}
+                        onSubmit={(values) => {
+                            lastParameterValues.current = { ...lastParameterValues.current, ...values };
+                            const savedQueryId = parameterRun.savedQueryId;
+                            if (savedQueryId) {
+                                savedQueryValues.current[savedQueryId] = { ...savedQueryValues.current[savedQueryId], ...values };
+                                RememberQueryValues(savedQueryId, values).catch(err => console.error('Failed to remember parameter values:', err));
+                            }
+                            executeScript(parameterRun.sql, { tabId: parameterRun.tabId, parameters: values });
+                            setParameterRun(null);
+                        }}
+                        onClose={() => setParameterRun(null)}
+                    />
+                )
+            }
+
+            {/* Saved Queries */}
+            {
+                showSavedQueries && (
+                    <SavedQueriesModal
+                        sql={query}
+                        connection={activeConnectionName}
+                        savedQueryId={activeTab?.savedQueryId}
+                        onOpen={openSavedQuery}
+                        onClose={() => setShowSavedQueries(false)}
+                    />
+                )
+            }
+
             {/* Update Modal */}
             {
                 updateInfo && (
@@ -526,6 +686,8 @@ function App() {
                     <span className="opacity-40 select-none">{t('app.license')}</span>
                 </div>
                 <div className="flex items-center gap-3">
+                    {connected && activeConnection?.config.type === 'postgres' && <NotificationsPopover />}
+                    <JobsPopover onShowResult={showJobResult} />
                     {connected && activeConnectionName && (
                         <div className="px-2 py-0.5 rounded bg-primary/10 text-primary text-[9px] font-black border border-primary/20 animate-in fade-in slide-in-from-right-2 duration-500">
                             {t('app.active')} {activeConnectionName.toUpperCase()}
@@ -557,6 +719,8 @@ function App() {
                 }}
                 onOpenSettings={() => setModalOpen(true)}
             />
+
+            <AppLock onLocked={disconnect} onUnlocked={loadSavedConnections} />
         </div >
     );
 }
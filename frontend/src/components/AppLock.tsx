@@ -0,0 +1,139 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Loader2, Lock } from 'lucide-react';
+import { toast } from 'sonner';
+import { GetLockStatus, Unlock, ResetLock, ReportActivity } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+interface Props {
+    onLocked: () => void; // the backend has dropped the connection
+    onUnlocked: () => void;
+}
+
+// How often user input is reported to postpone the idle lock
+const activityInterval = 30 * 1000;
+const activityEvents = ['keydown', 'mousedown', 'mousemove', 'wheel', 'touchstart'];
+
+// Covers the app while it is locked and reports user input so the idle
+// timeout only locks an unattended app
+export function AppLock({ onLocked, onUnlocked }: Props) {
+    const { t } = useTranslation();
+    const [locked, setLocked] = useState(false);
+    const [passphrase, setPassphrase] = useState('');
+    const [error, setError] = useState('');
+    const [unlocking, setUnlocking] = useState(false);
+    const [damaged, setDamaged] = useState('');
+
+    useEffect(() => {
+        GetLockStatus().then(status => {
+            setLocked(status.locked);
+            setDamaged(status.damaged || '');
+        }).catch(() => {});
+        const offLocked = EventsOn('app:locked', () => {
+            setLocked(true);
+            onLocked();
+        });
+        const offUnlocked = EventsOn('app:unlocked', () => setLocked(false));
+        return () => {
+            offLocked();
+            offUnlocked();
+        };
+    }, [onLocked]);
+
+    useEffect(() => {
+        if (locked) return;
+        let last = 0;
+        const report = () => {
+            const now = Date.now();
+            if (now - last < activityInterval) return;
+            last = now;
+            ReportActivity().catch(() => {});
+        };
+        activityEvents.forEach(name => window.addEventListener(name, report, { passive: true }));
+        return () => activityEvents.forEach(name => window.removeEventListener(name, report));
+    }, [locked]);
+
+    const handleUnlock = async (e: React.FormEvent) => {
+        e.preventDefault();
+        setUnlocking(true);
+        setError('');
+        try {
+            await Unlock(passphrase);
+            setPassphrase('');
+            setLocked(false);
+            onUnlocked();
+        } catch (err: any) {
+            setError(typeof err === 'string' ? err : err.message);
+        } finally {
+            setUnlocking(false);
+        }
+    };
+
+    // Damaged settings can't verify a passphrase; resetting them turns the lock off
+    const handleReset = async () => {
+        setUnlocking(true);
+        setError('');
+        try {
+            const backup = await ResetLock();
+            setDamaged('');
+            setLocked(false);
+            onUnlocked();
+            toast.warning(t('appLock.resetDone', { backup }));
+        } catch (err: any) {
+            setError(typeof err === 'string' ? err : err.message);
+        } finally {
+            setUnlocking(false);
+        }
+    };
+
+    if (!locked) return null;
+    if (damaged) {
+        return (
+            <div className="fixed inset-0 z-[100] flex items-center justify-center bg-background/95 backdrop-blur-xl">
+                <div className="w-[360px] space-y-4 text-center">
+                    <div className="mx-auto w-12 h-12 rounded-xl bg-destructive/10 flex items-center justify-center text-destructive border border-destructive/20">
+                        <Lock size={22} />
+                    </div>
+                    <div>
+                        <h2 className="text-lg font-black tracking-tight uppercase italic">{t('appLock.locked')}</h2>
+                        <p className="text-[11px] text-muted-foreground mt-1">{t('appLock.damaged')}</p>
+                        <p className="text-[10px] font-mono text-destructive mt-2 break-all">{damaged}</p>
+                    </div>
+                    {error && <p className="text-[11px] text-destructive">{error}</p>}
+                    <Button variant="destructive" onClick={handleReset} disabled={unlocking} className="w-full text-[10px] font-black uppercase tracking-widest gap-2">
+                        {unlocking && <Loader2 size={12} className="animate-spin" />}
+                        {t('appLock.reset')}
+                    </Button>
+                </div>
+            </div>
+        );
+    }
+    return (
+        <div className="fixed inset-0 z-[100] flex items-center justify-center bg-background/95 backdrop-blur-xl">
+            <form onSubmit={handleUnlock} className="w-[320px] space-y-4 text-center">
+                <div className="mx-auto w-12 h-12 rounded-xl bg-primary/10 flex items-center justify-center text-primary border border-primary/20">
+                    <Lock size={22} />
+                </div>
+                <div>
+                    <h2 className="text-lg font-black tracking-tight uppercase italic">{t('appLock.locked')}</h2>
+                    <p className="text-[11px] text-muted-foreground mt-1">{t('appLock.enterPassphrase')}</p>
+                </div>
+                <Input
+                    type="password"
+                    autoFocus
+                    className="h-9 text-[12px] bg-background/50"
+                    value={passphrase}
+                    onChange={(e) => setPassphrase(e.target.value)}
+                    placeholder={t('appLock.passphrase')}
+                />
+                {error && <p className="text-[11px] text-destructive">{error}</p>}
+                <Button type="submit" disabled={unlocking || !passphrase} className="w-full text-[10px] font-black uppercase tracking-widest gap-2">
+                    {unlocking && <Loader2 size={12} className="animate-spin" />}
+                    {t('appLock.unlock')}
+                </Button>
+            </form>
+        </div>
+    );
+}
@@ -0,0 +1,150 @@
+import { useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Checkbox } from '@/components/ui/checkbox';
+import { ArrowDown, ArrowUp, Brackets, Loader2, Plus, Save, X } from 'lucide-react';
+import { toast } from "sonner";
+import { ColumnInfo } from '../types';
+
+interface Props {
+    table: string;
+    column: ColumnInfo;
+    // Elements as listed by the grid, or the literal when it did not parse
+    value: any;
+    onSave: (list: any[] | null) => Promise<void>;
+    onClose: () => void;
+}
+
+// Edits a PostgreSQL array element by element. Elements are sent as text,
+// which the backend quotes into an array literal of the column's type.
+export function ArrayCellEditor({ table, column, value, onSave, onClose }: Props) {
+    const { t } = useTranslation();
+    // Multidimensional arrays and literals that did not parse can't be edited here
+    const nested = value !== null && (!Array.isArray(value) || value.some(v => Array.isArray(v)));
+    const [isNull, setIsNull] = useState(value === null);
+    const [items, setItems] = useState<(string | null)[]>(() =>
+        Array.isArray(value) && !nested ? value.map(v => v === null ? null : String(v)) : []);
+    const [saving, setSaving] = useState(false);
+
+    const update = (index: number, item: string | null) =>
+        setItems(prev => prev.map((v, i) => i === index ? item : v));
+
+    const move = (index: number, delta: number) => setItems(prev => {
+        const next = [...prev];
+        [next[index], next[index + delta]] = [next[index + delta], next[index]];
+        return next;
+    });
+
+    const handleSave = async () => {
+        setSaving(true);
+        try {
+            await onSave(isNull ? null : items);
+            onClose();
+        } catch (err: any) {
+            toast.error(`${t('dataEditor.updateFailed')}: ${typeof err === 'string' ? err : err.message}`);
+        } finally {
+            setSaving(false);
+        }
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[520px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Brackets size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('arrayEditor.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {table}.{column.name} · {column.type}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-2 max-h-[55vh] overflow-y-auto">
+                    {nested ? (
+                        <p className="text-[11px] text-muted-foreground">{t('arrayEditor.nested')}</p>
+                    ) : (
+                        <>
+                            <label className="flex items-center gap-1.5 text-[9px] font-black uppercase text-muted-foreground tracking-widest cursor-pointer pb-2">
+                                <Checkbox
+                                    checked={isNull}
+                                    onCheckedChange={(checked) => setIsNull(!!checked)}
+                                    className="h-4 w-4 rounded border-muted-foreground/30"
+                                />
+                                {t('arrayEditor.nullArray')}
+                            </label>
+                            {!isNull && items.map((item, i) => (
+                                <div key={i} className="flex items-center gap-2">
+                                    <span className="w-8 shrink-0 text-right font-mono text-[10px] text-muted-foreground">[{i + 1}]</span>
+                                    <Input
+                                        value={item ?? ''}
+                                        disabled={item === null}
+                                        placeholder={item === null ? 'NULL' : ''}
+                                        onChange={(e) => update(i, e.target.value)}
+                                        className="h-8 text-[11px] font-mono bg-background/50"
+                                    />
+                                    <label className="flex items-center gap-1 text-[9px] font-black uppercase text-muted-foreground tracking-widest cursor-pointer">
+                                        <Checkbox
+                                            checked={item === null}
+                                            onCheckedChange={(checked) => update(i, checked ? null : '')}
+                                            className="h-4 w-4 rounded border-muted-foreground/30"
+                                        />
+                                        NULL
+                                    </label>
+                                    <Button variant="ghost" size="icon" className="h-6 w-6 shrink-0" disabled={i === 0} onClick={() => move(i, -1)}>
+                                        <ArrowUp size={12} />
+                                    </Button>
+                                    <Button variant="ghost" size="icon" className="h-6 w-6 shrink-0" disabled={i === items.length - 1} onClick={() => move(i, 1)}>
+                                        <ArrowDown size={12} />
+                                    </Button>
+                                    <Button variant="ghost" size="icon" className="h-6 w-6 shrink-0" onClick={() => setItems(prev => prev.filter((_, j) => j !== i))}>
+                                        <X size={12} />
+                                    </Button>
+                                </div>
+                            ))}
+                            {!isNull && (
+                                <Button
+                                    variant="outline"
+                                    size="sm"
+                                    className="h-7 text-[10px] font-bold gap-1.5 uppercase"
+                                    onClick={() => setItems(prev => [...prev, ''])}
+                                >
+                                    <Plus size={12} /> {t('arrayEditor.addElement')}
+                                </Button>
+                            )}
+                        </>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.cancel')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={nested || saving}
+                        onClick={handleSave}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        {saving ? <Loader2 size={12} className="animate-spin" /> : <Save size={12} />}
+                        {t('common.save')}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,397 @@
+import { useEffect, useRef, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Tabs, TabsList, TabsTrigger, TabsContent } from '@/components/ui/tabs';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Checkbox } from '@/components/ui/checkbox';
+import { ArchiveRestore, DatabaseBackup, FolderOpen, Loader2, Trash2, TriangleAlert } from 'lucide-react';
+import { toast } from "sonner";
+import { BackupFile, BackupOptions, BackupProgress, BackupTools, RestoreOptions } from '../types';
+import {
+    DetectBackupTools,
+    GetDatabases,
+    GetTables,
+    Backup,
+    Restore,
+    ListBackups,
+    DeleteBackup,
+    SelectBackupFile,
+    GetActiveOperations,
+    CancelOperation
+} from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+interface Props {
+    type?: string; // postgres or mysql
+    onClose: () => void;
+}
+
+// Restore options shown as checkboxes; only continueOnError applies to MySQL
+const restoreFlags: ['clean' | 'noOwner' | 'singleTransaction' | 'continueOnError', string][] = [
+    ['clean', 'backup.clean'],
+    ['noOwner', 'backup.noOwner'],
+    ['singleTransaction', 'backup.singleTransaction'],
+    ['continueOnError', 'backup.continueOnError'],
+];
+
+const formatSize = (bytes: number) => {
+    if (bytes < 1024) return `${bytes} B`;
+    if (bytes < 1024 * 1024) return `${(bytes / 1024).toFixed(1)} KiB`;
+    return `${(bytes / 1024 / 1024).toFixed(1)} MiB`;
+};
+
+// Full backups and restores through pg_dump, pg_restore and psql, or
+// mysqldump and mysql, with the output of the running tool and the backups
+// kept so far
+export function BackupModal({ type, onClose }: Props) {
+    const { t } = useTranslation();
+    const [tools, setTools] = useState<BackupTools | null>(null);
+    const [databases, setDatabases] = useState<string[]>([]);
+    const [database, setDatabase] = useState('');
+    const [tables, setTables] = useState<string[]>([]);
+    const mysql = type === 'mysql';
+    const [backupOptions, setBackupOptions] = useState<BackupOptions>({ format: mysql ? 'plain' : 'custom', content: 'all', tables: [] });
+    const [restoreOptions, setRestoreOptions] = useState<RestoreOptions>({ content: 'all' });
+    const [backups, setBackups] = useState<BackupFile[]>([]);
+    const [pendingRestore, setPendingRestore] = useState<string | null>(null);
+    const [running, setRunning] = useState<'backup' | 'restore' | null>(null);
+    const [log, setLog] = useState<string[]>([]);
+    const [read, setRead] = useState<{ bytes: number; total: number } | null>(null);
+    const logRef = useRef<HTMLDivElement>(null);
+
+    const loadBackups = () => ListBackups().then(setBackups).catch(() => setBackups([]));
+
+    useEffect(() => {
+        DetectBackupTools().then(setTools);
+        GetDatabases().then(list => {
+            const names = list.map(db => db.name);
+            setDatabases(names);
+            if (names.length > 0) setDatabase(names[0]);
+        });
+        loadBackups();
+    }, []);
+
+    useEffect(() => {
+        if (!database) return;
+        setBackupOptions(options => ({ ...options, tables: [] }));
+        GetTables(database, '').then(list => setTables(list.map(table => table.name))).catch(() => setTables([]));
+    }, [database]);
+
+    useEffect(() => {
+        if (!running) return;
+        return EventsOn('backup:progress', (p: BackupProgress) => {
+            if (p.line) setLog(lines => [...lines.slice(-499), p.line!]);
+            if (p.bytes) setRead({ bytes: p.bytes, total: p.totalBytes ?? 0 });
+        });
+    }, [running]);
+
+    useEffect(() => {
+        logRef.current?.scrollTo({ top: logRef.current.scrollHeight });
+    }, [log]);
+
+    const toggleTable = (table: string, checked: boolean) => {
+        const selected = backupOptions.tables ?? [];
+        setBackupOptions({ ...backupOptions, tables: checked ? [...selected, table] : selected.filter(name => name !== table) });
+    };
+
+    const handleBackup = async () => {
+        setLog([]);
+        setRead(null);
+        setRunning('backup');
+        try {
+            const result = await Backup(database, backupOptions);
+            toast.success(t('backup.backedUp', { size: formatSize(result.size), seconds: (result.durationMs / 1000).toFixed(1) }));
+            loadBackups();
+        } catch (err: any) {
+            toast.error(t('backup.failed', { error: typeof err === 'string' ? err : err.message }));
+        } finally {
+            setRunning(null);
+        }
+    };
+
+    const handleRestore = async (path: string) => {
+        setPendingRestore(null);
+        setLog([]);
+        setRead(null);
+        setRunning('restore');
+        try {
+            await Restore(database, path, restoreOptions);
+            toast.success(t('backup.restored', { database }));
+        } catch (err: any) {
+            toast.error(t('backup.restoreFailed', { error: typeof err === 'string' ? err : err.message }));
+        } finally {
+            setRunning(null);
+        }
+    };
+
+    const handleOpenFile = async () => {
+        const path = await SelectBackupFile();
+        if (path) setPendingRestore(path);
+    };
+
+    const handleDelete = async (name: string) => {
+        try {
+            await DeleteBackup(name);
+            loadBackups();
+        } catch (err: any) {
+            toast.error(typeof err === 'string' ? err : err.message);
+        }
+    };
+
+    const handleCancel = async () => {
+        const ops = await GetActiveOperations();
+        const op = ops.find(o => o.kind === running);
+        if (op) await CancelOperation(op.id);
+    };
+
+    const missing = tools !== null && !(mysql ? tools.mysqldump : tools.pgDump);
+    const toolsLabel = mysql ? t('backup.toolsMysql', { flavor: tools?.flavor, version: tools?.version }) : t('backup.tools', { version: tools?.version });
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && !running && onClose()}>
+            <DialogContent className="sm:max-w-[640px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <DatabaseBackup size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('backup.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {tools === null ? t('backup.detecting') : missing ? t(mysql ? 'backup.mysqldumpMissing' : 'backup.toolsMissing') : toolsLabel}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-4 max-h-[70vh] overflow-y-auto">
+                    {missing && (
+                        <p className="text-[11px] text-destructive flex items-start gap-2">
+                            <TriangleAlert size={14} className="shrink-0 mt-0.5" /> {t(mysql ? 'backup.installHintMysql' : 'backup.installHint')}
+                        </p>
+                    )}
+                    {tools?.warning && (
+                        <p className="text-[11px] text-amber-500 flex items-start gap-2">
+                            <TriangleAlert size={14} className="shrink-0 mt-0.5" /> {tools.warning}
+                        </p>
+                    )}
+
+                    <div className="space-y-1.5">
+                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('backup.database')}</Label>
+                        <Select value={database} onValueChange={setDatabase} disabled={!!running}>
+                            <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent>
+                                {databases.map(name => (
+                                    <SelectItem key={name} value={name} className="text-[11px]">{name}</SelectItem>
+                                ))}
+                            </SelectContent>
+                        </Select>
+                    </div>
+
+                    <Tabs defaultValue="backup">
+                        <TabsList className="h-8">
+                            <TabsTrigger value="backup" className="text-[10px] font-black uppercase tracking-widest">{t('backup.backupTab')}</TabsTrigger>
+                            <TabsTrigger value="restore" className="text-[10px] font-black uppercase tracking-widest">{t('backup.restoreTab')}</TabsTrigger>
+                        </TabsList>
+
+                        <TabsContent value="backup" className="space-y-4 pt-2">
+                            <div className={mysql ? "grid grid-cols-2 gap-3" : "grid grid-cols-3 gap-3"}>
+                                {!mysql && <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('backup.format')}</Label>
+                                    <Select value={backupOptions.format} onValueChange={(format) => setBackupOptions({ ...backupOptions, format, compression: format === 'tar' ? 0 : backupOptions.compression })}>
+                                        <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                            <SelectValue />
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            <SelectItem value="custom" className="text-[11px]">{t('backup.formatCustom')}</SelectItem>
+                                            <SelectItem value="plain" className="text-[11px]">{t('backup.formatPlain')}</SelectItem>
+                                            <SelectItem value="tar" className="text-[11px]">{t('backup.formatTar')}</SelectItem>
+                                            <SelectItem value="directory" className="text-[11px]">{t('backup.formatDirectory')}</SelectItem>
+                                        </SelectContent>
+                                    </Select>
+                                </div>}
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('backup.compression')}</Label>
+                                    <Select
+                                        value={String(backupOptions.compression ?? 0)}
+                                        onValueChange={(level) => setBackupOptions({ ...backupOptions, compression: parseInt(level) })}
+                                        disabled={backupOptions.format === 'tar'}
+                                    >
+                                        <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                            <SelectValue />
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            <SelectItem value="0" className="text-[11px]">{t(mysql ? 'backup.compressionNone' : 'backup.compressionDefault')}</SelectItem>
+                                            {!mysql && <SelectItem value="-1" className="text-[11px]">{t('backup.compressionNone')}</SelectItem>}
+                                            {[1, 3, 6, 9].map(level => (
+                                                <SelectItem key={level} value={String(level)} className="text-[11px]">{t('backup.compressionLevel', { level })}</SelectItem>
+                                            ))}
+                                        </SelectContent>
+                                    </Select>
+                                </div>
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('dump.content')}</Label>
+                                    <Select value={backupOptions.content} onValueChange={(content) => setBackupOptions({ ...backupOptions, content })}>
+                                        <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                            <SelectValue />
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            <SelectItem value="all" className="text-[11px]">{t('dump.contentAll')}</SelectItem>
+                                            <SelectItem value="schema" className="text-[11px]">{t('dump.contentSchema')}</SelectItem>
+                                            <SelectItem value="data" className="text-[11px]">{t('dump.contentData')}</SelectItem>
+                                        </SelectContent>
+                                    </Select>
+                                </div>
+                            </div>
+                            {backupOptions.format === 'directory' && (
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('backup.jobs')}</Label>
+                                    <Input
+                                        className="h-8 text-[11px] font-mono bg-background/50"
+                                        type="number"
+                                        min={1}
+                                        value={backupOptions.jobs || ''}
+                                        onChange={(e) => setBackupOptions({ ...backupOptions, jobs: Math.max(0, parseInt(e.target.value) || 0) })}
+                                        placeholder="1"
+                                    />
+                                </div>
+                            )}
+                            <div className="space-y-1.5">
+                                <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">
+                                    {backupOptions.tables?.length ? t('dump.tables', { count: backupOptions.tables.length }) : t('backup.allTables')}
+                                </Label>
+                                <div className="rounded-md border max-h-32 overflow-y-auto p-2 grid grid-cols-2 gap-1">
+                                    {tables.map(table => (
+                                        <label key={table} className="flex items-center gap-2 text-[11px] font-mono cursor-pointer truncate">
+                                            <Checkbox
+                                                checked={backupOptions.tables?.includes(table)}
+                                                onCheckedChange={(checked) => toggleTable(table, !!checked)}
+                                            />
+                                            <span className="truncate">{table}</span>
+                                        </label>
+                                    ))}
+                                </div>
+                            </div>
+                            {mysql ? (
+                                <p className="text-[10px] text-muted-foreground">{t('backup.mysqlHint')}</p>
+                            ) : (
+                                <label className="flex items-center gap-2 text-[11px] cursor-pointer">
+                                    <Checkbox
+                                        checked={!!backupOptions.noOwner}
+                                        onCheckedChange={(checked) => setBackupOptions({ ...backupOptions, noOwner: !!checked })}
+                                    />
+                                    {t('backup.noOwner')}
+                                </label>
+                            )}
+                            <Button
+                                type="button"
+                                disabled={!!running || missing || !database}
+                                onClick={handleBackup}
+                                className="w-full text-[10px] font-black uppercase tracking-widest gap-2"
+                            >
+                                {running === 'backup' ? <Loader2 size={12} className="animate-spin" /> : <DatabaseBackup size={12} />}
+                                {t('backup.backup')}
+                            </Button>
+                        </TabsContent>
+
+                        <TabsContent value="restore" className="space-y-4 pt-2">
+                            <div className="grid grid-cols-2 gap-x-3 gap-y-2">
+                                {restoreFlags.filter(([key]) => !mysql || key === 'continueOnError').map(([key, label]) => (
+                                    <label key={key} className="flex items-center gap-2 text-[11px] cursor-pointer">
+                                        <Checkbox
+                                            checked={!!restoreOptions[key]}
+                                            onCheckedChange={(checked) => setRestoreOptions({ ...restoreOptions, [key]: !!checked })}
+                                        />
+                                        {t(label)}
+                                    </label>
+                                ))}
+                            </div>
+                            {!mysql && <p className="text-[10px] text-muted-foreground">{t('backup.plainHint')}</p>}
+
+                            <div className="space-y-1.5">
+                                <div className="flex items-center justify-between">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('backup.saved', { count: backups.length })}</Label>
+                                    <button className="text-[10px] text-primary hover:underline flex items-center gap-1" onClick={handleOpenFile} disabled={!!running}>
+                                        <FolderOpen size={10} /> {t('backup.openFile')}
+                                    </button>
+                                </div>
+                                <div className="rounded-md border max-h-40 overflow-y-auto divide-y">
+                                    {backups.length === 0 && (
+                                        <p className="p-3 text-[11px] text-muted-foreground text-center">{t('backup.none')}</p>
+                                    )}
+                                    {backups.map(file => (
+                                        <div key={file.name} className="flex items-center gap-2 px-2 py-1.5 text-[11px]">
+                                            <span className="font-mono truncate flex-1" title={file.path}>{file.name}</span>
+                                            <span className="text-muted-foreground text-[10px] uppercase">{file.format}</span>
+                                            <span className="text-muted-foreground text-[10px] w-16 text-right">{formatSize(file.size)}</span>
+                                            <Button variant="ghost" size="icon" className="h-6 w-6" title={t('backup.restore')} disabled={!!running} onClick={() => setPendingRestore(file.path)}>
+                                                <ArchiveRestore size={12} />
+                                            </Button>
+                                            <Button variant="ghost" size="icon" className="h-6 w-6 text-destructive" title={t('common.delete')} disabled={!!running} onClick={() => handleDelete(file.name)}>
+                                                <Trash2 size={12} />
+                                            </Button>
+                                        </div>
+                                    ))}
+                                </div>
+                            </div>
+
+                            {pendingRestore && (
+                                <div className="rounded-md border border-destructive/40 bg-destructive/5 p-3 space-y-2">
+                                    <p className="text-[11px]">{t('backup.confirmRestore', { file: pendingRestore, database })}</p>
+                                    <div className="flex justify-end gap-2">
+                                        <Button variant="ghost" size="sm" className="h-7 text-[10px]" onClick={() => setPendingRestore(null)}>{t('common.cancel')}</Button>
+                                        <Button variant="destructive" size="sm" className="h-7 text-[10px] gap-1" onClick={() => handleRestore(pendingRestore)}>
+                                            <ArchiveRestore size={12} /> {t('backup.restore')}
+                                        </Button>
+                                    </div>
+                                </div>
+                            )}
+                        </TabsContent>
+                    </Tabs>
+
+                    {running === 'restore' && read && (
+                        <p className="text-[11px] font-mono text-muted-foreground">
+                            {t('backup.read', { read: formatSize(read.bytes), total: formatSize(read.total) })}
+                        </p>
+                    )}
+                    {log.length > 0 && (
+                        <div ref={logRef} className="rounded-md border bg-muted/30 p-2 max-h-40 overflow-y-auto font-mono text-[10px] text-muted-foreground whitespace-pre-wrap">
+                            {log.join('\n')}
+                        </div>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    {running ? (
+                        <Button type="button" variant="ghost" onClick={handleCancel} className="text-[10px] font-black uppercase tracking-widest">
+                            {t('backup.cancelRunning')}
+                        </Button>
+                    ) : (
+                        <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                            {t('common.close')}
+                        </Button>
+                    )}
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,159 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import { Button } from '@/components/ui/button';
+import { Badge } from '@/components/ui/badge';
+import { Binary, Download, Loader2, Upload } from 'lucide-react';
+import { toast } from "sonner";
+import { BinaryValue } from '../types';
+import {
+    GetBinaryCell, SelectCellDownloadPath, DownloadCell, SelectCellUploadFile, UploadCell
+} from '../../wailsjs/go/main/App';
+
+interface Props {
+    database: string;
+    schema?: string;
+    table: string;
+    primaryKey: string[];
+    primaryValues: any[];
+    column: string;
+    readOnly?: boolean;
+    onSaved: () => void;
+    onClose: () => void;
+}
+
+const formatSize = (bytes: number) => {
+    if (bytes < 1024) return `${bytes} B`;
+    if (bytes < 1024 * 1024) return `${(bytes / 1024).toFixed(1)} KiB`;
+    return `${(bytes / 1024 / 1024).toFixed(1)} MiB`;
+};
+
+// Shows a bytea/blob cell as a hex dump, previews images and PDFs, and moves
+// the content to and from files
+export function BinaryCellViewer({ database, schema = '', table, primaryKey, primaryValues, column, readOnly, onSaved, onClose }: Props) {
+    const { t } = useTranslation();
+    const [value, setValue] = useState<BinaryValue | null>(null);
+    const [busy, setBusy] = useState(false);
+
+    const load = () => GetBinaryCell(database, schema, table, primaryKey, primaryValues, column)
+        .then(setValue)
+        .catch(err => {
+            toast.error(typeof err === 'string' ? err : err.message);
+            onClose();
+        });
+
+    useEffect(() => {
+        load();
+    }, [database, schema, table, column]);
+
+    const handleDownload = async () => {
+        const path = await SelectCellDownloadPath(column);
+        if (!path) return;
+        try {
+            await DownloadCell(database, schema, table, primaryKey, primaryValues, column, path);
+            toast.success(t('binaryViewer.downloaded', { path }));
+        } catch (err: any) {
+            toast.error(typeof err === 'string' ? err : err.message);
+        }
+    };
+
+    const handleUpload = async () => {
+        const path = await SelectCellUploadFile();
+        if (!path) return;
+        setBusy(true);
+        try {
+            await UploadCell(database, schema, table, primaryKey, primaryValues, column, path);
+            toast.success(t('binaryViewer.uploaded'));
+            onSaved();
+            await load();
+        } catch (err: any) {
+            toast.error(`${t('dataEditor.updateFailed')}: ${typeof err === 'string' ? err : err.message}`);
+        } finally {
+            setBusy(false);
+        }
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[760px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Binary size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('binaryViewer.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {table}.{column}
+                            </p>
+                        </div>
+                        {value && !value.isNull && (
+                            <div className="ml-auto flex gap-1.5">
+                                <Badge variant="secondary" className="text-[9px] font-mono">{formatSize(value.size)}</Badge>
+                                <Badge variant="outline" className="text-[9px] font-mono">{value.mimeType}</Badge>
+                            </div>
+                        )}
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-3 max-h-[60vh] overflow-y-auto">
+                    {!value ? (
+                        <div className="flex items-center gap-2 text-[10px] text-muted-foreground uppercase font-bold tracking-widest">
+                            <Loader2 size={12} className="animate-spin" />
+                            {t('common.loading')}
+                        </div>
+                    ) : value.isNull ? (
+                        <p className="text-[11px] font-mono italic text-muted-foreground">NULL</p>
+                    ) : (
+                        <>
+                            {value.preview && (value.mimeType.startsWith('image/') ? (
+                                <img src={value.preview} alt={column} className="max-h-64 mx-auto rounded border bg-muted/30" />
+                            ) : (
+                                <iframe src={value.preview} title={column} className="w-full h-80 rounded border bg-white" />
+                            ))}
+                            <pre className="rounded-md border bg-muted/30 p-3 text-[10px] font-mono whitespace-pre overflow-x-auto select-text">{value.dump}</pre>
+                            {value.truncated && (
+                                <p className="text-[10px] text-muted-foreground">{t('binaryViewer.truncated', { size: formatSize(value.size) })}</p>
+                            )}
+                        </>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.close')}
+                    </Button>
+                    <Button
+                        type="button"
+                        variant="outline"
+                        disabled={!value || value.isNull}
+                        onClick={handleDownload}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        <Download size={12} />
+                        {t('binaryViewer.download')}
+                    </Button>
+                    {!readOnly && (
+                        <Button
+                            type="button"
+                            disabled={!value || busy}
+                            onClick={handleUpload}
+                            className="text-[10px] font-black uppercase tracking-widest gap-2"
+                        >
+                            {busy ? <Loader2 size={12} className="animate-spin" /> : <Upload size={12} />}
+                            {t('binaryViewer.upload')}
+                        </Button>
+                    )}
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,101 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Checkbox } from '@/components/ui/checkbox';
+import { UploadProgress } from '../types';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+export interface Bucket {
+    enabled: boolean;
+    url: string; // s3://bucket/key or gs://bucket/key
+    region: string;
+    profile: string;
+    endpoint: string;
+}
+
+export const emptyBucket: Bucket = { enabled: false, url: '', region: '', profile: '', endpoint: '' };
+
+// bucketPath returns the export path the backend uploads to, with the
+// optional settings as query parameters
+export function bucketPath(bucket: Bucket): string {
+    const params = new URLSearchParams();
+    if (bucket.region.trim()) params.set('region', bucket.region.trim());
+    if (bucket.profile.trim()) params.set('profile', bucket.profile.trim());
+    if (bucket.endpoint.trim()) params.set('endpoint', bucket.endpoint.trim());
+    const query = params.toString();
+    return bucket.url.trim() + (query ? `?${query}` : '');
+}
+
+export const isValidBucket = (bucket: Bucket) => /^(s3|gs):\/\/[^/]+\/.*[^/]$/.test(bucket.url.trim());
+
+interface Props {
+    value: Bucket;
+    onChange: (value: Bucket) => void;
+    extension: string;
+    disabled?: boolean;
+}
+
+// Chooses between saving an export locally and uploading it to an S3 or GCS
+// bucket as it is written
+export function BucketTarget({ value, onChange, extension, disabled }: Props) {
+    const { t } = useTranslation();
+
+    return (
+        <div className="space-y-2">
+            <label className="flex items-center gap-2 text-[11px] cursor-pointer">
+                <Checkbox
+                    checked={value.enabled}
+                    disabled={disabled}
+                    onCheckedChange={(checked) => onChange({ ...value, enabled: !!checked })}
+                />
+                {t('bucketTarget.upload')}
+            </label>
+            {value.enabled && (
+                <div className="space-y-2 pl-6">
+                    <Input
+                        className="h-8 text-[11px] font-mono bg-background/50"
+                        value={value.url}
+                        disabled={disabled}
+                        onChange={(e) => onChange({ ...value, url: e.target.value })}
+                        placeholder={`s3://bucket/exports/data.${extension}`}
+                    />
+                    <div className="grid grid-cols-3 gap-2">
+                        <div className="space-y-1">
+                            <Label className="text-[9px] font-black uppercase text-muted-foreground tracking-widest">{t('bucketTarget.region')}</Label>
+                            <Input className="h-7 text-[11px] font-mono bg-background/50" value={value.region} disabled={disabled} onChange={(e) => onChange({ ...value, region: e.target.value })} placeholder={t('bucketTarget.default')} />
+                        </div>
+                        <div className="space-y-1">
+                            <Label className="text-[9px] font-black uppercase text-muted-foreground tracking-widest">{t('bucketTarget.profile')}</Label>
+                            <Input className="h-7 text-[11px] font-mono bg-background/50" value={value.profile} disabled={disabled} onChange={(e) => onChange({ ...value, profile: e.target.value })} placeholder={t('bucketTarget.default')} />
+                        </div>
+                        <div className="space-y-1">
+                            <Label className="text-[9px] font-black uppercase text-muted-foreground tracking-widest">{t('bucketTarget.endpoint')}</Label>
+                            <Input className="h-7 text-[11px] font-mono bg-background/50" value={value.endpoint} disabled={disabled} onChange={(e) => onChange({ ...value, endpoint: e.target.value })} placeholder="AWS" />
+                        </div>
+                    </div>
+                    <p className="text-[10px] text-muted-foreground">{t('bucketTarget.hint')}</p>
+                </div>
+            )}
+        </div>
+    );
+}
+
+// Shows how much of an export has been uploaded while active is set
+export function UploadStatus({ active }: { active: boolean }) {
+    const { t } = useTranslation();
+    const [progress, setProgress] = useState<UploadProgress | null>(null);
+
+    useEffect(() => {
+        if (!active) return;
+        setProgress(null);
+        return EventsOn('upload:progress', (p: UploadProgress) => setProgress(p));
+    }, [active]);
+
+    if (!active || !progress) return null;
+    return (
+        <p className="text-[11px] font-mono text-muted-foreground">
+            {t('bucketTarget.progress', { size: (progress.bytes / (1 << 20)).toFixed(1), parts: progress.parts })}
+        </p>
+    );
+}
@@ -0,0 +1,191 @@
+import { useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Checkbox } from '@/components/ui/checkbox';
+import { FileCode, Loader2, PencilLine, X } from 'lucide-react';
+import { toast } from "sonner";
+import { BulkPreview, BulkUpdate, ColumnInfo, FilterCondition } from '../types';
+import { BulkUpdateRows, PreviewBulkUpdate } from '../../wailsjs/go/main/App';
+import { SQLPreviewBlock } from './SQLPreviewBlock';
+
+interface Props {
+    database: string;
+    schema?: string;
+    table: string;
+    columns: ColumnInfo[];
+    // Filters of the table view; the update covers the rows they match
+    filters: FilterCondition[];
+    search: string;
+    onApplied: () => void;
+    onClose: () => void;
+}
+
+export function BulkUpdateModal({ database, schema = '', table, columns, filters, search, onApplied, onClose }: Props) {
+    const { t } = useTranslation();
+    // New value per column; null stands for SQL NULL
+    const [values, setValues] = useState<Record<string, string | null>>({});
+    const [preview, setPreview] = useState<BulkPreview | null>(null);
+    const [previewError, setPreviewError] = useState<string | null>(null);
+    const [reviewing, setReviewing] = useState(false);
+    const [applying, setApplying] = useState(false);
+
+    const editable = columns.filter(c => !c.generated && !(c.name in values));
+    const filtered = filters.length > 0 || search !== '';
+
+    const request = (): BulkUpdate => ({ filters, search: search || undefined, values });
+
+    // Any change of the values invalidates the reviewed statement
+    const changeValues = (next: Record<string, string | null>) => {
+        setValues(next);
+        setPreview(null);
+        setPreviewError(null);
+    };
+
+    const removeColumn = (name: string) => {
+        const next = { ...values };
+        delete next[name];
+        changeValues(next);
+    };
+
+    const handleReview = async () => {
+        setReviewing(true);
+        setPreviewError(null);
+        try {
+            setPreview(await PreviewBulkUpdate(database, schema, table, request()));
+        } catch (err: any) {
+            setPreviewError(typeof err === 'string' ? err : err.message);
+        } finally {
+            setReviewing(false);
+        }
+    };
+
+    const handleApply = async () => {
+        setApplying(true);
+        try {
+            const result = await BulkUpdateRows(database, schema, table, request());
+            toast.success(t('bulkUpdate.updated', { count: result.rowsAffected }));
+            onApplied();
+            onClose();
+        } catch (err: any) {
+            toast.error(t('bulkUpdate.failed', { error: typeof err === 'string' ? err : err.message }));
+        } finally {
+            setApplying(false);
+        }
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[560px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <PencilLine size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('bulkUpdate.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {filtered ? t('bulkUpdate.filtered', { table }) : t('bulkUpdate.allRows', { table })}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-3 max-h-[60vh] overflow-y-auto">
+                    {Object.entries(values).map(([name, value]) => (
+                        <div key={name} className="flex items-center gap-3">
+                            <span className="w-32 shrink-0 font-mono text-[11px] font-bold text-primary truncate" title={name}>
+                                {name}
+                            </span>
+                            <Input
+                                value={value ?? ''}
+                                disabled={value === null}
+                                placeholder={value === null ? 'NULL' : ''}
+                                onChange={(e) => changeValues({ ...values, [name]: e.target.value })}
+                                className="h-8 text-[11px] font-mono bg-background/50"
+                            />
+                            <label className="flex items-center gap-1.5 text-[9px] font-black uppercase text-muted-foreground tracking-widest cursor-pointer">
+                                <Checkbox
+                                    checked={value === null}
+                                    onCheckedChange={(checked) => changeValues({ ...values, [name]: checked ? null : '' })}
+                                    className="h-4 w-4 rounded border-muted-foreground/30"
+                                />
+                                NULL
+                            </label>
+                            <Button variant="ghost" size="icon" className="h-6 w-6 shrink-0" onClick={() => removeColumn(name)}>
+                                <X size={12} />
+                            </Button>
+                        </div>
+                    ))}
+
+                    {editable.length > 0 && (
+                        <Select value="" onValueChange={(name) => changeValues({ ...values, [name]: '' })}>
+                            <SelectTrigger className="h-8 text-[11px] font-mono bg-background/50">
+                                <SelectValue placeholder={t('bulkUpdate.addColumn')} />
+                            </SelectTrigger>
+                            <SelectContent>
+                                {editable.map(c => (
+                                    <SelectItem key={c.name} value={c.name} className="text-[11px] font-mono">
+                                        {c.name} <span className="text-muted-foreground">{c.type}</span>
+                                    </SelectItem>
+                                ))}
+                            </SelectContent>
+                        </Select>
+                    )}
+
+                    {(preview || previewError || reviewing) && (
+                        <div className="space-y-2 pt-2">
+                            {preview && (
+                                <p className="text-[11px] font-bold text-amber-500">
+                                    {t('bulkUpdate.matching', { count: preview.matchingRows })}
+                                </p>
+                            )}
+                            <SQLPreviewBlock preview={preview?.preview ?? null} error={previewError} />
+                        </div>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.cancel')}
+                    </Button>
+                    <Button
+                        type="button"
+                        variant="outline"
+                        disabled={Object.keys(values).length === 0 || reviewing}
+                        onClick={handleReview}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        <FileCode size={12} />
+                        {t('bulkUpdate.review')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={!preview || applying}
+                        onClick={handleApply}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        {applying ? <Loader2 size={12} className="animate-spin" /> : <PencilLine size={12} />}
+                        {t('bulkUpdate.apply', { count: preview?.matchingRows ?? 0 })}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,161 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Checkbox } from '@/components/ui/checkbox';
+import { Download, Loader2 } from 'lucide-react';
+import { toast } from "sonner";
+import { CSVExportOptions, TransferProgress } from '../types';
+import { SelectExportPath, ExportTableCSV, ExportQueryCSV } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { BucketTarget, UploadStatus, Bucket, emptyBucket, bucketPath, isValidBucket } from './BucketTarget';
+
+interface Props {
+    // Either a table or a query is exported
+    database?: string;
+    schema?: string;
+    table?: string;
+    query?: string;
+    onClose: () => void;
+}
+
+// Streams a table or the result of a query to a CSV file, with the
+// delimiter, quoting and NULL marker to use, reporting rows written so far
+export function CSVExportModal({ database, schema = '', table, query, onClose }: Props) {
+    const { t } = useTranslation();
+    const [options, setOptions] = useState<CSVExportOptions>({ delimiter: ',', quote: 'minimal', null: '' });
+    const [exporting, setExporting] = useState(false);
+    const [bucket, setBucket] = useState<Bucket>(emptyBucket);
+    const [rows, setRows] = useState(0);
+
+    useEffect(() => {
+        if (!exporting) return;
+        return EventsOn('export:progress', (p: TransferProgress) => setRows(p.rows));
+    }, [exporting]);
+
+    const handleExport = async () => {
+        const path = bucket.enabled ? bucketPath(bucket) : await SelectExportPath('csv');
+        if (!path) return; // Cancelled
+        setRows(0);
+        setExporting(true);
+        try {
+            if (table && database !== undefined) {
+                await ExportTableCSV(database, schema, table, path, options);
+            } else {
+                await ExportQueryCSV(query ?? '', path, options);
+            }
+            toast.success(t('dataEditor.exportSuccess'));
+            onClose();
+        } catch (err: any) {
+            toast.error(`${t('dataEditor.exportFailed')}: ${typeof err === 'string' ? err : err.message}`);
+        } finally {
+            setExporting(false);
+        }
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && !exporting && onClose()}>
+            <DialogContent className="sm:max-w-[480px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Download size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('csvExport.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60 truncate max-w-[340px]">
+                                {table ?? t('csvExport.queryResult')}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-4">
+                    <div className="grid grid-cols-2 gap-3">
+                        <div className="space-y-1.5">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('csvExport.delimiter')}</Label>
+                            <Select value={options.delimiter} onValueChange={(delimiter) => setOptions({ ...options, delimiter })}>
+                                <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                    <SelectValue />
+                                </SelectTrigger>
+                                <SelectContent>
+                                    <SelectItem value="," className="text-[11px]">{t('pasteRows.comma')}</SelectItem>
+                                    <SelectItem value=";" className="text-[11px]">{t('pasteRows.semicolon')}</SelectItem>
+                                    <SelectItem value={'\t'} className="text-[11px]">{t('pasteRows.tab')}</SelectItem>
+                                    <SelectItem value="|" className="text-[11px]">{t('csvExport.pipe')}</SelectItem>
+                                </SelectContent>
+                            </Select>
+                        </div>
+                        <div className="space-y-1.5">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('csvExport.quote')}</Label>
+                            <Select value={options.quote} onValueChange={(quote) => setOptions({ ...options, quote })}>
+                                <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                    <SelectValue />
+                                </SelectTrigger>
+                                <SelectContent>
+                                    <SelectItem value="minimal" className="text-[11px]">{t('csvExport.quoteMinimal')}</SelectItem>
+                                    <SelectItem value="all" className="text-[11px]">{t('csvExport.quoteAll')}</SelectItem>
+                                </SelectContent>
+                            </Select>
+                        </div>
+                    </div>
+                    <div className="space-y-1.5">
+                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('csvExport.null')}</Label>
+                        <Input
+                            className="h-8 text-[11px] font-mono bg-background/50"
+                            value={options.null}
+                            onChange={(e) => setOptions({ ...options, null: e.target.value })}
+                            placeholder={t('csvExport.nullEmpty')}
+                        />
+                    </div>
+                    <label className="flex items-center gap-2 text-[11px] cursor-pointer">
+                        <Checkbox
+                            checked={!options.noHeader}
+                            onCheckedChange={(checked) => setOptions({ ...options, noHeader: !checked })}
+                        />
+                        {t('csvExport.header')}
+                    </label>
+                    <BucketTarget value={bucket} onChange={setBucket} extension="csv" disabled={exporting} />
+                    {exporting && (
+                        <p className="text-[11px] font-mono text-muted-foreground">
+                            {t('csvExport.progress', { count: rows })}
+                        </p>
+                    )}
+                    <UploadStatus active={exporting} />
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} disabled={exporting} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.cancel')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={exporting || (bucket.enabled && !isValidBucket(bucket))}
+                        onClick={handleExport}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        {exporting ? <Loader2 size={12} className="animate-spin" /> : <Download size={12} />}
+                        {t('csvExport.export')}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,141 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import { Button } from '@/components/ui/button';
+import { Badge } from '@/components/ui/badge';
+import { Layers, Loader2, Play, X } from 'lucide-react';
+import { toast } from "sonner";
+import { ChangeSet, SQLPreview } from '../types';
+import { ApplyChangeSet, PreviewChangeSet, UnstageChange } from '../../wailsjs/go/main/App';
+import { SQLPreviewBlock } from './SQLPreviewBlock';
+
+interface Props {
+    database: string;
+    schema?: string;
+    table: string;
+    changeSet: ChangeSet;
+    onChange: (changeSet: ChangeSet) => void;
+    onApplied: () => void;
+    onClose: () => void;
+}
+
+const kindStyles: Record<string, string> = {
+    insert: 'border-green-500/50 text-green-600',
+    update: 'border-amber-500/50 text-amber-500',
+    delete: 'border-destructive/50 text-destructive',
+};
+
+// Reviews the staged changes of a table with the SQL they run, and applies
+// them in a single transaction
+export function ChangeSetModal({ database, schema = '', table, changeSet, onChange, onApplied, onClose }: Props) {
+    const { t } = useTranslation();
+    const [preview, setPreview] = useState<SQLPreview | null>(null);
+    const [previewError, setPreviewError] = useState<string | null>(null);
+    const [applying, setApplying] = useState(false);
+
+    useEffect(() => {
+        setPreview(null);
+        PreviewChangeSet(database, schema, table)
+            .then(result => {
+                setPreview(result);
+                setPreviewError(null);
+            })
+            .catch(err => setPreviewError(typeof err === 'string' ? err : err.message));
+    }, [changeSet]);
+
+    const handleUnstage = async (id: number) => {
+        try {
+            const next = await UnstageChange(database, schema, table, id);
+            onChange(next);
+            if (next.changes.length === 0) onClose();
+        } catch (err: any) {
+            toast.error(typeof err === 'string' ? err : err.message);
+        }
+    };
+
+    const handleApply = async () => {
+        setApplying(true);
+        try {
+            const result = await ApplyChangeSet(database, schema, table);
+            toast.success(t('changeSet.applied', { count: result.applied }));
+            onApplied();
+            onClose();
+        } catch (err: any) {
+            // Nothing was written; the changes stay staged
+            toast.error(t('changeSet.failed', { error: typeof err === 'string' ? err : err.message }));
+        } finally {
+            setApplying(false);
+        }
+    };
+
+    const describe = (values?: Record<string, any>) =>
+        Object.entries(values ?? {}).map(([name, value]) => `${name} = ${value === null ? 'NULL' : typeof value === 'object' ? JSON.stringify(value) : String(value)}`).join(', ');
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[720px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Layers size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('changeSet.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {t('changeSet.subtitle', { count: changeSet.changes.length, table })}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-3 max-h-[60vh] overflow-y-auto">
+                    <div className="space-y-1.5">
+                        {changeSet.changes.map(change => (
+                            <div key={change.id} className="flex items-center gap-2 text-[11px] font-mono">
+                                <Badge variant="outline" className={`h-4 px-1.5 text-[9px] uppercase shrink-0 ${kindStyles[change.kind]}`}>
+                                    {change.kind}
+                                </Badge>
+                                <span className="truncate flex-1" title={describe(change.values)}>
+                                    {change.kind !== 'insert' && (
+                                        <span className="text-muted-foreground">
+                                            {change.primaryKey?.map((key, i) => `${key} = ${change.primaryValues?.[i]}`).join(', ')}
+                                            {change.kind === 'update' && ': '}
+                                        </span>
+                                    )}
+                                    {describe(change.values)}
+                                </span>
+                                <Button variant="ghost" size="icon" className="h-6 w-6 shrink-0" title={t('changeSet.unstage')} onClick={() => change.id && handleUnstage(change.id)}>
+                                    <X size={12} />
+                                </Button>
+                            </div>
+                        ))}
+                    </div>
+                    <SQLPreviewBlock preview={preview} error={previewError} />
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.close')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={!preview || applying}
+                        onClick={handleApply}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        {applying ? <Loader2 size={12} className="animate-spin" /> : <Play size={12} />}
+                        {t('changeSet.apply', { count: changeSet.changes.length })}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
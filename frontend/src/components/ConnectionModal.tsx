@@ -10,6 +10,8 @@ import {
     Globe,
     KeyRound,
     Hash,
+    Timer,
+    Undo2,
     ChevronDown,
     ChevronRight,
     Lock,
@@ -101,11 +103,23 @@ export function ConnectionModal({ title, initialConfig, initialName, onSave, onC
         sshPassword: '',
         sshPrivateKey: '',
         sshPassphrase: '',
+        useVault: false,
+        vaultAddr: '',
+        vaultToken: '',
+        vaultNamespace: '',
+        vaultPath: '',
+        useAWSSecret: false,
+        awsSecretSource: 'secretsmanager',
+        awsSecretId: '',
+        awsRegion: '',
+        awsProfile: '',
     });
     const [name, setName] = useState(initialName || '');
     const [testResult, setTestResult] = useState<'success' | 'failed' | null>(null);
     const [sshOpen, setSSHOpen] = useState(initialConfig?.useSSHTunnel || false);
     const [sslOpen, setSSLOpen] = useState(initialConfig?.useSSL || false);
+    const [vaultOpen, setVaultOpen] = useState(initialConfig?.useVault || false);
+    const [awsOpen, setAWSOpen] = useState(initialConfig?.useAWSSecret || false);
 
     const handleDriverChange = (val: string) => {
         const driver = DRIVERS.find(d => d.id === val);
@@ -306,6 +320,68 @@ export function ConnectionModal({ title, initialConfig, initialName, onSave, onC
                         />
                     </div>
 
+                    <div className="grid grid-cols-2 gap-4">
+                        <div className="space-y-2">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground flex items-center gap-2 tracking-widest">
+                                <Timer size={11} className="text-primary/60" />
+                                {t('connectionModal.gridTimeout')}
+                            </Label>
+                            <Input
+                                className="h-9 text-[11px] font-mono bg-background/50"
+                                type="number"
+                                min={0}
+                                value={config.gridTimeout || ''}
+                                onChange={(e) => setConfig({ ...config, gridTimeout: Math.max(0, parseInt(e.target.value) || 0) })}
+                                placeholder={t('connectionModal.noTimeout')}
+                            />
+                        </div>
+                        <div className="space-y-2">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground flex items-center gap-2 tracking-widest">
+                                <Timer size={11} className="text-primary/60" />
+                                {t('connectionModal.queryTimeout')}
+                            </Label>
+                            <Input
+                                className="h-9 text-[11px] font-mono bg-background/50"
+                                type="number"
+                                min={0}
+                                value={config.queryTimeout || ''}
+                                onChange={(e) => setConfig({ ...config, queryTimeout: Math.max(0, parseInt(e.target.value) || 0) })}
+                                placeholder={t('connectionModal.noTimeout')}
+                            />
+                        </div>
+                    </div>
+
+                    <div className="grid grid-cols-2 gap-4">
+                        <div className="space-y-2">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground flex items-center gap-2 tracking-widest">
+                                <Undo2 size={11} className="text-primary/60" />
+                                {t('connectionModal.undoDepth')}
+                            </Label>
+                            <Input
+                                className="h-9 text-[11px] font-mono bg-background/50"
+                                type="number"
+                                min={0}
+                                value={config.undoDepth || ''}
+                                onChange={(e) => setConfig({ ...config, undoDepth: Math.max(0, parseInt(e.target.value) || 0) })}
+                                placeholder="50"
+                            />
+                        </div>
+                        <div className="space-y-2">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground flex items-center gap-2 tracking-widest">
+                                <Undo2 size={11} className="text-primary/60" />
+                                {t('connectionModal.undoMaxRows')}
+                            </Label>
+                            <Input
+                                className="h-9 text-[11px] font-mono bg-background/50"
+                                type="number"
+                                min={0}
+                                value={config.undoMaxRows || ''}
+                                onChange={(e) => setConfig({ ...config, undoMaxRows: Math.max(0, parseInt(e.target.value) || 0) })}
+                                placeholder="1000"
+                            />
+                        </div>
+                    </div>
+
                     {/* SSH Tunnel Section */}
                     <Collapsible open={sshOpen} onOpenChange={setSSHOpen}>
                         <CollapsibleTrigger className="flex items-center justify-between w-full p-3 rounded-lg bg-muted/30 hover:bg-muted/50 transition-colors border border-border/40">
@@ -480,6 +556,157 @@ export function ConnectionModal({ title, initialConfig, initialName, onSave, onC
                         </CollapsibleContent>
                     </Collapsible>
 
+                    {/* HashiCorp Vault Section */}
+                    <Collapsible open={vaultOpen} onOpenChange={setVaultOpen}>
+                        <CollapsibleTrigger className="flex items-center justify-between w-full p-3 rounded-lg bg-muted/30 hover:bg-muted/50 transition-colors border border-border/40">
+                            <div className="flex items-center gap-2">
+                                <Lock size={14} className="text-amber-500" />
+                                <span className="text-[11px] font-black uppercase tracking-wide">{t('connectionModal.vault')}</span>
+                                {config.useVault && (
+                                    <Badge variant="secondary" className="text-[8px] bg-amber-500/20 text-amber-500 h-4 px-1.5">ENABLED</Badge>
+                                )}
+                            </div>
+                            {vaultOpen ? <ChevronDown size={14} /> : <ChevronRight size={14} />}
+                        </CollapsibleTrigger>
+                        <CollapsibleContent className="mt-3 space-y-4 p-4 rounded-lg border border-border/40 bg-muted/10">
+                            <div className="flex items-center justify-between">
+                                <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">
+                                    {t('connectionModal.enableVault')}
+                                </Label>
+                                <Switch
+                                    checked={config.useVault}
+                                    onCheckedChange={(checked) => setConfig({ ...config, useVault: checked, useAWSSecret: checked ? false : config.useAWSSecret })}
+                                />
+                            </div>
+
+                            {config.useVault && (
+                                <>
+                                    <p className="text-[9px] text-muted-foreground">{t('connectionModal.vaultHint')}</p>
+                                    <div className="space-y-2">
+                                        <Label className="text-[9px] font-bold uppercase text-muted-foreground">{t('connectionModal.vaultPath')}</Label>
+                                        <Input
+                                            className="h-8 text-[10px] font-mono bg-background/50"
+                                            value={config.vaultPath}
+                                            onChange={(e) => setConfig({ ...config, vaultPath: e.target.value })}
+                                            placeholder="secret/data/db/prod"
+                                        />
+                                    </div>
+                                    <div className="grid grid-cols-2 gap-3">
+                                        <div className="space-y-2">
+                                            <Label className="text-[9px] font-bold uppercase text-muted-foreground">{t('connectionModal.vaultAddr')}</Label>
+                                            <Input
+                                                className="h-8 text-[10px] font-mono bg-background/50"
+                                                value={config.vaultAddr}
+                                                onChange={(e) => setConfig({ ...config, vaultAddr: e.target.value })}
+                                                placeholder="VAULT_ADDR"
+                                            />
+                                        </div>
+                                        <div className="space-y-2">
+                                            <Label className="text-[9px] font-bold uppercase text-muted-foreground">{t('connectionModal.vaultNamespace')}</Label>
+                                            <Input
+                                                className="h-8 text-[10px] font-mono bg-background/50"
+                                                value={config.vaultNamespace}
+                                                onChange={(e) => setConfig({ ...config, vaultNamespace: e.target.value })}
+                                                placeholder="Optional"
+                                            />
+                                        </div>
+                                    </div>
+                                    <div className="space-y-2">
+                                        <Label className="text-[9px] font-bold uppercase text-muted-foreground flex items-center gap-1">
+                                            <Key size={10} /> {t('connectionModal.vaultToken')}
+                                        </Label>
+                                        <Input
+                                            className="h-8 text-[10px] font-mono bg-background/50"
+                                            type="password"
+                                            value={config.vaultToken}
+                                            onChange={(e) => setConfig({ ...config, vaultToken: e.target.value })}
+                                            placeholder="VAULT_TOKEN or ~/.vault-token"
+                                        />
+                                    </div>
+                                </>
+                            )}
+                        </CollapsibleContent>
+                    </Collapsible>
+
+                    {/* AWS Secrets Manager / SSM Section */}
+                    <Collapsible open={awsOpen} onOpenChange={setAWSOpen}>
+                        <CollapsibleTrigger className="flex items-center justify-between w-full p-3 rounded-lg bg-muted/30 hover:bg-muted/50 transition-colors border border-border/40">
+                            <div className="flex items-center gap-2">
+                                <KeyRound size={14} className="text-orange-500" />
+                                <span className="text-[11px] font-black uppercase tracking-wide">{t('connectionModal.awsSecret')}</span>
+                                {config.useAWSSecret && (
+                                    <Badge variant="secondary" className="text-[8px] bg-orange-500/20 text-orange-500 h-4 px-1.5">ENABLED</Badge>
+                                )}
+                            </div>
+                            {awsOpen ? <ChevronDown size={14} /> : <ChevronRight size={14} />}
+                        </CollapsibleTrigger>
+                        <CollapsibleContent className="mt-3 space-y-4 p-4 rounded-lg border border-border/40 bg-muted/10">
+                            <div className="flex items-center justify-between">
+                                <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">
+                                    {t('connectionModal.enableAWSSecret')}
+                                </Label>
+                                <Switch
+                                    checked={config.useAWSSecret}
+                                    onCheckedChange={(checked) => setConfig({ ...config, useAWSSecret: checked, useVault: checked ? false : config.useVault })}
+                                />
+                            </div>
+
+                            {config.useAWSSecret && (
+                                <>
+                                    <p className="text-[9px] text-muted-foreground">{t('connectionModal.awsSecretHint')}</p>
+                                    <div className="grid grid-cols-3 gap-3">
+                                        <div className="space-y-2">
+                                            <Label className="text-[9px] font-bold uppercase text-muted-foreground">{t('connectionModal.awsSecretSource')}</Label>
+                                            <Select
+                                                value={config.awsSecretSource || 'secretsmanager'}
+                                                onValueChange={(val) => setConfig({ ...config, awsSecretSource: val })}
+                                            >
+                                                <SelectTrigger className="h-8 text-[10px] font-bold bg-background/50">
+                                                    <SelectValue />
+                                                </SelectTrigger>
+                                                <SelectContent>
+                                                    <SelectItem value="secretsmanager" className="text-[10px]">Secrets Manager</SelectItem>
+                                                    <SelectItem value="ssm" className="text-[10px]">SSM Parameter</SelectItem>
+                                                </SelectContent>
+                                            </Select>
+                                        </div>
+                                        <div className="col-span-2 space-y-2">
+                                            <Label className="text-[9px] font-bold uppercase text-muted-foreground">
+                                                {config.awsSecretSource === 'ssm' ? t('connectionModal.awsParameterName') : t('connectionModal.awsSecretId')}
+                                            </Label>
+                                            <Input
+                                                className="h-8 text-[10px] font-mono bg-background/50"
+                                                value={config.awsSecretId}
+                                                onChange={(e) => setConfig({ ...config, awsSecretId: e.target.value })}
+                                                placeholder={config.awsSecretSource === 'ssm' ? '/prod/db/password' : 'arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod-db'}
+                                            />
+                                        </div>
+                                    </div>
+                                    <div className="grid grid-cols-2 gap-3">
+                                        <div className="space-y-2">
+                                            <Label className="text-[9px] font-bold uppercase text-muted-foreground">{t('connectionModal.awsRegion')}</Label>
+                                            <Input
+                                                className="h-8 text-[10px] font-mono bg-background/50"
+                                                value={config.awsRegion}
+                                                onChange={(e) => setConfig({ ...config, awsRegion: e.target.value })}
+                                                placeholder="From the ARN or AWS config"
+                                            />
+                                        </div>
+                                        <div className="space-y-2">
+                                            <Label className="text-[9px] font-bold uppercase text-muted-foreground">{t('connectionModal.awsProfile')}</Label>
+                                            <Input
+                                                className="h-8 text-[10px] font-mono bg-background/50"
+                                                value={config.awsProfile}
+                                                onChange={(e) => setConfig({ ...config, awsProfile: e.target.value })}
+                                                placeholder="default"
+                                            />
+                                        </div>
+                                    </div>
+                                </>
+                            )}
+                        </CollapsibleContent>
+                    </Collapsible>
+
                     {testResult && (
                         <div className={cn(
                             "p-3 rounded-xl border text-[11px] font-black flex items-center justify-between animate-in fade-in slide-in-from-bottom-2 duration-300 uppercase tracking-widest",
@@ -1,7 +1,10 @@
-import React from 'react';
+import React, { useState } from 'react';
 import { ConnectionConfig, SavedConnection } from '../types';
 import { useTranslation } from 'react-i18next';
 import { LanguageSwitcher } from './LanguageSwitcher';
+import { BackupModal } from './BackupModal';
+import { ScheduledExportsModal } from './ScheduledExportsModal';
+import { LockSettingsModal } from './LockSettingsModal';
 import {
     Plus,
     Settings2,
@@ -11,7 +14,10 @@ import {
     Server,
     LayoutGrid,
     Search,
-    Hexagon
+    Hexagon,
+    DatabaseBackup,
+    CalendarClock,
+    Lock
 } from 'lucide-react';
 import { Button } from '@/components/ui/button';
 import { Input } from '@/components/ui/input';
@@ -60,6 +66,10 @@ export function ConnectionSidebar({
     onGoToHub
 }: Props) {
     const { t } = useTranslation();
+    const [showBackups, setShowBackups] = useState(false);
+    const [showSchedules, setShowSchedules] = useState(false);
+    const [showLock, setShowLock] = useState(false);
+    const activeType = savedConnections.find(conn => conn.name === activeName)?.config.type;
 
     return (
         <div className="flex flex-col h-full bg-card/30 backdrop-blur-xl border-r">
@@ -99,6 +109,17 @@ export function ConnectionSidebar({
                             </p>
                             <p className="text-[9px] text-muted-foreground font-mono opacity-60">{t('sidebar.engineAuthenticated')}</p>
                         </div>
+                        {(activeType === 'postgres' || activeType === 'mysql') && (
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                className="w-full h-7 text-[10px] font-black uppercase tracking-widest"
+                                onClick={() => setShowBackups(true)}
+                            >
+                                <DatabaseBackup size={12} className="mr-2" />
+                                {t('sidebar.backups')}
+                            </Button>
+                        )}
                         <Button
                             variant="destructive"
                             size="sm"
@@ -190,7 +211,27 @@ export function ConnectionSidebar({
                     <LayoutGrid size={14} />
                     {t('sidebar.engineHub')}
                 </Button>
+                <Button
+                    variant="ghost"
+                    className="w-full h-8 text-[10px] font-black uppercase tracking-widest gap-2 opacity-60 hover:opacity-100 hover:bg-primary/5 hover:text-primary transition-all"
+                    onClick={() => setShowSchedules(true)}
+                >
+                    <CalendarClock size={14} />
+                    {t('sidebar.scheduledExports')}
+                </Button>
+                <Button
+                    variant="ghost"
+                    className="w-full h-8 text-[10px] font-black uppercase tracking-widest gap-2 opacity-60 hover:opacity-100 hover:bg-primary/5 hover:text-primary transition-all"
+                    onClick={() => setShowLock(true)}
+                >
+                    <Lock size={14} />
+                    {t('sidebar.appLock')}
+                </Button>
             </div>
+
+            {showBackups && <BackupModal type={activeType} onClose={() => setShowBackups(false)} />}
+            {showSchedules && <ScheduledExportsModal connections={savedConnections} onClose={() => setShowSchedules(false)} />}
+            {showLock && <LockSettingsModal onClose={() => setShowLock(false)} />}
         </div>
     );
 }
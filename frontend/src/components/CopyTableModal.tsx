@@ -0,0 +1,245 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Checkbox } from '@/components/ui/checkbox';
+import { ArrowRightLeft, Loader2, RotateCw, Square } from 'lucide-react';
+import { toast } from "sonner";
+import { TableCopy, TableCopyRequest, TransferProgress } from '../types';
+import { LoadConnections, StartTableCopy, ResumeTableCopy, CancelTableCopy } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+interface Props {
+    database: string;
+    schema?: string;
+    table: string;
+    onClose: () => void;
+}
+
+// The active connection is the empty name; Select items cannot have an empty value
+const ACTIVE = '__active__';
+
+// Copies a table's rows to another connection in the background. The copy
+// keeps running when the dialog is closed.
+export function CopyTableModal({ database, schema, table, onClose }: Props) {
+    const { t } = useTranslation();
+    const [connections, setConnections] = useState<string[]>([]);
+    const [request, setRequest] = useState<TableCopyRequest>({
+        source: { connection: '', database, schema, table },
+        target: { connection: '', database, table },
+        batchSize: 1000,
+        createTable: true,
+    });
+    const [copy, setCopy] = useState<TableCopy | null>(null);
+    const [progress, setProgress] = useState<TransferProgress | null>(null);
+    const running = copy?.status === 'running';
+
+    useEffect(() => {
+        LoadConnections()
+            .then(list => setConnections(list.map(conn => conn.name)))
+            .catch(() => setConnections([]));
+    }, []);
+
+    useEffect(() => {
+        if (!copy) return;
+        const offProgress = EventsOn('copy:progress', (p: TransferProgress) => {
+            if (p.table === table) setProgress(p);
+        });
+        const offDone = EventsOn('copy:done', (done: TableCopy) => {
+            if (done.id !== copy.id) return;
+            setCopy(done);
+            if (done.status === 'done') {
+                toast.success(t('copyTable.done', { count: done.rows, table: done.request.target.table }));
+            } else if (done.status === 'failed') {
+                toast.error(t('copyTable.failed', { error: done.error }));
+            }
+        });
+        return () => {
+            offProgress();
+            offDone();
+        };
+    }, [copy?.id]);
+
+    const setTarget = (changes: Partial<TableCopyRequest['target']>) =>
+        setRequest({ ...request, target: { ...request.target, ...changes } });
+
+    const run = async (start: () => Promise<TableCopy>) => {
+        setProgress(null);
+        try {
+            setCopy(await start());
+        } catch (err: any) {
+            toast.error(t('copyTable.failed', { error: typeof err === 'string' ? err : err.message }));
+        }
+    };
+
+    const handleCancel = async () => {
+        if (!copy) return;
+        try {
+            await CancelTableCopy(copy.id);
+        } catch (err: any) {
+            toast.error(typeof err === 'string' ? err : err.message);
+        }
+    };
+
+    const rows = progress?.rows ?? copy?.rows ?? 0;
+    const total = copy?.totalRows || progress?.totalRows || 0;
+    const percent = total > 0 ? Math.min(100, Math.round(rows / total * 100)) : 0;
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[520px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <ArrowRightLeft size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('copyTable.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {database}.{table}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-4 max-h-[65vh] overflow-y-auto">
+                    <div className="space-y-1.5">
+                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('copyTable.connection')}</Label>
+                        <Select
+                            value={request.target.connection || ACTIVE}
+                            onValueChange={(value) => setTarget({ connection: value === ACTIVE ? '' : value })}
+                            disabled={!!copy}
+                        >
+                            <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent>
+                                <SelectItem value={ACTIVE} className="text-[11px]">{t('copyTable.activeConnection')}</SelectItem>
+                                {connections.map(name => (
+                                    <SelectItem key={name} value={name} className="text-[11px]">{name}</SelectItem>
+                                ))}
+                            </SelectContent>
+                        </Select>
+                    </div>
+                    <div className="grid grid-cols-2 gap-3">
+                        <div className="space-y-1.5">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('copyTable.database')}</Label>
+                            <Input
+                                className="h-8 text-[11px] font-mono bg-background/50"
+                                value={request.target.database}
+                                onChange={(e) => setTarget({ database: e.target.value })}
+                                disabled={!!copy}
+                            />
+                        </div>
+                        <div className="space-y-1.5">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('copyTable.table')}</Label>
+                            <Input
+                                className="h-8 text-[11px] font-mono bg-background/50"
+                                value={request.target.table}
+                                onChange={(e) => setTarget({ table: e.target.value })}
+                                disabled={!!copy}
+                            />
+                        </div>
+                    </div>
+                    <div className="space-y-1.5">
+                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('copyTable.batchSize')}</Label>
+                        <Input
+                            className="h-8 text-[11px] font-mono bg-background/50"
+                            type="number"
+                            min={1}
+                            value={request.batchSize || ''}
+                            onChange={(e) => setRequest({ ...request, batchSize: Math.max(0, parseInt(e.target.value) || 0) })}
+                            placeholder="1000"
+                            disabled={!!copy}
+                        />
+                    </div>
+                    <label className="flex items-center gap-2 text-[11px] cursor-pointer">
+                        <Checkbox
+                            checked={!!request.createTable}
+                            onCheckedChange={(checked) => setRequest({ ...request, createTable: !!checked })}
+                            disabled={!!copy}
+                        />
+                        {t('copyTable.createTable')}
+                    </label>
+                    <label className="flex items-center gap-2 text-[11px] cursor-pointer">
+                        <Checkbox
+                            checked={!!request.truncate}
+                            onCheckedChange={(checked) => setRequest({ ...request, truncate: !!checked })}
+                            disabled={!!copy}
+                        />
+                        {t('copyTable.truncate')}
+                    </label>
+
+                    {copy && (
+                        <div className="space-y-1.5 rounded-md border p-3">
+                            <div className="flex justify-between text-[11px] font-mono">
+                                <span>{t(`copyTable.status.${copy.status}`)}</span>
+                                <span className="text-muted-foreground">
+                                    {total > 0 ? t('copyTable.rowsOf', { count: rows, total }) : t('copyTable.rows', { count: rows })}
+                                </span>
+                            </div>
+                            {total > 0 && (
+                                <div className="h-1.5 rounded-full bg-muted overflow-hidden">
+                                    <div className="h-full bg-primary transition-all" style={{ width: `${percent}%` }} />
+                                </div>
+                            )}
+                            {copy.tableCreated && (
+                                <p className="text-[10px] text-muted-foreground">{t('copyTable.tableCreated')}</p>
+                            )}
+                            {copy.error && (
+                                <p className="text-[10px] text-destructive font-mono break-all">{copy.error}</p>
+                            )}
+                            {running && (
+                                <p className="text-[10px] text-muted-foreground">{t('copyTable.background')}</p>
+                            )}
+                        </div>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.close')}
+                    </Button>
+                    {running && (
+                        <Button type="button" variant="destructive" onClick={handleCancel} className="text-[10px] font-black uppercase tracking-widest gap-2">
+                            <Square size={12} /> {t('common.cancel')}
+                        </Button>
+                    )}
+                    {copy && copy.resumable && (copy.status === 'failed' || copy.status === 'cancelled') && (
+                        <Button type="button" onClick={() => run(() => ResumeTableCopy(copy.id))} className="text-[10px] font-black uppercase tracking-widest gap-2">
+                            <RotateCw size={12} /> {t('copyTable.resume')}
+                        </Button>
+                    )}
+                    {!copy && (
+                        <Button
+                            type="button"
+                            disabled={!request.target.table}
+                            onClick={() => run(() => StartTableCopy(request))}
+                            className="text-[10px] font-black uppercase tracking-widest gap-2"
+                        >
+                            <ArrowRightLeft size={12} /> {t('copyTable.copy')}
+                        </Button>
+                    )}
+                    {running && !progress && <Loader2 size={14} className="animate-spin self-center text-muted-foreground" />}
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -1,7 +1,11 @@
 import React, { useState, useEffect, useCallback } from 'react';
-import { ColumnInfo, TableDataResponse } from '../types';
+import { BulkPreview, ChangeSet, ColumnInfo, CopyFormat, FilterCondition, RowLocator, SortColumn, SQLPreview, StagedChange, TableDataResponse } from '../types';
 import { useTranslation } from 'react-i18next';
-import { GetTableData, InsertRow, UpdateRow, DeleteRow, SelectExportPath, ExportTable } from '../../wailsjs/go/main/App';
+import {
+    GetTableData, InsertRow, UpdateRow, DeleteRows, UpdateRowByLocator, DeleteRowByLocator, SelectExportPath, ExportTable,
+    PreviewTruncateTable, PreviewDropTable, PreviewDeleteRows, PreviewDeleteRowByLocator, PreviewBulkDelete, BulkDeleteRows,
+    DuplicateRow, DuplicateRowValues, StageChange, GetChangeSet, DiscardChangeSet
+} from '../../wailsjs/go/main/App';
 import {
     Plus,
     Trash2,
@@ -26,13 +30,19 @@ import {
     ArrowDown,
     ArrowUpDown,
     FilterX,
-    PieChart
+    PieChart,
+    PencilLine,
+    CopyPlus,
+    ClipboardPaste,
+    Layers,
+    Undo2,
+    FileCode,
+    ArrowRightLeft
 } from 'lucide-react';
 import { Button } from '@/components/ui/button';
 import { Input } from "@/components/ui/input";
-import { FilterInput } from "./FilterInput";
+import { FilterInput, splitJSONPath } from "./FilterInput";
 import { FilterHistory, addToHistory } from "./FilterHistory";
-import { RawFilterEditor } from "./RawFilterEditor";
 import { DataVisualizer } from './DataVisualizer';
 import { Label } from '@/components/ui/label';
 import {
@@ -72,6 +82,19 @@ import {
 } from "@/components/ui/context-menu";
 import { useDatabase } from '../hooks/useDatabase';
 import { ModifyTableModal } from './ModifyTableModal';
+import { SQLPreviewBlock } from './SQLPreviewBlock';
+import { BulkUpdateModal } from './BulkUpdateModal';
+import { GenerateSQLModal } from './GenerateSQLModal';
+import { PasteRowsModal } from './PasteRowsModal';
+import { ChangeSetModal } from './ChangeSetModal';
+import { UndoModal } from './UndoModal';
+import { CSVExportModal } from './CSVExportModal';
+import { DumpModal } from './DumpModal';
+import { CopyTableModal } from './CopyTableModal';
+import { copyRows } from '@/lib/copy';
+import { JSONCellEditor } from './JSONCellEditor';
+import { BinaryCellViewer } from './BinaryCellViewer';
+import { ArrayCellEditor } from './ArrayCellEditor';
 import {
     AlertDialog,
     AlertDialogAction,
@@ -85,11 +108,12 @@ import {
 
 interface Props {
     database: string;
+    schema?: string; // PostgreSQL schema of the table; the connection's when empty
     table: string;
     onClose: () => void;
 }
 
-export function DataEditor({ database, table, onClose }: Props) {
+export function DataEditor({ database, schema = '', table, onClose }: Props) {
     const { t } = useTranslation();
     const { truncateTable, dropTable, alterTable } = useDatabase();
     const [data, setData] = useState<TableDataResponse | null>(null);
@@ -102,68 +126,104 @@ export function DataEditor({ database, table, onClose }: Props) {
     const [selectedRows, setSelectedRows] = useState<Set<number>>(new Set());
     const [showAddRow, setShowAddRow] = useState(false);
     const [newRowData, setNewRowData] = useState<Record<string, string>>({});
+    // Row the new entry form duplicates, with the values it was filled with
+    const [duplicateOf, setDuplicateOf] = useState<{ key: any[]; values: Record<string, string> } | null>(null);
 
     // UI State
-    const [confirmAction, setConfirmAction] = useState<{ type: 'truncate' | 'drop' } | null>(null);
+    const [confirmAction, setConfirmAction] = useState<{ type: 'truncate' | 'drop' | 'delete' | 'deleteMatching' } | null>(null);
+    // SQL the confirmed action will run
+    const [confirmPreview, setConfirmPreview] = useState<SQLPreview | null>(null);
+    // Row count and confirmation token of a delete by filter
+    const [matchingDelete, setMatchingDelete] = useState<BulkPreview | null>(null);
+    const [confirmPreviewError, setConfirmPreviewError] = useState<string | null>(null);
     const [showModifyModal, setShowModifyModal] = useState(false);
+    const [showBulkUpdate, setShowBulkUpdate] = useState(false);
+    const [showPaste, setShowPaste] = useState(false);
+    // In staged mode edits are queued in a change set on the backend and
+    // applied together
+    const [stagedMode, setStagedMode] = useState(false);
+    const [changeSet, setChangeSet] = useState<ChangeSet | null>(null);
+    const [showChangeSet, setShowChangeSet] = useState(false);
+    const [showUndo, setShowUndo] = useState(false);
+    const [showCSVExport, setShowCSVExport] = useState(false);
+    const [showDump, setShowDump] = useState(false);
+    const [showCopyTable, setShowCopyTable] = useState(false);
+    // Rows the SQL statement generator is open for
+    const [statementRows, setStatementRows] = useState<any[][] | null>(null);
+    // json/jsonb cell open in the JSON editor
+    const [jsonCell, setJsonCell] = useState<{ row: number; col: number } | null>(null);
+    // bytea/blob cell open in the binary viewer
+    const [binaryCell, setBinaryCell] = useState<{ row: number; col: number } | null>(null);
+    // PostgreSQL array cell open in the array editor
+    const [arrayCell, setArrayCell] = useState<{ row: number; col: number } | null>(null);
 
     // Sorting State
-    const [sortColumn, setSortColumn] = useState<string>('');
-    const [sortDirection, setSortDirection] = useState<'ASC' | 'DESC'>('ASC');
+    const [sortOrder, setSortOrder] = useState<SortColumn[]>([]);
 
     // Filtering State
-    const [activeFilter, setActiveFilter] = useState('');
+    const [activeFilter, setActiveFilter] = useState<FilterCondition[]>([]);
+    const [activeSearch, setActiveSearch] = useState('');
     const [columnFilters, setColumnFilters] = useState<Record<string, string>>({});
     const [globalSearch, setGlobalSearch] = useState('');
     const [showChart, setShowChart] = useState(false);
 
-    // Parse filter value to SQL condition
-    const getSqlCondition = (colName: string, value: string): string | null => {
+    // Parse filter input to a condition
+    const getCondition = (colName: string, value: string): FilterCondition | null => {
         if (!value) return null;
 
+        // ".key.path <filter>" filters on a value inside a JSON column
+        if (isJSONColumn(colName)) {
+            const { path, rest } = splitJSONPath(value);
+            if (path && path.length > 0) {
+                const condition = rest.trim() ? getCondition(colName, rest) : { column: colName, operator: 'IS NOT NULL' as const };
+                return condition && { ...condition, path, value: jsonPathValue(condition) };
+            }
+        }
+
         value = value.trim();
-        if (value.startsWith('>=')) return `\`${colName}\` >= '${value.substring(2).trim()}'`;
-        if (value.startsWith('<=')) return `\`${colName}\` <= '${value.substring(2).trim()}'`;
-        if (value.startsWith('>')) return `\`${colName}\` > '${value.substring(1).trim()}'`;
-        if (value.startsWith('<')) return `\`${colName}\` < '${value.substring(1).trim()}'`;
-        if (value.startsWith('!=') || value.startsWith('<>')) return `\`${colName}\` != '${value.substring(2).trim()}'`;
-        if (value.startsWith('=')) return `\`${colName}\` = '${value.substring(1).trim()}'`;
+        if (value.startsWith('!~')) return { column: colName, operator: 'NOT REGEXP', value: value.substring(2).trim() };
+        if (value.startsWith('~')) return { column: colName, operator: 'REGEXP', value: value.substring(1).trim() };
+        if (value.startsWith('IN:')) return { column: colName, operator: 'IN', value: value.substring(3).split(',').map(v => v.trim()) };
+        if (value.startsWith('LIKE')) return { column: colName, operator: 'CONTAINS', value: value.substring(4).trim() };
+        if (value.startsWith('START')) return { column: colName, operator: 'ILIKE', value: `${value.substring(5).trim()}%` };
+        for (const op of ['>=', '<=', '!=', '<>', '>', '<', '='] as const) {
+            if (value.startsWith(op)) return { column: colName, operator: op, value: value.substring(op.length).trim() };
+        }
 
-        if (value.toLowerCase() === 'null') return `\`${colName}\` IS NULL`;
-        if (value.toLowerCase() === '!null') return `\`${colName}\` IS NOT NULL`;
+        if (value.toLowerCase() === 'null') return { column: colName, operator: 'IS NULL' };
+        if (value.toLowerCase() === '!null') return { column: colName, operator: 'IS NOT NULL' };
 
-        // Default to LIKE for strings, = for numbers if simple value
-        // But for simplicity/flexibility, LIKE is safer for text search, = for numbers
-        // Let's guess: if it looks like a number, use =
-        if (/^-?\d+(\.\d+)?$/.test(value)) return `\`${colName}\` = ${value}`;
+        // low..high is an inclusive range
+        const range = value.match(/^(.+?)\.\.(.+)$/);
+        if (range) return { column: colName, operator: 'BETWEEN', value: [range[1].trim(), range[2].trim()] };
 
-        // Otherwise partial match
-        return `\`${colName}\` LIKE '%${value.replace(/'/g, "''")}%'`;
+        // Numbers match exactly, anything else is a case-insensitive partial match
+        if (/^-?\d+(\.\d+)?$/.test(value)) return { column: colName, operator: '=', value };
+        return { column: colName, operator: 'CONTAINS', value };
     };
 
-    const applyFilters = (colFilters: Record<string, string> = columnFilters, globalVal: string = globalSearch) => {
-        const conditions = Object.entries(colFilters)
-            .map(([col, val]) => getSqlCondition(col, val))
-            .filter(Boolean);
-
-        const colSql = conditions.length > 0 ? `(${conditions.join(' AND ')})` : '';
-
-        // Global Search Logic
-        let globalSql = '';
-        if (globalVal && data?.columns) {
-            const orConditions = data.columns.map(col => {
-                // Heuristic: only search text/numeric, ignore blob etc if needed. 
-                // For now, simple LIKE on mostly everything.
-                return `\`${col.name}\` LIKE '%${globalVal.replace(/'/g, "''")}%'`;
-            });
-            globalSql = `(${orConditions.join(' OR ')})`;
-        }
+    const isJSONColumn = (colName: string) => {
+        const type = data?.columns.find(c => c.name === colName)?.type.toLowerCase();
+        return type === 'json' || type === 'jsonb';
+    };
 
-        const parts = [globalSql, colSql].filter(Boolean);
-        const finalSql = parts.length > 0 ? parts.join(' AND ') : '';
+    // Numbers inside JSON compare numerically; the backend does so for number values
+    const jsonPathValue = (condition: FilterCondition) => {
+        const toNumber = (v: any) => typeof v === 'string' && /^-?\d+(\.\d+)?$/.test(v) ? Number(v) : v;
+        if (Array.isArray(condition.value) && condition.operator?.includes('BETWEEN')) return condition.value.map(toNumber);
+        if (['=', '!=', '<>', '<', '<=', '>', '>='].includes(condition.operator || '')) return toNumber(condition.value);
+        return condition.value;
+    };
 
-        setActiveFilter(finalSql);
-        return finalSql; // Return the computed filter string
+    const applyFilters = (colFilters: Record<string, string> = columnFilters, globalVal: string = globalSearch) => {
+        const conditions: FilterCondition[] = Object.entries(colFilters)
+            .map(([col, val]) => getCondition(col, val))
+            .filter((c): c is FilterCondition => c !== null);
+
+        // Global search matches any column, see TableDataRequest.search
+        setActiveSearch(globalVal.trim());
+        setActiveFilter(conditions);
+        return conditions;
     };
 
     const handleGlobalSearchKeyDown = (e: React.KeyboardEvent) => {
@@ -171,7 +231,7 @@ export function DataEditor({ database, table, onClose }: Props) {
             const currentFilter = applyFilters(columnFilters, globalSearch);
             setPage(1);
             loadData();
-            if (currentFilter) addToHistory(table, currentFilter);
+            if (currentFilter.length > 0) addToHistory(table, currentFilter);
         }
     };
 
@@ -187,26 +247,29 @@ export function DataEditor({ database, table, onClose }: Props) {
             const currentFilter = applyFilters(columnFilters, globalSearch);
             setPage(1);
             loadData();
-            if (currentFilter) addToHistory(table, currentFilter);
+            if (currentFilter.length > 0) addToHistory(table, currentFilter);
         }
     };
 
-    const handleSort = (column: string) => {
-        if (sortColumn === column) {
-            setSortDirection(prev => prev === 'ASC' ? 'DESC' : 'ASC');
-        } else {
-            setSortColumn(column);
-            setSortDirection('ASC');
-        }
-        // Page reset is not strictly necessary but often good practice on sort change
-        // But if we want to keep context, maybe keep page. DataGrip keeps global pos? 
-        // Let's keep page for now, but usually sorting resets view.
+    // Click sorts by a column, Shift+Click adds it as the next sort level
+    const handleSort = (column: string, append: boolean) => {
+        setSortOrder(prev => {
+            const existing = prev.find(s => s.column === column);
+            const toggled: SortColumn = {
+                column,
+                direction: existing?.direction === 'ASC' ? 'DESC' : 'ASC',
+            };
+            if (!append) return [toggled];
+            if (existing) return prev.map(s => s.column === column ? toggled : s);
+            return [...prev, toggled];
+        });
     };
 
     const clearAllFilters = () => {
         setColumnFilters({});
         setGlobalSearch('');
-        setActiveFilter('');
+        setActiveFilter([]);
+        setActiveSearch('');
         setPage(1);
     };
 
@@ -221,7 +284,7 @@ export function DataEditor({ database, table, onClose }: Props) {
         const currentFilter = applyFilters(newFilters, globalSearch);
         setPage(1);
         loadData();
-        if (currentFilter) addToHistory(table, currentFilter);
+        if (currentFilter.length > 0) addToHistory(table, currentFilter);
         toast.success(`Filtered by ${colName} = ${cleanVal}`);
     };
 
@@ -232,7 +295,7 @@ export function DataEditor({ database, table, onClose }: Props) {
         const currentFilter = applyFilters(newFilters, globalSearch);
         setPage(1);
         loadData();
-        if (currentFilter) addToHistory(table, currentFilter);
+        if (currentFilter.length > 0) addToHistory(table, currentFilter);
         toast.success(`Excluded ${colName} = ${valStr}`);
     };
 
@@ -242,12 +305,13 @@ export function DataEditor({ database, table, onClose }: Props) {
         try {
             const result = await GetTableData({
                 database,
+                schema,
                 table,
                 page,
                 pageSize,
-                orderBy: sortColumn,
-                orderDir: sortDirection,
-                filters: activeFilter // This is mainly constructed in applyFilters now
+                orderBy: sortOrder,
+                filters: activeFilter,
+                search: activeSearch || undefined
             });
             setData(result);
         } catch (err: any) {
@@ -256,7 +320,7 @@ export function DataEditor({ database, table, onClose }: Props) {
         } finally {
             setLoading(false);
         }
-    }, [database, table, page, pageSize, activeFilter, sortColumn, sortDirection]);
+    }, [database, schema, table, page, pageSize, activeFilter, activeSearch, sortOrder]);
 
     useEffect(() => {
         loadData();
@@ -284,25 +348,106 @@ export function DataEditor({ database, table, onClose }: Props) {
         loadData();
     }, [loadData]);
 
+    // Changes staged before are kept by the backend until applied or discarded
+    useEffect(() => {
+        GetChangeSet(database, schema, table)
+            .then(set => {
+                setChangeSet(set);
+                setStagedMode(set.changes.length > 0);
+            })
+            .catch(() => setChangeSet(null));
+    }, [database, schema, table]);
+
+    // Staging needs the primary key to find the rows again
+    const staging = stagedMode && !!data && data.primaryKey.length > 0;
+
+    const stage = async (change: StagedChange) => {
+        setChangeSet(await StageChange(database, schema, table, change));
+    };
+
+    const discardChanges = async () => {
+        await DiscardChangeSet(database, schema, table);
+        setChangeSet(null);
+    };
+
+    // Staged updates and deletes by the key values of their row
+    const stagedRows = new Map<string, StagedChange>();
+    for (const change of changeSet?.changes ?? []) {
+        if (change.kind !== 'insert') stagedRows.set(JSON.stringify(change.primaryValues), change);
+    }
+    const stagedChange = (row: any[]) =>
+        stagedRows.size > 0 && data && data.primaryKey.length > 0 ? stagedRows.get(JSON.stringify(keyValues(row))) : undefined;
+
     const handleCellDoubleClick = (rowIndex: number, colIndex: number, value: any) => {
+        // JSON documents get their own editor; it needs the key to load and save the cell
+        const type = data?.columns[colIndex]?.type.toLowerCase();
+        if ((type === 'json' || type === 'jsonb') && data && data.primaryKey.length > 0) {
+            setJsonCell({ row: rowIndex, col: colIndex });
+            return;
+        }
+        if (data?.columns[colIndex]?.elementType) {
+            setArrayCell({ row: rowIndex, col: colIndex });
+            return;
+        }
+        // Binary content is shown as hex and replaced by uploading a file
+        if (type && isBinaryType(type) && data && data.primaryKey.length > 0) {
+            setBinaryCell({ row: rowIndex, col: colIndex });
+            return;
+        }
         setEditingCell({ row: rowIndex, col: colIndex });
         setEditValue(value === null ? '' : String(value));
     };
 
+    // Primary key values of a row, in the order of data.primaryKey
+    const keyValues = (row: any[]) => {
+        if (!data) return [];
+        return data.primaryKey.map(key => row[data.columns.findIndex(c => c.name === key)]);
+    };
+
+    // Identifies a row of a table without a primary key
+    const rowLocator = (rowIndex: number): RowLocator => {
+        if (!data) return {};
+        if (data.rowMatch === 'ctid') return { ctid: data.rowLocators?.[rowIndex] };
+        return { values: Object.fromEntries(data.columns.map((c, i) => [c.name, data.rows[rowIndex][i]])) };
+    };
+
     const handleCellSave = async () => {
         if (!editingCell || !data) return;
 
         const column = data.columns[editingCell.col];
         const row = data.rows[editingCell.row];
-        const pkIndex = data.columns.findIndex(c => c.name === data.primaryKey);
-        const pkValue = row[pkIndex];
 
         try {
-            await UpdateRow(database, table, data.primaryKey, pkValue, {
-                [column.name]: editValue === '' ? null : editValue
-            });
+            const changes = { [column.name]: editValue === '' ? null : editValue };
+            if (staging) {
+                const staged = stagedChange(row)?.values;
+                const current = staged && column.name in staged ? staged[column.name] : row[editingCell.col];
+                if (editValue !== (current === null ? '' : String(current))) {
+                    await stage({ kind: 'update', primaryKey: data.primaryKey, primaryValues: keyValues(row), values: changes });
+                }
+                setEditingCell(null);
+                return;
+            }
+            if (data.primaryKey.length > 0) {
+                const result = await UpdateRow(database, schema, table, data.primaryKey, keyValues(row), changes);
+                // Show the stored row, with defaults and trigger changes, without a refetch.
+                // Formatted display values are rendered by the backend, so pages
+                // that have them are reloaded instead.
+                const stored = result.returned?.rows?.[0];
+                const formatted = Object.keys(data.display || {}).length > 0;
+                if (!formatted && result.returned?.rowCount === 1 && stored && stored.length === data.columns.length) {
+                    const rows = [...data.rows];
+                    rows[editingCell.row] = stored;
+                    setData({ ...data, rows });
+                } else {
+                    await loadData();
+                }
+            } else {
+                // A ctid changes with every update, so the page is reloaded
+                await UpdateRowByLocator(database, schema, table, rowLocator(editingCell.row), changes);
+                await loadData();
+            }
             toast.success("Row updated successfully");
-            await loadData();
             setEditingCell(null);
         } catch (err: any) {
             toast.error(`Update failed: ${err.message}`);
@@ -310,6 +455,24 @@ export function DataEditor({ database, table, onClose }: Props) {
         }
     };
 
+    // Writes a list from the array editor; the page is reloaded to show the
+    // stored array as a list again
+    const saveArrayCell = async (rowIndex: number, colIndex: number, list: any[] | null) => {
+        if (!data) return;
+        const changes = { [data.columns[colIndex].name]: list };
+        if (staging) {
+            await stage({ kind: 'update', primaryKey: data.primaryKey, primaryValues: keyValues(data.rows[rowIndex]), values: changes });
+            return;
+        }
+        if (data.primaryKey.length > 0) {
+            await UpdateRow(database, schema, table, data.primaryKey, keyValues(data.rows[rowIndex]), changes);
+        } else {
+            await UpdateRowByLocator(database, schema, table, rowLocator(rowIndex), changes);
+        }
+        toast.success(t('dataEditor.rowUpdated'));
+        await loadData();
+    };
+
     const handleCellKeyDown = (e: React.KeyboardEvent) => {
         if (e.key === 'Enter') {
             handleCellSave();
@@ -322,12 +485,22 @@ export function DataEditor({ database, table, onClose }: Props) {
         if (!data || selectedRows.size === 0) return;
 
         const count = selectedRows.size;
-        const pkIndex = data.columns.findIndex(c => c.name === data.primaryKey);
 
         try {
-            const pkValues = Array.from(selectedRows).map(idx => data.rows[idx][pkIndex]);
-            for (const pkValue of pkValues) {
-                await DeleteRow(database, table, data.primaryKey, pkValue);
+            if (staging) {
+                for (const idx of selectedRows) {
+                    await stage({ kind: 'delete', primaryKey: data.primaryKey, primaryValues: keyValues(data.rows[idx]) });
+                }
+                setSelectedRows(new Set());
+                return;
+            }
+            if (data.primaryKey.length > 0) {
+                const pkValues = Array.from(selectedRows).map(idx => keyValues(data.rows[idx]));
+                await DeleteRows(database, schema, table, data.primaryKey, pkValues);
+            } else {
+                for (const idx of selectedRows) {
+                    await DeleteRowByLocator(database, schema, table, rowLocator(idx));
+                }
             }
             setSelectedRows(new Set());
             toast.success(`${count} row(s) deleted`);
@@ -337,8 +510,86 @@ export function DataEditor({ database, table, onClose }: Props) {
         }
     };
 
+    // Closing the form drops the duplicated values so that they aren't
+    // inserted as a new row later
+    useEffect(() => {
+        if (!showAddRow && duplicateOf) {
+            setDuplicateOf(null);
+            setNewRowData({});
+        }
+    }, [showAddRow]);
+
+    // Duplicates the selected row right away, or fills the new entry form with
+    // its values to be changed first
+    const handleDuplicate = async (edit: boolean) => {
+        if (!data || selectedRows.size !== 1) return;
+        const key = keyValues(data.rows[Array.from(selectedRows)[0]]);
+        // A natural key has no default to make a new one, so it is asked for
+        const naturalKey = data.primaryKey.some(name => {
+            const col = data.columns.find(c => c.name === name);
+            return col && !col.default && !col.identity && !col.extra?.toLowerCase().includes('auto_increment');
+        });
+        if (naturalKey) edit = true;
+        try {
+            if (!edit) {
+                await DuplicateRow(database, schema, table, data.primaryKey, key, {});
+                toast.success(t('dataEditor.rowDuplicated'));
+                await loadData();
+                return;
+            }
+            const copied = await DuplicateRowValues(database, schema, table, data.primaryKey, key);
+            const values: Record<string, string> = {};
+            for (const col of data.columns) {
+                const value = copied[col.name];
+                // Binary content is copied by the server without being shown
+                if (value === undefined || value === null || isBinaryType(col.type.toLowerCase())) continue;
+                values[col.name] = typeof value === 'object' ? JSON.stringify(value) : String(value);
+            }
+            setNewRowData(values);
+            setDuplicateOf({ key, values });
+            setShowAddRow(true);
+        } catch (err: any) {
+            toast.error(`${t('dataEditor.duplicateFailed')}: ${typeof err === 'string' ? err : err.message}`);
+        }
+    };
+
+    // Inserts the duplicate with the fields changed in the form; the server
+    // copies the others from the original row
+    const handleAddDuplicate = async (keepOpen: boolean) => {
+        if (!data || !duplicateOf) return;
+
+        const overrides: Record<string, any> = {};
+        for (const col of data.columns) {
+            const value = newRowData[col.name] ?? '';
+            if (value === (duplicateOf.values[col.name] ?? '')) continue;
+            if (value === '') {
+                overrides[col.name] = null;
+            } else if (col.elementType && value.startsWith('[')) {
+                try {
+                    overrides[col.name] = JSON.parse(value);
+                } catch {
+                    overrides[col.name] = value;
+                }
+            } else {
+                overrides[col.name] = value;
+            }
+        }
+
+        try {
+            await DuplicateRow(database, schema, table, data.primaryKey, duplicateOf.key, overrides);
+            if (!keepOpen) {
+                setShowAddRow(false);
+            }
+            toast.success(t('dataEditor.rowDuplicated'));
+            await loadData();
+        } catch (err: any) {
+            toast.error(`${t('dataEditor.duplicateFailed')}: ${typeof err === 'string' ? err : err.message}`);
+        }
+    };
+
     const handleAddRow = async (keepOpen = false) => {
         if (!data) return;
+        if (duplicateOf) return handleAddDuplicate(keepOpen);
 
         const rowData: Record<string, any> = {};
         for (const col of data.columns) {
@@ -348,7 +599,15 @@ export function DataEditor({ database, table, onClose }: Props) {
         }
 
         try {
-            await InsertRow(database, table, rowData);
+            if (staging) {
+                await stage({ kind: 'insert', values: rowData });
+                setNewRowData({});
+                if (!keepOpen) {
+                    setShowAddRow(false);
+                }
+                return;
+            }
+            await InsertRow(database, schema, table, rowData);
             setNewRowData({});
             if (!keepOpen) {
                 setShowAddRow(false);
@@ -374,8 +633,64 @@ export function DataEditor({ database, table, onClose }: Props) {
 
 
 
+    useEffect(() => {
+        setConfirmPreview(null);
+        setConfirmPreviewError(null);
+        setMatchingDelete(null);
+        if (!confirmAction || !data) return;
+
+        const type = confirmAction.type;
+        const current = data;
+        const load = async (): Promise<SQLPreview> => {
+            switch (type) {
+                case 'truncate':
+                    return PreviewTruncateTable(database, schema, table);
+                case 'drop':
+                    return PreviewDropTable(database, schema, table);
+                case 'delete': {
+                    if (current.primaryKey.length > 0) {
+                        return PreviewDeleteRows(database, schema, table, current.primaryKey, Array.from(selectedRows).map(idx => keyValues(current.rows[idx])));
+                    }
+                    // Rows without a key are deleted one statement at a time
+                    const previews = await Promise.all(Array.from(selectedRows).map(idx => PreviewDeleteRowByLocator(database, schema, table, rowLocator(idx))));
+                    return { statements: previews.flatMap(p => p.statements) };
+                }
+                case 'deleteMatching': {
+                    const bulk = await PreviewBulkDelete(database, schema, table, { filters: activeFilter, search: activeSearch || undefined, token: '' });
+                    if (active) setMatchingDelete(bulk);
+                    return bulk.preview;
+                }
+            }
+        };
+        let active = true;
+        load()
+            .then(preview => active && setConfirmPreview(preview))
+            .catch(err => active && setConfirmPreviewError(typeof err === 'string' ? err : err.message));
+        return () => { active = false; };
+    }, [confirmAction]);
+
+    const handleConfirmedDelete = async () => {
+        await handleDeleteSelected();
+        setConfirmAction(null);
+    };
+
+    // Deletes the rows the preview counted; the token makes the server refuse
+    // if they changed since
+    const handleMatchingDelete = async () => {
+        if (!matchingDelete?.token) return;
+        try {
+            const result = await BulkDeleteRows(database, schema, table, { filters: activeFilter, search: activeSearch || undefined, token: matchingDelete.token });
+            toast.success(t('dataEditor.rowsDeleted', { count: result.rowsAffected }));
+            setSelectedRows(new Set());
+            await loadData();
+        } catch (err: any) {
+            toast.error(`${t('dataEditor.deleteFailed')}: ${typeof err === 'string' ? err : err.message}`);
+        }
+        setConfirmAction(null);
+    };
+
     const handleTruncate = async () => {
-        const success = await truncateTable(database, table);
+        const success = await truncateTable(database, schema, table);
         if (success) {
             loadData();
             setConfirmAction(null);
@@ -383,13 +698,16 @@ export function DataEditor({ database, table, onClose }: Props) {
     };
 
     const handleDrop = async () => {
-        const success = await dropTable(database, table);
+        const success = await dropTable(database, schema, table);
         if (success) {
             setConfirmAction(null);
             onClose();
         }
     };
 
+    const isBinaryType = (type: string) =>
+        type === 'bytea' || type.endsWith('blob') || /^(var)?binary\b/.test(type);
+
     const formatValue = (value: any): string => {
         if (value === null) return 'NULL';
         if (typeof value === 'boolean') return value ? 'true' : 'false';
@@ -398,44 +716,21 @@ export function DataEditor({ database, table, onClose }: Props) {
     };
 
     // Copy helpers
-    const copyAsCSV = () => {
-        const columns = data?.columns.map(c => c.name) || [];
-        const rows = getSelectedRowsData();
-        const header = columns.join(',');
-        const csvData = rows.map(row =>
-            row.map(cell => {
-                if (cell === null) return '';
-                const str = String(cell);
-                return str.includes(',') || str.includes('"') || str.includes('\n')
-                    ? `"${str.replace(/"/g, '""')}"`
-                    : str;
-            }).join(',')
-        ).join('\n');
-        navigator.clipboard.writeText(`${header}\n${csvData}`);
-        toast.success(t('resultsTable.copiedToClipboard'));
-    };
-
-    const copyAsJSON = () => {
-        const columns = data?.columns.map(c => c.name) || [];
-        const rows = getSelectedRowsData();
-        const jsonData = rows.map(row => {
-            const obj: Record<string, any> = {};
-            columns.forEach((col, i) => {
-                obj[col] = row[i];
-            });
-            return obj;
-        });
-        navigator.clipboard.writeText(JSON.stringify(jsonData, null, 2));
-        toast.success(t('resultsTable.copiedToClipboard'));
+    // Copies the selected rows, rendered by the backend from the table's column types
+    const copyAs = (format: CopyFormat) => {
+        if (!data) return;
+        copyRows(format, data.columns, getSelectedRowsData(), table)
+            .then(() => toast.success(t('resultsTable.copiedToClipboard')))
+            .catch((err: any) => toast.error(typeof err === 'string' ? err : err.message));
     };
 
-    const handleExport = async (format: 'xlsx' | 'csv' | 'json') => {
+    const handleExport = async (format: 'xlsx' | 'json') => {
         try {
             const path = await SelectExportPath(format);
             if (!path) return; // Cancelled
             // Need to pass translated strings to toast promise if possible, or handle individually
             // For now, simpler messages:
-            toast.promise(ExportTable(database, table, format, path), {
+            toast.promise(ExportTable(database, schema, table, format, path), {
                 loading: t('dataEditor.exporting'),
                 success: t('dataEditor.exportSuccess'),
                 error: (err) => `${t('dataEditor.exportFailed')}: ${err}`
@@ -445,20 +740,8 @@ export function DataEditor({ database, table, onClose }: Props) {
         }
     };
 
-    const copyAsSQLInsert = () => {
-        const columns = data?.columns.map(c => c.name) || [];
-        const rows = getSelectedRowsData();
-        const inserts = rows.map(row => {
-            const values = row.map(cell => {
-                if (cell === null) return 'NULL';
-                if (typeof cell === 'number') return String(cell);
-                return `'${String(cell).replace(/'/g, "''")}'`;
-            }).join(', ');
-            return `INSERT INTO ${table} (${columns.join(', ')}) VALUES (${values});`;
-        }).join('\n');
-        navigator.clipboard.writeText(inserts);
-        toast.success(t('resultsTable.copiedToClipboard'));
-    };
+    // Opens the statement generator on the selected rows
+    const openStatementGenerator = () => setStatementRows(getSelectedRowsData());
 
     const getSelectedRowsData = (): any[][] => {
         if (!data) return [];
@@ -488,12 +771,13 @@ export function DataEditor({ database, table, onClose }: Props) {
                     <div>
                         <h3 className="text-sm font-black tracking-tight flex items-center gap-2 uppercase">
                             {table}
-                            {activeFilter && <Badge variant="secondary" className="h-4 text-[8px] bg-primary/20 text-primary animate-pulse">{t('dataEditor.filtered')}</Badge>}
+                            {(activeFilter.length > 0 || activeSearch) && <Badge variant="secondary" className="h-4 text-[8px] bg-primary/20 text-primary animate-pulse">{t('dataEditor.filtered')}</Badge>}
+                            {data?.rowMatch && <Badge variant="outline" className="h-4 text-[8px] border-amber-500/50 text-amber-500" title={t(`dataEditor.rowMatch.${data.rowMatch}`)}>{t('dataEditor.noPrimaryKey')}</Badge>}
                         </h3>
                         <div className="flex items-center gap-2 text-[10px] text-muted-foreground font-mono">
                             <span className="opacity-60">{database}</span>
                             <Separator orientation="vertical" className="h-2" />
-                            <span className="text-primary/70">{data.totalRows} {t('dataEditor.totalRows')}</span>
+                            <span className="text-primary/70">{data.totalRows}{data.capped && '+'} {t('dataEditor.totalRows')}</span>
                         </div>
                     </div>
                 </div>
@@ -520,7 +804,7 @@ export function DataEditor({ database, table, onClose }: Props) {
 
                 <div className="flex items-center gap-1.5 shrink-0">
                     {/* Active Filters Display & Clear */}
-                    {activeFilter && !showChart && (
+                    {(activeFilter.length > 0 || activeSearch) && !showChart && (
                         <div className="flex items-center gap-2 mr-2 animate-in fade-in slide-in-from-right-4">
                             <Button variant="ghost" size="icon" className="h-7 w-7 text-muted-foreground/70 hover:text-primary" onClick={loadData} title={t('common.refresh')}>
                                 <RefreshCcw size={14} />
@@ -533,25 +817,13 @@ export function DataEditor({ database, table, onClose }: Props) {
                                     setActiveFilter(f);
                                     setColumnFilters({}); // Clear column filters
                                     setGlobalSearch(''); // Clear global search
+                                    setActiveSearch('');
                                     setPage(1);
                                     loadData();
                                     toast.success(t('dataEditor.appliedFilterHistory'));
                                 }}
                             />
 
-                            <RawFilterEditor
-                                currentFilter={activeFilter}
-                                onSave={(f) => {
-                                    setActiveFilter(f);
-                                    setColumnFilters({});
-                                    setGlobalSearch('');
-                                    setPage(1);
-                                    setPage(1);
-                                    // loadData handled by effect
-                                    toast.success(t('dataEditor.rawFilterApplied'));
-                                }}
-                            />
-
                             <Button
                                 variant="ghost"
                                 size="icon"
@@ -575,6 +847,30 @@ export function DataEditor({ database, table, onClose }: Props) {
                     </Button>
                     <Separator orientation="vertical" className="h-5 mx-0.5" />
 
+                    {!showChart && (
+                        <Button
+                            variant="ghost"
+                            size="sm"
+                            className="h-7 px-2.5 text-[10px] font-bold gap-1.5 uppercase hover:bg-primary/5"
+                            title={t('dataEditor.undoHint')}
+                            onClick={() => setShowUndo(true)}
+                        >
+                            <Undo2 size={14} className="text-muted-foreground" />
+                            <span className="hidden sm:inline">{t('dataEditor.undo')}</span>
+                        </Button>
+                    )}
+                    {!showChart && data.primaryKey.length > 0 && (
+                        <Button
+                            variant={stagedMode ? 'default' : 'outline'}
+                            size="sm"
+                            className="h-7 px-2.5 text-[10px] font-bold gap-1.5 uppercase"
+                            title={t('dataEditor.stagedModeHint')}
+                            onClick={() => setStagedMode(!stagedMode)}
+                        >
+                            <Layers size={14} />
+                            <span className="hidden sm:inline">{t('dataEditor.stagedMode')}</span>
+                        </Button>
+                    )}
                     {!showChart && (
                         <Button
                             variant="outline"
@@ -600,17 +896,35 @@ export function DataEditor({ database, table, onClose }: Props) {
                             <DropdownMenuItem className="text-[11px] font-medium" onClick={() => setShowModifyModal(true)}>
                                 <Settings2 size={12} className="mr-2" /> {t('dataEditor.modifyTable')}
                             </DropdownMenuItem>
+                            <DropdownMenuItem className="text-[11px] font-medium" onClick={() => setShowBulkUpdate(true)}>
+                                <PencilLine size={12} className="mr-2" /> {t('dataEditor.bulkUpdate')}
+                            </DropdownMenuItem>
+                            <DropdownMenuItem className="text-[11px] font-medium" onClick={() => setShowPaste(true)}>
+                                <ClipboardPaste size={12} className="mr-2" /> {t('dataEditor.pasteRows')}
+                            </DropdownMenuItem>
                             <DropdownMenuSeparator />
                             <DropdownMenuItem className="text-[11px] font-medium" onClick={() => handleExport('xlsx')}>
                                 <FileText size={12} className="mr-2 text-green-600" /> {t('dataEditor.exportToExcel')}
                             </DropdownMenuItem>
-                            <DropdownMenuItem className="text-[11px] font-medium" onClick={() => handleExport('csv')}>
+                            <DropdownMenuItem className="text-[11px] font-medium" onClick={() => setShowCSVExport(true)}>
                                 <Download size={12} className="mr-2" /> {t('dataEditor.exportToCSV')}
                             </DropdownMenuItem>
                             <DropdownMenuItem className="text-[11px] font-medium" onClick={() => handleExport('json')}>
                                 <FileJson size={12} className="mr-2" /> {t('dataEditor.exportToJSON')}
                             </DropdownMenuItem>
+                            <DropdownMenuItem className="text-[11px] font-medium" onClick={() => setShowDump(true)}>
+                                <FileCode size={12} className="mr-2" /> {t('dataEditor.sqlDump')}
+                            </DropdownMenuItem>
+                            <DropdownMenuItem className="text-[11px] font-medium" onClick={() => setShowCopyTable(true)}>
+                                <ArrowRightLeft size={12} className="mr-2" /> {t('dataEditor.copyToConnection')}
+                            </DropdownMenuItem>
                             <DropdownMenuSeparator />
+                            <DropdownMenuItem
+                                className="text-[11px] font-medium text-destructive focus:text-destructive"
+                                onClick={() => setConfirmAction({ type: 'deleteMatching' })}
+                            >
+                                <FilterX size={12} className="mr-2" /> {t('dataEditor.deleteMatching')}
+                            </DropdownMenuItem>
                             <DropdownMenuItem
                                 className="text-[11px] font-medium text-destructive focus:text-destructive"
                                 onClick={() => setConfirmAction({ type: 'truncate' })}
@@ -638,7 +952,7 @@ export function DataEditor({ database, table, onClose }: Props) {
                     <Card className="m-4 border-primary/20 bg-primary/5 shadow-xl animate-in slide-in-from-top-4 duration-300">
                         <CardContent className="p-4">
                             <div className="flex items-center justify-between mb-4">
-                                <h4 className="text-xs font-bold uppercase tracking-[0.2em] text-primary">{t('dataEditor.newEntry')}</h4>
+                                <h4 className="text-xs font-bold uppercase tracking-[0.2em] text-primary">{duplicateOf ? t('dataEditor.duplicateEntry') : t('dataEditor.newEntry')}</h4>
                                 <Button variant="ghost" size="icon" className="h-6 w-6" onClick={() => setShowAddRow(false)}>
                                     <X size={14} />
                                 </Button>
@@ -672,7 +986,7 @@ export function DataEditor({ database, table, onClose }: Props) {
                                                 className="h-7 text-xs bg-background"
                                                 value={newRowData[col.name] || ''}
                                                 onChange={(e) => setNewRowData({ ...newRowData, [col.name]: e.target.value })}
-                                                placeholder={col.nullable ? 'NULL' : col.name}
+                                                placeholder={col.type === 'geometry' || col.type === 'geography' ? t('dataEditor.geoPlaceholder') : col.nullable ? 'NULL' : col.name}
                                                 type={col.type.includes('int') || col.type.includes('decimal') || col.type.includes('float') ? 'number' : 'text'}
                                             />
                                         )}
@@ -734,14 +1048,14 @@ export function DataEditor({ database, table, onClose }: Props) {
                                                     key={i}
                                                     className={cn(
                                                         "border-r last:border-r-0 text-muted-foreground p-0 h-auto align-top transition-colors hover:bg-muted/50",
-                                                        sortColumn === col.name && "bg-primary/5 text-primary font-bold"
+                                                        sortOrder.some(s => s.column === col.name) && "bg-primary/5 text-primary font-bold"
                                                     )}
                                                 >
                                                     <div className="flex flex-col">
                                                         {/* Header Title Area */}
                                                         <div
                                                             className="flex items-center justify-between px-2 py-1.5 cursor-pointer select-none group/header"
-                                                            onClick={() => handleSort(col.name)}
+                                                            onClick={(e) => handleSort(col.name, e.shiftKey)}
                                                         >
                                                             <div className="flex items-center gap-1.5 overflow-hidden">
                                                                 <span className="text-[11px] font-bold uppercase tracking-tight truncate" title={col.name}>
@@ -751,8 +1065,11 @@ export function DataEditor({ database, table, onClose }: Props) {
                                                             </div>
 
                                                             <div className="flex items-center text-muted-foreground/30 group-hover/header:text-muted-foreground/80 transition-colors">
-                                                                {sortColumn === col.name ? (
-                                                                    sortDirection === 'ASC' ? <ArrowUp size={10} className="text-primary" /> : <ArrowDown size={10} className="text-primary" />
+                                                                {sortOrder.some(s => s.column === col.name) ? (
+                                                                    <>
+                                                                        {sortOrder.length > 1 && <span className="text-[8px] text-primary mr-0.5">{sortOrder.findIndex(s => s.column === col.name) + 1}</span>}
+                                                                        {sortOrder.find(s => s.column === col.name)?.direction === 'ASC' ? <ArrowUp size={10} className="text-primary" /> : <ArrowDown size={10} className="text-primary" />}
+                                                                    </>
                                                                 ) : (
                                                                     <ArrowUpDown size={10} className="opacity-0 group-hover/header:opacity-100" />
                                                                 )}
@@ -765,11 +1082,13 @@ export function DataEditor({ database, table, onClose }: Props) {
                                                         <div className="relative bg-background/50" onClick={(e) => e.stopPropagation()}>
                                                             <FilterInput
                                                                 database={database}
+                                                                schema={schema}
                                                                 table={table}
                                                                 colName={col.name}
                                                                 value={columnFilters[col.name] || ''}
                                                                 onChange={(val) => handleColumnFilterChange(col.name, val)}
                                                                 onKeyDown={handleFilterKeyDown}
+                                                                json={isJSONColumn(col.name)}
                                                                 className={cn(
                                                                     "h-7 border-0 rounded-none text-[10px] bg-transparent focus-within:bg-background transition-all font-mono",
                                                                     columnFilters[col.name] && "text-primary font-medium bg-primary/5"
@@ -782,12 +1101,15 @@ export function DataEditor({ database, table, onClose }: Props) {
                                         </TableRow>
                                     </TableHeader>
                                     <TableBody>
-                                        {data.rows.map((row, rowIndex) => (
+                                        {data.rows.map((row, rowIndex) => {
+                                            const staged = stagedChange(row);
+                                            return (
                                             <TableRow
                                                 key={rowIndex}
                                                 className={cn(
                                                     "group transition-colors border-b last:border-b-0",
-                                                    selectedRows.has(rowIndex) ? "bg-primary/5 hover:bg-primary/10" : "hover:bg-accent/30"
+                                                    selectedRows.has(rowIndex) ? "bg-primary/5 hover:bg-primary/10" : "hover:bg-accent/30",
+                                                    staged?.kind === 'delete' && "line-through opacity-50 bg-destructive/5"
                                                 )}
                                             >
                                                 <TableCell className="text-center border-r py-2">
@@ -798,12 +1120,18 @@ export function DataEditor({ database, table, onClose }: Props) {
                                                         onChange={() => toggleRowSelection(rowIndex)}
                                                     />
                                                 </TableCell>
-                                                {row.map((cell, colIndex) => (
+                                                {row.map((stored, colIndex) => {
+                                                    // Staged values are shown in place of the stored ones
+                                                    const stagedValues = staged?.kind === 'update' ? staged.values ?? {} : {};
+                                                    const isStaged = data.columns[colIndex].name in stagedValues;
+                                                    const cell = isStaged ? stagedValues[data.columns[colIndex].name] : stored;
+                                                    return (
                                                     <TableCell
                                                         key={colIndex}
                                                         className={cn(
                                                             "text-[12px] py-1.5 border-r last:border-r-0 font-medium font-mono min-w-[120px] px-4",
                                                             cell === null ? "text-muted-foreground/30 italic font-normal" : "text-foreground/80",
+                                                            isStaged && "bg-amber-500/10",
                                                             editingCell?.row === rowIndex && editingCell?.col === colIndex && "p-0"
                                                         )}
                                                         onDoubleClick={() => handleCellDoubleClick(rowIndex, colIndex, cell)}
@@ -818,15 +1146,26 @@ export function DataEditor({ database, table, onClose }: Props) {
                                                                 onBlur={handleCellSave}
                                                                 autoFocus
                                                             />
+                                                        ) : Array.isArray(cell) ? (
+                                                            <span className="flex gap-1 max-w-[300px] overflow-hidden" title={formatValue(cell)}>
+                                                                {cell.length === 0 && <span className="text-muted-foreground">{'{}'}</span>}
+                                                                {cell.map((item, i) => (
+                                                                    <Badge key={i} variant="secondary" className="h-4 px-1.5 text-[10px] font-mono font-normal shrink-0">
+                                                                        {formatValue(item)}
+                                                                    </Badge>
+                                                                ))}
+                                                            </span>
                                                         ) : (
                                                             <span className="truncate block max-w-[300px]" title={formatValue(cell)}>
-                                                                {formatValue(cell)}
+                                                                {cell !== null && !isStaged && data.display?.[data.columns[colIndex].name]?.[rowIndex] || formatValue(cell)}
                                                             </span>
                                                         )}
                                                     </TableCell>
-                                                ))}
+                                                    );
+                                                })}
                                             </TableRow>
-                                        ))}
+                                            );
+                                        })}
                                     </TableBody>
                                 </Table>
                                 <ScrollBar orientation="horizontal" />
@@ -835,16 +1174,27 @@ export function DataEditor({ database, table, onClose }: Props) {
                         <ContextMenuContent>
                             <ContextMenuItem disabled>Actions</ContextMenuItem>
                             <ContextMenuSeparator />
-                            <ContextMenuItem onClick={copyAsCSV}>
-                                <Copy size={14} className="mr-2" /> Copy as CSV
+                            <ContextMenuItem onClick={() => copyAs('csv')}>
+                                <Copy size={14} className="mr-2" /> {t('resultsTable.copyAsCSV')}
+                            </ContextMenuItem>
+                            <ContextMenuItem onClick={() => copyAs('tsv')}>
+                                <Copy size={14} className="mr-2" /> {t('resultsTable.copyAsTSV')}
                             </ContextMenuItem>
-                            <ContextMenuItem onClick={copyAsJSON}>
+                            <ContextMenuItem onClick={() => copyAs('markdown')}>
+                                <FileText className="mr-2 h-4 w-4" />
+                                {t('resultsTable.copyAsMarkdown')}
+                            </ContextMenuItem>
+                            <ContextMenuItem onClick={() => copyAs('json')}>
                                 <FileJson className="mr-2 h-4 w-4" />
-                                Copy as JSON
+                                {t('resultsTable.copyAsJSON')}
+                            </ContextMenuItem>
+                            <ContextMenuItem onClick={() => copyAs('insert')}>
+                                <Database className="mr-2 h-4 w-4" />
+                                {t('resultsTable.copyAsSQL')}
                             </ContextMenuItem>
-                            <ContextMenuItem onClick={copyAsSQLInsert}>
+                            <ContextMenuItem onClick={openStatementGenerator}>
                                 <Database className="mr-2 h-4 w-4" />
-                                Copy as SQL INSERT
+                                {t('dataEditor.generateSQL')}
                             </ContextMenuItem>
                             <ContextMenuSeparator />
                             {editingCell === null && selectedRows.size <= 1 && (
@@ -872,9 +1222,52 @@ export function DataEditor({ database, table, onClose }: Props) {
                                 <Copy className="mr-2 h-4 w-4" />
                                 Copy Raw
                             </ContextMenuItem>
+                            {selectedRows.size === 1 && data.primaryKey.length > 0 && (
+                                <>
+                                    <ContextMenuSeparator />
+                                    <ContextMenuItem onClick={() => handleDuplicate(false)}>
+                                        <CopyPlus className="mr-2 h-4 w-4" />
+                                        {t('dataEditor.duplicateRow')}
+                                    </ContextMenuItem>
+                                    <ContextMenuItem onClick={() => handleDuplicate(true)}>
+                                        <PencilLine className="mr-2 h-4 w-4" />
+                                        {t('dataEditor.duplicateAndEdit')}
+                                    </ContextMenuItem>
+                                </>
+                            )}
                         </ContextMenuContent>
                     </ContextMenu>
 
+                    {/* Staged changes bar */}
+                    {changeSet && changeSet.changes.length > 0 && selectedRows.size === 0 && (
+                        <div className="absolute bottom-6 left-1/2 -translate-x-1/2 bg-popover border border-amber-500/40 shadow-2xl rounded-full px-6 py-2.5 flex items-center gap-6 animate-in slide-in-from-bottom-4 zoom-in-95 duration-300 z-50 ring-4 ring-amber-500/10 backdrop-blur-xl">
+                            <div className="flex items-center gap-2">
+                                <span className="w-2 h-2 rounded-full bg-amber-500 animate-pulse" />
+                                <span className="text-[11px] font-bold tracking-wider uppercase text-foreground/90">{t('dataEditor.stagedChanges', { count: changeSet.changes.length })}</span>
+                            </div>
+                            <Separator orientation="vertical" className="h-4 bg-amber-500/20" />
+                            <div className="flex items-center gap-2">
+                                <Button
+                                    variant="ghost"
+                                    size="sm"
+                                    onClick={() => setShowChangeSet(true)}
+                                    className="h-8 px-4 text-[10px] font-black uppercase text-amber-500 hover:bg-amber-500/10 hover:text-amber-500 tracking-widest gap-2"
+                                >
+                                    <Layers size={14} />
+                                    {t('dataEditor.reviewChanges')}
+                                </Button>
+                                <Button
+                                    variant="ghost"
+                                    size="sm"
+                                    onClick={discardChanges}
+                                    className="h-8 px-4 text-[10px] font-bold uppercase text-muted-foreground hover:bg-muted/30 tracking-widest"
+                                >
+                                    {t('dataEditor.discardChanges')}
+                                </Button>
+                            </div>
+                        </div>
+                    )}
+
                     {/* Floating Bulk Action bar */}
                     {selectedRows.size > 0 && (
                         <div className="absolute bottom-6 left-1/2 -translate-x-1/2 bg-popover border border-primary/40 shadow-2xl rounded-full px-6 py-2.5 flex items-center gap-6 animate-in slide-in-from-bottom-4 zoom-in-95 duration-300 z-50 ring-4 ring-primary/10 backdrop-blur-xl">
@@ -887,7 +1280,7 @@ export function DataEditor({ database, table, onClose }: Props) {
                                 <Button
                                     variant="ghost"
                                     size="sm"
-                                    onClick={handleDeleteSelected}
+                                    onClick={() => staging ? handleDeleteSelected() : setConfirmAction({ type: 'delete' })}
                                     className="h-8 px-4 text-[10px] font-black uppercase text-destructive hover:bg-destructive/10 hover:text-destructive tracking-widest gap-2"
                                 >
                                     <Trash2 size={14} />
@@ -968,18 +1361,32 @@ export function DataEditor({ database, table, onClose }: Props) {
                             <AlertTriangle size={24} />
                         </div>
                         <AlertDialogTitle className="text-xl font-bold tracking-tight uppercase">
-                            {confirmAction?.type === 'truncate' ? 'Deep Clean Table?' : 'Delete Table Entirely?'}
+                            {confirmAction?.type === 'truncate' ? 'Deep Clean Table?'
+                                : confirmAction?.type === 'delete' ? t('dataEditor.deleteRowsConfirmTitle')
+                                    : confirmAction?.type === 'deleteMatching' ? t('dataEditor.deleteMatchingConfirmTitle')
+                                        : 'Delete Table Entirely?'}
                         </AlertDialogTitle>
                         <AlertDialogDescription className="text-muted-foreground font-medium">
                             {confirmAction?.type === 'truncate'
                                 ? `You are about to remove ALL records from "${table}". This operation cannot be undone. Auto-increment values will be reset.`
-                                : `You are about to PERMANENTLY DELETE the table "${table}" and all its data. This action is irreversible.`}
+                                : confirmAction?.type === 'delete'
+                                    ? t('dataEditor.deleteRowsConfirmDesc', { count: selectedRows.size, table })
+                                    : confirmAction?.type === 'deleteMatching'
+                                        ? (matchingDelete
+                                            ? t('dataEditor.deleteMatchingConfirmDesc', { count: matchingDelete.matchingRows, table })
+                                            : t('sqlPreview.loading'))
+                                        : `You are about to PERMANENTLY DELETE the table "${table}" and all its data. This action is irreversible.`}
                         </AlertDialogDescription>
                     </AlertDialogHeader>
+                    <SQLPreviewBlock preview={confirmPreview} error={confirmPreviewError} />
                     <AlertDialogFooter className="mt-6 flex gap-2">
                         <AlertDialogCancel className="font-bold border-none hover:bg-muted/50 uppercase tracking-widest text-[11px] h-10">Cancel Action</AlertDialogCancel>
                         <AlertDialogAction
-                            onClick={confirmAction?.type === 'truncate' ? handleTruncate : handleDrop}
+                            onClick={confirmAction?.type === 'truncate' ? handleTruncate
+                                : confirmAction?.type === 'delete' ? handleConfirmedDelete
+                                    : confirmAction?.type === 'deleteMatching' ? handleMatchingDelete
+                                        : handleDrop}
+                            disabled={confirmAction?.type === 'deleteMatching' && !matchingDelete?.token}
                             className="bg-destructive hover:bg-destructive/90 text-destructive-foreground font-black uppercase tracking-widest text-[11px] h-10 px-6 shadow-lg shadow-destructive/20"
                         >
                             Confirmed
@@ -988,17 +1395,174 @@ export function DataEditor({ database, table, onClose }: Props) {
                 </AlertDialogContent>
             </AlertDialog>
 
+            {/* JSON Cell Editor */}
+            {
+                jsonCell && data && (
+                    <JSONCellEditor
+                        database={database}
+                        schema={schema}
+                        table={table}
+                        primaryKey={data.primaryKey}
+                        primaryValues={keyValues(data.rows[jsonCell.row])}
+                        column={data.columns[jsonCell.col].name}
+                        onSaved={loadData}
+                        onClose={() => setJsonCell(null)}
+                    />
+                )
+            }
+
+            {/* Binary Cell Viewer */}
+            {
+                binaryCell && data && (
+                    <BinaryCellViewer
+                        database={database}
+                        schema={schema}
+                        table={table}
+                        primaryKey={data.primaryKey}
+                        primaryValues={keyValues(data.rows[binaryCell.row])}
+                        column={data.columns[binaryCell.col].name}
+                        readOnly={data.columns[binaryCell.col].readOnly}
+                        onSaved={loadData}
+                        onClose={() => setBinaryCell(null)}
+                    />
+                )
+            }
+
+            {/* Array Cell Editor */}
+            {
+                arrayCell && data && (
+                    <ArrayCellEditor
+                        table={table}
+                        column={data.columns[arrayCell.col]}
+                        value={data.rows[arrayCell.row][arrayCell.col]}
+                        onSave={(list) => saveArrayCell(arrayCell.row, arrayCell.col, list)}
+                        onClose={() => setArrayCell(null)}
+                    />
+                )
+            }
+
+            {/* Bulk Update Modal */}
+            {
+                showBulkUpdate && data && (
+                    <BulkUpdateModal
+                        database={database}
+                        schema={schema}
+                        table={table}
+                        columns={data.columns}
+                        filters={activeFilter}
+                        search={activeSearch}
+                        onApplied={loadData}
+                        onClose={() => setShowBulkUpdate(false)}
+                    />
+                )
+            }
+
+            {/* Staged Changes Modal */}
+            {
+                showChangeSet && changeSet && (
+                    <ChangeSetModal
+                        database={database}
+                        schema={schema}
+                        table={table}
+                        changeSet={changeSet}
+                        onChange={setChangeSet}
+                        onApplied={() => {
+                            setChangeSet(null);
+                            loadData();
+                        }}
+                        onClose={() => setShowChangeSet(false)}
+                    />
+                )
+            }
+
+            {/* CSV Export Modal */}
+            {
+                showCSVExport && (
+                    <CSVExportModal
+                        database={database}
+                        schema={schema}
+                        table={table}
+                        onClose={() => setShowCSVExport(false)}
+                    />
+                )
+            }
+
+            {/* SQL Dump Modal */}
+            {
+                showDump && (
+                    <DumpModal
+                        database={database}
+                        tables={[table]}
+                        onClose={() => setShowDump(false)}
+                    />
+                )
+            }
+
+            {/* Copy Table Modal */}
+            {
+                showCopyTable && (
+                    <CopyTableModal
+                        database={database}
+                        schema={schema}
+                        table={table}
+                        onClose={() => setShowCopyTable(false)}
+                    />
+                )
+            }
+
+            {/* Undo Modal */}
+            {
+                showUndo && (
+                    <UndoModal
+                        onUndone={(entry) => {
+                            if (entry.database === database && entry.table === table) loadData();
+                        }}
+                        onClose={() => setShowUndo(false)}
+                    />
+                )
+            }
+
+            {/* Paste Rows Modal */}
+            {
+                showPaste && data && (
+                    <PasteRowsModal
+                        database={database}
+                        schema={schema}
+                        table={table}
+                        columns={data.columns}
+                        onApplied={loadData}
+                        onClose={() => setShowPaste(false)}
+                    />
+                )
+            }
+
+            {/* Generate SQL Modal */}
+            {
+                statementRows && data && (
+                    <GenerateSQLModal
+                        database={database}
+                        schema={schema}
+                        table={table}
+                        columns={data.columns.map(c => c.name)}
+                        rows={statementRows}
+                        hasPrimaryKey={data.primaryKey.length > 0}
+                        onClose={() => setStatementRows(null)}
+                    />
+                )
+            }
+
             {/* Modify Table Modal */}
             {
                 showModifyModal && data && (
                     <ModifyTableModal
                         database={database}
+                        schema={schema}
                         table={table}
                         columns={data.columns}
                         loading={loading}
                         onClose={() => setShowModifyModal(false)}
                         onSave={async (alt) => {
-                            const success = await alterTable(database, table, alt);
+                            const success = await alterTable(database, schema, table, alt);
                             if (success) {
                                 loadData();
                             }
@@ -14,13 +14,21 @@ import { Button } from '@/components/ui/button';
 import { Input } from '@/components/ui/input';
 import { cn } from '@/lib/utils';
 import { Badge } from '@/components/ui/badge';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { GetSchemas, GetSchema } from '../../wailsjs/go/main/App';
 
 interface Props {
     databases: DatabaseInfo[];
-    onGetTables: (db: string) => Promise<TableInfo[]>;
-    onGetColumns: (db: string, table: string) => Promise<ColumnInfo[]>;
+    onGetTables: (db: string, schema?: string) => Promise<TableInfo[]>;
+    onGetColumns: (db: string, table: string, schema?: string) => Promise<ColumnInfo[]>;
     onSelectDatabase: (db: string) => void;
-    onSelectTable: (db: string, table: string) => void;
+    onSelectTable: (db: string, table: string, schema?: string) => void;
     connected: boolean;
 }
 
@@ -30,6 +38,8 @@ interface TreeNode {
     type: 'database' | 'table' | 'column';
     expanded: boolean;
     children?: TreeNode[];
+    partitions?: TreeNode[]; // PostgreSQL partitions of a partitioned table
+    bound?: string;
     loading?: boolean;
 }
 
@@ -44,15 +54,41 @@ export function DatabaseTree({
     const { t } = useTranslation();
     const [nodes, setNodes] = useState<TreeNode[]>([]);
     const [filter, setFilter] = useState('');
+    const [schemas, setSchemas] = useState<string[]>([]); // empty when not on PostgreSQL
+    const [schema, setSchema] = useState(''); // PostgreSQL schema the tree lists tables of
 
-    useEffect(() => {
+    const resetNodes = () => {
         setNodes(databases.map(db => ({
             id: db.name,
             name: db.name,
             type: 'database',
             expanded: false,
         })));
-    }, [databases]);
+    };
+
+    useEffect(resetNodes, [databases]);
+
+    useEffect(() => {
+        if (!connected) {
+            setSchemas([]);
+            return;
+        }
+        Promise.all([GetSchemas(), GetSchema()])
+            .then(([list, current]) => {
+                setSchemas(list || []);
+                setSchema(current);
+            })
+            .catch(() => setSchemas([]));
+    }, [connected, databases]);
+
+    // Only the tree browses the other schema; opened tables carry theirs
+    const handleSchemaChange = (next: string) => {
+        setSchema(next);
+        // Loaded tables belong to the previous schema
+        resetNodes();
+    };
+
+    const tableSchema = schemas.length > 0 ? schema : undefined;
 
     const toggleNode = async (node: TreeNode) => {
         if (node.type === 'column') return;
@@ -68,6 +104,7 @@ export function DatabaseTree({
                     return true;
                 }
                 if (list[i].children && updateNode(list[i].children!)) return true;
+                if (list[i].partitions && updateNode(list[i].partitions!)) return true;
             }
             return false;
         };
@@ -82,16 +119,28 @@ export function DatabaseTree({
 
         try {
             if (node.type === 'database') {
-                const tables = await onGetTables(node.name);
-                node.children = tables.map(t => ({
+                const tables = await onGetTables(node.name, tableSchema);
+                const byName = new Map<string, TreeNode>(tables.map(t => [t.name, {
                     id: `${node.id}.${t.name}`,
                     name: t.name,
                     type: 'table',
                     expanded: false,
-                }));
+                    bound: t.partitionBound,
+                }]));
+                // Partitions are nested under their parent table
+                node.children = [];
+                for (const table of tables) {
+                    const child = byName.get(table.name)!;
+                    const parent = table.parent ? byName.get(table.parent) : undefined;
+                    if (parent) {
+                        parent.partitions = [...(parent.partitions || []), child];
+                    } else {
+                        node.children.push(child);
+                    }
+                }
             } else if (node.type === 'table') {
                 const dbName = node.id.split('.')[0];
-                const columns = await onGetColumns(dbName, node.name);
+                const columns = await onGetColumns(dbName, node.name, tableSchema);
                 node.children = columns.map(c => ({
                     id: `${node.id}.${c.name}`,
                     name: c.name,
@@ -125,7 +174,7 @@ export function DatabaseTree({
                     style={{ paddingLeft: `${indent + 12}px` }}
                     onClick={() => {
                         if (node.type === 'database') onSelectDatabase(node.name);
-                        if (node.type === 'table') onSelectTable(node.id.split('.')[0], node.name);
+                        if (node.type === 'table') onSelectTable(node.id.split('.')[0], node.name, tableSchema);
                         if (hasChildren) toggleNode(node);
                     }}
                 >
@@ -153,9 +202,14 @@ export function DatabaseTree({
                             node.type === 'database' ? "font-bold text-foreground/90" :
                                 node.type === 'table' ? "font-semibold text-foreground/80" :
                                     "text-muted-foreground"
-                        )}>
+                        )} title={node.bound}>
                             {node.name}
                         </span>
+                        {node.partitions && (
+                            <Badge variant="secondary" className="px-1.5 py-0 h-4 text-[9px] font-bold bg-muted/50 border-none" title={t('databaseTree.partitions')}>
+                                {node.partitions.length}
+                            </Badge>
+                        )}
 
                         {node.loading && (
                             <RefreshCw size={10} className="animate-spin text-muted-foreground" />
@@ -163,9 +217,10 @@ export function DatabaseTree({
                     </div>
                 </div>
 
-                {node.expanded && node.children && (
+                {node.expanded && (node.children || node.partitions) && (
                     <div className="animate-in slide-in-from-left-1 duration-200">
-                        {node.children.map(child => renderNode(child, depth + 1))}
+                        {node.partitions?.map(child => renderNode(child, depth + 1))}
+                        {node.children?.map(child => renderNode(child, depth + 1))}
                     </div>
                 )}
             </div>
@@ -196,6 +251,21 @@ export function DatabaseTree({
                 </Button>
             </div>
 
+            {schemas.length > 0 && (
+                <div className="px-3 pb-2">
+                    <Select value={schema} onValueChange={handleSchemaChange}>
+                        <SelectTrigger className="h-7 text-[11px] bg-background/50 border-muted-foreground/20" title={t('databaseTree.schema')}>
+                            <SelectValue />
+                        </SelectTrigger>
+                        <SelectContent>
+                            {schemas.map(name => (
+                                <SelectItem key={name} value={name} className="text-[11px]">{name}</SelectItem>
+                            ))}
+                        </SelectContent>
+                    </Select>
+                </div>
+            )}
+
             <div className="px-3 pb-2">
                 <div className="relative group">
                     <Search className="absolute left-2 top-1.5 h-3.5 w-3.5 text-muted-foreground group-focus-within:text-primary transition-colors" />
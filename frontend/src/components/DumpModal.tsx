@@ -0,0 +1,191 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Checkbox } from '@/components/ui/checkbox';
+import { FileCode, Loader2 } from 'lucide-react';
+import { toast } from "sonner";
+import { DumpOptions, TransferProgress } from '../types';
+import { GetTables, SelectExportPath, DumpTables } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { BucketTarget, UploadStatus, Bucket, emptyBucket, bucketPath, isValidBucket } from './BucketTarget';
+
+interface Props {
+    database: string;
+    tables: string[]; // selected to begin with
+    onClose: () => void;
+}
+
+// Writes selected tables to a SQL script of CREATE TABLE and INSERT
+// statements, optionally split into files of a maximum size
+export function DumpModal({ database, tables, onClose }: Props) {
+    const { t } = useTranslation();
+    const [available, setAvailable] = useState<string[]>([]);
+    const [options, setOptions] = useState<DumpOptions>({ tables, content: 'all', rowsPerInsert: 100 });
+    const [dumping, setDumping] = useState(false);
+    const [bucket, setBucket] = useState<Bucket>(emptyBucket);
+    const [progress, setProgress] = useState<TransferProgress | null>(null);
+
+    useEffect(() => {
+        GetTables(database, '')
+            .then(list => setAvailable(list.map(table => table.name)))
+            .catch(() => setAvailable(tables));
+    }, [database]);
+
+    useEffect(() => {
+        if (!dumping) return;
+        return EventsOn('export:progress', (p: TransferProgress) => setProgress(p));
+    }, [dumping]);
+
+    const toggle = (table: string, checked: boolean) => {
+        const selected = checked ? [...options.tables, table] : options.tables.filter(name => name !== table);
+        setOptions({ ...options, tables: selected });
+    };
+
+    const handleDump = async () => {
+        const path = bucket.enabled ? bucketPath(bucket) : await SelectExportPath('sql');
+        if (!path) return; // Cancelled
+        setProgress(null);
+        setDumping(true);
+        try {
+            const result = await DumpTables(database, path, options);
+            toast.success(t('dump.done', { tables: result.tables, rows: result.rows, files: result.files.length }));
+            onClose();
+        } catch (err: any) {
+            toast.error(t('dump.failed', { error: typeof err === 'string' ? err : err.message }));
+        } finally {
+            setDumping(false);
+        }
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && !dumping && onClose()}>
+            <DialogContent className="sm:max-w-[560px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <FileCode size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('dump.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {database}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-4 max-h-[65vh] overflow-y-auto">
+                    <div className="space-y-1.5">
+                        <div className="flex items-center justify-between">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">
+                                {t('dump.tables', { count: options.tables.length })}
+                            </Label>
+                            <div className="flex gap-2 text-[10px]">
+                                <button className="text-primary hover:underline" onClick={() => setOptions({ ...options, tables: available })}>{t('dump.selectAll')}</button>
+                                <button className="text-muted-foreground hover:underline" onClick={() => setOptions({ ...options, tables: [] })}>{t('dump.selectNone')}</button>
+                            </div>
+                        </div>
+                        <div className="rounded-md border max-h-40 overflow-y-auto p-2 grid grid-cols-2 gap-1">
+                            {available.map(table => (
+                                <label key={table} className="flex items-center gap-2 text-[11px] font-mono cursor-pointer truncate">
+                                    <Checkbox
+                                        checked={options.tables.includes(table)}
+                                        onCheckedChange={(checked) => toggle(table, !!checked)}
+                                    />
+                                    <span className="truncate">{table}</span>
+                                </label>
+                            ))}
+                        </div>
+                    </div>
+
+                    <div className="grid grid-cols-2 gap-3">
+                        <div className="space-y-1.5">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('dump.content')}</Label>
+                            <Select value={options.content} onValueChange={(content) => setOptions({ ...options, content })}>
+                                <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                    <SelectValue />
+                                </SelectTrigger>
+                                <SelectContent>
+                                    <SelectItem value="all" className="text-[11px]">{t('dump.contentAll')}</SelectItem>
+                                    <SelectItem value="schema" className="text-[11px]">{t('dump.contentSchema')}</SelectItem>
+                                    <SelectItem value="data" className="text-[11px]">{t('dump.contentData')}</SelectItem>
+                                </SelectContent>
+                            </Select>
+                        </div>
+                        <div className="space-y-1.5">
+                            <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('dump.rowsPerInsert')}</Label>
+                            <Input
+                                className="h-8 text-[11px] font-mono bg-background/50"
+                                type="number"
+                                min={1}
+                                value={options.rowsPerInsert || ''}
+                                onChange={(e) => setOptions({ ...options, rowsPerInsert: Math.max(0, parseInt(e.target.value) || 0) })}
+                                placeholder="100"
+                            />
+                        </div>
+                    </div>
+                    <div className="space-y-1.5">
+                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('dump.maxFileSize')}</Label>
+                        <Input
+                            className="h-8 text-[11px] font-mono bg-background/50"
+                            type="number"
+                            min={0}
+                            value={options.maxFileSizeMb || ''}
+                            onChange={(e) => setOptions({ ...options, maxFileSizeMb: Math.max(0, parseInt(e.target.value) || 0) })}
+                            placeholder={t('dump.singleFile')}
+                        />
+                    </div>
+                    {options.content !== 'data' && (
+                        <label className="flex items-center gap-2 text-[11px] cursor-pointer">
+                            <Checkbox
+                                checked={!!options.dropTables}
+                                onCheckedChange={(checked) => setOptions({ ...options, dropTables: !!checked })}
+                            />
+                            {t('dump.dropTables')}
+                        </label>
+                    )}
+                    <BucketTarget value={bucket} onChange={setBucket} extension="sql" disabled={dumping} />
+                    {dumping && progress && (
+                        <p className="text-[11px] font-mono text-muted-foreground">
+                            {t('dump.progress', { table: progress.table, count: progress.rows })}
+                        </p>
+                    )}
+                    <UploadStatus active={dumping} />
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} disabled={dumping} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.cancel')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={dumping || options.tables.length === 0 || (bucket.enabled && !isValidBucket(bucket))}
+                        onClick={handleDump}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        {dumping ? <Loader2 size={12} className="animate-spin" /> : <FileCode size={12} />}
+                        {t('dump.dump')}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -12,11 +12,12 @@ import {
 } from "@/components/ui/dropdown-menu";
 import { History, Clock, Trash2 } from "lucide-react";
 import { toast } from "sonner";
+import { FilterCondition } from "../types";
 
 interface FilterHistoryProps {
     table: string;
-    currentFilter: string;
-    onSelectFilter: (filter: string) => void;
+    currentFilter: FilterCondition[];
+    onSelectFilter: (filter: FilterCondition[]) => void;
 }
 
 const STORAGE_KEY = 'runedb_filter_history';
@@ -24,7 +25,7 @@ const MAX_HISTORY = 10;
 
 export function FilterHistory({ table, currentFilter, onSelectFilter }: FilterHistoryProps) {
     const { t } = useTranslation();
-    const [history, setHistory] = useState<string[]>([]);
+    const [history, setHistory] = useState<FilterCondition[][]>([]);
 
     useEffect(() => {
         loadHistory();
@@ -63,10 +64,10 @@ export function FilterHistory({ table, currentFilter, onSelectFilter }: FilterHi
                             key={idx}
                             onClick={() => onSelectFilter(filter)}
                             className="text-xs truncate font-mono cursor-pointer"
-                            title={filter}
+                            title={describeFilter(filter)}
                         >
                             <Clock size={12} className="mr-2 opacity-50 flex-shrink-0" />
-                            <span className="truncate">{filter}</span>
+                            <span className="truncate">{describeFilter(filter)}</span>
                         </DropdownMenuItem>
                     ))
                 )}
@@ -90,7 +91,8 @@ export function FilterHistory({ table, currentFilter, onSelectFilter }: FilterHi
             const raw = localStorage.getItem(STORAGE_KEY);
             if (!raw) return;
             const allHistory = JSON.parse(raw);
-            const tableHistory = allHistory[table] || [];
+            // Entries saved as raw WHERE strings by older versions are dropped
+            const tableHistory = (allHistory[table] || []).filter(Array.isArray);
             setHistory(tableHistory);
         } catch (e) {
             console.error("Failed to load filter history", e);
@@ -99,15 +101,16 @@ export function FilterHistory({ table, currentFilter, onSelectFilter }: FilterHi
 }
 
 // Helper to save history
-export function addToHistory(table: string, filter: string) {
-    if (!filter) return;
+export function addToHistory(table: string, filter: FilterCondition[]) {
+    if (filter.length === 0) return;
     try {
         const raw = localStorage.getItem(STORAGE_KEY);
         let allHistory = raw ? JSON.parse(raw) : {};
-        let tableHistory = allHistory[table] || [];
+        let tableHistory = (allHistory[table] || []).filter(Array.isArray);
 
         // Remove if exists (to move to top)
-        tableHistory = tableHistory.filter((f: string) => f !== filter);
+        const key = JSON.stringify(filter);
+        tableHistory = tableHistory.filter((f: FilterCondition[]) => JSON.stringify(f) !== key);
         // Add to top
         tableHistory.unshift(filter);
         // Limit
@@ -120,7 +123,18 @@ export function addToHistory(table: string, filter: string) {
     }
 }
 
-function clearHistory(table: string, setHistory: (h: string[]) => void) {
+// Renders conditions as readable text for the history list
+export function describeFilter(filter: FilterCondition[]): string {
+    return filter.map((c, i) => {
+        const prefix = i > 0 ? ` ${c.conjunction || 'AND'} ` : '';
+        if (c.group) return `${prefix}(${describeFilter(c.group)})`;
+        if (c.operator === 'IS NULL' || c.operator === 'IS NOT NULL') return `${prefix}${c.column} ${c.operator}`;
+        const value = Array.isArray(c.value) ? `(${c.value.join(', ')})` : String(c.value);
+        return `${prefix}${c.column} ${c.operator} ${value}`;
+    }).join('');
+}
+
+function clearHistory(table: string, setHistory: (h: FilterCondition[][]) => void) {
     try {
         const raw = localStorage.getItem(STORAGE_KEY);
         let allHistory = raw ? JSON.parse(raw) : {};
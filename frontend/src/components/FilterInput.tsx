@@ -26,7 +26,9 @@ import {
     Search,
     AlignLeft,
     Check,
-    Loader2
+    Loader2,
+    Regex,
+    Brackets
 } from "lucide-react";
 import { GetDistinctValues } from "../../wailsjs/go/main/App";
 import { toast } from "sonner";
@@ -34,11 +36,13 @@ import { cn } from "@/lib/utils";
 
 interface FilterInputProps {
     database: string;
+    schema?: string;
     table: string;
     colName: string;
     value: string;
     onChange: (val: string) => void;
     onKeyDown: (e: React.KeyboardEvent) => void;
+    json?: boolean; // JSON column; the filter may start with a .key.path
     className?: string;
 }
 
@@ -49,9 +53,18 @@ const OPERATORS = [
     { label: "greaterThan", value: ">", icon: ChevronRight },
     { label: "lessThan", value: "<", icon: ChevronLeft },
     { label: "startsWith", value: "START", icon: AlignLeft },
+    { label: "matchesRegex", value: "~", icon: Regex },
+    { label: "inList", value: "IN:", icon: Brackets },
 ];
 
-export function FilterInput({ database, table, colName, value, onChange, onKeyDown, className }: FilterInputProps) {
+// Splits the leading .key.path of a JSON column filter, e.g. ".address.city = Oslo"
+export function splitJSONPath(value: string): { path?: string[]; rest: string } {
+    const match = value.trim().match(/^\.([^\s=!<>~]+)\s*(.*)$/);
+    if (!match) return { rest: value };
+    return { path: match[1].split('.').filter(Boolean), rest: match[2] };
+}
+
+export function FilterInput({ database, schema = '', table, colName, value, onChange, onKeyDown, json, className }: FilterInputProps) {
     const { t } = useTranslation();
     const [suggestions, setSuggestions] = useState<string[]>([]);
     const [loading, setLoading] = useState(false);
@@ -66,7 +79,7 @@ export function FilterInput({ database, table, colName, value, onChange, onKeyDo
     const fetchSuggestions = async () => {
         setLoading(true);
         try {
-            const vals = await GetDistinctValues(database, table, colName);
+            const vals = await GetDistinctValues(database, schema, table, colName);
             setSuggestions(vals || []);
         } catch (err) {
             console.error("Failed to fetch distinct values:", err);
@@ -79,14 +92,16 @@ export function FilterInput({ database, table, colName, value, onChange, onKeyDo
     const handleSelectOperator = (opValue: string) => {
         // Replace existing operator or prepend
         // Simple heuristic: remove known operators then prepend new one
-        let cleanVal = value;
+        // The operator goes after the path of a JSON filter
+        const { path, rest } = json ? splitJSONPath(value) : { path: undefined, rest: value };
+        let cleanVal = rest;
         for (const op of OPERATORS) {
             if (cleanVal.startsWith(op.value)) {
                 cleanVal = cleanVal.substring(op.value.length);
                 break;
             }
         }
-        onChange(`${opValue}${cleanVal}`);
+        onChange(`${path ? `.${path.join('.')} ` : ''}${opValue}${cleanVal}`);
     };
 
     const handleSelectSuggestion = (val: string) => {
@@ -97,7 +112,8 @@ export function FilterInput({ database, table, colName, value, onChange, onKeyDo
         // We might want to trigger enter press logic too, but onChange should update state
     };
 
-    const currentOp = OPERATORS.find(op => value.startsWith(op.value)) || OPERATORS[0]; // Default to first (LIKE/Contains) or whatever logic
+    const opText = json ? splitJSONPath(value).rest : value;
+    const currentOp = OPERATORS.find(op => opText.startsWith(op.value)) || OPERATORS[0]; // Default to first (LIKE/Contains) or whatever logic
 
     return (
         <div className={cn("relative flex items-center group/input", className)}>
@@ -128,7 +144,8 @@ export function FilterInput({ database, table, colName, value, onChange, onKeyDo
                 onChange={(e) => onChange(e.target.value)}
                 onKeyDown={onKeyDown}
                 className="h-7 text-xs pl-7 pr-7 border-none bg-transparent focus-visible:ring-0 focus-visible:bg-accent/10 placeholder:text-muted-foreground/30"
-                placeholder="..."
+                placeholder={json ? t('filters.jsonPathPlaceholder') : "..."}
+                title={json ? t('filters.jsonPathHint') : undefined}
             />
 
             <Popover open={open} onOpenChange={setOpen}>
@@ -0,0 +1,147 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Checkbox } from '@/components/ui/checkbox';
+import { Textarea } from '@/components/ui/textarea';
+import { Copy, FileCode } from 'lucide-react';
+import { toast } from "sonner";
+import { StatementOptions } from '../types';
+import { GenerateStatements } from '../../wailsjs/go/main/App';
+
+interface Props {
+    database: string;
+    schema?: string;
+    table: string;
+    columns: string[];
+    rows: any[][];
+    hasPrimaryKey: boolean;
+    onClose: () => void;
+}
+
+// Writes the selected rows as INSERT or UPDATE statements to paste into
+// another environment
+export function GenerateSQLModal({ database, schema = '', table, columns, rows, hasPrimaryKey, onClose }: Props) {
+    const { t } = useTranslation();
+    const [options, setOptions] = useState<StatementOptions>({ kind: 'insert', dialect: '', table });
+    const [sql, setSQL] = useState('');
+    const [error, setError] = useState<string | null>(null);
+
+    useEffect(() => {
+        GenerateStatements(database, schema, table, columns, rows, options)
+            .then(text => {
+                setSQL(text);
+                setError(null);
+            })
+            .catch(err => {
+                setSQL('');
+                setError(typeof err === 'string' ? err : err.message);
+            });
+    }, [options]);
+
+    const handleCopy = () => {
+        navigator.clipboard.writeText(sql);
+        toast.success(t('resultsTable.copiedToClipboard'));
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[720px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <FileCode size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('generateSQL.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {t('generateSQL.rows', { count: rows.length, table })}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-3">
+                    <div className="grid grid-cols-3 gap-3">
+                        <Select value={options.kind} onValueChange={(kind) => setOptions({ ...options, kind: kind as StatementOptions['kind'] })}>
+                            <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent>
+                                <SelectItem value="insert" className="text-[11px]">INSERT</SelectItem>
+                                <SelectItem value="update" disabled={!hasPrimaryKey} className="text-[11px]">{t('generateSQL.updateByKey')}</SelectItem>
+                            </SelectContent>
+                        </Select>
+                        <Select value={options.dialect || 'connection'} onValueChange={(dialect) => setOptions({ ...options, dialect: dialect === 'connection' ? '' : dialect as StatementOptions['dialect'] })}>
+                            <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent>
+                                <SelectItem value="connection" className="text-[11px]">{t('generateSQL.connectionDialect')}</SelectItem>
+                                <SelectItem value="postgres" className="text-[11px]">PostgreSQL</SelectItem>
+                                <SelectItem value="mysql" className="text-[11px]">MySQL</SelectItem>
+                            </SelectContent>
+                        </Select>
+                        <Input
+                            value={options.table}
+                            onChange={(e) => setOptions({ ...options, table: e.target.value })}
+                            placeholder={table}
+                            title={t('generateSQL.targetTable')}
+                            className="h-8 text-[11px] font-mono bg-background/50"
+                        />
+                    </div>
+                    {options.kind === 'insert' && (
+                        <label className="flex items-center gap-1.5 text-[9px] font-black uppercase text-muted-foreground tracking-widest cursor-pointer">
+                            <Checkbox
+                                checked={!!options.multiRow}
+                                onCheckedChange={(checked) => setOptions({ ...options, multiRow: !!checked })}
+                                className="h-4 w-4 rounded border-muted-foreground/30"
+                            />
+                            {t('generateSQL.multiRow')}
+                        </label>
+                    )}
+                    {error ? (
+                        <p className="text-[11px] text-destructive font-mono">{error}</p>
+                    ) : (
+                        <Textarea
+                            readOnly
+                            value={sql}
+                            className="h-72 text-[11px] font-mono bg-background/50 whitespace-pre"
+                        />
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.close')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={!sql}
+                        onClick={handleCopy}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        <Copy size={12} />
+                        {t('generateSQL.copy')}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,225 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { ExternalLink, Loader2, LogIn, LogOut, Sheet } from 'lucide-react';
+import { toast } from "sonner";
+import { GoogleAccount, GoogleSheetExport, QueryResult } from '../types';
+import {
+    GetGoogleAccount,
+    SetGoogleClient,
+    SignInGoogle,
+    SignOutGoogle,
+    ExportToGoogleSheet
+} from '../../wailsjs/go/main/App';
+import { BrowserOpenURL } from '../../wailsjs/runtime/runtime';
+
+interface Props {
+    result: QueryResult;
+    onClose: () => void;
+}
+
+const errorText = (err: any) => typeof err === 'string' ? err : err.message;
+
+// Sends the loaded rows of a result set to a new or existing Google Sheet,
+// signing in with the user's own OAuth client first
+export function GoogleSheetsModal({ result, onClose }: Props) {
+    const { t } = useTranslation();
+    const [account, setAccount] = useState<GoogleAccount | null>(null);
+    const [client, setClient] = useState({ id: '', secret: '' });
+    const [target, setTarget] = useState<'new' | 'existing'>('new');
+    const [request, setRequest] = useState<GoogleSheetExport>({ mode: 'replace' });
+    const [busy, setBusy] = useState<'signIn' | 'export' | null>(null);
+
+    useEffect(() => {
+        GetGoogleAccount().then(acc => {
+            setAccount(acc);
+            setClient(c => ({ ...c, id: acc.clientId }));
+        }).catch(err => toast.error(errorText(err)));
+    }, []);
+
+    const handleSignIn = async () => {
+        setBusy('signIn');
+        try {
+            if (!account?.configured || client.id !== account.clientId || client.secret) {
+                await SetGoogleClient(client.id, client.secret);
+            }
+            setAccount(await SignInGoogle());
+        } catch (err: any) {
+            toast.error(t('googleSheets.signInFailed', { error: errorText(err) }));
+        } finally {
+            setBusy(null);
+        }
+    };
+
+    const handleSignOut = async () => {
+        try {
+            await SignOutGoogle();
+            setAccount(await GetGoogleAccount());
+        } catch (err: any) {
+            toast.error(errorText(err));
+        }
+    };
+
+    const handleExport = async () => {
+        setBusy('export');
+        try {
+            const req = target === 'new' ? { ...request, spreadsheet: '' } : { ...request, title: '' };
+            const written = await ExportToGoogleSheet(result, req);
+            toast.success(t('googleSheets.exported', { count: written.rows, sheet: written.sheet }), {
+                action: { label: t('googleSheets.open'), onClick: () => BrowserOpenURL(written.url) },
+            });
+            onClose();
+        } catch (err: any) {
+            toast.error(t('googleSheets.failed', { error: errorText(err) }));
+        } finally {
+            setBusy(null);
+        }
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && !busy && onClose()}>
+            <DialogContent className="sm:max-w-[520px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Sheet size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('googleSheets.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {t('googleSheets.rows', { count: result.rows.length })}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-4">
+                    {account && !account.signedIn && (
+                        <div className="space-y-3">
+                            <p className="text-[11px] text-muted-foreground">{t('googleSheets.clientHint')}</p>
+                            <div className="space-y-1.5">
+                                <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('googleSheets.clientId')}</Label>
+                                <Input className="h-8 text-[11px] font-mono bg-background/50" value={client.id} onChange={(e) => setClient({ ...client, id: e.target.value })} placeholder="1234-abc.apps.googleusercontent.com" />
+                            </div>
+                            <div className="space-y-1.5">
+                                <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('googleSheets.clientSecret')}</Label>
+                                <Input
+                                    type="password"
+                                    className="h-8 text-[11px] font-mono bg-background/50"
+                                    value={client.secret}
+                                    onChange={(e) => setClient({ ...client, secret: e.target.value })}
+                                    placeholder={account.configured ? t('googleSheets.secretUnchanged') : ''}
+                                />
+                            </div>
+                            <Button type="button" disabled={!!busy || !client.id.trim()} onClick={handleSignIn} className="w-full text-[10px] font-black uppercase tracking-widest gap-2">
+                                {busy === 'signIn' ? <Loader2 size={12} className="animate-spin" /> : <LogIn size={12} />}
+                                {busy === 'signIn' ? t('googleSheets.waiting') : t('googleSheets.signIn')}
+                            </Button>
+                        </div>
+                    )}
+
+                    {account?.signedIn && (
+                        <div className="space-y-4">
+                            <div className="flex items-center justify-between text-[11px]">
+                                <span className="text-muted-foreground">{t('googleSheets.signedInAs', { email: account.email || t('googleSheets.unknownAccount') })}</span>
+                                <button className="text-[10px] text-primary hover:underline flex items-center gap-1" onClick={handleSignOut} disabled={!!busy}>
+                                    <LogOut size={10} /> {t('googleSheets.signOut')}
+                                </button>
+                            </div>
+
+                            <div className="grid grid-cols-2 gap-3">
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('googleSheets.target')}</Label>
+                                    <Select value={target} onValueChange={(value) => setTarget(value as 'new' | 'existing')}>
+                                        <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                            <SelectValue />
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            <SelectItem value="new" className="text-[11px]">{t('googleSheets.newSpreadsheet')}</SelectItem>
+                                            <SelectItem value="existing" className="text-[11px]">{t('googleSheets.existingSpreadsheet')}</SelectItem>
+                                        </SelectContent>
+                                    </Select>
+                                </div>
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('googleSheets.sheet')}</Label>
+                                    <Input
+                                        className="h-8 text-[11px] bg-background/50"
+                                        value={request.sheet ?? ''}
+                                        onChange={(e) => setRequest({ ...request, sheet: e.target.value })}
+                                        placeholder={target === 'new' ? 'Results' : t('googleSheets.firstSheet')}
+                                    />
+                                </div>
+                            </div>
+
+                            {target === 'new' ? (
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('googleSheets.spreadsheetTitle')}</Label>
+                                    <Input className="h-8 text-[11px] bg-background/50" value={request.title ?? ''} onChange={(e) => setRequest({ ...request, title: e.target.value })} placeholder={t('googleSheets.defaultTitle')} />
+                                </div>
+                            ) : (
+                                <>
+                                    <div className="space-y-1.5">
+                                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('googleSheets.spreadsheet')}</Label>
+                                        <Input
+                                            className="h-8 text-[11px] font-mono bg-background/50"
+                                            value={request.spreadsheet ?? ''}
+                                            onChange={(e) => setRequest({ ...request, spreadsheet: e.target.value })}
+                                            placeholder="https://docs.google.com/spreadsheets/d/..."
+                                        />
+                                    </div>
+                                    <div className="space-y-1.5">
+                                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('googleSheets.mode')}</Label>
+                                        <Select value={request.mode} onValueChange={(mode) => setRequest({ ...request, mode: mode as GoogleSheetExport['mode'] })}>
+                                            <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                                <SelectValue />
+                                            </SelectTrigger>
+                                            <SelectContent>
+                                                <SelectItem value="replace" className="text-[11px]">{t('googleSheets.replace')}</SelectItem>
+                                                <SelectItem value="append" className="text-[11px]">{t('googleSheets.append')}</SelectItem>
+                                            </SelectContent>
+                                        </Select>
+                                    </div>
+                                </>
+                            )}
+                        </div>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} disabled={!!busy} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.cancel')}
+                    </Button>
+                    {account?.signedIn && (
+                        <Button
+                            type="button"
+                            disabled={!!busy || (target === 'existing' && !request.spreadsheet?.trim())}
+                            onClick={handleExport}
+                            className="text-[10px] font-black uppercase tracking-widest gap-2"
+                        >
+                            {busy === 'export' ? <Loader2 size={12} className="animate-spin" /> : <ExternalLink size={12} />}
+                            {t('googleSheets.export')}
+                        </Button>
+                    )}
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
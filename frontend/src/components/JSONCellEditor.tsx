@@ -0,0 +1,151 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import { Button } from '@/components/ui/button';
+import { Textarea } from '@/components/ui/textarea';
+import { Braces, CheckCircle2, Loader2, Save, WandSparkles, XCircle } from 'lucide-react';
+import { toast } from "sonner";
+import { JSONValidation } from '../types';
+import { GetCellValue, SaveCellValue, ValidateJSON } from '../../wailsjs/go/main/App';
+
+interface Props {
+    database: string;
+    schema?: string;
+    table: string;
+    primaryKey: string[];
+    primaryValues: any[];
+    column: string;
+    onSaved: () => void;
+    onClose: () => void;
+}
+
+// Edits a json/jsonb cell. The document is checked by the backend as it is
+// typed and only written back when its content changed.
+export function JSONCellEditor({ database, schema = '', table, primaryKey, primaryValues, column, onSaved, onClose }: Props) {
+    const { t } = useTranslation();
+    const [text, setText] = useState<string | null>(null);
+    const [validation, setValidation] = useState<JSONValidation | null>(null);
+    const [saving, setSaving] = useState(false);
+
+    useEffect(() => {
+        GetCellValue(database, schema, table, primaryKey, primaryValues, column)
+            .then(cell => setText(cell.isNull ? '' : cell.format?.pretty ?? cell.value))
+            .catch(err => {
+                toast.error(typeof err === 'string' ? err : err.message);
+                onClose();
+            });
+    }, [database, schema, table, column]);
+
+    useEffect(() => {
+        if (text === null) return;
+        let active = true;
+        const timer = setTimeout(() => {
+            ValidateJSON(text).then(result => active && setValidation(result));
+        }, 250);
+        return () => {
+            active = false;
+            clearTimeout(timer);
+        };
+    }, [text]);
+
+    const handleSave = async () => {
+        if (text === null || !validation?.valid) return;
+        setSaving(true);
+        try {
+            const result = await SaveCellValue(database, schema, table, primaryKey, primaryValues, column, text);
+            if (result.rowsAffected === 0) {
+                toast.info(t('jsonEditor.unchanged'));
+            } else {
+                toast.success(t('dataEditor.rowUpdated'));
+                onSaved();
+            }
+            onClose();
+        } catch (err: any) {
+            toast.error(`${t('dataEditor.updateFailed')}: ${typeof err === 'string' ? err : err.message}`);
+        } finally {
+            setSaving(false);
+        }
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[720px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Braces size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('jsonEditor.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {table}.{column}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-2">
+                    {text === null ? (
+                        <div className="flex items-center gap-2 text-[10px] text-muted-foreground uppercase font-bold tracking-widest">
+                            <Loader2 size={12} className="animate-spin" />
+                            {t('common.loading')}
+                        </div>
+                    ) : (
+                        <Textarea
+                            autoFocus
+                            value={text}
+                            onChange={(e) => setText(e.target.value)}
+                            spellCheck={false}
+                            className="h-[50vh] text-[11px] font-mono bg-background/50 resize-none"
+                        />
+                    )}
+                    {validation && (validation.valid ? (
+                        <p className="flex items-center gap-1.5 text-[10px] font-bold text-emerald-500">
+                            <CheckCircle2 size={12} /> {t('jsonEditor.valid')}
+                        </p>
+                    ) : (
+                        <p className="flex items-center gap-1.5 text-[10px] font-bold text-destructive">
+                            <XCircle size={12} />
+                            {validation.line
+                                ? t('jsonEditor.invalidAt', { line: validation.line, column: validation.column, error: validation.error })
+                                : t('jsonEditor.invalid', { error: validation.error })}
+                        </p>
+                    ))}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.cancel')}
+                    </Button>
+                    <Button
+                        type="button"
+                        variant="outline"
+                        disabled={!validation?.valid || validation.pretty === undefined}
+                        onClick={() => validation?.pretty !== undefined && setText(validation.pretty)}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        <WandSparkles size={12} />
+                        {t('jsonEditor.format')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={!validation?.valid || saving}
+                        onClick={handleSave}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        {saving ? <Loader2 size={12} className="animate-spin" /> : <Save size={12} />}
+                        {t('common.save')}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
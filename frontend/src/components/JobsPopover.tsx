@@ -0,0 +1,144 @@
+import { useEffect, useState, useCallback } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Popover,
+    PopoverContent,
+    PopoverTrigger,
+} from "@/components/ui/popover";
+import { ScrollArea } from "@/components/ui/scroll-area";
+import { Button } from '@/components/ui/button';
+import { Hourglass, Square, Eye, Loader2, CheckCircle2, AlertCircle, Ban } from 'lucide-react';
+import { toast } from "sonner";
+import { cn } from '@/lib/utils';
+import { ListJobs, CancelJob, ClearJobs } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { Job } from '../types';
+
+interface Props {
+    onShowResult: (id: number) => void;
+}
+
+export function JobsPopover({ onShowResult }: Props) {
+    const { t } = useTranslation();
+    const [open, setOpen] = useState(false);
+    const [jobs, setJobs] = useState<Job[]>([]);
+
+    const refresh = useCallback(() => {
+        ListJobs()
+            .then(list => setJobs(list || []))
+            .catch(err => console.error('Failed to list jobs:', err));
+    }, []);
+
+    // Jobs report every statement and their end, even when started from a closed tab
+    useEffect(() => {
+        refresh();
+        const update = (job: Job) => setJobs(prev => {
+            const others = prev.filter(j => j.id !== job.id);
+            return [job, ...others].sort((a, b) => b.id - a.id);
+        });
+        const offProgress = EventsOn('job:progress', update);
+        const offDone = EventsOn('job:done', (job: Job) => {
+            update(job);
+            const label = t('jobs.job', { id: job.id });
+            if (job.status === 'done') {
+                toast.success(t('jobs.finished', { job: label, ms: Math.round(job.durationMs) }), {
+                    action: { label: t('jobs.show'), onClick: () => onShowResult(job.id) }
+                });
+            } else if (job.status === 'failed') {
+                toast.error(`${label}: ${job.error}`);
+            } else {
+                toast.info(t('jobs.cancelled', { job: label }));
+            }
+        });
+        return () => {
+            offProgress();
+            offDone();
+        };
+    }, [refresh, onShowResult, t]);
+
+    const running = jobs.filter(j => j.status === 'running').length;
+
+    const statusIcon = (job: Job) => {
+        switch (job.status) {
+            case 'running': return <Loader2 size={12} className="mt-0.5 text-primary animate-spin shrink-0" />;
+            case 'done': return <CheckCircle2 size={12} className="mt-0.5 text-green-500 shrink-0" />;
+            case 'failed': return <AlertCircle size={12} className="mt-0.5 text-destructive shrink-0" />;
+            default: return <Ban size={12} className="mt-0.5 text-muted-foreground shrink-0" />;
+        }
+    };
+
+    return (
+        <Popover open={open} onOpenChange={(o) => { setOpen(o); if (o) refresh(); }}>
+            <PopoverTrigger asChild>
+                <button
+                    className={cn(
+                        "flex items-center gap-1.5 px-2 py-0.5 rounded text-[9px] font-black uppercase tracking-widest transition-colors",
+                        running > 0 ? "bg-primary/10 text-primary border border-primary/20" : "text-muted-foreground/60 hover:text-foreground"
+                    )}
+                    title={t('jobs.title')}
+                >
+                    <Hourglass size={10} strokeWidth={3} className={cn(running > 0 && "animate-pulse")} />
+                    {running > 0 ? t('jobs.running', { count: running }) : t('jobs.title')}
+                </button>
+            </PopoverTrigger>
+            <PopoverContent className="w-[420px] p-0" align="end" side="top">
+                <div className="flex items-center justify-between px-4 py-2 border-b bg-muted/30">
+                    <span className="text-[10px] font-black uppercase tracking-widest text-muted-foreground">{t('jobs.title')}</span>
+                    <Button
+                        variant="ghost"
+                        size="sm"
+                        className="h-6 text-[10px] font-bold"
+                        onClick={() => ClearJobs().then(refresh).catch(err => console.error('Failed to clear jobs:', err))}
+                    >
+                        {t('jobs.clearFinished')}
+                    </Button>
+                </div>
+                <ScrollArea className="h-[300px]">
+                    {jobs.length === 0 ? (
+                        <div className="p-8 text-center text-[10px] text-muted-foreground uppercase font-bold tracking-widest opacity-60">
+                            {t('jobs.none')}
+                        </div>
+                    ) : (
+                        <div className="divide-y divide-border/40">
+                            {jobs.map(job => (
+                                <div key={job.id} className="px-4 py-2 flex items-start gap-3">
+                                    {statusIcon(job)}
+                                    <div className="min-w-0 flex-1">
+                                        <code className="block text-[11px] font-mono text-foreground/80 truncate" title={job.query}>
+                                            {job.query}
+                                        </code>
+                                        <div className="mt-1 text-[9px] text-muted-foreground truncate" title={job.error}>
+                                            {t('jobs.job', { id: job.id })} · {job.database} · {t('jobs.progress', { completed: job.completed, total: job.statements })} · {Math.round(job.durationMs)} ms
+                                            {job.error && <span className="text-destructive"> · {job.error}</span>}
+                                        </div>
+                                    </div>
+                                    {job.status === 'running' ? (
+                                        <Button
+                                            variant="ghost"
+                                            size="icon"
+                                            className="h-6 w-6 text-muted-foreground hover:text-destructive"
+                                            onClick={() => CancelJob(job.id).catch(err => toast.error(String(err)))}
+                                            title={t('common.cancel')}
+                                        >
+                                            <Square size={12} />
+                                        </Button>
+                                    ) : (
+                                        <Button
+                                            variant="ghost"
+                                            size="icon"
+                                            className="h-6 w-6 text-muted-foreground hover:text-primary"
+                                            onClick={() => { onShowResult(job.id); setOpen(false); }}
+                                            title={t('jobs.show')}
+                                        >
+                                            <Eye size={12} />
+                                        </Button>
+                                    )}
+                                </div>
+                            ))}
+                        </div>
+                    )}
+                </ScrollArea>
+            </PopoverContent>
+        </Popover>
+    );
+}
@@ -0,0 +1,120 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Lock } from 'lucide-react';
+import { toast } from "sonner";
+import { LockStatus } from '../types';
+import { GetLockStatus, SetLockPassphrase, SetIdleLockTimeout, Lock as LockApp } from '../../wailsjs/go/main/App';
+
+interface Props {
+    onClose: () => void;
+}
+
+const errorText = (err: any) => typeof err === 'string' ? err : err.message;
+
+// Sets the lock passphrase and idle timeout, and locks the app on demand
+export function LockSettingsModal({ onClose }: Props) {
+    const { t } = useTranslation();
+    const [status, setStatus] = useState<LockStatus | null>(null);
+    const [form, setForm] = useState({ current: '', passphrase: '', confirm: '' });
+    const [idleMin, setIdleMin] = useState(0);
+
+    useEffect(() => {
+        GetLockStatus().then(s => {
+            setStatus(s);
+            setIdleMin(s.idleTimeoutMin);
+        }).catch(err => toast.error(errorText(err)));
+    }, []);
+
+    const handleSave = async () => {
+        if (form.passphrase !== form.confirm) {
+            toast.error(t('appLock.mismatch'));
+            return;
+        }
+        try {
+            if (form.passphrase || (status?.hasPassphrase && form.current)) {
+                await SetLockPassphrase(form.current, form.passphrase);
+            }
+            await SetIdleLockTimeout(idleMin);
+            toast.success(t('appLock.saved'));
+            onClose();
+        } catch (err: any) {
+            toast.error(errorText(err));
+        }
+    };
+
+    const handleLock = async () => {
+        try {
+            await LockApp();
+            onClose();
+        } catch (err: any) {
+            toast.error(errorText(err));
+        }
+    };
+
+    const field = (key: keyof typeof form, label: string) => (
+        <div className="space-y-1.5">
+            <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{label}</Label>
+            <Input type="password" className="h-8 text-[11px] bg-background/50" value={form[key]} onChange={(e) => setForm({ ...form, [key]: e.target.value })} />
+        </div>
+    );
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[420px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Lock size={20} />
+                        </div>
+                        <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                            {t('appLock.settings')}
+                        </DialogTitle>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-4">
+                    <p className="text-[11px] text-muted-foreground">{t('appLock.hint')}</p>
+                    {status?.hasPassphrase && field('current', t('appLock.currentPassphrase'))}
+                    {field('passphrase', status?.hasPassphrase ? t('appLock.newPassphrase') : t('appLock.passphrase'))}
+                    {field('confirm', t('appLock.confirmPassphrase'))}
+                    {status?.hasPassphrase && <p className="text-[10px] text-muted-foreground">{t('appLock.removeHint')}</p>}
+                    <div className="space-y-1.5">
+                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('appLock.idleTimeout')}</Label>
+                        <Input
+                            type="number"
+                            min={0}
+                            className="h-8 text-[11px] font-mono bg-background/50"
+                            value={idleMin || ''}
+                            onChange={(e) => setIdleMin(Math.max(0, parseInt(e.target.value) || 0))}
+                            placeholder={t('appLock.never')}
+                        />
+                    </div>
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    {status?.hasPassphrase && (
+                        <Button type="button" variant="outline" onClick={handleLock} className="text-[10px] font-black uppercase tracking-widest gap-2 mr-auto">
+                            <Lock size={12} /> {t('appLock.lockNow')}
+                        </Button>
+                    )}
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.cancel')}
+                    </Button>
+                    <Button type="button" onClick={handleSave} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.save')}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
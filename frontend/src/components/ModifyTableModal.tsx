@@ -1,6 +1,8 @@
 import React, { useState, useEffect } from 'react';
 import { useTranslation } from 'react-i18next';
-import { ColumnInfo, TableAlteration } from '../types';
+import { ColumnInfo, SQLPreview, TableAlteration } from '../types';
+import { PreviewAlterTable } from '../../wailsjs/go/main/App';
+import { SQLPreviewBlock } from './SQLPreviewBlock';
 import {
     X,
     Plus,
@@ -12,7 +14,8 @@ import {
     Type,
     ShieldCheck,
     Hash,
-    Key
+    Key,
+    FileCode
 } from 'lucide-react';
 import {
     Dialog,
@@ -45,6 +48,7 @@ import {
 
 interface Props {
     database: string;
+    schema?: string;
     table: string;
     columns: ColumnInfo[];
     onSave: (alteration: TableAlteration) => Promise<boolean>;
@@ -68,9 +72,13 @@ const typeGroups: Record<string, string[]> = {
 
 const allGroupedTypes = Object.values(typeGroups).reduce((acc, val) => acc.concat(val), [] as string[]);
 
-export function ModifyTableModal({ database, table, columns, onSave, onClose, loading }: Props) {
+export function ModifyTableModal({ database, schema = '', table, columns, onSave, onClose, loading }: Props) {
     const { t } = useTranslation();
     const [columnStates, setColumnStates] = useState<ColumnState[]>([]);
+    // Statements of the pending changes, once the user asked to review them
+    const [reviewing, setReviewing] = useState(false);
+    const [preview, setPreview] = useState<SQLPreview | null>(null);
+    const [previewError, setPreviewError] = useState<string | null>(null);
 
     useEffect(() => {
         setColumnStates(columns.map(c => ({ ...c, oldName: c.name })));
@@ -105,15 +113,27 @@ export function ModifyTableModal({ database, table, columns, onSave, onClose, lo
         setColumnStates(newStates);
     };
 
-    const handleSave = async () => {
-        const alteration: TableAlteration = {
-            addColumns: columnStates.filter(c => c.isNew && !c.isDeleted).map(({ isNew, isDeleted, isModified, oldName, ...c }) => c),
-            modifyColumns: columnStates.filter(c => !c.isNew && c.isModified && !c.isDeleted).map(({ isNew, isDeleted, isModified, ...c }) => ({ ...c, oldName: c.oldName })),
-            dropColumns: columnStates.filter(c => !c.isNew && c.isDeleted).map(c => c.oldName || c.name),
-            renameTo: table // For now, keep table name same
-        };
+    const buildAlteration = (): TableAlteration => ({
+        addColumns: columnStates.filter(c => c.isNew && !c.isDeleted).map(({ isNew, isDeleted, isModified, oldName, ...c }) => c),
+        modifyColumns: columnStates.filter(c => !c.isNew && c.isModified && !c.isDeleted).map(({ isNew, isDeleted, isModified, ...c }) => ({ ...c, oldName: c.oldName })),
+        dropColumns: columnStates.filter(c => !c.isNew && c.isDeleted).map(c => c.oldName || c.name),
+        renameTo: table // For now, keep table name same
+    });
+
+    // The review follows the edits while it is open
+    useEffect(() => {
+        if (!reviewing) return;
+        let active = true;
+        setPreview(null);
+        setPreviewError(null);
+        PreviewAlterTable(database, schema, table, buildAlteration())
+            .then(p => active && setPreview(p))
+            .catch(err => active && setPreviewError(typeof err === 'string' ? err : err.message));
+        return () => { active = false; };
+    }, [reviewing, columnStates]);
 
-        const success = await onSave(alteration);
+    const handleSave = async () => {
+        const success = await onSave(buildAlteration());
         if (success) onClose();
     };
 
@@ -232,13 +252,34 @@ export function ModifyTableModal({ database, table, columns, onSave, onClose, lo
                                                     </SelectContent>
                                                 </Select>
                                             ) : (
-                                                <Input
-                                                    value={col.default}
-                                                    onChange={(e) => updateColumn(index, { default: e.target.value })}
-                                                    placeholder={col.type.includes('date') ? "NOW()" : "NULL"}
-                                                    disabled={col.isDeleted}
-                                                    className="h-8 text-[11px] font-mono bg-background/50 border-muted-foreground/10"
-                                                />
+                                                <div className="flex items-center gap-1">
+                                                    <Input
+                                                        value={col.default}
+                                                        onChange={(e) => updateColumn(index, { default: e.target.value })}
+                                                        placeholder={col.type.includes('date') ? "NOW()" : "NULL"}
+                                                        disabled={col.isDeleted}
+                                                        className="h-8 text-[11px] font-mono bg-background/50 border-muted-foreground/10"
+                                                    />
+                                                    <TooltipProvider>
+                                                        <Tooltip>
+                                                            <TooltipTrigger asChild>
+                                                                <Toggle
+                                                                    pressed={!!col.defaultExpression}
+                                                                    onPressedChange={(pressed: boolean) => updateColumn(index, { defaultExpression: pressed })}
+                                                                    disabled={col.isDeleted}
+                                                                    variant="outline"
+                                                                    size="sm"
+                                                                    className={cn("h-6 w-6 p-0 text-[10px] font-mono italic", col.defaultExpression ? "bg-primary/20 text-primary" : "")}
+                                                                >
+                                                                    fx
+                                                                </Toggle>
+                                                            </TooltipTrigger>
+                                                            <TooltipContent>
+                                                                <p>{t('modifyModal.defaultExpressionTooltip')}</p>
+                                                            </TooltipContent>
+                                                        </Tooltip>
+                                                    </TooltipProvider>
+                                                </div>
                                             )}
                                         </div>
 
@@ -293,6 +334,12 @@ export function ModifyTableModal({ database, table, columns, onSave, onClose, lo
                     </Button>
                 </div>
 
+                {reviewing && (
+                    <div className="px-6 pb-4">
+                        <SQLPreviewBlock preview={preview} error={previewError} />
+                    </div>
+                )}
+
                 <DialogFooter className="p-6 bg-muted/20 border-t gap-3">
                     <div className="flex-1 flex items-center gap-4">
                         {(columnStates.some(c => c.isNew || c.isModified || c.isDeleted)) && (
@@ -306,6 +353,15 @@ export function ModifyTableModal({ database, table, columns, onSave, onClose, lo
                         <Button variant="ghost" className="h-10 px-6 text-[11px] font-bold uppercase tracking-widest hover:bg-destructive/5 hover:text-destructive" onClick={onClose}>
                             {t('modifyModal.discardChanges')}
                         </Button>
+                        <Button
+                            variant="outline"
+                            className="h-10 px-6 text-[11px] font-bold uppercase tracking-widest"
+                            onClick={() => setReviewing(r => !r)}
+                            disabled={!columnStates.some(c => c.isNew || c.isModified || c.isDeleted)}
+                        >
+                            <FileCode className="mr-2 h-4 w-4" />
+                            {t('modifyModal.reviewSql')}
+                        </Button>
                         <Button
                             className="h-10 px-8 text-[11px] font-black uppercase tracking-widest shadow-xl shadow-primary/20"
                             onClick={handleSave}
@@ -0,0 +1,174 @@
+import { useEffect, useState, useCallback } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Popover,
+    PopoverContent,
+    PopoverTrigger,
+} from "@/components/ui/popover";
+import { ScrollArea } from "@/components/ui/scroll-area";
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Radio, X, Send, Trash2 } from 'lucide-react';
+import { toast } from "sonner";
+import { cn } from '@/lib/utils';
+import { Listen, Unlisten, ListeningChannels, Notify } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { Notification, ListenerStatus } from '../types';
+
+// Messages kept in the log, newest first
+const MAX_MESSAGES = 200;
+
+export function NotificationsPopover() {
+    const { t } = useTranslation();
+    const [channels, setChannels] = useState<string[]>([]);
+    const [messages, setMessages] = useState<Notification[]>([]);
+    const [unread, setUnread] = useState(0);
+    const [open, setOpen] = useState(false);
+    const [channel, setChannel] = useState('');
+    const [payload, setPayload] = useState('');
+
+    const refresh = useCallback(() => {
+        ListeningChannels()
+            .then(list => setChannels(list || []))
+            .catch(err => console.error('Failed to list channels:', err));
+    }, []);
+
+    useEffect(() => {
+        refresh();
+        const offMessage = EventsOn('notify:message', (n: Notification) => {
+            setMessages(prev => [n, ...prev].slice(0, MAX_MESSAGES));
+            setUnread(prev => prev + 1);
+        });
+        const offStatus = EventsOn('notify:status', (s: ListenerStatus) => {
+            if (s.state === 'disconnected' || s.state === 'failed') {
+                toast.warning(t('notifications.lost', { error: s.error || s.state }));
+            } else if (s.state === 'reconnected') {
+                toast.info(t('notifications.reconnected'));
+            }
+        });
+        return () => {
+            offMessage();
+            offStatus();
+        };
+    }, [refresh, t]);
+
+    const handleListen = async () => {
+        const name = channel.trim();
+        if (!name) return;
+        try {
+            await Listen(name);
+            refresh();
+        } catch (err) {
+            toast.error(String(err));
+        }
+    };
+
+    const handleUnlisten = async (name: string) => {
+        try {
+            await Unlisten(name);
+            refresh();
+        } catch (err) {
+            toast.error(String(err));
+        }
+    };
+
+    const handleNotify = async () => {
+        const name = channel.trim();
+        if (!name) return;
+        try {
+            await Notify(name, payload);
+            setPayload('');
+        } catch (err) {
+            toast.error(String(err));
+        }
+    };
+
+    return (
+        <Popover open={open} onOpenChange={(o) => { setOpen(o); if (o) { setUnread(0); refresh(); } }}>
+            <PopoverTrigger asChild>
+                <button
+                    className={cn(
+                        "flex items-center gap-1.5 px-2 py-0.5 rounded text-[9px] font-black uppercase tracking-widest transition-colors",
+                        channels.length > 0 ? "bg-primary/10 text-primary border border-primary/20" : "text-muted-foreground/60 hover:text-foreground"
+                    )}
+                    title={t('notifications.title')}
+                >
+                    <Radio size={10} strokeWidth={3} className={cn(channels.length > 0 && "animate-pulse")} />
+                    {t('notifications.title')}
+                    {unread > 0 && <span className="px-1 rounded bg-primary text-primary-foreground">{unread}</span>}
+                </button>
+            </PopoverTrigger>
+            <PopoverContent className="w-[460px] p-0" align="end" side="top">
+                <div className="p-3 border-b bg-muted/30 space-y-2">
+                    <div className="flex gap-2">
+                        <Input
+                            value={channel}
+                            onChange={(e) => setChannel(e.target.value)}
+                            onKeyDown={(e) => e.key === 'Enter' && handleListen()}
+                            placeholder={t('notifications.channel')}
+                            className="h-7 text-[11px] font-mono"
+                        />
+                        <Button size="sm" className="h-7 text-[10px] font-bold" onClick={handleListen}>
+                            {t('notifications.listen')}
+                        </Button>
+                    </div>
+                    <div className="flex gap-2">
+                        <Input
+                            value={payload}
+                            onChange={(e) => setPayload(e.target.value)}
+                            onKeyDown={(e) => e.key === 'Enter' && handleNotify()}
+                            placeholder={t('notifications.payload')}
+                            className="h-7 text-[11px] font-mono"
+                        />
+                        <Button size="sm" variant="secondary" className="h-7 text-[10px] font-bold gap-1" onClick={handleNotify}>
+                            <Send size={10} />
+                            {t('notifications.notify')}
+                        </Button>
+                    </div>
+                    {channels.length > 0 && (
+                        <div className="flex flex-wrap gap-1">
+                            {channels.map(name => (
+                                <span key={name} className="flex items-center gap-1 px-1.5 py-0.5 rounded bg-primary/10 text-primary text-[10px] font-mono">
+                                    {name}
+                                    <button onClick={() => handleUnlisten(name)} title={t('notifications.unlisten')}>
+                                        <X size={10} />
+                                    </button>
+                                </span>
+                            ))}
+                        </div>
+                    )}
+                </div>
+                <div className="flex items-center justify-between px-3 py-1 border-b">
+                    <span className="text-[10px] font-black uppercase tracking-widest text-muted-foreground">
+                        {t('notifications.messages', { count: messages.length })}
+                    </span>
+                    <Button variant="ghost" size="icon" className="h-6 w-6" onClick={() => setMessages([])} title={t('queryEditor.clear')}>
+                        <Trash2 size={12} />
+                    </Button>
+                </div>
+                <ScrollArea className="h-[260px]">
+                    {messages.length === 0 ? (
+                        <div className="p-8 text-center text-[10px] text-muted-foreground uppercase font-bold tracking-widest opacity-60">
+                            {t('notifications.none')}
+                        </div>
+                    ) : (
+                        <div className="divide-y divide-border/40">
+                            {messages.map((n, i) => (
+                                <div key={`${n.receivedAt}-${i}`} className="px-3 py-1.5">
+                                    <div className="flex items-center gap-2 text-[9px] text-muted-foreground">
+                                        <span className="font-mono font-bold text-primary">{n.channel}</span>
+                                        <span>{new Date(n.receivedAt).toLocaleTimeString()}</span>
+                                        <span>PID {n.pid}</span>
+                                    </div>
+                                    <code className="block text-[11px] font-mono text-foreground/80 break-all select-text">
+                                        {n.payload}
+                                    </code>
+                                </div>
+                            ))}
+                        </div>
+                    )}
+                </ScrollArea>
+            </PopoverContent>
+        </Popover>
+    );
+}
@@ -0,0 +1,98 @@
+import { useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Checkbox } from '@/components/ui/checkbox';
+import { Braces, Play } from 'lucide-react';
+import { Placeholder } from '../types';
+
+interface Props {
+    parameters: Placeholder[];
+    // Values offered from the last run; null stands for SQL NULL
+    initialValues: Record<string, string | null>;
+    onSubmit: (values: Record<string, string | null>) => void;
+    onClose: () => void;
+}
+
+export function ParameterPrompt({ parameters, initialValues, onSubmit, onClose }: Props) {
+    const { t } = useTranslation();
+    const [values, setValues] = useState<Record<string, string | null>>(() => {
+        const v: Record<string, string | null> = {};
+        for (const p of parameters) {
+            v[p.name] = p.name in initialValues ? initialValues[p.name] : '';
+        }
+        return v;
+    });
+
+    const handleSubmit = (e: React.FormEvent) => {
+        e.preventDefault();
+        onSubmit(values);
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[460px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <form onSubmit={handleSubmit}>
+                    <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                        <div className="flex items-center gap-3 mb-2">
+                            <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                                <Braces size={20} />
+                            </div>
+                            <div>
+                                <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                    {t('parameterPrompt.title')}
+                                </DialogTitle>
+                                <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                    {t('parameterPrompt.subtitle', { count: parameters.length })}
+                                </p>
+                            </div>
+                        </div>
+                    </DialogHeader>
+
+                    <div className="p-6 space-y-3 max-h-[50vh] overflow-y-auto">
+                        {parameters.map((p, i) => (
+                            <div key={p.name} className="flex items-center gap-3">
+                                <span className="w-28 shrink-0 font-mono text-[11px] font-bold text-primary truncate" title={p.name}>
+                                    {p.name}
+                                </span>
+                                <Input
+                                    autoFocus={i === 0}
+                                    value={values[p.name] ?? ''}
+                                    disabled={values[p.name] === null}
+                                    placeholder={values[p.name] === null ? 'NULL' : ''}
+                                    onChange={(e) => setValues(prev => ({ ...prev, [p.name]: e.target.value }))}
+                                    className="h-8 text-[11px] font-mono bg-background/50"
+                                />
+                                <label className="flex items-center gap-1.5 text-[9px] font-black uppercase text-muted-foreground tracking-widest cursor-pointer">
+                                    <Checkbox
+                                        checked={values[p.name] === null}
+                                        onCheckedChange={(checked) => setValues(prev => ({ ...prev, [p.name]: checked ? null : '' }))}
+                                        className="h-4 w-4 rounded border-muted-foreground/30"
+                                    />
+                                    NULL
+                                </label>
+                            </div>
+                        ))}
+                    </div>
+
+                    <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                        <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                            {t('common.cancel')}
+                        </Button>
+                        <Button type="submit" className="text-[10px] font-black uppercase tracking-widest gap-2">
+                            <Play size={12} />
+                            {t('parameterPrompt.run')}
+                        </Button>
+                    </DialogFooter>
+                </form>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,221 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from '@/components/ui/button';
+import { Textarea } from '@/components/ui/textarea';
+import { ClipboardPaste, Loader2 } from 'lucide-react';
+import { toast } from "sonner";
+import { ColumnInfo, PasteOptions, PastePreview } from '../types';
+import { ReadClipboard, PreviewPaste, PasteRows } from '../../wailsjs/go/main/App';
+
+interface Props {
+    database: string;
+    schema?: string;
+    table: string;
+    columns: ColumnInfo[];
+    onApplied: () => void;
+    onClose: () => void;
+}
+
+// Marks a pasted column that is not inserted; Select items can't have an empty value
+const SKIP = '__skip__';
+
+// Inserts tab or comma separated rows from the clipboard, e.g. copied from a
+// spreadsheet, after showing how their columns map and how values convert
+export function PasteRowsModal({ database, schema = '', table, columns, onApplied, onClose }: Props) {
+    const { t } = useTranslation();
+    const [text, setText] = useState('');
+    const [options, setOptions] = useState<PasteOptions>({ header: 'auto' });
+    const [preview, setPreview] = useState<PastePreview | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [pasting, setPasting] = useState(false);
+
+    useEffect(() => {
+        ReadClipboard().then(setText).catch(() => setText(''));
+    }, []);
+
+    useEffect(() => {
+        if (!text.trim()) {
+            setPreview(null);
+            setError(null);
+            return;
+        }
+        PreviewPaste(database, schema, table, text, options)
+            .then(result => {
+                setPreview(result);
+                setError(null);
+            })
+            .catch(err => {
+                setPreview(null);
+                setError(typeof err === 'string' ? err : err.message);
+            });
+    }, [text, options]);
+
+    const changeMapping = (index: number, column: string) => {
+        if (!preview) return;
+        const mapping = preview.mapping.map((name, i) => i === index ? (column === SKIP ? '' : column) : name);
+        setOptions({ ...options, mapping });
+    };
+
+    const handlePaste = async () => {
+        setPasting(true);
+        try {
+            const result = await PasteRows(database, schema, table, text, options);
+            toast.success(t('pasteRows.pasted', { count: result.rowsImported }));
+            onApplied();
+            onClose();
+        } catch (err: any) {
+            toast.error(t('pasteRows.failed', { error: typeof err === 'string' ? err : err.message }));
+        } finally {
+            setPasting(false);
+        }
+    };
+
+    const errors = preview?.errors ?? [];
+    const writable = columns.filter(c => !c.readOnly);
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[820px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <ClipboardPaste size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('pasteRows.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {table}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-3 max-h-[65vh] overflow-y-auto">
+                    <Textarea
+                        value={text}
+                        onChange={(e) => {
+                            setText(e.target.value);
+                            setOptions({ ...options, mapping: undefined });
+                        }}
+                        placeholder={t('pasteRows.placeholder')}
+                        className="h-28 text-[11px] font-mono bg-background/50 whitespace-pre"
+                    />
+                    <div className="grid grid-cols-2 gap-3">
+                        <Select value={options.header} onValueChange={(header) => setOptions({ ...options, header: header as PasteOptions['header'], mapping: undefined })}>
+                            <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent>
+                                <SelectItem value="auto" className="text-[11px]">{t('pasteRows.headerAuto')}</SelectItem>
+                                <SelectItem value="yes" className="text-[11px]">{t('pasteRows.headerYes')}</SelectItem>
+                                <SelectItem value="no" className="text-[11px]">{t('pasteRows.headerNo')}</SelectItem>
+                            </SelectContent>
+                        </Select>
+                        <Select value={options.delimiter || 'auto'} onValueChange={(delimiter) => setOptions({ ...options, delimiter: delimiter === 'auto' ? undefined : delimiter, mapping: undefined })}>
+                            <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent>
+                                <SelectItem value="auto" className="text-[11px]">{t('pasteRows.delimiterAuto')}</SelectItem>
+                                <SelectItem value={'\t'} className="text-[11px]">{t('pasteRows.tab')}</SelectItem>
+                                <SelectItem value="," className="text-[11px]">{t('pasteRows.comma')}</SelectItem>
+                                <SelectItem value=";" className="text-[11px]">{t('pasteRows.semicolon')}</SelectItem>
+                            </SelectContent>
+                        </Select>
+                    </div>
+
+                    {error && <p className="text-[11px] text-destructive font-mono">{error}</p>}
+
+                    {preview && (
+                        <>
+                            <div className="rounded-md border overflow-x-auto">
+                                <table className="w-full text-[11px] font-mono">
+                                    <thead className="bg-muted/30">
+                                        <tr>
+                                            {preview.source.map((source, i) => (
+                                                <th key={i} className="p-1.5 text-left font-normal min-w-[140px]">
+                                                    <div className="text-[9px] text-muted-foreground uppercase tracking-widest truncate mb-1" title={source}>{source}</div>
+                                                    <Select value={preview.mapping[i] || SKIP} onValueChange={(column) => changeMapping(i, column)}>
+                                                        <SelectTrigger className="h-7 text-[11px] font-mono bg-background/50">
+                                                            <SelectValue />
+                                                        </SelectTrigger>
+                                                        <SelectContent>
+                                                            <SelectItem value={SKIP} className="text-[11px] italic">{t('pasteRows.skip')}</SelectItem>
+                                                            {writable.map(c => (
+                                                                <SelectItem key={c.name} value={c.name} className="text-[11px] font-mono">
+                                                                    {c.name} <span className="text-muted-foreground">{c.type}</span>
+                                                                </SelectItem>
+                                                            ))}
+                                                        </SelectContent>
+                                                    </Select>
+                                                </th>
+                                            ))}
+                                        </tr>
+                                    </thead>
+                                    <tbody>
+                                        {preview.rows.map((row, r) => (
+                                            <tr key={r} className="border-t">
+                                                {preview.mapping.map((name, i) => {
+                                                    const value = name ? row[preview.columns.indexOf(name)] : undefined;
+                                                    return (
+                                                        <td key={i} className="p-1.5 truncate max-w-[220px]">
+                                                            {!name ? '' : value === null ? <span className="italic text-muted-foreground">NULL</span> : String(value)}
+                                                        </td>
+                                                    );
+                                                })}
+                                            </tr>
+                                        ))}
+                                    </tbody>
+                                </table>
+                            </div>
+                            <p className="text-[10px] text-muted-foreground">
+                                {t('pasteRows.summary', { count: preview.totalRows, shown: preview.rows.length })}
+                            </p>
+                            {errors.length > 0 && (
+                                <div className="space-y-1">
+                                    <p className="text-[11px] font-bold text-destructive">{t('pasteRows.errors', { count: errors.length })}</p>
+                                    {errors.slice(0, 10).map((e, i) => (
+                                        <p key={i} className="text-[10px] font-mono text-destructive">
+                                            {t('pasteRows.error', { row: e.row, column: e.column, value: e.value, message: e.message })}
+                                        </p>
+                                    ))}
+                                </div>
+                            )}
+                        </>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.cancel')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={!preview || preview.totalRows === 0 || errors.length > 0 || pasting}
+                        onClick={handlePaste}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        {pasting ? <Loader2 size={12} className="animate-spin" /> : <ClipboardPaste size={12} />}
+                        {t('pasteRows.paste', { count: preview?.totalRows ?? 0 })}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
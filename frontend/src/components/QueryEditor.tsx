@@ -2,7 +2,7 @@ import React, { useEffect, useRef, useState, useCallback } from 'react';
 import { useTranslation } from 'react-i18next';
 import Editor, { loader, Monaco } from '@monaco-editor/react';
 import { Button } from '@/components/ui/button';
-import { Play, Sparkles, Code2, Trash2, History, Clock, AlignLeft } from 'lucide-react';
+import { Play, Square, Sparkles, Code2, Trash2, History, Clock, AlignLeft, AlertCircle, Hourglass, Download, GitBranch, Check, Undo2, Bookmark } from 'lucide-react';
 import { Badge } from '@/components/ui/badge';
 import { Separator } from '@/components/ui/separator';
 import { cn } from '@/lib/utils';
@@ -12,8 +12,12 @@ import {
     PopoverTrigger,
 } from "@/components/ui/popover";
 import { ScrollArea } from "@/components/ui/scroll-area";
-import { format as formatSQL } from 'sql-formatter';
+import { Input } from '@/components/ui/input';
+import { SearchHistory, PruneHistory, FormatSQL } from '../../wailsjs/go/main/App';
+import { HistoryEntry, TransactionState } from '../types';
+import { toast } from "sonner";
 import { useTheme } from '../contexts/ThemeContext';
+import { CSVExportModal } from './CSVExportModal';
 
 // Configure Monaco loader
 loader.config({ paths: { vs: 'https://cdn.jsdelivr.net/npm/monaco-editor@0.43.0/min/vs' } });
@@ -22,23 +26,43 @@ interface Props {
     value: string;
     onChange: (value: string) => void;
     onExecute: (sql?: string) => void;
+    onCancel?: () => void;
+    onRunInBackground?: (sql: string) => void;
     loading: boolean;
     schema: Record<string, string[]> | null;
+    // Transaction held open by this tab's session, if any
+    transaction?: TransactionState;
+    onBegin?: () => void;
+    onCommit?: () => void;
+    onRollback?: () => void;
+    onOpenSavedQueries?: () => void;
 }
 
-export function QueryEditor({ value, onChange, onExecute, loading, schema }: Props) {
+export function QueryEditor({ value, onChange, onExecute, onCancel, onRunInBackground, loading, schema, transaction, onBegin, onCommit, onRollback, onOpenSavedQueries }: Props) {
     const { t } = useTranslation();
     const { resolvedTheme } = useTheme();
     const [monacoInstance, setMonacoInstance] = useState<Monaco | null>(null);
     const [historyOpen, setHistoryOpen] = useState(false);
-    const [queryHistory, setQueryHistory] = useState<string[]>(() => {
-        const saved = localStorage.getItem('rune_query_history');
-        return saved ? JSON.parse(saved) : [];
-    });
+    const [historySearch, setHistorySearch] = useState('');
+    const [queryHistory, setQueryHistory] = useState<HistoryEntry[]>([]);
+    const [exportQuery, setExportQuery] = useState<string | null>(null);
 
+    // Executed statements are recorded by the backend; search them while the popover is open
     useEffect(() => {
-        localStorage.setItem('rune_query_history', JSON.stringify(queryHistory));
-    }, [queryHistory]);
+        if (!historyOpen) return;
+        const timer = setTimeout(() => {
+            SearchHistory({ text: historySearch, connection: '', database: '', errorsOnly: false, limit: 50, offset: 0 })
+                .then(entries => setQueryHistory(entries || []))
+                .catch(err => console.error('Failed to load history:', err));
+        }, 200);
+        return () => clearTimeout(timer);
+    }, [historyOpen, historySearch]);
+
+    const clearHistory = useCallback(() => {
+        PruneHistory({ olderThanDays: 0, keepLast: 0, all: true })
+            .then(() => setQueryHistory([]))
+            .catch(err => console.error('Failed to clear history:', err));
+    }, []);
 
     // Verify schema prop
     useEffect(() => {
@@ -50,18 +74,14 @@ export function QueryEditor({ value, onChange, onExecute, loading, schema }: Pro
 
     const editorRef = useRef<any>(null);
 
-    // SQL Format handler
-    const handleFormat = useCallback(() => {
+    // SQL Format handler; the backend formats in the dialect of the connection
+    const handleFormat = useCallback(async () => {
         try {
-            const formatted = formatSQL(value, {
-                language: 'mysql',
-                tabWidth: 2,
-                keywordCase: 'upper',
-                linesBetweenQueries: 2,
-            });
+            const formatted = await FormatSQL(value, { indent: 2, keywordCase: 'upper' });
             onChange(formatted);
         } catch (e) {
             console.error('SQL Format error:', e);
+            toast.error(String(e));
         }
     }, [value, onChange]);
 
@@ -79,8 +99,8 @@ export function QueryEditor({ value, onChange, onExecute, loading, schema }: Pro
         });
     };
 
-    // Update history when executing
-    const handleExecuteWrapper = () => {
+    // The selected text, or the whole script when nothing is selected
+    const sqlToRun = () => {
         const editor = editorRef.current;
         let sqlToExecute = value;
         const selection = editor?.getSelection();
@@ -93,18 +113,24 @@ export function QueryEditor({ value, onChange, onExecute, loading, schema }: Pro
             }
         }
 
+        return sqlToExecute;
+    };
+
+    // Update history when executing
+    const handleExecuteWrapper = () => {
+        const sqlToExecute = sqlToRun();
         if (sqlToExecute.trim()) {
-            // Only add to history if it's the full query (optional choice, but cleaner)
-            if (sqlToExecute === value) {
-                setQueryHistory(prev => {
-                    const newHistory = [value, ...prev.filter(q => q !== value)].slice(0, 50);
-                    return newHistory;
-                });
-            }
             onExecute(sqlToExecute);
         }
     };
 
+    const handleRunInBackground = () => {
+        const sqlToExecute = sqlToRun();
+        if (sqlToExecute.trim() && onRunInBackground) {
+            onRunInBackground(sqlToExecute);
+        }
+    };
+
     const schemaRef = useRef(schema);
     schemaRef.current = schema;
 
@@ -342,9 +368,40 @@ export function QueryEditor({ value, onChange, onExecute, loading, schema }: Pro
                         <Code2 size={12} className="text-muted-foreground/60" />
                         {t('queryEditor.console')}
                     </div>
+                    {transaction?.inTransaction && (
+                        <Badge
+                            variant="outline"
+                            className={cn(
+                                "h-5 px-1.5 font-mono text-[9px] uppercase tracking-tighter",
+                                transaction.aborted
+                                    ? "border-destructive/40 text-destructive"
+                                    : transaction.dirty
+                                        ? "border-amber-500/40 text-amber-500"
+                                        : "border-primary/30 text-primary"
+                            )}
+                            title={t('queryEditor.transactionStatements', { count: transaction.statements })}
+                        >
+                            {transaction.aborted
+                                ? t('queryEditor.transactionAborted')
+                                : transaction.dirty
+                                    ? t('queryEditor.transactionDirty')
+                                    : t('queryEditor.inTransaction')}
+                        </Badge>
+                    )}
                 </div>
 
                 <div className="flex items-center gap-2">
+                    {onOpenSavedQueries && (
+                        <Button
+                            variant="ghost"
+                            size="icon"
+                            className="h-7 w-7 text-muted-foreground hover:text-foreground"
+                            onClick={onOpenSavedQueries}
+                            title={t('savedQueries.title')}
+                        >
+                            <Bookmark size={14} />
+                        </Button>
+                    )}
                     <Popover open={historyOpen} onOpenChange={setHistoryOpen}>
                         <PopoverTrigger asChild>
                             <Button
@@ -363,11 +420,19 @@ export function QueryEditor({ value, onChange, onExecute, loading, schema }: Pro
                                     variant="ghost"
                                     size="sm"
                                     className="h-6 text-[9px] hover:text-destructive"
-                                    onClick={() => setQueryHistory([])}
+                                    onClick={clearHistory}
                                 >
                                     {t('queryEditor.clear')}
                                 </Button>
                             </div>
+                            <div className="p-2 border-b">
+                                <Input
+                                    value={historySearch}
+                                    onChange={e => setHistorySearch(e.target.value)}
+                                    placeholder={t('queryEditor.searchHistory')}
+                                    className="h-7 text-xs"
+                                />
+                            </div>
                             <ScrollArea className="h-[300px]">
                                 <div className="p-1">
                                     {queryHistory.length === 0 && (
@@ -375,20 +440,28 @@ export function QueryEditor({ value, onChange, onExecute, loading, schema }: Pro
                                             {t('queryEditor.noHistory')}
                                         </div>
                                     )}
-                                    {queryHistory.map((q, i) => (
+                                    {queryHistory.map(entry => (
                                         <div
-                                            key={i}
+                                            key={entry.id}
                                             className="p-3 hover:bg-muted/50 rounded-lg cursor-pointer group transition-colors border border-transparent hover:border-border/40 mb-1"
                                             onClick={() => {
-                                                onChange(q);
+                                                onChange(entry.statement);
                                                 setHistoryOpen(false);
                                             }}
+                                            title={entry.error}
                                         >
                                             <div className="flex items-start gap-3">
-                                                <Clock size={12} className="mt-1 text-muted-foreground/50 shrink-0" />
-                                                <code className="text-[11px] font-mono text-foreground/80 break-all leading-tight">
-                                                    {q.length > 150 ? q.substring(0, 150) + '...' : q}
-                                                </code>
+                                                {entry.error
+                                                    ? <AlertCircle size={12} className="mt-1 text-destructive shrink-0" />
+                                                    : <Clock size={12} className="mt-1 text-muted-foreground/50 shrink-0" />}
+                                                <div className="min-w-0 flex-1">
+                                                    <code className="text-[11px] font-mono text-foreground/80 break-all leading-tight">
+                                                        {entry.statement.length > 150 ? entry.statement.substring(0, 150) + '...' : entry.statement}
+                                                    </code>
+                                                    <div className="mt-1 text-[9px] text-muted-foreground truncate">
+                                                        {new Date(entry.executedAt).toLocaleString()} · {entry.database || entry.connection} · {t('queryEditor.historyStats', { rows: entry.rowCount, ms: Math.round(entry.durationMs) })}
+                                                    </div>
+                                                </div>
                                             </div>
                                         </div>
                                     ))}
@@ -416,7 +489,79 @@ export function QueryEditor({ value, onChange, onExecute, loading, schema }: Pro
                     >
                         <Trash2 size={14} />
                     </Button>
+                    <Button
+                        variant="ghost"
+                        size="icon"
+                        className="h-7 w-7 text-muted-foreground hover:text-primary"
+                        onClick={() => {
+                            const sql = sqlToRun();
+                            if (sql.trim()) setExportQuery(sql);
+                        }}
+                        disabled={!value.trim()}
+                        title={t('queryEditor.exportCSV')}
+                    >
+                        <Download size={14} />
+                    </Button>
+                    {onRunInBackground && (
+                        <Button
+                            variant="ghost"
+                            size="icon"
+                            className="h-7 w-7 text-muted-foreground hover:text-primary"
+                            onClick={handleRunInBackground}
+                            title={t('queryEditor.runInBackground')}
+                        >
+                            <Hourglass size={14} />
+                        </Button>
+                    )}
+                    {onBegin && !transaction?.inTransaction && (
+                        <Button
+                            variant="ghost"
+                            size="icon"
+                            className="h-7 w-7 text-muted-foreground hover:text-primary"
+                            onClick={onBegin}
+                            disabled={loading}
+                            title={t('queryEditor.beginTransaction')}
+                        >
+                            <GitBranch size={14} />
+                        </Button>
+                    )}
+                    {transaction?.inTransaction && (
+                        <>
+                            <Button
+                                variant="ghost"
+                                size="icon"
+                                className="h-7 w-7 text-muted-foreground hover:text-primary"
+                                onClick={onCommit}
+                                disabled={loading || transaction.aborted}
+                                title={t('queryEditor.commitTransaction')}
+                            >
+                                <Check size={14} />
+                            </Button>
+                            <Button
+                                variant="ghost"
+                                size="icon"
+                                className="h-7 w-7 text-muted-foreground hover:text-destructive"
+                                onClick={onRollback}
+                                disabled={loading}
+                                title={t('queryEditor.rollbackTransaction')}
+                            >
+                                <Undo2 size={14} />
+                            </Button>
+                        </>
+                    )}
                     <Separator orientation="vertical" className="h-4" />
+                    {loading && onCancel && (
+                        <Button
+                            variant="destructive"
+                            size="sm"
+                            className="h-7 px-3 text-[11px] font-bold"
+                            onClick={onCancel}
+                            title={t('queryEditor.stopTooltip')}
+                        >
+                            <Square className="mr-1.5 h-3 w-3 fill-current" />
+                            {t('queryEditor.stop')}
+                        </Button>
+                    )}
                     <Button
                         size="sm"
                         className="h-7 px-3 text-[11px] font-bold bg-primary hover:bg-primary/90 shadow-sm shadow-primary/20"
@@ -464,6 +609,10 @@ export function QueryEditor({ value, onChange, onExecute, loading, schema }: Pro
                     }}
                 />
             </div>
+
+            {exportQuery !== null && (
+                <CSVExportModal query={exportQuery} onClose={() => setExportQuery(null)} />
+            )}
         </div>
     );
 }
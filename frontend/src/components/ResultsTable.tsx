@@ -1,6 +1,6 @@
 import React, { useState, useEffect, useRef, useMemo } from 'react';
 import { useTranslation } from 'react-i18next';
-import { QueryResult } from '../types';
+import { CopyFormat, QueryResult } from '../types';
 import {
     AlertCircle,
     Terminal,
@@ -15,7 +15,11 @@ import {
     ArrowUp,
     ArrowDown,
     ArrowUpDown,
-    Filter
+    Filter,
+    Timer,
+    Gauge,
+    Sheet,
+    CloudUpload
 } from 'lucide-react';
 import {
     Table,
@@ -46,11 +50,18 @@ import {
 import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
 import { toast } from "sonner";
+import { SelectExportPath, ExportResultsXLSX, ExportResultsMarkup } from '../../wailsjs/go/main/App';
+import { copyRows, resultColumns } from '@/lib/copy';
+import { GoogleSheetsModal } from './GoogleSheetsModal';
 
 interface Props {
     results?: QueryResult[];
     result?: QueryResult | null; // Deprecated
     error: string | null;
+    hasMore?: boolean[]; // by result index: more rows can be streamed in
+    onLoadMore?: (index: number) => void;
+    analyze?: boolean; // queries are also run under EXPLAIN ANALYZE
+    onAnalyzeChange?: (on: boolean) => void;
 }
 
 // Helper to detect JSON
@@ -75,53 +86,44 @@ const formatJSON = (val: string): string => {
     }
 };
 
-// Copy helpers
-const copyAsCSV = (rows: any[][], columns: string[], t: any) => {
-    const header = columns.join(',');
-    const data = rows.map(row =>
-        row.map(cell => {
-            if (cell === null) return '';
-            const str = String(cell);
-            return str.includes(',') || str.includes('"') || str.includes('\n')
-                ? `"${str.replace(/"/g, '""')}"`
-                : str;
-        }).join(',')
-    ).join('\n');
-    navigator.clipboard.writeText(`${header}\n${data}`);
-    toast.success(t('resultsTable.copiedToClipboard'));
+// Copies rows through the backend, which formats values by column type
+const copyAs = (format: CopyFormat, rows: any[][], result: QueryResult, t: any) => {
+    copyRows(format, resultColumns(result.columns, result.columnTypes || []), rows, 'table_name')
+        .then(() => toast.success(t('resultsTable.copiedToClipboard')))
+        .catch((err: any) => toast.error(typeof err === 'string' ? err : err.message));
 };
 
-const copyAsJSON = (rows: any[][], columns: string[], t: any) => {
-    const data = rows.map(row => {
-        const obj: Record<string, any> = {};
-        columns.forEach((col, i) => {
-            obj[col] = row[i];
-        });
-        return obj;
-    });
-    navigator.clipboard.writeText(JSON.stringify(data, null, 2));
-    toast.success(t('resultsTable.copiedToClipboard'));
-};
-
-const copyAsSQLInsert = (rows: any[][], columns: string[], t: any, tableName: string = 'table_name') => {
-    const inserts = rows.map(row => {
-        const values = row.map(cell => {
-            if (cell === null) return 'NULL';
-            if (typeof cell === 'number') return String(cell);
-            return `'${String(cell).replace(/'/g, "''")}'`;
-        }).join(', ');
-        return `INSERT INTO ${tableName} (${columns.join(', ')}) VALUES (${values});`;
-    }).join('\n');
-    navigator.clipboard.writeText(inserts);
-    toast.success(t('resultsTable.copiedToClipboard'));
-};
-
-export function ResultsTable({ results, result, error }: Props) {
+export function ResultsTable({ results, result, error, hasMore, onLoadMore, analyze, onAnalyzeChange }: Props) {
     const { t } = useTranslation();
     const data = results || (result ? [result] : []);
+
+    // Every result set goes to a sheet of its own, with the rows loaded so far
+    const exportToExcel = async () => {
+        try {
+            const path = await SelectExportPath('xlsx');
+            if (!path) return; // Cancelled
+            await ExportResultsXLSX(data, path);
+            toast.success(t('resultsTable.exportedExcel', { count: data.length }));
+        } catch (err: any) {
+            toast.error(t('resultsTable.exportExcelFailed', { error: typeof err === 'string' ? err : err.message }));
+        }
+    };
+
+    // Tables for wikis, pull requests and incident docs, a heading per result set
+    const exportMarkup = async (format: 'markdown' | 'html') => {
+        try {
+            const path = await SelectExportPath(format);
+            if (!path) return; // Cancelled
+            await ExportResultsMarkup(data, format, path);
+            toast.success(t('resultsTable.exportedMarkup', { count: data.length }));
+        } catch (err: any) {
+            toast.error(t('resultsTable.exportMarkupFailed', { error: typeof err === 'string' ? err : err.message }));
+        }
+    };
     const [activeIndex, setActiveIndex] = useState(0);
     const [selectedRows, setSelectedRows] = useState<Set<number>>(new Set());
     const [jsonPreview, setJsonPreview] = useState<{ value: string; open: boolean }>({ value: '', open: false });
+    const [showSheets, setShowSheets] = useState(false);
 
     // Client-side Sorting & Filtering
     const [sortConfig, setSortConfig] = useState<{ index: number; direction: 'asc' | 'desc' } | null>(null);
@@ -305,8 +307,50 @@ export function ResultsTable({ results, result, error }: Props) {
                     <Separator orientation="vertical" className="h-3" />
                     <div className="flex items-center gap-1.5 shrink-0">
                         <Hash size={12} className="text-muted-foreground/40" />
-                        {activeResult?.rowCount?.toLocaleString()} rows
+                        {activeResult?.rowCount?.toLocaleString()}{hasMore?.[activeIndex] ? '+' : ''} rows
+                        {activeResult && activeResult.rows.length < activeResult.rowCount && (
+                            <span className="normal-case tracking-normal opacity-60">
+                                {t('resultsTable.firstRowsShown', { count: activeResult.rows.length })}
+                            </span>
+                        )}
                     </div>
+                    {hasMore?.[activeIndex] && onLoadMore && (
+                        <button
+                            onClick={() => onLoadMore(activeIndex)}
+                            className="px-2 py-0.5 rounded text-[9px] border border-primary/20 bg-primary/10 text-primary hover:bg-primary/20 transition-colors"
+                        >
+                            {t('resultsTable.loadMore')}
+                        </button>
+                    )}
+
+                    {activeResult?.stats && (
+                        <>
+                            <Separator orientation="vertical" className="h-3" />
+                            <div
+                                className="flex items-center gap-1.5 shrink-0 normal-case tracking-normal font-mono"
+                                title={t('resultsTable.statsDetail', {
+                                    ms: activeResult.stats.wallTimeMs.toFixed(1),
+                                    fetched: activeResult.stats.rowsFetched,
+                                    affected: activeResult.stats.rowsAffected,
+                                    roundtrips: activeResult.stats.estimatedRoundtrips
+                                })}
+                            >
+                                <Timer size={12} className="text-muted-foreground/40" />
+                                {t('resultsTable.stats', { ms: activeResult.stats.wallTimeMs.toFixed(1), roundtrips: activeResult.stats.estimatedRoundtrips })}
+                                {activeResult.stats.planningTimeMs !== undefined && (
+                                    <span>· {t('resultsTable.planning', { ms: activeResult.stats.planningTimeMs.toFixed(2) })}</span>
+                                )}
+                                {activeResult.stats.executionTimeMs !== undefined && (
+                                    <span>· {t('resultsTable.execution', { ms: activeResult.stats.executionTimeMs.toFixed(2) })}</span>
+                                )}
+                                {activeResult.stats.analyzeError && (
+                                    <span title={t('resultsTable.analyzeFailed', { error: activeResult.stats.analyzeError })}>
+                                        <AlertCircle size={12} className="text-destructive" />
+                                    </span>
+                                )}
+                            </div>
+                        </>
+                    )}
 
                     {selectedRows.size > 0 && (
                         <>
@@ -318,6 +362,53 @@ export function ResultsTable({ results, result, error }: Props) {
                     )}
                 </div>
                 <div className="flex items-center gap-2">
+                    <button
+                        onClick={exportToExcel}
+                        className="flex items-center gap-1 px-2 py-0.5 rounded text-[9px] font-bold uppercase border border-transparent text-muted-foreground hover:bg-muted/20 transition-colors"
+                        title={t('resultsTable.exportExcelHint')}
+                    >
+                        <Sheet size={10} />
+                        {t('resultsTable.exportExcel')}
+                    </button>
+                    <button
+                        onClick={() => exportMarkup('markdown')}
+                        className="flex items-center gap-1 px-2 py-0.5 rounded text-[9px] font-bold uppercase border border-transparent text-muted-foreground hover:bg-muted/20 transition-colors"
+                        title={t('resultsTable.exportMarkdownHint')}
+                    >
+                        <Hash size={10} />
+                        {t('resultsTable.exportMarkdown')}
+                    </button>
+                    <button
+                        onClick={() => exportMarkup('html')}
+                        className="flex items-center gap-1 px-2 py-0.5 rounded text-[9px] font-bold uppercase border border-transparent text-muted-foreground hover:bg-muted/20 transition-colors"
+                        title={t('resultsTable.exportHTMLHint')}
+                    >
+                        <FileText size={10} />
+                        {t('resultsTable.exportHTML')}
+                    </button>
+                    <button
+                        onClick={() => setShowSheets(true)}
+                        className="flex items-center gap-1 px-2 py-0.5 rounded text-[9px] font-bold uppercase border border-transparent text-muted-foreground hover:bg-muted/20 transition-colors"
+                        title={t('resultsTable.exportGoogleSheetsHint')}
+                    >
+                        <CloudUpload size={10} />
+                        {t('resultsTable.exportGoogleSheets')}
+                    </button>
+                    {onAnalyzeChange && (
+                        <button
+                            onClick={() => onAnalyzeChange(!analyze)}
+                            className={cn(
+                                "flex items-center gap-1 px-2 py-0.5 rounded text-[9px] font-bold uppercase border transition-colors",
+                                analyze
+                                    ? "bg-primary/10 text-primary border-primary/20"
+                                    : "text-muted-foreground border-transparent hover:bg-muted/20"
+                            )}
+                            title={t('resultsTable.analyzeHint')}
+                        >
+                            <Gauge size={10} />
+                            {t('resultsTable.analyze')}
+                        </button>
+                    )}
                     {rows.length > 100 && (
                         <Badge variant="outline" className="text-[8px] font-mono font-bold border-green-500/30 text-green-500">
                             {t('resultsTable.virtual')}
@@ -464,15 +555,23 @@ export function ResultsTable({ results, result, error }: Props) {
                     </div>
                 </ContextMenuTrigger>
                 <ContextMenuContent className="w-56">
-                    <ContextMenuItem onClick={() => copyAsCSV(getSelectedRowsData(), columns, t)}>
+                    <ContextMenuItem onClick={() => copyAs('csv', getSelectedRowsData(), activeResult, t)}>
                         <FileText className="mr-2 h-4 w-4" />
                         {t('resultsTable.copyAsCSV')}
                     </ContextMenuItem>
-                    <ContextMenuItem onClick={() => copyAsJSON(getSelectedRowsData(), columns, t)}>
+                    <ContextMenuItem onClick={() => copyAs('tsv', getSelectedRowsData(), activeResult, t)}>
+                        <Sheet className="mr-2 h-4 w-4" />
+                        {t('resultsTable.copyAsTSV')}
+                    </ContextMenuItem>
+                    <ContextMenuItem onClick={() => copyAs('markdown', getSelectedRowsData(), activeResult, t)}>
+                        <Hash className="mr-2 h-4 w-4" />
+                        {t('resultsTable.copyAsMarkdown')}
+                    </ContextMenuItem>
+                    <ContextMenuItem onClick={() => copyAs('json', getSelectedRowsData(), activeResult, t)}>
                         <FileJson className="mr-2 h-4 w-4" />
                         {t('resultsTable.copyAsJSON')}
                     </ContextMenuItem>
-                    <ContextMenuItem onClick={() => copyAsSQLInsert(getSelectedRowsData(), columns, t)}>
+                    <ContextMenuItem onClick={() => copyAs('insert', getSelectedRowsData(), activeResult, t)}>
                         <Database className="mr-2 h-4 w-4" />
                         {t('resultsTable.copyAsSQL')}
                     </ContextMenuItem>
@@ -523,6 +622,8 @@ export function ResultsTable({ results, result, error }: Props) {
                     </div>
                 </DialogContent>
             </Dialog>
+
+            {showSheets && activeResult && <GoogleSheetsModal result={activeResult} onClose={() => setShowSheets(false)} />}
         </div>
     );
 }
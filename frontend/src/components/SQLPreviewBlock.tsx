@@ -0,0 +1,43 @@
+import { useTranslation } from 'react-i18next';
+import { Loader2, Copy } from 'lucide-react';
+import { toast } from "sonner";
+import { SQLPreview } from '../types';
+
+interface Props {
+    preview: SQLPreview | null;
+    error?: string | null;
+}
+
+// Shows the SQL a change is about to run, with its values written in
+export function SQLPreviewBlock({ preview, error }: Props) {
+    const { t } = useTranslation();
+
+    if (error) {
+        return <div className="text-[11px] text-destructive font-mono break-all">{t('sqlPreview.failed', { error })}</div>;
+    }
+    if (!preview) {
+        return (
+            <div className="flex items-center gap-2 text-[10px] text-muted-foreground uppercase font-bold tracking-widest">
+                <Loader2 size={12} className="animate-spin" />
+                {t('sqlPreview.loading')}
+            </div>
+        );
+    }
+
+    const sql = preview.statements.map(s => s.sql + ';').join('\n');
+    return (
+        <div className="relative rounded-md border bg-muted/30">
+            <div className="flex items-center justify-between px-3 py-1 border-b text-[9px] font-black uppercase tracking-widest text-muted-foreground">
+                {t('sqlPreview.title', { count: preview.statements.length })}
+                <button
+                    onClick={() => navigator.clipboard.writeText(sql).then(() => toast.success(t('resultsTable.copiedToClipboard')))}
+                    className="hover:text-foreground"
+                    title={t('sqlPreview.copy')}
+                >
+                    <Copy size={10} />
+                </button>
+            </div>
+            <pre className="max-h-48 overflow-auto p-3 text-[11px] font-mono whitespace-pre-wrap break-all select-text">{sql}</pre>
+        </div>
+    );
+}
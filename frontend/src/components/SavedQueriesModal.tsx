@@ -0,0 +1,206 @@
+import { useEffect, useMemo, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+} from '@/components/ui/dialog';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { ScrollArea } from '@/components/ui/scroll-area';
+import { Bookmark, Folder, Trash2, Save } from 'lucide-react';
+import { toast } from "sonner";
+import { SavedQuery } from '../types';
+import { LoadSavedQueries, SaveQuery, DeleteSavedQuery, DetectParameters } from '../../wailsjs/go/main/App';
+
+interface Props {
+    sql: string; // SQL of the active query tab, offered for saving
+    connection?: string; // saved connection in use; only its queries are listed
+    savedQueryId?: string; // saved query the active tab was opened from
+    onOpen: (saved: SavedQuery) => void;
+    onClose: () => void;
+}
+
+const errorText = (err: any) => typeof err === 'string' ? err : err.message;
+
+// Lists the saved queries by folder to search and open them, and saves the
+// query of the active tab
+export function SavedQueriesModal({ sql, connection, savedQueryId, onOpen, onClose }: Props) {
+    const { t } = useTranslation();
+    const [queries, setQueries] = useState<SavedQuery[]>([]);
+    const [search, setSearch] = useState('');
+    const [form, setForm] = useState({ name: '', folder: '', description: '' });
+    const [confirmDelete, setConfirmDelete] = useState('');
+
+    const load = () => LoadSavedQueries(connection || '').then(list => setQueries(list || [])).catch(err => toast.error(errorText(err)));
+
+    useEffect(() => {
+        load();
+    }, []);
+
+    const linked = queries.find(q => q.id === savedQueryId);
+
+    // Matches on the name, folder, description and SQL, grouped by folder
+    const folders = useMemo(() => {
+        const needle = search.trim().toLowerCase();
+        const groups = new Map<string, SavedQuery[]>();
+        for (const q of queries) {
+            const text = [q.name, q.folder, q.description, q.sql].join('\n').toLowerCase();
+            if (needle && !text.includes(needle)) continue;
+            const folder = q.folder || '';
+            groups.set(folder, [...(groups.get(folder) || []), q]);
+        }
+        return [...groups.entries()]
+            .sort(([a], [b]) => a.localeCompare(b))
+            .map(([folder, list]) => [folder, list.sort((a, b) => a.name.localeCompare(b.name))] as const);
+    }, [queries, search]);
+
+    // Placeholders of the SQL become the parameters of the saved query
+    const parametersOf = async (text: string, previous: SavedQuery['parameters'] = []) => {
+        const names = [...new Set((await DetectParameters(text) || []).map(p => p.name))];
+        return names.map(name => previous.find(p => p.name === name) || { name });
+    };
+
+    const handleSave = async () => {
+        try {
+            const saved = await SaveQuery({
+                id: '',
+                name: form.name,
+                folder: form.folder,
+                description: form.description,
+                sql,
+                parameters: await parametersOf(sql),
+                createdAt: '',
+                updatedAt: '',
+            });
+            toast.success(t('savedQueries.saved', { name: saved.name }));
+            setForm({ name: '', folder: '', description: '' });
+            load();
+        } catch (err: any) {
+            toast.error(errorText(err));
+        }
+    };
+
+    const handleUpdate = async () => {
+        if (!linked) return;
+        try {
+            await SaveQuery({ ...linked, sql, parameters: await parametersOf(sql, linked.parameters) });
+            toast.success(t('savedQueries.saved', { name: linked.name }));
+            load();
+        } catch (err: any) {
+            toast.error(errorText(err));
+        }
+    };
+
+    const handleDelete = async (saved: SavedQuery) => {
+        // The first click asks for confirmation
+        if (confirmDelete !== saved.id) {
+            setConfirmDelete(saved.id);
+            return;
+        }
+        try {
+            await DeleteSavedQuery(saved.id);
+            setConfirmDelete('');
+            load();
+        } catch (err: any) {
+            toast.error(errorText(err));
+        }
+    };
+
+    const handleOpen = (saved: SavedQuery) => {
+        onOpen(saved);
+        onClose();
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[640px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Bookmark size={20} />
+                        </div>
+                        <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                            {t('savedQueries.title')}
+                        </DialogTitle>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 pt-4 space-y-4">
+                    <Input
+                        autoFocus
+                        className="h-8 text-[11px] bg-background/50"
+                        value={search}
+                        onChange={(e) => setSearch(e.target.value)}
+                        placeholder={t('savedQueries.search')}
+                    />
+
+                    <ScrollArea className="h-[260px] border rounded-lg border-border/40">
+                        <div className="p-1">
+                            {folders.length === 0 && (
+                                <div className="p-8 text-center text-muted-foreground opacity-50 text-[10px] uppercase font-bold tracking-widest">
+                                    {t('savedQueries.empty')}
+                                </div>
+                            )}
+                            {folders.map(([folder, list]) => (
+                                <div key={folder} className="mb-2">
+                                    {folder && (
+                                        <div className="flex items-center gap-1.5 px-2 py-1 text-[10px] font-bold uppercase tracking-widest text-muted-foreground">
+                                            <Folder size={11} /> {folder}
+                                        </div>
+                                    )}
+                                    {list.map(q => (
+                                        <div
+                                            key={q.id}
+                                            className="group flex items-start gap-3 p-2 hover:bg-muted/50 rounded-lg cursor-pointer border border-transparent hover:border-border/40"
+                                            onClick={() => handleOpen(q)}
+                                        >
+                                            <div className="min-w-0 flex-1">
+                                                <div className="text-[12px] font-semibold truncate">{q.name}</div>
+                                                {q.description && <div className="text-[10px] text-muted-foreground truncate">{q.description}</div>}
+                                                <code className="block text-[10px] font-mono text-foreground/60 truncate">{q.sql}</code>
+                                            </div>
+                                            <Button
+                                                variant="ghost"
+                                                size="sm"
+                                                className="h-6 text-[9px] opacity-0 group-hover:opacity-100 hover:text-destructive gap-1"
+                                                onClick={(e) => {
+                                                    e.stopPropagation();
+                                                    handleDelete(q);
+                                                }}
+                                            >
+                                                <Trash2 size={11} />
+                                                {confirmDelete === q.id ? t('savedQueries.confirmDelete') : t('common.delete')}
+                                            </Button>
+                                        </div>
+                                    ))}
+                                </div>
+                            ))}
+                        </div>
+                    </ScrollArea>
+
+                    <div className="space-y-2 border-t border-border/40 pt-4">
+                        <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('savedQueries.saveCurrent')}</Label>
+                        <div className="grid grid-cols-2 gap-2">
+                            <Input className="h-8 text-[11px] bg-background/50" value={form.name} onChange={(e) => setForm({ ...form, name: e.target.value })} placeholder={t('savedQueries.name')} />
+                            <Input className="h-8 text-[11px] bg-background/50" value={form.folder} onChange={(e) => setForm({ ...form, folder: e.target.value })} placeholder={t('savedQueries.folder')} />
+                        </div>
+                        <Input className="h-8 text-[11px] bg-background/50" value={form.description} onChange={(e) => setForm({ ...form, description: e.target.value })} placeholder={t('savedQueries.description')} />
+                        <div className="flex justify-end gap-2">
+                            {linked && (
+                                <Button type="button" variant="outline" onClick={handleUpdate} disabled={!sql.trim()} className="text-[10px] font-black uppercase tracking-widest gap-2">
+                                    <Save size={12} /> {t('savedQueries.update', { name: linked.name })}
+                                </Button>
+                            )}
+                            <Button type="button" onClick={handleSave} disabled={!form.name.trim() || !sql.trim()} className="text-[10px] font-black uppercase tracking-widest gap-2">
+                                <Save size={12} /> {t('common.save')}
+                            </Button>
+                        </div>
+                    </div>
+                </div>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,371 @@
+import { useEffect, useRef, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Checkbox } from '@/components/ui/checkbox';
+import { CalendarClock, CircleAlert, CircleCheck, FolderOpen, Loader2, Pencil, Play, Plus, Trash2 } from 'lucide-react';
+import { toast } from "sonner";
+import { cn } from '@/lib/utils';
+import { ExportRun, ExportSchedule, ExportScheduleStatus, SavedConnection, SavedQuery } from '../types';
+import {
+    ListExportSchedules,
+    SaveExportSchedule,
+    DeleteExportSchedule,
+    RunExportSchedule,
+    GetExportRuns,
+    SelectExportDirectory,
+    LoadSavedQueries
+} from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+interface Props {
+    connections: SavedConnection[];
+    onClose: () => void;
+}
+
+// Schedules offered in the form besides a cron expression
+const cronPresets: [string, string][] = [
+    ['@hourly', 'schedules.hourly'],
+    ['@daily', 'schedules.daily'],
+    ['0 8 * * 1-5', 'schedules.weekdays'],
+    ['@weekly', 'schedules.weekly'],
+    ['@monthly', 'schedules.monthly'],
+];
+
+const emptySchedule = (): ExportSchedule => ({
+    id: '',
+    name: '',
+    queryId: '',
+    connection: '',
+    cron: '@daily',
+    format: 'csv',
+    directory: '',
+    enabled: true,
+    createdAt: '',
+    updatedAt: '',
+});
+
+// The stored schedule without its status
+const toSchedule = ({ nextRun, running, lastRun, ...schedule }: ExportScheduleStatus): ExportSchedule => schedule;
+
+const errorText = (err: any) => typeof err === 'string' ? err : err.message;
+
+// Saved queries exported on a schedule to a directory or S3 while the app is
+// open, with their next run and the history of past ones
+export function ScheduledExportsModal({ connections, onClose }: Props) {
+    const { t } = useTranslation();
+    const [schedules, setSchedules] = useState<ExportScheduleStatus[]>([]);
+    const [queries, setQueries] = useState<SavedQuery[]>([]);
+    const [editing, setEditing] = useState<ExportSchedule | null>(null);
+    const [saving, setSaving] = useState(false);
+    const [selected, setSelected] = useState<string | null>(null);
+    const [runs, setRuns] = useState<ExportRun[]>([]);
+    const selectedRef = useRef<string | null>(null);
+    selectedRef.current = selected;
+
+    const load = () => ListExportSchedules().then(list => setSchedules(list || [])).catch(err => toast.error(errorText(err)));
+
+    useEffect(() => {
+        load();
+        LoadSavedQueries('').then(list => setQueries(list || [])).catch(() => setQueries([]));
+        return EventsOn('schedule:run', (run: ExportRun) => {
+            load();
+            if (selectedRef.current === run.scheduleId) setRuns(prev => [run, ...prev]);
+        });
+    }, []);
+
+    useEffect(() => {
+        if (!selected) return;
+        GetExportRuns(selected).then(setRuns).catch(() => setRuns([]));
+    }, [selected]);
+
+    const query = queries.find(q => q.id === editing?.queryId);
+    const update = (changes: Partial<ExportSchedule>) => setEditing(current => current && { ...current, ...changes });
+    const updateS3 = (changes: Partial<NonNullable<ExportSchedule['s3']>>) =>
+        setEditing(current => current && { ...current, s3: { bucket: '', ...current.s3, ...changes } });
+
+    const handleBrowse = async () => {
+        const dir = await SelectExportDirectory();
+        if (dir) update({ directory: dir });
+    };
+
+    const handleSave = async () => {
+        if (!editing) return;
+        setSaving(true);
+        try {
+            const saved = await SaveExportSchedule(editing);
+            toast.success(t('schedules.saved', { name: saved.name }));
+            setEditing(null);
+            setSelected(saved.id);
+            load();
+        } catch (err: any) {
+            toast.error(errorText(err));
+        } finally {
+            setSaving(false);
+        }
+    };
+
+    const handleRun = async (id: string) => {
+        try {
+            await RunExportSchedule(id);
+            setSelected(id);
+            load();
+        } catch (err: any) {
+            toast.error(errorText(err));
+        }
+    };
+
+    const handleDelete = async (id: string) => {
+        try {
+            await DeleteExportSchedule(id);
+            if (selected === id) setSelected(null);
+            load();
+        } catch (err: any) {
+            toast.error(errorText(err));
+        }
+    };
+
+    const formatTime = (value?: string) => value ? new Date(value).toLocaleString() : '—';
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[720px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <CalendarClock size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('schedules.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {t('schedules.subtitle')}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-4 max-h-[70vh] overflow-y-auto">
+                    {!editing && (
+                        <>
+                            <div className="flex items-center justify-between">
+                                <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.count', { count: schedules.length })}</Label>
+                                <button className="text-[10px] text-primary hover:underline flex items-center gap-1" onClick={() => setEditing(emptySchedule())}>
+                                    <Plus size={10} /> {t('schedules.new')}
+                                </button>
+                            </div>
+                            <div className="rounded-md border max-h-56 overflow-y-auto divide-y">
+                                {schedules.length === 0 && (
+                                    <p className="p-3 text-[11px] text-muted-foreground text-center">{t('schedules.none')}</p>
+                                )}
+                                {schedules.map(schedule => (
+                                    <div
+                                        key={schedule.id}
+                                        className={cn("flex items-center gap-2 px-2 py-1.5 text-[11px] cursor-pointer hover:bg-muted/40", selected === schedule.id && "bg-primary/5")}
+                                        onClick={() => setSelected(schedule.id)}
+                                    >
+                                        {schedule.running ? <Loader2 size={12} className="animate-spin text-primary shrink-0" />
+                                            : schedule.lastRun?.status === 'failed' ? <CircleAlert size={12} className="text-destructive shrink-0" />
+                                            : schedule.lastRun ? <CircleCheck size={12} className="text-green-500 shrink-0" />
+                                            : <span className="w-3 shrink-0" />}
+                                        <span className={cn("font-bold truncate flex-1", !schedule.enabled && "opacity-50")}>{schedule.name}</span>
+                                        <span className="font-mono text-muted-foreground text-[10px]">{schedule.cron}</span>
+                                        <span className="text-muted-foreground text-[10px] w-36 text-right" title={t('schedules.nextRun')}>
+                                            {schedule.enabled ? formatTime(schedule.nextRun) : t('schedules.disabled')}
+                                        </span>
+                                        <Button variant="ghost" size="icon" className="h-6 w-6" title={t('schedules.runNow')} disabled={schedule.running} onClick={(e) => { e.stopPropagation(); handleRun(schedule.id); }}>
+                                            <Play size={12} />
+                                        </Button>
+                                        <Button variant="ghost" size="icon" className="h-6 w-6" title={t('common.edit')} onClick={(e) => { e.stopPropagation(); setEditing(toSchedule(schedule)); }}>
+                                            <Pencil size={12} />
+                                        </Button>
+                                        <Button variant="ghost" size="icon" className="h-6 w-6 text-destructive" title={t('common.delete')} onClick={(e) => { e.stopPropagation(); handleDelete(schedule.id); }}>
+                                            <Trash2 size={12} />
+                                        </Button>
+                                    </div>
+                                ))}
+                            </div>
+
+                            {selected && (
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.history')}</Label>
+                                    <div className="rounded-md border max-h-48 overflow-y-auto divide-y">
+                                        {runs.length === 0 && (
+                                            <p className="p-3 text-[11px] text-muted-foreground text-center">{t('schedules.noRuns')}</p>
+                                        )}
+                                        {runs.map((run, i) => (
+                                            <div key={i} className="px-2 py-1.5 text-[11px] space-y-0.5">
+                                                <div className="flex items-center gap-2">
+                                                    {run.status === 'failed' ? <CircleAlert size={12} className="text-destructive shrink-0" /> : <CircleCheck size={12} className="text-green-500 shrink-0" />}
+                                                    <span className="flex-1">{formatTime(run.started)}{run.manual && <span className="text-muted-foreground"> · {t('schedules.manual')}</span>}</span>
+                                                    <span className="text-muted-foreground text-[10px]">{t('schedules.rows', { count: run.rows })}</span>
+                                                    <span className="text-muted-foreground text-[10px] w-14 text-right">{(run.durationMs / 1000).toFixed(1)}s</span>
+                                                </div>
+                                                {run.location && <p className="font-mono text-[10px] text-muted-foreground truncate pl-5" title={run.location}>{run.location}</p>}
+                                                {run.error && <p className="font-mono text-[10px] text-destructive break-all pl-5">{run.error}</p>}
+                                            </div>
+                                        ))}
+                                    </div>
+                                </div>
+                            )}
+                        </>
+                    )}
+
+                    {editing && (
+                        <div className="space-y-4">
+                            <div className="grid grid-cols-2 gap-3">
+                                <div className="space-y-1.5 col-span-2">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.name')}</Label>
+                                    <Input className="h-8 text-[11px] bg-background/50" value={editing.name} onChange={(e) => update({ name: e.target.value })} />
+                                </div>
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.query')}</Label>
+                                    <Select value={editing.queryId} onValueChange={(queryId) => update({ queryId, parameters: {} })}>
+                                        <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                            <SelectValue placeholder={t('schedules.chooseQuery')} />
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            {queries.map(q => (
+                                                <SelectItem key={q.id} value={q.id} className="text-[11px]">{q.folder ? `${q.folder}/${q.name}` : q.name}</SelectItem>
+                                            ))}
+                                        </SelectContent>
+                                    </Select>
+                                </div>
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.connection')}</Label>
+                                    <Select value={editing.connection} onValueChange={(connection) => update({ connection })}>
+                                        <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                            <SelectValue placeholder={t('schedules.chooseConnection')} />
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            {connections.map(conn => (
+                                                <SelectItem key={conn.name} value={conn.name} className="text-[11px]">{conn.name}</SelectItem>
+                                            ))}
+                                        </SelectContent>
+                                    </Select>
+                                </div>
+                            </div>
+
+                            {query?.parameters && query.parameters.length > 0 && (
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.parameters')}</Label>
+                                    <div className="grid grid-cols-2 gap-2">
+                                        {query.parameters.map(p => (
+                                            <Input
+                                                key={p.name}
+                                                className="h-8 text-[11px] font-mono bg-background/50"
+                                                value={editing.parameters?.[p.name] ?? ''}
+                                                onChange={(e) => update({ parameters: { ...editing.parameters, [p.name]: e.target.value } })}
+                                                placeholder={p.default ? `${p.name} = ${p.default}` : p.name}
+                                                title={p.description}
+                                            />
+                                        ))}
+                                    </div>
+                                </div>
+                            )}
+
+                            <div className="grid grid-cols-2 gap-3">
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.cron')}</Label>
+                                    <Input className="h-8 text-[11px] font-mono bg-background/50" value={editing.cron} onChange={(e) => update({ cron: e.target.value })} placeholder="0 8 * * 1-5" />
+                                    <div className="flex flex-wrap gap-1">
+                                        {cronPresets.map(([cron, label]) => (
+                                            <button
+                                                key={cron}
+                                                className={cn("px-1.5 py-0.5 rounded border text-[9px]", editing.cron === cron ? "border-primary text-primary" : "text-muted-foreground hover:text-foreground")}
+                                                onClick={() => update({ cron })}
+                                            >
+                                                {t(label)}
+                                            </button>
+                                        ))}
+                                    </div>
+                                </div>
+                                <div className="space-y-1.5">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.format')}</Label>
+                                    <Select value={editing.format} onValueChange={(format) => update({ format: format as ExportSchedule['format'] })}>
+                                        <SelectTrigger className="h-8 text-[11px] bg-background/50">
+                                            <SelectValue />
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            <SelectItem value="csv" className="text-[11px]">CSV</SelectItem>
+                                            <SelectItem value="json" className="text-[11px]">JSON</SelectItem>
+                                        </SelectContent>
+                                    </Select>
+                                </div>
+                            </div>
+
+                            <div className="space-y-1.5">
+                                <div className="flex items-center justify-between">
+                                    <Label className="text-[10px] font-black uppercase text-muted-foreground tracking-widest">{t('schedules.destination')}</Label>
+                                    <label className="flex items-center gap-2 text-[11px] cursor-pointer">
+                                        <Checkbox
+                                            checked={!!editing.s3}
+                                            onCheckedChange={(checked) => update({ s3: checked ? { bucket: '' } : undefined })}
+                                        />
+                                        {t('schedules.uploadS3')}
+                                    </label>
+                                </div>
+                                {editing.s3 ? (
+                                    <div className="grid grid-cols-2 gap-2">
+                                        <Input className="h-8 text-[11px] font-mono bg-background/50" value={editing.s3.bucket} onChange={(e) => updateS3({ bucket: e.target.value })} placeholder={t('schedules.bucket')} />
+                                        <Input className="h-8 text-[11px] font-mono bg-background/50" value={editing.s3.prefix ?? ''} onChange={(e) => updateS3({ prefix: e.target.value })} placeholder="reports/daily/" />
+                                        <Input className="h-8 text-[11px] font-mono bg-background/50" value={editing.s3.region ?? ''} onChange={(e) => updateS3({ region: e.target.value })} placeholder={t('schedules.region')} />
+                                        <Input className="h-8 text-[11px] font-mono bg-background/50" value={editing.s3.profile ?? ''} onChange={(e) => updateS3({ profile: e.target.value })} placeholder={t('schedules.profile')} />
+                                        <Input className="h-8 text-[11px] font-mono bg-background/50 col-span-2" value={editing.s3.endpoint ?? ''} onChange={(e) => updateS3({ endpoint: e.target.value })} placeholder={t('schedules.endpoint')} />
+                                    </div>
+                                ) : (
+                                    <div className="flex gap-2">
+                                        <Input className="h-8 text-[11px] font-mono bg-background/50" value={editing.directory ?? ''} onChange={(e) => update({ directory: e.target.value })} placeholder={t('schedules.directory')} />
+                                        <Button variant="outline" size="sm" className="h-8 text-[10px] gap-1" onClick={handleBrowse}>
+                                            <FolderOpen size={12} /> {t('schedules.browse')}
+                                        </Button>
+                                    </div>
+                                )}
+                                <p className="text-[10px] text-muted-foreground">{t('schedules.hint')}</p>
+                            </div>
+
+                            <label className="flex items-center gap-2 text-[11px] cursor-pointer">
+                                <Checkbox checked={editing.enabled} onCheckedChange={(checked) => update({ enabled: !!checked })} />
+                                {t('schedules.enabled')}
+                            </label>
+                        </div>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    {editing ? (
+                        <>
+                            <Button type="button" variant="ghost" onClick={() => setEditing(null)} className="text-[10px] font-black uppercase tracking-widest">
+                                {t('common.cancel')}
+                            </Button>
+                            <Button type="button" disabled={saving} onClick={handleSave} className="text-[10px] font-black uppercase tracking-widest gap-2">
+                                {saving && <Loader2 size={12} className="animate-spin" />}
+                                {t('common.save')}
+                            </Button>
+                        </>
+                    ) : (
+                        <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                            {t('common.close')}
+                        </Button>
+                    )}
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,128 @@
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import {
+    Dialog,
+    DialogContent,
+    DialogHeader,
+    DialogTitle,
+    DialogFooter
+} from '@/components/ui/dialog';
+import { Button } from '@/components/ui/button';
+import { Badge } from '@/components/ui/badge';
+import { Loader2, Undo2 } from 'lucide-react';
+import { toast } from "sonner";
+import { SQLPreview, UndoEntry } from '../types';
+import { GetUndoLog, PreviewUndo, UndoLastChange } from '../../wailsjs/go/main/App';
+import { SQLPreviewBlock } from './SQLPreviewBlock';
+
+interface Props {
+    onUndone: (entry: UndoEntry) => void;
+    onClose: () => void;
+}
+
+const kindStyles: Record<string, string> = {
+    insert: 'border-green-500/50 text-green-600',
+    update: 'border-amber-500/50 text-amber-500',
+    delete: 'border-destructive/50 text-destructive',
+};
+
+// Lists the recent grid changes of the session and reverts the last one
+// with the statements shown
+export function UndoModal({ onUndone, onClose }: Props) {
+    const { t } = useTranslation();
+    const [entries, setEntries] = useState<UndoEntry[]>([]);
+    const [preview, setPreview] = useState<SQLPreview | null>(null);
+    const [previewError, setPreviewError] = useState<string | null>(null);
+    const [undoing, setUndoing] = useState(false);
+
+    const refresh = async () => {
+        const log = await GetUndoLog();
+        setEntries(log);
+        setPreview(null);
+        setPreviewError(null);
+        if (log.length === 0) return;
+        PreviewUndo()
+            .then(setPreview)
+            .catch(err => setPreviewError(typeof err === 'string' ? err : err.message));
+    };
+
+    useEffect(() => {
+        refresh();
+    }, []);
+
+    const handleUndo = async () => {
+        setUndoing(true);
+        try {
+            const entry = await UndoLastChange();
+            toast.success(t('undo.undone', { kind: entry.kind, count: entry.rows, table: entry.table }));
+            onUndone(entry);
+            await refresh();
+        } catch (err: any) {
+            // The transaction was rolled back; the change stays in the log
+            toast.error(t('undo.failed', { error: typeof err === 'string' ? err : err.message }));
+        } finally {
+            setUndoing(false);
+        }
+    };
+
+    return (
+        <Dialog open={true} onOpenChange={(open) => !open && onClose()}>
+            <DialogContent className="sm:max-w-[720px] bg-card border-border/40 shadow-2xl p-0 overflow-hidden">
+                <DialogHeader className="p-6 pb-2 bg-primary/5 border-b border-primary/10">
+                    <div className="flex items-center gap-3 mb-2">
+                        <div className="w-10 h-10 rounded-xl bg-primary/10 flex items-center justify-center text-primary shadow-inner border border-primary/20">
+                            <Undo2 size={20} />
+                        </div>
+                        <div>
+                            <DialogTitle className="text-lg font-black tracking-tight uppercase italic">
+                                {t('undo.title')}
+                            </DialogTitle>
+                            <p className="text-[10px] text-muted-foreground font-bold uppercase tracking-widest leading-none mt-1 opacity-60">
+                                {t('undo.subtitle', { count: entries.length })}
+                            </p>
+                        </div>
+                    </div>
+                </DialogHeader>
+
+                <div className="p-6 space-y-3 max-h-[60vh] overflow-y-auto">
+                    {entries.length === 0 ? (
+                        <p className="text-[11px] text-muted-foreground italic">{t('undo.empty')}</p>
+                    ) : (
+                        <>
+                            <div className="space-y-1.5">
+                                {entries.map((entry, i) => (
+                                    <div key={entry.id} className={`flex items-center gap-2 text-[11px] font-mono ${i > 0 ? 'opacity-50' : ''}`}>
+                                        <Badge variant="outline" className={`h-4 px-1.5 text-[9px] uppercase shrink-0 ${kindStyles[entry.kind]}`}>
+                                            {entry.kind}
+                                        </Badge>
+                                        <span className="truncate flex-1">
+                                            {entry.database}.{entry.schema ? `${entry.schema}.` : ''}{entry.table}
+                                            <span className="text-muted-foreground"> · {t('undo.rows', { count: entry.rows })}</span>
+                                        </span>
+                                        <span className="text-muted-foreground shrink-0">{new Date(entry.time).toLocaleTimeString()}</span>
+                                    </div>
+                                ))}
+                            </div>
+                            <SQLPreviewBlock preview={preview} error={previewError} />
+                        </>
+                    )}
+                </div>
+
+                <DialogFooter className="p-6 bg-muted/20 border-t border-border/40 gap-3">
+                    <Button type="button" variant="ghost" onClick={onClose} className="text-[10px] font-black uppercase tracking-widest">
+                        {t('common.close')}
+                    </Button>
+                    <Button
+                        type="button"
+                        disabled={!preview || undoing}
+                        onClick={handleUndo}
+                        className="text-[10px] font-black uppercase tracking-widest gap-2"
+                    >
+                        {undoing ? <Loader2 size={12} className="animate-spin" /> : <Undo2 size={12} />}
+                        {t('undo.undoLast')}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
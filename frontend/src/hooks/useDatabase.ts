@@ -1,17 +1,51 @@
-import { useState, useCallback } from 'react';
+import { useState, useCallback, useRef } from 'react';
 import {
     Connect, Disconnect, TestConnection, IsConnected, ExecuteQuery,
     GetDatabases, GetTables, GetColumns, SaveConnection, LoadConnections,
     DeleteConnection, UseDatabase, RenameConnection, UpdateConnection,
     GetTableData, InsertRow, UpdateRow, DeleteRow, DeleteRows,
-    AlterTable, TruncateTable, DropTable, GetDatabaseSchema
+    AlterTable, TruncateTable, DropTable, GetCompletionMetadata,
+    GetActiveOperations, CancelOperation, ExecuteSQL, StartJob, GetJobResult
 } from '../../wailsjs/go/main/App';
 import {
     ConnectionConfig, SavedConnection, QueryResult, DatabaseInfo,
-    TableInfo, ColumnInfo, TableDataRequest, TableAlteration
+    TableInfo, ColumnInfo, TableDataRequest, TableAlteration, CompletionTable, ServerNotice, SQLResult
 } from '../types';
 import { toast } from "sonner";
 
+// Rows kept per result set of the editor; the rest are only counted
+const MAX_RESULT_ROWS = 10000;
+
+// Notices shown individually before the rest are summarized
+const MAX_NOTICE_TOASTS = 5;
+
+// showNotices surfaces RAISE NOTICE output and server warnings next to the results
+function showNotices(notices?: ServerNotice[]) {
+    if (!notices || notices.length === 0) return;
+    for (const n of notices.slice(0, MAX_NOTICE_TOASTS)) {
+        const text = n.detail ? `${n.message}\n${n.detail}` : n.message;
+        if (/^(warning|error)$/i.test(n.severity)) {
+            toast.warning(`${n.severity}: ${text}`);
+        } else {
+            toast.info(`${n.severity}: ${text}`);
+        }
+    }
+    if (notices.length > MAX_NOTICE_TOASTS) {
+        toast.info(`${notices.length - MAX_NOTICE_TOASTS} more notices`);
+    }
+}
+
+// toQueryResults flattens the result sets of the statements of a script
+function toQueryResults(result: SQLResult): QueryResult[] {
+    return (result.statements || []).flatMap(stmt => (stmt.resultSets || []).map(set => ({
+        columns: set.columns.map(c => c.name),
+        columnTypes: set.columns.map(c => c.type),
+        rows: set.rows || [],
+        rowCount: set.rowCount,
+        stats: stmt.stats
+    })));
+}
+
 export function useDatabase() {
     const [connected, setConnected] = useState(false);
     const [loading, setLoading] = useState(false);
@@ -28,12 +62,12 @@ export function useDatabase() {
         setError(null);
         try {
             const result = await TestConnection(config);
-            if (result) {
-                toast.success("Connection test successful!");
+            if (result?.success) {
+                toast.success(`Connection test successful! (${result.latencyMs.toFixed(1)} ms, ${result.serverVersion})`);
             } else {
                 toast.error("Connection test failed.");
             }
-            return result;
+            return !!result?.success;
         } catch (err: any) {
             toast.error(`Connection error: ${err.message || 'Unknown error'}`);
             setError(err.message || 'Connection test failed');
@@ -89,9 +123,9 @@ export function useDatabase() {
         }
     }, []);
 
-    const getTables = useCallback(async (database: string): Promise<TableInfo[]> => {
+    const getTables = useCallback(async (database: string, schema = ''): Promise<TableInfo[]> => {
         try {
-            const tables = await GetTables(database);
+            const tables = await GetTables(database, schema);
             return tables || [];
         } catch (err: any) {
             setError(err.message || 'Failed to load tables');
@@ -99,9 +133,9 @@ export function useDatabase() {
         }
     }, []);
 
-    const getColumns = useCallback(async (database: string, table: string): Promise<ColumnInfo[]> => {
+    const getColumns = useCallback(async (database: string, table: string, schema = ''): Promise<ColumnInfo[]> => {
         try {
-            const columns = await GetColumns(database, table);
+            const columns = await GetColumns(database, schema, table);
             return columns || [];
         } catch (err: any) {
             setError(err.message || 'Failed to load columns');
@@ -109,10 +143,26 @@ export function useDatabase() {
         }
     }, []);
 
+    // Autocomplete metadata per database, refreshed with only what changed since its version
+    const completionRef = useRef<Record<string, { version: number; tables: Record<string, CompletionTable> }>>({});
+
     const getDatabaseSchema = useCallback(async (database: string): Promise<Record<string, string[]> | null> => {
         try {
-            const schema = await GetDatabaseSchema(database);
-            return schema || null;
+            const cached = completionRef.current[database];
+            const meta = await GetCompletionMetadata(database, cached?.version || 0);
+            const tables = meta.full || !cached ? {} : { ...cached.tables };
+            for (const key of meta.removed || []) delete tables[key];
+            for (const table of meta.tables) tables[`${table.schema}.${table.name}`] = table;
+            completionRef.current[database] = { version: meta.version, tables };
+
+            // Tables complete both qualified and by name alone
+            const schema: Record<string, string[]> = {};
+            for (const table of Object.values(tables)) {
+                const columns = table.columns.map(c => c.name);
+                schema[`${table.schema}.${table.name}`] = columns;
+                if (!(table.name in schema)) schema[table.name] = columns;
+            }
+            return schema;
         } catch (err: any) {
             console.error('Failed to get schema for autocomplete:', err);
             return null;
@@ -131,44 +181,50 @@ export function useDatabase() {
     }, []);
 
     const [queryResults, setQueryResults] = useState<QueryResult[]>([]);
+    // Queries are analyzed with EXPLAIN ANALYZE as well when opted in
+    const [analyzeQueries, setAnalyzeQueriesState] = useState(() => localStorage.getItem('opendb_analyze_queries') === 'true');
+    const analyzeRef = useRef(analyzeQueries);
+
+    const setAnalyzeQueries = useCallback((on: boolean) => {
+        analyzeRef.current = on;
+        setAnalyzeQueriesState(on);
+        localStorage.setItem('opendb_analyze_queries', String(on));
+    }, []);
 
     // Backward compatibility for single result views
     const queryResult = queryResults.length > 0 ? queryResults[0] : null;
 
-    const executeQuery = useCallback(async (query: string) => {
-        return executeQueries([query]);
-    }, []);
-
-    const executeQueries = useCallback(async (queries: string[]) => {
+    // Runs a script typed in the editor. The backend splits it into
+    // statements, binds the parameter values by name and, with tabId, runs it
+    // in the transaction open in that tab.
+    const executeScript = useCallback(async (sql: string, opts: { parameters?: Record<string, any>; tabId?: string } = {}) => {
         setLoading(true);
         setError(null);
         setQueryResults([]);
-
-        const results: QueryResult[] = [];
-
         try {
-            for (const q of queries) {
-                if (!q.trim()) continue;
-                const res = await ExecuteQuery(q);
-                if (res) {
-                    results.push(res);
-                }
-            }
-
+            const result = await ExecuteSQL(sql, {
+                continueOnError: false,
+                tabId: opts.tabId,
+                parameters: opts.parameters,
+                analyze: analyzeRef.current,
+                maxRows: MAX_RESULT_ROWS
+            });
+            const statements = result.statements || [];
+            statements.forEach(stmt => showNotices(stmt.notices));
+            const results = toQueryResults(result);
             setQueryResults(results);
 
-            if (results.length > 0) {
-                const totalRows = results.reduce((acc, r) => acc + (r.rowCount || 0), 0);
-                toast.success(`Executed ${results.length} queries (${totalRows} rows).`);
+            const failed = statements.find(stmt => stmt.error);
+            if (failed) {
+                toast.error(`Execution failed: ${failed.error}`);
+                setError(failed.error || 'Query failed');
+                return result;
             }
-
-            return results.length > 0 ? results[0] : null;
+            const totalRows = results.reduce((acc, r) => acc + (r.rowCount || 0), 0);
+            const affected = statements.reduce((acc, stmt) => acc + (stmt.rowsAffected || 0), 0);
+            toast.success(`Executed ${statements.length} statements (${totalRows} rows${affected ? `, ${affected} affected` : ''}).`);
+            return result;
         } catch (err: any) {
-            // If we have some results, keep them? 
-            // Usually DB tools show the error and maybe previous results.
-            // For now, allow partial results + error override
-            setQueryResults(results);
-
             const errorMessage = typeof err === 'string' ? err : (err.message || 'Query failed');
             toast.error(`Execution failed: ${errorMessage}`);
             setError(errorMessage);
@@ -178,6 +234,42 @@ export function useDatabase() {
         }
     }, []);
 
+    // Detaches a script into a background job that keeps running when its tab is closed
+    const runInBackground = useCallback(async (sql: string) => {
+        try {
+            const job = await StartJob(sql, { continueOnError: false });
+            toast.info(`Job #${job.id} started in the background.`);
+            return job;
+        } catch (err: any) {
+            const errorMessage = typeof err === 'string' ? err : (err.message || 'Failed to start job');
+            toast.error(errorMessage);
+            return null;
+        }
+    }, []);
+
+    // Shows the results a finished background job kept
+    const showJobResult = useCallback(async (id: number) => {
+        try {
+            const result = await GetJobResult(id);
+            setError(null);
+            setQueryResults(toQueryResults(result));
+        } catch (err: any) {
+            toast.error(typeof err === 'string' ? err : (err.message || 'Failed to load job results'));
+        }
+    }, []);
+
+    // Stops the queries in flight, on the server as well
+    const cancelQueries = useCallback(async () => {
+        try {
+            const ops = await GetActiveOperations();
+            await Promise.all((ops || [])
+                .filter(op => ['query', 'statement', 'sql', 'stream'].includes(op.kind))
+                .map(op => CancelOperation(op.id)));
+        } catch (err: any) {
+            toast.error(`Failed to cancel query: ${err.message || err}`);
+        }
+    }, []);
+
     const loadSavedConnections = useCallback(async () => {
         try {
             const connections = await LoadConnections();
@@ -237,10 +329,10 @@ export function useDatabase() {
         }
     }, [loadSavedConnections]);
 
-    const alterTable = useCallback(async (database: string, table: string, alteration: TableAlteration) => {
+    const alterTable = useCallback(async (database: string, schema: string, table: string, alteration: TableAlteration) => {
         setLoading(true);
         try {
-            await AlterTable(database, table, alteration as any);
+            await AlterTable(database, schema, table, alteration as any);
             toast.success(`Table "${table}" modified successfully.`);
             return true;
         } catch (err: any) {
@@ -251,10 +343,10 @@ export function useDatabase() {
         }
     }, []);
 
-    const truncateTable = useCallback(async (database: string, table: string) => {
+    const truncateTable = useCallback(async (database: string, schema: string, table: string) => {
         setLoading(true);
         try {
-            await TruncateTable(database, table);
+            await TruncateTable(database, schema, table);
             toast.success(`Table "${table}" truncated.`);
             return true;
         } catch (err: any) {
@@ -265,10 +357,10 @@ export function useDatabase() {
         }
     }, []);
 
-    const dropTable = useCallback(async (database: string, table: string) => {
+    const dropTable = useCallback(async (database: string, schema: string, table: string) => {
         setLoading(true);
         try {
-            await DropTable(database, table);
+            await DropTable(database, schema, table);
             toast.success(`Table "${table}" dropped.`);
             return true;
         } catch (err: any) {
@@ -298,8 +390,12 @@ export function useDatabase() {
         getColumns,
         getDatabaseSchema,
         useDb,
-        executeQuery,
-        executeQueries,
+        executeScript,
+        cancelQueries,
+        runInBackground,
+        showJobResult,
+        analyzeQueries,
+        setAnalyzeQueries,
         loadSavedConnections,
         saveConnection,
         updateConnection,
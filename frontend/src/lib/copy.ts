@@ -0,0 +1,25 @@
+import { ColumnInfo, CopyFormat, CopyOptions } from '../types';
+import { CopyCells } from '../../wailsjs/go/main/App';
+
+// Places rows on the clipboard in the chosen format. The backend renders them,
+// formatting each value by its column type; table names INSERT statements.
+export function copyRows(format: CopyFormat, columns: ColumnInfo[], rows: any[][], table?: string): Promise<string> {
+    const options: CopyOptions = {
+        delimiter: '',
+        includeHeaders: true,
+        nullMarker: '',
+        timestampFormat: '',
+        dateFormat: '',
+        binaryFormat: '',
+        clearAfterSec: 0,
+        styled: false,
+        format,
+        table,
+    };
+    return CopyCells(columns, rows, options);
+}
+
+// Columns of a query result, which only knows their names and types
+export function resultColumns(names: string[], types: string[]): ColumnInfo[] {
+    return names.map((name, i) => ({ name, type: types[i] || '', nullable: true, key: '', default: '', extra: '' }));
+}
@@ -7,6 +7,7 @@ export interface ConnectionConfig {
   user: string;
   password: string;
   database: string;
+  schema?: string; // PostgreSQL schema, defaults to public
 
   // Connection Color Coding
   color: string; // hex color e.g. "#ef4444" for prod
@@ -26,6 +27,34 @@ export interface ConnectionConfig {
   sshPassword: string;
   sshPrivateKey: string;
   sshPassphrase: string;
+
+  // HashiCorp Vault Credentials
+  useVault: boolean;
+  vaultAddr: string; // defaults to VAULT_ADDR
+  vaultToken: string; // defaults to VAULT_TOKEN or ~/.vault-token
+  vaultNamespace: string;
+  vaultPath: string; // e.g. secret/data/db/prod or database/creds/readonly
+
+  // AWS Secrets Manager / SSM Parameter Store Credentials
+  useAWSSecret: boolean;
+  awsSecretSource: string; // secretsmanager or ssm
+  awsSecretId: string;
+  awsRegion: string;
+  awsProfile: string;
+
+  // Statement timeouts in seconds, 0 or absent for none
+  gridTimeout?: number; // table data pages
+  queryTimeout?: number; // queries from the editor
+  undoDepth?: number; // changes kept for undo, default 50
+  undoMaxRows?: number; // rows a change may touch to be undoable, default 1000
+}
+
+// Profile rendered for other tools, password masked
+export interface ConnectionStrings {
+  uri: string;
+  cli: string; // psql or mysql command; prompts for the password
+  jdbc: string;
+  go: string; // database/sql snippet
 }
 
 export interface SavedConnection {
@@ -35,13 +64,146 @@ export interface SavedConnection {
 
 export interface QueryResult {
   columns: string[];
+  columnTypes: string[]; // database type names; numeric columns may hold exact values as strings
   rows: any[][];
   rowCount: number;
+  stats?: QueryStats;
+}
+
+// Where the time of a statement went
+export interface QueryStats {
+  wallTimeMs: number; // for streams, leaves out the time spent waiting to be fetched from
+  rowsAffected: number;
+  rowsFetched: number;
+  estimatedRoundtrips: number; // requests expected to wait for the server, not measured
+  planningTimeMs?: number; // from EXPLAIN ANALYZE, when opted in; MySQL has none
+  executionTimeMs?: number;
+  analyzeError?: string;
 }
 
 export interface ExecuteResult {
   rowsAffected: number;
   lastInsertId: number;
+  returned?: QueryResult; // rows as stored after a row edit (PostgreSQL RETURNING)
+}
+
+// SQL a change would run, returned by the Preview* methods without running it
+export interface SQLPreview {
+  statements: PreviewStatement[];
+}
+
+export interface PreviewStatement {
+  query: string; // as sent to the server
+  args?: any[];
+  sql: string; // query with the arguments written in as literals
+}
+
+// Sets columns of every row matching the filters; no filter matches every row
+export interface BulkUpdate {
+  filters?: FilterCondition[];
+  search?: string;
+  searchColumns?: string[];
+  values: Record<string, any>; // new value per column, null for NULL
+}
+
+// An edit of a table's grid queued to be applied with the others
+export interface StagedChange {
+  id?: number; // assigned when staged
+  kind: string; // insert, update or delete
+  primaryKey?: string[];
+  primaryValues?: any[];
+  values?: Record<string, any>; // insert: the new row; update: the changed columns
+}
+
+export interface ChangeSet {
+  database: string;
+  schema?: string; // PostgreSQL only
+  table: string;
+  changes: StagedChange[];
+}
+
+export interface ChangeSetResult {
+  applied: number;
+  rowsAffected: number;
+}
+
+// A recent grid change that can be undone
+export interface UndoEntry {
+  id: number;
+  database: string;
+  schema?: string; // PostgreSQL only
+  table: string;
+  kind: string; // insert, update or delete
+  rows: number;
+  time: string;
+}
+
+// Options of PreviewPaste and PasteRows
+export interface PasteOptions {
+  delimiter?: string; // tab or comma; detected when empty
+  header?: 'auto' | 'yes' | 'no'; // whether the first row names the columns
+  mapping?: string[]; // target column of each pasted column, '' skips it
+  previewRows?: number;
+}
+
+// Values that don't fit their column; pasting is refused while there are any
+export interface PasteError {
+  row: number; // from 1, not counting the header
+  column: string;
+  value: string;
+  message: string;
+}
+
+export interface PastePreview {
+  delimiter: string;
+  header: boolean; // the first row named the columns and is not inserted
+  source: string[]; // header names of the pasted columns, or Column n
+  mapping: string[]; // target column of each pasted column, '' when skipped
+  columns: string[]; // target columns of rows
+  rows: any[][];
+  totalRows: number;
+  errors?: PasteError[];
+}
+
+// Formats of "copy as", rendered by CopyCells
+export type CopyFormat = 'tsv' | 'csv' | 'markdown' | 'json' | 'html' | 'insert';
+
+// Options of CopyCells; empty strings take the defaults
+export interface CopyOptions {
+  delimiter: string; // tab, or comma for csv
+  includeHeaders: boolean;
+  nullMarker: string; // NULL
+  timestampFormat: string; // Go layout
+  dateFormat: string; // Go layout
+  binaryFormat: string; // hex or base64
+  clearAfterSec: number; // clear the clipboard after, 0 keeps it
+  styled: boolean; // html: inline borders and shading
+  format?: CopyFormat;
+  table?: string; // insert: name written into the statements
+  dialect?: '' | 'postgres' | 'mysql'; // insert: empty for that of the connection
+  multiRow?: boolean; // insert: a single INSERT listing every row
+}
+
+// Options of GenerateStatements
+export interface StatementOptions {
+  kind?: 'insert' | 'update'; // update matches rows by primary key
+  dialect?: '' | 'postgres' | 'mysql'; // empty for that of the connection
+  table?: string; // name written into the statements, e.g. schema.table
+  multiRow?: boolean; // insert: a single INSERT listing every row
+}
+
+export interface BulkPreview {
+  preview: SQLPreview;
+  matchingRows: number; // rows the filters match at preview time
+  token?: string; // confirms a BulkDelete of exactly these rows
+}
+
+// Deletes every row matching the filters; token comes from PreviewBulkDelete
+export interface BulkDelete {
+  filters?: FilterCondition[];
+  search?: string;
+  searchColumns?: string[];
+  token: string;
 }
 
 export interface DatabaseInfo {
@@ -50,10 +212,303 @@ export interface DatabaseInfo {
 
 export interface TableInfo {
   name: string;
+  schema?: string; // PostgreSQL only
   engine: string;
   rowCount: number;
   dataSize: number;
   createTime: string;
+  lastVacuum?: string; // PostgreSQL: latest manual or auto vacuum
+  comment: string;
+
+  // PostgreSQL partitioning; a partitioned table reports the totals of its leaf partitions
+  partitioned?: boolean;
+  partitionKey?: string; // e.g. RANGE (created_at)
+  parent?: string; // nest partitions under this table
+  partitionBound?: string;
+}
+
+export interface ViewInfo {
+  name: string;
+  definition: string;
+}
+
+export interface MaterializedViewInfo {
+  name: string;
+  definition: string;
+  populated: boolean;
+  size: number;
+  hasUniqueIndex: boolean; // required for a concurrent refresh
+  lastRefresh: string; // only known for refreshes run from this session
+}
+
+// Numeric sequence values are decimal strings to keep bigint bounds exact
+export interface SequenceInfo {
+  name: string;
+  currentValue: string; // empty until first used
+  increment: string;
+  minValue: string;
+  maxValue: string;
+  startValue: string;
+  cycle: boolean;
+  ownedBy: string; // table.column
+}
+
+export interface SequenceAlteration {
+  increment?: string;
+  minValue?: string;
+  maxValue?: string;
+  cycle?: boolean;
+}
+
+export interface TriggerInfo {
+  name: string;
+  timing: string; // BEFORE, AFTER, INSTEAD OF
+  events: string[];
+  level: string; // ROW or STATEMENT
+  function?: string;
+  definition: string;
+  body: string;
+  enabled: boolean;
+}
+
+export interface RoutineArgument {
+  name: string;
+  type: string;
+  mode: 'IN' | 'OUT' | 'INOUT' | 'VARIADIC' | 'TABLE';
+}
+
+export interface RoutineInfo {
+  name: string;
+  kind: 'FUNCTION' | 'PROCEDURE';
+  arguments: RoutineArgument[];
+  returnType: string;
+  language: string;
+  source: string;
+}
+
+export interface ResultColumn {
+  name: string;
+  type: string; // database type name, e.g. VARCHAR, NUMERIC
+  nullable?: boolean; // absent when the driver cannot tell
+  length?: number;
+  precision?: number;
+  scale?: number;
+}
+
+export interface ResultSet {
+  columns: ResultColumn[];
+  rows: any[][];
+  rowCount: number;
+}
+
+export interface StatementResult {
+  statement: string;
+  resultSets: ResultSet[];
+  rowsAffected: number;
+  lastInsertId: number;
+  durationMs: number;
+  error?: string; // the statement failed; earlier results are kept
+  notices?: ServerNotice[]; // RAISE NOTICE output and warnings
+  stats: QueryStats;
+}
+
+export interface ServerNotice {
+  severity: string; // NOTICE, WARNING, INFO, ... (PostgreSQL); Note, Warning, Error (MySQL)
+  code?: string;
+  message: string;
+  detail?: string;
+  hint?: string;
+}
+
+export interface SQLResult {
+  statements: StatementResult[];
+  skipped?: number; // statements not run after a failure
+  durationMs: number;
+}
+
+export interface ExplainOptions {
+  analyze: boolean; // runs the statement in a transaction that is rolled back
+}
+
+export interface PlanNode {
+  operation: string; // e.g. Seq Scan, Hash Join, Full table scan
+  relation?: string;
+  index?: string;
+  condition?: string; // filter, index or join condition
+  startupCost?: number; // costs are in the server's own units
+  totalCost?: number;
+  planRows?: number; // estimated rows
+  actualRows?: number; // ANALYZE only
+  actualTimeMs?: number; // ANALYZE only, per loop
+  loops?: number;
+  details?: Record<string, any>; // other properties as reported by the server
+  children?: PlanNode[];
+}
+
+export interface ExplainResult {
+  plan: PlanNode;
+  raw: string; // the plan as the server returned it
+  format: 'json' | 'tree'; // tree for MySQL EXPLAIN ANALYZE
+  planningTimeMs?: number;
+  executionTimeMs?: number;
+}
+
+export interface QueryParameter {
+  name: string;
+  type?: 'text' | 'number' | 'date' | 'boolean'; // text when absent
+  default?: string;
+  description?: string;
+}
+
+export interface SavedQuery {
+  id: string; // empty to create
+  name: string;
+  folder?: string; // slash-separated path, e.g. reports/monthly
+  sql: string;
+  description?: string;
+  parameters?: QueryParameter[];
+  connections?: string[]; // saved connections it is offered on; all when empty
+  createdAt: string;
+  updatedAt: string;
+  lastValues?: Record<string, any>; // parameter values it was last run with
+}
+
+export interface CompletionColumn {
+  name: string;
+  type: string;
+}
+
+export interface CompletionTable {
+  schema: string; // database on MySQL
+  name: string;
+  type: 'TABLE' | 'VIEW' | 'MATERIALIZED VIEW' | 'FOREIGN TABLE';
+  columns: CompletionColumn[];
+}
+
+export interface CompletionFunction {
+  schema: string;
+  name: string;
+  kind: 'FUNCTION' | 'PROCEDURE';
+  arguments: string;
+  returnType?: string;
+}
+
+export interface CompletionMetadata {
+  database: string;
+  version: number; // pass back as since to receive only the changes
+  full: boolean; // tables lists every table; otherwise only those added or changed
+  schemas: string[];
+  tables: CompletionTable[];
+  removed?: string[]; // schema.name of dropped tables
+  functions: CompletionFunction[];
+  keywords?: string[]; // full responses only
+  builtins?: string[]; // full responses only
+}
+
+export interface HistoryEntry {
+  id: number;
+  executedAt: string; // RFC 3339
+  connection: string; // e.g. postgres://app@db.internal:5432
+  database: string;
+  statement: string;
+  durationMs: number;
+  rowCount: number; // rows returned or affected
+  error?: string;
+}
+
+export interface HistoryFilter {
+  text: string; // words the statement contains, matched as prefixes
+  connection: string;
+  database: string;
+  errorsOnly: boolean;
+  limit: number; // defaults to 100
+  offset: number;
+}
+
+export interface HistoryPrune {
+  olderThanDays: number;
+  keepLast: number;
+  all: boolean;
+}
+
+export interface StreamInfo {
+  id: number;
+  columns: ResultColumn[];
+}
+
+export interface StreamChunk {
+  rows: any[][];
+  offset: number; // position of the first row in the result
+  done: boolean; // no rows are left and the stream is closed
+  notices?: ServerNotice[]; // sent by the server since the previous chunk
+  stats: QueryStats; // of the stream so far
+}
+
+export interface SQLOptions {
+  continueOnError: boolean;
+  timeout?: number; // seconds per statement; defaults to the connection's queryTimeout
+  tabId?: string; // query tab whose transaction the statements run in
+  parameters?: Record<string, any>; // placeholder values keyed by Placeholder.name
+  analyze?: boolean; // run queries again under EXPLAIN ANALYZE for planning and execution times
+}
+
+export interface FormatOptions {
+  dialect?: 'mysql' | 'postgres'; // the connected server's when unset
+  indent?: number; // spaces per level; defaults to 2
+  keywordCase?: 'upper' | 'lower' | 'preserve';
+}
+
+// A script detached to run in the background; emitted as "job:progress"
+// after each statement and "job:done" at the end
+export interface Job {
+  id: number;
+  query: string;
+  database: string;
+  status: 'running' | 'done' | 'failed' | 'cancelled';
+  statements: number;
+  completed: number; // statements run so far
+  rowsAffected: number;
+  started: string; // RFC 3339
+  finished?: string;
+  durationMs: number;
+  error?: string;
+}
+
+// Sent with NOTIFY on a channel listened on; emitted as "notify:message"
+export interface Notification {
+  channel: string;
+  payload: string;
+  pid: number; // server process that sent it
+  receivedAt: string;
+}
+
+// Emitted as "notify:status" when the listener's connection changes
+export interface ListenerStatus {
+  state: 'connected' | 'disconnected' | 'reconnected' | 'failed';
+  error?: string;
+}
+
+// A bind parameter found in editor SQL, e.g. $1, :name or ?1
+export interface Placeholder {
+  name: string;
+  kind: 'positional' | 'named' | 'anonymous';
+}
+
+// Emitted as "transaction:state" whenever it changes
+export interface TransactionState {
+  tabId: string;
+  inTransaction: boolean;
+  dirty: boolean; // statements that may have changed data ran since BEGIN
+  aborted: boolean; // PostgreSQL rejects every statement until ROLLBACK
+  startedAt?: string;
+  statements: number; // run since BEGIN
+  error?: string; // why the transaction ended on its own
+}
+
+export interface RoutineResult {
+  resultSets: QueryResult[];
+  outValues: Record<string, any>;
+  durationMs: number;
 }
 
 export interface ColumnInfo {
@@ -62,8 +517,41 @@ export interface ColumnInfo {
   nullable: boolean;
   key: string;
   default: string;
+  defaultExpression?: boolean; // default is an SQL expression such as now(); otherwise quoted as a literal
   extra: string;
   oldName?: string;
+  comment?: string;
+  charset?: string; // MySQL; changes apply to string types only
+  collation?: string; // PostgreSQL reports it only when not the type default
+  enumValues?: string[]; // allowed labels of enum/set columns
+  elementType?: string; // PostgreSQL arrays: element type; type is then e.g. int4[]
+  customType?: CustomTypeInfo; // PostgreSQL composite (expand values) or domain (validate constraints)
+  generated?: 'STORED' | 'VIRTUAL';
+  expression?: string; // generation expression, when known
+  identity?: 'ALWAYS' | 'BY DEFAULT'; // PostgreSQL
+  readOnly?: boolean; // generated or GENERATED ALWAYS identity; skipped on insert/update
+}
+
+export interface CustomTypeInfo {
+  name: string;
+  kind: 'enum' | 'composite' | 'domain' | 'range';
+  values?: string[];
+  definition?: string;
+  attributes?: CompositeAttribute[]; // composite fields, in order
+  baseType?: string; // domain
+  notNull?: boolean; // domain
+  default?: string; // domain
+  checks?: DomainConstraint[]; // domain
+}
+
+export interface CompositeAttribute {
+  name: string;
+  type: string;
+}
+
+export interface DomainConstraint {
+  name: string;
+  expression: string; // refers to the value as VALUE
 }
 
 export interface IndexInfo {
@@ -71,12 +559,43 @@ export interface IndexInfo {
   columns: string[];
   isUnique: boolean;
   isPrimary: boolean;
+  method?: string;
+  predicate?: string;
+  size?: number;
+}
+
+export interface ForeignKeyInfo {
+  name: string;
+  columns: string[];
+  referencedTable: string;
+  referencedColumns: string[];
+  onDelete: string;
+  onUpdate: string;
+}
+
+export interface ConstraintInfo {
+  name: string; // may be empty when adding, to let the server pick one
+  type: 'CHECK' | 'UNIQUE';
+  columns: string[];
+  expression?: string; // CHECK only
+}
+
+// Match of the schema-wide search palette
+export interface SchemaSearchResult {
+  kind: 'table' | 'view' | 'materialized_view' | 'column' | 'function' | 'procedure';
+  schema: string; // PostgreSQL schema or MySQL database
+  table?: string; // columns only
+  name: string;
+  detail?: string; // column type or function arguments
 }
 
 export interface TableDetails {
   name: string;
+  comment: string;
   columns: ColumnInfo[];
   indexes: IndexInfo[];
+  foreignKeys: ForeignKeyInfo[];
+  constraints: ConstraintInfo[];
 }
 
 export interface UpdateInfo {
@@ -93,28 +612,97 @@ export interface TableAlteration {
   modifyColumns: ColumnInfo[];
   dropColumns: string[];
   renameTo: string;
+  addConstraints?: ConstraintInfo[];
+  dropConstraints?: ConstraintInfo[]; // name and type are required
 }
 
 // Data editor request
 export interface TableDataRequest {
   database: string;
+  schema?: string; // PostgreSQL schema of the table; the connection's when empty
   table: string;
   page: number;
   pageSize: number;
-  orderBy: string;
-  orderDir: 'ASC' | 'DESC';
-  filters?: string; // SQL-like filter string
+  orderBy?: SortColumn[]; // in priority order; primary key when empty
+  filters?: FilterCondition[]; // compiled into a parameterized WHERE clause
+  search?: string; // quick search: any column contains the text, ignoring case
+  searchColumns?: string[]; // columns searched; every non-binary column by default
+  jsonPaths?: JSONPathColumn[]; // nested JSON values shown as extra read-only columns
+  pagination?: 'offset' | 'keyset'; // keyset pages by cursor instead of page number; needs a primary key
+  after?: any[]; // keyset cursor: nextCursor of the previous page, omitted for the first page
+  formatters?: ColumnFormatter[]; // display formatters evaluated by the backend
+}
+
+// Value nested in a JSON column, projected as a virtual column of the grid
+export interface JSONPathColumn {
+  column: string;
+  path: string[]; // object keys; array indexes as numeric strings
+  alias?: string; // defaults to column.key.key
+}
+
+// One column of a table data sort order
+export interface SortColumn {
+  column: string;
+  direction: 'ASC' | 'DESC';
+}
+
+// Identifies a row of a table without a primary key: its ctid (PostgreSQL)
+// or all of its loaded values (MySQL)
+export interface RowLocator {
+  ctid?: string;
+  values?: Record<string, any>;
+}
+
+// Condition of a table data filter. Either column/operator/value or a
+// parenthesized group; conjunction joins it to the previous condition.
+export interface FilterCondition {
+  column?: string;
+  operator?: '=' | '!=' | '<>' | '<' | '<=' | '>' | '>=' | 'LIKE' | 'NOT LIKE' | 'ILIKE' | 'NOT ILIKE'
+    | 'CONTAINS' | 'NOT CONTAINS' | 'REGEXP' | 'NOT REGEXP' | 'IN' | 'NOT IN' | 'BETWEEN' | 'NOT BETWEEN'
+    | 'IS NULL' | 'IS NOT NULL';
+  value?: any; // an array for IN / NOT IN, [low, high] for BETWEEN
+  path?: string[]; // keys inside a JSON column, e.g. ['status'] for payload->>'status'; numbers compare numerically
+  conjunction?: 'AND' | 'OR';
+  group?: FilterCondition[];
+}
+
+// Display formatter attached to a column of a table view
+export interface ColumnFormatter {
+  column: string;
+  kind: 'epoch' | 'currency' | 'bytes' | 'map';
+  unit?: string; // epoch: s, ms, us, ns
+  layout?: string; // epoch: Go time layout
+  timezone?: string; // epoch: IANA zone name
+  symbol?: string; // currency
+  divisor?: number; // currency, e.g. 100 for cents
+  decimals?: number; // currency
+  labels?: Record<string, string>; // map: raw value -> label
 }
 
 // Data editor response
 export interface TableDataResponse {
   columns: ColumnInfo[];
   rows: any[][];
+  rowIds: string[]; // stable per row across refreshes: PK hash, or row content hash for keyless tables
   totalRows: number;
+  capped?: boolean; // quick search totals stop counting at a limit
   page: number;
   pageSize: number;
   totalPages: number;
-  primaryKey: string;
+  primaryKey: string[]; // key columns, several for composite keys; empty when rows can't be edited
+  readOnly: boolean; // true for views
+  rowMatch?: 'ctid' | 'row'; // how rows are identified when there is no primary key
+  rowLocators?: string[]; // ctid per row when rowMatch is 'ctid'
+  nextCursor?: any[]; // keyset cursor of the next page; absent on the last page
+  display?: Record<string, string[]>; // formatted values per column, one per row
+  translations?: Record<string, Record<string, string>>; // column -> raw value -> label
+}
+
+// Shared lookup applied to columns by name (e.g. status 1=active, 2=banned)
+export interface TranslationMap {
+  name: string;
+  columns: string[];
+  values: Record<string, string>;
 }
 
 // Tree node for database explorer
@@ -126,3 +714,455 @@ export interface TreeNode {
   children?: TreeNode[];
   loading?: boolean;
 }
+
+// Full content of a single cell, fetched on demand by the large value editor
+export interface CellValue {
+  value: string;
+  isNull: boolean;
+  size: number;
+  type: string;
+  format?: FormatHint;
+}
+
+// Structured content detected inside a text cell
+export interface FormatHint {
+  format: 'json' | 'xml' | 'image' | 'jwt' | 'url';
+  pretty?: string;
+  mimeType?: string;
+  dataUri?: string;
+  header?: Record<string, any>;
+  claims?: Record<string, any>; // JWT payload, signature not verified
+}
+
+// Content of a binary (bytea/blob) cell
+export interface BinaryValue {
+  isNull: boolean;
+  size: number; // bytes
+  mimeType: string; // sniffed from the content
+  dump: string; // hex dump of the first bytes
+  truncated: boolean; // dump covers only part of the value
+  preview?: string; // data: URI of an image or PDF small enough to show
+}
+
+// Result of checking the text of a JSON document
+export interface JSONValidation {
+  valid: boolean;
+  error?: string;
+  line?: number; // 1-based position of the error
+  column?: number;
+  pretty?: string; // indented document when valid
+}
+
+// Diff shown before saving an edited cell
+export interface CellUpdatePreview {
+  changed: boolean;
+  diff: string;
+  query: string;
+}
+
+// Table data comparison
+export interface TableRef {
+  connection: string; // saved connection name, empty for the active connection
+  database: string;
+  schema?: string; // PostgreSQL schema of the table; the connection's when empty
+  table: string;
+}
+
+export interface TableCompareRequest {
+  source: TableRef;
+  target: TableRef;
+  chunkSize?: number;
+}
+
+export interface ChunkMismatch {
+  from: any; // exclusive, null = unbounded
+  to: any; // inclusive, null = unbounded
+  sourceRows: number;
+  targetRows: number;
+  sourceHash: string;
+  targetHash: string;
+}
+
+export interface TableCopyRequest {
+  source: TableRef;
+  target: TableRef; // table defaults to the source table
+  batchSize?: number;
+  createTable?: boolean; // create the target table when missing
+  truncate?: boolean; // empty the target table first
+  after?: any[]; // primary key of the last row already copied
+}
+
+// Table copy running in the background; "copy:done" carries it when it ends
+export interface TableCopy {
+  id: number;
+  request: TableCopyRequest;
+  status: 'running' | 'done' | 'failed' | 'cancelled';
+  rows: number;
+  totalRows: number;
+  lastKey?: any[];
+  resumable: boolean;
+  tableCreated: boolean;
+  started: string;
+  finished?: string;
+  durationMs: number;
+  error?: string;
+}
+
+export interface TableCompareResult {
+  primaryKey: string;
+  chunks: number;
+  sourceRows: number;
+  targetRows: number;
+  mismatches: ChunkMismatch[];
+  identical: boolean;
+  durationMs: number;
+}
+
+// PostgreSQL WAL archiving / PITR health
+export interface ReplicationSlotInfo {
+  name: string;
+  type: string;
+  database: string;
+  active: boolean;
+  walStatus: string;
+  retainedBytes: number;
+}
+
+export interface WALArchiveStatus {
+  walLevel: string;
+  archiveMode: string;
+  archiveCommand: string;
+  inRecovery: boolean;
+  currentWal: string;
+  archivedCount: number;
+  lastArchivedWal: string;
+  lastArchivedTime: string;
+  failedCount: number;
+  lastFailedWal: string;
+  lastFailedTime: string;
+  slots: ReplicationSlotInfo[];
+  warnings: string[];
+}
+
+// PostgreSQL extension from pg_available_extensions
+export interface ExtensionInfo {
+  name: string;
+  defaultVersion: string;
+  installedVersion: string; // empty when not installed
+  schema: string;
+  comment: string;
+}
+
+// PostgreSQL foreign data; foreign tables are also returned by GetTables with engine "foreign"
+export interface ForeignServerInfo {
+  name: string;
+  wrapper: string; // e.g. postgres_fdw
+  type?: string;
+  version?: string;
+  owner: string;
+  options: Record<string, string>;
+  comment: string;
+}
+
+export interface ForeignTableInfo {
+  name: string;
+  server: string; // nest under this server in the tree
+  options: Record<string, string>;
+  comment: string;
+}
+
+// Statement issued by the app on the active connection
+export interface ActivityEntry {
+  id: number;
+  time: string;
+  query: string;
+  args: number;
+  durationMs: number;
+  error?: string;
+}
+
+// Repeated query execution ("run N times")
+export interface BenchmarkOptions {
+  runs: number;
+  warmup: number;
+  discardResults: boolean;
+  clearCache: boolean;
+}
+
+export interface BenchmarkResult {
+  runs: number;
+  latenciesMs: number[];
+  minMs: number;
+  maxMs: number;
+  meanMs: number;
+  medianMs: number;
+  p95Ms: number;
+  rowsPerRun: number;
+  rowsPerSec: number;
+  cacheCleared: boolean;
+  warnings: string[];
+}
+
+// Concurrent synthetic workload
+export interface LoadTestQuery {
+  sql: string;
+  weight: number; // relative frequency, defaults to 1
+}
+
+export interface LoadTestOptions {
+  queries: LoadTestQuery[];
+  sessions: number; // concurrent connections, at most 100
+  durationSec: number; // at most 3600
+  thinkTimeMs: number; // pause between statements of a session
+}
+
+// Emitted every second as "loadtest:progress"; rate and latencies cover the last interval
+export interface LoadTestSnapshot {
+  elapsedSec: number;
+  queries: number;
+  errors: number;
+  qps: number;
+  p50Ms: number;
+  p95Ms: number;
+  maxMs: number;
+  lastError?: string;
+}
+
+export interface LoadTestQueryStats {
+  sql: string;
+  count: number;
+  errors: number;
+  meanMs: number;
+  p50Ms: number;
+  p95Ms: number;
+  maxMs: number;
+}
+
+// Emitted as "loadtest:done" when the run ends
+export interface LoadTestStatus {
+  running: boolean;
+  elapsedSec: number;
+  queries: number;
+  errors: number;
+  qps: number; // average over the whole run
+  perQuery: LoadTestQueryStats[];
+  history: LoadTestSnapshot[];
+  error?: string;
+}
+
+// App lock; events "app:locked" and "app:unlocked" are emitted on changes
+export interface LockStatus {
+  locked: boolean;
+  hasPassphrase: boolean; // locking requires a passphrase
+  idleTimeoutMin: number; // 0 disables the idle lock
+  damaged?: string; // why the lock settings can't be read; locked until reset
+}
+
+// Options of ExportTableCSV and ExportQueryCSV
+export interface CSVExportOptions {
+  delimiter?: string; // comma by default
+  quote?: string; // minimal (default) or all
+  null?: string; // written for NULL, empty by default
+  noHeader?: boolean;
+}
+
+export interface DumpOptions {
+  tables: string[];
+  content?: string; // all (default), schema or data
+  dropTables?: boolean;
+  rowsPerInsert?: number; // 100 by default
+  maxFileSizeMb?: number; // 0 writes a single file
+}
+
+export interface DumpResult {
+  files: string[];
+  tables: number;
+  rows: number;
+}
+
+// Client programs found for backups of the connected server; paths are empty when missing
+export interface BackupTools {
+  pgDump?: string;
+  pgRestore?: string;
+  psql?: string;
+  mysqldump?: string;
+  mysql?: string;
+  flavor?: string; // MySQL or MariaDB, for the MySQL tools
+  version?: string;
+  serverVersion?: string;
+  warning?: string; // set when the tools may not suit the server
+}
+
+export interface BackupOptions {
+  format?: string; // custom (default), plain, tar or directory; plain only on MySQL
+  compression?: number; // 1-9, 0 for the default, -1 for none
+  content?: string; // all (default), schema or data
+  tables?: string[]; // all tables when empty
+  noOwner?: boolean; // PostgreSQL only
+  jobs?: number; // directory format only
+}
+
+export interface BackupResult {
+  path: string;
+  size: number;
+  durationMs: number;
+}
+
+// SQL script backups only take continueOnError and, on PostgreSQL, singleTransaction
+export interface RestoreOptions {
+  clean?: boolean;
+  content?: string;
+  tables?: string[];
+  noOwner?: boolean;
+  jobs?: number;
+  singleTransaction?: boolean;
+  continueOnError?: boolean;
+}
+
+export interface BackupFile {
+  name: string;
+  path: string;
+  format: string;
+  size: number;
+  modified: string; // RFC 3339
+}
+
+// Emitted as "backup:progress" for each line the client program prints, and
+// with the bytes read while a SQL script is restored
+export interface BackupProgress {
+  operation: 'backup' | 'restore';
+  line?: string;
+  bytes?: number;
+  totalBytes?: number;
+  done: boolean;
+}
+
+// Emitted as "export:progress" and "import:progress" every 1000 rows and when done
+export interface TransferProgress {
+  operation: 'export' | 'import' | 'copy';
+  table: string;
+  rows: number;
+  totalRows?: number; // imports, estimated for copies
+  done: boolean;
+}
+
+// Work in flight on the connection; "app:quit-pending" carries the list while quitting waits for it
+export interface Operation {
+  id: number;
+  kind: 'query' | 'statement' | 'export' | 'import' | 'benchmark' | 'loadtest' | 'dump' | 'backup' | 'restore' | 'copy';
+  description: string;
+  started: string;
+}
+
+// Roles (PostgreSQL) or accounts (MySQL)
+export interface RoleInfo {
+  name: string;
+  host?: string; // MySQL account host
+  canLogin: boolean;
+  superuser: boolean;
+  createRole: boolean;
+  createDb: boolean;
+  memberOf: string[];
+}
+
+export interface TablePrivilege {
+  grantee: string; // role, 'user'@'host' (MySQL) or PUBLIC
+  privilege: string;
+  grantable: boolean;
+  grantor?: string; // PostgreSQL only
+  level: 'table' | 'database' | 'global'; // MySQL lists database and global grants covering the table
+}
+
+// Preview with PreviewPrivilegeChange before ApplyPrivilegeChange
+export interface PrivilegeChange {
+  action: 'GRANT' | 'REVOKE';
+  table: string;
+  privileges: string[]; // e.g. SELECT, INSERT; ALL for every privilege
+  grantee: string; // PUBLIC for everyone (PostgreSQL)
+  host?: string; // MySQL account host, defaults to %
+  withGrantOption: boolean; // REVOKE: only revokes the right to grant them on
+}
+
+// Launch check of the local data files (connections, formatters, translations, lock settings, history)
+export interface StoreHealth {
+  name: string;
+  status: 'ok' | 'missing' | 'unreadable' | 'quarantined' | 'recovered';
+  backup?: string; // quarantined copy, kept after recovery
+  readable: number; // entries that can be, or were, recovered
+  error?: string;
+}
+
+// Bucket scheduled exports are uploaded to with the default AWS credentials
+export interface S3Destination {
+  bucket: string;
+  prefix?: string; // key prefix, e.g. reports/daily/
+  region?: string;
+  profile?: string; // AWS shared config profile
+  endpoint?: string; // S3-compatible service such as MinIO
+}
+
+// Saved query run on a saved connection on a cron-like schedule while the app is open
+export interface ExportSchedule {
+  id: string; // empty to create
+  name: string;
+  queryId: string;
+  connection: string;
+  parameters?: Record<string, any>; // override the saved query's defaults
+  cron: string; // minute hour day-of-month month day-of-week, or @hourly, @daily, @weekly, @monthly
+  format: 'csv' | 'json';
+  directory?: string;
+  s3?: S3Destination; // uploaded there instead of written to directory
+  enabled: boolean;
+  createdAt: string;
+  updatedAt: string;
+}
+
+// Run of a scheduled export, carried by "schedule:run" and, when failed, "schedule:failed"
+export interface ExportRun {
+  scheduleId: string;
+  schedule: string;
+  started: string;
+  durationMs: number;
+  status: 'done' | 'failed';
+  rows: number;
+  location?: string; // file written or s3:// URL
+  error?: string;
+  manual?: boolean;
+}
+
+export interface ExportScheduleStatus extends ExportSchedule {
+  nextRun?: string; // empty when disabled
+  running: boolean;
+  lastRun?: ExportRun;
+}
+
+// Google account exports to Google Sheets are made with, through the user's own OAuth client
+export interface GoogleAccount {
+  configured: boolean; // an OAuth client is set
+  clientId: string;
+  signedIn: boolean;
+  email?: string;
+}
+
+// Destination of a result set; a new spreadsheet is created without one
+export interface GoogleSheetExport {
+  spreadsheet?: string; // ID or URL
+  title?: string; // of a new spreadsheet
+  sheet?: string; // tab, created when missing; the first one when empty
+  mode: 'replace' | 'append';
+}
+
+export interface GoogleSheetResult {
+  spreadsheetId: string;
+  url: string;
+  sheet: string;
+  rows: number;
+}
+
+// How much of an export has been uploaded to a bucket
+export interface UploadProgress {
+    location: string; // s3:// or gs:// URL of the object
+    bytes: number;
+    parts: number;
+    done: boolean;
+}
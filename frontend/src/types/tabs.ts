@@ -3,8 +3,10 @@ export interface Tab {
     id: string;
     type: 'query' | 'table';
     title: string;
+    savedQueryId?: string; // saved query opened in a query tab
     data?: {
         db: string;
+        schema?: string; // PostgreSQL schema of the table; the connection's when empty
         table: string;
     };
 }
@@ -2,70 +2,396 @@
 // This file is automatically generated. DO NOT EDIT
 import {database} from '../models';
 
-export function AlterTable(arg1:string,arg2:string,arg3:database.TableAlteration):Promise<void>;
+export function AddEnumValue(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function AlterSequence(arg1:string,arg2:string,arg3:database.SequenceAlteration):Promise<void>;
+
+export function AlterTable(arg1:string,arg2:string,arg3:string,arg4:database.TableAlteration):Promise<void>;
+
+export function AnalyzeStatement(arg1:string,arg2:Record<string, any>):Promise<database.QueryStats>;
+
+export function ApplyChangeSet(arg1:string,arg2:string,arg3:string):Promise<database.ChangeSetResult>;
+
+export function ApplyPrivilegeChange(arg1:string,arg2:database.PrivilegeChange):Promise<void>;
 
 export function ApplyUpdate(arg1:string):Promise<void>;
 
+export function Backup(arg1:string,arg2:database.BackupOptions):Promise<database.BackupResult>;
+
+export function BeginTransaction(arg1:string):Promise<database.TransactionState>;
+
+export function BenchmarkQuery(arg1:string,arg2:database.BenchmarkOptions):Promise<database.BenchmarkResult>;
+
+export function BulkDeleteRows(arg1:string,arg2:string,arg3:string,arg4:database.BulkDelete):Promise<database.ExecuteResult>;
+
+export function BulkUpdateRows(arg1:string,arg2:string,arg3:string,arg4:database.BulkUpdate):Promise<database.ExecuteResult>;
+
+export function CancelJob(arg1:number):Promise<void>;
+
+export function CancelOperation(arg1:number):Promise<void>;
+
+export function CancelOperations():Promise<void>;
+
+export function CancelTableCopy(arg1:number):Promise<void>;
+
 export function CheckForUpdate():Promise<database.UpdateInfo>;
 
+export function ClearActivityLog():Promise<void>;
+
+export function ClearJobs():Promise<number>;
+
+export function ClearTableCopies():Promise<number>;
+
+export function CloseQueryTab(arg1:string):Promise<void>;
+
+export function CloseStream(arg1:number):Promise<void>;
+
+export function CommitTransaction(arg1:string):Promise<database.TransactionState>;
+
+export function CompareTables(arg1:database.TableCompareRequest):Promise<database.TableCompareResult>;
+
 export function Connect(arg1:database.ConnectionConfig):Promise<void>;
 
+export function CopyCells(arg1:Array<database.ColumnInfo>,arg2:Array<any>,arg3:database.CopyOptions):Promise<string>;
+
+export function CopySecret(arg1:string,arg2:number):Promise<void>;
+
+export function CreateExtension(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
+export function DeleteBackup(arg1:string):Promise<void>;
+
 export function DeleteConnection(arg1:string):Promise<void>;
 
-export function DeleteRow(arg1:string,arg2:string,arg3:string,arg4:any):Promise<database.ExecuteResult>;
+export function DeleteExportSchedule(arg1:string):Promise<void>;
+
+export function DeleteRow(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>):Promise<database.ExecuteResult>;
+
+export function DeleteRowByLocator(arg1:string,arg2:string,arg3:string,arg4:database.RowLocator):Promise<database.ExecuteResult>;
+
+export function DeleteRows(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>):Promise<database.ExecuteResult>;
+
+export function DeleteSavedQuery(arg1:string):Promise<void>;
+
+export function DeleteTranslationMap(arg1:string):Promise<void>;
+
+export function DetectBackupTools():Promise<database.BackupTools>;
 
-export function DeleteRows(arg1:string,arg2:string,arg3:string,arg4:Array<any>):Promise<database.ExecuteResult>;
+export function DetectFormat(arg1:string):Promise<database.FormatHint>;
+
+export function DetectParameters(arg1:string):Promise<Array<database.Placeholder>>;
+
+export function DiscardChangeSet(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function Disconnect():Promise<void>;
 
-export function DropTable(arg1:string,arg2:string):Promise<void>;
+export function DiscoverLocalServers():Promise<Array<database.DiscoveredServer>>;
+
+export function DownloadCell(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:string,arg7:string):Promise<void>;
+
+export function DropExtension(arg1:string,arg2:boolean):Promise<void>;
+
+export function DropTable(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function DropTrigger(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function DumpTables(arg1:string,arg2:string,arg3:database.DumpOptions):Promise<database.DumpResult>;
+
+export function DuplicateRow(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:Record<string, any>):Promise<database.ExecuteResult>;
+
+export function DuplicateRowValues(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>):Promise<Record<string, any>>;
 
 export function ExecuteQuery(arg1:string):Promise<database.QueryResult>;
 
+export function ExecuteRoutine(arg1:string,arg2:database.RoutineInfo,arg3:Array<any>):Promise<database.RoutineResult>;
+
+export function ExecuteSQL(arg1:string,arg2:database.SQLOptions):Promise<database.SQLResult>;
+
 export function ExecuteStatement(arg1:string):Promise<database.ExecuteResult>;
 
-export function ExportTable(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+export function ExplainQuery(arg1:string,arg2:database.ExplainOptions):Promise<database.ExplainResult>;
+
+export function ExportQueryCSV(arg1:string,arg2:string,arg3:database.CSVExportOptions):Promise<void>;
+
+export function ExportResultsMarkup(arg1:Array<database.QueryResult>,arg2:string,arg3:string):Promise<void>;
+
+export function ExportResultsXLSX(arg1:Array<database.QueryResult>,arg2:string):Promise<void>;
+
+export function ExportTable(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<void>;
+
+export function ExportTableCSV(arg1:string,arg2:string,arg3:string,arg4:string,arg5:database.CSVExportOptions):Promise<void>;
+
+export function ExportToGoogleSheet(arg1:database.QueryResult,arg2:database.GoogleSheetExport):Promise<database.GoogleSheetResult>;
+
+export function FetchStream(arg1:number):Promise<database.StreamChunk>;
+
+export function FormatCellsHTML(arg1:Array<database.ColumnInfo>,arg2:Array<any>,arg3:database.CopyOptions):Promise<string>;
+
+export function FormatSQL(arg1:string,arg2:database.FormatOptions):Promise<string>;
+
+export function GenerateStatements(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:database.StatementOptions):Promise<string>;
+
+export function GetActiveOperations():Promise<Array<database.Operation>>;
+
+export function GetActivityLog(arg1:number):Promise<Array<database.ActivityEntry>>;
 
 export function GetAppVersion():Promise<string>;
 
-export function GetColumns(arg1:string,arg2:string):Promise<Array<database.ColumnInfo>>;
+export function GetBinaryCell(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:string):Promise<database.BinaryValue>;
+
+export function GetCellValue(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:string):Promise<database.CellValue>;
+
+export function GetChangeSet(arg1:string,arg2:string,arg3:string):Promise<database.ChangeSet>;
+
+export function GetColumnTranslations(arg1:string,arg2:string,arg3:string):Promise<Record<string, Record<string, string>>>;
+
+export function GetColumns(arg1:string,arg2:string,arg3:string):Promise<Array<database.ColumnInfo>>;
+
+export function GetCompletionMetadata(arg1:string,arg2:number):Promise<database.CompletionMetadata>;
+
+export function GetConnectionStrings(arg1:database.ConnectionConfig):Promise<database.ConnectionStrings>;
+
+export function GetConstraints(arg1:string,arg2:string,arg3:string):Promise<Array<database.ConstraintInfo>>;
+
+export function GetCustomTypes():Promise<Array<database.CustomTypeInfo>>;
 
 export function GetDatabaseSchema(arg1:string):Promise<Record<string, Array<string>>>;
 
 export function GetDatabases():Promise<Array<database.DatabaseInfo>>;
 
-export function GetDistinctValues(arg1:string,arg2:string,arg3:string):Promise<Array<string>>;
+export function GetDistinctValues(arg1:string,arg2:string,arg3:string,arg4:string):Promise<Array<string>>;
+
+export function GetExportRuns(arg1:string):Promise<Array<database.ExportRun>>;
+
+export function GetExtensions():Promise<Array<database.ExtensionInfo>>;
+
+export function GetForeignKeys(arg1:string,arg2:string,arg3:string):Promise<Array<database.ForeignKeyInfo>>;
+
+export function GetForeignServers():Promise<Array<database.ForeignServerInfo>>;
+
+export function GetForeignTables(arg1:string):Promise<Array<database.ForeignTableInfo>>;
+
+export function GetGoogleAccount():Promise<database.GoogleAccount>;
+
+export function GetInterceptors():Promise<Array<string>>;
+
+export function GetJobResult(arg1:number):Promise<database.SQLResult>;
+
+export function GetLoadTestStatus():Promise<database.LoadTestStatus>;
+
+export function GetLockStatus():Promise<database.LockStatus>;
+
+export function GetMaterializedViews(arg1:string):Promise<Array<database.MaterializedViewInfo>>;
+
+export function GetRoles():Promise<Array<database.RoleInfo>>;
+
+export function GetRoutines(arg1:string):Promise<Array<database.RoutineInfo>>;
+
+export function GetSchema():Promise<string>;
+
+export function GetSchemas():Promise<Array<string>>;
+
+export function GetSequences(arg1:string):Promise<Array<database.SequenceInfo>>;
+
+export function GetStoreHealth():Promise<Array<database.StoreHealth>>;
+
+export function GetTableDDL(arg1:string,arg2:string,arg3:string):Promise<string>;
 
 export function GetTableData(arg1:database.TableDataRequest):Promise<database.TableDataResponse>;
 
-export function GetTableInfo(arg1:string,arg2:string):Promise<database.TableDetails>;
+export function GetTableInfo(arg1:string,arg2:string,arg3:string):Promise<database.TableDetails>;
+
+export function GetTablePrivileges(arg1:string,arg2:string,arg3:string):Promise<Array<database.TablePrivilege>>;
+
+export function GetTables(arg1:string,arg2:string):Promise<Array<database.TableInfo>>;
 
-export function GetTables(arg1:string):Promise<Array<database.TableInfo>>;
+export function GetTransactionState(arg1:string):Promise<database.TransactionState>;
 
-export function InsertRow(arg1:string,arg2:string,arg3:Record<string, any>):Promise<database.ExecuteResult>;
+export function GetTriggers(arg1:string,arg2:string,arg3:string):Promise<Array<database.TriggerInfo>>;
+
+export function GetUndoLog():Promise<Array<database.UndoEntry>>;
+
+export function GetViews(arg1:string):Promise<Array<database.ViewInfo>>;
+
+export function GetWALArchiveStatus():Promise<database.WALArchiveStatus>;
+
+export function ImportFile(arg1:string,arg2:string,arg3:string,arg4:string,arg5:database.ImportOptions):Promise<database.ImportResult>;
+
+export function InsertRow(arg1:string,arg2:string,arg3:string,arg4:Record<string, any>):Promise<database.ExecuteResult>;
 
 export function IsConnected():Promise<boolean>;
 
 export function IsFullscreen():Promise<boolean>;
 
+export function ListBackups():Promise<Array<database.BackupFile>>;
+
+export function ListExportSchedules():Promise<Array<database.ExportScheduleStatus>>;
+
+export function ListJobs():Promise<Array<database.Job>>;
+
+export function ListTableCopies():Promise<Array<database.TableCopy>>;
+
+export function Listen(arg1:string):Promise<void>;
+
+export function ListeningChannels():Promise<Array<string>>;
+
+export function LoadColumnFormatters(arg1:string,arg2:string,arg3:string):Promise<Array<database.ColumnFormatter>>;
+
 export function LoadConnections():Promise<Array<database.SavedConnection>>;
 
+export function LoadSavedQueries(arg1:string):Promise<Array<database.SavedQuery>>;
+
+export function LoadTranslationMaps():Promise<Array<database.TranslationMap>>;
+
+export function Lock():Promise<void>;
+
+export function MoveSavedQueries(arg1:string,arg2:string):Promise<number>;
+
+export function Notify(arg1:string,arg2:string):Promise<void>;
+
+export function OpenStream(arg1:string,arg2:number,arg3:Record<string, any>):Promise<database.StreamInfo>;
+
+export function PasteRows(arg1:string,arg2:string,arg3:string,arg4:string,arg5:database.PasteOptions):Promise<database.ImportResult>;
+
+export function PreviewAlterTable(arg1:string,arg2:string,arg3:string,arg4:database.TableAlteration):Promise<database.SQLPreview>;
+
+export function PreviewBulkDelete(arg1:string,arg2:string,arg3:string,arg4:database.BulkDelete):Promise<database.BulkPreview>;
+
+export function PreviewBulkUpdate(arg1:string,arg2:string,arg3:string,arg4:database.BulkUpdate):Promise<database.BulkPreview>;
+
+export function PreviewCellUpdate(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:string,arg7:string):Promise<database.CellUpdatePreview>;
+
+export function PreviewChangeSet(arg1:string,arg2:string,arg3:string):Promise<database.SQLPreview>;
+
+export function PreviewDeleteRow(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>):Promise<database.SQLPreview>;
+
+export function PreviewDeleteRowByLocator(arg1:string,arg2:string,arg3:string,arg4:database.RowLocator):Promise<database.SQLPreview>;
+
+export function PreviewDeleteRows(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>):Promise<database.SQLPreview>;
+
+export function PreviewDropTable(arg1:string,arg2:string,arg3:string):Promise<database.SQLPreview>;
+
+export function PreviewImport(arg1:string,arg2:database.ImportOptions):Promise<database.ImportPreview>;
+
+export function PreviewInsertRow(arg1:string,arg2:string,arg3:string,arg4:Record<string, any>):Promise<database.SQLPreview>;
+
+export function PreviewPaste(arg1:string,arg2:string,arg3:string,arg4:string,arg5:database.PasteOptions):Promise<database.PastePreview>;
+
+export function PreviewPrivilegeChange(arg1:string,arg2:database.PrivilegeChange):Promise<string>;
+
+export function PreviewTruncateTable(arg1:string,arg2:string,arg3:string):Promise<database.SQLPreview>;
+
+export function PreviewUndo():Promise<database.SQLPreview>;
+
+export function PreviewUpdateRow(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:Record<string, any>):Promise<database.SQLPreview>;
+
+export function PreviewUpdateRowByLocator(arg1:string,arg2:string,arg3:string,arg4:database.RowLocator,arg5:Record<string, any>):Promise<database.SQLPreview>;
+
+export function PruneHistory(arg1:database.HistoryPrune):Promise<number>;
+
+export function ReadClipboard():Promise<string>;
+
+export function RecoverStore(arg1:string):Promise<database.StoreHealth>;
+
+export function RefreshMaterializedView(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
+export function RememberQueryValues(arg1:string,arg2:Record<string, any>):Promise<void>;
+
 export function RenameConnection(arg1:string,arg2:string):Promise<void>;
 
+export function ReportActivity():Promise<void>;
+
+export function RerunHistory(arg1:number):Promise<database.SQLResult>;
+
+export function ResetLock():Promise<string>;
+
 export function RestartApp():Promise<void>;
 
+export function RestartSequence(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function Restore(arg1:string,arg2:string,arg3:database.RestoreOptions):Promise<void>;
+
+export function ResumeTableCopy(arg1:number):Promise<database.TableCopy>;
+
+export function RollbackTransaction(arg1:string):Promise<database.TransactionState>;
+
+export function RunExportSchedule(arg1:string):Promise<void>;
+
+export function SaveCellValue(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:string,arg7:string):Promise<database.ExecuteResult>;
+
+export function SaveColumnFormatters(arg1:string,arg2:string,arg3:string,arg4:Array<database.ColumnFormatter>):Promise<void>;
+
 export function SaveConnection(arg1:string,arg2:database.ConnectionConfig):Promise<void>;
 
+export function SaveExportSchedule(arg1:database.ExportSchedule):Promise<database.ExportSchedule>;
+
+export function SaveQuery(arg1:database.SavedQuery):Promise<database.SavedQuery>;
+
+export function SaveTranslationMap(arg1:database.TranslationMap):Promise<void>;
+
+export function SearchHistory(arg1:database.HistoryFilter):Promise<Array<database.HistoryEntry>>;
+
+export function SearchSchema(arg1:string,arg2:number):Promise<Array<database.SchemaSearchResult>>;
+
+export function SelectBackupFile():Promise<string>;
+
+export function SelectCellDownloadPath(arg1:string):Promise<string>;
+
+export function SelectCellUploadFile():Promise<string>;
+
+export function SelectExportDirectory():Promise<string>;
+
 export function SelectExportPath(arg1:string):Promise<string>;
 
-export function TestConnection(arg1:database.ConnectionConfig):Promise<boolean>;
+export function SelectImportFile():Promise<string>;
+
+export function SetColumnComment(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<void>;
+
+export function SetGoogleClient(arg1:string,arg2:string):Promise<void>;
+
+export function SetIdleLockTimeout(arg1:number):Promise<void>;
+
+export function SetLockPassphrase(arg1:string,arg2:string):Promise<void>;
+
+export function SetTableComment(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function SetTriggerEnabled(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean):Promise<void>;
+
+export function SignInGoogle():Promise<database.GoogleAccount>;
+
+export function SignOutGoogle():Promise<void>;
+
+export function StageChange(arg1:string,arg2:string,arg3:string,arg4:database.StagedChange):Promise<database.ChangeSet>;
+
+export function StartJob(arg1:string,arg2:database.SQLOptions):Promise<database.Job>;
+
+export function StartLoadTest(arg1:database.LoadTestOptions):Promise<void>;
+
+export function StartTableCopy(arg1:database.TableCopyRequest):Promise<database.TableCopy>;
+
+export function StopLoadTest():Promise<void>;
+
+export function TestConnection(arg1:database.ConnectionConfig):Promise<database.ConnectionDiagnostics>;
 
 export function ToggleFullscreen():Promise<void>;
 
-export function TruncateTable(arg1:string,arg2:string):Promise<void>;
+export function TruncateTable(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function UndoLastChange():Promise<database.UndoEntry>;
+
+export function Unlisten(arg1:string):Promise<void>;
+
+export function Unlock(arg1:string):Promise<void>;
+
+export function UnstageChange(arg1:string,arg2:string,arg3:string,arg4:number):Promise<database.ChangeSet>;
 
 export function UpdateConnection(arg1:string,arg2:database.ConnectionConfig):Promise<void>;
 
-export function UpdateRow(arg1:string,arg2:string,arg3:string,arg4:any,arg5:Record<string, any>):Promise<database.ExecuteResult>;
+export function UpdateRow(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:Record<string, any>):Promise<database.ExecuteResult>;
+
+export function UpdateRowByLocator(arg1:string,arg2:string,arg3:string,arg4:database.RowLocator,arg5:Record<string, any>):Promise<database.ExecuteResult>;
+
+export function UploadCell(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:Array<any>,arg6:string,arg7:string):Promise<database.ExecuteResult>;
 
 export function UseDatabase(arg1:string):Promise<void>;
+
+export function ValidateJSON(arg1:string):Promise<database.JSONValidation>;
@@ -2,60 +2,308 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function AlterTable(arg1, arg2, arg3) {
-  return window['go']['main']['App']['AlterTable'](arg1, arg2, arg3);
+export function AddEnumValue(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['AddEnumValue'](arg1, arg2, arg3, arg4);
+}
+
+export function AlterSequence(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AlterSequence'](arg1, arg2, arg3);
+}
+
+export function AlterTable(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['AlterTable'](arg1, arg2, arg3, arg4);
+}
+
+export function AnalyzeStatement(arg1, arg2) {
+  return window['go']['main']['App']['AnalyzeStatement'](arg1, arg2);
+}
+
+export function ApplyChangeSet(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ApplyChangeSet'](arg1, arg2, arg3);
+}
+
+export function ApplyPrivilegeChange(arg1, arg2) {
+  return window['go']['main']['App']['ApplyPrivilegeChange'](arg1, arg2);
 }
 
 export function ApplyUpdate(arg1) {
   return window['go']['main']['App']['ApplyUpdate'](arg1);
 }
 
+export function Backup(arg1, arg2) {
+  return window['go']['main']['App']['Backup'](arg1, arg2);
+}
+
+export function BeginTransaction(arg1) {
+  return window['go']['main']['App']['BeginTransaction'](arg1);
+}
+
+export function BenchmarkQuery(arg1, arg2) {
+  return window['go']['main']['App']['BenchmarkQuery'](arg1, arg2);
+}
+
+export function BulkDeleteRows(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['BulkDeleteRows'](arg1, arg2, arg3, arg4);
+}
+
+export function BulkUpdateRows(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['BulkUpdateRows'](arg1, arg2, arg3, arg4);
+}
+
+export function CancelJob(arg1) {
+  return window['go']['main']['App']['CancelJob'](arg1);
+}
+
+export function CancelOperation(arg1) {
+  return window['go']['main']['App']['CancelOperation'](arg1);
+}
+
+export function CancelOperations() {
+  return window['go']['main']['App']['CancelOperations']();
+}
+
+export function CancelTableCopy(arg1) {
+  return window['go']['main']['App']['CancelTableCopy'](arg1);
+}
+
 export function CheckForUpdate() {
   return window['go']['main']['App']['CheckForUpdate']();
 }
 
+export function ClearActivityLog() {
+  return window['go']['main']['App']['ClearActivityLog']();
+}
+
+export function ClearJobs() {
+  return window['go']['main']['App']['ClearJobs']();
+}
+
+export function ClearTableCopies() {
+  return window['go']['main']['App']['ClearTableCopies']();
+}
+
+export function CloseQueryTab(arg1) {
+  return window['go']['main']['App']['CloseQueryTab'](arg1);
+}
+
+export function CloseStream(arg1) {
+  return window['go']['main']['App']['CloseStream'](arg1);
+}
+
+export function CommitTransaction(arg1) {
+  return window['go']['main']['App']['CommitTransaction'](arg1);
+}
+
+export function CompareTables(arg1) {
+  return window['go']['main']['App']['CompareTables'](arg1);
+}
+
 export function Connect(arg1) {
   return window['go']['main']['App']['Connect'](arg1);
 }
 
+export function CopyCells(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CopyCells'](arg1, arg2, arg3);
+}
+
+export function CopySecret(arg1, arg2) {
+  return window['go']['main']['App']['CopySecret'](arg1, arg2);
+}
+
+export function CreateExtension(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CreateExtension'](arg1, arg2, arg3);
+}
+
+export function DeleteBackup(arg1) {
+  return window['go']['main']['App']['DeleteBackup'](arg1);
+}
+
 export function DeleteConnection(arg1) {
   return window['go']['main']['App']['DeleteConnection'](arg1);
 }
 
-export function DeleteRow(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['DeleteRow'](arg1, arg2, arg3, arg4);
+export function DeleteExportSchedule(arg1) {
+  return window['go']['main']['App']['DeleteExportSchedule'](arg1);
+}
+
+export function DeleteRow(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['DeleteRow'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function DeleteRowByLocator(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['DeleteRowByLocator'](arg1, arg2, arg3, arg4);
+}
+
+export function DeleteRows(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['DeleteRows'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function DeleteSavedQuery(arg1) {
+  return window['go']['main']['App']['DeleteSavedQuery'](arg1);
+}
+
+export function DeleteTranslationMap(arg1) {
+  return window['go']['main']['App']['DeleteTranslationMap'](arg1);
+}
+
+export function DetectBackupTools() {
+  return window['go']['main']['App']['DetectBackupTools']();
 }
 
-export function DeleteRows(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['DeleteRows'](arg1, arg2, arg3, arg4);
+export function DetectFormat(arg1) {
+  return window['go']['main']['App']['DetectFormat'](arg1);
+}
+
+export function DetectParameters(arg1) {
+  return window['go']['main']['App']['DetectParameters'](arg1);
+}
+
+export function DiscardChangeSet(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DiscardChangeSet'](arg1, arg2, arg3);
 }
 
 export function Disconnect() {
   return window['go']['main']['App']['Disconnect']();
 }
 
-export function DropTable(arg1, arg2) {
-  return window['go']['main']['App']['DropTable'](arg1, arg2);
+export function DiscoverLocalServers() {
+  return window['go']['main']['App']['DiscoverLocalServers']();
+}
+
+export function DownloadCell(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['DownloadCell'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function DropExtension(arg1, arg2) {
+  return window['go']['main']['App']['DropExtension'](arg1, arg2);
+}
+
+export function DropTable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DropTable'](arg1, arg2, arg3);
+}
+
+export function DropTrigger(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['DropTrigger'](arg1, arg2, arg3, arg4);
+}
+
+export function DumpTables(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DumpTables'](arg1, arg2, arg3);
+}
+
+export function DuplicateRow(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['DuplicateRow'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function DuplicateRowValues(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['DuplicateRowValues'](arg1, arg2, arg3, arg4, arg5);
 }
 
 export function ExecuteQuery(arg1) {
   return window['go']['main']['App']['ExecuteQuery'](arg1);
 }
 
+export function ExecuteRoutine(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExecuteRoutine'](arg1, arg2, arg3);
+}
+
+export function ExecuteSQL(arg1, arg2) {
+  return window['go']['main']['App']['ExecuteSQL'](arg1, arg2);
+}
+
 export function ExecuteStatement(arg1) {
   return window['go']['main']['App']['ExecuteStatement'](arg1);
 }
 
-export function ExportTable(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['ExportTable'](arg1, arg2, arg3, arg4);
+export function ExplainQuery(arg1, arg2) {
+  return window['go']['main']['App']['ExplainQuery'](arg1, arg2);
+}
+
+export function ExportQueryCSV(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportQueryCSV'](arg1, arg2, arg3);
+}
+
+export function ExportResultsMarkup(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportResultsMarkup'](arg1, arg2, arg3);
+}
+
+export function ExportResultsXLSX(arg1, arg2) {
+  return window['go']['main']['App']['ExportResultsXLSX'](arg1, arg2);
+}
+
+export function ExportTable(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['ExportTable'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function ExportTableCSV(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['ExportTableCSV'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function ExportToGoogleSheet(arg1, arg2) {
+  return window['go']['main']['App']['ExportToGoogleSheet'](arg1, arg2);
+}
+
+export function FetchStream(arg1) {
+  return window['go']['main']['App']['FetchStream'](arg1);
+}
+
+export function FormatCellsHTML(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FormatCellsHTML'](arg1, arg2, arg3);
+}
+
+export function FormatSQL(arg1, arg2) {
+  return window['go']['main']['App']['FormatSQL'](arg1, arg2);
+}
+
+export function GenerateStatements(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['GenerateStatements'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function GetActiveOperations() {
+  return window['go']['main']['App']['GetActiveOperations']();
+}
+
+export function GetActivityLog(arg1) {
+  return window['go']['main']['App']['GetActivityLog'](arg1);
 }
 
 export function GetAppVersion() {
   return window['go']['main']['App']['GetAppVersion']();
 }
 
-export function GetColumns(arg1, arg2) {
-  return window['go']['main']['App']['GetColumns'](arg1, arg2);
+export function GetBinaryCell(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['GetBinaryCell'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function GetCellValue(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['GetCellValue'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function GetChangeSet(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetChangeSet'](arg1, arg2, arg3);
+}
+
+export function GetColumnTranslations(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetColumnTranslations'](arg1, arg2, arg3);
+}
+
+export function GetColumns(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetColumns'](arg1, arg2, arg3);
+}
+
+export function GetCompletionMetadata(arg1, arg2) {
+  return window['go']['main']['App']['GetCompletionMetadata'](arg1, arg2);
+}
+
+export function GetConnectionStrings(arg1) {
+  return window['go']['main']['App']['GetConnectionStrings'](arg1);
+}
+
+export function GetConstraints(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetConstraints'](arg1, arg2, arg3);
+}
+
+export function GetCustomTypes() {
+  return window['go']['main']['App']['GetCustomTypes']();
 }
 
 export function GetDatabaseSchema(arg1) {
@@ -66,24 +314,124 @@ export function GetDatabases() {
   return window['go']['main']['App']['GetDatabases']();
 }
 
-export function GetDistinctValues(arg1, arg2, arg3) {
-  return window['go']['main']['App']['GetDistinctValues'](arg1, arg2, arg3);
+export function GetDistinctValues(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetDistinctValues'](arg1, arg2, arg3, arg4);
+}
+
+export function GetExportRuns(arg1) {
+  return window['go']['main']['App']['GetExportRuns'](arg1);
+}
+
+export function GetExtensions() {
+  return window['go']['main']['App']['GetExtensions']();
+}
+
+export function GetForeignKeys(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetForeignKeys'](arg1, arg2, arg3);
+}
+
+export function GetForeignServers() {
+  return window['go']['main']['App']['GetForeignServers']();
+}
+
+export function GetForeignTables(arg1) {
+  return window['go']['main']['App']['GetForeignTables'](arg1);
+}
+
+export function GetGoogleAccount() {
+  return window['go']['main']['App']['GetGoogleAccount']();
+}
+
+export function GetInterceptors() {
+  return window['go']['main']['App']['GetInterceptors']();
+}
+
+export function GetJobResult(arg1) {
+  return window['go']['main']['App']['GetJobResult'](arg1);
+}
+
+export function GetLoadTestStatus() {
+  return window['go']['main']['App']['GetLoadTestStatus']();
+}
+
+export function GetLockStatus() {
+  return window['go']['main']['App']['GetLockStatus']();
+}
+
+export function GetMaterializedViews(arg1) {
+  return window['go']['main']['App']['GetMaterializedViews'](arg1);
+}
+
+export function GetRoles() {
+  return window['go']['main']['App']['GetRoles']();
+}
+
+export function GetRoutines(arg1) {
+  return window['go']['main']['App']['GetRoutines'](arg1);
+}
+
+export function GetSchema() {
+  return window['go']['main']['App']['GetSchema']();
+}
+
+export function GetSchemas() {
+  return window['go']['main']['App']['GetSchemas']();
+}
+
+export function GetSequences(arg1) {
+  return window['go']['main']['App']['GetSequences'](arg1);
+}
+
+export function GetStoreHealth() {
+  return window['go']['main']['App']['GetStoreHealth']();
+}
+
+export function GetTableDDL(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetTableDDL'](arg1, arg2, arg3);
 }
 
 export function GetTableData(arg1) {
   return window['go']['main']['App']['GetTableData'](arg1);
 }
 
-export function GetTableInfo(arg1, arg2) {
-  return window['go']['main']['App']['GetTableInfo'](arg1, arg2);
+export function GetTableInfo(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetTableInfo'](arg1, arg2, arg3);
+}
+
+export function GetTablePrivileges(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetTablePrivileges'](arg1, arg2, arg3);
+}
+
+export function GetTables(arg1, arg2) {
+  return window['go']['main']['App']['GetTables'](arg1, arg2);
+}
+
+export function GetTransactionState(arg1) {
+  return window['go']['main']['App']['GetTransactionState'](arg1);
+}
+
+export function GetTriggers(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetTriggers'](arg1, arg2, arg3);
+}
+
+export function GetUndoLog() {
+  return window['go']['main']['App']['GetUndoLog']();
+}
+
+export function GetViews(arg1) {
+  return window['go']['main']['App']['GetViews'](arg1);
+}
+
+export function GetWALArchiveStatus() {
+  return window['go']['main']['App']['GetWALArchiveStatus']();
 }
 
-export function GetTables(arg1) {
-  return window['go']['main']['App']['GetTables'](arg1);
+export function ImportFile(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['ImportFile'](arg1, arg2, arg3, arg4, arg5);
 }
 
-export function InsertRow(arg1, arg2, arg3) {
-  return window['go']['main']['App']['InsertRow'](arg1, arg2, arg3);
+export function InsertRow(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['InsertRow'](arg1, arg2, arg3, arg4);
 }
 
 export function IsConnected() {
@@ -94,26 +442,302 @@ export function IsFullscreen() {
   return window['go']['main']['App']['IsFullscreen']();
 }
 
+export function ListBackups() {
+  return window['go']['main']['App']['ListBackups']();
+}
+
+export function ListExportSchedules() {
+  return window['go']['main']['App']['ListExportSchedules']();
+}
+
+export function ListJobs() {
+  return window['go']['main']['App']['ListJobs']();
+}
+
+export function ListTableCopies() {
+  return window['go']['main']['App']['ListTableCopies']();
+}
+
+export function Listen(arg1) {
+  return window['go']['main']['App']['Listen'](arg1);
+}
+
+export function ListeningChannels() {
+  return window['go']['main']['App']['ListeningChannels']();
+}
+
+export function LoadColumnFormatters(arg1, arg2, arg3) {
+  return window['go']['main']['App']['LoadColumnFormatters'](arg1, arg2, arg3);
+}
+
 export function LoadConnections() {
   return window['go']['main']['App']['LoadConnections']();
 }
 
+export function LoadSavedQueries(arg1) {
+  return window['go']['main']['App']['LoadSavedQueries'](arg1);
+}
+
+export function LoadTranslationMaps() {
+  return window['go']['main']['App']['LoadTranslationMaps']();
+}
+
+export function Lock() {
+  return window['go']['main']['App']['Lock']();
+}
+
+export function MoveSavedQueries(arg1, arg2) {
+  return window['go']['main']['App']['MoveSavedQueries'](arg1, arg2);
+}
+
+export function Notify(arg1, arg2) {
+  return window['go']['main']['App']['Notify'](arg1, arg2);
+}
+
+export function OpenStream(arg1, arg2, arg3) {
+  return window['go']['main']['App']['OpenStream'](arg1, arg2, arg3);
+}
+
+export function PasteRows(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['PasteRows'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function PreviewAlterTable(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['PreviewAlterTable'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewBulkDelete(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['PreviewBulkDelete'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewBulkUpdate(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['PreviewBulkUpdate'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewCellUpdate(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['PreviewCellUpdate'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function PreviewChangeSet(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewChangeSet'](arg1, arg2, arg3);
+}
+
+export function PreviewDeleteRow(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['PreviewDeleteRow'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function PreviewDeleteRowByLocator(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['PreviewDeleteRowByLocator'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewDeleteRows(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['PreviewDeleteRows'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function PreviewDropTable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewDropTable'](arg1, arg2, arg3);
+}
+
+export function PreviewImport(arg1, arg2) {
+  return window['go']['main']['App']['PreviewImport'](arg1, arg2);
+}
+
+export function PreviewInsertRow(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['PreviewInsertRow'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewPaste(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['PreviewPaste'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function PreviewPrivilegeChange(arg1, arg2) {
+  return window['go']['main']['App']['PreviewPrivilegeChange'](arg1, arg2);
+}
+
+export function PreviewTruncateTable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewTruncateTable'](arg1, arg2, arg3);
+}
+
+export function PreviewUndo() {
+  return window['go']['main']['App']['PreviewUndo']();
+}
+
+export function PreviewUpdateRow(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['PreviewUpdateRow'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function PreviewUpdateRowByLocator(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['PreviewUpdateRowByLocator'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function PruneHistory(arg1) {
+  return window['go']['main']['App']['PruneHistory'](arg1);
+}
+
+export function ReadClipboard() {
+  return window['go']['main']['App']['ReadClipboard']();
+}
+
+export function RecoverStore(arg1) {
+  return window['go']['main']['App']['RecoverStore'](arg1);
+}
+
+export function RefreshMaterializedView(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RefreshMaterializedView'](arg1, arg2, arg3);
+}
+
+export function RememberQueryValues(arg1, arg2) {
+  return window['go']['main']['App']['RememberQueryValues'](arg1, arg2);
+}
+
 export function RenameConnection(arg1, arg2) {
   return window['go']['main']['App']['RenameConnection'](arg1, arg2);
 }
 
+export function ReportActivity() {
+  return window['go']['main']['App']['ReportActivity']();
+}
+
+export function RerunHistory(arg1) {
+  return window['go']['main']['App']['RerunHistory'](arg1);
+}
+
+export function ResetLock() {
+  return window['go']['main']['App']['ResetLock']();
+}
+
 export function RestartApp() {
   return window['go']['main']['App']['RestartApp']();
 }
 
+export function RestartSequence(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RestartSequence'](arg1, arg2, arg3);
+}
+
+export function Restore(arg1, arg2, arg3) {
+  return window['go']['main']['App']['Restore'](arg1, arg2, arg3);
+}
+
+export function ResumeTableCopy(arg1) {
+  return window['go']['main']['App']['ResumeTableCopy'](arg1);
+}
+
+export function RollbackTransaction(arg1) {
+  return window['go']['main']['App']['RollbackTransaction'](arg1);
+}
+
+export function RunExportSchedule(arg1) {
+  return window['go']['main']['App']['RunExportSchedule'](arg1);
+}
+
+export function SaveCellValue(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['SaveCellValue'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function SaveColumnFormatters(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SaveColumnFormatters'](arg1, arg2, arg3, arg4);
+}
+
 export function SaveConnection(arg1, arg2) {
   return window['go']['main']['App']['SaveConnection'](arg1, arg2);
 }
 
+export function SaveExportSchedule(arg1) {
+  return window['go']['main']['App']['SaveExportSchedule'](arg1);
+}
+
+export function SaveQuery(arg1) {
+  return window['go']['main']['App']['SaveQuery'](arg1);
+}
+
+export function SaveTranslationMap(arg1) {
+  return window['go']['main']['App']['SaveTranslationMap'](arg1);
+}
+
+export function SearchHistory(arg1) {
+  return window['go']['main']['App']['SearchHistory'](arg1);
+}
+
+export function SearchSchema(arg1, arg2) {
+  return window['go']['main']['App']['SearchSchema'](arg1, arg2);
+}
+
+export function SelectBackupFile() {
+  return window['go']['main']['App']['SelectBackupFile']();
+}
+
+export function SelectCellDownloadPath(arg1) {
+  return window['go']['main']['App']['SelectCellDownloadPath'](arg1);
+}
+
+export function SelectCellUploadFile() {
+  return window['go']['main']['App']['SelectCellUploadFile']();
+}
+
+export function SelectExportDirectory() {
+  return window['go']['main']['App']['SelectExportDirectory']();
+}
+
 export function SelectExportPath(arg1) {
   return window['go']['main']['App']['SelectExportPath'](arg1);
 }
 
+export function SelectImportFile() {
+  return window['go']['main']['App']['SelectImportFile']();
+}
+
+export function SetColumnComment(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SetColumnComment'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SetGoogleClient(arg1, arg2) {
+  return window['go']['main']['App']['SetGoogleClient'](arg1, arg2);
+}
+
+export function SetIdleLockTimeout(arg1) {
+  return window['go']['main']['App']['SetIdleLockTimeout'](arg1);
+}
+
+export function SetLockPassphrase(arg1, arg2) {
+  return window['go']['main']['App']['SetLockPassphrase'](arg1, arg2);
+}
+
+export function SetTableComment(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetTableComment'](arg1, arg2, arg3, arg4);
+}
+
+export function SetTriggerEnabled(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SetTriggerEnabled'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SignInGoogle() {
+  return window['go']['main']['App']['SignInGoogle']();
+}
+
+export function SignOutGoogle() {
+  return window['go']['main']['App']['SignOutGoogle']();
+}
+
+export function StageChange(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['StageChange'](arg1, arg2, arg3, arg4);
+}
+
+export function StartJob(arg1, arg2) {
+  return window['go']['main']['App']['StartJob'](arg1, arg2);
+}
+
+export function StartLoadTest(arg1) {
+  return window['go']['main']['App']['StartLoadTest'](arg1);
+}
+
+export function StartTableCopy(arg1) {
+  return window['go']['main']['App']['StartTableCopy'](arg1);
+}
+
+export function StopLoadTest() {
+  return window['go']['main']['App']['StopLoadTest']();
+}
+
 export function TestConnection(arg1) {
   return window['go']['main']['App']['TestConnection'](arg1);
 }
@@ -122,18 +746,46 @@ export function ToggleFullscreen() {
   return window['go']['main']['App']['ToggleFullscreen']();
 }
 
-export function TruncateTable(arg1, arg2) {
-  return window['go']['main']['App']['TruncateTable'](arg1, arg2);
+export function TruncateTable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['TruncateTable'](arg1, arg2, arg3);
+}
+
+export function UndoLastChange() {
+  return window['go']['main']['App']['UndoLastChange']();
+}
+
+export function Unlisten(arg1) {
+  return window['go']['main']['App']['Unlisten'](arg1);
+}
+
+export function Unlock(arg1) {
+  return window['go']['main']['App']['Unlock'](arg1);
+}
+
+export function UnstageChange(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['UnstageChange'](arg1, arg2, arg3, arg4);
 }
 
 export function UpdateConnection(arg1, arg2) {
   return window['go']['main']['App']['UpdateConnection'](arg1, arg2);
 }
 
-export function UpdateRow(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['main']['App']['UpdateRow'](arg1, arg2, arg3, arg4, arg5);
+export function UpdateRow(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['UpdateRow'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function UpdateRowByLocator(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['UpdateRowByLocator'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function UploadCell(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['UploadCell'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
 }
 
 export function UseDatabase(arg1) {
   return window['go']['main']['App']['UseDatabase'](arg1);
 }
+
+export function ValidateJSON(arg1) {
+  return window['go']['main']['App']['ValidateJSON'](arg1);
+}
@@ -1,149 +1,2968 @@
 export namespace database {
 	
-	export class ColumnInfo {
+	export class ActivityEntry {
+	    id: number;
+	    time: string;
+	    query: string;
+	    args: number;
+	    durationMs: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.time = source["time"];
+	        this.query = source["query"];
+	        this.args = source["args"];
+	        this.durationMs = source["durationMs"];
+	        this.error = source["error"];
+	    }
+	}
+	export class BackupFile {
 	    name: string;
-	    type: string;
-	    nullable: boolean;
-	    key: string;
-	    default: string;
-	    extra: string;
-	    oldName?: string;
+	    path: string;
+	    format: string;
+	    size: number;
+	    modified: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ColumnInfo(source);
+	        return new BackupFile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.type = source["type"];
-	        this.nullable = source["nullable"];
-	        this.key = source["key"];
-	        this.default = source["default"];
-	        this.extra = source["extra"];
-	        this.oldName = source["oldName"];
+	        this.path = source["path"];
+	        this.format = source["format"];
+	        this.size = source["size"];
+	        this.modified = source["modified"];
 	    }
 	}
-	export class ConnectionConfig {
+	export class BackupOptions {
+	    format?: string;
+	    compression?: number;
+	    content?: string;
+	    tables?: string[];
+	    noOwner?: boolean;
+	    jobs?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.compression = source["compression"];
+	        this.content = source["content"];
+	        this.tables = source["tables"];
+	        this.noOwner = source["noOwner"];
+	        this.jobs = source["jobs"];
+	    }
+	}
+	export class BackupResult {
+	    path: string;
+	    size: number;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.durationMs = source["durationMs"];
+	    }
+	}
+	export class BackupTools {
+	    pgDump?: string;
+	    pgRestore?: string;
+	    psql?: string;
+	    mysqldump?: string;
+	    mysql?: string;
+	    flavor?: string;
+	    version?: string;
+	    serverVersion?: string;
+	    warning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupTools(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pgDump = source["pgDump"];
+	        this.pgRestore = source["pgRestore"];
+	        this.psql = source["psql"];
+	        this.mysqldump = source["mysqldump"];
+	        this.mysql = source["mysql"];
+	        this.flavor = source["flavor"];
+	        this.version = source["version"];
+	        this.serverVersion = source["serverVersion"];
+	        this.warning = source["warning"];
+	    }
+	}
+	export class BenchmarkOptions {
+	    runs: number;
+	    warmup: number;
+	    discardResults: boolean;
+	    clearCache: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BenchmarkOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runs = source["runs"];
+	        this.warmup = source["warmup"];
+	        this.discardResults = source["discardResults"];
+	        this.clearCache = source["clearCache"];
+	    }
+	}
+	export class BenchmarkResult {
+	    runs: number;
+	    latenciesMs: number[];
+	    minMs: number;
+	    maxMs: number;
+	    meanMs: number;
+	    medianMs: number;
+	    p95Ms: number;
+	    rowsPerRun: number;
+	    rowsPerSec: number;
+	    cacheCleared: boolean;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BenchmarkResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runs = source["runs"];
+	        this.latenciesMs = source["latenciesMs"];
+	        this.minMs = source["minMs"];
+	        this.maxMs = source["maxMs"];
+	        this.meanMs = source["meanMs"];
+	        this.medianMs = source["medianMs"];
+	        this.p95Ms = source["p95Ms"];
+	        this.rowsPerRun = source["rowsPerRun"];
+	        this.rowsPerSec = source["rowsPerSec"];
+	        this.cacheCleared = source["cacheCleared"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class BinaryValue {
+	    isNull: boolean;
+	    size: number;
+	    mimeType: string;
+	    dump: string;
+	    truncated: boolean;
+	    preview?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BinaryValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isNull = source["isNull"];
+	        this.size = source["size"];
+	        this.mimeType = source["mimeType"];
+	        this.dump = source["dump"];
+	        this.truncated = source["truncated"];
+	        this.preview = source["preview"];
+	    }
+	}
+	export class FilterCondition {
+	    column?: string;
+	    operator?: string;
+	    value?: any;
+	    path?: string[];
+	    conjunction?: string;
+	    group?: FilterCondition[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FilterCondition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.operator = source["operator"];
+	        this.value = source["value"];
+	        this.path = source["path"];
+	        this.conjunction = source["conjunction"];
+	        this.group = this.convertValues(source["group"], FilterCondition);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkDelete {
+	    filters?: FilterCondition[];
+	    search?: string;
+	    searchColumns?: string[];
+	    token: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkDelete(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filters = this.convertValues(source["filters"], FilterCondition);
+	        this.search = source["search"];
+	        this.searchColumns = source["searchColumns"];
+	        this.token = source["token"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PreviewStatement {
+	    query: string;
+	    args?: any[];
+	    sql: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PreviewStatement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.args = source["args"];
+	        this.sql = source["sql"];
+	    }
+	}
+	export class SQLPreview {
+	    statements: PreviewStatement[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SQLPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.statements = this.convertValues(source["statements"], PreviewStatement);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkPreview {
+	    preview: SQLPreview;
+	    matchingRows: number;
+	    token?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.preview = this.convertValues(source["preview"], SQLPreview);
+	        this.matchingRows = source["matchingRows"];
+	        this.token = source["token"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkUpdate {
+	    filters?: FilterCondition[];
+	    search?: string;
+	    searchColumns?: string[];
+	    values: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkUpdate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filters = this.convertValues(source["filters"], FilterCondition);
+	        this.search = source["search"];
+	        this.searchColumns = source["searchColumns"];
+	        this.values = source["values"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CSVExportOptions {
+	    delimiter?: string;
+	    quote?: string;
+	    null?: string;
+	    noHeader?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVExportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.delimiter = source["delimiter"];
+	        this.quote = source["quote"];
+	        this.null = source["null"];
+	        this.noHeader = source["noHeader"];
+	    }
+	}
+	export class CellUpdatePreview {
+	    changed: boolean;
+	    diff: string;
+	    query: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CellUpdatePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.changed = source["changed"];
+	        this.diff = source["diff"];
+	        this.query = source["query"];
+	    }
+	}
+	export class FormatHint {
+	    format: string;
+	    pretty?: string;
+	    mimeType?: string;
+	    dataUri?: string;
+	    header?: Record<string, any>;
+	    claims?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new FormatHint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.pretty = source["pretty"];
+	        this.mimeType = source["mimeType"];
+	        this.dataUri = source["dataUri"];
+	        this.header = source["header"];
+	        this.claims = source["claims"];
+	    }
+	}
+	export class CellValue {
+	    value: string;
+	    isNull: boolean;
+	    size: number;
 	    type: string;
-	    host: string;
-	    port: number;
-	    user: string;
-	    password: string;
-	    database: string;
-	    color: string;
-	    useSSL: boolean;
-	    sslMode: string;
-	    sslCACert: string;
-	    sslClientCert: string;
-	    sslClientKey: string;
-	    useSSHTunnel: boolean;
-	    sshHost: string;
-	    sshPort: number;
-	    sshUser: string;
-	    sshPassword: string;
-	    sshPrivateKey: string;
-	    sshPassphrase: string;
+	    format?: FormatHint;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConnectionConfig(source);
+	        return new CellValue(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.isNull = source["isNull"];
+	        this.size = source["size"];
 	        this.type = source["type"];
-	        this.host = source["host"];
-	        this.port = source["port"];
-	        this.user = source["user"];
-	        this.password = source["password"];
+	        this.format = this.convertValues(source["format"], FormatHint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StagedChange {
+	    id?: number;
+	    kind: string;
+	    primaryKey?: string[];
+	    primaryValues?: any[];
+	    values?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new StagedChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.primaryKey = source["primaryKey"];
+	        this.primaryValues = source["primaryValues"];
+	        this.values = source["values"];
+	    }
+	}
+	export class ChangeSet {
+	    database: string;
+	    schema?: string;
+	    table: string;
+	    changes: StagedChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangeSet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.database = source["database"];
-	        this.color = source["color"];
-	        this.useSSL = source["useSSL"];
-	        this.sslMode = source["sslMode"];
-	        this.sslCACert = source["sslCACert"];
-	        this.sslClientCert = source["sslClientCert"];
-	        this.sslClientKey = source["sslClientKey"];
-	        this.useSSHTunnel = source["useSSHTunnel"];
-	        this.sshHost = source["sshHost"];
-	        this.sshPort = source["sshPort"];
-	        this.sshUser = source["sshUser"];
-	        this.sshPassword = source["sshPassword"];
-	        this.sshPrivateKey = source["sshPrivateKey"];
-	        this.sshPassphrase = source["sshPassphrase"];
+	        this.schema = source["schema"];
+	        this.table = source["table"];
+	        this.changes = this.convertValues(source["changes"], StagedChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ChangeSetResult {
+	    applied: number;
+	    rowsAffected: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangeSetResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.applied = source["applied"];
+	        this.rowsAffected = source["rowsAffected"];
+	    }
+	}
+	export class ChunkMismatch {
+	    from: any;
+	    to: any;
+	    sourceRows: number;
+	    targetRows: number;
+	    sourceHash: string;
+	    targetHash: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChunkMismatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.sourceRows = source["sourceRows"];
+	        this.targetRows = source["targetRows"];
+	        this.sourceHash = source["sourceHash"];
+	        this.targetHash = source["targetHash"];
+	    }
+	}
+	export class ColumnFormatter {
+	    column: string;
+	    kind: string;
+	    unit?: string;
+	    layout?: string;
+	    timezone?: string;
+	    symbol?: string;
+	    divisor?: number;
+	    decimals?: number;
+	    labels?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ColumnFormatter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.kind = source["kind"];
+	        this.unit = source["unit"];
+	        this.layout = source["layout"];
+	        this.timezone = source["timezone"];
+	        this.symbol = source["symbol"];
+	        this.divisor = source["divisor"];
+	        this.decimals = source["decimals"];
+	        this.labels = source["labels"];
+	    }
+	}
+	export class DomainConstraint {
+	    name: string;
+	    expression: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DomainConstraint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.expression = source["expression"];
+	    }
+	}
+	export class CompositeAttribute {
+	    name: string;
+	    type: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompositeAttribute(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	    }
+	}
+	export class CustomTypeInfo {
+	    name: string;
+	    kind: string;
+	    values?: string[];
+	    definition?: string;
+	    attributes?: CompositeAttribute[];
+	    baseType?: string;
+	    notNull?: boolean;
+	    default?: string;
+	    checks?: DomainConstraint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CustomTypeInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.values = source["values"];
+	        this.definition = source["definition"];
+	        this.attributes = this.convertValues(source["attributes"], CompositeAttribute);
+	        this.baseType = source["baseType"];
+	        this.notNull = source["notNull"];
+	        this.default = source["default"];
+	        this.checks = this.convertValues(source["checks"], DomainConstraint);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ColumnInfo {
+	    name: string;
+	    type: string;
+	    nullable: boolean;
+	    key: string;
+	    default: string;
+	    extra: string;
+	    oldName?: string;
+	    comment: string;
+	    defaultExpression?: boolean;
+	    charset?: string;
+	    collation?: string;
+	    enumValues?: string[];
+	    elementType?: string;
+	    customType?: CustomTypeInfo;
+	    generated?: string;
+	    expression?: string;
+	    identity?: string;
+	    readOnly?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ColumnInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.nullable = source["nullable"];
+	        this.key = source["key"];
+	        this.default = source["default"];
+	        this.extra = source["extra"];
+	        this.oldName = source["oldName"];
+	        this.comment = source["comment"];
+	        this.defaultExpression = source["defaultExpression"];
+	        this.charset = source["charset"];
+	        this.collation = source["collation"];
+	        this.enumValues = source["enumValues"];
+	        this.elementType = source["elementType"];
+	        this.customType = this.convertValues(source["customType"], CustomTypeInfo);
+	        this.generated = source["generated"];
+	        this.expression = source["expression"];
+	        this.identity = source["identity"];
+	        this.readOnly = source["readOnly"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CompletionColumn {
+	    name: string;
+	    type: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompletionColumn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	    }
+	}
+	export class CompletionFunction {
+	    schema: string;
+	    name: string;
+	    kind: string;
+	    arguments: string;
+	    returnType?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompletionFunction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schema = source["schema"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.arguments = source["arguments"];
+	        this.returnType = source["returnType"];
+	    }
+	}
+	export class CompletionTable {
+	    schema: string;
+	    name: string;
+	    type: string;
+	    columns: CompletionColumn[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CompletionTable(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schema = source["schema"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.columns = this.convertValues(source["columns"], CompletionColumn);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CompletionMetadata {
+	    database: string;
+	    version: number;
+	    full: boolean;
+	    schemas: string[];
+	    tables: CompletionTable[];
+	    removed?: string[];
+	    functions: CompletionFunction[];
+	    keywords?: string[];
+	    builtins?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CompletionMetadata(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.database = source["database"];
+	        this.version = source["version"];
+	        this.full = source["full"];
+	        this.schemas = source["schemas"];
+	        this.tables = this.convertValues(source["tables"], CompletionTable);
+	        this.removed = source["removed"];
+	        this.functions = this.convertValues(source["functions"], CompletionFunction);
+	        this.keywords = source["keywords"];
+	        this.builtins = source["builtins"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class ConnectionConfig {
+	    type: string;
+	    host: string;
+	    port: number;
+	    user: string;
+	    password: string;
+	    database: string;
+	    schema?: string;
+	    color: string;
+	    useSSL: boolean;
+	    sslMode: string;
+	    sslCACert: string;
+	    sslClientCert: string;
+	    sslClientKey: string;
+	    useSSHTunnel: boolean;
+	    sshHost: string;
+	    sshPort: number;
+	    sshUser: string;
+	    sshPassword: string;
+	    sshPrivateKey: string;
+	    sshPassphrase: string;
+	    useVault: boolean;
+	    vaultAddr: string;
+	    vaultToken: string;
+	    vaultNamespace: string;
+	    vaultPath: string;
+	    useAWSSecret: boolean;
+	    awsSecretSource: string;
+	    awsSecretId: string;
+	    awsRegion: string;
+	    awsProfile: string;
+	    gridTimeout?: number;
+	    queryTimeout?: number;
+	    undoDepth?: number;
+	    undoMaxRows?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.user = source["user"];
+	        this.password = source["password"];
+	        this.database = source["database"];
+	        this.schema = source["schema"];
+	        this.color = source["color"];
+	        this.useSSL = source["useSSL"];
+	        this.sslMode = source["sslMode"];
+	        this.sslCACert = source["sslCACert"];
+	        this.sslClientCert = source["sslClientCert"];
+	        this.sslClientKey = source["sslClientKey"];
+	        this.useSSHTunnel = source["useSSHTunnel"];
+	        this.sshHost = source["sshHost"];
+	        this.sshPort = source["sshPort"];
+	        this.sshUser = source["sshUser"];
+	        this.sshPassword = source["sshPassword"];
+	        this.sshPrivateKey = source["sshPrivateKey"];
+	        this.sshPassphrase = source["sshPassphrase"];
+	        this.useVault = source["useVault"];
+	        this.vaultAddr = source["vaultAddr"];
+	        this.vaultToken = source["vaultToken"];
+	        this.vaultNamespace = source["vaultNamespace"];
+	        this.vaultPath = source["vaultPath"];
+	        this.useAWSSecret = source["useAWSSecret"];
+	        this.awsSecretSource = source["awsSecretSource"];
+	        this.awsSecretId = source["awsSecretId"];
+	        this.awsRegion = source["awsRegion"];
+	        this.awsProfile = source["awsProfile"];
+	        this.gridTimeout = source["gridTimeout"];
+	        this.queryTimeout = source["queryTimeout"];
+	        this.undoDepth = source["undoDepth"];
+	        this.undoMaxRows = source["undoMaxRows"];
+	    }
+	}
+	export class ConnectionDiagnostics {
+	    success: boolean;
+	    connectTimeMs: number;
+	    latencyMs: number;
+	    serverVersion: string;
+	    tls: boolean;
+	    tlsVersion?: string;
+	    currentUser: string;
+	    defaultSchema: string;
+	    permissions: string[];
+	    warnings?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.connectTimeMs = source["connectTimeMs"];
+	        this.latencyMs = source["latencyMs"];
+	        this.serverVersion = source["serverVersion"];
+	        this.tls = source["tls"];
+	        this.tlsVersion = source["tlsVersion"];
+	        this.currentUser = source["currentUser"];
+	        this.defaultSchema = source["defaultSchema"];
+	        this.permissions = source["permissions"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class ConnectionStrings {
+	    uri: string;
+	    cli: string;
+	    jdbc: string;
+	    go: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionStrings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.uri = source["uri"];
+	        this.cli = source["cli"];
+	        this.jdbc = source["jdbc"];
+	        this.go = source["go"];
+	    }
+	}
+	export class ConstraintInfo {
+	    name: string;
+	    type: string;
+	    columns: string[];
+	    expression?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConstraintInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.columns = source["columns"];
+	        this.expression = source["expression"];
+	    }
+	}
+	export class CopyOptions {
+	    delimiter: string;
+	    includeHeaders: boolean;
+	    nullMarker: string;
+	    timestampFormat: string;
+	    dateFormat: string;
+	    binaryFormat: string;
+	    clearAfterSec: number;
+	    styled: boolean;
+	    format?: string;
+	    table?: string;
+	    dialect?: string;
+	    multiRow?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CopyOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.delimiter = source["delimiter"];
+	        this.includeHeaders = source["includeHeaders"];
+	        this.nullMarker = source["nullMarker"];
+	        this.timestampFormat = source["timestampFormat"];
+	        this.dateFormat = source["dateFormat"];
+	        this.binaryFormat = source["binaryFormat"];
+	        this.clearAfterSec = source["clearAfterSec"];
+	        this.styled = source["styled"];
+	        this.format = source["format"];
+	        this.table = source["table"];
+	        this.dialect = source["dialect"];
+	        this.multiRow = source["multiRow"];
+	    }
+	}
+	
+	export class DatabaseInfo {
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	    }
+	}
+	export class DiscoveredServer {
+	    type: string;
+	    host: string;
+	    port: number;
+	    source: string;
+	    container?: string;
+	    image?: string;
+	    supported: boolean;
+	    config: ConnectionConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiscoveredServer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.source = source["source"];
+	        this.container = source["container"];
+	        this.image = source["image"];
+	        this.supported = source["supported"];
+	        this.config = this.convertValues(source["config"], ConnectionConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DumpOptions {
+	    tables: string[];
+	    content?: string;
+	    dropTables?: boolean;
+	    rowsPerInsert?: number;
+	    maxFileSizeMb?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DumpOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tables = source["tables"];
+	        this.content = source["content"];
+	        this.dropTables = source["dropTables"];
+	        this.rowsPerInsert = source["rowsPerInsert"];
+	        this.maxFileSizeMb = source["maxFileSizeMb"];
+	    }
+	}
+	export class DumpResult {
+	    files: string[];
+	    tables: number;
+	    rows: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DumpResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = source["files"];
+	        this.tables = source["tables"];
+	        this.rows = source["rows"];
+	    }
+	}
+	export class QueryResult {
+	    columns: string[];
+	    columnTypes: string[];
+	    rows: any[][];
+	    rowCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.columns = source["columns"];
+	        this.columnTypes = source["columnTypes"];
+	        this.rows = source["rows"];
+	        this.rowCount = source["rowCount"];
+	    }
+	}
+	export class ExecuteResult {
+	    rowsAffected: number;
+	    lastInsertId: number;
+	    returned?: QueryResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExecuteResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rowsAffected = source["rowsAffected"];
+	        this.lastInsertId = source["lastInsertId"];
+	        this.returned = this.convertValues(source["returned"], QueryResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExplainOptions {
+	    analyze: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExplainOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.analyze = source["analyze"];
+	    }
+	}
+	export class PlanNode {
+	    operation: string;
+	    relation?: string;
+	    index?: string;
+	    condition?: string;
+	    startupCost?: number;
+	    totalCost?: number;
+	    planRows?: number;
+	    actualRows?: number;
+	    actualTimeMs?: number;
+	    loops?: number;
+	    details?: Record<string, any>;
+	    children?: PlanNode[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.operation = source["operation"];
+	        this.relation = source["relation"];
+	        this.index = source["index"];
+	        this.condition = source["condition"];
+	        this.startupCost = source["startupCost"];
+	        this.totalCost = source["totalCost"];
+	        this.planRows = source["planRows"];
+	        this.actualRows = source["actualRows"];
+	        this.actualTimeMs = source["actualTimeMs"];
+	        this.loops = source["loops"];
+	        this.details = source["details"];
+	        this.children = this.convertValues(source["children"], PlanNode);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExplainResult {
+	    plan: PlanNode;
+	    raw: string;
+	    format: string;
+	    planningTimeMs?: number;
+	    executionTimeMs?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExplainResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.plan = this.convertValues(source["plan"], PlanNode);
+	        this.raw = source["raw"];
+	        this.format = source["format"];
+	        this.planningTimeMs = source["planningTimeMs"];
+	        this.executionTimeMs = source["executionTimeMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExportRun {
+	    scheduleId: string;
+	    schedule: string;
+	    started: string;
+	    durationMs: number;
+	    status: string;
+	    rows: number;
+	    location?: string;
+	    error?: string;
+	    manual?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scheduleId = source["scheduleId"];
+	        this.schedule = source["schedule"];
+	        this.started = source["started"];
+	        this.durationMs = source["durationMs"];
+	        this.status = source["status"];
+	        this.rows = source["rows"];
+	        this.location = source["location"];
+	        this.error = source["error"];
+	        this.manual = source["manual"];
+	    }
+	}
+	export class S3Destination {
+	    bucket: string;
+	    prefix?: string;
+	    region?: string;
+	    profile?: string;
+	    endpoint?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new S3Destination(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bucket = source["bucket"];
+	        this.prefix = source["prefix"];
+	        this.region = source["region"];
+	        this.profile = source["profile"];
+	        this.endpoint = source["endpoint"];
+	    }
+	}
+	export class ExportSchedule {
+	    id: string;
+	    name: string;
+	    queryId: string;
+	    connection: string;
+	    parameters?: Record<string, any>;
+	    cron: string;
+	    format: string;
+	    directory?: string;
+	    s3?: S3Destination;
+	    enabled: boolean;
+	    createdAt: string;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportSchedule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.queryId = source["queryId"];
+	        this.connection = source["connection"];
+	        this.parameters = source["parameters"];
+	        this.cron = source["cron"];
+	        this.format = source["format"];
+	        this.directory = source["directory"];
+	        this.s3 = this.convertValues(source["s3"], S3Destination);
+	        this.enabled = source["enabled"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExportScheduleStatus {
+	    id: string;
+	    name: string;
+	    queryId: string;
+	    connection: string;
+	    parameters?: Record<string, any>;
+	    cron: string;
+	    format: string;
+	    directory?: string;
+	    s3?: S3Destination;
+	    enabled: boolean;
+	    createdAt: string;
+	    updatedAt: string;
+	    nextRun?: string;
+	    running: boolean;
+	    lastRun?: ExportRun;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportScheduleStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.queryId = source["queryId"];
+	        this.connection = source["connection"];
+	        this.parameters = source["parameters"];
+	        this.cron = source["cron"];
+	        this.format = source["format"];
+	        this.directory = source["directory"];
+	        this.s3 = this.convertValues(source["s3"], S3Destination);
+	        this.enabled = source["enabled"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.nextRun = source["nextRun"];
+	        this.running = source["running"];
+	        this.lastRun = this.convertValues(source["lastRun"], ExportRun);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExtensionInfo {
+	    name: string;
+	    defaultVersion: string;
+	    installedVersion: string;
+	    schema: string;
+	    comment: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExtensionInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.defaultVersion = source["defaultVersion"];
+	        this.installedVersion = source["installedVersion"];
+	        this.schema = source["schema"];
+	        this.comment = source["comment"];
+	    }
+	}
+	
+	export class ForeignKeyInfo {
+	    name: string;
+	    columns: string[];
+	    referencedTable: string;
+	    referencedColumns: string[];
+	    onDelete: string;
+	    onUpdate: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ForeignKeyInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.columns = source["columns"];
+	        this.referencedTable = source["referencedTable"];
+	        this.referencedColumns = source["referencedColumns"];
+	        this.onDelete = source["onDelete"];
+	        this.onUpdate = source["onUpdate"];
+	    }
+	}
+	export class ForeignServerInfo {
+	    name: string;
+	    wrapper: string;
+	    type?: string;
+	    version?: string;
+	    owner: string;
+	    options: Record<string, string>;
+	    comment: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ForeignServerInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.wrapper = source["wrapper"];
+	        this.type = source["type"];
+	        this.version = source["version"];
+	        this.owner = source["owner"];
+	        this.options = source["options"];
+	        this.comment = source["comment"];
+	    }
+	}
+	export class ForeignTableInfo {
+	    name: string;
+	    server: string;
+	    options: Record<string, string>;
+	    comment: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ForeignTableInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.server = source["server"];
+	        this.options = source["options"];
+	        this.comment = source["comment"];
+	    }
+	}
+	
+	export class FormatOptions {
+	    dialect?: string;
+	    indent?: number;
+	    keywordCase?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FormatOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dialect = source["dialect"];
+	        this.indent = source["indent"];
+	        this.keywordCase = source["keywordCase"];
+	    }
+	}
+	export class GoogleAccount {
+	    configured: boolean;
+	    clientId: string;
+	    signedIn: boolean;
+	    email?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoogleAccount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.configured = source["configured"];
+	        this.clientId = source["clientId"];
+	        this.signedIn = source["signedIn"];
+	        this.email = source["email"];
+	    }
+	}
+	export class GoogleSheetExport {
+	    spreadsheet?: string;
+	    title?: string;
+	    sheet?: string;
+	    mode: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoogleSheetExport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.spreadsheet = source["spreadsheet"];
+	        this.title = source["title"];
+	        this.sheet = source["sheet"];
+	        this.mode = source["mode"];
+	    }
+	}
+	export class GoogleSheetResult {
+	    spreadsheetId: string;
+	    url: string;
+	    sheet: string;
+	    rows: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoogleSheetResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.spreadsheetId = source["spreadsheetId"];
+	        this.url = source["url"];
+	        this.sheet = source["sheet"];
+	        this.rows = source["rows"];
+	    }
+	}
+	export class HistoryEntry {
+	    id: number;
+	    executedAt: string;
+	    connection: string;
+	    database: string;
+	    statement: string;
+	    durationMs: number;
+	    rowCount: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.executedAt = source["executedAt"];
+	        this.connection = source["connection"];
+	        this.database = source["database"];
+	        this.statement = source["statement"];
+	        this.durationMs = source["durationMs"];
+	        this.rowCount = source["rowCount"];
+	        this.error = source["error"];
+	    }
+	}
+	export class HistoryFilter {
+	    text: string;
+	    connection: string;
+	    database: string;
+	    errorsOnly: boolean;
+	    limit: number;
+	    offset: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.connection = source["connection"];
+	        this.database = source["database"];
+	        this.errorsOnly = source["errorsOnly"];
+	        this.limit = source["limit"];
+	        this.offset = source["offset"];
+	    }
+	}
+	export class HistoryPrune {
+	    olderThanDays: number;
+	    keepLast: number;
+	    all: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryPrune(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.olderThanDays = source["olderThanDays"];
+	        this.keepLast = source["keepLast"];
+	        this.all = source["all"];
+	    }
+	}
+	export class ImportColumn {
+	    name: string;
+	    type: string;
+	    sqlType: string;
+	    nullable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportColumn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.sqlType = source["sqlType"];
+	        this.nullable = source["nullable"];
+	    }
+	}
+	export class ImportOptions {
+	    format: string;
+	    flatten: boolean;
+	    separator: string;
+	    createTable: boolean;
+	    previewRows: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.flatten = source["flatten"];
+	        this.separator = source["separator"];
+	        this.createTable = source["createTable"];
+	        this.previewRows = source["previewRows"];
+	    }
+	}
+	export class ImportPreview {
+	    columns: ImportColumn[];
+	    rows: any[][];
+	    totalRows: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.columns = this.convertValues(source["columns"], ImportColumn);
+	        this.rows = source["rows"];
+	        this.totalRows = source["totalRows"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportResult {
+	    rowsImported: number;
+	    tableCreated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rowsImported = source["rowsImported"];
+	        this.tableCreated = source["tableCreated"];
+	    }
+	}
+	export class IndexInfo {
+	    name: string;
+	    columns: string[];
+	    isUnique: boolean;
+	    isPrimary: boolean;
+	    method?: string;
+	    predicate?: string;
+	    size?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new IndexInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.columns = source["columns"];
+	        this.isUnique = source["isUnique"];
+	        this.isPrimary = source["isPrimary"];
+	        this.method = source["method"];
+	        this.predicate = source["predicate"];
+	        this.size = source["size"];
+	    }
+	}
+	export class JSONPathColumn {
+	    column: string;
+	    path: string[];
+	    alias?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JSONPathColumn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.path = source["path"];
+	        this.alias = source["alias"];
+	    }
+	}
+	export class JSONValidation {
+	    valid: boolean;
+	    error?: string;
+	    line?: number;
+	    column?: number;
+	    pretty?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JSONValidation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.valid = source["valid"];
+	        this.error = source["error"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.pretty = source["pretty"];
+	    }
+	}
+	export class Job {
+	    id: number;
+	    query: string;
+	    database: string;
+	    status: string;
+	    statements: number;
+	    completed: number;
+	    rowsAffected: number;
+	    started: string;
+	    finished?: string;
+	    durationMs: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Job(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.query = source["query"];
+	        this.database = source["database"];
+	        this.status = source["status"];
+	        this.statements = source["statements"];
+	        this.completed = source["completed"];
+	        this.rowsAffected = source["rowsAffected"];
+	        this.started = source["started"];
+	        this.finished = source["finished"];
+	        this.durationMs = source["durationMs"];
+	        this.error = source["error"];
+	    }
+	}
+	export class LoadTestQuery {
+	    sql: string;
+	    weight: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadTestQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sql = source["sql"];
+	        this.weight = source["weight"];
+	    }
+	}
+	export class LoadTestOptions {
+	    queries: LoadTestQuery[];
+	    sessions: number;
+	    durationSec: number;
+	    thinkTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadTestOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.queries = this.convertValues(source["queries"], LoadTestQuery);
+	        this.sessions = source["sessions"];
+	        this.durationSec = source["durationSec"];
+	        this.thinkTimeMs = source["thinkTimeMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LoadTestQueryStats {
+	    sql: string;
+	    count: number;
+	    errors: number;
+	    meanMs: number;
+	    p50Ms: number;
+	    p95Ms: number;
+	    maxMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadTestQueryStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sql = source["sql"];
+	        this.count = source["count"];
+	        this.errors = source["errors"];
+	        this.meanMs = source["meanMs"];
+	        this.p50Ms = source["p50Ms"];
+	        this.p95Ms = source["p95Ms"];
+	        this.maxMs = source["maxMs"];
+	    }
+	}
+	export class LoadTestSnapshot {
+	    elapsedSec: number;
+	    queries: number;
+	    errors: number;
+	    qps: number;
+	    p50Ms: number;
+	    p95Ms: number;
+	    maxMs: number;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadTestSnapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.elapsedSec = source["elapsedSec"];
+	        this.queries = source["queries"];
+	        this.errors = source["errors"];
+	        this.qps = source["qps"];
+	        this.p50Ms = source["p50Ms"];
+	        this.p95Ms = source["p95Ms"];
+	        this.maxMs = source["maxMs"];
+	        this.lastError = source["lastError"];
+	    }
+	}
+	export class LoadTestStatus {
+	    running: boolean;
+	    elapsedSec: number;
+	    queries: number;
+	    errors: number;
+	    qps: number;
+	    perQuery: LoadTestQueryStats[];
+	    history: LoadTestSnapshot[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadTestStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.running = source["running"];
+	        this.elapsedSec = source["elapsedSec"];
+	        this.queries = source["queries"];
+	        this.errors = source["errors"];
+	        this.qps = source["qps"];
+	        this.perQuery = this.convertValues(source["perQuery"], LoadTestQueryStats);
+	        this.history = this.convertValues(source["history"], LoadTestSnapshot);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LockStatus {
+	    locked: boolean;
+	    hasPassphrase: boolean;
+	    idleTimeoutMin: number;
+	    damaged?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LockStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.locked = source["locked"];
+	        this.hasPassphrase = source["hasPassphrase"];
+	        this.idleTimeoutMin = source["idleTimeoutMin"];
+	        this.damaged = source["damaged"];
+	    }
+	}
+	export class MaterializedViewInfo {
+	    name: string;
+	    definition: string;
+	    populated: boolean;
+	    size: number;
+	    hasUniqueIndex: boolean;
+	    lastRefresh: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaterializedViewInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.definition = source["definition"];
+	        this.populated = source["populated"];
+	        this.size = source["size"];
+	        this.hasUniqueIndex = source["hasUniqueIndex"];
+	        this.lastRefresh = source["lastRefresh"];
+	    }
+	}
+	export class Operation {
+	    id: number;
+	    kind: string;
+	    description: string;
+	    started: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Operation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.description = source["description"];
+	        this.started = source["started"];
+	    }
+	}
+	export class PasteError {
+	    row: number;
+	    column: string;
+	    value: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PasteError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.column = source["column"];
+	        this.value = source["value"];
+	        this.message = source["message"];
+	    }
+	}
+	export class PasteOptions {
+	    delimiter?: string;
+	    header?: string;
+	    mapping?: string[];
+	    previewRows?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PasteOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.delimiter = source["delimiter"];
+	        this.header = source["header"];
+	        this.mapping = source["mapping"];
+	        this.previewRows = source["previewRows"];
+	    }
+	}
+	export class PastePreview {
+	    delimiter: string;
+	    header: boolean;
+	    source: string[];
+	    mapping: string[];
+	    columns: string[];
+	    rows: any[][];
+	    totalRows: number;
+	    errors: PasteError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PastePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.delimiter = source["delimiter"];
+	        this.header = source["header"];
+	        this.source = source["source"];
+	        this.mapping = source["mapping"];
+	        this.columns = source["columns"];
+	        this.rows = source["rows"];
+	        this.totalRows = source["totalRows"];
+	        this.errors = this.convertValues(source["errors"], PasteError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Placeholder {
+	    name: string;
+	    kind: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Placeholder(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	    }
+	}
+	
+	
+	export class PrivilegeChange {
+	    action: string;
+	    table: string;
+	    privileges: string[];
+	    grantee: string;
+	    host?: string;
+	    withGrantOption: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PrivilegeChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.table = source["table"];
+	        this.privileges = source["privileges"];
+	        this.grantee = source["grantee"];
+	        this.host = source["host"];
+	        this.withGrantOption = source["withGrantOption"];
+	    }
+	}
+	export class QueryParameter {
+	    name: string;
+	    type?: string;
+	    default?: string;
+	    description?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryParameter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.default = source["default"];
+	        this.description = source["description"];
+	    }
+	}
+	
+	export class QueryStats {
+	    wallTimeMs: number;
+	    rowsAffected: number;
+	    rowsFetched: number;
+	    estimatedRoundtrips: number;
+	    planningTimeMs?: number;
+	    executionTimeMs?: number;
+	    analyzeError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.wallTimeMs = source["wallTimeMs"];
+	        this.rowsAffected = source["rowsAffected"];
+	        this.rowsFetched = source["rowsFetched"];
+	        this.estimatedRoundtrips = source["estimatedRoundtrips"];
+	        this.planningTimeMs = source["planningTimeMs"];
+	        this.executionTimeMs = source["executionTimeMs"];
+	        this.analyzeError = source["analyzeError"];
+	    }
+	}
+	export class ReplicationSlotInfo {
+	    name: string;
+	    type: string;
+	    database: string;
+	    active: boolean;
+	    walStatus: string;
+	    retainedBytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReplicationSlotInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.database = source["database"];
+	        this.active = source["active"];
+	        this.walStatus = source["walStatus"];
+	        this.retainedBytes = source["retainedBytes"];
+	    }
+	}
+	export class RestoreOptions {
+	    clean?: boolean;
+	    content?: string;
+	    tables?: string[];
+	    noOwner?: boolean;
+	    jobs?: number;
+	    singleTransaction?: boolean;
+	    continueOnError?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RestoreOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.clean = source["clean"];
+	        this.content = source["content"];
+	        this.tables = source["tables"];
+	        this.noOwner = source["noOwner"];
+	        this.jobs = source["jobs"];
+	        this.singleTransaction = source["singleTransaction"];
+	        this.continueOnError = source["continueOnError"];
+	    }
+	}
+	export class ResultColumn {
+	    name: string;
+	    type: string;
+	    nullable?: boolean;
+	    length?: number;
+	    precision?: number;
+	    scale?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResultColumn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.nullable = source["nullable"];
+	        this.length = source["length"];
+	        this.precision = source["precision"];
+	        this.scale = source["scale"];
+	    }
+	}
+	export class ResultSet {
+	    columns: ResultColumn[];
+	    rows: any[][];
+	    rowCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResultSet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.columns = this.convertValues(source["columns"], ResultColumn);
+	        this.rows = source["rows"];
+	        this.rowCount = source["rowCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoleInfo {
+	    name: string;
+	    host?: string;
+	    canLogin: boolean;
+	    superuser: boolean;
+	    createRole: boolean;
+	    createDb: boolean;
+	    memberOf: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RoleInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.host = source["host"];
+	        this.canLogin = source["canLogin"];
+	        this.superuser = source["superuser"];
+	        this.createRole = source["createRole"];
+	        this.createDb = source["createDb"];
+	        this.memberOf = source["memberOf"];
+	    }
+	}
+	export class RoutineArgument {
+	    name: string;
+	    type: string;
+	    mode: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutineArgument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.mode = source["mode"];
+	    }
+	}
+	export class RoutineInfo {
+	    name: string;
+	    kind: string;
+	    arguments: RoutineArgument[];
+	    returnType: string;
+	    language: string;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutineInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.arguments = this.convertValues(source["arguments"], RoutineArgument);
+	        this.returnType = source["returnType"];
+	        this.language = source["language"];
+	        this.source = source["source"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoutineResult {
+	    resultSets: QueryResult[];
+	    outValues: Record<string, any>;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutineResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.resultSets = this.convertValues(source["resultSets"], QueryResult);
+	        this.outValues = source["outValues"];
+	        this.durationMs = source["durationMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RowLocator {
+	    ctid?: string;
+	    values?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new RowLocator(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ctid = source["ctid"];
+	        this.values = source["values"];
+	    }
+	}
+	
+	export class SQLOptions {
+	    continueOnError: boolean;
+	    timeout?: number;
+	    tabId?: string;
+	    parameters?: Record<string, any>;
+	    analyze?: boolean;
+	    maxRows?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SQLOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.continueOnError = source["continueOnError"];
+	        this.timeout = source["timeout"];
+	        this.tabId = source["tabId"];
+	        this.parameters = source["parameters"];
+	        this.analyze = source["analyze"];
+	        this.maxRows = source["maxRows"];
+	    }
+	}
+	
+	export class ServerNotice {
+	    severity: string;
+	    code?: string;
+	    message: string;
+	    detail?: string;
+	    hint?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerNotice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.severity = source["severity"];
+	        this.code = source["code"];
+	        this.message = source["message"];
+	        this.detail = source["detail"];
+	        this.hint = source["hint"];
+	    }
+	}
+	export class StatementResult {
+	    statement: string;
+	    resultSets: ResultSet[];
+	    rowsAffected: number;
+	    lastInsertId: number;
+	    durationMs: number;
+	    error?: string;
+	    notices?: ServerNotice[];
+	    stats: QueryStats;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatementResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.statement = source["statement"];
+	        this.resultSets = this.convertValues(source["resultSets"], ResultSet);
+	        this.rowsAffected = source["rowsAffected"];
+	        this.lastInsertId = source["lastInsertId"];
+	        this.durationMs = source["durationMs"];
+	        this.error = source["error"];
+	        this.notices = this.convertValues(source["notices"], ServerNotice);
+	        this.stats = this.convertValues(source["stats"], QueryStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SQLResult {
+	    statements: StatementResult[];
+	    skipped?: number;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SQLResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.statements = this.convertValues(source["statements"], StatementResult);
+	        this.skipped = source["skipped"];
+	        this.durationMs = source["durationMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SavedConnection {
+	    name: string;
+	    config: ConnectionConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new SavedConnection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.config = this.convertValues(source["config"], ConnectionConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SavedQuery {
+	    id: string;
+	    name: string;
+	    folder?: string;
+	    sql: string;
+	    description?: string;
+	    parameters?: QueryParameter[];
+	    connections?: string[];
+	    createdAt: string;
+	    updatedAt: string;
+	    lastValues?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new SavedQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.folder = source["folder"];
+	        this.sql = source["sql"];
+	        this.description = source["description"];
+	        this.parameters = this.convertValues(source["parameters"], QueryParameter);
+	        this.connections = source["connections"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.lastValues = source["lastValues"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SchemaSearchResult {
+	    kind: string;
+	    schema: string;
+	    table?: string;
+	    name: string;
+	    detail?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchemaSearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.schema = source["schema"];
+	        this.table = source["table"];
+	        this.name = source["name"];
+	        this.detail = source["detail"];
+	    }
+	}
+	export class SequenceAlteration {
+	    increment?: string;
+	    minValue?: string;
+	    maxValue?: string;
+	    cycle?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SequenceAlteration(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.increment = source["increment"];
+	        this.minValue = source["minValue"];
+	        this.maxValue = source["maxValue"];
+	        this.cycle = source["cycle"];
+	    }
+	}
+	export class SequenceInfo {
+	    name: string;
+	    currentValue: string;
+	    increment: string;
+	    minValue: string;
+	    maxValue: string;
+	    startValue: string;
+	    cycle: boolean;
+	    ownedBy: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SequenceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.currentValue = source["currentValue"];
+	        this.increment = source["increment"];
+	        this.minValue = source["minValue"];
+	        this.maxValue = source["maxValue"];
+	        this.startValue = source["startValue"];
+	        this.cycle = source["cycle"];
+	        this.ownedBy = source["ownedBy"];
+	    }
+	}
+	
+	export class SortColumn {
+	    column: string;
+	    direction: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SortColumn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.direction = source["direction"];
+	    }
+	}
+	
+	export class StatementOptions {
+	    kind?: string;
+	    dialect?: string;
+	    table?: string;
+	    multiRow?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatementOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.dialect = source["dialect"];
+	        this.table = source["table"];
+	        this.multiRow = source["multiRow"];
+	    }
+	}
+	
+	export class StoreHealth {
+	    name: string;
+	    status: string;
+	    backup?: string;
+	    readable: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StoreHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.backup = source["backup"];
+	        this.readable = source["readable"];
+	        this.error = source["error"];
+	    }
+	}
+	export class StreamChunk {
+	    rows: any[][];
+	    offset: number;
+	    done: boolean;
+	    notices?: ServerNotice[];
+	    stats?: QueryStats;
+	
+	    static createFrom(source: any = {}) {
+	        return new StreamChunk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rows = source["rows"];
+	        this.offset = source["offset"];
+	        this.done = source["done"];
+	        this.notices = this.convertValues(source["notices"], ServerNotice);
+	        this.stats = this.convertValues(source["stats"], QueryStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StreamInfo {
+	    id: number;
+	    columns: ResultColumn[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StreamInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.columns = this.convertValues(source["columns"], ResultColumn);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class DatabaseInfo {
-	    name: string;
+	export class TableAlteration {
+	    addColumns: ColumnInfo[];
+	    modifyColumns: ColumnInfo[];
+	    dropColumns: string[];
+	    renameTo: string;
+	    addConstraints?: ConstraintInfo[];
+	    dropConstraints?: ConstraintInfo[];
 	
 	    static createFrom(source: any = {}) {
-	        return new DatabaseInfo(source);
+	        return new TableAlteration(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
+	        this.addColumns = this.convertValues(source["addColumns"], ColumnInfo);
+	        this.modifyColumns = this.convertValues(source["modifyColumns"], ColumnInfo);
+	        this.dropColumns = source["dropColumns"];
+	        this.renameTo = source["renameTo"];
+	        this.addConstraints = this.convertValues(source["addConstraints"], ConstraintInfo);
+	        this.dropConstraints = this.convertValues(source["dropConstraints"], ConstraintInfo);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class ExecuteResult {
-	    rowsAffected: number;
-	    lastInsertId: number;
+	export class TableRef {
+	    connection: string;
+	    database: string;
+	    schema?: string;
+	    table: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ExecuteResult(source);
+	        return new TableRef(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.rowsAffected = source["rowsAffected"];
-	        this.lastInsertId = source["lastInsertId"];
+	        this.connection = source["connection"];
+	        this.database = source["database"];
+	        this.schema = source["schema"];
+	        this.table = source["table"];
 	    }
 	}
-	export class IndexInfo {
-	    name: string;
-	    columns: string[];
-	    isUnique: boolean;
-	    isPrimary: boolean;
+	export class TableCompareRequest {
+	    source: TableRef;
+	    target: TableRef;
+	    chunkSize: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new IndexInfo(source);
+	        return new TableCompareRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.columns = source["columns"];
-	        this.isUnique = source["isUnique"];
-	        this.isPrimary = source["isPrimary"];
+	        this.source = this.convertValues(source["source"], TableRef);
+	        this.target = this.convertValues(source["target"], TableRef);
+	        this.chunkSize = source["chunkSize"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class QueryResult {
-	    columns: string[];
-	    rows: any[][];
-	    rowCount: number;
+	export class TableCompareResult {
+	    primaryKey: string;
+	    chunks: number;
+	    sourceRows: number;
+	    targetRows: number;
+	    mismatches: ChunkMismatch[];
+	    identical: boolean;
+	    durationMs: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new QueryResult(source);
+	        return new TableCompareResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.columns = source["columns"];
-	        this.rows = source["rows"];
-	        this.rowCount = source["rowCount"];
+	        this.primaryKey = source["primaryKey"];
+	        this.chunks = source["chunks"];
+	        this.sourceRows = source["sourceRows"];
+	        this.targetRows = source["targetRows"];
+	        this.mismatches = this.convertValues(source["mismatches"], ChunkMismatch);
+	        this.identical = source["identical"];
+	        this.durationMs = source["durationMs"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class SavedConnection {
-	    name: string;
-	    config: ConnectionConfig;
+	export class TableCopyRequest {
+	    source: TableRef;
+	    target: TableRef;
+	    batchSize?: number;
+	    createTable?: boolean;
+	    truncate?: boolean;
+	    after?: any[];
 	
 	    static createFrom(source: any = {}) {
-	        return new SavedConnection(source);
+	        return new TableCopyRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.config = this.convertValues(source["config"], ConnectionConfig);
+	        this.source = this.convertValues(source["source"], TableRef);
+	        this.target = this.convertValues(source["target"], TableRef);
+	        this.batchSize = source["batchSize"];
+	        this.createTable = source["createTable"];
+	        this.truncate = source["truncate"];
+	        this.after = source["after"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -164,22 +2983,38 @@ export namespace database {
 		    return a;
 		}
 	}
-	export class TableAlteration {
-	    addColumns: ColumnInfo[];
-	    modifyColumns: ColumnInfo[];
-	    dropColumns: string[];
-	    renameTo: string;
+	export class TableCopy {
+	    id: number;
+	    request: TableCopyRequest;
+	    status: string;
+	    rows: number;
+	    totalRows: number;
+	    lastKey?: any[];
+	    resumable: boolean;
+	    tableCreated: boolean;
+	    started: string;
+	    finished?: string;
+	    durationMs: number;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TableAlteration(source);
+	        return new TableCopy(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.addColumns = this.convertValues(source["addColumns"], ColumnInfo);
-	        this.modifyColumns = this.convertValues(source["modifyColumns"], ColumnInfo);
-	        this.dropColumns = source["dropColumns"];
-	        this.renameTo = source["renameTo"];
+	        this.id = source["id"];
+	        this.request = this.convertValues(source["request"], TableCopyRequest);
+	        this.status = source["status"];
+	        this.rows = source["rows"];
+	        this.totalRows = source["totalRows"];
+	        this.lastKey = source["lastKey"];
+	        this.resumable = source["resumable"];
+	        this.tableCreated = source["tableCreated"];
+	        this.started = source["started"];
+	        this.finished = source["finished"];
+	        this.durationMs = source["durationMs"];
+	        this.error = source["error"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -200,14 +3035,21 @@ export namespace database {
 		    return a;
 		}
 	}
+	
 	export class TableDataRequest {
 	    database: string;
+	    schema?: string;
 	    table: string;
 	    page: number;
 	    pageSize: number;
-	    orderBy: string;
-	    orderDir: string;
-	    filters: string;
+	    orderBy?: SortColumn[];
+	    filters?: FilterCondition[];
+	    search?: string;
+	    searchColumns?: string[];
+	    jsonPaths?: JSONPathColumn[];
+	    pagination?: string;
+	    after?: any[];
+	    formatters?: ColumnFormatter[];
 	
 	    static createFrom(source: any = {}) {
 	        return new TableDataRequest(source);
@@ -216,22 +3058,54 @@ export namespace database {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.database = source["database"];
+	        this.schema = source["schema"];
 	        this.table = source["table"];
 	        this.page = source["page"];
 	        this.pageSize = source["pageSize"];
-	        this.orderBy = source["orderBy"];
-	        this.orderDir = source["orderDir"];
-	        this.filters = source["filters"];
+	        this.orderBy = this.convertValues(source["orderBy"], SortColumn);
+	        this.filters = this.convertValues(source["filters"], FilterCondition);
+	        this.search = source["search"];
+	        this.searchColumns = source["searchColumns"];
+	        this.jsonPaths = this.convertValues(source["jsonPaths"], JSONPathColumn);
+	        this.pagination = source["pagination"];
+	        this.after = source["after"];
+	        this.formatters = this.convertValues(source["formatters"], ColumnFormatter);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class TableDataResponse {
 	    columns: ColumnInfo[];
 	    rows: any[][];
+	    rowIds: string[];
 	    totalRows: number;
+	    capped?: boolean;
 	    page: number;
 	    pageSize: number;
 	    totalPages: number;
-	    primaryKey: string;
+	    primaryKey: string[];
+	    readOnly: boolean;
+	    rowMatch?: string;
+	    rowLocators?: string[];
+	    nextCursor?: any[];
+	    display?: Record<string, Array<string>>;
+	    translations?: Record<string, any>;
 	
 	    static createFrom(source: any = {}) {
 	        return new TableDataResponse(source);
@@ -241,11 +3115,19 @@ export namespace database {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.columns = this.convertValues(source["columns"], ColumnInfo);
 	        this.rows = source["rows"];
+	        this.rowIds = source["rowIds"];
 	        this.totalRows = source["totalRows"];
+	        this.capped = source["capped"];
 	        this.page = source["page"];
 	        this.pageSize = source["pageSize"];
 	        this.totalPages = source["totalPages"];
 	        this.primaryKey = source["primaryKey"];
+	        this.readOnly = source["readOnly"];
+	        this.rowMatch = source["rowMatch"];
+	        this.rowLocators = source["rowLocators"];
+	        this.nextCursor = source["nextCursor"];
+	        this.display = source["display"];
+	        this.translations = source["translations"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -268,8 +3150,11 @@ export namespace database {
 	}
 	export class TableDetails {
 	    name: string;
+	    comment: string;
 	    columns: ColumnInfo[];
 	    indexes: IndexInfo[];
+	    foreignKeys: ForeignKeyInfo[];
+	    constraints: ConstraintInfo[];
 	
 	    static createFrom(source: any = {}) {
 	        return new TableDetails(source);
@@ -278,8 +3163,11 @@ export namespace database {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
+	        this.comment = source["comment"];
 	        this.columns = this.convertValues(source["columns"], ColumnInfo);
 	        this.indexes = this.convertValues(source["indexes"], IndexInfo);
+	        this.foreignKeys = this.convertValues(source["foreignKeys"], ForeignKeyInfo);
+	        this.constraints = this.convertValues(source["constraints"], ConstraintInfo);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -302,10 +3190,17 @@ export namespace database {
 	}
 	export class TableInfo {
 	    name: string;
+	    schema?: string;
 	    engine: string;
 	    rowCount: number;
 	    dataSize: number;
 	    createTime: string;
+	    lastVacuum?: string;
+	    comment: string;
+	    partitioned?: boolean;
+	    partitionKey?: string;
+	    parent?: string;
+	    partitionBound?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new TableInfo(source);
@@ -314,11 +3209,148 @@ export namespace database {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
+	        this.schema = source["schema"];
 	        this.engine = source["engine"];
 	        this.rowCount = source["rowCount"];
 	        this.dataSize = source["dataSize"];
 	        this.createTime = source["createTime"];
+	        this.lastVacuum = source["lastVacuum"];
+	        this.comment = source["comment"];
+	        this.partitioned = source["partitioned"];
+	        this.partitionKey = source["partitionKey"];
+	        this.parent = source["parent"];
+	        this.partitionBound = source["partitionBound"];
+	    }
+	}
+	export class TablePrivilege {
+	    grantee: string;
+	    privilege: string;
+	    grantable: boolean;
+	    grantor?: string;
+	    level: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TablePrivilege(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.grantee = source["grantee"];
+	        this.privilege = source["privilege"];
+	        this.grantable = source["grantable"];
+	        this.grantor = source["grantor"];
+	        this.level = source["level"];
+	    }
+	}
+	
+	export class TransactionState {
+	    tabId: string;
+	    inTransaction: boolean;
+	    dirty: boolean;
+	    aborted: boolean;
+	    startedAt?: string;
+	    statements: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TransactionState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tabId = source["tabId"];
+	        this.inTransaction = source["inTransaction"];
+	        this.dirty = source["dirty"];
+	        this.aborted = source["aborted"];
+	        this.startedAt = source["startedAt"];
+	        this.statements = source["statements"];
+	        this.error = source["error"];
+	    }
+	}
+	export class TranslationMap {
+	    name: string;
+	    columns: string[];
+	    values: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TranslationMap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.columns = source["columns"];
+	        this.values = source["values"];
+	    }
+	}
+	export class TriggerInfo {
+	    name: string;
+	    timing: string;
+	    events: string[];
+	    level: string;
+	    function?: string;
+	    definition: string;
+	    body: string;
+	    enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TriggerInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.timing = source["timing"];
+	        this.events = source["events"];
+	        this.level = source["level"];
+	        this.function = source["function"];
+	        this.definition = source["definition"];
+	        this.body = source["body"];
+	        this.enabled = source["enabled"];
+	    }
+	}
+	export class UndoEntry {
+	    id: number;
+	    database: string;
+	    schema?: string;
+	    table: string;
+	    kind: string;
+	    rows: number;
+	    // Go type: time
+	    time: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new UndoEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.database = source["database"];
+	        this.schema = source["schema"];
+	        this.table = source["table"];
+	        this.kind = source["kind"];
+	        this.rows = source["rows"];
+	        this.time = this.convertValues(source["time"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class UpdateInfo {
 	    currentVersion: string;
@@ -340,6 +3372,74 @@ export namespace database {
 	        this.hasUpdate = source["hasUpdate"];
 	    }
 	}
+	export class ViewInfo {
+	    name: string;
+	    definition: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ViewInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.definition = source["definition"];
+	    }
+	}
+	export class WALArchiveStatus {
+	    walLevel: string;
+	    archiveMode: string;
+	    archiveCommand: string;
+	    inRecovery: boolean;
+	    currentWal: string;
+	    archivedCount: number;
+	    lastArchivedWal: string;
+	    lastArchivedTime: string;
+	    failedCount: number;
+	    lastFailedWal: string;
+	    lastFailedTime: string;
+	    slots: ReplicationSlotInfo[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WALArchiveStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.walLevel = source["walLevel"];
+	        this.archiveMode = source["archiveMode"];
+	        this.archiveCommand = source["archiveCommand"];
+	        this.inRecovery = source["inRecovery"];
+	        this.currentWal = source["currentWal"];
+	        this.archivedCount = source["archivedCount"];
+	        this.lastArchivedWal = source["lastArchivedWal"];
+	        this.lastArchivedTime = source["lastArchivedTime"];
+	        this.failedCount = source["failedCount"];
+	        this.lastFailedWal = source["lastFailedWal"];
+	        this.lastFailedTime = source["lastFailedTime"];
+	        this.slots = this.convertValues(source["slots"], ReplicationSlotInfo);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"mergen/database"
+)
+
+// headlessFlag runs a single operation without a window, see runHeadless
+const headlessFlag = "--headless"
+
+// headlessPassphraseEnv holds the lock passphrase when -passphrase is not given,
+// keeping it out of the process list
+const headlessPassphraseEnv = "MERGEN_LOCK_PASSPHRASE"
+
+// headlessEvent is one JSON line written to stdout in headless mode
+type headlessEvent struct {
+	Event string      `json:"event"` // start, export:progress, import:progress, done or error
+	Time  string      `json:"time"`
+	Data  interface{} `json:"data,omitempty"`
+}
+
+// headlessArgs reports whether the headless flag was given and returns the
+// remaining arguments
+func headlessArgs(args []string) ([]string, bool) {
+	for i, arg := range args {
+		if arg == headlessFlag || arg == "-headless" {
+			return append(append([]string{}, args[:i]...), args[i+1:]...), true
+		}
+	}
+	return nil, false
+}
+
+// runHeadless exports or imports a table using a saved connection and reports
+// progress as JSON lines on stdout for scripts and CI. While a lock passphrase
+// is set it must be given, as the saved connections are behind the lock. It
+// returns the exit code.
+//
+//	mergen --headless export -connection prod -database shop -table orders -format csv -out orders.csv
+//	mergen --headless import -connection dev -database shop -table orders -file orders.ndjson -create
+func runHeadless(args []string) int {
+	var mu sync.Mutex
+	enc := json.NewEncoder(os.Stdout)
+	emit := func(event string, data interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		enc.Encode(headlessEvent{Event: event, Time: time.Now().Format(time.RFC3339Nano), Data: data})
+	}
+	fail := func(err error) int {
+		emit("error", map[string]string{"message": err.Error()})
+		return 1
+	}
+
+	if len(args) == 0 || (args[0] != "export" && args[0] != "import") {
+		return fail(fmt.Errorf("usage: %s export|import -connection NAME -table TABLE [options]", headlessFlag))
+	}
+	operation := args[0]
+
+	fs := flag.NewFlagSet(operation, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	connection := fs.String("connection", "", "saved connection name")
+	dbName := fs.String("database", "", "database, defaults to the one of the connection")
+	schema := fs.String("schema", "", "PostgreSQL schema, defaults to the one of the connection")
+	table := fs.String("table", "", "table name")
+	format := fs.String("format", "", "export: csv, json or xlsx; import: json, ndjson or parquet (detected when empty)")
+	outputPath := fs.String("out", "", "export: output file")
+	inputPath := fs.String("file", "", "import: input file")
+	create := fs.Bool("create", false, "import: create the table from the inferred schema")
+	flatten := fs.Bool("flatten", false, "import: flatten nested objects into a.b.c columns")
+	passphrase := fs.String("passphrase", "", "lock passphrase, required while the app lock is set; read from "+headlessPassphraseEnv+" when empty")
+	if err := fs.Parse(args[1:]); err != nil {
+		return fail(err)
+	}
+	if *connection == "" || *table == "" {
+		return fail(fmt.Errorf("-connection and -table are required"))
+	}
+
+	storage, err := database.NewStorage()
+	if err != nil {
+		return fail(err)
+	}
+	if locker := database.NewLocker(storage, nil); locker.IsLocked() {
+		if *passphrase == "" {
+			*passphrase = os.Getenv(headlessPassphraseEnv)
+		}
+		if *passphrase == "" {
+			return fail(fmt.Errorf("the application is locked; give the lock passphrase with -passphrase or %s", headlessPassphraseEnv))
+		}
+		if err := locker.Unlock(*passphrase); err != nil {
+			return fail(fmt.Errorf("failed to unlock: %w", err))
+		}
+	}
+	saved, err := storage.GetConnection(*connection)
+	if err != nil {
+		return fail(err)
+	}
+	if *dbName == "" {
+		*dbName = saved.Config.Database
+	}
+
+	db := database.NewManager()
+	db.SetEventSink(emit)
+	if err := db.Connect(saved.Config); err != nil {
+		return fail(err)
+	}
+	defer db.Disconnect()
+
+	emit("start", map[string]string{"operation": operation, "database": *dbName, "table": *table})
+
+	var result interface{}
+	switch operation {
+	case "export":
+		if *outputPath == "" {
+			return fail(fmt.Errorf("-out is required for export"))
+		}
+		if *format == "" {
+			*format = "csv"
+		}
+		err = db.ExportTable(*dbName, *schema, *table, *format, *outputPath)
+		result = map[string]string{"path": *outputPath}
+	case "import":
+		if *inputPath == "" {
+			return fail(fmt.Errorf("-file is required for import"))
+		}
+		result, err = db.ImportFile(*dbName, *schema, *table, *inputPath, database.ImportOptions{
+			Format:      *format,
+			Flatten:     *flatten,
+			CreateTable: *create,
+		})
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	emit("done", result)
+	return 0
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -12,6 +13,11 @@ import (
 var assets embed.FS
 
 func main() {
+	// Run a single export or import without a window
+	if args, ok := headlessArgs(os.Args[1:]); ok {
+		os.Exit(runHeadless(args))
+	}
+
 	// Create an instance of the app structure
 	app := NewApp()
 
@@ -27,6 +33,7 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 17, G: 24, B: 39, A: 1},
 		OnStartup:        app.startup,
+		OnBeforeClose:    app.beforeClose,
 		OnShutdown:       app.shutdown,
 		Frameless:        true,
 		Bind: []interface{}{